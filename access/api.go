@@ -63,6 +63,21 @@ type API interface {
 	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
 	GetExecutionResultByID(ctx context.Context, id flow.Identifier) (*flow.ExecutionResult, error)
 
+	// GetEmergencySealsByBlockID returns audit records for all seals in the payload of the given block,
+	// which were constructed with fewer than the required number of approvals (emergency sealing).
+	GetEmergencySealsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.EmergencySeal, error)
+
+	// GetRegisterProofs returns a batch proof of the given registers against the state commitment of the given
+	// sealed block. The proof is obtained from the execution nodes, and verified against the sealed result.
+	GetRegisterProofs(ctx context.Context, blockID flow.Identifier, registerIDs flow.RegisterIDs) (*RegisterProofs, error)
+
+	// GetTransactionResourceReport returns the breakdown of the resources metered while executing the given
+	// transaction in the given sealed block.
+	GetTransactionResourceReport(ctx context.Context, blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionResourceReport, error)
+	// GetTransactionResourceReportsByBlockID returns the breakdown of the resources metered while executing all
+	// transactions of the given sealed block, ordered by transaction index.
+	GetTransactionResourceReportsByBlockID(ctx context.Context, blockID flow.Identifier) ([]flow.TransactionResourceReport, error)
+
 	// SubscribeBlocks
 
 	// SubscribeBlocksFromStartBlockID subscribes to the finalized or sealed blocks starting at the requested
@@ -283,6 +298,15 @@ type NodeVersionInfo struct {
 	CompatibleRange      *CompatibleRange
 }
 
+// RegisterProofs contains a batch proof of registers against the state commitment of a sealed block.
+type RegisterProofs struct {
+	BlockID         flow.Identifier
+	BlockHeight     uint64
+	StateCommitment flow.StateCommitment
+	// Proof is the encoded ledger.TrieBatchProof, containing one proof per requested register.
+	Proof []byte
+}
+
 // CompatibleRangeToMessage converts a flow.CompatibleRange to a protobuf message
 func CompatibleRangeToMessage(c *CompatibleRange) *entities.CompatibleRange {
 	if c != nil {
@@ -0,0 +1,47 @@
+package access
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/emergencyseals"
+)
+
+// EmergencySealsHandler serves the EmergencySealAPI on access nodes, by reconstructing the audit records of emergency
+// seals via the Access API from the local protocol state.
+type EmergencySealsHandler struct {
+	emergencyseals.UnimplementedEmergencySealAPIServer
+
+	api API
+}
+
+var _ emergencyseals.EmergencySealAPIServer = (*EmergencySealsHandler)(nil)
+
+// NewEmergencySealsHandler creates a new EmergencySealsHandler.
+func NewEmergencySealsHandler(api API) *EmergencySealsHandler {
+	return &EmergencySealsHandler{
+		api: api,
+	}
+}
+
+// GetEmergencySealsByBlockID returns the audit records for all emergency seals included in the payload of the
+// requested block.
+func (h *EmergencySealsHandler) GetEmergencySealsByBlockID(
+	ctx context.Context,
+	req *emergencyseals.GetEmergencySealsByBlockIDRequest,
+) (*emergencyseals.GetEmergencySealsByBlockIDResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := h.api.GetEmergencySealsByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emergencyseals.GetEmergencySealsByBlockIDResponse{
+		BlockId: convert.IdentifierToMessage(blockID),
+		Seals:   emergencyseals.EmergencySealsToMessages(records),
+	}, nil
+}
@@ -555,6 +555,36 @@ func (_m *API) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow
 	return r0, r1
 }
 
+// GetEmergencySealsByBlockID provides a mock function with given fields: ctx, blockID
+func (_m *API) GetEmergencySealsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.EmergencySeal, error) {
+	ret := _m.Called(ctx, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmergencySealsByBlockID")
+	}
+
+	var r0 []*flow.EmergencySeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) ([]*flow.EmergencySeal, error)); ok {
+		return rf(ctx, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) []*flow.EmergencySeal); ok {
+		r0 = rf(ctx, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.EmergencySeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsForBlockIDs provides a mock function with given fields: ctx, eventType, blockIDs, requiredEventEncodingVersion
 func (_m *API) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error) {
 	ret := _m.Called(ctx, eventType, blockIDs, requiredEventEncodingVersion)
@@ -917,6 +947,36 @@ func (_m *API) GetProtocolStateSnapshotByHeight(ctx context.Context, blockHeight
 	return r0, r1
 }
 
+// GetRegisterProofs provides a mock function with given fields: ctx, blockID, registerIDs
+func (_m *API) GetRegisterProofs(ctx context.Context, blockID flow.Identifier, registerIDs flow.RegisterIDs) (*access.RegisterProofs, error) {
+	ret := _m.Called(ctx, blockID, registerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisterProofs")
+	}
+
+	var r0 *access.RegisterProofs
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.RegisterIDs) (*access.RegisterProofs, error)); ok {
+		return rf(ctx, blockID, registerIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.RegisterIDs) *access.RegisterProofs); ok {
+		r0 = rf(ctx, blockID, registerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.RegisterProofs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, flow.RegisterIDs) error); ok {
+		r1 = rf(ctx, blockID, registerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSystemTransaction provides a mock function with given fields: ctx, blockID
 func (_m *API) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.TransactionBody, error) {
 	ret := _m.Called(ctx, blockID)
@@ -1007,6 +1067,66 @@ func (_m *API) GetTransaction(ctx context.Context, id flow.Identifier) (*flow.Tr
 	return r0, r1
 }
 
+// GetTransactionResourceReport provides a mock function with given fields: ctx, blockID, transactionID
+func (_m *API) GetTransactionResourceReport(ctx context.Context, blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionResourceReport, error) {
+	ret := _m.Called(ctx, blockID, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionResourceReport")
+	}
+
+	var r0 *flow.TransactionResourceReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.Identifier) (*flow.TransactionResourceReport, error)); ok {
+		return rf(ctx, blockID, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.Identifier) *flow.TransactionResourceReport); ok {
+		r0 = rf(ctx, blockID, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionResourceReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, flow.Identifier) error); ok {
+		r1 = rf(ctx, blockID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionResourceReportsByBlockID provides a mock function with given fields: ctx, blockID
+func (_m *API) GetTransactionResourceReportsByBlockID(ctx context.Context, blockID flow.Identifier) ([]flow.TransactionResourceReport, error) {
+	ret := _m.Called(ctx, blockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionResourceReportsByBlockID")
+	}
+
+	var r0 []flow.TransactionResourceReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) ([]flow.TransactionResourceReport, error)); ok {
+		return rf(ctx, blockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) []flow.TransactionResourceReport); ok {
+		r0 = rf(ctx, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.TransactionResourceReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionResult provides a mock function with given fields: ctx, id, blockID, collectionID, requiredEventEncodingVersion
 func (_m *API) GetTransactionResult(ctx context.Context, id flow.Identifier, blockID flow.Identifier, collectionID flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) (*access.TransactionResult, error) {
 	ret := _m.Called(ctx, id, blockID, collectionID, requiredEventEncodingVersion)
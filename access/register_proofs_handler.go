@@ -0,0 +1,59 @@
+package access
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RegisterProofsHandler serves the RegisterProofAPI on access nodes, by proxying requests via the Access API
+// to the execution nodes.
+type RegisterProofsHandler struct {
+	registerproofs.UnimplementedRegisterProofAPIServer
+
+	api   API
+	chain flow.Chain
+}
+
+var _ registerproofs.RegisterProofAPIServer = (*RegisterProofsHandler)(nil)
+
+// NewRegisterProofsHandler creates a new RegisterProofsHandler.
+func NewRegisterProofsHandler(api API, chain flow.Chain) *RegisterProofsHandler {
+	return &RegisterProofsHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// GetRegisterProofs returns a batch proof of the requested registers against the state commitment of the
+// requested sealed block.
+func (h *RegisterProofsHandler) GetRegisterProofs(
+	ctx context.Context,
+	req *registerproofs.GetRegisterProofsRequest,
+) (*registerproofs.GetRegisterProofsResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+	registerIDs, err := convert.MessagesToRegisterIDs(req.GetRegisterIds(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid register IDs: %v", err)
+	}
+
+	proofs, err := h.api.GetRegisterProofs(ctx, blockID, registerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &registerproofs.GetRegisterProofsResponse{
+		BlockId:         convert.IdentifierToMessage(proofs.BlockID),
+		BlockHeight:     proofs.BlockHeight,
+		StateCommitment: proofs.StateCommitment[:],
+		Proof:           proofs.Proof,
+	}, nil
+}
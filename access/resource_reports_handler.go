@@ -0,0 +1,73 @@
+package access
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
+)
+
+// ResourceReportsHandler serves the TransactionResourceReportAPI on access nodes, by serving requests via the
+// Access API from the local index or the execution nodes.
+type ResourceReportsHandler struct {
+	resourcereports.UnimplementedTransactionResourceReportAPIServer
+
+	api API
+}
+
+var _ resourcereports.TransactionResourceReportAPIServer = (*ResourceReportsHandler)(nil)
+
+// NewResourceReportsHandler creates a new ResourceReportsHandler.
+func NewResourceReportsHandler(api API) *ResourceReportsHandler {
+	return &ResourceReportsHandler{
+		api: api,
+	}
+}
+
+// GetTransactionResourceReport returns the resource report of the requested transaction executed in the
+// requested sealed block.
+func (h *ResourceReportsHandler) GetTransactionResourceReport(
+	ctx context.Context,
+	req *resourcereports.GetTransactionResourceReportRequest,
+) (*resourcereports.GetTransactionResourceReportResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+	txID, err := convert.TransactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := h.api.GetTransactionResourceReport(ctx, blockID, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resourcereports.GetTransactionResourceReportResponse{
+		BlockId: convert.IdentifierToMessage(blockID),
+		Report:  resourcereports.ReportToMessage(report),
+	}, nil
+}
+
+// GetTransactionResourceReportsByBlockID returns the resource reports of all transactions executed in the
+// requested sealed block, ordered by transaction index.
+func (h *ResourceReportsHandler) GetTransactionResourceReportsByBlockID(
+	ctx context.Context,
+	req *resourcereports.GetTransactionResourceReportsByBlockIDRequest,
+) (*resourcereports.GetTransactionResourceReportsByBlockIDResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := h.api.GetTransactionResourceReportsByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resourcereports.GetTransactionResourceReportsByBlockIDResponse{
+		BlockId: convert.IdentifierToMessage(blockID),
+		Reports: resourcereports.ReportsToMessages(reports),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+)
+
+var _ commands.AdminCommand = (*FlushScriptResultCacheCommand)(nil)
+
+// FlushScriptResultCacheCommand removes all results from the script result cache.
+type FlushScriptResultCacheCommand struct {
+	cache *backend.ScriptResultCache
+}
+
+// NewFlushScriptResultCacheCommand creates a new FlushScriptResultCacheCommand. The cache is nil if disabled.
+func NewFlushScriptResultCacheCommand(cache *backend.ScriptResultCache) *FlushScriptResultCacheCommand {
+	return &FlushScriptResultCacheCommand{
+		cache: cache,
+	}
+}
+
+// Handler flushes the script result cache, and returns the number of removed results.
+func (c *FlushScriptResultCacheCommand) Handler(_ context.Context, _ *admin.CommandRequest) (interface{}, error) {
+	if c.cache == nil {
+		return nil, fmt.Errorf("script result cache is disabled")
+	}
+
+	removed := c.cache.Flush()
+	return map[string]interface{}{
+		"removed": removed,
+	}, nil
+}
+
+// Validator validates the request, the command takes no input.
+func (c *FlushScriptResultCacheCommand) Validator(_ *admin.CommandRequest) error {
+	return nil
+}
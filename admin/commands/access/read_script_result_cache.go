@@ -0,0 +1,107 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+)
+
+// defaultScriptResultCacheTopN is the number of entries returned if the request does not specify it.
+const defaultScriptResultCacheTopN = 10
+
+var _ commands.AdminCommand = (*ReadScriptResultCacheCommand)(nil)
+
+// ScriptResultCacheEntry is a cached script result as returned by the admin command.
+type ScriptResultCacheEntry struct {
+	ScriptHash    string `json:"script_hash"`
+	ArgumentsHash string `json:"arguments_hash"`
+	BlockID       string `json:"block_id"`
+	Height        uint64 `json:"height"`
+	Size          int    `json:"size"`
+	Hits          uint64 `json:"hits"`
+	AddedAt       string `json:"added_at"`
+}
+
+// ScriptResultCacheStats is the state of the script result cache as returned by the admin command.
+type ScriptResultCacheStats struct {
+	Entries    int                       `json:"entries"`
+	Bytes      uint64                    `json:"bytes"`
+	MaxEntries int                       `json:"max_entries"`
+	MaxBytes   uint64                    `json:"max_bytes"`
+	Hits       uint64                    `json:"hits"`
+	Misses     uint64                    `json:"misses"`
+	Top        []*ScriptResultCacheEntry `json:"top"`
+}
+
+// ReadScriptResultCacheCommand returns the state of the script result cache, with the most hit cached results.
+type ReadScriptResultCacheCommand struct {
+	cache *backend.ScriptResultCache
+}
+
+// NewReadScriptResultCacheCommand creates a new ReadScriptResultCacheCommand. The cache is nil if disabled.
+func NewReadScriptResultCacheCommand(cache *backend.ScriptResultCache) *ReadScriptResultCacheCommand {
+	return &ReadScriptResultCacheCommand{
+		cache: cache,
+	}
+}
+
+// Handler returns the state of the script result cache.
+func (c *ReadScriptResultCacheCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	n := req.ValidatorData.(int)
+
+	if c.cache == nil {
+		return nil, fmt.Errorf("script result cache is disabled")
+	}
+
+	stats := c.cache.Stats()
+	result := &ScriptResultCacheStats{
+		Entries:    stats.Entries,
+		Bytes:      stats.Bytes,
+		MaxEntries: stats.MaxEntries,
+		MaxBytes:   stats.MaxBytes,
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+	}
+	for _, entry := range c.cache.Entries(n) {
+		result.Top = append(result.Top, &ScriptResultCacheEntry{
+			ScriptHash:    entry.ScriptHash.String(),
+			ArgumentsHash: entry.ArgumentsHash.String(),
+			BlockID:       entry.BlockID.String(),
+			Height:        entry.Height,
+			Size:          entry.Size,
+			Hits:          entry.Hits,
+			AddedAt:       entry.AddedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return commands.ConvertToMap(result)
+}
+
+// Validator validates the request. The request data may contain
+//   - n: the number of most hit entries to return, defaults to 10
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ReadScriptResultCacheCommand) Validator(req *admin.CommandRequest) error {
+	n := defaultScriptResultCacheTopN
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+		}
+
+		if rawN, ok := input["n"]; ok {
+			value, ok := rawN.(float64)
+			if !ok || value < 0 || value != float64(int(value)) {
+				return admin.NewInvalidAdminReqParameterError("n", "must be a non-negative integer", rawN)
+			}
+			n = int(value)
+		}
+	}
+
+	req.ValidatorData = n
+	return nil
+}
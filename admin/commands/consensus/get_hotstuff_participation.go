@@ -0,0 +1,74 @@
+package consensus
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+)
+
+var _ commands.AdminCommand = (*GetHotstuffParticipationCommand)(nil)
+
+// GetHotstuffParticipationCommand returns the per-replica participation statistics collected
+// by the HotStuff ParticipationTracker.
+type GetHotstuffParticipationCommand struct {
+	tracker *notifications.ParticipationTracker
+}
+
+type getHotstuffParticipationRequest struct {
+	epoch    uint64
+	hasEpoch bool
+}
+
+// NewGetHotstuffParticipationCommand creates a new GetHotstuffParticipationCommand.
+func NewGetHotstuffParticipationCommand(tracker *notifications.ParticipationTracker) *GetHotstuffParticipationCommand {
+	return &GetHotstuffParticipationCommand{
+		tracker: tracker,
+	}
+}
+
+// Handler returns the participation statistics of all tracked epochs, or only of the
+// requested epoch.
+// No errors are expected during normal operation.
+func (c *GetHotstuffParticipationCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*getHotstuffParticipationRequest)
+
+	participation := c.tracker.Participation()
+	if data.hasEpoch {
+		filtered := make([]*notifications.EpochParticipation, 0, 1)
+		for _, epoch := range participation {
+			if epoch.Epoch == data.epoch {
+				filtered = append(filtered, epoch)
+			}
+		}
+		participation = filtered
+	}
+	return commands.ConvertToInterfaceList(participation)
+}
+
+// Validator validates the request. The request data is optional; it may contain
+//   - epoch: the counter of the epoch to return statistics for
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *GetHotstuffParticipationCommand) Validator(req *admin.CommandRequest) error {
+	data := &getHotstuffParticipationRequest{}
+	req.ValidatorData = data
+
+	if req.Data == nil {
+		return nil
+	}
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+	if epoch, ok := input["epoch"]; ok {
+		counter, ok := epoch.(float64)
+		if !ok || counter < 0 || counter != float64(uint64(counter)) {
+			return admin.NewInvalidAdminReqParameterError("epoch", "must be a non-negative integer", epoch)
+		}
+		data.epoch = uint64(counter)
+		data.hasEpoch = true
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network/p2p"
+)
+
+var _ commands.AdminCommand = (*AddDisallowListEntryCommand)(nil)
+
+// AddDisallowListEntryCommand disallow-lists a node, i.e., the connections to the node are pruned and no new
+// connections are established with it, till the entry is removed or expires. The entry is persisted, and hence
+// re-applied when the node restarts.
+type AddDisallowListEntryCommand struct {
+	disallowList DisallowList
+	idTranslator p2p.IDTranslator
+}
+
+// NewAddDisallowListEntryCommand creates a new AddDisallowListEntryCommand.
+func NewAddDisallowListEntryCommand(disallowList DisallowList, idTranslator p2p.IDTranslator) *AddDisallowListEntryCommand {
+	return &AddDisallowListEntryCommand{
+		disallowList: disallowList,
+		idTranslator: idTranslator,
+	}
+}
+
+// Handler adds the disallow-list entry and returns it.
+func (c *AddDisallowListEntryCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	entry := req.ValidatorData.(*flow.DisallowListEntry)
+
+	if c.disallowList == nil {
+		return nil, fmt.Errorf("disallow-list is not available")
+	}
+
+	entry.CreatedAt = time.Now()
+	err := c.disallowList.Add(*entry)
+	if err != nil {
+		return nil, fmt.Errorf("could not add disallow-list entry: %w", err)
+	}
+
+	return commands.ConvertToMap(newDisallowListEntry(*entry, c.idTranslator))
+}
+
+// Validator validates the request. The request data must contain either
+//   - node_id: the node ID of the node to disallow-list
+//   - peer_id: the libp2p peer ID of the node to disallow-list
+//
+// and may contain
+//   - reason: the reason for disallow-listing the node
+//   - expiry: the time at which the node is allow-listed again, either as a duration from now (e.g. "24h")
+//     or as an RFC3339 timestamp
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *AddDisallowListEntryCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	nodeID, err := parseNodeID(input, c.idTranslator)
+	if err != nil {
+		return err
+	}
+	entry := &flow.DisallowListEntry{NodeID: nodeID}
+
+	if rawReason, ok := input["reason"]; ok {
+		reason, ok := rawReason.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("reason", "must be a string", rawReason)
+		}
+		entry.Reason = reason
+	}
+
+	if rawExpiry, ok := input["expiry"]; ok {
+		expiry, err := parseExpiry(rawExpiry, time.Now())
+		if err != nil {
+			return err
+		}
+		entry.Expiry = expiry
+	}
+
+	req.ValidatorData = entry
+	return nil
+}
+
+// parseExpiry parses the expiry of a disallow-list entry, given either as a duration from now or as an RFC3339 timestamp.
+// Returns admin.InvalidAdminReqError if the expiry is malformed or not in the future.
+func parseExpiry(rawExpiry interface{}, now time.Time) (time.Time, error) {
+	expiryStr, ok := rawExpiry.(string)
+	if !ok {
+		return time.Time{}, admin.NewInvalidAdminReqParameterError("expiry", "must be a duration or an RFC3339 timestamp", rawExpiry)
+	}
+
+	var expiry time.Time
+	if d, err := time.ParseDuration(expiryStr); err == nil {
+		expiry = now.Add(d)
+	} else if t, err := time.Parse(time.RFC3339, expiryStr); err == nil {
+		expiry = t
+	} else {
+		return time.Time{}, admin.NewInvalidAdminReqParameterError("expiry", "must be a duration or an RFC3339 timestamp", rawExpiry)
+	}
+
+	if !expiry.After(now) {
+		return time.Time{}, admin.NewInvalidAdminReqParameterError("expiry", "must be in the future", rawExpiry)
+	}
+	return expiry, nil
+}
@@ -0,0 +1,211 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network/bandwidth"
+)
+
+const (
+	// defaultBandwidthTopN is the number of consumers returned if the request does not specify it.
+	defaultBandwidthTopN = 10
+
+	sortByTotal    = "total"
+	sortByInbound  = "inbound"
+	sortByOutbound = "outbound"
+
+	groupByKey     = "key"
+	groupByPeer    = "peer"
+	groupByChannel = "channel"
+	groupByRole    = "role"
+)
+
+var _ commands.AdminCommand = (*BandwidthTopCommand)(nil)
+
+// BandwidthUsage provides the bandwidth usage of the node, see bandwidth.Accountant.
+type BandwidthUsage interface {
+	// Usage returns the bytes received and sent within the given window per channel, peer role and peer ID.
+	Usage(window time.Duration) []bandwidth.Usage
+	// Window returns the longest window that can be queried.
+	Window() time.Duration
+}
+
+// BandwidthConsumer is the bandwidth used within the queried window as returned by the admin command. Depending on
+// the grouping of the request, some of the channel, role and peer ID fields are empty.
+type BandwidthConsumer struct {
+	Channel       string `json:"channel,omitempty"`
+	Role          string `json:"role,omitempty"`
+	PeerID        string `json:"peer_id,omitempty"`
+	NodeID        string `json:"node_id,omitempty"`
+	InboundBytes  uint64 `json:"inbound_bytes"`
+	OutboundBytes uint64 `json:"outbound_bytes"`
+	TotalBytes    uint64 `json:"total_bytes"`
+}
+
+// bandwidthTopRequest is the validated request of the BandwidthTopCommand.
+type bandwidthTopRequest struct {
+	window  time.Duration
+	n       int
+	sortBy  string
+	groupBy string
+}
+
+// BandwidthTopCommand returns the top bandwidth consumers of the node within a window. The consumers are grouped by
+// channel, peer role and peer ID, or by any one of them.
+type BandwidthTopCommand struct {
+	usage      BandwidthUsage
+	idProvider module.IdentityProvider
+}
+
+// NewBandwidthTopCommand creates a new BandwidthTopCommand.
+func NewBandwidthTopCommand(usage BandwidthUsage, idProvider module.IdentityProvider) *BandwidthTopCommand {
+	return &BandwidthTopCommand{
+		usage:      usage,
+		idProvider: idProvider,
+	}
+}
+
+// Handler returns the top bandwidth consumers.
+func (c *BandwidthTopCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*bandwidthTopRequest)
+
+	if c.usage == nil {
+		return nil, fmt.Errorf("bandwidth accounting is disabled")
+	}
+
+	window := data.window
+	if window == 0 || window > c.usage.Window() {
+		window = c.usage.Window()
+	}
+
+	groups := make(map[bandwidth.Key]*BandwidthConsumer)
+	for _, usage := range c.usage.Usage(window) {
+		key := groupKey(usage.Key, data.groupBy)
+		consumer, ok := groups[key]
+		if !ok {
+			consumer = &BandwidthConsumer{
+				Channel: key.Channel.String(),
+				Role:    key.Role,
+			}
+			if key.PeerID != "" {
+				consumer.PeerID = key.PeerID.String()
+				if identity, ok := c.idProvider.ByPeerID(key.PeerID); ok {
+					consumer.NodeID = identity.NodeID.String()
+				}
+			}
+			groups[key] = consumer
+		}
+		consumer.InboundBytes += usage.Inbound
+		consumer.OutboundBytes += usage.Outbound
+		consumer.TotalBytes += usage.Inbound + usage.Outbound
+	}
+
+	consumers := make([]*BandwidthConsumer, 0, len(groups))
+	for _, consumer := range groups {
+		consumers = append(consumers, consumer)
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		return sortValue(consumers[i], data.sortBy) > sortValue(consumers[j], data.sortBy)
+	})
+	if len(consumers) > data.n {
+		consumers = consumers[:data.n]
+	}
+
+	return commands.ConvertToInterfaceList(consumers)
+}
+
+// Validator validates the request. The request data may contain
+//   - window: the window to query as a duration, e.g. "5m"; defaults to (and is capped at) the accounting window
+//   - n: the number of consumers to return, defaults to 10
+//   - sort_by: one of "total" (default), "inbound" or "outbound"
+//   - group_by: one of "key" (default, i.e., channel, role and peer ID), "peer", "channel" or "role"
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *BandwidthTopCommand) Validator(req *admin.CommandRequest) error {
+	data := &bandwidthTopRequest{
+		n:       defaultBandwidthTopN,
+		sortBy:  sortByTotal,
+		groupBy: groupByKey,
+	}
+
+	if req.Data == nil {
+		req.ValidatorData = data
+		return nil
+	}
+
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	if rawWindow, ok := input["window"]; ok {
+		windowStr, ok := rawWindow.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("window", "must be a duration", rawWindow)
+		}
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window <= 0 {
+			return admin.NewInvalidAdminReqParameterError("window", "must be a positive duration", rawWindow)
+		}
+		data.window = window
+	}
+
+	if rawN, ok := input["n"]; ok {
+		n, ok := rawN.(float64)
+		if !ok || n < 1 || n != float64(int(n)) {
+			return admin.NewInvalidAdminReqParameterError("n", "must be a positive integer", rawN)
+		}
+		data.n = int(n)
+	}
+
+	if rawSortBy, ok := input["sort_by"]; ok {
+		sortBy, ok := rawSortBy.(string)
+		if !ok || (sortBy != sortByTotal && sortBy != sortByInbound && sortBy != sortByOutbound) {
+			return admin.NewInvalidAdminReqParameterError("sort_by", "must be one of total, inbound or outbound", rawSortBy)
+		}
+		data.sortBy = sortBy
+	}
+
+	if rawGroupBy, ok := input["group_by"]; ok {
+		groupBy, ok := rawGroupBy.(string)
+		if !ok || (groupBy != groupByKey && groupBy != groupByPeer && groupBy != groupByChannel && groupBy != groupByRole) {
+			return admin.NewInvalidAdminReqParameterError("group_by", "must be one of key, peer, channel or role", rawGroupBy)
+		}
+		data.groupBy = groupBy
+	}
+
+	req.ValidatorData = data
+	return nil
+}
+
+// groupKey returns the key the usage of the given key is grouped by.
+func groupKey(key bandwidth.Key, groupBy string) bandwidth.Key {
+	switch groupBy {
+	case groupByPeer:
+		return bandwidth.Key{Role: key.Role, PeerID: key.PeerID}
+	case groupByChannel:
+		return bandwidth.Key{Channel: key.Channel}
+	case groupByRole:
+		return bandwidth.Key{Role: key.Role}
+	default:
+		return key
+	}
+}
+
+// sortValue returns the value the consumers are sorted by.
+func sortValue(consumer *BandwidthConsumer, sortBy string) uint64 {
+	switch sortBy {
+	case sortByInbound:
+		return consumer.InboundBytes
+	case sortByOutbound:
+		return consumer.OutboundBytes
+	default:
+		return consumer.TotalBytes
+	}
+}
@@ -0,0 +1,163 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/bandwidth"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testBandwidthUsage is a BandwidthUsage returning a fixed usage.
+type testBandwidthUsage struct {
+	usage  []bandwidth.Usage
+	window time.Duration
+	// queried is the window of the last query
+	queried time.Duration
+}
+
+var _ BandwidthUsage = (*testBandwidthUsage)(nil)
+
+func (u *testBandwidthUsage) Usage(window time.Duration) []bandwidth.Usage {
+	u.queried = window
+	return u.usage
+}
+
+func (u *testBandwidthUsage) Window() time.Duration {
+	return u.window
+}
+
+func TestBandwidthTop_Validator(t *testing.T) {
+	command := NewBandwidthTopCommand(nil, nil)
+
+	invalid := []interface{}{
+		"top",
+		map[string]interface{}{"window": "abc"},
+		map[string]interface{}{"window": "-1m"},
+		map[string]interface{}{"window": float64(1)},
+		map[string]interface{}{"n": float64(0)},
+		map[string]interface{}{"n": float64(1.5)},
+		map[string]interface{}{"n": "1"},
+		map[string]interface{}{"sort_by": "size"},
+		map[string]interface{}{"group_by": "node"},
+	}
+	for _, data := range invalid {
+		req := &admin.CommandRequest{Data: data}
+		assert.Error(t, command.Validator(req), "expected invalid request for %v", data)
+	}
+
+	req := &admin.CommandRequest{}
+	require.NoError(t, command.Validator(req))
+	assert.Equal(t, &bandwidthTopRequest{n: 10, sortBy: sortByTotal, groupBy: groupByKey}, req.ValidatorData)
+
+	req = &admin.CommandRequest{Data: map[string]interface{}{
+		"window":   "5m",
+		"n":        float64(3),
+		"sort_by":  "inbound",
+		"group_by": "role",
+	}}
+	require.NoError(t, command.Validator(req))
+	assert.Equal(t, &bandwidthTopRequest{window: 5 * time.Minute, n: 3, sortBy: sortByInbound, groupBy: groupByRole}, req.ValidatorData)
+}
+
+func TestBandwidthTop_Handler(t *testing.T) {
+	identity := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	executionPeer := unittest.PeerIdFixture(t)
+	unknownPeer := unittest.PeerIdFixture(t)
+
+	idProvider := mockmodule.NewIdentityProvider(t)
+	idProvider.On("ByPeerID", executionPeer).Return(identity, true).Maybe()
+	idProvider.On("ByPeerID", unknownPeer).Return(nil, false).Maybe()
+
+	usage := &testBandwidthUsage{
+		window: time.Hour,
+		usage: []bandwidth.Usage{
+			{
+				Key:      bandwidth.Key{Channel: channels.ExecutionDataService, Role: flow.RoleExecution.String(), PeerID: executionPeer},
+				Inbound:  1000,
+				Outbound: 10,
+			},
+			{
+				Key:      bandwidth.Key{Channel: channels.PushBlocks, Role: flow.RoleExecution.String(), PeerID: executionPeer},
+				Inbound:  100,
+				Outbound: 200,
+			},
+			{
+				Key:      bandwidth.Key{Channel: channels.PushBlocks, PeerID: unknownPeer},
+				Inbound:  50,
+				Outbound: 500,
+			},
+		},
+	}
+	command := NewBandwidthTopCommand(usage, idProvider)
+
+	run := func(data map[string]interface{}) []interface{} {
+		req := &admin.CommandRequest{Data: data}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+		return result.([]interface{})
+	}
+
+	t.Run("top consumers by total", func(t *testing.T) {
+		result := run(map[string]interface{}{"n": float64(2), "window": "5m"})
+		assert.Equal(t, 5*time.Minute, usage.queried)
+		require.Len(t, result, 2)
+
+		top := result[0].(map[string]interface{})
+		assert.Equal(t, channels.ExecutionDataService.String(), top["channel"])
+		assert.Equal(t, flow.RoleExecution.String(), top["role"])
+		assert.Equal(t, executionPeer.String(), top["peer_id"])
+		assert.Equal(t, identity.NodeID.String(), top["node_id"])
+		assert.Equal(t, float64(1000), top["inbound_bytes"])
+		assert.Equal(t, float64(10), top["outbound_bytes"])
+		assert.Equal(t, float64(1010), top["total_bytes"])
+
+		second := result[1].(map[string]interface{})
+		assert.Equal(t, unknownPeer.String(), second["peer_id"])
+		assert.NotContains(t, second, "role")
+		assert.NotContains(t, second, "node_id")
+	})
+
+	t.Run("window is capped", func(t *testing.T) {
+		run(map[string]interface{}{"window": "24h"})
+		assert.Equal(t, time.Hour, usage.queried)
+	})
+
+	t.Run("group by channel sorted by outbound", func(t *testing.T) {
+		result := run(map[string]interface{}{"group_by": "channel", "sort_by": "outbound"})
+		require.Len(t, result, 2)
+
+		top := result[0].(map[string]interface{})
+		assert.Equal(t, channels.PushBlocks.String(), top["channel"])
+		assert.NotContains(t, top, "peer_id")
+		assert.Equal(t, float64(150), top["inbound_bytes"])
+		assert.Equal(t, float64(700), top["outbound_bytes"])
+	})
+
+	t.Run("group by peer", func(t *testing.T) {
+		result := run(map[string]interface{}{"group_by": "peer"})
+		require.Len(t, result, 2)
+
+		top := result[0].(map[string]interface{})
+		assert.Equal(t, executionPeer.String(), top["peer_id"])
+		assert.NotContains(t, top, "channel")
+		assert.Equal(t, float64(1310), top["total_bytes"])
+	})
+
+	t.Run("accounting disabled", func(t *testing.T) {
+		command := NewBandwidthTopCommand(nil, idProvider)
+		req := &admin.CommandRequest{}
+		require.NoError(t, command.Validator(req))
+		_, err := command.Handler(context.Background(), req)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package network
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network/p2p"
+)
+
+// DisallowList is the operator-managed disallow-list of the networking layer, see cache.NodeDisallowListingWrapper.
+type DisallowList interface {
+	// Add disallow-lists the node of the given entry, or replaces its existing entry.
+	// No errors are expected during normal operations.
+	Add(entry flow.DisallowListEntry) error
+	// Remove removes the entry of the given node, returns false if the node has no entry.
+	// No errors are expected during normal operations.
+	Remove(nodeID flow.Identifier) (bool, error)
+	// Entries returns the entries that are not expired.
+	Entries() []flow.DisallowListEntry
+}
+
+// DisallowListEntry is the disallow-list entry of a node as returned by the admin commands.
+type DisallowListEntry struct {
+	NodeID    string `json:"node_id"`
+	PeerID    string `json:"peer_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Expiry    string `json:"expiry,omitempty"`
+}
+
+// newDisallowListEntry converts the given entry for output, the peer ID is omitted if it cannot be resolved.
+func newDisallowListEntry(entry flow.DisallowListEntry, idTranslator p2p.IDTranslator) *DisallowListEntry {
+	result := &DisallowListEntry{
+		NodeID: entry.NodeID.String(),
+		Reason: entry.Reason,
+	}
+	if peerID, err := idTranslator.GetPeerID(entry.NodeID); err == nil {
+		result.PeerID = peerID.String()
+	}
+	if !entry.CreatedAt.IsZero() {
+		result.CreatedAt = entry.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if !entry.Expiry.IsZero() {
+		result.Expiry = entry.Expiry.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// parseNodeID parses the node to disallow-list from either the node_id or the peer_id field of the input, a peer ID
+// is resolved to the node ID of the peer.
+// Returns admin.InvalidAdminReqError for invalid/malformed input, or if the peer ID cannot be resolved.
+func parseNodeID(input map[string]interface{}, idTranslator p2p.IDTranslator) (flow.Identifier, error) {
+	if rawNodeID, ok := input["node_id"]; ok {
+		if nodeIDStr, ok := rawNodeID.(string); ok && len(nodeIDStr) == 2*flow.IdentifierLen {
+			if b, err := hex.DecodeString(nodeIDStr); err == nil {
+				return flow.HashToID(b), nil
+			}
+		}
+		return flow.ZeroID, admin.NewInvalidAdminReqParameterError("node_id", "must be 64-char hex string", rawNodeID)
+	}
+
+	if rawPeerID, ok := input["peer_id"]; ok {
+		if peerIDStr, ok := rawPeerID.(string); ok {
+			if peerID, err := peer.Decode(peerIDStr); err == nil {
+				nodeID, err := idTranslator.GetFlowID(peerID)
+				if err != nil {
+					return flow.ZeroID, admin.NewInvalidAdminReqParameterError("peer_id", "must be the peer id of a known node", rawPeerID)
+				}
+				return nodeID, nil
+			}
+		}
+		return flow.ZeroID, admin.NewInvalidAdminReqParameterError("peer_id", "must be valid peer id string", rawPeerID)
+	}
+
+	return flow.ZeroID, admin.NewInvalidAdminReqErrorf("either \"node_id\" or \"peer_id\" field is required")
+}
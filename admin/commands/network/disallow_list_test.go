@@ -0,0 +1,138 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network/p2p"
+	mockp2p "github.com/onflow/flow-go/network/p2p/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testDisallowList is an in-memory DisallowList.
+type testDisallowList struct {
+	entries map[flow.Identifier]flow.DisallowListEntry
+}
+
+var _ DisallowList = (*testDisallowList)(nil)
+
+func (l *testDisallowList) Add(entry flow.DisallowListEntry) error {
+	l.entries[entry.NodeID] = entry
+	return nil
+}
+
+func (l *testDisallowList) Remove(nodeID flow.Identifier) (bool, error) {
+	_, found := l.entries[nodeID]
+	delete(l.entries, nodeID)
+	return found, nil
+}
+
+func (l *testDisallowList) Entries() []flow.DisallowListEntry {
+	entries := make([]flow.DisallowListEntry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAddDisallowListEntry_Validator(t *testing.T) {
+	peerID := unittest.PeerIdFixture(t)
+	unknownPeerID := unittest.PeerIdFixture(t)
+	nodeID := unittest.IdentifierFixture()
+
+	idTranslator := mockp2p.NewIDTranslator(t)
+	idTranslator.On("GetFlowID", peerID).Return(nodeID, nil).Maybe()
+	idTranslator.On("GetFlowID", unknownPeerID).Return(flow.ZeroID, p2p.ErrUnknownId).Maybe()
+	command := NewAddDisallowListEntryCommand(&testDisallowList{}, idTranslator)
+
+	invalid := []interface{}{
+		nil,
+		"node",
+		map[string]interface{}{},
+		map[string]interface{}{"node_id": "abc"},
+		map[string]interface{}{"peer_id": "abc"},
+		map[string]interface{}{"peer_id": unknownPeerID.String()},
+		map[string]interface{}{"node_id": nodeID.String(), "reason": float64(1)},
+		map[string]interface{}{"node_id": nodeID.String(), "expiry": "tomorrow"},
+		map[string]interface{}{"node_id": nodeID.String(), "expiry": "-1h"},
+		map[string]interface{}{"node_id": nodeID.String(), "expiry": time.Now().Add(-time.Hour).Format(time.RFC3339)},
+	}
+	for _, data := range invalid {
+		req := &admin.CommandRequest{Data: data}
+		assert.Error(t, command.Validator(req), "expected invalid request for %v", data)
+	}
+
+	t.Run("node id with reason and expiry duration", func(t *testing.T) {
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"node_id": nodeID.String(),
+			"reason":  "spamming",
+			"expiry":  "1h",
+		}}
+		require.NoError(t, command.Validator(req))
+		entry := req.ValidatorData.(*flow.DisallowListEntry)
+		assert.Equal(t, nodeID, entry.NodeID)
+		assert.Equal(t, "spamming", entry.Reason)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), entry.Expiry, time.Minute)
+	})
+
+	t.Run("peer id with expiry timestamp", func(t *testing.T) {
+		expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"peer_id": peerID.String(),
+			"expiry":  expiry.Format(time.RFC3339),
+		}}
+		require.NoError(t, command.Validator(req))
+		entry := req.ValidatorData.(*flow.DisallowListEntry)
+		assert.Equal(t, nodeID, entry.NodeID)
+		assert.True(t, expiry.Equal(entry.Expiry))
+	})
+}
+
+func TestDisallowListCommands(t *testing.T) {
+	nodeID := unittest.IdentifierFixture()
+	peerID := unittest.PeerIdFixture(t)
+
+	idTranslator := mockp2p.NewIDTranslator(t)
+	idTranslator.On("GetPeerID", nodeID).Return(peerID, nil)
+	idTranslator.On("GetPeerID", mock.Anything).Return(peerID, p2p.ErrUnknownId).Maybe()
+
+	disallowList := &testDisallowList{entries: make(map[flow.Identifier]flow.DisallowListEntry)}
+	add := NewAddDisallowListEntryCommand(disallowList, idTranslator)
+	remove := NewRemoveDisallowListEntryCommand(disallowList, idTranslator)
+	read := NewReadDisallowListCommand(disallowList, idTranslator)
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{"node_id": nodeID.String(), "reason": "spamming"}}
+	require.NoError(t, add.Validator(req))
+	result, err := add.Handler(context.Background(), req)
+	require.NoError(t, err)
+	added := result.(map[string]interface{})
+	assert.Equal(t, nodeID.String(), added["node_id"])
+	assert.Equal(t, peerID.String(), added["peer_id"])
+	assert.Equal(t, "spamming", added["reason"])
+	assert.NotEmpty(t, added["created_at"])
+	assert.NotContains(t, added, "expiry")
+
+	req = &admin.CommandRequest{}
+	require.NoError(t, read.Validator(req))
+	result, err = read.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{added}, result)
+
+	req = &admin.CommandRequest{Data: map[string]interface{}{"node_id": nodeID.String()}}
+	require.NoError(t, remove.Validator(req))
+	result, err = remove.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Empty(t, disallowList.entries)
+
+	// removing a node that is not disallow-listed fails
+	_, err = remove.Handler(context.Background(), req)
+	require.Error(t, err)
+}
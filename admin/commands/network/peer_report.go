@@ -0,0 +1,264 @@
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	flownet "github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/p2p"
+)
+
+var _ commands.AdminCommand = (*PeerReportCommand)(nil)
+
+// PeerReportCommand reports the state the networking layer keeps about remote peers, i.e., their identity, GossipSub
+// scores and mesh membership, application layer (ALSP) misbehavior penalty, disallow-list status and unicast dial state.
+// Without input, it reports all peers the node is connected to or knows from the identity table; a single peer can be
+// requested by its peer_id or flow_id.
+type PeerReportCommand struct {
+	node         p2p.LibP2PNode
+	underlay     flownet.Underlay
+	idProvider   module.IdentityProvider
+	idTranslator p2p.IDTranslator
+}
+
+type peerReportRequest struct {
+	peerID peer.ID // optional, empty if all peers are requested
+}
+
+// PeerReport is the report of a single remote peer.
+type PeerReport struct {
+	PeerID string `json:"peer_id"`
+	// NodeID and Role are the Flow identity of the peer, empty if the peer is not in the identity table.
+	NodeID string `json:"node_id,omitempty"`
+	Role   string `json:"role,omitempty"`
+	// Connected is true if the node currently has at least one connection to the peer.
+	Connected bool `json:"connected"`
+	// DisallowListed is true if the peer is currently disallow-listed, for the given causes.
+	DisallowListed     bool     `json:"disallow_listed"`
+	DisallowListCauses []string `json:"disallow_list_causes,omitempty"`
+	// GossipSubScore is the GossipSub score of the peer, omitted if the node does not score the peer.
+	GossipSubScore *GossipSubScore `json:"gossipsub_score,omitempty"`
+	// GossipSubSpamPenalty is the penalty on the application specific score of the peer for GossipSub control message
+	// misbehavior, omitted if the peer has no spam record.
+	GossipSubSpamPenalty *float64 `json:"gossipsub_spam_penalty,omitempty"`
+	// MeshTopics are the topics on which the peer is in the local GossipSub mesh of the node.
+	MeshTopics []string `json:"mesh_topics"`
+	// Misbehavior is the application layer spam record of the peer, omitted if the peer has no spam record.
+	Misbehavior *MisbehaviorRecord `json:"misbehavior,omitempty"`
+	// UnicastDial is the unicast dial state of the peer, omitted if the node has not dialed the peer.
+	UnicastDial *UnicastDialState `json:"unicast_dial,omitempty"`
+}
+
+// GossipSubScore is the GossipSub score of a peer broken down into its components.
+type GossipSubScore struct {
+	Score              float64                         `json:"score"`
+	AppSpecificScore   float64                         `json:"app_specific_score"`
+	IPColocationFactor float64                         `json:"ip_colocation_factor"`
+	BehaviourPenalty   float64                         `json:"behaviour_penalty"`
+	Topics             map[string]*GossipSubTopicScore `json:"topics,omitempty"`
+}
+
+// GossipSubTopicScore is the GossipSub score of a peer on a single topic.
+type GossipSubTopicScore struct {
+	TimeInMesh               string  `json:"time_in_mesh"`
+	FirstMessageDeliveries   float64 `json:"first_message_deliveries"`
+	MeshMessageDeliveries    float64 `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries float64 `json:"invalid_message_deliveries"`
+}
+
+// MisbehaviorRecord is the application layer spam record of a peer.
+type MisbehaviorRecord struct {
+	Penalty        float64 `json:"penalty"`
+	Decay          float64 `json:"decay"`
+	CutoffCounter  uint64  `json:"cutoff_counter"`
+	DisallowListed bool    `json:"disallow_listed"`
+}
+
+// UnicastDialState is the unicast dial state of a peer.
+type UnicastDialState struct {
+	StreamCreationRetryAttemptBudget uint64 `json:"stream_creation_retry_attempt_budget"`
+	ConsecutiveSuccessfulStream      uint64 `json:"consecutive_successful_stream"`
+}
+
+// NewPeerReportCommand creates a new PeerReportCommand.
+func NewPeerReportCommand(
+	node p2p.LibP2PNode,
+	underlay flownet.Underlay,
+	idProvider module.IdentityProvider,
+	idTranslator p2p.IDTranslator,
+) *PeerReportCommand {
+	return &PeerReportCommand{
+		node:         node,
+		underlay:     underlay,
+		idProvider:   idProvider,
+		idTranslator: idTranslator,
+	}
+}
+
+// Handler returns the reports of the requested peers, ordered by peer ID.
+func (c *PeerReportCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*peerReportRequest)
+
+	if c.node == nil {
+		return nil, fmt.Errorf("libp2p node is not available")
+	}
+
+	peerIDs := []peer.ID{data.peerID}
+	if data.peerID == "" {
+		peerIDs = c.knownPeers()
+	}
+
+	reports := make([]*PeerReport, 0, len(peerIDs))
+	for _, peerID := range peerIDs {
+		reports = append(reports, c.report(peerID))
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].PeerID < reports[j].PeerID
+	})
+
+	return commands.ConvertToInterfaceList(reports)
+}
+
+// knownPeers returns the peers the node is connected to, together with the peers of the identities in the identity table.
+func (c *PeerReportCommand) knownPeers() []peer.ID {
+	seen := make(map[peer.ID]struct{})
+	var peerIDs []peer.ID
+	add := func(peerID peer.ID) {
+		if _, ok := seen[peerID]; ok || peerID == c.node.ID() {
+			return
+		}
+		seen[peerID] = struct{}{}
+		peerIDs = append(peerIDs, peerID)
+	}
+
+	for _, peerID := range c.node.Host().Network().Peers() {
+		add(peerID)
+	}
+	for _, identity := range c.idProvider.Identities(filter.Any) {
+		peerID, err := c.idTranslator.GetPeerID(identity.NodeID)
+		if err != nil {
+			// identities that cannot be translated are skipped, as the node cannot connect to them either.
+			continue
+		}
+		add(peerID)
+	}
+	return peerIDs
+}
+
+// report joins the state of the given peer kept by the libp2p node, the misbehavior report manager and the identity table.
+func (c *PeerReportCommand) report(peerID peer.ID) *PeerReport {
+	p2pReport := c.node.PeerReport(peerID)
+
+	report := &PeerReport{
+		PeerID:               peerID.String(),
+		Connected:            p2pReport.Connected,
+		DisallowListed:       len(p2pReport.DisallowListCauses) > 0,
+		GossipSubSpamPenalty: p2pReport.SpamPenalty,
+		MeshTopics:           p2pReport.MeshTopics,
+	}
+	for _, cause := range p2pReport.DisallowListCauses {
+		report.DisallowListCauses = append(report.DisallowListCauses, cause.String())
+	}
+	if report.MeshTopics == nil {
+		report.MeshTopics = []string{}
+	}
+
+	if dial := p2pReport.Dial; dial != nil {
+		report.UnicastDial = &UnicastDialState{
+			StreamCreationRetryAttemptBudget: dial.StreamCreationRetryAttemptBudget,
+			ConsecutiveSuccessfulStream:      dial.ConsecutiveSuccessfulStream,
+		}
+	}
+
+	if score := p2pReport.Score; score != nil {
+		report.GossipSubScore = &GossipSubScore{
+			Score:              score.Score,
+			AppSpecificScore:   score.AppSpecificScore,
+			IPColocationFactor: score.IPColocationFactor,
+			BehaviourPenalty:   score.BehaviourPenalty,
+			Topics:             make(map[string]*GossipSubTopicScore, len(score.TopicScores)),
+		}
+		for topic, topicScore := range score.TopicScores {
+			report.GossipSubScore.Topics[topic] = &GossipSubTopicScore{
+				TimeInMesh:               topicScore.TimeInMesh.String(),
+				FirstMessageDeliveries:   topicScore.FirstMessageDeliveries,
+				MeshMessageDeliveries:    topicScore.MeshMessageDeliveries,
+				InvalidMessageDeliveries: topicScore.InvalidMessageDeliveries,
+			}
+		}
+	}
+
+	identity, ok := c.idProvider.ByPeerID(peerID)
+	if !ok {
+		return report
+	}
+	report.NodeID = identity.NodeID.String()
+	report.Role = identity.Role.String()
+
+	if c.underlay == nil {
+		return report
+	}
+	if record, ok := c.underlay.SpamRecord(identity.NodeID); ok {
+		report.Misbehavior = &MisbehaviorRecord{
+			Penalty:        record.Penalty,
+			Decay:          record.Decay,
+			CutoffCounter:  record.CutoffCounter,
+			DisallowListed: record.DisallowListed,
+		}
+	}
+
+	return report
+}
+
+// Validator validates the request. The request data is optional, and may contain either
+//   - peer_id: to only report the peer with the given libp2p peer ID
+//   - flow_id: to only report the peer with the given Flow node ID
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *PeerReportCommand) Validator(req *admin.CommandRequest) error {
+	data := &peerReportRequest{}
+	req.ValidatorData = data
+
+	if req.Data == nil {
+		return nil
+	}
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	if rawPeerID, ok := input["peer_id"]; ok {
+		if peerIDStr, ok := rawPeerID.(string); ok {
+			if peerID, err := peer.Decode(peerIDStr); err == nil {
+				data.peerID = peerID
+				return nil
+			}
+		}
+		return admin.NewInvalidAdminReqParameterError("peer_id", "must be valid peer id string", rawPeerID)
+	}
+
+	if rawFlowID, ok := input["flow_id"]; ok {
+		if flowIDStr, ok := rawFlowID.(string); ok && len(flowIDStr) == 2*flow.IdentifierLen {
+			if b, err := hex.DecodeString(flowIDStr); err == nil {
+				peerID, err := c.idTranslator.GetPeerID(flow.HashToID(b))
+				if err != nil {
+					return admin.NewInvalidAdminReqParameterError("flow_id", "must be the node id of a known node", rawFlowID)
+				}
+				data.peerID = peerID
+				return nil
+			}
+		}
+		return admin.NewInvalidAdminReqParameterError("flow_id", "must be 64-char hex string", rawFlowID)
+	}
+
+	return nil
+}
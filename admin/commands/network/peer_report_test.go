@@ -0,0 +1,136 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	flownet "github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/alsp/model"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/network/p2p"
+	mockp2p "github.com/onflow/flow-go/network/p2p/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPeerReport_Validator(t *testing.T) {
+	peerID := unittest.PeerIdFixture(t)
+	nodeID := unittest.IdentifierFixture()
+	unknownID := unittest.IdentifierFixture()
+
+	idTranslator := mockp2p.NewIDTranslator(t)
+	idTranslator.On("GetPeerID", nodeID).Return(peerID, nil).Maybe()
+	idTranslator.On("GetPeerID", unknownID).Return(peerID, p2p.ErrUnknownId).Maybe()
+	command := NewPeerReportCommand(nil, nil, nil, idTranslator)
+
+	invalid := []interface{}{
+		"peers",
+		map[string]interface{}{"peer_id": "abc"},
+		map[string]interface{}{"peer_id": float64(1)},
+		map[string]interface{}{"flow_id": "abc"},
+		map[string]interface{}{"flow_id": unknownID.String()},
+	}
+	for _, data := range invalid {
+		req := &admin.CommandRequest{Data: data}
+		err := command.Validator(req)
+		assert.True(t, admin.IsInvalidAdminParameterError(err), "expected invalid request for %v", data)
+	}
+
+	valid := map[string]interface{}{
+		"no input":    nil,
+		"empty input": map[string]interface{}{},
+		"peer id":     map[string]interface{}{"peer_id": peerID.String()},
+		"flow id":     map[string]interface{}{"flow_id": nodeID.String()},
+	}
+	for name, data := range valid {
+		t.Run(name, func(t *testing.T) {
+			req := &admin.CommandRequest{Data: data}
+			require.NoError(t, command.Validator(req))
+			if data != nil && len(data.(map[string]interface{})) > 0 {
+				assert.Equal(t, peerID, req.ValidatorData.(*peerReportRequest).peerID)
+			} else {
+				assert.Empty(t, req.ValidatorData.(*peerReportRequest).peerID)
+			}
+		})
+	}
+}
+
+func TestPeerReport_Handler(t *testing.T) {
+	identity := unittest.IdentityFixture(unittest.WithRole(flow.RoleConsensus))
+	peerID := unittest.PeerIdFixture(t)
+	spamPenalty := -10.0
+
+	node := mockp2p.NewLibP2PNode(t)
+	node.On("PeerReport", peerID).Return(&p2p.PeerReport{
+		PeerID:             peerID,
+		Connected:          true,
+		DisallowListCauses: []flownet.DisallowListedCause{flownet.DisallowListedCauseAlsp},
+		Score: &p2p.PeerScoreReport{
+			Score:            -5,
+			AppSpecificScore: -10,
+			TopicScores: map[string]p2p.TopicScoreSnapshot{
+				"push-blocks": {TimeInMesh: time.Minute, MeshMessageDeliveries: 2},
+			},
+		},
+		SpamPenalty: &spamPenalty,
+		MeshTopics:  []string{"push-blocks"},
+		Dial: &p2p.UnicastDialState{
+			StreamCreationRetryAttemptBudget: 3,
+			ConsecutiveSuccessfulStream:      1,
+		},
+	})
+
+	underlay := mocknetwork.NewUnderlay(t)
+	underlay.On("SpamRecord", identity.NodeID).Return(&model.ProtocolSpamRecord{
+		OriginId:       identity.NodeID,
+		Decay:          1000,
+		CutoffCounter:  1,
+		DisallowListed: true,
+		Penalty:        -100,
+	}, true)
+
+	idProvider := mockmodule.NewIdentityProvider(t)
+	idProvider.On("ByPeerID", peerID).Return(identity, true)
+
+	command := NewPeerReportCommand(node, underlay, idProvider, mockp2p.NewIDTranslator(t))
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{"peer_id": peerID.String()}}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	reports := result.([]interface{})
+	require.Len(t, reports, 1)
+	report := reports[0].(map[string]interface{})
+
+	assert.Equal(t, peerID.String(), report["peer_id"])
+	assert.Equal(t, identity.NodeID.String(), report["node_id"])
+	assert.Equal(t, flow.RoleConsensus.String(), report["role"])
+	assert.Equal(t, true, report["connected"])
+	assert.Equal(t, true, report["disallow_listed"])
+	assert.Equal(t, []interface{}{flownet.DisallowListedCauseAlsp.String()}, report["disallow_list_causes"])
+	assert.Equal(t, spamPenalty, report["gossipsub_spam_penalty"])
+	assert.Equal(t, []interface{}{"push-blocks"}, report["mesh_topics"])
+
+	score := report["gossipsub_score"].(map[string]interface{})
+	assert.Equal(t, float64(-5), score["score"])
+	assert.Equal(t, float64(-10), score["app_specific_score"])
+	topic := score["topics"].(map[string]interface{})["push-blocks"].(map[string]interface{})
+	assert.Equal(t, time.Minute.String(), topic["time_in_mesh"])
+	assert.Equal(t, float64(2), topic["mesh_message_deliveries"])
+
+	misbehavior := report["misbehavior"].(map[string]interface{})
+	assert.Equal(t, float64(-100), misbehavior["penalty"])
+	assert.Equal(t, float64(1), misbehavior["cutoff_counter"])
+	assert.Equal(t, true, misbehavior["disallow_listed"])
+
+	dial := report["unicast_dial"].(map[string]interface{})
+	assert.Equal(t, float64(3), dial["stream_creation_retry_attempt_budget"])
+	assert.Equal(t, float64(1), dial["consecutive_successful_stream"])
+}
@@ -0,0 +1,45 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/network/p2p"
+)
+
+var _ commands.AdminCommand = (*ReadDisallowListCommand)(nil)
+
+// ReadDisallowListCommand returns the operator-managed disallow-list entries that are not expired.
+type ReadDisallowListCommand struct {
+	disallowList DisallowList
+	idTranslator p2p.IDTranslator
+}
+
+// NewReadDisallowListCommand creates a new ReadDisallowListCommand.
+func NewReadDisallowListCommand(disallowList DisallowList, idTranslator p2p.IDTranslator) *ReadDisallowListCommand {
+	return &ReadDisallowListCommand{
+		disallowList: disallowList,
+		idTranslator: idTranslator,
+	}
+}
+
+// Handler returns the disallow-list entries, ordered by creation time.
+func (c *ReadDisallowListCommand) Handler(_ context.Context, _ *admin.CommandRequest) (interface{}, error) {
+	if c.disallowList == nil {
+		return nil, fmt.Errorf("disallow-list is not available")
+	}
+
+	entries := c.disallowList.Entries()
+	result := make([]*DisallowListEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, newDisallowListEntry(entry, c.idTranslator))
+	}
+	return commands.ConvertToInterfaceList(result)
+}
+
+// Validator validates the request, the command takes no input.
+func (c *ReadDisallowListCommand) Validator(_ *admin.CommandRequest) error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network/p2p"
+)
+
+var _ commands.AdminCommand = (*RemoveDisallowListEntryCommand)(nil)
+
+// RemoveDisallowListEntryCommand removes the disallow-list entry of a node, i.e., the node is allow-listed again.
+type RemoveDisallowListEntryCommand struct {
+	disallowList DisallowList
+	idTranslator p2p.IDTranslator
+}
+
+// NewRemoveDisallowListEntryCommand creates a new RemoveDisallowListEntryCommand.
+func NewRemoveDisallowListEntryCommand(disallowList DisallowList, idTranslator p2p.IDTranslator) *RemoveDisallowListEntryCommand {
+	return &RemoveDisallowListEntryCommand{
+		disallowList: disallowList,
+		idTranslator: idTranslator,
+	}
+}
+
+// Handler removes the disallow-list entry of the requested node.
+func (c *RemoveDisallowListEntryCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	nodeID := req.ValidatorData.(flow.Identifier)
+
+	if c.disallowList == nil {
+		return nil, fmt.Errorf("disallow-list is not available")
+	}
+
+	removed, err := c.disallowList.Remove(nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("could not remove disallow-list entry: %w", err)
+	}
+	if !removed {
+		return nil, fmt.Errorf("node %v is not disallow-listed", nodeID)
+	}
+
+	return "ok", nil
+}
+
+// Validator validates the request. The request data must contain either
+//   - node_id: the node ID of the node to allow-list
+//   - peer_id: the libp2p peer ID of the node to allow-list
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *RemoveDisallowListEntryCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	nodeID, err := parseNodeID(input, c.idTranslator)
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = nodeID
+	return nil
+}
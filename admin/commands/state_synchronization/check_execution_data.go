@@ -0,0 +1,79 @@
+package state_synchronization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/executiondatasync/checker"
+)
+
+var _ commands.AdminCommand = (*CheckExecutionDataCommand)(nil)
+
+// CheckExecutionDataCommand checks the consistency of the execution data blobstore with the execution data
+// tracker, and optionally repairs it.
+type CheckExecutionDataCommand struct {
+	checker *checker.Checker
+}
+
+func NewCheckExecutionDataCommand(checker *checker.Checker) commands.AdminCommand {
+	return &CheckExecutionDataCommand{
+		checker: checker,
+	}
+}
+
+func (c *CheckExecutionDataCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if c.checker == nil {
+		return nil, errors.New("execution data checker is not enabled, execution data pruning must be enabled")
+	}
+
+	repair := req.ValidatorData.(bool)
+
+	report, err := c.checker.Check(ctx, repair)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check execution data: %w", err)
+	}
+
+	inconsistentHeights := make([]interface{}, len(report.InconsistentHeights))
+	for i, height := range report.InconsistentHeights {
+		inconsistentHeights[i] = height
+	}
+
+	return map[string]interface{}{
+		"start_height":              report.StartHeight,
+		"end_height":                report.EndHeight,
+		"inconsistent_heights":      inconsistentHeights,
+		"inconsistent_height_count": report.InconsistentHeightCount,
+		"missing_blobs":             report.MissingBlobs,
+		"malformed_blobs":           report.MalformedBlobs,
+		"refetched_heights":         report.RefetchedHeights,
+		"untracked_blobs":           report.UntrackedBlobs,
+		"deleted_blobs":             report.DeletedBlobs,
+	}, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *CheckExecutionDataCommand) Validator(req *admin.CommandRequest) error {
+	repair := false
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+		}
+
+		if value, ok := input["repair"]; ok {
+			repair, ok = value.(bool)
+			if !ok {
+				return admin.NewInvalidAdminReqParameterError("repair", "must be a bool", value)
+			}
+		}
+	}
+
+	req.ValidatorData = repair
+
+	return nil
+}
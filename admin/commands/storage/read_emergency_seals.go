@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadEmergencySealsCommand)(nil)
+
+// Max_Range_Emergency_Seals_Limit is the maximum number of heights that can be queried in one request.
+const Max_Range_Emergency_Seals_Limit = uint64(100001)
+
+// ReadEmergencySealsCommand returns the audit records of seals, which this consensus node constructed
+// via emergency sealing, for executed blocks in the requested height range.
+type ReadEmergencySealsCommand struct {
+	emergencySeals storage.EmergencySeals
+}
+
+func NewReadEmergencySealsCommand(emergencySeals storage.EmergencySeals) commands.AdminCommand {
+	return &ReadEmergencySealsCommand{
+		emergencySeals: emergencySeals,
+	}
+}
+
+func (c *ReadEmergencySealsCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	reqData := req.ValidatorData.(*heightRangeReqData)
+
+	log.Info().Str("module", "admin-tool").Msgf("read emergency seals, data: %v", reqData)
+
+	records, err := c.emergencySeals.ByHeightRange(reqData.startHeight, reqData.endHeight)
+	if err != nil {
+		return nil, fmt.Errorf("could not read emergency seals: %w", err)
+	}
+	return commands.ConvertToInterfaceList(records)
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ReadEmergencySealsCommand) Validator(req *admin.CommandRequest) error {
+	reqData, err := parseHeightRangeRequestData(req)
+	if err != nil {
+		return err
+	}
+	if reqData.Range() > Max_Range_Emergency_Seals_Limit {
+		return admin.NewInvalidAdminReqErrorf("getting emergency seals for more than %v heights at a time is not allowed", Max_Range_Emergency_Seals_Limit)
+	}
+	req.ValidatorData = reqData
+	return nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadEmergencySeals(t *testing.T) {
+	t.Parallel()
+
+	emergencySeals := storagemock.NewEmergencySeals(t)
+	command := NewReadEmergencySealsCommand(emergencySeals)
+
+	t.Run("valid range", func(t *testing.T) {
+		record := &flow.EmergencySeal{
+			ResultID:            unittest.IdentifierFixture(),
+			BlockID:             unittest.IdentifierFixture(),
+			BlockHeight:         15,
+			IncorporatedBlockID: unittest.IdentifierFixture(),
+			RequiredApprovals:   1,
+			UnapprovedChunks: []flow.EmergencySealChunk{{
+				Index:             0,
+				AssignedVerifiers: unittest.IdentifierListFixture(2),
+			}},
+		}
+		emergencySeals.On("ByHeightRange", uint64(10), uint64(20)).Return([]*flow.EmergencySeal{record}, nil).Once()
+
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"start-height": float64(10),
+				"end-height":   float64(20),
+			},
+		}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		expected, err := commands.ConvertToInterfaceList([]*flow.EmergencySeal{record})
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"start-height": float64(20),
+				"end-height":   float64(10),
+			},
+		}
+		require.Error(t, command.Validator(req))
+
+		req = &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"start-height": float64(0),
+				"end-height":   float64(Max_Range_Emergency_Seals_Limit),
+			},
+		}
+		require.Error(t, command.Validator(req))
+	})
+}
@@ -0,0 +1,185 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*GetExecutionNodeVerificationStatsCommand)(nil)
+
+// MaxVerificationStatsHeightRange is the maximum number of heights that can be queried in one request.
+const MaxVerificationStatsHeightRange = uint64(100_000)
+
+// GetExecutionNodeVerificationStatsCommand aggregates the verification history of this node per execution node,
+// for executed blocks in the requested height range.
+type GetExecutionNodeVerificationStatsCommand struct {
+	records storage.ChunkVerificationRecords
+}
+
+type verificationStatsRequest struct {
+	startHeight     uint64
+	endHeight       uint64
+	executionNodeID flow.Identifier // optional, zero if stats of all execution nodes are requested
+}
+
+// ExecutionNodeVerificationStats are the verification statistics of a single execution node.
+type ExecutionNodeVerificationStats struct {
+	ExecutionNodeID flow.Identifier `json:"execution_node_id"`
+	// VerifiedChunks is the number of verified chunks of results the execution node committed to.
+	VerifiedChunks uint64 `json:"verified_chunks"`
+	// FaultyChunks is the number of verified chunks with a chunk fault.
+	FaultyChunks uint64 `json:"faulty_chunks"`
+	// FaultRate is FaultyChunks / VerifiedChunks.
+	FaultRate float64 `json:"fault_rate"`
+	// Faults counts the faulty chunks by fault type.
+	Faults map[string]uint64 `json:"faults"`
+	// ChunkDataPacksServed is the number of chunk data packs, that the execution node provided to this node.
+	ChunkDataPacksServed uint64 `json:"chunk_data_packs_served"`
+	// AvgFetchLatency and MaxFetchLatency are computed over the chunk data packs served by the execution node.
+	AvgFetchLatency string `json:"avg_fetch_latency"`
+	MaxFetchLatency string `json:"max_fetch_latency"`
+
+	totalFetchLatency time.Duration
+	maxFetchLatency   time.Duration
+}
+
+// NewGetExecutionNodeVerificationStatsCommand creates a new GetExecutionNodeVerificationStatsCommand.
+func NewGetExecutionNodeVerificationStatsCommand(records storage.ChunkVerificationRecords) *GetExecutionNodeVerificationStatsCommand {
+	return &GetExecutionNodeVerificationStatsCommand{
+		records: records,
+	}
+}
+
+// Handler returns the verification statistics per execution node, ordered by execution node ID.
+func (c *GetExecutionNodeVerificationStatsCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*verificationStatsRequest)
+
+	records, err := c.records.ByHeightRange(data.startHeight, data.endHeight)
+	if err != nil {
+		return nil, fmt.Errorf("could not read chunk verification records: %w", err)
+	}
+
+	stats := make(map[flow.Identifier]*ExecutionNodeVerificationStats)
+	statsFor := func(executionNodeID flow.Identifier) *ExecutionNodeVerificationStats {
+		s, ok := stats[executionNodeID]
+		if !ok {
+			s = &ExecutionNodeVerificationStats{
+				ExecutionNodeID: executionNodeID,
+				Faults:          make(map[string]uint64),
+			}
+			stats[executionNodeID] = s
+		}
+		return s
+	}
+	requested := func(executionNodeID flow.Identifier) bool {
+		return data.executionNodeID == flow.ZeroID || data.executionNodeID == executionNodeID
+	}
+
+	for _, record := range records {
+		for _, executorID := range record.Executors {
+			if !requested(executorID) {
+				continue
+			}
+			s := statsFor(executorID)
+			s.VerifiedChunks++
+			if !record.Valid() {
+				s.FaultyChunks++
+				s.Faults[record.FaultType]++
+			}
+		}
+		if record.ChunkDataPackOrigin != flow.ZeroID && requested(record.ChunkDataPackOrigin) {
+			s := statsFor(record.ChunkDataPackOrigin)
+			s.ChunkDataPacksServed++
+			s.totalFetchLatency += record.FetchDuration
+			if record.FetchDuration > s.maxFetchLatency {
+				s.maxFetchLatency = record.FetchDuration
+			}
+		}
+	}
+
+	result := make([]*ExecutionNodeVerificationStats, 0, len(stats))
+	for _, s := range stats {
+		if s.VerifiedChunks > 0 {
+			s.FaultRate = float64(s.FaultyChunks) / float64(s.VerifiedChunks)
+		}
+		if s.ChunkDataPacksServed > 0 {
+			s.AvgFetchLatency = (s.totalFetchLatency / time.Duration(s.ChunkDataPacksServed)).String()
+			s.MaxFetchLatency = s.maxFetchLatency.String()
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return flow.IsIdentifierCanonical(result[i].ExecutionNodeID, result[j].ExecutionNodeID)
+	})
+
+	return commands.ConvertToInterfaceList(result)
+}
+
+// Validator validates the request. The request data must contain
+//   - start-height: the lowest height of executed blocks to include
+//   - end-height: the highest height of executed blocks to include
+//
+// and may contain
+//   - execution-node-id: to only return the statistics of the given execution node
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *GetExecutionNodeVerificationStatsCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	data := &verificationStatsRequest{}
+
+	var err error
+	data.startHeight, err = parseHeight(input, "start-height")
+	if err != nil {
+		return err
+	}
+	data.endHeight, err = parseHeight(input, "end-height")
+	if err != nil {
+		return err
+	}
+	if data.endHeight < data.startHeight {
+		return admin.NewInvalidAdminReqErrorf("end-height %v should not be smaller than start-height %v", data.endHeight, data.startHeight)
+	}
+	if data.endHeight-data.startHeight+1 > MaxVerificationStatsHeightRange {
+		return admin.NewInvalidAdminReqErrorf("getting verification stats for more than %v heights at a time is not allowed", MaxVerificationStatsHeightRange)
+	}
+
+	if nodeIn, ok := input["execution-node-id"]; ok {
+		node, ok := nodeIn.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("execution-node-id", "expected a node ID represented as a 64 character long hex string", nodeIn)
+		}
+		data.executionNodeID, err = flow.HexStringToIdentifier(node)
+		if err != nil {
+			return admin.NewInvalidAdminReqParameterError("execution-node-id", "expected a node ID represented as a 64 character long hex string", nodeIn)
+		}
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+// parseHeight parses the required, non-negative integer field of the request data.
+// Returns admin.InvalidAdminReqError if the field is missing or invalid.
+func parseHeight(input map[string]interface{}, field string) (uint64, error) {
+	heightIn, ok := input[field]
+	if !ok {
+		return 0, admin.NewInvalidAdminReqErrorf("missing required field '%s'", field)
+	}
+	height, ok := heightIn.(float64)
+	if !ok || height < 0 || height != float64(uint64(height)) {
+		return 0, admin.NewInvalidAdminReqParameterError(field, "must be a non-negative integer", heightIn)
+	}
+	return uint64(height), nil
+}
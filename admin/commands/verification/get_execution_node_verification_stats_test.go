@@ -0,0 +1,127 @@
+package verification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetExecutionNodeVerificationStats_Validator(t *testing.T) {
+	command := NewGetExecutionNodeVerificationStatsCommand(nil)
+	nodeID := unittest.IdentifierFixture()
+
+	invalid := []interface{}{
+		nil,
+		"heights",
+		map[string]interface{}{"start-height": float64(1)},
+		map[string]interface{}{"end-height": float64(1)},
+		map[string]interface{}{"start-height": float64(-1), "end-height": float64(1)},
+		map[string]interface{}{"start-height": 1.5, "end-height": float64(2)},
+		map[string]interface{}{"start-height": float64(2), "end-height": float64(1)},
+		map[string]interface{}{"start-height": float64(0), "end-height": float64(MaxVerificationStatsHeightRange)},
+		map[string]interface{}{"start-height": float64(1), "end-height": float64(2), "execution-node-id": "abc"},
+	}
+	for _, data := range invalid {
+		req := &admin.CommandRequest{Data: data}
+		err := command.Validator(req)
+		assert.True(t, admin.IsInvalidAdminParameterError(err), "expected invalid request for %v", data)
+	}
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{
+		"start-height":      float64(10),
+		"end-height":        float64(20),
+		"execution-node-id": nodeID.String(),
+	}}
+	require.NoError(t, command.Validator(req))
+	data := req.ValidatorData.(*verificationStatsRequest)
+	assert.Equal(t, uint64(10), data.startHeight)
+	assert.Equal(t, uint64(20), data.endHeight)
+	assert.Equal(t, nodeID, data.executionNodeID)
+}
+
+func TestGetExecutionNodeVerificationStats_Handler(t *testing.T) {
+	exe1 := unittest.IdentifierFixture()
+	exe2 := unittest.IdentifierFixture()
+	records := []*chunks.VerificationRecord{
+		{
+			BlockHeight:         10,
+			Executors:           flow.IdentifierList{exe1, exe2},
+			ChunkDataPackOrigin: exe1,
+			FetchDuration:       time.Second,
+		},
+		{
+			BlockHeight:         11,
+			Executors:           flow.IdentifierList{exe1},
+			ChunkDataPackOrigin: exe1,
+			FaultType:           "final_state_mismatch",
+			Fault:               "final state mismatch",
+			FetchDuration:       3 * time.Second,
+		},
+	}
+
+	recordsStorage := storagemock.NewChunkVerificationRecords(t)
+	recordsStorage.On("ByHeightRange", uint64(10), uint64(11)).Return(records, nil)
+	command := NewGetExecutionNodeVerificationStatsCommand(recordsStorage)
+
+	t.Run("all execution nodes", func(t *testing.T) {
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"start-height": float64(10),
+			"end-height":   float64(11),
+		}}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		stats, ok := result.([]interface{})
+		require.True(t, ok)
+		require.Len(t, stats, 2)
+
+		byNode := make(map[string]map[string]interface{})
+		for _, s := range stats {
+			m := s.(map[string]interface{})
+			byNode[m["execution_node_id"].(string)] = m
+		}
+
+		s1 := byNode[exe1.String()]
+		require.NotNil(t, s1)
+		assert.Equal(t, float64(2), s1["verified_chunks"])
+		assert.Equal(t, float64(1), s1["faulty_chunks"])
+		assert.Equal(t, 0.5, s1["fault_rate"])
+		assert.Equal(t, map[string]interface{}{"final_state_mismatch": float64(1)}, s1["faults"])
+		assert.Equal(t, float64(2), s1["chunk_data_packs_served"])
+		assert.Equal(t, (2 * time.Second).String(), s1["avg_fetch_latency"])
+		assert.Equal(t, (3 * time.Second).String(), s1["max_fetch_latency"])
+
+		s2 := byNode[exe2.String()]
+		require.NotNil(t, s2)
+		assert.Equal(t, float64(1), s2["verified_chunks"])
+		assert.Equal(t, float64(0), s2["faulty_chunks"])
+		assert.Equal(t, float64(0), s2["fault_rate"])
+		assert.Equal(t, float64(0), s2["chunk_data_packs_served"])
+	})
+
+	t.Run("single execution node", func(t *testing.T) {
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"start-height":      float64(10),
+			"end-height":        float64(11),
+			"execution-node-id": exe2.String(),
+		}}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		stats, ok := result.([]interface{})
+		require.True(t, ok)
+		require.Len(t, stats, 1)
+		assert.Equal(t, exe2.String(), stats[0].(map[string]interface{})["execution_node_id"])
+	})
+}
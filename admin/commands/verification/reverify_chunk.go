@@ -0,0 +1,112 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/verification/reverifier"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var _ commands.AdminCommand = (*ReverifyChunkCommand)(nil)
+
+// ChunkReverifier re-verifies chunks of sealed execution results on demand.
+type ChunkReverifier interface {
+	Reverify(ctx context.Context, resultID flow.Identifier, chunkIndex uint64) (*reverifier.Report, error)
+}
+
+// ReverifyChunkCommand re-verifies a single chunk of a sealed execution result and returns a report of the
+// outcome. No result approval is published for the re-verified chunk.
+type ReverifyChunkCommand struct {
+	reverifier ChunkReverifier
+}
+
+type reverifyChunkRequest struct {
+	resultID   flow.Identifier
+	chunkIndex uint64
+	timeout    time.Duration
+}
+
+// NewReverifyChunkCommand creates a new ReverifyChunkCommand.
+func NewReverifyChunkCommand(reverifier ChunkReverifier) *ReverifyChunkCommand {
+	return &ReverifyChunkCommand{
+		reverifier: reverifier,
+	}
+}
+
+// Handler re-verifies the requested chunk and returns the verification report.
+func (c *ReverifyChunkCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*reverifyChunkRequest)
+
+	if data.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, data.timeout)
+		defer cancel()
+	}
+
+	report, err := c.reverifier.Reverify(ctx, data.resultID, data.chunkIndex)
+	if err != nil {
+		return nil, fmt.Errorf("could not re-verify chunk %d of result %v: %w", data.chunkIndex, data.resultID, err)
+	}
+	return commands.ConvertToMap(report)
+}
+
+// Validator validates the request. The request data must contain
+//   - result_id: the ID of the sealed execution result, as a hex string
+//   - chunk_index: the index of the chunk within the result
+//
+// and may contain
+//   - timeout: the maximum time to wait for the chunk data pack, as a duration string (e.g. "30s")
+//
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ReverifyChunkCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	data := &reverifyChunkRequest{}
+
+	resultIn, ok := input["result_id"]
+	if !ok {
+		return admin.NewInvalidAdminReqErrorf("missing required field 'result_id'")
+	}
+	result, ok := resultIn.(string)
+	if !ok {
+		return admin.NewInvalidAdminReqParameterError("result_id", "expected a result ID represented as a 64 character long hex string", resultIn)
+	}
+	resultID, err := flow.HexStringToIdentifier(result)
+	if err != nil {
+		return admin.NewInvalidAdminReqParameterError("result_id", "expected a result ID represented as a 64 character long hex string", resultIn)
+	}
+	data.resultID = resultID
+
+	indexIn, ok := input["chunk_index"]
+	if !ok {
+		return admin.NewInvalidAdminReqErrorf("missing required field 'chunk_index'")
+	}
+	index, ok := indexIn.(float64)
+	if !ok || index < 0 || index != float64(uint64(index)) {
+		return admin.NewInvalidAdminReqParameterError("chunk_index", "must be a non-negative integer", indexIn)
+	}
+	data.chunkIndex = uint64(index)
+
+	if timeoutIn, ok := input["timeout"]; ok {
+		timeoutStr, ok := timeoutIn.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("timeout", "must be a duration string", timeoutIn)
+		}
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout <= 0 {
+			return admin.NewInvalidAdminReqParameterError("timeout", "must be a positive duration string", timeoutIn)
+		}
+		data.timeout = timeout
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package verification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/verification/reverifier"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// reverifierFunc implements ChunkReverifier with a function.
+type reverifierFunc func(ctx context.Context, resultID flow.Identifier, chunkIndex uint64) (*reverifier.Report, error)
+
+func (f reverifierFunc) Reverify(ctx context.Context, resultID flow.Identifier, chunkIndex uint64) (*reverifier.Report, error) {
+	return f(ctx, resultID, chunkIndex)
+}
+
+func TestReverifyChunk_Validator(t *testing.T) {
+	command := NewReverifyChunkCommand(nil)
+	resultID := unittest.IdentifierFixture()
+
+	invalid := []interface{}{
+		nil,
+		"result",
+		map[string]interface{}{"chunk_index": float64(1)},
+		map[string]interface{}{"result_id": "abc", "chunk_index": float64(1)},
+		map[string]interface{}{"result_id": resultID.String()},
+		map[string]interface{}{"result_id": resultID.String(), "chunk_index": float64(-1)},
+		map[string]interface{}{"result_id": resultID.String(), "chunk_index": 1.5},
+		map[string]interface{}{"result_id": resultID.String(), "chunk_index": float64(1), "timeout": "soon"},
+		map[string]interface{}{"result_id": resultID.String(), "chunk_index": float64(1), "timeout": float64(30)},
+	}
+	for _, data := range invalid {
+		req := &admin.CommandRequest{Data: data}
+		err := command.Validator(req)
+		assert.True(t, admin.IsInvalidAdminParameterError(err), "expected invalid request for %v", data)
+	}
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{
+		"result_id":   resultID.String(),
+		"chunk_index": float64(3),
+		"timeout":     "30s",
+	}}
+	require.NoError(t, command.Validator(req))
+	data := req.ValidatorData.(*reverifyChunkRequest)
+	assert.Equal(t, resultID, data.resultID)
+	assert.Equal(t, uint64(3), data.chunkIndex)
+	assert.Equal(t, 30*time.Second, data.timeout)
+}
+
+func TestReverifyChunk_Handler(t *testing.T) {
+	resultID := unittest.IdentifierFixture()
+	report := &reverifier.Report{
+		ResultID:   resultID,
+		ChunkIndex: 3,
+		Valid:      true,
+	}
+	command := NewReverifyChunkCommand(reverifierFunc(func(ctx context.Context, id flow.Identifier, index uint64) (*reverifier.Report, error) {
+		assert.Equal(t, resultID, id)
+		assert.Equal(t, uint64(3), index)
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return report, nil
+	}))
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{
+		"result_id":   resultID.String(),
+		"chunk_index": float64(3),
+		"timeout":     "30s",
+	}}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	resultMap, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, resultID.String(), resultMap["result_id"])
+	assert.Equal(t, float64(3), resultMap["chunk_index"])
+	assert.Equal(t, true, resultMap["valid"])
+}
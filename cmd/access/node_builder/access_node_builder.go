@@ -29,6 +29,7 @@ import (
 
 	accessNode "github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/admin/commands"
+	accessCommands "github.com/onflow/flow-go/admin/commands/access"
 	stateSyncCommands "github.com/onflow/flow-go/admin/commands/state_synchronization"
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
@@ -43,6 +44,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/verification"
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/access/ethrpc"
 	"github.com/onflow/flow-go/engine/access/index"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	"github.com/onflow/flow-go/engine/access/ingestion/tx_error_messages"
@@ -63,6 +65,7 @@ import (
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/common/version"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/fvm/evm/debug"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -74,9 +77,11 @@ import (
 	"github.com/onflow/flow-go/module/chainsync"
 	"github.com/onflow/flow-go/module/counters"
 	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/executiondatasync/checker"
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	execdatacache "github.com/onflow/flow-go/module/executiondatasync/execution_data/cache"
 	"github.com/onflow/flow-go/module/executiondatasync/pruner"
+	"github.com/onflow/flow-go/module/executiondatasync/sink"
 	edstorage "github.com/onflow/flow-go/module/executiondatasync/storage"
 	"github.com/onflow/flow-go/module/executiondatasync/tracker"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
@@ -146,6 +151,7 @@ type AccessNodeConfig struct {
 	apiRatelimits                        map[string]int
 	apiBurstlimits                       map[string]int
 	rpcConf                              rpc.Config
+	ethRPCConf                           ethrpc.Config
 	stateStreamConf                      statestreambackend.Config
 	stateStreamFilterConf                map[string]int
 	ExecutionNodeAddress                 string // deprecated
@@ -162,12 +168,20 @@ type AccessNodeConfig struct {
 	executionDataPrunerHeightRangeTarget uint64
 	executionDataPrunerThreshold         uint64
 	executionDataPruningInterval         time.Duration
+	executionDataCheckInterval           time.Duration
+	executionDataUntrackedGracePeriod    time.Duration
+	executionDataSinkDir                 string
+	executionDataSinkFormat              string
+	executionDataSinkMaxSegmentSize      uint64
 	executionDataDir                     string
 	executionDataStartHeight             uint64
 	executionDataConfig                  edrequester.ExecutionDataConfig
 	PublicNetworkConfig                  PublicNetworkConfig
 	TxResultCacheSize                    uint
+	ScriptResultCacheSize                uint
+	ScriptResultCacheMaxBytes            uint64
 	executionDataIndexingEnabled         bool
+	evmIndexingEnabled                   bool
 	registersDBPath                      string
 	checkpointFile                       string
 	scriptExecutorConfig                 query.QueryConfig
@@ -179,6 +193,7 @@ type AccessNodeConfig struct {
 	checkPayerBalanceMode                string
 	versionControlEnabled                bool
 	storeTxResultErrorMessages           bool
+	storeTxResourceReports               bool
 	stopControlEnabled                   bool
 	registerDBPruneThreshold             uint64
 }
@@ -230,6 +245,7 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			MaxMsgSize:     grpcutils.DefaultMaxMsgSize,
 			CompressorName: grpcutils.NoCompressor,
 		},
+		ethRPCConf: ethrpc.DefaultConfig(),
 		stateStreamConf: statestreambackend.Config{
 			MaxExecutionDataMsgSize: grpcutils.DefaultMaxMsgSize,
 			ExecutionDataCacheSize:  subscription.DefaultCacheSize,
@@ -254,6 +270,8 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		apiRatelimits:                nil,
 		apiBurstlimits:               nil,
 		TxResultCacheSize:            0,
+		ScriptResultCacheSize:        0,
+		ScriptResultCacheMaxBytes:    64 * 1024 * 1024, // 64 MB
 		PublicNetworkConfig: PublicNetworkConfig{
 			BindAddress: cmd.NotSet,
 			Metrics:     metrics.NewNoopCollector(),
@@ -271,10 +289,16 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
 		executionDataIndexingEnabled:         false,
+		evmIndexingEnabled:                   false,
 		executionDataDBMode:                  execution_data.ExecutionDataDBModeBadger.String(),
 		executionDataPrunerHeightRangeTarget: 0,
 		executionDataPrunerThreshold:         pruner.DefaultThreshold,
 		executionDataPruningInterval:         pruner.DefaultPruningInterval,
+		executionDataCheckInterval:           0,
+		executionDataUntrackedGracePeriod:    checker.DefaultUntrackedBlobGracePeriod,
+		executionDataSinkDir:                 "",
+		executionDataSinkFormat:              sink.FormatCBOR.String(),
+		executionDataSinkMaxSegmentSize:      sink.DefaultMaxSegmentSize,
 		registersDBPath:                      filepath.Join(homedir, ".flow", "execution_state"),
 		checkpointFile:                       cmd.NotSet,
 		scriptExecutorConfig:                 query.NewDefaultConfig(),
@@ -286,6 +310,7 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		checkPayerBalanceMode:                accessNode.Disabled.String(),
 		versionControlEnabled:                true,
 		storeTxResultErrorMessages:           false,
+		storeTxResourceReports:               false,
 		stopControlEnabled:                   false,
 		registerDBPruneThreshold:             pruner.DefaultThreshold,
 	}
@@ -313,6 +338,7 @@ type FlowAccessNodeBuilder struct {
 	TransactionValidationMetrics *metrics.TransactionValidationCollector
 	RestMetrics                  *metrics.RestCollector
 	AccessMetrics                module.AccessMetrics
+	ScriptResultCache            *backend.ScriptResultCache
 	PingMetrics                  module.PingMetrics
 	Committee                    hotstuff.DynamicCommittee
 	Finalized                    *flow.Header // latest finalized block that the node knows of at startup time
@@ -331,12 +357,14 @@ type FlowAccessNodeBuilder struct {
 	RegistersAsyncStore          *execution.RegistersAsyncStore
 	Reporter                     *index.Reporter
 	EventsIndex                  *index.EventsIndex
+	EVMIndex                     storage.EVMIndex
 	TxResultsIndex               *index.TransactionResultsIndex
 	IndexerDependencies          *cmd.DependencyList
 	collectionExecutedMetric     module.CollectionExecutedMetric
 	ExecutionDataPruner          *pruner.Pruner
 	ExecutionDatastoreManager    edstorage.DatastoreManager
 	ExecutionDataTracker         tracker.Storage
+	ExecutionDataChecker         *checker.Checker
 	VersionControl               *version.VersionControl
 	StopControl                  *stop.StopControl
 
@@ -555,6 +583,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 	var execDataDistributor *edrequester.ExecutionDataDistributor
 	var execDataCacheBackend *herocache.BlockExecutionData
 	var executionDataStoreCache *execdatacache.ExecutionDataCache
+	var executionDataSinkConsumer *sink.RequesterConsumer
 	var executionDataDBMode execution_data.ExecutionDataDBMode
 
 	// setup dependency chain to ensure indexer starts after the requester
@@ -567,6 +596,9 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 		AdminCommand("read-execution-data", func(config *cmd.NodeConfig) commands.AdminCommand {
 			return stateSyncCommands.NewReadExecutionDataCommand(builder.ExecutionDataStore)
 		}).
+		AdminCommand("check-execution-data", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return stateSyncCommands.NewCheckExecutionDataCommand(builder.ExecutionDataChecker)
+		}).
 		Module("execution data datastore and blobstore", func(node *cmd.NodeConfig) error {
 			datastoreDir := filepath.Join(builder.executionDataDir, "blobstore")
 			err := os.MkdirAll(datastoreDir, 0700)
@@ -631,6 +663,44 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 			builder.ExecutionDataStore = execution_data.NewExecutionDataStore(builder.ExecutionDataBlobstore, execution_data.DefaultSerializer)
 			return nil
 		}).
+		Module("execution data tracker", func(node *cmd.NodeConfig) error {
+			if !executionDataPrunerEnabled {
+				return nil
+			}
+
+			sealed, err := node.State.Sealed().Head()
+			if err != nil {
+				return fmt.Errorf("cannot get the sealed block: %w", err)
+			}
+
+			trackerDir := filepath.Join(builder.executionDataDir, "tracker")
+			builder.ExecutionDataTracker, err = tracker.OpenStorage(
+				trackerDir,
+				sealed.Height,
+				node.Logger,
+				tracker.WithPruneCallback(func(c cid.Cid) error {
+					// TODO: use a proper context here
+					return builder.ExecutionDataBlobstore.DeleteBlob(context.TODO(), c)
+				}),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create execution data tracker: %w", err)
+			}
+
+			// the checker relies on the tracker to find the retained heights and the untracked blobs
+			builder.ExecutionDataChecker = checker.NewChecker(
+				node.Logger,
+				builder.ExecutionDataBlobstore,
+				builder.ExecutionDataTracker,
+				node.Storage.Headers,
+				node.Storage.Seals,
+				node.Storage.Results,
+				checker.WithCheckInterval(builder.executionDataCheckInterval),
+				checker.WithUntrackedBlobGracePeriod(builder.executionDataUntrackedGracePeriod),
+			)
+
+			return nil
+		}).
 		Module("execution data cache", func(node *cmd.NodeConfig) error {
 			var heroCacheCollector module.HeroCacheMetrics = metrics.NewNoopCollector()
 			if builder.HeroCacheMetricsEnable {
@@ -653,12 +723,16 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 			return nil
 		}).
 		Component("execution data service", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			// Only allow block requests from staked ENs and ANs
+			requestFilter := blob.AuthorizedRequester(nil, builder.IdentityProvider, builder.Logger)
+			if node.BandwidthAccountant != nil {
+				// reject the block requests of peers that exhausted their blob egress budget
+				requestFilter = node.BandwidthAccountant.BlobRequestFilter(requestFilter)
+			}
+
 			opts := []network.BlobServiceOption{
 				blob.WithBitswapOptions(
-					// Only allow block requests from staked ENs and ANs
-					bitswap.WithPeerBlockRequestFilter(
-						blob.AuthorizedRequester(nil, builder.IdentityProvider, builder.Logger),
-					),
+					bitswap.WithPeerBlockRequestFilter(requestFilter),
 					bitswap.WithTracer(
 						blob.NewTracer(node.Logger.With().Str("blob_service", channels.ExecutionDataService.String()).Logger()),
 					),
@@ -683,25 +757,6 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 			var downloaderOpts []execution_data.DownloaderOption
 
 			if executionDataPrunerEnabled {
-				sealed, err := node.State.Sealed().Head()
-				if err != nil {
-					return nil, fmt.Errorf("cannot get the sealed block: %w", err)
-				}
-
-				trackerDir := filepath.Join(builder.executionDataDir, "tracker")
-				builder.ExecutionDataTracker, err = tracker.OpenStorage(
-					trackerDir,
-					sealed.Height,
-					node.Logger,
-					tracker.WithPruneCallback(func(c cid.Cid) error {
-						// TODO: use a proper context here
-						return builder.ExecutionDataBlobstore.DeleteBlob(context.TODO(), c)
-					}),
-				)
-				if err != nil {
-					return nil, fmt.Errorf("failed to create execution data tracker: %w", err)
-				}
-
 				downloaderOpts = []execution_data.DownloaderOption{
 					execution_data.WithExecutionDataTracker(builder.ExecutionDataTracker, node.Storage.Headers),
 				}
@@ -743,6 +798,43 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 
 			execDataDistributor = edrequester.NewExecutionDataDistributor()
 
+			if builder.executionDataSinkDir != "" {
+				format, err := sink.ParseFormat(builder.executionDataSinkFormat)
+				if err != nil {
+					return nil, err
+				}
+				fileSink, err := sink.NewFileSink(
+					node.Logger,
+					builder.executionDataSinkDir,
+					format,
+					builder.executionDataSinkMaxSegmentSize,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("could not create execution data sink: %w", err)
+				}
+				builder.ShutdownFunc(func() error {
+					if err := fileSink.Close(); err != nil {
+						return fmt.Errorf("error closing execution data sink: %w", err)
+					}
+					return nil
+				})
+
+				var sinkMetrics module.ExecutionDataSinkMetrics = metrics.NewNoopCollector()
+				if node.MetricsEnabled {
+					sinkMetrics = metrics.NewExecutionDataSinkCollector()
+				}
+
+				// the received execution data is read from the local execution data store
+				executionDataSinkConsumer = sink.NewRequesterConsumer(
+					node.Logger,
+					sinkMetrics,
+					fileSink,
+					builder.Storage.Headers,
+					executionDataStoreCache,
+				)
+				execDataDistributor.AddOnExecutionDataReceivedConsumer(executionDataSinkConsumer.OnExecutionDataReceived)
+			}
+
 			// Execution Data cache with a downloader as the backend. This is used by the requester
 			// to download and cache execution data for each block. It shares a cache backend instance
 			// with the datastore implementation.
@@ -779,6 +871,12 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 
 			return builder.ExecutionDataRequester, nil
 		}).
+		Component("execution data sink", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if executionDataSinkConsumer == nil {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return executionDataSinkConsumer, nil
+		}).
 		Component("execution data pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			if !executionDataPrunerEnabled {
 				return &module.NoopReadyDoneAware{}, nil
@@ -808,6 +906,16 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 			builder.ExecutionDataPruner.RegisterHeightRecorder(builder.ExecutionDataDownloader)
 
 			return builder.ExecutionDataPruner, nil
+		}).
+		Component("execution data checker", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if !executionDataPrunerEnabled {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+
+			// missing blobs are requested again from the network
+			builder.ExecutionDataChecker.SetDownloader(builder.ExecutionDataDownloader)
+
+			return builder.ExecutionDataChecker, nil
 		})
 
 	if builder.publicNetworkExecutionDataEnabled {
@@ -819,12 +927,18 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 			return nil
 		})
 		builder.Component("public network execution data service", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
-			opts := []network.BlobServiceOption{
-				blob.WithBitswapOptions(
-					bitswap.WithTracer(
-						blob.NewTracer(node.Logger.With().Str("public_blob_service", channels.PublicExecutionDataService.String()).Logger()),
-					),
+			bitswapOpts := []bitswap.Option{
+				bitswap.WithTracer(
+					blob.NewTracer(node.Logger.With().Str("public_blob_service", channels.PublicExecutionDataService.String()).Logger()),
 				),
+			}
+			if node.BandwidthAccountant != nil {
+				// reject the block requests of peers that exhausted their blob egress budget
+				bitswapOpts = append(bitswapOpts, bitswap.WithPeerBlockRequestFilter(node.BandwidthAccountant.BlobRequestFilter(nil)))
+			}
+
+			opts := []network.BlobServiceOption{
+				blob.WithBitswapOptions(bitswapOpts...),
 				blob.WithParentBlobService(bs),
 			}
 
@@ -943,6 +1057,11 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					return nil, fmt.Errorf("could not create derived chain data: %w", err)
 				}
 
+				var evmIndexer *indexer.EVMIndexer
+				if builder.EVMIndex != nil {
+					evmIndexer = indexer.NewEVMIndexer(builder.Logger, builder.RootChainID, builder.EVMIndex)
+				}
+
 				indexerCore, err := indexer.New(
 					builder.Logger,
 					metrics.NewExecutionStateIndexerCollector(),
@@ -956,6 +1075,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.RootChainID.Chain(),
 					indexerDerivedChainData,
 					builder.collectionExecutedMetric,
+					evmIndexer,
 				)
 				if err != nil {
 					return nil, err
@@ -1260,6 +1380,8 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.BoolVar(&builder.retryEnabled, "retry-enabled", defaultConfig.retryEnabled, "whether to enable the retry mechanism at the access node level")
 		flags.BoolVar(&builder.rpcMetricsEnabled, "rpc-metrics-enabled", defaultConfig.rpcMetricsEnabled, "whether to enable the rpc metrics")
 		flags.UintVar(&builder.TxResultCacheSize, "transaction-result-cache-size", defaultConfig.TxResultCacheSize, "transaction result cache size.(Disabled by default i.e 0)")
+		flags.UintVar(&builder.ScriptResultCacheSize, "script-result-cache-size", defaultConfig.ScriptResultCacheSize, "max number of results of scripts executed at sealed blocks to cache. (Disabled by default i.e 0)")
+		flags.Uint64Var(&builder.ScriptResultCacheMaxBytes, "script-result-cache-max-bytes", defaultConfig.ScriptResultCacheMaxBytes, "max total size in bytes of the cached script results")
 		flags.StringVarP(&builder.nodeInfoFile,
 			"node-info-file",
 			"",
@@ -1343,6 +1465,26 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"execution-data-pruning-interval",
 			defaultConfig.executionDataPruningInterval,
 			"duration after which the pruner tries to prune execution data. The default value is 10 minutes")
+		flags.DurationVar(&builder.executionDataCheckInterval,
+			"execution-data-check-interval",
+			defaultConfig.executionDataCheckInterval,
+			"interval at which the execution data blobstore is checked for missing and untracked blobs, which are repaired. requires execution data pruning. the blobstore is only checked on demand if 0")
+		flags.DurationVar(&builder.executionDataUntrackedGracePeriod,
+			"execution-data-untracked-grace-period",
+			defaultConfig.executionDataUntrackedGracePeriod,
+			"minimum duration for which a blob in the execution data blobstore must be untracked before it is deleted by the execution data check. must exceed the time needed to download the execution data of a block")
+		flags.StringVar(&builder.executionDataSinkDir,
+			"execution-data-sink-dir",
+			defaultConfig.executionDataSinkDir,
+			"directory to which the received execution data is written as rotating segment files. if left empty the execution data is not written")
+		flags.StringVar(&builder.executionDataSinkFormat,
+			"execution-data-sink-format",
+			defaultConfig.executionDataSinkFormat,
+			"encoding of the execution data written to --execution-data-sink-dir. One of [cbor, json]")
+		flags.Uint64Var(&builder.executionDataSinkMaxSegmentSize,
+			"execution-data-sink-max-segment-size",
+			defaultConfig.executionDataSinkMaxSegmentSize,
+			"size in bytes at which the execution data segment files are rotated")
 
 		// Execution State Streaming API
 		flags.Uint32Var(&builder.stateStreamConf.ExecutionDataCacheSize, "execution-data-cache-size", defaultConfig.stateStreamConf.ExecutionDataCacheSize, "block execution data cache size")
@@ -1381,9 +1523,35 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.evmIndexingEnabled,
+			"evm-indexing-enabled",
+			defaultConfig.evmIndexingEnabled,
+			"whether to index the EVM blocks, transactions and receipts from the EVM events. when set, the Ethereum JSON-RPC lookups by block hash, transaction hash and log filter are enabled. requires execution-data-indexing-enabled")
 		flags.StringVar(&builder.registersDBPath, "execution-state-dir", defaultConfig.registersDBPath, "directory to use for execution-state database")
 		flags.StringVar(&builder.checkpointFile, "execution-state-checkpoint", defaultConfig.checkpointFile, "execution-state checkpoint file")
 
+		// Ethereum JSON-RPC server
+		flags.StringVar(&builder.ethRPCConf.ListenAddress,
+			"ethrpc-addr",
+			defaultConfig.ethRPCConf.ListenAddress,
+			"the address the Ethereum JSON-RPC server listens on, the server is disabled if empty. requires execution-data-indexing-enabled")
+		flags.Uint64Var(&builder.ethRPCConf.MaxCallGasLimit,
+			"ethrpc-max-call-gas-limit",
+			defaultConfig.ethRPCConf.MaxCallGasLimit,
+			"gas limit cap of eth_call and eth_estimateGas")
+		flags.IntVar(&builder.ethRPCConf.BatchRequestLimit,
+			"ethrpc-batch-request-limit",
+			defaultConfig.ethRPCConf.BatchRequestLimit,
+			"maximum number of requests in an Ethereum JSON-RPC batch")
+		flags.StringVar(&builder.ethRPCConf.TracesDir,
+			"ethrpc-traces-dir",
+			defaultConfig.ethRPCConf.TracesDir,
+			"directory of the local database storing EVM call traces. when set, the debug_traceTransaction and debug_traceBlockByNumber methods are enabled, and missing traces are regenerated by replaying the EVM blocks")
+		flags.DurationVar(&builder.ethRPCConf.TracesRetention,
+			"ethrpc-traces-retention",
+			defaultConfig.ethRPCConf.TracesRetention,
+			"duration for which EVM call traces are kept in the database provided by --ethrpc-traces-dir, 0 keeps them forever")
+
 		flags.StringVar(&builder.rpcConf.BackendConfig.EventQueryMode,
 			"event-query-mode",
 			defaultConfig.rpcConf.BackendConfig.EventQueryMode,
@@ -1397,6 +1565,10 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"store-tx-result-error-messages",
 			defaultConfig.storeTxResultErrorMessages,
 			"whether to enable storing transaction error messages into the db")
+		flags.BoolVar(&builder.storeTxResourceReports,
+			"store-tx-resource-reports",
+			defaultConfig.storeTxResourceReports,
+			"whether to enable indexing the transaction resource reports fetched from execution nodes into the db")
 		// Script Execution
 		flags.StringVar(&builder.rpcConf.BackendConfig.ScriptExecutionMode,
 			"script-execution-mode",
@@ -1470,6 +1642,11 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			if builder.executionDataConfig.MaxSearchAhead == 0 {
 				return errors.New("execution-data-max-search-ahead must be greater than 0")
 			}
+			if builder.executionDataSinkDir != "" {
+				if _, err := sink.ParseFormat(builder.executionDataSinkFormat); err != nil {
+					return fmt.Errorf("invalid execution-data-sink-format: %w", err)
+				}
+			}
 		}
 		if builder.stateStreamConf.ListenAddr != "" {
 			if builder.stateStreamConf.ExecutionDataCacheSize == 0 {
@@ -1518,6 +1695,13 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			return errors.New("rest-max-request-size must be greater than 0")
 		}
 
+		if builder.ethRPCConf.ListenAddress != "" && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if ethrpc-addr is set")
+		}
+		if builder.evmIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if evm-indexing-enabled is set")
+		}
+
 		return nil
 	})
 }
@@ -1554,6 +1738,7 @@ func (builder *FlowAccessNodeBuilder) InitIDProviders() {
 			return fmt.Errorf("could not initialize NodeBlockListWrapper: %w", err)
 		}
 		builder.IdentityProvider = disallowListWrapper
+		builder.DisallowListWrapper = disallowListWrapper
 
 		// register the wrapper for dynamic configuration via admin command
 		err = node.ConfigManager.RegisterIdentifierListConfig("network-id-provider-blocklist",
@@ -1825,6 +2010,12 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.EventsIndex = index.NewEventsIndex(builder.Reporter, builder.Storage.Events)
 			return nil
 		}).
+		Module("evm index", func(node *cmd.NodeConfig) error {
+			if builder.evmIndexingEnabled {
+				builder.EVMIndex = bstorage.NewEVMIndex(node.DB)
+			}
+			return nil
+		}).
 		Module("transaction result index", func(node *cmd.NodeConfig) error {
 			builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 			return nil
@@ -1854,6 +2045,35 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 
 			return nil
 		}).
+		Module("script result cache", func(node *cmd.NodeConfig) error {
+			if builder.ScriptResultCacheSize == 0 {
+				return nil
+			}
+
+			var err error
+			builder.ScriptResultCache, err = backend.NewScriptResultCache(
+				builder.ScriptResultCacheSize,
+				builder.ScriptResultCacheMaxBytes,
+				metrics.NewScriptResultCacheCollector(),
+			)
+			if err != nil {
+				return fmt.Errorf("could not create script result cache: %w", err)
+			}
+			return nil
+		}).
+		AdminCommand("read-script-result-cache", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return accessCommands.NewReadScriptResultCacheCommand(builder.ScriptResultCache)
+		}).
+		AdminCommand("flush-script-result-cache", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return accessCommands.NewFlushScriptResultCacheCommand(builder.ScriptResultCache)
+		}).
+		Module("transaction resource reports storage", func(node *cmd.NodeConfig) error {
+			if builder.storeTxResourceReports {
+				builder.Storage.TransactionResourceReports = bstorage.NewTransactionResourceReports(node.DB)
+			}
+
+			return nil
+		}).
 		Component("version control", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			if !builder.versionControlEnabled {
 				noop := &module.NoopReadyDoneAware{}
@@ -2038,6 +2258,8 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				IndexReporter:              indexReporter,
 				VersionControl:             builder.VersionControl,
 				ExecNodeIdentitiesProvider: builder.ExecNodeIdentitiesProvider,
+				TxResourceReports:          node.Storage.TransactionResourceReports,
+				ScriptResultCache:          builder.ScriptResultCache,
 			})
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize backend: %w", err)
@@ -2198,6 +2420,38 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 		})
 	}
 
+	if builder.ethRPCConf.ListenAddress != "" {
+		builder.Component("Ethereum JSON-RPC server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var traces debug.TraceStore
+			if builder.ethRPCConf.TracesDir != "" {
+				tracesDB, err := pstorage.OpenDefaultPebbleDB(builder.ethRPCConf.TracesDir)
+				if err != nil {
+					return nil, fmt.Errorf("could not open evm traces database: %w", err)
+				}
+				builder.ShutdownFunc(func() error {
+					if err := tracesDB.Close(); err != nil {
+						return fmt.Errorf("error closing evm traces database: %w", err)
+					}
+					return nil
+				})
+				traces = debug.NewLocalTraceStore(tracesDB, builder.ethRPCConf.TracesRetention)
+			}
+
+			backend := ethrpc.NewBackend(
+				node.Logger,
+				node.RootChainID,
+				builder.ethRPCConf.MaxCallGasLimit,
+				builder.RegistersAsyncStore,
+				builder.Reporter,
+				node.Storage.Headers,
+				builder.EventsIndex,
+				traces,
+				builder.EVMIndex,
+			)
+			return ethrpc.NewServer(node.Logger, builder.ethRPCConf, backend)
+		})
+	}
+
 	if builder.pingEnabled {
 		builder.Component("ping engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			ping, err := pingeng.New(
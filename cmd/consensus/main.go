@@ -13,6 +13,9 @@ import (
 	client "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go-sdk/crypto"
 
+	"github.com/onflow/flow-go/admin/commands"
+	consensusCommands "github.com/onflow/flow-go/admin/commands/consensus"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
 	"github.com/onflow/flow-go/consensus"
@@ -128,6 +131,8 @@ func main() {
 		committee             *committees.Consensus
 		epochLookup           *epochs.EpochLookup
 		hotstuffModules       *consensus.HotstuffModules
+		participationTracker  *notifications.ParticipationTracker
+		emergencySeals        *bstorage.EmergencySeals
 		dkgState              *bstorage.DKGState
 		safeBeaconKeys        *bstorage.SafeBeaconPrivateKeys
 		getSealingConfigs     module.SealingConfigsGetter
@@ -201,6 +206,12 @@ func main() {
 
 	nodeBuilder.
 		PreInit(cmd.DynamicStartPreInit).
+		AdminCommand("get-hotstuff-participation", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return consensusCommands.NewGetHotstuffParticipationCommand(participationTracker)
+		}).
+		AdminCommand("read-emergency-seals", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadEmergencySealsCommand(emergencySeals)
+		}).
 		ValidateRootSnapshot(badgerState.ValidRootSnapshotContainsEntityExpiryRange).
 		Module("machine account config", func(node *cmd.NodeConfig) error {
 			machineAccountInfo, err = cmd.LoadNodeMachineAccountInfoFile(node.BootstrapDir, node.NodeID)
@@ -222,6 +233,10 @@ func main() {
 			safeBeaconKeys = bstorage.NewSafeBeaconPrivateKeys(dkgState)
 			return nil
 		}).
+		Module("emergency seals storage", func(node *cmd.NodeConfig) error {
+			emergencySeals = bstorage.NewEmergencySeals(node.DB)
+			return nil
+		}).
 		Module("updatable sealing config", func(node *cmd.NodeConfig) error {
 			setter, err := updatable_configs.NewSealingConfigs(
 				requiredApprovalsForSealConstruction,
@@ -458,6 +473,7 @@ func main() {
 				node.Storage.Index,
 				node.State,
 				node.Storage.Seals,
+				emergencySeals,
 				chunkAssigner,
 				seals,
 				getSealingConfigs,
@@ -609,6 +625,16 @@ func main() {
 				return nil, err
 			}
 
+			// track the participation of the individual replicas in votes and timeouts
+			participationTracker = notifications.NewParticipationTracker(
+				logger,
+				metrics.NewHotstuffParticipationCollector(node.RootChainID),
+				committee,
+				epochLookup,
+				finalizedBlock.View,
+			)
+			notifier.AddFinalizationConsumer(participationTracker)
+
 			forks, err := consensus.NewForks(
 				finalizedBlock,
 				node.Storage.Headers,
@@ -625,6 +651,7 @@ func main() {
 			voteAggregationDistributor := pubsub.NewVoteAggregationDistributor()
 			voteAggregationDistributor.AddVoteCollectorConsumer(telemetryConsumer)
 			voteAggregationDistributor.AddVoteAggregationViolationConsumer(slashingViolationConsumer)
+			voteAggregationDistributor.AddVoteAggregationConsumer(participationTracker)
 
 			validator := consensus.NewValidator(mainMetrics, wrappedCommittee)
 			voteProcessorFactory := votecollector.NewCombinedVoteProcessorFactory(wrappedCommittee, voteAggregationDistributor.OnQcConstructedFromVotes)
@@ -646,6 +673,7 @@ func main() {
 			timeoutAggregationDistributor := pubsub.NewTimeoutAggregationDistributor()
 			timeoutAggregationDistributor.AddTimeoutCollectorConsumer(telemetryConsumer)
 			timeoutAggregationDistributor.AddTimeoutAggregationViolationConsumer(slashingViolationConsumer)
+			timeoutAggregationDistributor.AddTimeoutAggregationConsumer(participationTracker)
 
 			timeoutProcessorFactory := timeoutcollector.NewTimeoutProcessorFactory(
 				logger,
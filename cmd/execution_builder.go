@@ -50,6 +50,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
 	txmetrics "github.com/onflow/flow-go/engine/execution/computation/metrics"
+	"github.com/onflow/flow-go/engine/execution/computation/programcache"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	"github.com/onflow/flow-go/engine/execution/ingestion/fetcher"
 	"github.com/onflow/flow-go/engine/execution/ingestion/stop"
@@ -67,6 +68,8 @@ import (
 	ledgerpkg "github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/paging"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	modelbootstrap "github.com/onflow/flow-go/model/bootstrap"
@@ -78,6 +81,7 @@ import (
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	exedataprovider "github.com/onflow/flow-go/module/executiondatasync/provider"
 	"github.com/onflow/flow-go/module/executiondatasync/pruner"
+	"github.com/onflow/flow-go/module/executiondatasync/sink"
 	"github.com/onflow/flow-go/module/executiondatasync/tracker"
 	"github.com/onflow/flow-go/module/finalizedreader"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
@@ -136,6 +140,7 @@ type ExecutionNode struct {
 	events                 *storage.Events
 	serviceEvents          *storage.ServiceEvents
 	txResults              *storage.TransactionResults
+	resourceReports        *storage.TransactionResourceReports
 	results                *storage.ExecutionResults
 	myReceipts             *storage.MyExecutionReceipts
 	providerEngine         exeprovider.ProviderEngine
@@ -145,6 +150,7 @@ type ExecutionNode struct {
 	followerCore           *hotstuff.FollowerLoop        // follower hotstuff logic
 	followerEng            *followereng.ComplianceEngine // to sync blocks from consensus nodes
 	computationManager     *computation.Manager
+	programCache           *programcache.Cache
 	collectionRequester    ingestion.CollectionRequester
 	scriptsEng             *scripts.Engine
 	followerDistributor    *pubsub.FollowerDistributor
@@ -231,7 +237,9 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Component("S3 block data uploader", exeNode.LoadS3BlockDataUploader).
 		Component("transaction execution metrics", exeNode.LoadTransactionExecutionMetrics).
 		Component("provider engine", exeNode.LoadProviderEngine).
+		Component("execution data sink", exeNode.LoadExecutionDataSink).
 		Component("checker engine", exeNode.LoadCheckerEngine).
+		Component("program cache", exeNode.LoadProgramCache).
 		Component("ingestion engine", exeNode.LoadIngestionEngine).
 		Component("scripts engine", exeNode.LoadScriptsEngine).
 		Component("consensus committee", exeNode.LoadConsensusCommittee).
@@ -350,12 +358,16 @@ func (exeNode *ExecutionNode) LoadBlobService(
 		}
 	}
 
+	// Only allow block requests from staked ENs and ANs on the allowedANs list (if set)
+	requestFilter := blob.AuthorizedRequester(allowedANs, exeNode.builder.IdentityProvider, exeNode.builder.Logger)
+	if node.BandwidthAccountant != nil {
+		// reject the block requests of peers that exhausted their blob egress budget
+		requestFilter = node.BandwidthAccountant.BlobRequestFilter(requestFilter)
+	}
+
 	opts := []network.BlobServiceOption{
 		blob.WithBitswapOptions(
-			// Only allow block requests from staked ENs and ANs on the allowedANs list (if set)
-			bitswap.WithPeerBlockRequestFilter(
-				blob.AuthorizedRequester(allowedANs, exeNode.builder.IdentityProvider, exeNode.builder.Logger),
-			),
+			bitswap.WithPeerBlockRequestFilter(requestFilter),
 			bitswap.WithTracer(
 				blob.NewTracer(node.Logger.With().Str("blob_service", channels.ExecutionDataService.String()).Logger()),
 			),
@@ -496,6 +508,60 @@ func (exeNode *ExecutionNode) LoadS3BlockDataUploader(
 	return asyncUploader, nil
 }
 
+func (exeNode *ExecutionNode) LoadExecutionDataSink(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if exeNode.exeConf.executionDataSinkDir == "" {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	format, err := sink.ParseFormat(exeNode.exeConf.executionDataSinkFormat)
+	if err != nil {
+		return nil, err
+	}
+	fileSink, err := sink.NewFileSink(
+		node.Logger,
+		exeNode.exeConf.executionDataSinkDir,
+		format,
+		exeNode.exeConf.executionDataSinkMaxSegmentSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create execution data sink: %w", err)
+	}
+	exeNode.builder.ShutdownFunc(func() error {
+		if err := fileSink.Close(); err != nil {
+			return fmt.Errorf("error closing execution data sink: %w", err)
+		}
+		return nil
+	})
+
+	var sinkMetrics module.ExecutionDataSinkMetrics = metrics.NewNoopCollector()
+	if node.MetricsEnabled {
+		sinkMetrics = metrics.NewExecutionDataSinkCollector()
+	}
+
+	// only the execution data of sealed results is written, so that blocks of abandoned forks are never written
+	writer, err := sink.NewSealedWriter(
+		node.Logger,
+		sinkMetrics,
+		fileSink,
+		node.State,
+		node.Storage.Headers,
+		node.Storage.Seals,
+		node.Storage.Results,
+		exeNode.executionDataStore,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create execution data sink writer: %w", err)
+	}
+	exeNode.followerDistributor.AddOnBlockFinalizedConsumer(writer.OnBlockFinalized)
+
+	return writer, nil
+}
+
 func (exeNode *ExecutionNode) LoadProviderEngine(
 	node *NodeConfig,
 ) (
@@ -536,6 +602,19 @@ func (exeNode *ExecutionNode) LoadProviderEngine(
 				return nil, fmt.Errorf("could not create evm trace uploader: %w", err)
 			}
 		}
+		if len(exeNode.exeConf.evmTracesDir) > 0 {
+			evmTracesDB, err := storagepebble.OpenDefaultPebbleDB(exeNode.exeConf.evmTracesDir)
+			if err != nil {
+				return nil, fmt.Errorf("could not open evm traces database: %w", err)
+			}
+			exeNode.builder.ShutdownFunc(func() error {
+				if err := evmTracesDB.Close(); err != nil {
+					return fmt.Errorf("error closing evm traces database: %w", err)
+				}
+				return nil
+			})
+			evmTraceUploader = debug.NewLocalTraceStore(evmTracesDB, exeNode.exeConf.evmTracesRetention)
+		}
 		evmTracer, err := debug.NewEVMCallTracer(evmTraceUploader, node.Logger)
 		if err != nil {
 			return nil, fmt.Errorf("could not create evm tracer: %w", err)
@@ -580,6 +659,29 @@ func (exeNode *ExecutionNode) LoadProviderEngine(
 	}
 	exeNode.computationManager = manager
 
+	if exeNode.exeConf.programCacheDir != "" {
+		programCacheDB, err := storagepebble.OpenDefaultPebbleDB(exeNode.exeConf.programCacheDir)
+		if err != nil {
+			return nil, fmt.Errorf("could not open program cache database: %w", err)
+		}
+		exeNode.builder.ShutdownFunc(func() error {
+			if err := programCacheDB.Close(); err != nil {
+				return fmt.Errorf("error closing program cache database: %w", err)
+			}
+			return nil
+		})
+		exeNode.programCache = programcache.NewCache(
+			node.Logger,
+			metrics.NewProgramCacheCollector(),
+			programcache.NewStore(programCacheDB),
+			manager.VM(),
+			fvm.NewContextFromParent(vmCtx, computation.DefaultFVMOptions(node.RootChainID, false, false)...),
+			manager.DerivedChainData(),
+			exeNode.latestExecutedState,
+			programcache.DefaultPersistInterval,
+		)
+	}
+
 	if node.ObserverMode {
 		exeNode.providerEngine = &exeprovider.NoopEngine{}
 	} else {
@@ -761,6 +863,7 @@ func (exeNode *ExecutionNode) LoadExecutionState(
 	exeNode.events = storage.NewEvents(node.Metrics.Cache, node.DB)
 	exeNode.serviceEvents = storage.NewServiceEvents(node.Metrics.Cache, node.DB)
 	exeNode.txResults = storage.NewTransactionResults(node.Metrics.Cache, node.DB, exeNode.exeConf.transactionResultsCacheSize)
+	exeNode.resourceReports = storage.NewTransactionResourceReports(node.DB)
 
 	exeNode.executionState = state.NewExecutionState(
 		exeNode.ledgerStorage,
@@ -774,6 +877,7 @@ func (exeNode *ExecutionNode) LoadExecutionState(
 		exeNode.events,
 		exeNode.serviceEvents,
 		exeNode.txResults,
+		exeNode.resourceReports,
 		node.DB,
 		node.Tracer,
 		exeNode.registerStore,
@@ -930,8 +1034,25 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedger(
 		return nil, fmt.Errorf("failed to initialize wal: %w", err)
 	}
 
+	var forestOpts []mtrie.ForestOption
+	if exeNode.exeConf.payloadPagingDir != "" {
+		pager, err := paging.NewPebblePayloadPager(exeNode.exeConf.payloadPagingDir, exeNode.exeConf.payloadPagingCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize payload pager: %w", err)
+		}
+		// the pager is closed after all components have exited, since the compactor
+		// reads payloads when creating a checkpoint on shutdown
+		exeNode.builder.ShutdownFunc(func() error {
+			if err := pager.Close(); err != nil {
+				return fmt.Errorf("error closing payload pager: %w", err)
+			}
+			return nil
+		})
+		forestOpts = append(forestOpts, mtrie.WithPayloadPager(pager))
+	}
+
 	exeNode.ledgerStorage, err = ledger.NewLedger(exeNode.diskWAL, int(exeNode.exeConf.mTrieCacheSize), exeNode.collector, node.Logger.With().Str("subcomponent",
-		"ledger").Logger(), ledger.DefaultPathFinderVersion)
+		"ledger").Logger(), ledger.DefaultPathFinderVersion, forestOpts...)
 	return exeNode.ledgerStorage, err
 }
 
@@ -950,6 +1071,7 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 		exeNode.exeConf.checkpointsToKeep,
 		exeNode.toTriggerCheckpoint, // compactor will listen to the signal from admin tool for force triggering checkpointing
 		exeNode.collector,
+		ledger.WithFullCheckpointInterval(exeNode.exeConf.fullCheckpointInterval),
 	)
 }
 
@@ -1023,6 +1145,34 @@ func (exeNode *ExecutionNode) LoadCheckerEngine(
 	return exeNode.checkerEng, nil
 }
 
+func (exeNode *ExecutionNode) LoadProgramCache(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if exeNode.programCache == nil {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	// the program cache is ready once it is warmed up, hence before the ingestion engine starts executing blocks on
+	// top of the latest executed block
+	return exeNode.programCache, nil
+}
+
+// latestExecutedState returns the header of the highest executed block and a snapshot of the state at the end of it.
+func (exeNode *ExecutionNode) latestExecutedState() (*flow.Header, snapshot.StorageSnapshot, error) {
+	_, blockID, err := exeNode.executionState.GetHighestExecutedBlockID(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get highest executed block: %w", err)
+	}
+	storageSnapshot, header, err := exeNode.executionState.CreateStorageSnapshot(blockID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create storage snapshot at block %v: %w", blockID, err)
+	}
+	return header, storageSnapshot, nil
+}
+
 func (exeNode *ExecutionNode) LoadIngestionEngine(
 	node *NodeConfig,
 ) (
@@ -1330,7 +1480,10 @@ func (exeNode *ExecutionNode) LoadGrpcServer(
 		exeNode.events,
 		exeNode.results,
 		exeNode.txResults,
+		exeNode.resourceReports,
 		node.Storage.Commits,
+		node.Storage.Seals,
+		exeNode.ledgerStorage,
 		exeNode.metricsProvider,
 		node.RootChainID,
 		signature.NewBlockSignerDecoder(exeNode.committee),
@@ -13,7 +13,9 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation/query"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/paging"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/sink"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/utils/grpcutils"
 
@@ -34,6 +36,9 @@ type ExecutionConfig struct {
 	transactionResultsCacheSize           uint
 	checkpointDistance                    uint
 	checkpointsToKeep                     uint
+	fullCheckpointInterval                uint
+	payloadPagingDir                      string
+	payloadPagingCacheSize                int
 	chunkDataPackDir                      string
 	chunkDataPackCacheSize                uint
 	chunkDataPackRequestsCacheSize        uint32
@@ -61,6 +66,15 @@ type ExecutionConfig struct {
 	// evm tracing configuration
 	evmTracingEnabled  bool
 	evmTracesGCPBucket string
+	evmTracesDir       string
+	evmTracesRetention time.Duration
+
+	programCacheDir string
+
+	// execution data sink configuration
+	executionDataSinkDir            string
+	executionDataSinkFormat         string
+	executionDataSinkMaxSegmentSize uint64
 
 	computationConfig        computation.ComputationConfig
 	receiptRequestWorkers    uint   // common provider engine workers
@@ -88,6 +102,9 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.Uint32Var(&exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
 	flags.UintVar(&exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 	flags.UintVar(&exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+	flags.StringVar(&exeConf.payloadPagingDir, "payload-paging-dir", "", "directory to page out register payloads of the execution state to, instead of keeping them in memory. the payloads are stored in its payload-pager subdirectory, which is cleared on startup (empty to keep all payloads in memory)")
+	flags.IntVar(&exeConf.payloadPagingCacheSize, "payload-paging-cache-size", paging.DefaultCacheSize, "number of paged out register payloads to cache in memory, if payload paging is enabled")
+	flags.UintVar(&exeConf.fullCheckpointInterval, "full-checkpoint-interval", 1, "number of checkpoints from one full checkpoint to the next, checkpoints in between are incremental (0 or 1 to only create full checkpoints, the default). incremental checkpoints require their base checkpoints, and are only supported by the tools listed in the docs of wal.StoreIncrementalCheckpoint")
 	flags.UintVar(&exeConf.computationConfig.DerivedDataCacheSize, "cadence-execution-cache", derived.DefaultDerivedDataCacheSize,
 		"cache size for Cadence execution")
 	flags.BoolVar(&exeConf.computationConfig.ExtensiveTracing, "extensive-tracing", false, "adds high-overhead tracing to execution")
@@ -126,8 +143,14 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.IntVar(&exeConf.importCheckpointWorkerCount, "import-checkpoint-worker-count", 10, "number of workers to import checkpoint file during bootstrap")
 	flags.BoolVar(&exeConf.transactionExecutionMetricsEnabled, "tx-execution-metrics", true, "enable collection of transaction execution metrics")
 	flags.UintVar(&exeConf.transactionExecutionMetricsBufferSize, "tx-execution-metrics-buffer-size", 200, "buffer size for transaction execution metrics. The buffer size is the number of blocks that are kept in memory by the metrics provider engine")
-	flags.BoolVar(&exeConf.evmTracingEnabled, "evm-tracing-enabled", false, "enable EVM tracing, when set it will generate traces and upload them to the GCP bucket provided by the --evm-traces-gcp-bucket. Warning: this might affect speed of execution")
+	flags.BoolVar(&exeConf.evmTracingEnabled, "evm-tracing-enabled", false, "enable EVM tracing, when set it will generate traces and upload them to the GCP bucket provided by the --evm-traces-gcp-bucket, or store them in the local database provided by --evm-traces-dir. Warning: this might affect speed of execution")
 	flags.StringVar(&exeConf.evmTracesGCPBucket, "evm-traces-gcp-bucket", "", "define GCP bucket name used for uploading EVM traces, must be used in combination with --evm-tracing-enabled. if left empty the upload step is skipped")
+	flags.StringVar(&exeConf.evmTracesDir, "evm-traces-dir", "", "directory of the local database storing EVM traces, must be used in combination with --evm-tracing-enabled and cannot be combined with --evm-traces-gcp-bucket")
+	flags.DurationVar(&exeConf.evmTracesRetention, "evm-traces-retention", 7*24*time.Hour, "duration for which EVM traces are kept in the local database provided by --evm-traces-dir, 0 keeps them forever")
+	flags.StringVar(&exeConf.programCacheDir, "program-cache-dir", "", "directory of the local database persisting the cached cadence programs, which are derived again on startup. if left empty the cached programs are not persisted")
+	flags.StringVar(&exeConf.executionDataSinkDir, "execution-data-sink-dir", "", "directory to which the execution data of sealed blocks is written as rotating segment files. if left empty the execution data is not written")
+	flags.StringVar(&exeConf.executionDataSinkFormat, "execution-data-sink-format", sink.FormatCBOR.String(), "encoding of the execution data written to --execution-data-sink-dir. One of [cbor, json]")
+	flags.Uint64Var(&exeConf.executionDataSinkMaxSegmentSize, "execution-data-sink-max-segment-size", sink.DefaultMaxSegmentSize, "size in bytes at which the execution data segment files are rotated")
 
 	flags.BoolVar(&exeConf.onflowOnlyLNs, "temp-onflow-only-lns", false, "do not use unless required. forces node to only request collections from onflow collection nodes")
 	flags.BoolVar(&exeConf.enableStorehouse, "enable-storehouse", false, "enable storehouse to store registers on disk, default is false")
@@ -145,6 +168,14 @@ func (exeConf *ExecutionConfig) ValidateFlags() error {
 			return errors.New("invalid flag. gcp-bucket-name or s3-bucket-name required when blockdata-uploader is enabled")
 		}
 	}
+	if exeConf.evmTracesDir != "" && exeConf.evmTracesGCPBucket != "" {
+		return errors.New("invalid flags. evm-traces-dir and evm-traces-gcp-bucket cannot be used together")
+	}
+	if exeConf.executionDataSinkDir != "" {
+		if _, err := sink.ParseFormat(exeConf.executionDataSinkFormat); err != nil {
+			return fmt.Errorf("invalid flag. execution-data-sink-format: %w", err)
+		}
+	}
 	if exeConf.executionDataAllowedPeers != "" {
 		ids := strings.Split(exeConf.executionDataAllowedPeers, ",")
 		for _, id := range ids {
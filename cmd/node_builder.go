@@ -22,8 +22,10 @@ import (
 	"github.com/onflow/flow-go/module/profiler"
 	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/bandwidth"
 	"github.com/onflow/flow-go/network/codec/cbor"
 	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/p2p/cache"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/events"
 	bstorage "github.com/onflow/flow-go/storage/badger"
@@ -218,6 +220,8 @@ type NodeConfig struct {
 	IdentityProvider             module.IdentityProvider
 	IDTranslator                 p2p.IDTranslator
 	SyncEngineIdentifierProvider module.IdentifierProvider
+	// DisallowListWrapper holds the operator-managed disallow-list, it wraps the identity provider of the node.
+	DisallowListWrapper *cache.NodeDisallowListingWrapper
 
 	// root state information
 	RootSnapshot protocol.Snapshot
@@ -229,6 +233,8 @@ type NodeConfig struct {
 
 	// UnicastRateLimiterDistributor notifies consumers when a peer's unicast message is rate limited.
 	UnicastRateLimiterDistributor p2p.UnicastRateLimiterDistributor
+	// BandwidthAccountant tracks the bandwidth per channel and peer, it is nil if the bandwidth accounting is disabled.
+	BandwidthAccountant *bandwidth.Accountant
 }
 
 // StateExcerptAtBoot stores information about the root snapshot and latest finalized block for use in bootstrapping.
@@ -40,6 +40,7 @@ import (
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/apiproxy"
+	"github.com/onflow/flow-go/engine/access/ethrpc"
 	"github.com/onflow/flow-go/engine/access/index"
 	"github.com/onflow/flow-go/engine/access/rest"
 	restapiproxy "github.com/onflow/flow-go/engine/access/rest/apiproxy"
@@ -58,6 +59,7 @@ import (
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/common/version"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/fvm/evm/debug"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -137,6 +139,7 @@ type ObserverServiceConfig struct {
 	apiRatelimits                        map[string]int
 	apiBurstlimits                       map[string]int
 	rpcConf                              rpc.Config
+	ethRPCConf                           ethrpc.Config
 	rpcMetricsEnabled                    bool
 	registersDBPath                      string
 	checkpointFile                       string
@@ -153,6 +156,7 @@ type ObserverServiceConfig struct {
 	logTxTimeToSealed                    bool
 	executionDataSyncEnabled             bool
 	executionDataIndexingEnabled         bool
+	evmIndexingEnabled                   bool
 	executionDataDBMode                  string
 	executionDataPrunerHeightRangeTarget uint64
 	executionDataPrunerThreshold         uint64
@@ -203,6 +207,7 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 			MaxMsgSize:     grpcutils.DefaultMaxMsgSize,
 			CompressorName: grpcutils.NoCompressor,
 		},
+		ethRPCConf: ethrpc.DefaultConfig(),
 		stateStreamConf: statestreambackend.Config{
 			MaxExecutionDataMsgSize: grpcutils.DefaultMaxMsgSize,
 			ExecutionDataCacheSize:  subscription.DefaultCacheSize,
@@ -231,6 +236,7 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 		logTxTimeToSealed:                    false,
 		executionDataSyncEnabled:             false,
 		executionDataIndexingEnabled:         false,
+		evmIndexingEnabled:                   false,
 		executionDataDBMode:                  execution_data.ExecutionDataDBModeBadger.String(),
 		executionDataPrunerHeightRangeTarget: 0,
 		executionDataPrunerThreshold:         pruner.DefaultThreshold,
@@ -294,6 +300,7 @@ type ObserverServiceBuilder struct {
 	RegistersAsyncStore *execution.RegistersAsyncStore
 	Reporter            *index.Reporter
 	EventsIndex         *index.EventsIndex
+	EVMIndex            storage.EVMIndex
 	ScriptExecutor      *backend.ScriptExecutor
 
 	// available until after the network has started. Hence, a factory function that needs to be called just before
@@ -679,6 +686,10 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.evmIndexingEnabled,
+			"evm-indexing-enabled",
+			defaultConfig.evmIndexingEnabled,
+			"whether to index the EVM blocks, transactions and receipts from the EVM events. when set, the Ethereum JSON-RPC lookups by block hash, transaction hash and log filter are enabled. requires execution-data-indexing-enabled")
 		flags.BoolVar(&builder.versionControlEnabled,
 			"version-control-enabled",
 			defaultConfig.versionControlEnabled,
@@ -690,6 +701,28 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 		flags.BoolVar(&builder.localServiceAPIEnabled, "local-service-api-enabled", defaultConfig.localServiceAPIEnabled, "whether to use local indexed data for api queries")
 		flags.StringVar(&builder.registersDBPath, "execution-state-dir", defaultConfig.registersDBPath, "directory to use for execution-state database")
 		flags.StringVar(&builder.checkpointFile, "execution-state-checkpoint", defaultConfig.checkpointFile, "execution-state checkpoint file")
+
+		// Ethereum JSON-RPC server
+		flags.StringVar(&builder.ethRPCConf.ListenAddress,
+			"ethrpc-addr",
+			defaultConfig.ethRPCConf.ListenAddress,
+			"the address the Ethereum JSON-RPC server listens on, the server is disabled if empty. requires execution-data-indexing-enabled")
+		flags.Uint64Var(&builder.ethRPCConf.MaxCallGasLimit,
+			"ethrpc-max-call-gas-limit",
+			defaultConfig.ethRPCConf.MaxCallGasLimit,
+			"gas limit cap of eth_call and eth_estimateGas")
+		flags.IntVar(&builder.ethRPCConf.BatchRequestLimit,
+			"ethrpc-batch-request-limit",
+			defaultConfig.ethRPCConf.BatchRequestLimit,
+			"maximum number of requests in an Ethereum JSON-RPC batch")
+		flags.StringVar(&builder.ethRPCConf.TracesDir,
+			"ethrpc-traces-dir",
+			defaultConfig.ethRPCConf.TracesDir,
+			"directory of the local database storing EVM call traces. when set, the debug_traceTransaction and debug_traceBlockByNumber methods are enabled, and missing traces are regenerated by replaying the EVM blocks")
+		flags.DurationVar(&builder.ethRPCConf.TracesRetention,
+			"ethrpc-traces-retention",
+			defaultConfig.ethRPCConf.TracesRetention,
+			"duration for which EVM call traces are kept in the database provided by --ethrpc-traces-dir, 0 keeps them forever")
 		flags.StringVar(&builder.executionDataDBMode,
 			"execution-data-db",
 			defaultConfig.executionDataDBMode,
@@ -864,6 +897,13 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			return errors.New("rest-max-request-size must be greater than 0")
 		}
 
+		if builder.ethRPCConf.ListenAddress != "" && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if ethrpc-addr is set")
+		}
+		if builder.evmIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if evm-indexing-enabled is set")
+		}
+
 		return nil
 	})
 }
@@ -921,12 +961,14 @@ func (builder *ObserverServiceBuilder) InitIDProviders() {
 
 		// The following wrapper allows to black-list byzantine nodes via an admin command:
 		// the wrapper overrides the 'Ejected' flag of disallow-listed nodes to true
-		builder.IdentityProvider, err = cache.NewNodeDisallowListWrapper(idCache, node.DB, func() network.DisallowListNotificationConsumer {
+		disallowListWrapper, err := cache.NewNodeDisallowListWrapper(idCache, node.DB, func() network.DisallowListNotificationConsumer {
 			return builder.NetworkUnderlay
 		})
 		if err != nil {
 			return fmt.Errorf("could not initialize NodeBlockListWrapper: %w", err)
 		}
+		builder.IdentityProvider = disallowListWrapper
+		builder.DisallowListWrapper = disallowListWrapper
 
 		// use the default identifier provider
 		builder.SyncEngineParticipantsProviderFactory = func() module.IdentifierProvider {
@@ -1399,6 +1441,12 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 			}
 
 			var collectionExecutedMetric module.CollectionExecutedMetric = metrics.NewNoopCollector()
+
+			var evmIndexer *indexer.EVMIndexer
+			if builder.EVMIndex != nil {
+				evmIndexer = indexer.NewEVMIndexer(builder.Logger, builder.RootChainID, builder.EVMIndex)
+			}
+
 			indexerCore, err := indexer.New(
 				builder.Logger,
 				metrics.NewExecutionStateIndexerCollector(),
@@ -1412,6 +1460,7 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				builder.RootChainID.Chain(),
 				indexerDerivedChainData,
 				collectionExecutedMetric,
+				evmIndexer,
 			)
 			if err != nil {
 				return nil, err
@@ -1661,6 +1710,11 @@ func (builder *ObserverServiceBuilder) enqueuePublicNetworkInit() {
 			builder.ProtocolEvents.AddConsumer(idEvents)
 
 			return builder.EngineRegistry, nil
+		}).
+		// the disallow-list wrapper is started after the network, so that it re-applies the persisted
+		// disallow-list to the network on startup.
+		Component("disallow list", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			return builder.DisallowListWrapper, nil
 		})
 }
 
@@ -1767,6 +1821,12 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 		builder.EventsIndex = index.NewEventsIndex(builder.Reporter, builder.Storage.Events)
 		return nil
 	})
+	builder.Module("evm index", func(node *cmd.NodeConfig) error {
+		if builder.evmIndexingEnabled {
+			builder.EVMIndex = bstorage.NewEVMIndex(node.DB)
+		}
+		return nil
+	})
 	builder.Module("transaction result index", func(node *cmd.NodeConfig) error {
 		builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 		return nil
@@ -2015,6 +2075,38 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			return builder.unsecureGrpcServer, nil
 		})
 	}
+
+	if builder.ethRPCConf.ListenAddress != "" {
+		builder.Component("Ethereum JSON-RPC server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var traces debug.TraceStore
+			if builder.ethRPCConf.TracesDir != "" {
+				tracesDB, err := pstorage.OpenDefaultPebbleDB(builder.ethRPCConf.TracesDir)
+				if err != nil {
+					return nil, fmt.Errorf("could not open evm traces database: %w", err)
+				}
+				builder.ShutdownFunc(func() error {
+					if err := tracesDB.Close(); err != nil {
+						return fmt.Errorf("error closing evm traces database: %w", err)
+					}
+					return nil
+				})
+				traces = debug.NewLocalTraceStore(tracesDB, builder.ethRPCConf.TracesRetention)
+			}
+
+			backend := ethrpc.NewBackend(
+				node.Logger,
+				node.RootChainID,
+				builder.ethRPCConf.MaxCallGasLimit,
+				builder.RegistersAsyncStore,
+				builder.Reporter,
+				node.Storage.Headers,
+				builder.EventsIndex,
+				traces,
+				builder.EVMIndex,
+			)
+			return ethrpc.NewServer(node.Logger, builder.ethRPCConf, backend)
+		})
+	}
 }
 
 func loadNetworkingKey(path string) (crypto.PrivateKey, error) {
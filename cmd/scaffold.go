@@ -29,6 +29,7 @@ import (
 	"github.com/onflow/flow-go/admin"
 	"github.com/onflow/flow-go/admin/commands"
 	"github.com/onflow/flow-go/admin/commands/common"
+	networkCommands "github.com/onflow/flow-go/admin/commands/network"
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd/build"
 	"github.com/onflow/flow-go/config"
@@ -51,6 +52,7 @@ import (
 	"github.com/onflow/flow-go/module/util"
 	"github.com/onflow/flow-go/network"
 	alspmgr "github.com/onflow/flow-go/network/alsp/manager"
+	"github.com/onflow/flow-go/network/bandwidth"
 	netcache "github.com/onflow/flow-go/network/cache"
 	"github.com/onflow/flow-go/network/channels"
 	"github.com/onflow/flow-go/network/converter"
@@ -70,6 +72,7 @@ import (
 	"github.com/onflow/flow-go/network/p2p/unicast/ratelimit"
 	"github.com/onflow/flow-go/network/p2p/utils"
 	"github.com/onflow/flow-go/network/p2p/utils/ratelimiter"
+	"github.com/onflow/flow-go/network/recorder"
 	"github.com/onflow/flow-go/network/slashing"
 	"github.com/onflow/flow-go/network/topology"
 	"github.com/onflow/flow-go/network/underlay"
@@ -405,6 +408,31 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 		ConnectorFactory:  connection.DefaultLibp2pBackoffConnectorFactory(),
 	}
 
+	// the bandwidth accountant is created before the libp2p node, as it is the bandwidth reporter of the libp2p host.
+	fnb.Module("bandwidth accountant", func(node *NodeConfig) error {
+		accountingConfig := fnb.FlowConfig.NetworkConfig.BandwidthAccounting
+		if !accountingConfig.Enabled {
+			return nil
+		}
+		accountant, err := bandwidth.NewAccountant(fnb.Logger, node.IdentityProvider, bandwidth.Config{
+			Window:           accountingConfig.Window,
+			BucketInterval:   accountingConfig.BucketInterval,
+			BlobEgressBudget: accountingConfig.BlobEgressBudget,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create bandwidth accountant: %w", err)
+		}
+		node.BandwidthAccountant = accountant
+		return nil
+	})
+
+	fnb.Component("bandwidth accountant", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		if node.BandwidthAccountant == nil {
+			return &module.NoopReadyDoneAware{}, nil
+		}
+		return node.BandwidthAccountant, nil
+	})
+
 	fnb.Component(LibP2PNodeComponent, func(node *NodeConfig) (module.ReadyDoneAware, error) {
 		myAddr := fnb.NodeConfig.Me.Address()
 		if fnb.BaseConfig.BindAddr != NotSet {
@@ -457,6 +485,9 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 		if err != nil {
 			return nil, fmt.Errorf("could not create libp2p node builder: %w", err)
 		}
+		if node.BandwidthAccountant != nil {
+			builder.SetBandwidthReporter(node.BandwidthAccountant)
+		}
 
 		libp2pNode, err := builder.Build()
 		if err != nil {
@@ -475,6 +506,12 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 			peerManagerFilters)
 	})
 
+	// the disallow-list wrapper is started after the network, so that it re-applies the persisted
+	// disallow-list to the network on startup.
+	fnb.Component("disallow list", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		return node.DisallowListWrapper, nil
+	})
+
 	fnb.Module("epoch transition logger", func(node *NodeConfig) error {
 		node.ProtocolEvents.AddConsumer(events.NewEventLogger(node.Logger))
 		return nil
@@ -542,7 +579,7 @@ func (fnb *FlowNodeBuilder) BuildPublicLibp2pNode(address string, bootstrapIdent
 		pis = append(pis, pi)
 	}
 
-	node, err := p2pbuilder.NewNodeBuilder(
+	builder := p2pbuilder.NewNodeBuilder(
 		fnb.Logger,
 		&fnb.FlowConfig.NetworkConfig.GossipSub,
 		&p2pbuilderconfig.MetricsConfig{
@@ -575,9 +612,12 @@ func (fnb *FlowNodeBuilder) BuildPublicLibp2pNode(address string, bootstrapIdent
 				p2pdht.AsClient(),
 				dht.BootstrapPeers(pis...),
 			)
-		}).
-		Build()
+		})
+	if fnb.BandwidthAccountant != nil {
+		builder.SetBandwidthReporter(fnb.BandwidthAccountant)
+	}
 
+	node, err := builder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize libp2p node for observer: %w", err)
 	}
@@ -608,6 +648,25 @@ func (fnb *FlowNodeBuilder) InitFlowNetworkWithConduitFactory(
 		networkOptions = append(networkOptions, underlay.WithPeerManagerFilters(peerManagerFilters...))
 	}
 
+	// the message recorder is a debugging aid, which is only enabled when a directory is configured.
+	if recorderConfig := fnb.FlowConfig.NetworkConfig.MessageRecorder; recorderConfig.Dir != "" {
+		messageRecorder, err := recorder.NewFileRecorder(fnb.Logger, recorder.FileRecorderConfig{
+			Dir:         recorderConfig.Dir,
+			MaxFileSize: recorderConfig.MaxFileSize,
+			MaxFiles:    recorderConfig.MaxFiles,
+			QueueSize:   recorderConfig.QueueSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not create network message recorder: %w", err)
+		}
+		networkOptions = append(networkOptions, underlay.WithMessageRecorder(messageRecorder))
+		fnb.Logger.Warn().Str("dir", recorderConfig.Dir).Msg("network message recorder enabled, all network messages are recorded on disk")
+	}
+
+	if node.BandwidthAccountant != nil {
+		networkOptions = append(networkOptions, underlay.WithBandwidthAccountant(node.BandwidthAccountant))
+	}
+
 	receiveCache := netcache.NewHeroReceiveCache(fnb.FlowConfig.NetworkConfig.NetworkReceivedMessageCacheSize,
 		fnb.Logger,
 		metrics.NetworkReceiveCacheMetricsFactory(fnb.HeroCacheMetricsFactory(), network.PrivateNetwork))
@@ -1232,6 +1291,7 @@ func (fnb *FlowNodeBuilder) InitIDProviders() {
 			return fmt.Errorf("could not initialize NodeBlockListWrapper: %w", err)
 		}
 		node.IdentityProvider = disallowListWrapper
+		node.DisallowListWrapper = disallowListWrapper
 
 		if node.ObserverMode {
 			// identifier providers decides which node to connect to when syncing blocks,
@@ -2000,9 +2060,36 @@ func (fnb *FlowNodeBuilder) RegisterDefaultAdminCommands() {
 		return storageCommands.NewReadSealsCommand(config.State, config.Storage.Seals, config.Storage.Index)
 	}).AdminCommand("get-latest-identity", func(config *NodeConfig) commands.AdminCommand {
 		return common.NewGetIdentityCommand(config.IdentityProvider)
+	}).AdminCommand("peer-report", func(config *NodeConfig) commands.AdminCommand {
+		return networkCommands.NewPeerReportCommand(config.LibP2PNode, config.NetworkUnderlay, config.IdentityProvider, config.IDTranslator)
+	}).AdminCommand("add-disallow-list-entry", func(config *NodeConfig) commands.AdminCommand {
+		return networkCommands.NewAddDisallowListEntryCommand(disallowList(config), config.IDTranslator)
+	}).AdminCommand("remove-disallow-list-entry", func(config *NodeConfig) commands.AdminCommand {
+		return networkCommands.NewRemoveDisallowListEntryCommand(disallowList(config), config.IDTranslator)
+	}).AdminCommand("read-disallow-list", func(config *NodeConfig) commands.AdminCommand {
+		return networkCommands.NewReadDisallowListCommand(disallowList(config), config.IDTranslator)
+	}).AdminCommand("bandwidth-top", func(config *NodeConfig) commands.AdminCommand {
+		return networkCommands.NewBandwidthTopCommand(bandwidthUsage(config), config.IdentityProvider)
 	})
 }
 
+// bandwidthUsage returns the bandwidth accountant of the node for the admin commands, or nil if the bandwidth
+// accounting is disabled.
+func bandwidthUsage(config *NodeConfig) networkCommands.BandwidthUsage {
+	if config.BandwidthAccountant == nil {
+		return nil
+	}
+	return config.BandwidthAccountant
+}
+
+// disallowList returns the disallow-list of the node for the admin commands, or nil if the node has none.
+func disallowList(config *NodeConfig) networkCommands.DisallowList {
+	if config.DisallowListWrapper == nil {
+		return nil
+	}
+	return config.DisallowListWrapper
+}
+
 func (fnb *FlowNodeBuilder) Build() (Node, error) {
 	// Run the prestart initialization. This includes anything that should be done before
 	// starting the components.
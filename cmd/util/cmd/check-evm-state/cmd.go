@@ -0,0 +1,124 @@
+package check_evm_state
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/onflow/atree"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	evm_exporter "github.com/onflow/flow-go/cmd/util/cmd/export-evm-state"
+	"github.com/onflow/flow-go/fvm/evm/emulator/state"
+	"github.com/onflow/flow-go/fvm/evm/handler"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var (
+	flagChain             string
+	flagExecutionStateDir string
+	flagStateCommitment   string
+	flagEVMStateGobDir    string
+	flagEVMStateGobHeight uint64
+	flagOutputDir         string
+)
+
+// usage example
+//
+//	./util check-evm-state --chain flow-mainnet --execution-state-dir /var/flow/data/execution --state-commitment 4c9e...
+//	./util check-evm-state --chain flow-testnet --evm_state_gob_dir /var/flow/data/evm_state_gob --evm_state_gob_height 211176670
+var Cmd = &cobra.Command{
+	Use:   "check-evm-state",
+	Short: "checks the integrity of the evm state and computes its Ethereum state root",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagChain, "chain", "", "Chain name")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs are written")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"State commitment (hex-encoded, 64 characters)")
+
+	Cmd.Flags().StringVar(&flagEVMStateGobDir, "evm_state_gob_dir", "/var/flow/data/evm_state_gob",
+		"directory that stores the evm state gob files as checkpoint")
+
+	Cmd.Flags().Uint64Var(&flagEVMStateGobHeight, "evm_state_gob_height", 0,
+		"the flow height of the evm state gob files")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory to export the checked evm state to, the state is not exported if empty")
+}
+
+func run(*cobra.Command, []string) {
+	var ledger atree.Ledger
+	var storageRoot flow.Address
+	var err error
+	if flagExecutionStateDir != "" {
+		ledger, storageRoot, err = evm_exporter.LedgerFromCheckpoint(flagChain, flagExecutionStateDir, flagStateCommitment)
+	} else {
+		ledger, storageRoot, err = evm_exporter.LedgerFromGob(flagChain, flagEVMStateGobDir, flagEVMStateGobHeight)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot read evm state")
+	}
+
+	report, err := CheckEVMState(ledger, storageRoot)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot check evm state")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatal().Err(err).Msg("cannot print report")
+	}
+
+	if flagOutputDir != "" {
+		err := evm_exporter.ExportEVMStateFromPayloads(ledger, storageRoot, flagOutputDir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot export evm state")
+		}
+	}
+
+	if len(report.Issues) > 0 {
+		log.Fatal().Int("issues", len(report.Issues)).Msg("evm state is inconsistent")
+	}
+	log.Info().Str("state_root", report.StateRoot.String()).Msg("evm state is consistent")
+}
+
+// CheckEVMState checks the integrity of the EVM state stored in the given ledger. The balances are expected to sum
+// to the total supply of the latest EVM block, which is the FLOW locked in the EVM.
+func CheckEVMState(ledger atree.Ledger, storageRoot flow.Address) (*state.IntegrityReport, error) {
+	baseView, err := state.NewBaseView(ledger, storageRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base view: %w", err)
+	}
+	st, err := state.Extract(storageRoot, baseView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract state: %w", err)
+	}
+
+	var totalSupply *big.Int
+	encoded, err := ledger.GetValue(storageRoot[:], []byte(handler.BlockStoreLatestBlockKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read latest block: %w", err)
+	}
+	if len(encoded) > 0 {
+		block, err := types.NewBlockFromBytes(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode latest block: %w", err)
+		}
+		totalSupply = block.TotalSupply
+	} else {
+		log.Warn().Msg("latest evm block not found, the total balance is not checked")
+	}
+
+	return state.CheckIntegrity(st, totalSupply)
+}
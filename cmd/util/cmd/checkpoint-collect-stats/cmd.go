@@ -15,12 +15,8 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/atomic"
 
-	"github.com/onflow/atree"
-
 	"github.com/onflow/flow-go/cmd/util/ledger/reporters"
 	"github.com/onflow/flow-go/cmd/util/ledger/util"
-	"github.com/onflow/flow-go/fvm/evm/emulator/state"
-	"github.com/onflow/flow-go/fvm/evm/handler"
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
@@ -48,17 +44,10 @@ const (
 )
 
 const (
-	domainTypePrefix         = "domain "
 	payloadChannelBufferSize = 100_000
 	initialAccountMapSize    = 5_000_000
 )
 
-const (
-	// EVM register keys from fvm/evm/handler/blockHashList.go
-	blockHashListMetaKey         = "BlockHashListMeta"
-	blockHashListBucketKeyPrefix = "BlockHashListBucket"
-)
-
 var Cmd = &cobra.Command{
 	Use:   "checkpoint-collect-stats",
 	Short: "collects stats on tries stored in a checkpoint, or payloads from a payloads file",
@@ -503,61 +492,15 @@ func writeStats(reportName string, stats interface{}) {
 }
 
 func isDomainType(typ string) bool {
-	return strings.HasPrefix(typ, domainTypePrefix)
+	return strings.HasPrefix(typ, util.DomainRegisterTypePrefix)
 }
 
 func getType(key ledger.Key) string {
-	k := key.KeyParts[1].Value
-	kstr := string(k)
-
-	if atree.LedgerKeyIsSlabKey(kstr) {
-		return "atree slab"
-	}
-
-	isDomain := slices.Contains(util.StorageMapDomains, kstr)
-	if isDomain {
-		return domainTypePrefix + kstr
-	}
-
-	switch kstr {
-	case flow.ContractNamesKey:
-		return "contract names"
-	case flow.AccountStatusKey:
-		return "account status"
-	case flow.AddressStateKey:
-		return "address generator state"
-	case state.AccountsStorageIDKey:
-		return "account storage ID"
-	case state.CodesStorageIDKey:
-		return "code storage ID"
-	case handler.BlockStoreLatestBlockKey:
-		return "latest block"
-	case handler.BlockStoreLatestBlockProposalKey:
-		return "latest block proposal"
+	typ := util.RegisterType(key)
+	if typ == util.OtherRegisterType {
+		log.Warn().Msgf("unknown payload key: %s", string(key.KeyParts[1].Value))
 	}
-
-	// other fvm registers
-	if kstr == "uuid" || strings.HasPrefix(kstr, "uuid_") {
-		return "uuid generator state"
-	}
-	if strings.HasPrefix(kstr, "public_key_") {
-		return "public key"
-	}
-	if strings.HasPrefix(kstr, flow.CodeKeyPrefix) {
-		return "contract content"
-	}
-
-	// other evm registers
-	if strings.HasPrefix(kstr, blockHashListBucketKeyPrefix) {
-		return "block hash list bucket"
-	}
-	if strings.HasPrefix(kstr, blockHashListMetaKey) {
-		return "block hash list meta"
-	}
-
-	log.Warn().Msgf("unknown payload key: %s", kstr)
-
-	return "others"
+	return typ
 }
 
 func serviceAccountAddressForChain(chainID flow.ChainID) flow.Address {
@@ -2,12 +2,19 @@ package debug_tx
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/onflow/cadence/common"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	sdk "github.com/onflow/flow-go-sdk"
 
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/grpcclient"
 	"github.com/onflow/flow-go/utils/debug"
@@ -24,12 +31,22 @@ var (
 	flagComputeLimit     uint64
 	flagAtLatestBlock    bool
 	flagProposalKeySeq   uint64
+	flagScriptFile       string
+	flagContractFiles    []string
+	flagCacheDir         string
+	flagOffline          bool
 )
 
 var Cmd = &cobra.Command{
 	Use:   "debug-tx",
 	Short: "debug a transaction",
-	Run:   run,
+	Long: `debug a transaction by re-running it against the registers of an execution node,
+and print its result, events, computation per kind, and the register changes it produced.
+
+The transaction script and deployed contracts can be replaced with modified Cadence code, which is run
+against the same registers. If a cache directory is given, the transaction and the fetched registers are
+cached, so that later runs can be done offline.`,
+	Run: run,
 }
 
 func init() {
@@ -42,11 +59,9 @@ func init() {
 	)
 	_ = Cmd.MarkFlagRequired("chain")
 
-	Cmd.Flags().StringVar(&flagAccessAddress, "access-address", "", "address of the access node")
-	_ = Cmd.MarkFlagRequired("access-address")
+	Cmd.Flags().StringVar(&flagAccessAddress, "access-address", "", "address of the access node, not required when running offline")
 
-	Cmd.Flags().StringVar(&flagExecutionAddress, "execution-address", "", "address of the execution node")
-	_ = Cmd.MarkFlagRequired("execution-address")
+	Cmd.Flags().StringVar(&flagExecutionAddress, "execution-address", "", "address of the execution node, not required when running offline")
 
 	Cmd.Flags().StringVar(&flagTx, "tx", "", "transaction ID")
 	_ = Cmd.MarkFlagRequired("tx")
@@ -56,6 +71,21 @@ func init() {
 	Cmd.Flags().BoolVar(&flagAtLatestBlock, "at-latest-block", false, "run at latest block")
 
 	Cmd.Flags().Uint64Var(&flagProposalKeySeq, "proposal-key-seq", 0, "proposal key sequence number")
+
+	Cmd.Flags().StringVar(&flagScriptFile, "script-file", "", "path to a file with Cadence code replacing the transaction script")
+
+	Cmd.Flags().StringArrayVar(&flagContractFiles, "contract-file", nil,
+		"replace the code of a deployed contract, in the format <address>.<name>=<path>, can be repeated")
+
+	Cmd.Flags().StringVar(&flagCacheDir, "cache-dir", "", "directory to cache the transaction and the fetched registers in")
+
+	Cmd.Flags().BoolVar(&flagOffline, "offline", false, "run only from the cache directory, without connecting to any node")
+}
+
+// cachedTransaction is the transaction cached in the cache directory, with the block it is run at.
+type cachedTransaction struct {
+	BlockID     flow.Identifier
+	Transaction *flow.TransactionBody
 }
 
 func run(*cobra.Command, []string) {
@@ -68,6 +98,106 @@ func run(*cobra.Command, []string) {
 		log.Fatal().Err(err).Msg("failed to parse transaction ID")
 	}
 
+	if flagOffline && flagCacheDir == "" {
+		log.Fatal().Msg("--cache-dir is required when running offline")
+	}
+	if !flagOffline && (flagAccessAddress == "" || flagExecutionAddress == "") {
+		log.Fatal().Msg("--access-address and --execution-address are required when not running offline")
+	}
+
+	var cached *cachedTransaction
+	var preState snapshot.StorageSnapshot
+	var registerCache *debug.FileRegisterCache
+
+	if flagOffline {
+		cached, err = readCachedTransaction(txID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read cached transaction")
+		}
+
+		registerCache, err = debug.NewFileRegisterCache(registersCachePath(cached.BlockID))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read cached registers")
+		}
+		preState = debug.NewCachedStorageSnapshot(registerCache)
+	} else {
+		cached = fetchTransaction(txID)
+
+		var opts []debug.RemoteStorageSnapshotOption
+		if !flagAtLatestBlock {
+			opts = append(opts, debug.WithBlockID(cached.BlockID))
+		}
+		remoteSnapshot := debug.NewRemoteStorageSnapshot(flagExecutionAddress, opts...)
+		defer remoteSnapshot.Close()
+		cached.BlockID = flow.HashToID(remoteSnapshot.BlockID)
+
+		if flagCacheDir != "" {
+			err = writeCachedTransaction(txID, cached)
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to cache transaction")
+			}
+
+			registerCache, err = debug.NewFileRegisterCache(registersCachePath(cached.BlockID))
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to read cached registers")
+			}
+			remoteSnapshot.Cache = registerCache
+		}
+		preState = remoteSnapshot
+	}
+
+	overrides, err := readContractOverrides()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read contract files")
+	}
+	if len(overrides) > 0 {
+		preState = snapshot.NewSnapshotTree(preState).Append(&snapshot.ExecutionSnapshot{WriteSet: overrides})
+	}
+
+	txBody := cached.Transaction
+	txBody.SetComputeLimit(flagComputeLimit)
+	if flagProposalKeySeq != 0 {
+		txBody.ProposalKey.SequenceNumber = flagProposalKeySeq
+	}
+	if flagScriptFile != "" {
+		script, err := os.ReadFile(flagScriptFile)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed to read script from file %s", flagScriptFile)
+		}
+		txBody.SetScript(script)
+	}
+
+	log.Info().Msgf("Debugging transaction at block %s ...", cached.BlockID)
+
+	debugger := debug.NewRemoteDebugger(
+		flagExecutionAddress,
+		chain,
+		log.Logger,
+	)
+
+	replay, err := debugger.ReplayTransaction(txBody, preState)
+	if err != nil {
+		log.Fatal().Err(err).Msg("process error")
+	}
+
+	// computing the changes reads the previous values of the written registers, which must be cached as well
+	changes, err := replay.RegisterChanges()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to compute register changes")
+	}
+
+	if registerCache != nil && !flagOffline {
+		err = registerCache.Persist()
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to cache registers")
+		}
+	}
+
+	printReplay(txID, cached.BlockID, replay, changes)
+}
+
+// fetchTransaction fetches the transaction and the block it was executed in from the access node.
+func fetchTransaction(txID flow.Identifier) *cachedTransaction {
 	config, err := grpcclient.NewFlowClientConfig(flagAccessAddress, "", flow.ZeroID, true)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create flow client config")
@@ -96,17 +226,9 @@ func run(*cobra.Command, []string) {
 
 	log.Info().Msgf("Fetched transaction result: %s at block %s", txResult.Status, txResult.BlockID)
 
-	log.Info().Msg("Debugging transaction ...")
-
-	debugger := debug.NewRemoteDebugger(
-		flagExecutionAddress,
-		chain,
-		log.Logger,
-	)
-
 	txBody := flow.NewTransactionBody().
 		SetScript(tx.Script).
-		SetComputeLimit(flagComputeLimit).
+		SetComputeLimit(tx.GasLimit).
 		SetPayer(flow.Address(tx.Payer))
 
 	for _, argument := range tx.Arguments {
@@ -117,31 +239,123 @@ func run(*cobra.Command, []string) {
 		txBody.AddAuthorizer(flow.Address(authorizer))
 	}
 
-	proposalKeySequenceNumber := tx.ProposalKey.SequenceNumber
-	if flagProposalKeySeq != 0 {
-		proposalKeySequenceNumber = flagProposalKeySeq
-	}
-
 	txBody.SetProposalKey(
 		flow.Address(tx.ProposalKey.Address),
 		tx.ProposalKey.KeyIndex,
-		proposalKeySequenceNumber,
+		tx.ProposalKey.SequenceNumber,
 	)
 
-	var txErr, processErr error
-	if flagAtLatestBlock {
-		txErr, processErr = debugger.RunTransaction(txBody)
+	return &cachedTransaction{
+		BlockID:     flow.Identifier(txResult.BlockID),
+		Transaction: txBody,
+	}
+}
+
+func transactionCachePath(txID flow.Identifier) string {
+	return filepath.Join(flagCacheDir, fmt.Sprintf("%s.tx.json", txID))
+}
+
+func registersCachePath(blockID flow.Identifier) string {
+	return filepath.Join(flagCacheDir, fmt.Sprintf("%s.registers", blockID))
+}
+
+func readCachedTransaction(txID flow.Identifier) (*cachedTransaction, error) {
+	data, err := os.ReadFile(transactionCachePath(txID))
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedTransaction
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode cached transaction: %w", err)
+	}
+	return &cached, nil
+}
+
+func writeCachedTransaction(txID flow.Identifier, cached *cachedTransaction) error {
+	err := os.MkdirAll(flagCacheDir, 0755)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("could not encode transaction: %w", err)
+	}
+	return os.WriteFile(transactionCachePath(txID), data, 0644)
+}
+
+// readContractOverrides reads the contract code registers replaced by the --contract-file flags.
+func readContractOverrides() (map[flow.RegisterID]flow.RegisterValue, error) {
+	overrides := make(map[flow.RegisterID]flow.RegisterValue, len(flagContractFiles))
+	for _, contractFile := range flagContractFiles {
+		contract, path, ok := strings.Cut(contractFile, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid contract file %q, expected <address>.<name>=<path>", contractFile)
+		}
+		hexAddress, name, ok := strings.Cut(contract, ".")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid contract %q, expected <address>.<name>", contract)
+		}
+		address, err := flow.StringToAddress(hexAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract address %q: %w", hexAddress, err)
+		}
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read contract %s from file %s: %w", contract, path, err)
+		}
+		overrides[flow.ContractRegisterID(address, name)] = code
+	}
+	return overrides, nil
+}
+
+func printReplay(
+	txID flow.Identifier,
+	blockID flow.Identifier,
+	replay *debug.TransactionReplay,
+	changes []debug.RegisterChange,
+) {
+	output := replay.Output
+
+	fmt.Printf("Transaction %s at block %s\n", txID, blockID)
+	if output.Err != nil {
+		fmt.Printf("Error: %s\n", output.Err)
 	} else {
-		txErr, processErr = debugger.RunTransactionAtBlockID(
-			txBody,
-			flow.Identifier(txResult.BlockID),
-			"",
-		)
+		fmt.Println("Status: success")
 	}
-	if txErr != nil {
-		log.Fatal().Err(txErr).Msg("transaction error")
+	fmt.Printf("Computation used: %d\n", output.ComputationUsed)
+	fmt.Printf("Memory estimate: %d\n", output.MemoryEstimate)
+
+	fmt.Println("\nComputation per kind:")
+	for _, intensity := range replay.ComputationIntensities() {
+		fmt.Printf("  %s: %d\n", common.ComputationKind(intensity.Kind), intensity.Intensity)
+	}
+
+	fmt.Printf("\nEvents (%d):\n", len(output.Events))
+	for _, event := range output.Events {
+		fmt.Printf("  [%d] %s: %s\n", event.EventIndex, event.Type, debug.DecodeEventPayload(event))
+	}
+
+	if len(output.Logs) > 0 {
+		fmt.Printf("\nLogs (%d):\n", len(output.Logs))
+		for _, line := range output.Logs {
+			fmt.Printf("  %s\n", line)
+		}
 	}
-	if processErr != nil {
-		log.Fatal().Err(processErr).Msg("process error")
+
+	fmt.Printf("\nRegister changes (%d):\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  %s\n", formatRegisterID(change.ID))
+		fmt.Printf("    old: %s\n", debug.DecodeRegisterValue(change.ID, change.OldValue))
+		fmt.Printf("    new: %s\n", debug.DecodeRegisterValue(change.ID, change.NewValue))
+	}
+}
+
+// formatRegisterID formats the register ID like RegisterID.String, but with a readable key for the registers
+// which are not slabs, e.g. account status and contract code registers.
+func formatRegisterID(id flow.RegisterID) string {
+	if id.IsSlabIndex() {
+		return id.String()
 	}
+	return fmt.Sprintf("%x/%s", id.Owner, id.Key)
 }
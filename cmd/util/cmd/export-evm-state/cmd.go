@@ -77,6 +77,35 @@ func ExportEVMState(
 	targetState string,
 	outputPath string) error {
 
+	payloadsLedger, storageRoot, err := LedgerFromCheckpoint(chainName, ledgerPath, targetState)
+	if err != nil {
+		return err
+	}
+
+	return ExportEVMStateFromPayloads(payloadsLedger, storageRoot, outputPath)
+}
+
+func ExportEVMStateFromGob(
+	chainName string,
+	evmStateGobDir string,
+	flowHeight uint64,
+	outputPath string) error {
+
+	store, storageRoot, err := LedgerFromGob(chainName, evmStateGobDir, flowHeight)
+	if err != nil {
+		return err
+	}
+
+	return ExportEVMStateFromPayloads(store, storageRoot, outputPath)
+}
+
+// LedgerFromCheckpoint returns a ledger of the EVM storage registers at the given state commitment, and the EVM
+// storage address.
+func LedgerFromCheckpoint(
+	chainName string,
+	ledgerPath string,
+	targetState string,
+) (atree.Ledger, flow.Address, error) {
 	chainID := flow.ChainID(chainName)
 
 	storageRoot := evm.StorageAccountAddress(chainID)
@@ -84,7 +113,7 @@ func ExportEVMState(
 
 	payloads, err := util.ReadTrie(ledgerPath, util.ParseStateCommitment(targetState))
 	if err != nil {
-		return err
+		return nil, flow.EmptyAddress, err
 	}
 
 	// filter payloads of evm storage
@@ -92,41 +121,38 @@ func ExportEVMState(
 	for _, payload := range payloads {
 		registerID, _, err := convert.PayloadToRegister(payload)
 		if err != nil {
-			return fmt.Errorf("failed to convert payload to register: %w", err)
+			return nil, flow.EmptyAddress, fmt.Errorf("failed to convert payload to register: %w", err)
 		}
 		if registerID.Owner == rootOwner {
 			filteredPayloads[registerID] = payload
 		}
 	}
 
-	payloadsLedger := util.NewPayloadsLedger(filteredPayloads)
-
-	return ExportEVMStateFromPayloads(payloadsLedger, storageRoot, outputPath)
+	return util.NewPayloadsLedger(filteredPayloads), storageRoot, nil
 }
 
-func ExportEVMStateFromGob(
+// LedgerFromGob returns a ledger of the EVM storage registers stored in the gob files of the given Flow height, and
+// the EVM storage address.
+func LedgerFromGob(
 	chainName string,
 	evmStateGobDir string,
 	flowHeight uint64,
-	outputPath string) error {
-
+) (atree.Ledger, flow.Address, error) {
 	valueFileName, allocatorFileName := evmStateGobFileNamesByEndHeight(evmStateGobDir, flowHeight)
 	chainID := flow.ChainID(chainName)
 
 	storageRoot := evm.StorageAccountAddress(chainID)
 	valuesGob, err := testutils.DeserializeState(valueFileName)
 	if err != nil {
-		return err
+		return nil, flow.EmptyAddress, err
 	}
 
 	allocatorGobs, err := testutils.DeserializeAllocator(allocatorFileName)
 	if err != nil {
-		return err
+		return nil, flow.EmptyAddress, err
 	}
 
-	store := testutils.GetSimpleValueStorePopulated(valuesGob, allocatorGobs)
-
-	return ExportEVMStateFromPayloads(store, storageRoot, outputPath)
+	return testutils.GetSimpleValueStorePopulated(valuesGob, allocatorGobs), storageRoot, nil
 }
 
 func ExportEVMStateFromPayloads(
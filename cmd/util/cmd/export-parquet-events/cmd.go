@@ -0,0 +1,191 @@
+package export_parquet_events
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/storage"
+)
+
+var (
+	flagDatadir     string
+	flagOutputDir   string
+	flagStartHeight uint64
+	flagEndHeight   uint64
+)
+
+const (
+	eventsFileName             = "events.parquet"
+	transactionResultsFileName = "transaction_results.parquet"
+)
+
+// EventRow is a row of the exported events.
+type EventRow struct {
+	BlockHeight      uint64 `parquet:"block_height"`
+	BlockID          string `parquet:"block_id"`
+	TransactionID    string `parquet:"transaction_id"`
+	TransactionIndex uint32 `parquet:"transaction_index"`
+	EventIndex       uint32 `parquet:"event_index"`
+	Type             string `parquet:"type,dict"`
+	// Payload is the CCF-encoded event payload.
+	Payload []byte `parquet:"payload"`
+}
+
+// TransactionResultRow is a row of the exported transaction results.
+type TransactionResultRow struct {
+	BlockHeight      uint64 `parquet:"block_height"`
+	BlockID          string `parquet:"block_id"`
+	TransactionID    string `parquet:"transaction_id"`
+	TransactionIndex uint32 `parquet:"transaction_index"`
+	Failed           bool   `parquet:"failed"`
+	ErrorMessage     string `parquet:"error_message"`
+	ComputationUsed  uint64 `parquet:"computation_used"`
+	MemoryUsed       uint64 `parquet:"memory_used"`
+}
+
+// export the events and transaction results of a range of finalized blocks from the protocol database
+// of an execution node to parquet files for offline analytics.
+//
+// example:
+// ./util export-parquet-events --datadir /var/flow/data/protocol --output-dir ./ --start-height 2 --end-height 242
+var Cmd = &cobra.Command{
+	Use:   "export-parquet-events",
+	Short: "exports events and transaction results of a block range into parquet files",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "/var/flow/data/protocol",
+		"the protocol state")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory to write the parquet files to")
+	_ = Cmd.MarkFlagRequired("output-dir")
+
+	Cmd.Flags().Uint64Var(&flagStartHeight, "start-height", 0,
+		"start height of the block range")
+	_ = Cmd.MarkFlagRequired("start-height")
+
+	Cmd.Flags().Uint64Var(&flagEndHeight, "end-height", 0,
+		"end height of the block range")
+	_ = Cmd.MarkFlagRequired("end-height")
+}
+
+func run(*cobra.Command, []string) {
+	if flagStartHeight > flagEndHeight {
+		log.Fatal().Msgf("start height %d is above end height %d", flagStartHeight, flagEndHeight)
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+	storages := common.InitStorages(db)
+
+	log.Info().Msgf("exporting events and transaction results from height %d to %d", flagStartHeight, flagEndHeight)
+
+	err := ExportEventsAndTransactionResults(storages, flagOutputDir, flagStartHeight, flagEndHeight)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot export events and transaction results")
+	}
+
+	log.Info().Msg("events and transaction results exported")
+}
+
+// ExportEventsAndTransactionResults exports the events and transaction results of the finalized blocks
+// from startHeight to endHeight (inclusive) to parquet files in outputDir.
+func ExportEventsAndTransactionResults(storages *storage.All, outputDir string, startHeight uint64, endHeight uint64) (errToReturn error) {
+	eventsFile, err := os.Create(filepath.Join(outputDir, eventsFileName))
+	if err != nil {
+		return fmt.Errorf("could not create events file: %w", err)
+	}
+	defer closeFile(eventsFile, &errToReturn)
+
+	resultsFile, err := os.Create(filepath.Join(outputDir, transactionResultsFileName))
+	if err != nil {
+		return fmt.Errorf("could not create transaction results file: %w", err)
+	}
+	defer closeFile(resultsFile, &errToReturn)
+
+	eventsWriter := parquet.NewGenericWriter[EventRow](eventsFile, parquet.Compression(&parquet.Zstd))
+	resultsWriter := parquet.NewGenericWriter[TransactionResultRow](resultsFile, parquet.Compression(&parquet.Zstd))
+
+	for height := startHeight; height <= endHeight; height++ {
+		blockID, err := storages.Headers.BlockIDByHeight(height)
+		if err != nil {
+			return fmt.Errorf("could not get finalized block at height %d: %w", height, err)
+		}
+
+		events, err := storages.Events.ByBlockID(blockID)
+		if err != nil {
+			return fmt.Errorf("could not get events of block %v: %w", blockID, err)
+		}
+
+		eventRows := make([]EventRow, 0, len(events))
+		for _, event := range events {
+			eventRows = append(eventRows, EventRow{
+				BlockHeight:      height,
+				BlockID:          blockID.String(),
+				TransactionID:    event.TransactionID.String(),
+				TransactionIndex: event.TransactionIndex,
+				EventIndex:       event.EventIndex,
+				Type:             string(event.Type),
+				Payload:          event.Payload,
+			})
+		}
+		_, err = eventsWriter.Write(eventRows)
+		if err != nil {
+			return fmt.Errorf("could not write events of block %v: %w", blockID, err)
+		}
+
+		results, err := storages.TransactionResults.ByBlockID(blockID)
+		if err != nil {
+			return fmt.Errorf("could not get transaction results of block %v: %w", blockID, err)
+		}
+
+		resultRows := make([]TransactionResultRow, 0, len(results))
+		for i, result := range results {
+			resultRows = append(resultRows, TransactionResultRow{
+				BlockHeight:      height,
+				BlockID:          blockID.String(),
+				TransactionID:    result.TransactionID.String(),
+				TransactionIndex: uint32(i),
+				Failed:           result.ErrorMessage != "",
+				ErrorMessage:     result.ErrorMessage,
+				ComputationUsed:  result.ComputationUsed,
+				MemoryUsed:       result.MemoryUsed,
+			})
+		}
+		_, err = resultsWriter.Write(resultRows)
+		if err != nil {
+			return fmt.Errorf("could not write transaction results of block %v: %w", blockID, err)
+		}
+
+		if (height-startHeight+1)%10_000 == 0 {
+			log.Info().Msgf("exported %d blocks", height-startHeight+1)
+		}
+	}
+
+	err = eventsWriter.Close()
+	if err != nil {
+		return fmt.Errorf("could not close events writer: %w", err)
+	}
+
+	err = resultsWriter.Close()
+	if err != nil {
+		return fmt.Errorf("could not close transaction results writer: %w", err)
+	}
+
+	return nil
+}
+
+func closeFile(file *os.File, errToReturn *error) {
+	err := file.Close()
+	if err != nil && *errToReturn == nil {
+		*errToReturn = fmt.Errorf("could not close %v: %w", file.Name(), err)
+	}
+}
@@ -0,0 +1,85 @@
+package export_parquet_events
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestExportEventsAndTransactionResults(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		storages := common.InitStorages(db)
+		outputDir := t.TempDir()
+
+		// store the events and transaction results of the finalized blocks at heights 1 to 3
+		blockIDs := make(map[uint64]flow.Identifier)
+		events := make(map[uint64][]flow.Event)
+		results := make(map[uint64][]flow.TransactionResult)
+		for height := uint64(1); height <= 3; height++ {
+			blockID := unittest.IdentifierFixture()
+			require.NoError(t, db.Update(operation.IndexBlockHeight(height, blockID)))
+
+			blockEvents := unittest.EventsFixture(int(height))
+			require.NoError(t, storages.Events.Store(blockID, []flow.EventsList{blockEvents}))
+
+			blockResults := unittest.TransactionResultsFixture(2)
+			blockResults[1].ErrorMessage = ""
+			batch := bstorage.NewBatch(db)
+			require.NoError(t, storages.TransactionResults.BatchStore(blockID, blockResults, batch))
+			require.NoError(t, batch.Flush())
+
+			blockIDs[height] = blockID
+			events[height] = blockEvents
+			results[height] = blockResults
+		}
+
+		// only heights 2 and 3 are exported
+		err := ExportEventsAndTransactionResults(storages, outputDir, 2, 3)
+		require.NoError(t, err)
+
+		eventRows, err := parquet.ReadFile[EventRow](filepath.Join(outputDir, eventsFileName))
+		require.NoError(t, err)
+		require.Len(t, eventRows, len(events[2])+len(events[3]))
+		for i, row := range eventRows {
+			height := uint64(2)
+			index := i
+			if i >= len(events[2]) {
+				height = 3
+				index = i - len(events[2])
+			}
+			event := events[height][index]
+			require.Equal(t, height, row.BlockHeight)
+			require.Equal(t, blockIDs[height].String(), row.BlockID)
+			require.Equal(t, event.TransactionID.String(), row.TransactionID)
+			require.Equal(t, event.EventIndex, row.EventIndex)
+			require.Equal(t, string(event.Type), row.Type)
+		}
+
+		resultRows, err := parquet.ReadFile[TransactionResultRow](filepath.Join(outputDir, transactionResultsFileName))
+		require.NoError(t, err)
+		require.Len(t, resultRows, 4)
+		for i, row := range resultRows {
+			height := uint64(2 + i/2)
+			result := results[height][i%2]
+			require.Equal(t, height, row.BlockHeight)
+			require.Equal(t, blockIDs[height].String(), row.BlockID)
+			require.Equal(t, result.TransactionID.String(), row.TransactionID)
+			require.Equal(t, uint32(i%2), row.TransactionIndex)
+			require.Equal(t, result.ErrorMessage != "", row.Failed)
+			require.Equal(t, result.ComputationUsed, row.ComputationUsed)
+		}
+
+		// exporting blocks which are not finalized fails
+		err = ExportEventsAndTransactionResults(storages, t.TempDir(), 3, 4)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,313 @@
+package export_parquet_execution_state
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/util"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var (
+	flagCheckpointDir   string
+	flagCheckpointFile  string
+	flagStateCommitment string
+	flagOutputDir       string
+	flagWorkers         int
+	flagPrefixLength    int
+)
+
+// globalOwnerPrefix is the partition of registers without owner.
+const globalOwnerPrefix = "global"
+
+// rowBatchSize is the number of rows buffered per file before they are written.
+const rowBatchSize = 10_000
+
+// subtrieLevel is the level of the trie at which it is split into subtries, which are exported
+// concurrently. The trie is split into at most 2^subtrieLevel subtries.
+const subtrieLevel = 4
+
+// RegisterRow is a row of the exported register state.
+type RegisterRow struct {
+	// Owner is the hex-encoded owner of the register, empty for registers without owner.
+	Owner string `parquet:"owner,dict"`
+	Key   []byte `parquet:"key"`
+	// Type is the decoded register type, see util.RegisterType.
+	Type      string `parquet:"type,dict"`
+	ValueSize int64  `parquet:"value_size"`
+}
+
+// export the register state of a trie of a checkpoint (e.g. created by execution-state-extract)
+// to parquet files for offline analytics. the trie is loaded from the checkpoint, split into subtries
+// which are iterated concurrently, and the files are partitioned by owner prefix, with one file per
+// partition and subtrie:
+//
+//	<output-dir>/owner_prefix=<prefix>/part-<subtrie>.parquet
+//
+// example:
+// ./util export-parquet-execution-state --checkpoint-dir /var/flow/data/execution --checkpoint-file root.checkpoint \
+// --state-commitment <hex> --output-dir ./registers
+var Cmd = &cobra.Command{
+	Use:   "export-parquet-execution-state",
+	Short: "exports the register state of a checkpoint into parquet files, partitioned by owner prefix",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpointDir, "checkpoint-dir", "",
+		"directory containing the checkpoint files")
+	_ = Cmd.MarkFlagRequired("checkpoint-dir")
+
+	Cmd.Flags().StringVar(&flagCheckpointFile, "checkpoint-file", "",
+		"checkpoint file name, full and incremental checkpoints are supported")
+	_ = Cmd.MarkFlagRequired("checkpoint-file")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"state commitment of the trie to export (hex-encoded, 64 characters)")
+	_ = Cmd.MarkFlagRequired("state-commitment")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory to write the parquet files to")
+	_ = Cmd.MarkFlagRequired("output-dir")
+
+	Cmd.Flags().IntVar(&flagWorkers, "workers", 16,
+		"number of subtries to export concurrently")
+
+	Cmd.Flags().IntVar(&flagPrefixLength, "owner-prefix-length", 1,
+		"number of hex characters of the owner to partition the registers by")
+}
+
+func run(*cobra.Command, []string) {
+	stateCommitment, err := ledger.ToRootHash(mustDecodeHex(flagStateCommitment))
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid state commitment")
+	}
+
+	if flagPrefixLength < 1 || flagPrefixLength > 2*8 {
+		log.Fatal().Msgf("owner prefix length must be between 1 and 16, got %d", flagPrefixLength)
+	}
+
+	log.Info().Msgf("exporting registers of checkpoint %v to %v", filepath.Join(flagCheckpointDir, flagCheckpointFile), flagOutputDir)
+
+	count, err := ExportRegisters(flagCheckpointDir, flagCheckpointFile, stateCommitment, flagOutputDir, flagWorkers, flagPrefixLength, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot export registers")
+	}
+
+	log.Info().Msgf("exported %d registers", count)
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("cannot decode hex string %v", s)
+	}
+	return b
+}
+
+// ExportRegisters exports the registers of the trie with the given root hash in the given checkpoint to
+// parquet files in outputDir, partitioned by the first prefixLength hex characters of the owner. The trie
+// is split into subtries, which are iterated and exported concurrently by nWorkers workers. It returns
+// the number of exported registers.
+//
+// CAUTION: all tries of the checkpoint are loaded into memory.
+func ExportRegisters(
+	checkpointDir string,
+	checkpointFile string,
+	stateCommitment ledger.RootHash,
+	outputDir string,
+	nWorkers int,
+	prefixLength int,
+	logger zerolog.Logger,
+) (uint64, error) {
+	tries, err := wal.LoadCheckpoint(filepath.Join(checkpointDir, checkpointFile), logger)
+	if err != nil {
+		return 0, fmt.Errorf("cannot load checkpoint: %w", err)
+	}
+
+	var root *node.Node
+	found := false
+	for _, t := range tries {
+		if t.RootHash() == stateCommitment {
+			root = t.RootNode()
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("checkpoint does not contain a trie with root hash %v", stateCommitment)
+	}
+
+	subtries := subtrieRoots(root, subtrieLevel)
+	logger.Info().Msgf("exporting %d subtries", len(subtries))
+
+	// each subtrie is exported into its own set of partition files,
+	// so that files are only written by a single worker
+	writersBySubtrie := make([]*partitionWriters, len(subtries))
+	for i := range subtries {
+		writersBySubtrie[i] = newPartitionWriters(outputDir, i)
+	}
+
+	count := atomic.NewUint64(0)
+	var group errgroup.Group
+	group.SetLimit(nWorkers)
+	for i, subtrie := range subtries {
+		group.Go(func() error {
+			for itr := flattener.NewNodeIterator(subtrie); itr.Next(); {
+				n := itr.Value()
+				if !n.IsLeaf() {
+					continue
+				}
+
+				err := exportLeaf(n, writersBySubtrie[i], prefixLength)
+				if err != nil {
+					return fmt.Errorf("cannot export register at path %v: %w", *n.Path(), err)
+				}
+				count.Inc()
+			}
+			return nil
+		})
+	}
+	err = group.Wait()
+
+	// close all files, even if the export failed
+	for _, writers := range writersBySubtrie {
+		closeErr := writers.close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return count.Load(), nil
+}
+
+// subtrieRoots returns the roots of the disjoint subtries at the given level below the given node, which
+// together contain all leaves of the trie. Leaves above the level are returned as their own subtrie.
+func subtrieRoots(n *node.Node, level int) []*node.Node {
+	if n == nil {
+		return nil
+	}
+	if level == 0 || n.IsLeaf() {
+		return []*node.Node{n}
+	}
+	return append(subtrieRoots(n.LeftChild(), level-1), subtrieRoots(n.RightChild(), level-1)...)
+}
+
+// exportLeaf writes the register of the given leaf node to the partition of its owner.
+func exportLeaf(n *node.Node, writers *partitionWriters, prefixLength int) error {
+	payload := n.Payload()
+	key, err := payload.Key()
+	if err != nil {
+		return fmt.Errorf("cannot get key of payload: %w", err)
+	}
+
+	owner := hex.EncodeToString(key.KeyParts[0].Value)
+	prefix := globalOwnerPrefix
+	if len(owner) > 0 {
+		prefix = owner[:min(prefixLength, len(owner))]
+	}
+
+	return writers.write(prefix, RegisterRow{
+		Owner:     owner,
+		Key:       key.KeyParts[1].Value,
+		Type:      util.RegisterType(key),
+		ValueSize: int64(payload.Value().Size()),
+	})
+}
+
+// partitionWriters writes the registers of a subtrie into one parquet file per partition.
+// Not safe for concurrent use.
+type partitionWriters struct {
+	outputDir string
+	part      int
+	writers   map[string]*partitionWriter
+}
+
+type partitionWriter struct {
+	file   *os.File
+	writer *parquet.GenericWriter[RegisterRow]
+	rows   []RegisterRow
+}
+
+func newPartitionWriters(outputDir string, part int) *partitionWriters {
+	return &partitionWriters{
+		outputDir: outputDir,
+		part:      part,
+		writers:   make(map[string]*partitionWriter),
+	}
+}
+
+func (p *partitionWriters) write(prefix string, row RegisterRow) error {
+	w, ok := p.writers[prefix]
+	if !ok {
+		dir := filepath.Join(p.outputDir, "owner_prefix="+prefix)
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("cannot create partition directory %v: %w", dir, err)
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("part-%03d.parquet", p.part))
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("cannot create partition file %v: %w", path, err)
+		}
+
+		w = &partitionWriter{
+			file:   file,
+			writer: parquet.NewGenericWriter[RegisterRow](file, parquet.Compression(&parquet.Zstd)),
+			rows:   make([]RegisterRow, 0, rowBatchSize),
+		}
+		p.writers[prefix] = w
+	}
+
+	w.rows = append(w.rows, row)
+	if len(w.rows) < rowBatchSize {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *partitionWriter) flush() error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+	_, err := w.writer.Write(w.rows)
+	if err != nil {
+		return fmt.Errorf("cannot write rows to %v: %w", w.file.Name(), err)
+	}
+	w.rows = w.rows[:0]
+	return nil
+}
+
+func (p *partitionWriters) close() error {
+	var firstErr error
+	for _, w := range p.writers {
+		err := w.flush()
+		if err == nil {
+			err = w.writer.Close()
+		}
+		closeErr := w.file.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("cannot close partition file %v: %w", w.file.Name(), err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,108 @@
+package export_parquet_execution_state
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestExportRegisters(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		checkpointDir := filepath.Join(dir, "checkpoint")
+		outputDir := filepath.Join(dir, "output")
+
+		// registers of a number of accounts, and a global register
+		expected := make(map[string]RegisterRow)
+		var paths []ledger.Path
+		var payloads []ledger.Payload
+		addRegister := func(owner []byte, key string, value []byte) {
+			ledgerKey := ledger.NewKey([]ledger.KeyPart{
+				ledger.NewKeyPart(ledger.KeyPartOwner, owner),
+				ledger.NewKeyPart(ledger.KeyPartKey, []byte(key)),
+			})
+			path, err := pathfinder.KeyToPath(ledgerKey, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			paths = append(paths, path)
+			payloads = append(payloads, *ledger.NewPayload(ledgerKey, value))
+			expected[hex.EncodeToString(owner)+"/"+key] = RegisterRow{
+				Owner:     hex.EncodeToString(owner),
+				Key:       []byte(key),
+				ValueSize: int64(len(value)),
+			}
+		}
+
+		for i := 0; i < 100; i++ {
+			owner := unittest.RandomAddressFixture().Bytes()
+			addRegister(owner, flow.AccountStatusKey, []byte{0, 1, 2})
+			addRegister(owner, "public_key_0", []byte(fmt.Sprintf("key %d", i)))
+			addRegister(owner, "code.Contract", []byte("access(all) contract Contract {}"))
+		}
+		addRegister(nil, "uuid", []byte{1})
+
+		tr, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+		require.NoError(t, err)
+
+		// the checkpoint also contains a trie with only some of the registers, which is not exported
+		other, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths[:10], payloads[:10], true)
+		require.NoError(t, err)
+
+		require.NoError(t, os.MkdirAll(checkpointDir, 0755))
+		require.NoError(t, wal.StoreCheckpointV6Concurrently([]*trie.MTrie{other, tr}, checkpointDir, "root.checkpoint", unittest.Logger()))
+
+		_, err = ExportRegisters(checkpointDir, "root.checkpoint", ledger.RootHash(unittest.StateCommitmentFixture()), outputDir, 4, 1, unittest.Logger())
+		require.Error(t, err)
+
+		count, err := ExportRegisters(checkpointDir, "root.checkpoint", tr.RootHash(), outputDir, 4, 1, unittest.Logger())
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(expected)), count)
+
+		// read back all partitions
+		files, err := filepath.Glob(filepath.Join(outputDir, "owner_prefix=*", "*.parquet"))
+		require.NoError(t, err)
+		require.NotEmpty(t, files)
+
+		types := make(map[string]string)
+		read := 0
+		for _, file := range files {
+			rows, err := parquet.ReadFile[RegisterRow](file)
+			require.NoError(t, err)
+
+			prefix := filepath.Base(filepath.Dir(file))
+			for _, row := range rows {
+				if row.Owner == "" {
+					require.Equal(t, "owner_prefix="+globalOwnerPrefix, prefix)
+				} else {
+					require.Equal(t, "owner_prefix="+row.Owner[:1], prefix)
+				}
+
+				exp, ok := expected[row.Owner+"/"+string(row.Key)]
+				require.True(t, ok, "unexpected register %v/%s", row.Owner, row.Key)
+				require.Equal(t, exp.ValueSize, row.ValueSize)
+				types[string(row.Key)] = row.Type
+				read++
+			}
+		}
+		require.Equal(t, len(expected), read)
+
+		require.Equal(t, map[string]string{
+			flow.AccountStatusKey: "account status",
+			"public_key_0":        "public key",
+			"code.Contract":       "contract content",
+			"uuid":                "uuid generator state",
+		}, types)
+	})
+}
@@ -0,0 +1,123 @@
+package read_evm_traces
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/pebble"
+)
+
+var (
+	flagTracesDir string
+	flagTxHash    string
+	flagBlockID   string
+)
+
+// usage example
+//
+//	./util read-evm-traces --traces-dir /var/flow/data/evm_traces --tx-hash 0x...
+//	./util read-evm-traces --traces-dir /var/flow/data/evm_traces --block-id 4c9edc...
+var Cmd = &cobra.Command{
+	Use:   "read-evm-traces",
+	Short: "reads EVM call traces from a local trace database",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagTracesDir, "traces-dir", "",
+		"directory of the local EVM trace database, see --evm-traces-dir and --ethrpc-traces-dir")
+	_ = Cmd.MarkFlagRequired("traces-dir")
+
+	Cmd.Flags().StringVar(&flagTxHash, "tx-hash", "",
+		"hash of the EVM transaction to read the traces of")
+
+	Cmd.Flags().StringVar(&flagBlockID, "block-id", "",
+		"ID of the Flow block to read the traces of, combined with --tx-hash only the trace of the transaction in the block is read")
+}
+
+// trace is a trace printed by the command.
+type trace struct {
+	BlockID flow.Identifier `json:"blockID"`
+	TxHash  gethCommon.Hash `json:"txHash"`
+	Trace   json.RawMessage `json:"trace"`
+}
+
+func run(*cobra.Command, []string) {
+	if flagTxHash == "" && flagBlockID == "" {
+		log.Fatal().Msg("either --tx-hash or --block-id must be provided")
+	}
+
+	db, err := pebble.MustOpenDefaultPebbleDB(flagTracesDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open trace database")
+	}
+	defer db.Close()
+
+	traces, err := readTraces(debug.NewLocalTraceStore(db, 0), flagTxHash, flagBlockID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read traces")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(traces); err != nil {
+		log.Fatal().Err(err).Msg("could not print traces")
+	}
+}
+
+// readTraces reads the traces of the given transaction, the given Flow block, or the given transaction in the given
+// Flow block. The empty arguments are ignored.
+func readTraces(store debug.TraceStore, txHashHex string, blockIDHex string) ([]trace, error) {
+	var blockID flow.Identifier
+	if blockIDHex != "" {
+		var err error
+		blockID, err = flow.HexStringToIdentifier(blockIDHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block ID: %w", err)
+		}
+	}
+
+	if txHashHex == "" {
+		blockTraces, err := store.TracesByBlockID(blockID)
+		if err != nil {
+			return nil, err
+		}
+		traces := make([]trace, 0, len(blockTraces))
+		for txHash, data := range blockTraces {
+			traces = append(traces, trace{BlockID: blockID, TxHash: txHash, Trace: data})
+		}
+		return traces, nil
+	}
+
+	txHash := gethCommon.HexToHash(txHashHex)
+	blockIDs := []flow.Identifier{blockID}
+	if blockIDHex == "" {
+		var err error
+		blockIDs, err = store.BlockIDsByTransaction(txHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	traces := make([]trace, 0, len(blockIDs))
+	for _, blockID := range blockIDs {
+		data, err := store.Trace(txHash, blockID)
+		if err != nil {
+			if errors.Is(err, debug.ErrTraceNotFound) && blockIDHex == "" {
+				// pruned since it was listed
+				continue
+			}
+			return nil, err
+		}
+		traces = append(traces, trace{BlockID: blockID, TxHash: txHash, Trace: data})
+	}
+	return traces, nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/cmd/util/cmd/read-hotstuff/replay"
+	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var (
+	flagFromView   uint64
+	flagToView     uint64
+	flagLookahead  uint64
+	flagJSONOutput string
+	flagDOTOutput  string
+)
+
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "rebuild the fork tree for a view range from stored proposals and report per-view consensus progress",
+	Run:   runReplay,
+}
+
+func init() {
+	rootCmd.AddCommand(ReplayCmd)
+
+	ReplayCmd.Flags().Uint64Var(&flagFromView, "from-view", 0, "first view (inclusive) to replay")
+	_ = ReplayCmd.MarkFlagRequired("from-view")
+	ReplayCmd.Flags().Uint64Var(&flagToView, "to-view", 0, "last view (inclusive) to replay")
+	_ = ReplayCmd.MarkFlagRequired("to-view")
+	ReplayCmd.Flags().Uint64Var(&flagLookahead, "lookahead", 100,
+		"number of views beyond --to-view to load, for determining QCs, TCs and finality of the last views in the range")
+	ReplayCmd.Flags().StringVar(&flagJSONOutput, "json-output", "", "file to write the JSON report to (default stdout)")
+	ReplayCmd.Flags().StringVar(&flagDOTOutput, "dot-output", "", "file to write the Graphviz DOT graph of the block tree to")
+}
+
+func runReplay(*cobra.Command, []string) {
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	final, err := state.Final().Head()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get finalized header")
+	}
+
+	// leader selection is only available for the epochs around the latest finalized block;
+	// for older views, the report falls back to the proposer of the stored blocks
+	committee, err := committees.NewConsensusCommittee(state, flow.ZeroID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create consensus committee")
+	}
+
+	log.Info().Msgf("replaying hotstuff views [%d, %d]", flagFromView, flagToView)
+
+	report, err := replay.Replay(storages.Headers, committee, state.Params().FinalizedRoot(), final, replay.Config{
+		FromView:  flagFromView,
+		ToView:    flagToView,
+		Lookahead: flagLookahead,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not replay hotstuff views")
+	}
+
+	log.Info().Msgf("replayed %d blocks in %d views", len(report.Blocks), len(report.Views))
+
+	if flagJSONOutput == "" {
+		common.PrettyPrint(report)
+	} else {
+		bytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not marshal report")
+		}
+		err = os.WriteFile(flagJSONOutput, bytes, 0644)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("could not write report to %s", flagJSONOutput)
+		}
+		log.Info().Msgf("report written to %s", flagJSONOutput)
+	}
+
+	if flagDOTOutput != "" {
+		file, err := os.Create(flagDOTOutput)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("could not create %s", flagDOTOutput)
+		}
+		defer file.Close()
+
+		err = replay.WriteDOT(file, report)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not write block tree graph")
+		}
+		log.Info().Msgf("block tree graph written to %s", flagDOTOutput)
+	}
+}
@@ -14,7 +14,7 @@ var (
 
 var rootCmd = &cobra.Command{
 	Use:   "read-hotstuff",
-	Short: "read hotstuff liveness/safety data",
+	Short: "read hotstuff liveness/safety data and replay stored proposals",
 }
 
 var RootCmd = rootCmd
@@ -0,0 +1,47 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteDOT renders the block tree of the report as a Graphviz DOT graph. Every block is a
+// node labelled with its view, height, ID prefix and proposer; edges point from a block to
+// its parent. Finalized blocks are filled, and proposals carrying a TC for the previous
+// view are outlined in red.
+func WriteDOT(w io.Writer, report *Report) error {
+	_, err := fmt.Fprintln(w, "digraph hotstuff {")
+	if err != nil {
+		return err
+	}
+	lines := []string{
+		"  rankdir=RL;",
+		"  node [shape=box, fontname=\"monospace\"];",
+	}
+	blocks := append([]*Block{report.Root}, report.Blocks...)
+	for i, block := range blocks {
+		attributes := ""
+		switch {
+		case i == 0:
+			attributes = ", style=\"filled,bold\", fillcolor=lightblue"
+		case block.Finalized:
+			attributes = ", style=filled, fillcolor=lightgrey"
+		}
+		if block.HasLastViewTC {
+			attributes += ", color=red, peripheries=2"
+		}
+		lines = append(lines, fmt.Sprintf("  \"%s\" [label=\"view %d | height %d\\n%.8s\\nproposer %.8s\"%s];",
+			block.BlockID, block.View, block.Height, block.BlockID.String(), block.ProposerID.String(), attributes))
+	}
+	for _, block := range report.Blocks {
+		lines = append(lines, fmt.Sprintf("  \"%s\" -> \"%s\";", block.BlockID, block.ParentID))
+	}
+	for _, line := range lines {
+		_, err = fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w, "}")
+	return err
+}
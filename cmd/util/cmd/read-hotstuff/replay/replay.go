@@ -0,0 +1,309 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/forks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// LeaderSelection determines the leader for a view. It is satisfied by the
+// HotStuff committees (see `hotstuff.Replicas`).
+type LeaderSelection interface {
+	// LeaderForView returns the identity of the leader for a given view.
+	// Expected error returns:
+	//   - model.ErrViewForUnknownEpoch if no epoch containing the given view is known
+	LeaderForView(view uint64) (flow.Identifier, error)
+}
+
+// Config specifies the view range to replay.
+type Config struct {
+	// FromView is the first view (inclusive) included in the report.
+	FromView uint64
+	// ToView is the last view (inclusive) included in the report.
+	ToView uint64
+	// Lookahead is the number of views beyond ToView that are still loaded from storage,
+	// so that QCs, TCs and finality for the last views of the range can be determined.
+	Lookahead uint64
+}
+
+// Block describes a single proposal stored in the database.
+type Block struct {
+	BlockID    flow.Identifier `json:"block_id"`
+	ParentID   flow.Identifier `json:"parent_id"`
+	Height     uint64          `json:"height"`
+	View       uint64          `json:"view"`
+	ParentView uint64          `json:"parent_view"`
+	ProposerID flow.Identifier `json:"proposer_id"`
+	Timestamp  time.Time       `json:"timestamp"`
+	// HasLastViewTC is true if the proposal includes a TC for view View-1.
+	HasLastViewTC bool `json:"has_last_view_tc"`
+	// CertifiedAt is the timestamp of the earliest child including a QC for this block.
+	// Nil if no QC for the block is known in the replayed range.
+	CertifiedAt *time.Time `json:"certified_at,omitempty"`
+	// Finalized is true if the block was finalized while replaying the range.
+	Finalized bool `json:"finalized"`
+	// FinalizedAt is the timestamp of the proposal whose QC finalized the block.
+	FinalizedAt *time.Time `json:"finalized_at,omitempty"`
+}
+
+// ViewReport summarizes the consensus progress of a single view.
+type ViewReport struct {
+	View uint64 `json:"view"`
+	// Leader is the primary for the view. If the committee for the view's epoch is
+	// unknown, this falls back to the proposer of the first block for the view.
+	Leader flow.Identifier `json:"leader"`
+	// Proposals lists the IDs of all blocks proposed for this view. More than one
+	// entry is evidence of equivocation by the leader.
+	Proposals []flow.Identifier `json:"proposals"`
+	// ProposalTimestamp is the timestamp of the first proposal for the view, as set by the leader.
+	// The receive time of proposals is not persisted, so the proposer's timestamp is the best
+	// available approximation of the proposal's arrival.
+	ProposalTimestamp *time.Time `json:"proposal_timestamp,omitempty"`
+	// QCFormed is true if a QC for a proposal of this view was included in a descendant.
+	QCFormed bool `json:"qc_formed"`
+	// QCDelay is the time between the proposal and the first proposal carrying the QC.
+	QCDelay *time.Duration `json:"qc_delay,omitempty"`
+	// TCNeeded is true if the view was concluded by a timeout certificate.
+	TCNeeded bool `json:"tc_needed"`
+	// Finalized is true if a proposal for this view was finalized.
+	Finalized bool `json:"finalized"`
+	// FinalizationDelay is the time between the proposal and the proposal which finalized it.
+	FinalizationDelay *time.Duration `json:"finalization_delay,omitempty"`
+}
+
+// Report is the result of replaying a view range.
+type Report struct {
+	// Root is the latest finalized block below the replayed range, which Forks was initialized with.
+	Root   *Block        `json:"root"`
+	Views  []*ViewReport `json:"views"`
+	Blocks []*Block      `json:"blocks"`
+}
+
+// Replay loads all proposals for the configured view range from storage and feeds them through
+// `forks.Forks` to rebuild the fork tree and re-derive finality. Blocks are discovered by walking
+// the children index from the latest finalized block below `cfg.FromView`, so orphaned forks are
+// included in the report as well.
+//
+// `lowest` and `final` bound the heights of the finalized chain that are present in the database.
+// No errors are expected during normal operation, as long as the range is within the stored history.
+func Replay(headers storage.Headers, leaders LeaderSelection, lowest, final *flow.Header, cfg Config) (*Report, error) {
+	if cfg.FromView > cfg.ToView {
+		return nil, fmt.Errorf("invalid view range [%d, %d]", cfg.FromView, cfg.ToView)
+	}
+
+	root, err := findRoot(headers, lowest, final, cfg.FromView)
+	if err != nil {
+		return nil, fmt.Errorf("could not find root block for view %d: %w", cfg.FromView, err)
+	}
+	stored, err := collectDescendants(headers, root, cfg.ToView+cfg.Lookahead)
+	if err != nil {
+		return nil, fmt.Errorf("could not collect descendants of root block %v: %w", root.ID(), err)
+	}
+	if len(stored) == 0 {
+		return nil, fmt.Errorf("no descendants of root block %v (view %d) found", root.ID(), root.View)
+	}
+
+	// the children of the root carry the QC certifying the root, which Forks requires for initialization
+	rootBlock := model.BlockFromFlow(root)
+	certifiedRoot, err := model.NewCertifiedBlock(rootBlock, stored[0].QuorumCertificate())
+	if err != nil {
+		return nil, fmt.Errorf("could not certify root block: %w", err)
+	}
+
+	blocks := make(map[flow.Identifier]*Block, len(stored)+1)
+	blocks[root.ID()] = toBlock(root)
+	for _, header := range stored {
+		blocks[header.ID()] = toBlock(header)
+	}
+
+	tracker := &finalizationTracker{blocks: blocks}
+	f, err := forks.New(&certifiedRoot, tracker, tracker)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize forks: %w", err)
+	}
+	for _, header := range stored {
+		block := blocks[header.ID()]
+		if parent, ok := blocks[header.ParentID]; ok && parent.CertifiedAt == nil {
+			parent.CertifiedAt = &block.Timestamp
+		}
+		tracker.current = block
+		err = f.AddValidatedBlock(model.BlockFromFlow(header))
+		if err != nil {
+			return nil, fmt.Errorf("could not add block %v (view %d) to forks: %w", header.ID(), header.View, err)
+		}
+	}
+
+	report := &Report{
+		Root:   blocks[root.ID()],
+		Blocks: make([]*Block, 0, len(stored)),
+	}
+	for _, header := range stored {
+		if header.View > cfg.ToView || header.View < cfg.FromView {
+			continue
+		}
+		report.Blocks = append(report.Blocks, blocks[header.ID()])
+	}
+	report.Views, err = summarizeViews(report.Blocks, blocks, leaders, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not summarize views: %w", err)
+	}
+	return report, nil
+}
+
+// findRoot returns the finalized block with the largest view strictly smaller than `fromView`.
+// If `fromView` is not larger than the view of `lowest`, `lowest` is returned.
+func findRoot(headers storage.Headers, lowest, final *flow.Header, fromView uint64) (*flow.Header, error) {
+	if fromView <= lowest.View {
+		return lowest, nil
+	}
+	if final.View < fromView {
+		return final, nil
+	}
+	// views are strictly increasing along the finalized chain, so we can binary search by height
+	low, high := lowest.Height, final.Height
+	root := lowest
+	for low <= high {
+		mid := low + (high-low)/2
+		header, err := headers.ByHeight(mid)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve finalized block at height %d: %w", mid, err)
+		}
+		if header.View < fromView {
+			root = header
+			low = mid + 1
+		} else {
+			high = mid - 1
+		}
+	}
+	return root, nil
+}
+
+// collectDescendants returns all known descendants of `root` up to and including `maxView`,
+// ordered by view (ascending). Parents always precede their children in the returned slice.
+func collectDescendants(headers storage.Headers, root *flow.Header, maxView uint64) ([]*flow.Header, error) {
+	var descendants []*flow.Header
+	queue := []flow.Identifier{root.ID()}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		children, err := headers.ByParentID(parentID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("could not retrieve children of block %v: %w", parentID, err)
+		}
+		for _, child := range children {
+			if child.View > maxView {
+				continue
+			}
+			descendants = append(descendants, child)
+			queue = append(queue, child.ID())
+		}
+	}
+	sort.SliceStable(descendants, func(i, j int) bool {
+		return descendants[i].View < descendants[j].View
+	})
+	return descendants, nil
+}
+
+// summarizeViews builds a ViewReport for every view in the configured range.
+func summarizeViews(inRange []*Block, all map[flow.Identifier]*Block, leaders LeaderSelection, cfg Config) ([]*ViewReport, error) {
+	byView := make(map[uint64][]*Block)
+	for _, block := range inRange {
+		byView[block.View] = append(byView[block.View], block)
+	}
+	// a TC for view v is included in proposals for view v+1, which may lie beyond the range
+	tcViews := make(map[uint64]struct{})
+	for _, block := range all {
+		if block.HasLastViewTC {
+			tcViews[block.View-1] = struct{}{}
+		}
+	}
+
+	views := make([]*ViewReport, 0, cfg.ToView-cfg.FromView+1)
+	for view := cfg.FromView; view <= cfg.ToView; view++ {
+		report := &ViewReport{
+			View:      view,
+			Proposals: []flow.Identifier{},
+		}
+		_, report.TCNeeded = tcViews[view]
+
+		proposals := byView[view]
+		for _, block := range proposals {
+			report.Proposals = append(report.Proposals, block.BlockID)
+			if block.CertifiedAt != nil && !report.QCFormed {
+				report.QCFormed = true
+				report.QCDelay = durationBetween(block.Timestamp, *block.CertifiedAt)
+			}
+			if block.Finalized {
+				report.Finalized = true
+				report.FinalizationDelay = durationBetween(block.Timestamp, *block.FinalizedAt)
+			}
+		}
+		if len(proposals) > 0 {
+			report.ProposalTimestamp = &proposals[0].Timestamp
+		}
+
+		leader, err := leaders.LeaderForView(view)
+		if err != nil {
+			if !errors.Is(err, model.ErrViewForUnknownEpoch) {
+				return nil, fmt.Errorf("could not determine leader for view %d: %w", view, err)
+			}
+			if len(proposals) > 0 {
+				leader = proposals[0].ProposerID
+			}
+		}
+		report.Leader = leader
+
+		views = append(views, report)
+	}
+	return views, nil
+}
+
+func durationBetween(from, to time.Time) *time.Duration {
+	d := to.Sub(from)
+	return &d
+}
+
+func toBlock(header *flow.Header) *Block {
+	return &Block{
+		BlockID:       header.ID(),
+		ParentID:      header.ParentID,
+		Height:        header.Height,
+		View:          header.View,
+		ParentView:    header.ParentView,
+		ProposerID:    header.ProposerID,
+		Timestamp:     header.Timestamp,
+		HasLastViewTC: header.LastViewTC != nil,
+	}
+}
+
+// finalizationTracker records which blocks Forks finalizes, and which proposal
+// (the one currently being added) triggered the finalization.
+type finalizationTracker struct {
+	notifications.NoopProposalViolationConsumer
+	notifications.NoopFinalizationConsumer
+	blocks  map[flow.Identifier]*Block
+	current *Block
+}
+
+// MakeFinal implements module.Finalizer.
+func (t *finalizationTracker) MakeFinal(blockID flow.Identifier) error {
+	block, ok := t.blocks[blockID]
+	if !ok {
+		return fmt.Errorf("finalized unknown block %v", blockID)
+	}
+	block.Finalized = true
+	if t.current != nil {
+		block.FinalizedAt = &t.current.Timestamp
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/badger/procedure"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// leaders is a LeaderSelection stub, which knows the leaders for a fixed set of views only.
+type leaders map[uint64]flow.Identifier
+
+func (l leaders) LeaderForView(view uint64) (flow.Identifier, error) {
+	leader, ok := l[view]
+	if !ok {
+		return flow.ZeroID, model.ErrViewForUnknownEpoch
+	}
+	return leader, nil
+}
+
+// TestReplay replays the following block tree, where [.] denotes blocks on the finalized chain:
+//
+//	[R:10] <- [A:11] <- [B:12] <- [C:15] <- D:16 <- E:17
+//	             ^-- X:13
+//
+// C includes a TC for view 14. E lies outside the replayed range [11, 16], but is loaded
+// as lookahead and finalizes B and C.
+func TestReplay(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		start := time.Now().UTC().Truncate(time.Millisecond)
+		root := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(100), unittest.HeaderWithView(10))
+		root.Timestamp = start
+		child := func(parent *flow.Header, view uint64, delay time.Duration) *flow.Header {
+			header := unittest.BlockHeaderWithParentFixture(parent)
+			header.View = view
+			header.LastViewTC = nil
+			header.Timestamp = parent.Timestamp.Add(delay)
+			return header
+		}
+		a := child(root, 11, time.Second)
+		b := child(a, 12, time.Second)
+		x := child(a, 13, 2*time.Second)
+		c := child(b, 15, 5*time.Second)
+		c.LastViewTC = &flow.TimeoutCertificate{
+			View:          14,
+			NewestQCViews: []uint64{b.View},
+			NewestQC:      c.QuorumCertificate(),
+			SignerIndices: unittest.SignerIndicesFixture(4),
+			SigData:       unittest.SignatureFixture(),
+		}
+		d := child(c, 16, time.Second)
+		e := child(d, 17, time.Second)
+
+		for _, header := range []*flow.Header{root, a, b, x, c, d, e} {
+			require.NoError(t, db.Update(operation.InsertHeader(header.ID(), header)))
+			parentID := header.ParentID
+			if header == root {
+				parentID = flow.ZeroID
+			}
+			require.NoError(t, db.Update(procedure.IndexNewBlock(header.ID(), parentID)))
+		}
+		for _, header := range []*flow.Header{root, a, b, c} {
+			require.NoError(t, db.Update(operation.IndexBlockHeight(header.Height, header.ID())))
+		}
+		headers := badgerstorage.NewHeaders(metrics.NewNoopCollector(), db)
+
+		leaderIDs := leaders{11: a.ProposerID, 12: b.ProposerID, 13: x.ProposerID, 15: c.ProposerID}
+		report, err := Replay(headers, leaderIDs, root, c, Config{FromView: 11, ToView: 16, Lookahead: 10})
+		require.NoError(t, err)
+
+		assert.Equal(t, root.ID(), report.Root.BlockID)
+		require.Len(t, report.Blocks, 5)
+		require.Len(t, report.Views, 6)
+		views := make(map[uint64]*ViewReport)
+		for _, view := range report.Views {
+			views[view.View] = view
+		}
+
+		// A is certified by B and finalized once C (certifying B) is added
+		assert.Equal(t, []flow.Identifier{a.ID()}, views[11].Proposals)
+		assert.Equal(t, a.ProposerID, views[11].Leader)
+		assert.True(t, views[11].QCFormed)
+		assert.Equal(t, time.Second, *views[11].QCDelay)
+		assert.True(t, views[11].Finalized)
+		assert.Equal(t, c.Timestamp.Sub(a.Timestamp), *views[11].FinalizationDelay)
+
+		// B is finalized by E
+		assert.True(t, views[12].Finalized)
+		assert.Equal(t, e.Timestamp.Sub(b.Timestamp), *views[12].FinalizationDelay)
+
+		// X is orphaned
+		assert.Equal(t, []flow.Identifier{x.ID()}, views[13].Proposals)
+		assert.False(t, views[13].QCFormed)
+		assert.False(t, views[13].Finalized)
+		assert.False(t, views[13].TCNeeded)
+
+		// view 14 has no proposal and was concluded by a TC; its leader is unknown
+		assert.Empty(t, views[14].Proposals)
+		assert.True(t, views[14].TCNeeded)
+		assert.Equal(t, flow.ZeroID, views[14].Leader)
+		assert.Nil(t, views[14].ProposalTimestamp)
+
+		// C is finalized by E
+		assert.True(t, views[15].Finalized)
+		assert.Equal(t, e.Timestamp.Sub(c.Timestamp), *views[15].FinalizationDelay)
+
+		// D is certified by E, but not finalized; the leader falls back to the proposer
+		assert.Equal(t, d.ProposerID, views[16].Leader)
+		assert.True(t, views[16].QCFormed)
+		assert.False(t, views[16].Finalized)
+		assert.Nil(t, views[16].FinalizationDelay)
+
+		var dot bytes.Buffer
+		require.NoError(t, WriteDOT(&dot, report))
+		for _, header := range []*flow.Header{a, b, x, c, d} {
+			assert.Contains(t, dot.String(), fmt.Sprintf("\"%s\" -> \"%s\";", header.ID(), header.ParentID))
+		}
+		assert.NotContains(t, dot.String(), e.ID().String())
+	})
+}
+
+// TestReplay_InvalidRange verifies that an inverted view range is rejected.
+func TestReplay_InvalidRange(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		root := unittest.BlockHeaderFixture()
+		headers := badgerstorage.NewHeaders(metrics.NewNoopCollector(), db)
+		_, err := Replay(headers, leaders{}, root, root, Config{FromView: 10, ToView: 9})
+		require.Error(t, err)
+	})
+}
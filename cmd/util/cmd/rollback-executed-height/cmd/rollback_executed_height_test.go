@@ -33,6 +33,7 @@ func TestReExecuteBlock(t *testing.T) {
 
 		headers := bstorage.NewHeaders(metrics, db)
 		txResults := bstorage.NewTransactionResults(metrics, db, bstorage.DefaultCacheSize)
+		resourceReports := bstorage.NewTransactionResourceReports(db)
 		commits := bstorage.NewCommits(metrics, db)
 		chunkDataPacks := bstorage.NewChunkDataPacks(metrics, db, bstorage.NewCollections(db, bstorage.NewTransactions(metrics, db)), bstorage.DefaultCacheSize)
 		results := bstorage.NewExecutionResults(metrics, db)
@@ -59,6 +60,7 @@ func TestReExecuteBlock(t *testing.T) {
 			events,
 			serviceEvents,
 			txResults,
+			resourceReports,
 			db,
 			trace.NewNoopTracer(),
 			nil,
@@ -84,6 +86,7 @@ func TestReExecuteBlock(t *testing.T) {
 			headers,
 			commits,
 			txResults,
+			resourceReports,
 			results,
 			chunkDataPacks,
 			myReceipts,
@@ -100,6 +103,7 @@ func TestReExecuteBlock(t *testing.T) {
 			headers,
 			commits,
 			txResults,
+			resourceReports,
 			results,
 			chunkDataPacks,
 			myReceipts,
@@ -121,6 +125,7 @@ func TestReExecuteBlock(t *testing.T) {
 			headers,
 			commits,
 			txResults,
+			resourceReports,
 			results,
 			chunkDataPacks,
 			myReceipts,
@@ -157,6 +162,7 @@ func TestReExecuteBlockWithDifferentResult(t *testing.T) {
 
 		headers := bstorage.NewHeaders(metrics, db)
 		txResults := bstorage.NewTransactionResults(metrics, db, bstorage.DefaultCacheSize)
+		resourceReports := bstorage.NewTransactionResourceReports(db)
 		commits := bstorage.NewCommits(metrics, db)
 		chunkDataPacks := bstorage.NewChunkDataPacks(metrics, db, bstorage.NewCollections(db, bstorage.NewTransactions(metrics, db)), bstorage.DefaultCacheSize)
 		results := bstorage.NewExecutionResults(metrics, db)
@@ -183,6 +189,7 @@ func TestReExecuteBlockWithDifferentResult(t *testing.T) {
 			events,
 			serviceEvents,
 			txResults,
+			resourceReports,
 			db,
 			trace.NewNoopTracer(),
 			nil,
@@ -215,6 +222,7 @@ func TestReExecuteBlockWithDifferentResult(t *testing.T) {
 			headers,
 			commits,
 			txResults,
+			resourceReports,
 			results,
 			chunkDataPacks,
 			myReceipts,
@@ -236,6 +244,7 @@ func TestReExecuteBlockWithDifferentResult(t *testing.T) {
 			headers,
 			commits,
 			txResults,
+			resourceReports,
 			results,
 			chunkDataPacks,
 			myReceipts,
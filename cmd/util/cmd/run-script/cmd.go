@@ -463,6 +463,22 @@ func (*api) GetExecutionResultByID(_ context.Context, _ flow.Identifier) (*flow.
 	return nil, errors.New("unimplemented")
 }
 
+func (*api) GetEmergencySealsByBlockID(_ context.Context, _ flow.Identifier) ([]*flow.EmergencySeal, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetRegisterProofs(_ context.Context, _ flow.Identifier, _ flow.RegisterIDs) (*access.RegisterProofs, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetTransactionResourceReport(_ context.Context, _ flow.Identifier, _ flow.Identifier) (*flow.TransactionResourceReport, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetTransactionResourceReportsByBlockID(_ context.Context, _ flow.Identifier) ([]flow.TransactionResourceReport, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (*api) SubscribeBlocksFromStartBlockID(
 	_ context.Context,
 	_ flow.Identifier,
@@ -0,0 +1,220 @@
+package verify_checkpoint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+var (
+	flagCheckpointDir  string
+	flagCheckpointFile string
+	flagWorkers        int
+	flagDatadir        string
+	flagSealedLookback uint64
+	flagRepair         bool
+)
+
+// verify a checkpoint file fully, which is more thorough than the checksum validation
+// done when loading a checkpoint:
+// - the checksums of the header and all part files are verified
+// - the hash of every node is recomputed from its payload or its children
+// - the register count and size of every trie is recomputed from its registers
+// - optionally, the trie root hashes are cross-checked against the sealed state commitments in the protocol database
+// the subtrie part files are verified concurrently. if a part file is corrupted, it can be regenerated
+// from the WAL files in the checkpoint directory with the --repair flag.
+// incremental checkpoints are verified together with their base checkpoints, which must be in the
+// checkpoint directory. they consist of a single file, and can't be repaired.
+var Cmd = &cobra.Command{
+	Use:   "verify-checkpoint",
+	Short: "verify node hashes and metadata of a checkpoint, and optionally repair corrupted part files from the WAL",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpointDir, "checkpoint-dir", "",
+		"directory containing the checkpoint files and the WAL")
+	_ = Cmd.MarkFlagRequired("checkpoint-dir")
+
+	Cmd.Flags().StringVar(&flagCheckpointFile, "checkpoint-file", "",
+		"checkpoint file name, such as checkpoint.00000012 or root.checkpoint")
+	_ = Cmd.MarkFlagRequired("checkpoint-file")
+
+	Cmd.Flags().IntVar(&flagWorkers, "workers", 16,
+		"number of part files to verify concurrently")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory to the protocol database. if set, the trie root hashes are cross-checked against sealed state commitments")
+
+	Cmd.Flags().Uint64Var(&flagSealedLookback, "sealed-lookback", 1000,
+		"number of sealed blocks, starting from the latest sealed block, to cross-check the trie root hashes against")
+
+	Cmd.Flags().BoolVar(&flagRepair, "repair", false,
+		"regenerate corrupted part files from the WAL")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().Msgf("verifying checkpoint %v in %v with %d workers", flagCheckpointFile, flagCheckpointDir, flagWorkers)
+
+	result, err := wal.VerifyCheckpointV6(flagCheckpointDir, flagCheckpointFile, flagWorkers, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not verify checkpoint")
+	}
+
+	if result.Incremental {
+		log.Info().Msgf("checkpoint is an incremental checkpoint, verified against base checkpoint %d", result.BaseCheckpoint)
+	}
+
+	for _, part := range result.Parts {
+		switch {
+		case part.Skipped:
+			log.Warn().Int("part", part.Index).Msg("part file skipped, because subtrie part files are corrupted")
+		case part.Err != nil:
+			log.Error().Err(part.Err).Int("part", part.Index).Msg("part file is corrupted")
+		case part.CorruptedNode != nil:
+			log.Error().Int("part", part.Index).Msgf("part file has corrupted node: %v", part.CorruptedNode)
+		default:
+			log.Info().Int("part", part.Index).Uint64("nodes", part.NodeCount).Msg("part file is valid")
+		}
+	}
+
+	for i, t := range result.Tries {
+		if !t.Valid() {
+			log.Error().
+				Int("trie", i).
+				Str("root_hash", t.RootHash.String()).
+				Uint64("reg_count", t.RegCount).
+				Uint64("computed_reg_count", t.ComputedRegCount).
+				Uint64("reg_size", t.RegSize).
+				Uint64("computed_reg_size", t.ComputedRegSize).
+				Msg("trie has wrong register metadata")
+		}
+	}
+
+	if result.Valid() {
+		log.Info().Msgf("checkpoint is valid, %d tries verified", len(result.Tries))
+		if flagDatadir != "" {
+			crossCheckSealedCommitments(result.Tries)
+		}
+		return
+	}
+
+	corruptedParts := result.CorruptedParts()
+	if !flagRepair || len(corruptedParts) == 0 {
+		log.Fatal().Ints("corrupted_parts", corruptedParts).Msg("checkpoint is corrupted")
+	}
+	if result.Incremental {
+		log.Fatal().Msgf("incremental checkpoint is corrupted and can't be repaired from the WAL, verify its base checkpoint %d", result.BaseCheckpoint)
+	}
+
+	checkpoint, err := checkpointNumber(flagCheckpointFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("checkpoint can't be repaired from the WAL")
+	}
+
+	log.Info().Ints("corrupted_parts", corruptedParts).Msgf("regenerating part files of checkpoint %d from the WAL", checkpoint)
+
+	diskWal, err := wal.NewDiskWAL(log.Logger, nil, metrics.NewNoopCollector(), flagCheckpointDir,
+		complete.DefaultCacheSize, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open WAL")
+	}
+	defer func() {
+		<-diskWal.Done()
+	}()
+
+	err = diskWal.RegenerateCheckpointPartFiles(checkpoint, corruptedParts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not regenerate part files")
+	}
+
+	result, err = wal.VerifyCheckpointV6(flagCheckpointDir, flagCheckpointFile, flagWorkers, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not verify repaired checkpoint")
+	}
+	if !result.Valid() {
+		log.Fatal().Ints("corrupted_parts", result.CorruptedParts()).Msg("repaired checkpoint is still corrupted")
+	}
+
+	log.Info().Msgf("checkpoint repaired, %d tries verified", len(result.Tries))
+}
+
+// checkpointNumber returns the number of a numbered checkpoint file. The root checkpoint has no number,
+// and can't be regenerated from the WAL.
+func checkpointNumber(fileName string) (int, error) {
+	justNumber, ok := strings.CutPrefix(fileName, "checkpoint.")
+	if !ok {
+		return 0, fmt.Errorf("%v is not a numbered checkpoint file", fileName)
+	}
+	return strconv.Atoi(justNumber)
+}
+
+// crossCheckSealedCommitments checks which trie root hashes of the checkpoint match the sealed state
+// commitments of the latest sealed blocks.
+func crossCheckSealedCommitments(tries []*wal.TrieVerification) {
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	sealed, err := state.Sealed().Head()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get latest sealed block")
+	}
+
+	rootHashes := make(map[ledger.RootHash]struct{}, len(tries))
+	for _, t := range tries {
+		rootHashes[t.RootHash] = struct{}{}
+	}
+
+	lowest := uint64(0)
+	if sealed.Height > flagSealedLookback {
+		lowest = sealed.Height - flagSealedLookback
+	}
+
+	matched := 0
+	for height := sealed.Height; height > lowest; height-- {
+		blockID, err := storages.Headers.BlockIDByHeight(height)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("could not get block at height %d", height)
+		}
+
+		seal, err := storages.Seals.FinalizedSealForBlock(blockID)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("could not get seal for block %v at height %d", blockID, height)
+		}
+
+		if _, ok := rootHashes[ledger.RootHash(seal.FinalState)]; !ok {
+			continue
+		}
+
+		if matched == 0 {
+			log.Info().Msgf("latest sealed block in checkpoint: height %d, block %v, state commitment %v",
+				height, blockID, seal.FinalState)
+		}
+		matched++
+	}
+
+	if matched == 0 {
+		log.Warn().Msgf("none of the %d tries match the sealed state commitments between heights %d and %d",
+			len(tries), lowest+1, sealed.Height)
+		return
+	}
+
+	log.Info().Msgf("%d sealed state commitments between heights %d and %d match tries of the checkpoint",
+		matched, lowest+1, sealed.Height)
+}
@@ -0,0 +1,82 @@
+package verify_execution_data_sink
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/sink"
+)
+
+var (
+	flagDir     string
+	flagDatadir string
+)
+
+// Cmd verifies the segments written by an execution data sink against its manifest.
+// If the protocol state is provided, the execution data ID of each record is also compared with the
+// execution data ID of the sealed execution result of the block.
+var Cmd = &cobra.Command{
+	Use:   "verify-execution-data-sink",
+	Short: "verifies the execution data segments written by an execution data sink",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDir, "dir", "",
+		"directory of the execution data sink")
+	_ = Cmd.MarkFlagRequired("dir")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state. if set, the execution data IDs are compared with the sealed execution results")
+}
+
+func run(*cobra.Command, []string) {
+	var lookupID sink.LookupExecutionDataID
+	if flagDatadir != "" {
+		db := common.InitStorage(flagDatadir)
+		defer db.Close()
+
+		storages := common.InitStorages(db)
+		lookupID = func(blockID flow.Identifier) (flow.Identifier, error) {
+			seal, err := storages.Seals.FinalizedSealForBlock(blockID)
+			if err != nil {
+				return flow.ZeroID, fmt.Errorf("could not get seal for block: %w", err)
+			}
+			result, err := storages.Results.ByID(seal.ResultID)
+			if err != nil {
+				return flow.ZeroID, fmt.Errorf("could not get execution result: %w", err)
+			}
+			return result.ExecutionDataID, nil
+		}
+	}
+
+	log.Info().Str("dir", flagDir).Msg("verifying execution data sink")
+
+	report, err := sink.Verify(flagDir, lookupID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not verify execution data sink")
+	}
+
+	for _, msg := range report.Errors {
+		log.Error().Msg(msg)
+	}
+
+	log := log.With().
+		Int("segments", report.Segments).
+		Uint64("records", report.Records).
+		Uint64("first_height", report.FirstHeight).
+		Uint64("last_height", report.LastHeight).
+		Int("gaps", report.Gaps).
+		Int("errors", len(report.Errors)).
+		Logger()
+
+	if len(report.Errors) > 0 {
+		log.Fatal().Msg("execution data sink is inconsistent")
+	}
+
+	log.Info().Msg("execution data sink is consistent")
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/flow-go/fvm/evm/emulator/state"
+	"github.com/onflow/flow-go/fvm/evm/handler"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// DomainRegisterTypePrefix is the prefix of the types of storage domain registers,
+	// followed by the storage domain.
+	DomainRegisterTypePrefix = "domain "
+
+	// OtherRegisterType is the type of registers with unknown keys.
+	OtherRegisterType = "others"
+)
+
+const (
+	// EVM register keys from fvm/evm/handler/blockHashList.go
+	blockHashListMetaKey         = "BlockHashListMeta"
+	blockHashListBucketKeyPrefix = "BlockHashListBucket"
+)
+
+// RegisterType returns a human-readable type of the register with the given key,
+// such as "account status", "public key", "contract content", "atree slab", or
+// DomainRegisterTypePrefix followed by the storage domain.
+// OtherRegisterType is returned for unknown register keys.
+func RegisterType(key ledger.Key) string {
+	k := key.KeyParts[1].Value
+	kstr := string(k)
+
+	if atree.LedgerKeyIsSlabKey(kstr) {
+		return "atree slab"
+	}
+
+	isDomain := slices.Contains(StorageMapDomains, kstr)
+	if isDomain {
+		return DomainRegisterTypePrefix + kstr
+	}
+
+	switch kstr {
+	case flow.ContractNamesKey:
+		return "contract names"
+	case flow.AccountStatusKey:
+		return "account status"
+	case flow.AddressStateKey:
+		return "address generator state"
+	case state.AccountsStorageIDKey:
+		return "account storage ID"
+	case state.CodesStorageIDKey:
+		return "code storage ID"
+	case handler.BlockStoreLatestBlockKey:
+		return "latest block"
+	case handler.BlockStoreLatestBlockProposalKey:
+		return "latest block proposal"
+	}
+
+	// other fvm registers
+	if kstr == "uuid" || strings.HasPrefix(kstr, "uuid_") {
+		return "uuid generator state"
+	}
+	if strings.HasPrefix(kstr, "public_key_") {
+		return "public key"
+	}
+	if strings.HasPrefix(kstr, flow.CodeKeyPrefix) {
+		return "contract content"
+	}
+
+	// other evm registers
+	if strings.HasPrefix(kstr, blockHashListBucketKeyPrefix) {
+		return "block hash list bucket"
+	}
+	if strings.HasPrefix(kstr, blockHashListMetaKey) {
+		return "block hash list meta"
+	}
+
+	return OtherRegisterType
+}
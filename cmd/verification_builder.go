@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/onflow/flow-go/admin/commands"
+	verificationCommands "github.com/onflow/flow-go/admin/commands/verification"
 	flowconsensus "github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
@@ -24,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/verification/fetcher"
 	"github.com/onflow/flow-go/engine/verification/fetcher/chunkconsumer"
 	"github.com/onflow/flow-go/engine/verification/requester"
+	"github.com/onflow/flow-go/engine/verification/reverifier"
 	"github.com/onflow/flow-go/engine/verification/verifier"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
@@ -54,6 +57,8 @@ type VerificationConfig struct {
 	chunkWorkers uint64 // number of chunks processed in parallel.
 
 	stopAtHeight uint64 // height to stop the node on
+
+	verificationRecordsRetention uint64 // number of heights below the highest verified block to keep verification records for.
 }
 
 type VerificationNodeBuilder struct {
@@ -81,6 +86,7 @@ func (v *VerificationNodeBuilder) LoadFlags() {
 			flags.Uint64Var(&v.verConf.blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 			flags.Uint64Var(&v.verConf.chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
 			flags.Uint64Var(&v.verConf.stopAtHeight, "stop-at-height", 0, "height to stop the node at (0 to disable)")
+			flags.Uint64Var(&v.verConf.verificationRecordsRetention, "verification-records-retention", verifier.DefaultVerificationRecordsRetention, "number of heights below the highest verified block to keep chunk verification records for, records of lower heights are pruned (0 to keep all records)")
 		})
 }
 
@@ -99,6 +105,9 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 		fetcherEngine       *fetcher.Engine   // the fetcher engine
 		requesterEngine     *requester.Engine // the requester engine
 		verifierEng         *verifier.Engine  // the verifier engine
+		chunkVerifier       module.ChunkVerifier
+		chunkReverifier     *reverifier.Reverifier           // re-verifies chunks of sealed results on demand
+		verificationRecords *badger.ChunkVerificationRecords // history of verified chunks
 		chunkConsumer       *chunkconsumer.ChunkConsumer
 		blockConsumer       *blockconsumer.BlockConsumer
 		followerDistributor *pubsub.FollowerDistributor
@@ -111,6 +120,12 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 
 	v.FlowNodeBuilder.
 		PreInit(DynamicStartPreInit).
+		AdminCommand("reverify-chunk", func(config *NodeConfig) commands.AdminCommand {
+			return verificationCommands.NewReverifyChunkCommand(chunkReverifier)
+		}).
+		AdminCommand("get-execution-node-verification-stats", func(config *NodeConfig) commands.AdminCommand {
+			return verificationCommands.NewGetExecutionNodeVerificationStatsCommand(verificationRecords)
+		}).
 		Module("mutable follower state", func(node *NodeConfig) error {
 			var err error
 			// For now, we only support state implementations from package badger.
@@ -200,8 +215,9 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			fvmOptions = append(fvmOptions, computation.DefaultFVMOptions(node.RootChainID, false, false)...)
 			vmCtx := fvm.NewContext(fvmOptions...)
 
-			chunkVerifier := chunks.NewChunkVerifier(vm, vmCtx, node.Logger)
+			chunkVerifier = chunks.NewChunkVerifier(vm, vmCtx, node.Logger)
 			approvalStorage := badger.NewResultApprovals(node.Metrics.Cache, node.DB)
+			verificationRecords = badger.NewChunkVerificationRecords(node.DB)
 			verifierEng, err = verifier.New(
 				node.Logger,
 				collector,
@@ -210,7 +226,9 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 				node.State,
 				node.Me,
 				chunkVerifier,
-				approvalStorage)
+				approvalStorage,
+				verificationRecords,
+				v.verConf.verificationRecordsRetention)
 			return verifierEng, err
 		}).
 		Component("chunk consumer, requester, and fetcher engines", func(node *NodeConfig) (module.ReadyDoneAware, error) {
@@ -235,6 +253,21 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 				return nil, fmt.Errorf("could not create requester engine: %w", err)
 			}
 
+			// the reverifier sits between the requester and fetcher engines, and intercepts the chunk data packs
+			// requested for on-demand re-verification of sealed chunks.
+			chunkReverifier = reverifier.New(
+				node.Logger,
+				node.Me,
+				node.State,
+				node.Storage.Headers,
+				node.Storage.Blocks,
+				node.Storage.Results,
+				node.Storage.Receipts,
+				node.Storage.Seals,
+				requesterEngine,
+				chunkVerifier,
+				reverifier.DefaultRequestTimeout)
+
 			fetcherEngine = fetcher.New(
 				node.Logger,
 				collector,
@@ -246,7 +279,7 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 				node.Storage.Blocks,
 				node.Storage.Results,
 				node.Storage.Receipts,
-				requesterEngine,
+				chunkReverifier,
 				v.verConf.stopAtHeight)
 
 			// requester and fetcher engines are started by chunk consumer
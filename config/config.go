@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/onflow/flow-go/network/netconf"
+	"github.com/onflow/flow-go/network/p2p/unicast/protocols"
 )
 
 var (
@@ -49,6 +50,13 @@ func (fc *FlowConfig) Validate() error {
 		}
 		return fmt.Errorf("unexpeceted error encountered while validating flow configuration: %w", err)
 	}
+
+	err = protocols.ValidateChannelProtocols(
+		fc.NetworkConfig.Unicast.UnicastManager.ChannelProtocols,
+		protocols.ToProtocolNames(fc.NetworkConfig.PreferredUnicastProtocols))
+	if err != nil {
+		return fmt.Errorf("failed to validate flow configuration: invalid channel unicast protocols: %w", err)
+	}
 	return nil
 }
 
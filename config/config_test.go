@@ -64,6 +64,23 @@ func TestFlowConfig_Validate(t *testing.T) {
 	require.Len(t, errs, 2)
 }
 
+// TestFlowConfig_ValidateChannelProtocols ensures the Flow config is only valid if the preferred protocols of channels are
+// either preferred unicast protocols, or plain.
+func TestFlowConfig_ValidateChannelProtocols(t *testing.T) {
+	c := defaultConfig(t)
+	c.NetworkConfig.PreferredUnicastProtocols = []string{"gzip-compression", "zstd-compression"}
+
+	c.NetworkConfig.Unicast.UnicastManager.ChannelProtocols = []string{"sync-committee=zstd-compression", "push-blocks=plain"}
+	require.NoError(t, c.Validate())
+
+	// lz4-compression is a known unicast protocol, but it is not registered since it is not preferred
+	c.NetworkConfig.Unicast.UnicastManager.ChannelProtocols = []string{"sync-committee=lz4-compression"}
+	require.Error(t, c.Validate())
+
+	c.NetworkConfig.Unicast.UnicastManager.ChannelProtocols = []string{"sync-committee=unknown"}
+	require.Error(t, c.Validate())
+}
+
 // TestUnmarshall_UnsetFields ensures that if the config store has any missing config values an error is returned when the config is decoded into a Flow config.
 func TestUnmarshall_UnsetFields(t *testing.T) {
 	conf = viper.New()
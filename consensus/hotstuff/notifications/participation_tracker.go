@@ -0,0 +1,439 @@
+package notifications
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+const (
+	// maxTrackedEpochs is the number of most recent epochs for which participation statistics are retained.
+	maxTrackedEpochs = 3
+	// maxVoteDelaySamples is the number of most recent vote delays retained per replica for computing the median.
+	maxVoteDelaySamples = 1000
+)
+
+// ReplicaParticipation summarizes the participation of a single consensus replica within one epoch.
+type ReplicaParticipation struct {
+	NodeID flow.Identifier `json:"node_id"`
+	// ParticipationRatio is the fraction of the observed views, in which the replica voted or timed out.
+	ParticipationRatio float64 `json:"participation_ratio"`
+	// ParticipatedViews is the number of observed views, in which the replica voted or timed out.
+	ParticipatedViews uint64 `json:"participated_views"`
+	Votes             uint64 `json:"votes"`
+	Timeouts          uint64 `json:"timeouts"`
+	// MedianVoteDelay is the median time from incorporating a proposal to processing the replica's
+	// vote for it, over the most recent votes. Zero if no delays have been measured.
+	MedianVoteDelay time.Duration `json:"median_vote_delay"`
+	DoubleVotes     uint64        `json:"double_votes"`
+	InvalidVotes    uint64        `json:"invalid_votes"`
+	DoubleTimeouts  uint64        `json:"double_timeouts"`
+	InvalidTimeouts uint64        `json:"invalid_timeouts"`
+}
+
+// EpochParticipation summarizes the participation of all consensus replicas within one epoch.
+type EpochParticipation struct {
+	Epoch uint64 `json:"epoch"`
+	// ObservedViews is the number of views, for which this node processed at least one vote or timeout.
+	ObservedViews uint64                  `json:"observed_views"`
+	Replicas      []*ReplicaParticipation `json:"replicas"`
+}
+
+// ParticipationTracker consumes vote and timeout aggregation notifications and keeps, per epoch
+// and per consensus participant, statistics on how actively the replica participates in HotStuff.
+//
+// The statistics are local observations: votes are only sent to the leader of the next view,
+// so this node only processes votes for views in which it collects votes. Timeouts are broadcast
+// to all replicas. Hence, a view counts as observed if this node processed at least one vote or
+// timeout for it, and a replica's participation ratio is the fraction of observed views in which
+// its vote or timeout was processed.
+//
+// ParticipationTracker is safe for concurrent use and non-blocking.
+type ParticipationTracker struct {
+	NoopVoteCollectorConsumer
+	NoopTimeoutCollectorConsumer
+	log         zerolog.Logger
+	metrics     module.HotstuffParticipationMetrics
+	committee   hotstuff.Replicas
+	epochLookup module.EpochLookup
+
+	// mu guards the fields below. The epoch and committee lookups are done without holding the lock.
+	mu sync.Mutex
+	// finalizedView is the view of the latest finalized block; events for views at or below are ignored
+	finalizedView uint64
+	// proposals holds the time a proposal for the view was first incorporated, for views above finalizedView
+	proposals map[uint64]time.Time
+	// participants holds the replicas which voted or timed out in a view, for views above finalizedView
+	participants map[uint64]map[flow.Identifier]struct{}
+	epochs       map[uint64]*epochParticipation
+	// currentEpoch is the highest epoch counter observed so far
+	currentEpoch uint64
+	// reported holds the replicas for which a participation ratio has been reported, so that the ratios
+	// of replicas which are not participants of the current epoch are removed
+	reported map[flow.Identifier]struct{}
+}
+
+var _ hotstuff.VoteAggregationConsumer = (*ParticipationTracker)(nil)
+var _ hotstuff.TimeoutAggregationConsumer = (*ParticipationTracker)(nil)
+var _ hotstuff.FinalizationConsumer = (*ParticipationTracker)(nil)
+
+// epochParticipation holds the participation statistics for one epoch.
+type epochParticipation struct {
+	observedViews uint64
+	replicas      map[flow.Identifier]*replicaParticipation
+}
+
+// epochCommittee holds the epoch containing a view, and the consensus committee of that epoch.
+type epochCommittee struct {
+	counter    uint64
+	identities flow.IdentitySkeletonList
+}
+
+// replicaParticipation holds the participation statistics for one replica within one epoch.
+type replicaParticipation struct {
+	participatedViews uint64
+	votes             uint64
+	timeouts          uint64
+	// voteDelays is a ring buffer of the most recent vote delays
+	voteDelays      []time.Duration
+	nextVoteDelay   int
+	doubleVotes     uint64
+	invalidVotes    uint64
+	doubleTimeouts  uint64
+	invalidTimeouts uint64
+}
+
+// NewParticipationTracker creates a new ParticipationTracker. The committee is used to list all
+// participants of an epoch (including ones which never voted), and the epoch lookup to attribute
+// views to epochs.
+func NewParticipationTracker(
+	log zerolog.Logger,
+	metrics module.HotstuffParticipationMetrics,
+	committee hotstuff.Replicas,
+	epochLookup module.EpochLookup,
+	finalizedView uint64,
+) *ParticipationTracker {
+	return &ParticipationTracker{
+		log:           log.With().Str("component", "hotstuff_participation_tracker").Logger(),
+		metrics:       metrics,
+		committee:     committee,
+		epochLookup:   epochLookup,
+		finalizedView: finalizedView,
+		proposals:     make(map[uint64]time.Time),
+		participants:  make(map[uint64]map[flow.Identifier]struct{}),
+		epochs:        make(map[uint64]*epochParticipation),
+		reported:      make(map[flow.Identifier]struct{}),
+	}
+}
+
+// OnBlockIncorporated records the time the first proposal for the block's view was incorporated,
+// as reference for measuring vote delays.
+func (t *ParticipationTracker) OnBlockIncorporated(block *model.Block) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if block.View <= t.finalizedView {
+		return
+	}
+	if _, ok := t.proposals[block.View]; !ok {
+		t.proposals[block.View] = time.Now()
+	}
+}
+
+// OnFinalizedBlock prunes all per-view state up to the finalized view and reports the
+// participation ratios for the current epoch. The ratios of replicas which are not participants
+// of the current epoch are removed.
+func (t *ParticipationTracker) OnFinalizedBlock(block *model.Block) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if block.View <= t.finalizedView {
+		return
+	}
+	t.finalizedView = block.View
+	for view := range t.proposals {
+		if view <= block.View {
+			delete(t.proposals, view)
+		}
+	}
+	for view := range t.participants {
+		if view <= block.View {
+			delete(t.participants, view)
+		}
+	}
+
+	epoch, ok := t.epochs[t.currentEpoch]
+	if !ok || epoch.observedViews == 0 {
+		return
+	}
+	for nodeID, replica := range epoch.replicas {
+		t.metrics.ReplicaParticipation(nodeID, float64(replica.participatedViews)/float64(epoch.observedViews))
+		t.reported[nodeID] = struct{}{}
+	}
+	for nodeID := range t.reported {
+		if _, ok := epoch.replicas[nodeID]; !ok {
+			t.metrics.RemoveReplicaParticipation(nodeID)
+			delete(t.reported, nodeID)
+		}
+	}
+}
+
+// OnVoteProcessed records the vote's signer as participant of the vote's view and measures the
+// delay relative to the proposal, if the proposal has been incorporated already.
+func (t *ParticipationTracker) OnVoteProcessed(vote *model.Vote) {
+	now := time.Now()
+	epoch, ok := t.lookupEpoch(vote.View)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	replica, ok := t.participate(epoch, vote.View, vote.SignerID)
+	if !ok {
+		return
+	}
+	replica.votes++
+	proposed, ok := t.proposals[vote.View]
+	if !ok {
+		return
+	}
+	delay := now.Sub(proposed)
+	replica.addVoteDelay(delay)
+	t.metrics.ReplicaVoteDelay(vote.SignerID, delay)
+}
+
+// OnTimeoutProcessed records the timeout's signer as participant of the timeout's view.
+func (t *ParticipationTracker) OnTimeoutProcessed(timeout *model.TimeoutObject) {
+	epoch, ok := t.lookupEpoch(timeout.View)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	replica, ok := t.participate(epoch, timeout.View, timeout.SignerID)
+	if !ok {
+		return
+	}
+	replica.timeouts++
+}
+
+// OnDoubleVotingDetected counts a double vote for the signer.
+func (t *ParticipationTracker) OnDoubleVotingDetected(vote *model.Vote, _ *model.Vote) {
+	epoch, ok := t.lookupEpoch(vote.View)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	replica := t.epoch(epoch).replica(vote.SignerID)
+	replica.doubleVotes++
+	t.metrics.ReplicaViolation(vote.SignerID, metrics.HotstuffViolationDoubleVote)
+}
+
+// OnInvalidVoteDetected counts an invalid vote for the signer.
+func (t *ParticipationTracker) OnInvalidVoteDetected(err model.InvalidVoteError) {
+	t.countInvalidVote(err.Vote)
+}
+
+// OnVoteForInvalidBlockDetected counts an invalid vote for the signer.
+func (t *ParticipationTracker) OnVoteForInvalidBlockDetected(vote *model.Vote, _ *model.SignedProposal) {
+	t.countInvalidVote(vote)
+}
+
+// OnDoubleTimeoutDetected counts a double timeout for the signer.
+func (t *ParticipationTracker) OnDoubleTimeoutDetected(timeout *model.TimeoutObject, _ *model.TimeoutObject) {
+	epoch, ok := t.lookupEpoch(timeout.View)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	replica := t.epoch(epoch).replica(timeout.SignerID)
+	replica.doubleTimeouts++
+	t.metrics.ReplicaViolation(timeout.SignerID, metrics.HotstuffViolationDoubleTimeout)
+}
+
+// OnInvalidTimeoutDetected counts an invalid timeout for the signer.
+func (t *ParticipationTracker) OnInvalidTimeoutDetected(err model.InvalidTimeoutError) {
+	epoch, ok := t.lookupEpoch(err.Timeout.View)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	replica := t.epoch(epoch).replica(err.Timeout.SignerID)
+	replica.invalidTimeouts++
+	t.metrics.ReplicaViolation(err.Timeout.SignerID, metrics.HotstuffViolationInvalidTimeout)
+}
+
+// Participation returns the participation statistics for all tracked epochs, ordered by epoch
+// counter. Replicas are ordered by node ID.
+func (t *ParticipationTracker) Participation() []*EpochParticipation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]*EpochParticipation, 0, len(t.epochs))
+	for counter, epoch := range t.epochs {
+		summary := &EpochParticipation{
+			Epoch:         counter,
+			ObservedViews: epoch.observedViews,
+			Replicas:      make([]*ReplicaParticipation, 0, len(epoch.replicas)),
+		}
+		for nodeID, replica := range epoch.replicas {
+			summary.Replicas = append(summary.Replicas, replica.summary(nodeID, epoch.observedViews))
+		}
+		sort.Slice(summary.Replicas, func(i, j int) bool {
+			return summary.Replicas[i].NodeID.String() < summary.Replicas[j].NodeID.String()
+		})
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Epoch < result[j].Epoch
+	})
+	return result
+}
+
+func (t *ParticipationTracker) countInvalidVote(vote *model.Vote) {
+	epoch, ok := t.lookupEpoch(vote.View)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	replica := t.epoch(epoch).replica(vote.SignerID)
+	replica.invalidVotes++
+	t.metrics.ReplicaViolation(vote.SignerID, metrics.HotstuffViolationInvalidVote)
+}
+
+// participate records that the replica voted or timed out in the given view and returns its
+// statistics. The first vote or timeout for a view marks the view as observed. Returns false
+// if the view is already finalized.
+// CAUTION: caller must hold the lock.
+func (t *ParticipationTracker) participate(committee epochCommittee, view uint64, nodeID flow.Identifier) (*replicaParticipation, bool) {
+	if view <= t.finalizedView {
+		return nil, false
+	}
+	epoch := t.epoch(committee)
+	participants, ok := t.participants[view]
+	if !ok {
+		participants = make(map[flow.Identifier]struct{})
+		t.participants[view] = participants
+		epoch.observedViews++
+	}
+	replica := epoch.replica(nodeID)
+	if _, ok := participants[nodeID]; !ok {
+		participants[nodeID] = struct{}{}
+		replica.participatedViews++
+	}
+	return replica, true
+}
+
+// lookupEpoch looks up the epoch containing the given view, and the consensus committee of that
+// epoch. Returns false if the view cannot be attributed to a known epoch.
+// CAUTION: must be called without holding the lock, as the lookups may access the protocol state.
+func (t *ParticipationTracker) lookupEpoch(view uint64) (epochCommittee, bool) {
+	counter, err := t.epochLookup.EpochForView(view)
+	if err != nil {
+		if !errors.Is(err, model.ErrViewForUnknownEpoch) {
+			t.log.Error().Err(err).Uint64("view", view).Msg("could not look up epoch for view")
+		}
+		return epochCommittee{}, false
+	}
+	identities, err := t.committee.IdentitiesByEpoch(view)
+	if err != nil {
+		if !errors.Is(err, model.ErrViewForUnknownEpoch) {
+			t.log.Error().Err(err).Uint64("view", view).Msg("could not retrieve consensus committee for view")
+		}
+		return epochCommittee{}, false
+	}
+	return epochCommittee{counter: counter, identities: identities}, true
+}
+
+// epoch returns the statistics for the given epoch, initializing them with all participants of
+// the epoch on first access.
+// CAUTION: caller must hold the lock.
+func (t *ParticipationTracker) epoch(committee epochCommittee) *epochParticipation {
+	epoch, ok := t.epochs[committee.counter]
+	if ok {
+		return epoch
+	}
+
+	epoch = &epochParticipation{replicas: make(map[flow.Identifier]*replicaParticipation)}
+	for _, identity := range committee.identities {
+		epoch.replica(identity.NodeID)
+	}
+	t.epochs[committee.counter] = epoch
+	if committee.counter > t.currentEpoch {
+		t.currentEpoch = committee.counter
+	}
+
+	// retain only the most recent epochs
+	for old := range t.epochs {
+		if old+maxTrackedEpochs <= t.currentEpoch {
+			delete(t.epochs, old)
+		}
+	}
+	return epoch
+}
+
+func (e *epochParticipation) replica(nodeID flow.Identifier) *replicaParticipation {
+	replica, ok := e.replicas[nodeID]
+	if !ok {
+		replica = &replicaParticipation{}
+		e.replicas[nodeID] = replica
+	}
+	return replica
+}
+
+func (r *replicaParticipation) addVoteDelay(delay time.Duration) {
+	if len(r.voteDelays) < maxVoteDelaySamples {
+		r.voteDelays = append(r.voteDelays, delay)
+		return
+	}
+	r.voteDelays[r.nextVoteDelay] = delay
+	r.nextVoteDelay = (r.nextVoteDelay + 1) % maxVoteDelaySamples
+}
+
+func (r *replicaParticipation) summary(nodeID flow.Identifier, observedViews uint64) *ReplicaParticipation {
+	summary := &ReplicaParticipation{
+		NodeID:            nodeID,
+		ParticipatedViews: r.participatedViews,
+		Votes:             r.votes,
+		Timeouts:          r.timeouts,
+		DoubleVotes:       r.doubleVotes,
+		InvalidVotes:      r.invalidVotes,
+		DoubleTimeouts:    r.doubleTimeouts,
+		InvalidTimeouts:   r.invalidTimeouts,
+	}
+	if observedViews > 0 {
+		summary.ParticipationRatio = float64(r.participatedViews) / float64(observedViews)
+	}
+	if len(r.voteDelays) > 0 {
+		delays := make([]time.Duration, len(r.voteDelays))
+		copy(delays, r.voteDelays)
+		sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+		summary.MedianVoteDelay = delays[len(delays)/2]
+	}
+	return summary
+}
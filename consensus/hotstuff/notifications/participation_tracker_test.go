@@ -0,0 +1,201 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/helper"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestParticipationTracker(t *testing.T) {
+	suite.Run(t, new(ParticipationTrackerSuite))
+}
+
+// ParticipationTrackerSuite tests the ParticipationTracker for a committee of 4 replicas,
+// with epoch 1 spanning views [0, 99] and epoch 2 spanning views [100, 199].
+type ParticipationTrackerSuite struct {
+	suite.Suite
+
+	participants flow.IdentityList
+	committee    *mocks.Replicas
+	epochLookup  *modulemock.EpochLookup
+	metrics      *modulemock.HotstuffParticipationMetrics
+	tracker      *ParticipationTracker
+}
+
+func (s *ParticipationTrackerSuite) SetupTest() {
+	s.participants = unittest.IdentityListFixture(4)
+	s.committee = mocks.NewReplicas(s.T())
+	s.committee.On("IdentitiesByEpoch", mock.Anything).Return(s.participants.ToSkeleton(), nil).Maybe()
+	s.epochLookup = modulemock.NewEpochLookup(s.T())
+	s.epochLookup.On("EpochForView", mock.Anything).Return(
+		func(view uint64) uint64 {
+			return view/100 + 1
+		},
+		func(view uint64) error {
+			if view >= 200 {
+				return model.ErrViewForUnknownEpoch
+			}
+			return nil
+		},
+	).Maybe()
+	s.metrics = modulemock.NewHotstuffParticipationMetrics(s.T())
+	s.metrics.On("ReplicaVoteDelay", mock.Anything, mock.Anything).Maybe()
+	s.metrics.On("ReplicaParticipation", mock.Anything, mock.Anything).Maybe()
+
+	s.tracker = NewParticipationTracker(unittest.Logger(), s.metrics, s.committee, s.epochLookup, 9)
+}
+
+// replica returns the participation of the given replica in the given epoch.
+func (s *ParticipationTrackerSuite) replica(epoch uint64, nodeID flow.Identifier) *ReplicaParticipation {
+	for _, e := range s.tracker.Participation() {
+		if e.Epoch != epoch {
+			continue
+		}
+		for _, replica := range e.Replicas {
+			if replica.NodeID == nodeID {
+				return replica
+			}
+		}
+	}
+	s.T().Fatalf("no participation for replica %v in epoch %d", nodeID, epoch)
+	return nil
+}
+
+// TestParticipationRatio verifies that votes and timeouts are counted once per replica and view,
+// and that the ratio is relative to the views observed by this node.
+func (s *ParticipationTrackerSuite) TestParticipationRatio() {
+	a, b, c, d := s.participants[0].NodeID, s.participants[1].NodeID, s.participants[2].NodeID, s.participants[3].NodeID
+
+	// view 10: a, b and c vote; c additionally times out
+	for _, signerID := range []flow.Identifier{a, b, c} {
+		s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(10), unittest.WithVoteSignerID(signerID)))
+	}
+	s.tracker.OnTimeoutProcessed(helper.TimeoutObjectFixture(helper.WithTimeoutObjectView(10), helper.WithTimeoutObjectSignerID(c)))
+	// view 11: only a times out
+	s.tracker.OnTimeoutProcessed(helper.TimeoutObjectFixture(helper.WithTimeoutObjectView(11), helper.WithTimeoutObjectSignerID(a)))
+	// view 9 is already finalized and must be ignored
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(9), unittest.WithVoteSignerID(d)))
+
+	participation := s.tracker.Participation()
+	require.Len(s.T(), participation, 1)
+	require.Equal(s.T(), uint64(1), participation[0].Epoch)
+	require.Equal(s.T(), uint64(2), participation[0].ObservedViews)
+	require.Len(s.T(), participation[0].Replicas, 4)
+
+	require.Equal(s.T(), 1.0, s.replica(1, a).ParticipationRatio)
+	require.Equal(s.T(), 0.5, s.replica(1, b).ParticipationRatio)
+	require.Equal(s.T(), 0.5, s.replica(1, c).ParticipationRatio)
+	require.Equal(s.T(), uint64(1), s.replica(1, c).Votes)
+	require.Equal(s.T(), uint64(1), s.replica(1, c).Timeouts)
+	// d never participated, but is listed as member of the committee
+	require.Equal(s.T(), 0.0, s.replica(1, d).ParticipationRatio)
+	require.Equal(s.T(), uint64(0), s.replica(1, d).Votes)
+}
+
+// TestVoteDelay verifies that vote delays are only measured for proposals which have been incorporated.
+func (s *ParticipationTrackerSuite) TestVoteDelay() {
+	a, b := s.participants[0].NodeID, s.participants[1].NodeID
+
+	// vote for view 10 arrives before the proposal is known
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(10), unittest.WithVoteSignerID(a)))
+	s.tracker.OnBlockIncorporated(helper.MakeBlock(helper.WithBlockView(10)))
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(10), unittest.WithVoteSignerID(b)))
+
+	s.metrics.AssertNumberOfCalls(s.T(), "ReplicaVoteDelay", 1)
+	s.metrics.AssertCalled(s.T(), "ReplicaVoteDelay", b, mock.Anything)
+	require.Zero(s.T(), s.replica(1, a).MedianVoteDelay)
+	require.Equal(s.T(), uint64(1), s.replica(1, a).Votes)
+}
+
+// TestViolations verifies that double and invalid votes and timeouts are counted and reported.
+func (s *ParticipationTrackerSuite) TestViolations() {
+	a := s.participants[0].NodeID
+	s.metrics.On("ReplicaViolation", a, metrics.HotstuffViolationDoubleVote).Once()
+	s.metrics.On("ReplicaViolation", a, metrics.HotstuffViolationInvalidVote).Twice()
+	s.metrics.On("ReplicaViolation", a, metrics.HotstuffViolationDoubleTimeout).Once()
+	s.metrics.On("ReplicaViolation", a, metrics.HotstuffViolationInvalidTimeout).Once()
+
+	vote := unittest.VoteFixture(unittest.WithVoteView(10), unittest.WithVoteSignerID(a))
+	timeout := helper.TimeoutObjectFixture(helper.WithTimeoutObjectView(10), helper.WithTimeoutObjectSignerID(a))
+	s.tracker.OnDoubleVotingDetected(vote, unittest.VoteFixture(unittest.WithVoteView(10), unittest.WithVoteSignerID(a)))
+	s.tracker.OnInvalidVoteDetected(model.InvalidVoteError{Vote: vote})
+	s.tracker.OnVoteForInvalidBlockDetected(vote, nil)
+	s.tracker.OnDoubleTimeoutDetected(timeout, helper.TimeoutObjectFixture(helper.WithTimeoutObjectView(10), helper.WithTimeoutObjectSignerID(a)))
+	s.tracker.OnInvalidTimeoutDetected(model.InvalidTimeoutError{Timeout: timeout})
+
+	replica := s.replica(1, a)
+	require.Equal(s.T(), uint64(1), replica.DoubleVotes)
+	require.Equal(s.T(), uint64(2), replica.InvalidVotes)
+	require.Equal(s.T(), uint64(1), replica.DoubleTimeouts)
+	require.Equal(s.T(), uint64(1), replica.InvalidTimeouts)
+	// violations do not count as participation
+	require.Equal(s.T(), uint64(0), replica.ParticipatedViews)
+}
+
+// TestEpochs verifies that statistics are kept separately per epoch, that views of unknown
+// epochs are ignored, and that finalization reports the participation of the current epoch.
+func (s *ParticipationTrackerSuite) TestEpochs() {
+	a := s.participants[0].NodeID
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(50), unittest.WithVoteSignerID(a)))
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(150), unittest.WithVoteSignerID(a)))
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(250), unittest.WithVoteSignerID(a)))
+
+	participation := s.tracker.Participation()
+	require.Len(s.T(), participation, 2)
+	require.Equal(s.T(), uint64(1), participation[0].Epoch)
+	require.Equal(s.T(), uint64(2), participation[1].Epoch)
+	require.Equal(s.T(), uint64(1), s.replica(1, a).Votes)
+	require.Equal(s.T(), uint64(1), s.replica(2, a).Votes)
+
+	s.tracker.OnFinalizedBlock(helper.MakeBlock(helper.WithBlockView(160)))
+	s.metrics.AssertCalled(s.T(), "ReplicaParticipation", a, 1.0)
+	s.metrics.AssertCalled(s.T(), "ReplicaParticipation", s.participants[1].NodeID, 0.0)
+
+	// votes for finalized views are ignored
+	s.tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(155), unittest.WithVoteSignerID(a)))
+	require.Equal(s.T(), uint64(1), s.replica(2, a).Votes)
+}
+
+// TestEpochTransition verifies that the participation ratios of replicas which are not participants
+// of the new epoch are removed once the participation of the new epoch is reported.
+func (s *ParticipationTrackerSuite) TestEpochTransition() {
+	// the first replica leaves the committee in epoch 2, and a new replica joins
+	joined := unittest.IdentityFixture()
+	nextParticipants := append(s.participants[1:].Copy(), joined)
+	committee := mocks.NewReplicas(s.T())
+	committee.On("IdentitiesByEpoch", mock.Anything).Return(
+		func(view uint64) flow.IdentitySkeletonList {
+			if view >= 100 {
+				return nextParticipants.ToSkeleton()
+			}
+			return s.participants.ToSkeleton()
+		},
+		nil,
+	)
+	tracker := NewParticipationTracker(unittest.Logger(), s.metrics, committee, s.epochLookup, 9)
+
+	left, b := s.participants[0].NodeID, s.participants[1].NodeID
+	tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(50), unittest.WithVoteSignerID(left)))
+	tracker.OnFinalizedBlock(helper.MakeBlock(helper.WithBlockView(60)))
+	s.metrics.AssertCalled(s.T(), "ReplicaParticipation", left, 1.0)
+
+	s.metrics.On("RemoveReplicaParticipation", left).Once()
+	tracker.OnVoteProcessed(unittest.VoteFixture(unittest.WithVoteView(150), unittest.WithVoteSignerID(b)))
+	tracker.OnFinalizedBlock(helper.MakeBlock(helper.WithBlockView(160)))
+	s.metrics.AssertCalled(s.T(), "ReplicaParticipation", b, 1.0)
+	s.metrics.AssertCalled(s.T(), "ReplicaParticipation", joined.NodeID, 0.0)
+
+	// the ratio of the replica which left is only removed once
+	tracker.OnFinalizedBlock(helper.MakeBlock(helper.WithBlockView(170)))
+	s.metrics.AssertNumberOfCalls(s.T(), "RemoveReplicaParticipation", 1)
+}
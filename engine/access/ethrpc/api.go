@@ -0,0 +1,470 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/go-ethereum/accounts/abi"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethVM "github.com/onflow/go-ethereum/core/vm"
+	"github.com/onflow/go-ethereum/rpc"
+
+	"github.com/onflow/flow-go/fvm/evm/offchain/query"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/storage"
+)
+
+// estimateGasErrorRatio is the gas estimation error ratio at which the binary search of eth_estimateGas stops.
+const estimateGasErrorRatio = 0.015
+
+// EthAPI implements the read methods of the "eth" namespace of the Ethereum JSON-RPC API.
+//
+// Blocks are identified by their number, the block tags "latest", "safe", "finalized" and "pending" all resolve to the
+// latest indexed block, since the node only indexes sealed data. Lookups by block hash, by transaction hash and by log
+// filter require the EVM index, they fail if the node does not index the EVM blocks.
+//
+// Calls are executed as direct calls on the state after the execution of the block, in the context of the block.
+// The Cadence Arch precompiled contracts are not available to the calls.
+type EthAPI struct {
+	backend *Backend
+}
+
+// NewEthAPI creates a new EthAPI.
+func NewEthAPI(backend *Backend) *EthAPI {
+	return &EthAPI{
+		backend: backend,
+	}
+}
+
+// ChainId returns the EVM chain ID.
+func (api *EthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.backend.evmChainID())
+}
+
+// BlockNumber returns the number of the latest indexed EVM block.
+func (api *EthAPI) BlockNumber() (hexutil.Uint64, error) {
+	block, err := api.backend.latestBlock()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Height), nil
+}
+
+// GetBalance returns the balance of the account at the given block.
+func (api *EthAPI) GetBalance(address gethCommon.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	view, err := api.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := view.GetBalance(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get balance of %v: %w", address, err)
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// GetTransactionCount returns the nonce of the account at the given block.
+func (api *EthAPI) GetTransactionCount(address gethCommon.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	view, err := api.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := view.GetNonce(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get nonce of %v: %w", address, err)
+	}
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+// GetCode returns the code of the account at the given block.
+func (api *EthAPI) GetCode(address gethCommon.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	view, err := api.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	code, err := view.GetCode(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get code of %v: %w", address, err)
+	}
+	return code, nil
+}
+
+// GetStorageAt returns the value of the storage slot of the account at the given block.
+func (api *EthAPI) GetStorageAt(address gethCommon.Address, slot string, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	key, err := decodeSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	view, err := api.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	value, err := view.GetSlab(address, key)
+	if err != nil {
+		return nil, fmt.Errorf("could not get storage slot %v of %v: %w", key, address, err)
+	}
+	return value[:], nil
+}
+
+// Call executes the call on the state of the given block, optionally with the given state overrides, and returns
+// its returned data. Returns a revert error with the returned data if the call reverted.
+func (api *EthAPI) Call(args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *StateOverride) (hexutil.Bytes, error) {
+	block, err := api.block(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := api.backend.maxCallGasLimit
+	if args.Gas != nil && uint64(*args.Gas) < gasLimit {
+		gasLimit = uint64(*args.Gas)
+	}
+
+	res, err := api.call(block, args, gasLimit, overrides)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(res); err != nil {
+		return nil, err
+	}
+	return res.ReturnedData, nil
+}
+
+// EstimateGas returns the lowest gas limit with which the call succeeds on the state of the given block, optionally
+// with the given state overrides.
+func (api *EthAPI) EstimateGas(args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *StateOverride) (hexutil.Uint64, error) {
+	block, err := api.block(blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	hi := api.backend.maxCallGasLimit
+	if args.Gas != nil && uint64(*args.Gas) < hi {
+		hi = uint64(*args.Gas)
+	}
+
+	res, err := api.call(block, args, hi, overrides)
+	if err != nil {
+		return 0, err
+	}
+	if err := resultError(res); err != nil {
+		return 0, err
+	}
+
+	// the gas consumed is a lower bound of the gas limit, the call may require more gas than it consumes
+	// because of the refunds and the gas retained by the calls to other contracts.
+	lo := uint64(0)
+	if res.GasConsumed > 0 {
+		lo = res.GasConsumed - 1
+	}
+	for lo+1 < hi {
+		if float64(hi-lo)/float64(hi) < estimateGasErrorRatio {
+			break
+		}
+		mid := lo + (hi-lo)/2
+		res, err := api.call(block, args, mid, overrides)
+		if err != nil {
+			return 0, err
+		}
+		if res.Invalid() || res.Failed() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hexutil.Uint64(hi), nil
+}
+
+// GetBlockByNumber returns the block with the given number, with either the hashes or the full transactions of the
+// block. Returns nil if the block is not indexed.
+func (api *EthAPI) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*Block, error) {
+	block, err := api.backend.blockByNumber(number)
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return api.rpcBlock(block, fullTx)
+}
+
+// GetBlockByHash returns the block with the given hash, with either the hashes or the full transactions of the
+// block. Returns nil if the block is not indexed.
+func (api *EthAPI) GetBlockByHash(hash gethCommon.Hash, fullTx bool) (*Block, error) {
+	block, err := api.backend.blockByHash(hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return api.rpcBlock(block, fullTx)
+}
+
+// GetTransactionByHash returns the transaction with the given hash. Returns nil if the transaction is not indexed.
+func (api *EthAPI) GetTransactionByHash(hash gethCommon.Hash) (*Transaction, error) {
+	indexed, block, err := api.backend.transactionByHash(hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tx, err := decodeIndexedTransaction(indexed)
+	if err != nil {
+		return nil, err
+	}
+	return tx.transaction(block.Hash, block.Height), nil
+}
+
+// GetTransactionReceipt returns the receipt of the transaction with the given hash. Returns nil if the transaction
+// is not indexed.
+func (api *EthAPI) GetTransactionReceipt(hash gethCommon.Hash) (*Receipt, error) {
+	indexed, receipt, err := api.backend.receiptByHash(hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tx, err := decodeIndexedTransaction(indexed)
+	if err != nil {
+		return nil, err
+	}
+	return tx.indexedReceipt(receipt), nil
+}
+
+// GetLogs returns the logs matching the given filter criteria.
+func (api *EthAPI) GetLogs(criteria FilterCriteria) ([]*gethTypes.Log, error) {
+	return api.backend.logs(&criteria)
+}
+
+// rpcBlock returns the JSON-RPC representation of the given block, with either the hashes or the full transactions
+// of the block.
+func (api *EthAPI) rpcBlock(block *evmBlock, fullTx bool) (*Block, error) {
+	txs, receipts, err := api.transactionsAndReceipts(block)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := block.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("could not compute hash of evm block %d: %w", block.Height, err)
+	}
+
+	var transactions interface{}
+	if fullTx {
+		full := make([]*Transaction, 0, len(txs))
+		for _, tx := range txs {
+			full = append(full, tx.transaction(hash, block.Height))
+		}
+		transactions = full
+	} else {
+		hashes := make([]gethCommon.Hash, 0, len(txs))
+		for _, tx := range txs {
+			hashes = append(hashes, tx.hash)
+		}
+		transactions = hashes
+	}
+	return newBlock(block.Block, hash, transactions, receipts), nil
+}
+
+// GetBlockTransactionCountByNumber returns the number of transactions of the block with the given number.
+// Returns nil if the block is not indexed.
+func (api *EthAPI) GetBlockTransactionCountByNumber(number rpc.BlockNumber) (*hexutil.Uint, error) {
+	block, err := api.backend.blockByNumber(number)
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	txs, err := api.backend.transactions(block)
+	if err != nil {
+		return nil, err
+	}
+	count := hexutil.Uint(len(txs))
+	return &count, nil
+}
+
+// GetBlockReceipts returns the receipts of the transactions of the given block. Returns nil if the block is not indexed.
+func (api *EthAPI) GetBlockReceipts(blockNrOrHash rpc.BlockNumberOrHash) ([]*Receipt, error) {
+	block, err := api.block(&blockNrOrHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	_, receipts, err := api.transactionsAndReceipts(block)
+	if err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// view returns a view of the state after the execution of the given block.
+func (api *EthAPI) view(blockNrOrHash rpc.BlockNumberOrHash) (*query.View, error) {
+	block, err := api.block(&blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.backend.view(block), nil
+}
+
+// block returns the given block, or the latest block if none is given.
+func (api *EthAPI) block(blockNrOrHash *rpc.BlockNumberOrHash) (*evmBlock, error) {
+	if blockNrOrHash == nil {
+		return api.backend.latestBlock()
+	}
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return api.backend.blockByHash(hash)
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return api.backend.latestBlock()
+	}
+	return api.backend.blockByNumber(number)
+}
+
+// call executes the call with the given gas limit on a new view of the state after the execution of the given block.
+func (api *EthAPI) call(block *evmBlock, args TransactionArgs, gasLimit uint64, overrides *StateOverride) (*types.Result, error) {
+	var from, to gethCommon.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	}
+	value := big.NewInt(0)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	res, err := api.backend.view(block).DryCall(from, to, args.data(), value, gasLimit, overrideOptions(overrides)...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute call: %w", err)
+	}
+	return res, nil
+}
+
+// transactionsAndReceipts returns the decoded transactions of the given block and their receipts.
+func (api *EthAPI) transactionsAndReceipts(block *evmBlock) ([]*decodedTransaction, []*Receipt, error) {
+	events, err := api.backend.transactions(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	hash, err := block.Hash()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not compute hash of evm block %d: %w", block.Height, err)
+	}
+
+	signer := gethTypes.LatestSignerForChainID(api.backend.evmChainID())
+	txs := make([]*decodedTransaction, 0, len(events))
+	receipts := make([]*Receipt, 0, len(events))
+	cumulativeGasUsed := uint64(0)
+	logIndex := uint(0)
+	for _, event := range events {
+		tx, err := decodeTransaction(event, signer)
+		if err != nil {
+			return nil, nil, err
+		}
+		cumulativeGasUsed += event.GasConsumed
+		receipt, err := tx.receipt(hash, block.Height, cumulativeGasUsed, logIndex)
+		if err != nil {
+			return nil, nil, err
+		}
+		logIndex += uint(len(receipt.Logs))
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	return txs, receipts, nil
+}
+
+// overrideOptions returns the dry call options applying the given state overrides.
+func overrideOptions(overrides *StateOverride) []query.DryCallOption {
+	if overrides == nil {
+		return nil
+	}
+	opts := make([]query.DryCallOption, 0)
+	for address, account := range *overrides {
+		if account.Nonce != nil {
+			opts = append(opts, query.WithStateOverrideNonce(address, uint64(*account.Nonce)))
+		}
+		if account.Code != nil {
+			opts = append(opts, query.WithStateOverrideCode(address, *account.Code))
+		}
+		if account.Balance != nil {
+			opts = append(opts, query.WithStateOverrideBalance(address, account.Balance.ToInt()))
+		}
+		if account.State != nil {
+			opts = append(opts, query.WithStateOverrideState(address, account.State))
+		}
+		if account.StateDiff != nil {
+			opts = append(opts, query.WithStateOverrideStateDiff(address, account.StateDiff))
+		}
+	}
+	return opts
+}
+
+// resultError returns the error of a call which is invalid or failed, and nil otherwise.
+func resultError(res *types.Result) error {
+	if res.Invalid() {
+		return res.ValidationError
+	}
+	if !res.Failed() {
+		return nil
+	}
+	if errors.Is(res.VMError, gethVM.ErrExecutionReverted) {
+		return newRevertError(res.ReturnedData)
+	}
+	return res.VMError
+}
+
+// decodeSlot decodes a storage slot given as a hex string of at most 32 bytes.
+func decodeSlot(slot string) (gethCommon.Hash, error) {
+	if len(slot) >= 2 && slot[0] == '0' && (slot[1] == 'x' || slot[1] == 'X') {
+		slot = slot[2:]
+	}
+	if len(slot)%2 == 1 {
+		slot = "0" + slot
+	}
+	b, err := hexutil.Decode("0x" + slot)
+	if err != nil || len(b) > gethCommon.HashLength {
+		return gethCommon.Hash{}, fmt.Errorf("invalid storage slot %q", slot)
+	}
+	return gethCommon.BytesToHash(b), nil
+}
+
+// revertError is the error of a reverted call, it is returned with the JSON-RPC error code 3 and the returned data,
+// which is how Ethereum clients expect reverts.
+type revertError struct {
+	reason string
+	data   hexutil.Bytes
+}
+
+var _ rpc.DataError = (*revertError)(nil)
+
+func newRevertError(data []byte) *revertError {
+	reason := gethVM.ErrExecutionReverted.Error()
+	if unpacked, err := abi.UnpackRevert(data); err == nil {
+		reason = fmt.Sprintf("%s: %s", reason, unpacked)
+	}
+	return &revertError{
+		reason: reason,
+		data:   data,
+	}
+}
+
+func (e *revertError) Error() string {
+	return e.reason
+}
+
+// ErrorCode returns the JSON-RPC error code of reverted calls.
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the returned data of the reverted call.
+func (e *revertError) ErrorData() interface{} {
+	return e.data.String()
+}
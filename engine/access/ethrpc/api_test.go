@@ -0,0 +1,471 @@
+package ethrpc_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/cadence/encoding/ccf"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/ethrpc"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/handler"
+	. "github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const chainID = flow.Emulator
+
+// testRegisters serves the registers of the test backend at all indexed heights, unless a snapshot of the registers
+// is given for the height. The latest EVM block register is served from the given blocks per Flow height.
+type testRegisters struct {
+	backend   *TestBackend
+	snapshots map[uint64]*TestValueStore
+	rootAddr  flow.Address
+	blocks    map[uint64]*types.Block
+	lowest    uint64
+	highest   uint64
+}
+
+func (r *testRegisters) RegisterValues(ids flow.RegisterIDs, height uint64) ([]flow.RegisterValue, error) {
+	if height < r.lowest || height > r.highest {
+		return nil, storage.ErrHeightNotIndexed
+	}
+	latestBlockID := flow.CadenceRegisterID(r.rootAddr[:], []byte(handler.BlockStoreLatestBlockKey))
+
+	values := make([]flow.RegisterValue, len(ids))
+	for i, id := range ids {
+		var value []byte
+		var err error
+		if id == latestBlockID {
+			if block := r.blocks[height]; block != nil {
+				value, err = block.ToBytes()
+			}
+		} else if snapshot, ok := r.snapshots[height]; ok {
+			value, err = snapshot.GetValue([]byte(id.Owner), []byte(id.Key))
+		} else {
+			value, err = r.backend.GetValue([]byte(id.Owner), []byte(id.Key))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(value) == 0 {
+			return nil, storage.ErrNotFound
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// testEvents serves the events per Flow block ID.
+type testEvents map[flow.Identifier][]flow.Event
+
+func (e testEvents) ByBlockID(blockID flow.Identifier, _ uint64) ([]flow.Event, error) {
+	return e[blockID], nil
+}
+
+// transactionEvent returns the EVM.TransactionExecuted event of the given result.
+func transactionEvent(t *testing.T, res *types.Result, payload []byte, evmHeight uint64) flow.Event {
+	ev, err := events.NewTransactionEvent(res, payload, evmHeight).Payload.ToCadence(chainID)
+	require.NoError(t, err)
+	encoded, err := ccf.Encode(ev)
+	require.NoError(t, err)
+	return flow.Event{
+		Type:    flow.EventType(ev.Type().ID()),
+		Payload: encoded,
+	}
+}
+
+// blockEvent returns the EVM.BlockExecuted event of the given block.
+func blockEvent(t *testing.T, block *types.Block) flow.Event {
+	ev, err := events.NewBlockEvent(block).Payload.ToCadence(chainID)
+	require.NoError(t, err)
+	encoded, err := ccf.Encode(ev)
+	require.NoError(t, err)
+	return flow.Event{
+		Type:    flow.EventType(ev.Type().ID()),
+		Payload: encoded,
+	}
+}
+
+// TestEthAPI verifies that the Ethereum JSON-RPC server serves the state, the blocks and the receipts of Flow EVM
+// from the indexed registers and events.
+func TestEthAPI(t *testing.T) {
+	RunWithTestBackend(t, func(backend *TestBackend) {
+		rootAddr := systemcontracts.SystemContractsForChain(chainID).EVMStorage.Address
+		require.NoError(t, backend.SetValue(rootAddr[:], []byte(flow.AccountStatusKey), environment.NewAccountStatus().ToBytes()))
+
+		RunWithDeployedContract(t, GetStorageTestContract(t), backend, rootAddr, func(testContract *TestContract) {
+			RunWithEOATestAccount(t, backend, rootAddr, func(testAccount *EOATestAccount) {
+				// the EVM is deployed at Flow height 11, and Flow height 12 does not commit an EVM block
+				evmBlocks := map[uint64]*types.Block{
+					11: {Height: 1, Timestamp: 100, TotalSupply: big.NewInt(0)},
+					12: {Height: 1, Timestamp: 100, TotalSupply: big.NewInt(0)},
+					13: {Height: 2, Timestamp: 101, TotalSupply: big.NewInt(0), TotalGasUsed: 42_000},
+					14: {Height: 3, Timestamp: 102, TotalSupply: big.NewInt(0)},
+				}
+				registers := &testRegisters{
+					backend:  backend,
+					rootAddr: rootAddr,
+					blocks:   evmBlocks,
+					lowest:   10,
+					highest:  14,
+				}
+
+				reporter := syncmock.NewIndexReporter(t)
+				reporter.On("LowestIndexedHeight").Return(uint64(10), nil).Maybe()
+				reporter.On("HighestIndexedHeight").Return(uint64(14), nil).Maybe()
+
+				headers := storagemock.NewHeaders(t)
+				blockIDs := make(map[uint64]flow.Identifier)
+				for height := uint64(10); height <= 14; height++ {
+					blockIDs[height] = unittest.IdentifierFixture()
+					headers.On("BlockIDByHeight", height).Return(blockIDs[height], nil).Maybe()
+				}
+
+				// EVM block 2 includes a deposit executed at Flow height 12 and a transaction executed at Flow height 13
+				deposit := types.NewDepositCall(types.NewAddress(gethCommon.Address{1}), testAccount.Address(), big.NewInt(1000), 1)
+				depositPayload, err := deposit.Encode()
+				require.NoError(t, err)
+				depositResult := &types.Result{
+					TxType:      types.DirectCallTxType,
+					GasConsumed: 21_000,
+					TxHash:      deposit.Hash(),
+					Index:       0,
+				}
+
+				tx := testAccount.PrepareAndSignTx(t, testContract.DeployedAt.ToCommon(), testContract.MakeCallData(t, "store", big.NewInt(1)), big.NewInt(0), 100_000, big.NewInt(1))
+				txPayload, err := tx.MarshalBinary()
+				require.NoError(t, err)
+				txLog := &gethTypes.Log{
+					Address: testContract.DeployedAt.ToCommon(),
+					Topics:  []gethCommon.Hash{{2}},
+					Data:    []byte{3},
+				}
+				txResult := &types.Result{
+					TxType:      tx.Type(),
+					GasConsumed: 21_000,
+					TxHash:      tx.Hash(),
+					Index:       1,
+					Logs:        []*gethTypes.Log{txLog},
+				}
+
+				evts := testEvents{
+					blockIDs[11]: {
+						blockEvent(t, evmBlocks[11]),
+					},
+					blockIDs[12]: {
+						transactionEvent(t, depositResult, depositPayload, 2),
+					},
+					blockIDs[13]: {
+						{Type: "flow.AccountCreated"},
+						transactionEvent(t, txResult, txPayload, 2),
+						blockEvent(t, evmBlocks[13]),
+					},
+					blockIDs[14]: {
+						blockEvent(t, evmBlocks[14]),
+					},
+				}
+
+				config := ethrpc.DefaultConfig()
+				config.ListenAddress = unittest.DefaultAddress
+				server, err := ethrpc.NewServer(
+					unittest.Logger(),
+					config,
+					ethrpc.NewBackend(unittest.Logger(), chainID, config.MaxCallGasLimit, registers, reporter, headers, evts, nil, nil),
+				)
+				require.NoError(t, err)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+				server.Start(signalerCtx)
+				unittest.RequireCloseBefore(t, server.Ready(), time.Second, "server did not start")
+				defer func() {
+					cancel()
+					unittest.RequireCloseBefore(t, server.Done(), time.Second, "server did not stop")
+				}()
+
+				h := SetupHandler(chainID, backend, rootAddr)
+
+				client, err := rpc.DialHTTP("http://" + server.Address().String())
+				require.NoError(t, err)
+				defer client.Close()
+
+				t.Run("eth_chainId and eth_blockNumber", func(t *testing.T) {
+					var evmChainID hexutil.Big
+					require.NoError(t, client.Call(&evmChainID, "eth_chainId"))
+					assert.Equal(t, types.EVMChainIDFromFlowChainID(chainID), evmChainID.ToInt())
+
+					var number hexutil.Uint64
+					require.NoError(t, client.Call(&number, "eth_blockNumber"))
+					assert.Equal(t, hexutil.Uint64(3), number)
+				})
+
+				t.Run("state queries", func(t *testing.T) {
+					var balance hexutil.Big
+					require.NoError(t, client.Call(&balance, "eth_getBalance", testAccount.Address().ToCommon(), "latest"))
+					assert.True(t, types.BalancesAreEqual(h.AccountByAddress(testAccount.Address(), false).Balance(), balance.ToInt()))
+
+					var nonce hexutil.Uint64
+					require.NoError(t, client.Call(&nonce, "eth_getTransactionCount", testAccount.Address().ToCommon(), "0x2"))
+					assert.Equal(t, hexutil.Uint64(h.AccountByAddress(testAccount.Address(), false).Nonce()), nonce)
+
+					var code hexutil.Bytes
+					require.NoError(t, client.Call(&code, "eth_getCode", testContract.DeployedAt.ToCommon(), "latest"))
+					assert.NotEmpty(t, code)
+
+					var value hexutil.Bytes
+					require.NoError(t, client.Call(&value, "eth_getStorageAt", testContract.DeployedAt.ToCommon(), "0x0", "latest"))
+					assert.Equal(t, gethCommon.Hash{}.Bytes(), []byte(value))
+
+					// blocks outside the indexed heights are rejected
+					err := client.Call(&balance, "eth_getBalance", testAccount.Address().ToCommon(), "0x4")
+					require.Error(t, err)
+				})
+
+				t.Run("eth_call and eth_estimateGas", func(t *testing.T) {
+					call := map[string]interface{}{
+						"from":  testAccount.Address().ToCommon(),
+						"to":    testContract.DeployedAt.ToCommon(),
+						"input": hexutil.Bytes(testContract.MakeCallData(t, "blockNumber")),
+					}
+
+					// the call is executed in the context of the requested block
+					var result hexutil.Bytes
+					require.NoError(t, client.Call(&result, "eth_call", call, "0x2"))
+					assert.Equal(t, big.NewInt(2), new(big.Int).SetBytes(result))
+
+					require.NoError(t, client.Call(&result, "eth_call", call))
+					assert.Equal(t, big.NewInt(3), new(big.Int).SetBytes(result))
+
+					// reverts are returned with the returned data
+					call["input"] = hexutil.Bytes(testContract.MakeCallData(t, "storeButRevert", big.NewInt(1)))
+					err := client.Call(&result, "eth_call", call, "latest")
+					require.Error(t, err)
+					var dataErr rpc.DataError
+					require.ErrorAs(t, err, &dataErr)
+					assert.Contains(t, dataErr.Error(), "execution reverted")
+
+					call["input"] = hexutil.Bytes(testContract.MakeCallData(t, "store", big.NewInt(1)))
+					var gas hexutil.Uint64
+					require.NoError(t, client.Call(&gas, "eth_estimateGas", call, "latest"))
+					assert.Greater(t, uint64(gas), uint64(21_000))
+					assert.Less(t, uint64(gas), ethrpc.DefaultMaxCallGasLimit)
+
+					// the estimated gas is sufficient for the call
+					call["gas"] = gas
+					require.NoError(t, client.Call(&result, "eth_call", call, "latest"))
+				})
+
+				t.Run("blocks and receipts", func(t *testing.T) {
+					blockHash, err := evmBlocks[13].Hash()
+					require.NoError(t, err)
+
+					var block ethrpc.Block
+					require.NoError(t, client.Call(&block, "eth_getBlockByNumber", "0x2", false))
+					assert.Equal(t, hexutil.Uint64(2), block.Number)
+					assert.Equal(t, blockHash, block.Hash)
+					assert.Equal(t, hexutil.Uint64(101), block.Timestamp)
+					assert.Equal(t, hexutil.Uint64(42_000), block.GasUsed)
+					assert.ElementsMatch(t, []interface{}{deposit.Hash().Hex(), tx.Hash().Hex()}, block.Transactions)
+					assert.True(t, gethTypes.BloomLookup(block.LogsBloom, txLog.Address))
+
+					var fullBlock struct {
+						Transactions []ethrpc.Transaction `json:"transactions"`
+					}
+					require.NoError(t, client.Call(&fullBlock, "eth_getBlockByNumber", "0x2", true))
+					require.Len(t, fullBlock.Transactions, 2)
+					assert.Equal(t, testAccount.Address().ToCommon(), *fullBlock.Transactions[0].To)
+					assert.Equal(t, gethCommon.Address{1}, fullBlock.Transactions[0].From)
+					assert.Equal(t, testAccount.Address().ToCommon(), fullBlock.Transactions[1].From)
+					assert.Equal(t, tx.Hash(), fullBlock.Transactions[1].Hash)
+
+					var receipts []*ethrpc.Receipt
+					require.NoError(t, client.Call(&receipts, "eth_getBlockReceipts", "0x2"))
+					require.Len(t, receipts, 2)
+					assert.Equal(t, hexutil.Uint64(21_000), receipts[0].CumulativeGasUsed)
+					assert.Equal(t, hexutil.Uint64(42_000), receipts[1].CumulativeGasUsed)
+					assert.Equal(t, hexutil.Uint64(gethTypes.ReceiptStatusSuccessful), receipts[1].Status)
+					require.Len(t, receipts[1].Logs, 1)
+					assert.Equal(t, blockHash, receipts[1].Logs[0].BlockHash)
+					assert.Equal(t, tx.Hash(), receipts[1].Logs[0].TxHash)
+
+					var count hexutil.Uint
+					require.NoError(t, client.Call(&count, "eth_getBlockTransactionCountByNumber", "latest"))
+					assert.Zero(t, count)
+
+					// the earliest block is the first EVM block of the indexed heights
+					require.NoError(t, client.Call(&block, "eth_getBlockByNumber", "earliest", false))
+					assert.Equal(t, hexutil.Uint64(1), block.Number)
+
+					// blocks which are not indexed are returned as null
+					var missing *ethrpc.Block
+					require.NoError(t, client.Call(&missing, "eth_getBlockByNumber", "0x4", false))
+					assert.Nil(t, missing)
+				})
+
+				t.Run("lookups requiring the index", func(t *testing.T) {
+					var block *ethrpc.Block
+					err := client.Call(&block, "eth_getBlockByHash", gethCommon.Hash{1}, false)
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), ethrpc.ErrIndexDisabled.Error())
+
+					var logs []*gethTypes.Log
+					err = client.Call(&logs, "eth_getLogs", map[string]interface{}{"fromBlock": "earliest"})
+					require.Error(t, err)
+				})
+
+				// with an EVM index, the blocks, transactions and logs can be looked up by hash and filtered. The
+				// EVM blocks are indexed from the events of the indexed heights
+				unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+					index := bstorage.NewEVMIndex(db)
+					evmIndexer := indexer.NewEVMIndexer(unittest.Logger(), chainID, index)
+					for height := uint64(10); height <= 14; height++ {
+						batch := bstorage.NewBatch(db)
+						require.NoError(t, evmIndexer.IndexEvents(blockIDs[height], height, evts[blockIDs[height]], batch))
+						require.NoError(t, batch.Flush())
+					}
+
+					config := ethrpc.DefaultConfig()
+					config.ListenAddress = unittest.DefaultAddress
+					server, err := ethrpc.NewServer(
+						unittest.Logger(),
+						config,
+						ethrpc.NewBackend(unittest.Logger(), chainID, config.MaxCallGasLimit, registers, reporter, headers, evts, nil, index),
+					)
+					require.NoError(t, err)
+
+					ctx, cancel := context.WithCancel(context.Background())
+					signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+					server.Start(signalerCtx)
+					unittest.RequireCloseBefore(t, server.Ready(), time.Second, "server did not start")
+					defer func() {
+						cancel()
+						unittest.RequireCloseBefore(t, server.Done(), time.Second, "server did not stop")
+					}()
+
+					client, err := rpc.DialHTTP("http://" + server.Address().String())
+					require.NoError(t, err)
+					defer client.Close()
+
+					t.Run("lookups by hash", func(t *testing.T) {
+						blockHash, err := evmBlocks[13].Hash()
+						require.NoError(t, err)
+
+						var block ethrpc.Block
+						require.NoError(t, client.Call(&block, "eth_getBlockByHash", blockHash, false))
+						assert.Equal(t, hexutil.Uint64(2), block.Number)
+						assert.Equal(t, blockHash, block.Hash)
+
+						var balance hexutil.Big
+						require.NoError(t, client.Call(&balance, "eth_getBalance", testAccount.Address().ToCommon(), map[string]interface{}{"blockHash": blockHash}))
+
+						var transaction ethrpc.Transaction
+						require.NoError(t, client.Call(&transaction, "eth_getTransactionByHash", tx.Hash()))
+						assert.Equal(t, tx.Hash(), transaction.Hash)
+						assert.Equal(t, blockHash, transaction.BlockHash)
+						assert.Equal(t, hexutil.Uint64(1), transaction.TransactionIndex)
+						assert.Equal(t, testAccount.Address().ToCommon(), transaction.From)
+
+						require.NoError(t, client.Call(&transaction, "eth_getTransactionByHash", deposit.Hash()))
+						assert.Equal(t, gethCommon.Address{1}, transaction.From)
+
+						var receipt ethrpc.Receipt
+						require.NoError(t, client.Call(&receipt, "eth_getTransactionReceipt", tx.Hash()))
+						assert.Equal(t, blockHash, receipt.BlockHash)
+						assert.Equal(t, hexutil.Uint64(42_000), receipt.CumulativeGasUsed)
+						assert.Equal(t, hexutil.Uint64(gethTypes.ReceiptStatusSuccessful), receipt.Status)
+						require.Len(t, receipt.Logs, 1)
+						assert.Equal(t, uint(0), receipt.Logs[0].Index)
+						assert.Equal(t, tx.Hash(), receipt.Logs[0].TxHash)
+
+						// unknown hashes are returned as null
+						var missingBlock *ethrpc.Block
+						require.NoError(t, client.Call(&missingBlock, "eth_getBlockByHash", gethCommon.Hash{1}, false))
+						assert.Nil(t, missingBlock)
+						var missingTx *ethrpc.Transaction
+						require.NoError(t, client.Call(&missingTx, "eth_getTransactionByHash", gethCommon.Hash{1}))
+						assert.Nil(t, missingTx)
+						var missingReceipt *ethrpc.Receipt
+						require.NoError(t, client.Call(&missingReceipt, "eth_getTransactionReceipt", gethCommon.Hash{1}))
+						assert.Nil(t, missingReceipt)
+					})
+
+					t.Run("eth_getLogs", func(t *testing.T) {
+						var logs []*gethTypes.Log
+						require.NoError(t, client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"fromBlock": "earliest",
+							"address":   testContract.DeployedAt.ToCommon(),
+						}))
+						require.Len(t, logs, 1)
+						assert.Equal(t, tx.Hash(), logs[0].TxHash)
+						assert.Equal(t, uint64(2), logs[0].BlockNumber)
+						assert.Equal(t, txLog.Data, logs[0].Data)
+
+						// topics are matched by position, a null position matches any topic
+						require.NoError(t, client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"fromBlock": "0x1",
+							"toBlock":   "0x2",
+							"topics":    []interface{}{[]gethCommon.Hash{{1}, {2}}},
+						}))
+						require.Len(t, logs, 1)
+						require.NoError(t, client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"fromBlock": "0x1",
+							"topics":    []interface{}{nil, gethCommon.Hash{2}},
+						}))
+						assert.Empty(t, logs)
+						require.NoError(t, client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"fromBlock": "0x1",
+							"address":   []gethCommon.Address{{1}, {2}},
+						}))
+						assert.Empty(t, logs)
+
+						blockHash, err := evmBlocks[13].Hash()
+						require.NoError(t, err)
+						require.NoError(t, client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"blockHash": blockHash,
+						}))
+						require.Len(t, logs, 1)
+
+						// the blocks above the latest indexed block are ignored
+						require.NoError(t, client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"fromBlock": "0x3",
+							"toBlock":   "0x10",
+						}))
+						assert.Empty(t, logs)
+
+						// the range must be valid
+						err = client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"fromBlock": "0x2",
+							"toBlock":   "0x1",
+						})
+						require.Error(t, err)
+						err = client.Call(&logs, "eth_getLogs", map[string]interface{}{
+							"blockHash": blockHash,
+							"fromBlock": "0x1",
+						})
+						require.Error(t, err)
+					})
+				})
+			})
+		})
+	})
+}
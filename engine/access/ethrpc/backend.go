@@ -0,0 +1,375 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/handler"
+	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
+	"github.com/onflow/flow-go/fvm/evm/offchain/query"
+	evmStorage "github.com/onflow/flow-go/fvm/evm/offchain/storage"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+)
+
+// RegisterReader provides the register values indexed at a Flow block height, see execution.RegistersAsyncStore.
+type RegisterReader interface {
+	// RegisterValues returns the values of the given registers at the given Flow block height.
+	// Expected errors:
+	//   - storage.ErrHeightNotIndexed if the values at the height are not indexed
+	//   - storage.ErrNotFound if a register does not exist at the height
+	RegisterValues(ids flow.RegisterIDs, height uint64) ([]flow.RegisterValue, error)
+}
+
+// EventsReader provides the events indexed for a Flow block, see index.EventsIndex.
+type EventsReader interface {
+	// ByBlockID returns the events of the given Flow block.
+	ByBlockID(blockID flow.Identifier, height uint64) ([]flow.Event, error)
+}
+
+// Backend reads the state, the blocks and the receipts of Flow EVM from the registers and the events indexed by the
+// node.
+//
+// Every Flow block commits an EVM block in its system chunk, so the EVM state after the execution of an EVM block is
+// the register state at the end of the Flow block which committed it. The Flow height of an EVM block is resolved with
+// a binary search over the indexed Flow heights, using the latest EVM block stored in the registers, which grows
+// monotonically with the Flow height, or with the EVM index if the node indexes the EVM blocks.
+type Backend struct {
+	log                  zerolog.Logger
+	chainID              flow.ChainID
+	rootAddr             flow.Address
+	transactionEventType flow.EventType
+	maxCallGasLimit      uint64
+	registers            RegisterReader
+	reporter             state_synchronization.IndexReporter
+	headers              storage.Headers
+	events               EventsReader
+	traces               debug.TraceStore
+	index                storage.EVMIndex
+}
+
+// evmBlock is an EVM block and the Flow height it was committed at.
+type evmBlock struct {
+	*types.Block
+	flowHeight uint64
+}
+
+// NewBackend creates a new Backend. The trace store is optional, the call traces are not available without it.
+// The EVM index is optional, the lookups by block hash, by transaction hash and by log filter are not available
+// without it.
+func NewBackend(
+	log zerolog.Logger,
+	chainID flow.ChainID,
+	maxCallGasLimit uint64,
+	registers RegisterReader,
+	reporter state_synchronization.IndexReporter,
+	headers storage.Headers,
+	eventsReader EventsReader,
+	traces debug.TraceStore,
+	index storage.EVMIndex,
+) *Backend {
+	sc := systemcontracts.SystemContractsForChain(chainID)
+	return &Backend{
+		log:                  log.With().Str("component", "ethrpc_backend").Logger(),
+		chainID:              chainID,
+		rootAddr:             sc.EVMStorage.Address,
+		transactionEventType: flow.EventType(sc.EVMContract.Location().TypeID(nil, string(events.EventTypeTransactionExecuted))),
+		maxCallGasLimit:      maxCallGasLimit,
+		registers:            registers,
+		reporter:             reporter,
+		headers:              headers,
+		events:               eventsReader,
+		traces:               traces,
+		index:                index,
+	}
+}
+
+// evmChainID returns the EVM chain ID.
+func (b *Backend) evmChainID() *big.Int {
+	return types.EVMChainIDFromFlowChainID(b.chainID)
+}
+
+// latestBlock returns the latest EVM block of the indexed Flow heights.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if no EVM block is indexed yet
+func (b *Backend) latestBlock() (*evmBlock, error) {
+	highest, err := b.reporter.HighestIndexedHeight()
+	if err != nil {
+		return nil, fmt.Errorf("could not get highest indexed height: %w", err)
+	}
+	block, err := b.blockAt(highest)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("no evm block is indexed: %w", storage.ErrHeightNotIndexed)
+	}
+	return &evmBlock{Block: block, flowHeight: highest}, nil
+}
+
+// earliestBlock returns the earliest EVM block of the indexed Flow heights.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if no EVM block is indexed yet
+func (b *Backend) earliestBlock() (*evmBlock, error) {
+	return b.search(0)
+}
+
+// blockByHeight returns the EVM block with the given height.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the EVM block was not committed within the indexed Flow heights
+func (b *Backend) blockByHeight(evmHeight uint64) (*evmBlock, error) {
+	if b.index != nil {
+		indexed, err := b.index.BlockByHeight(evmHeight)
+		if err == nil {
+			return b.indexedBlock(indexed)
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	block, err := b.search(evmHeight)
+	if err != nil {
+		return nil, err
+	}
+	if block.Height != evmHeight {
+		return nil, fmt.Errorf("evm block %d was committed below the lowest indexed height: %w", evmHeight, storage.ErrHeightNotIndexed)
+	}
+	return block, nil
+}
+
+// blockByNumber returns the block with the given number or tag.
+func (b *Backend) blockByNumber(number rpc.BlockNumber) (*evmBlock, error) {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber, rpc.PendingBlockNumber:
+		return b.latestBlock()
+	case rpc.EarliestBlockNumber:
+		return b.earliestBlock()
+	}
+	if number < 0 {
+		return nil, fmt.Errorf("invalid block number %d", number)
+	}
+	return b.blockByHeight(uint64(number))
+}
+
+// search returns the latest EVM block at the lowest indexed Flow height at which the latest EVM block is at least
+// the given EVM height.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the EVM height is above the latest indexed EVM block
+func (b *Backend) search(evmHeight uint64) (*evmBlock, error) {
+	lowest, err := b.reporter.LowestIndexedHeight()
+	if err != nil {
+		return nil, fmt.Errorf("could not get lowest indexed height: %w", err)
+	}
+	highest, err := b.reporter.HighestIndexedHeight()
+	if err != nil {
+		return nil, fmt.Errorf("could not get highest indexed height: %w", err)
+	}
+
+	var searchErr error
+	i := sort.Search(int(highest-lowest+1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		block, err := b.blockAt(lowest + uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return block != nil && block.Height >= evmHeight
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if uint64(i) > highest-lowest {
+		return nil, fmt.Errorf("evm block %d is not indexed yet: %w", evmHeight, storage.ErrHeightNotIndexed)
+	}
+
+	flowHeight := lowest + uint64(i)
+	block, err := b.blockAt(flowHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &evmBlock{Block: block, flowHeight: flowHeight}, nil
+}
+
+// view returns a view of the EVM state after the execution of the given block. Calls made on the view are executed
+// in the context of the block.
+func (b *Backend) view(block *evmBlock) *query.View {
+	readOnly := evmStorage.NewReadOnlyStorage(&registerSnapshot{registers: b.registers, height: block.flowHeight})
+	return query.NewView(
+		b.chainID,
+		b.rootAddr,
+		evmStorage.NewEphemeralStorage(readOnly),
+		&blockSnapshot{
+			chainID:  b.chainID,
+			rootAddr: b.rootAddr,
+			block:    block.Block,
+			storage:  readOnly,
+		},
+		b.maxCallGasLimit,
+	)
+}
+
+// transactions returns the transaction events of the given EVM block, ordered by their index in the block.
+func (b *Backend) transactions(block *evmBlock) ([]*events.TransactionEventPayload, error) {
+	txs, _, err := b.transactionEvents(block)
+	if err != nil {
+		return nil, err
+	}
+	payloads := make([]*events.TransactionEventPayload, 0, len(txs))
+	for _, tx := range txs {
+		payloads = append(payloads, tx.TransactionEventPayload)
+	}
+	return payloads, nil
+}
+
+// transactionEvent is a transaction event of an EVM block, and the ID of the Flow block which emitted it.
+type transactionEvent struct {
+	*events.TransactionEventPayload
+	blockID flow.Identifier
+}
+
+// transactionEvents returns the transaction events of the given EVM block ordered by their index in the block, and
+// the lowest Flow height which emitted events of the block.
+func (b *Backend) transactionEvents(block *evmBlock) ([]transactionEvent, uint64, error) {
+	lowest, err := b.reporter.LowestIndexedHeight()
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get lowest indexed height: %w", err)
+	}
+
+	// the transactions are usually executed in the Flow block which committed the EVM block. Older Flow blocks
+	// could execute transactions of the EVM block before it was committed, so the Flow blocks since the previous
+	// EVM block are included. The latest EVM block of all these Flow blocks is the previous EVM block.
+	firstHeight := block.flowHeight
+	for block.Height > 0 && firstHeight-1 > lowest {
+		before, err := b.blockAt(firstHeight - 2)
+		if err != nil {
+			return nil, 0, err
+		}
+		if before == nil || before.Height < block.Height-1 {
+			// the Flow block at firstHeight-1 committed the previous EVM block
+			break
+		}
+		firstHeight--
+	}
+
+	txs := make([]transactionEvent, 0)
+	for height := firstHeight; height <= block.flowHeight; height++ {
+		blockID, err := b.headers.BlockIDByHeight(height)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not get block ID of height %d: %w", height, err)
+		}
+		evts, err := b.events.ByBlockID(blockID, height)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not get events of block %v: %w", blockID, err)
+		}
+		for _, event := range evts {
+			if event.Type != b.transactionEventType {
+				continue
+			}
+			cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+			if err != nil {
+				return nil, 0, fmt.Errorf("could not decode event %v of block %v: %w", event.EventIndex, blockID, err)
+			}
+			tx, err := events.DecodeTransactionEventPayload(cadenceEvent)
+			if err != nil {
+				return nil, 0, fmt.Errorf("could not decode transaction event %v of block %v: %w", event.EventIndex, blockID, err)
+			}
+			if tx.BlockHeight == block.Height {
+				txs = append(txs, transactionEvent{TransactionEventPayload: tx, blockID: blockID})
+			}
+		}
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Index < txs[j].Index
+	})
+	return txs, firstHeight, nil
+}
+
+// blockAt returns the latest EVM block committed at or below the given Flow height, or nil if the EVM was not
+// deployed at the height.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the Flow height is not indexed
+func (b *Backend) blockAt(flowHeight uint64) (*types.Block, error) {
+	snapshot := &registerSnapshot{registers: b.registers, height: flowHeight}
+	value, err := snapshot.GetValue(b.rootAddr[:], []byte(handler.BlockStoreLatestBlockKey))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the latest evm block at height %d: %w", flowHeight, err)
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	block, err := types.NewBlockFromBytes(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the latest evm block at height %d: %w", flowHeight, err)
+	}
+	return block, nil
+}
+
+// registerSnapshot is a read only snapshot of the registers indexed at a Flow height.
+type registerSnapshot struct {
+	registers RegisterReader
+	height    uint64
+}
+
+var _ types.BackendStorageSnapshot = (*registerSnapshot)(nil)
+
+// GetValue returns the value of the register, or an empty value if the register does not exist.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the height is not indexed
+func (s *registerSnapshot) GetValue(owner []byte, key []byte) ([]byte, error) {
+	values, err := s.registers.RegisterValues(flow.RegisterIDs{flow.CadenceRegisterID(owner, key)}, s.height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// blockSnapshot provides the block context of an EVM block, the hashes of the previous blocks are read from the
+// block hash list in the state after the execution of the block.
+type blockSnapshot struct {
+	chainID  flow.ChainID
+	rootAddr flow.Address
+	block    *types.Block
+	storage  types.BackendStorage
+}
+
+var _ types.BlockSnapshot = (*blockSnapshot)(nil)
+
+// BlockContext returns the block context of the EVM block.
+func (s *blockSnapshot) BlockContext() (types.BlockContext, error) {
+	bhl, err := handler.NewBlockHashList(s.storage, s.rootAddr, handler.BlockHashListCapacity)
+	if err != nil {
+		return types.BlockContext{}, err
+	}
+	return blocks.NewBlockContext(
+		s.chainID,
+		s.block.Height,
+		s.block.Timestamp,
+		func(height uint64) gethCommon.Hash {
+			_, hash, err := bhl.BlockHashByHeight(height)
+			if err != nil {
+				return gethCommon.Hash{}
+			}
+			return hash
+		},
+		s.block.PrevRandao,
+		nil,
+	)
+}
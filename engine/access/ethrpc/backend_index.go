@@ -0,0 +1,191 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rpc"
+
+	"github.com/onflow/flow-go/model/evm"
+	"github.com/onflow/flow-go/storage"
+)
+
+// maxLogsBlockRange is the maximum number of blocks eth_getLogs filters in a single request.
+const maxLogsBlockRange = 10_000
+
+// ErrIndexDisabled is returned when lookups requiring the EVM index are requested from a Backend without index.
+var ErrIndexDisabled = errors.New("evm index is disabled")
+
+// blockByHash returns the EVM block with the given hash.
+// Expected errors:
+//   - ErrIndexDisabled if the backend has no EVM index
+//   - storage.ErrNotFound if the block is not indexed
+//   - storage.ErrHeightNotIndexed if the state of the block is not indexed
+func (b *Backend) blockByHash(hash gethCommon.Hash) (*evmBlock, error) {
+	if b.index == nil {
+		return nil, ErrIndexDisabled
+	}
+	indexed, err := b.index.BlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return b.indexedBlock(indexed)
+}
+
+// indexedBlock returns the given block of the EVM index, with the Flow height which committed it.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the state of the block is not indexed
+func (b *Backend) indexedBlock(indexed *evm.Block) (*evmBlock, error) {
+	block, err := b.blockAt(indexed.FlowHeight)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.Height != indexed.Height {
+		return nil, fmt.Errorf("evm block %d is not the latest evm block at height %d", indexed.Height, indexed.FlowHeight)
+	}
+	return &evmBlock{Block: block, flowHeight: indexed.FlowHeight}, nil
+}
+
+// transactionByHash returns the indexed EVM transaction with the given hash, and the block which includes it.
+// Expected errors:
+//   - ErrIndexDisabled if the backend has no EVM index
+//   - storage.ErrNotFound if the transaction is not indexed
+func (b *Backend) transactionByHash(hash gethCommon.Hash) (*evm.Transaction, *evm.Block, error) {
+	if b.index == nil {
+		return nil, nil, ErrIndexDisabled
+	}
+	tx, err := b.index.TransactionByHash(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := b.index.BlockByHeight(tx.BlockHeight)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get evm block %d of transaction %v: %w", tx.BlockHeight, hash, err)
+	}
+	return tx, block, nil
+}
+
+// receiptByHash returns the indexed EVM transaction with the given hash, and its receipt.
+// Expected errors:
+//   - ErrIndexDisabled if the backend has no EVM index
+//   - storage.ErrNotFound if the transaction is not indexed
+func (b *Backend) receiptByHash(hash gethCommon.Hash) (*evm.Transaction, *evm.Receipt, error) {
+	if b.index == nil {
+		return nil, nil, ErrIndexDisabled
+	}
+	tx, err := b.index.TransactionByHash(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := b.index.ReceiptByTransactionHash(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get receipt of transaction %v: %w", hash, err)
+	}
+	return tx, receipt, nil
+}
+
+// logs returns the logs of the indexed EVM blocks matching the given criteria, in the order they were emitted. The
+// blocks and receipts whose bloom filter does not match the criteria are skipped.
+// Expected errors:
+//   - ErrIndexDisabled if the backend has no EVM index
+//   - storage.ErrNotFound if the filtered block hash is not indexed
+//   - storage.ErrHeightNotIndexed if the filtered range starts below the first indexed EVM block
+func (b *Backend) logs(criteria *FilterCriteria) ([]*gethTypes.Log, error) {
+	if b.index == nil {
+		return nil, ErrIndexDisabled
+	}
+	logs := make([]*gethTypes.Log, 0)
+	from, to, err := b.logsRange(criteria)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) && criteria.BlockHash == nil {
+			// no evm block is indexed yet
+			return logs, nil
+		}
+		return nil, err
+	}
+
+	for height := from; height <= to; height++ {
+		block, err := b.index.BlockByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not get evm block %d: %w", height, err)
+		}
+		if !criteria.matchesBloom(block.LogsBloom) {
+			continue
+		}
+		for _, txHash := range block.TransactionHashes {
+			receipt, err := b.index.ReceiptByTransactionHash(txHash)
+			if err != nil {
+				return nil, fmt.Errorf("could not get receipt of transaction %v: %w", txHash, err)
+			}
+			if !criteria.matchesBloom(receipt.LogsBloom) {
+				continue
+			}
+			for _, log := range receipt.Logs {
+				if criteria.matches(log) {
+					logs = append(logs, log)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+// logsRange returns the range of EVM heights filtered by the given criteria, capped at the latest indexed EVM block.
+// The range is empty if the first height is above the last height.
+func (b *Backend) logsRange(criteria *FilterCriteria) (uint64, uint64, error) {
+	if criteria.BlockHash != nil {
+		block, err := b.index.BlockByHash(*criteria.BlockHash)
+		if err != nil {
+			return 0, 0, err
+		}
+		return block.Height, block.Height, nil
+	}
+
+	first, err := b.index.FirstHeight()
+	if err != nil {
+		return 0, 0, err
+	}
+	latest, err := b.index.LatestHeight()
+	if err != nil {
+		return 0, 0, err
+	}
+	resolve := func(number *rpc.BlockNumber) (uint64, error) {
+		if number == nil {
+			return latest, nil
+		}
+		switch *number {
+		case rpc.LatestBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber, rpc.PendingBlockNumber:
+			return latest, nil
+		case rpc.EarliestBlockNumber:
+			return first, nil
+		}
+		if *number < 0 {
+			return 0, fmt.Errorf("invalid block number %d", *number)
+		}
+		return uint64(*number), nil
+	}
+
+	from, err := resolve(criteria.FromBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := resolve(criteria.ToBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("invalid block range: from block %d is above to block %d", from, to)
+	}
+	if from < first {
+		return 0, 0, fmt.Errorf("evm block %d is below the first indexed evm block %d: %w", from, first, storage.ErrHeightNotIndexed)
+	}
+	if to > latest {
+		to = latest
+	}
+	if from <= to && to-from >= maxLogsBlockRange {
+		return 0, 0, fmt.Errorf("block range of %d blocks exceeds the limit of %d blocks", to-from+1, maxLogsBlockRange)
+	}
+	return from, to, nil
+}
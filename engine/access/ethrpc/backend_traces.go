@@ -0,0 +1,225 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	evmStorage "github.com/onflow/flow-go/fvm/evm/offchain/storage"
+	"github.com/onflow/flow-go/fvm/evm/offchain/sync"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/storage"
+)
+
+// ErrTracesDisabled is returned when call traces are requested from a Backend without trace store.
+var ErrTracesDisabled = errors.New("call traces are disabled")
+
+// transactionTrace returns the stored call trace of the transaction. The trace of the transaction executed in a
+// finalized Flow block is returned if traces of several forks are stored. If no such trace is stored and the backend
+// has an EVM index, the trace is regenerated by tracing the block of the transaction.
+//
+// Without EVM index, the block of the transaction can't be resolved from its hash, so the trace can't be regenerated.
+// Tracing the block of the transaction by number regenerates and stores its trace instead (see blockTraces).
+// Expected errors:
+//   - ErrTracesDisabled if the backend has no trace store
+//   - ErrIndexDisabled if no trace of the transaction executed in a finalized block is stored, and the backend has no
+//     EVM index to regenerate it
+//   - debug.ErrTraceNotFound if no trace of the transaction executed in a finalized block is stored, and it can not
+//     be regenerated
+func (b *Backend) transactionTrace(txHash gethCommon.Hash) (json.RawMessage, error) {
+	if b.traces == nil {
+		return nil, ErrTracesDisabled
+	}
+	blockIDs, err := b.traces.BlockIDsByTransaction(txHash)
+	if err != nil && !errors.Is(err, debug.ErrTraceNotFound) {
+		return nil, err
+	}
+	for _, blockID := range blockIDs {
+		header, err := b.headers.ByBlockID(blockID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("could not get header of block %v: %w", blockID, err)
+		}
+		finalizedID, err := b.headers.BlockIDByHeight(header.Height)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("could not get finalized block of height %d: %w", header.Height, err)
+		}
+		if finalizedID == blockID {
+			return b.traces.Trace(txHash, blockID)
+		}
+	}
+	if b.index == nil {
+		return nil, fmt.Errorf("no trace of transaction %s in a finalized block is stored, and it can only be regenerated "+
+			"with evm index, or by tracing its block: %w", txHash, ErrIndexDisabled)
+	}
+	return b.indexedTransactionTrace(txHash)
+}
+
+// indexedTransactionTrace regenerates the call trace of the transaction by tracing its block, which is resolved with
+// the EVM index.
+// Expected errors:
+//   - debug.ErrTraceNotFound if the transaction is not indexed
+//   - storage.ErrHeightNotIndexed if the state before the block of the transaction is not indexed
+func (b *Backend) indexedTransactionTrace(txHash gethCommon.Hash) (json.RawMessage, error) {
+	tx, err := b.index.TransactionByHash(txHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("transaction %s is not indexed: %w", txHash, debug.ErrTraceNotFound)
+		}
+		return nil, err
+	}
+	block, err := b.blockByHeight(tx.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	hashes, traces, err := b.blockTraces(block)
+	if err != nil {
+		return nil, err
+	}
+	for i, hash := range hashes {
+		if hash == txHash {
+			return traces[i], nil
+		}
+	}
+	return nil, fmt.Errorf("transaction %s is not part of evm block %d: %w", txHash, block.Height, debug.ErrTraceNotFound)
+}
+
+// blockTraces returns the call traces of the transactions of the given EVM block, in the order of the transactions.
+// If a trace is not stored, the traces are regenerated by replaying the block, and stored.
+// Expected errors:
+//   - ErrTracesDisabled if the backend has no trace store
+//   - storage.ErrHeightNotIndexed if the state before the block is not indexed
+func (b *Backend) blockTraces(block *evmBlock) ([]gethCommon.Hash, []json.RawMessage, error) {
+	if b.traces == nil {
+		return nil, nil, ErrTracesDisabled
+	}
+	txs, firstHeight, err := b.transactionEvents(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]gethCommon.Hash, 0, len(txs))
+	traces := make([]json.RawMessage, 0, len(txs))
+	for _, tx := range txs {
+		trace, err := b.traces.Trace(tx.Hash, tx.blockID)
+		if errors.Is(err, debug.ErrTraceNotFound) {
+			return b.replayTraces(block, txs, firstHeight)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get trace of transaction %s: %w", tx.Hash, err)
+		}
+		hashes = append(hashes, tx.Hash)
+		traces = append(traces, trace)
+	}
+	return hashes, traces, nil
+}
+
+// replayTraces regenerates the call traces of the transactions of the given EVM block by replaying the block on top
+// of the state before the first Flow block which emitted its transactions. The traces are stored once the replay
+// is validated against the events.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the state before the block is not indexed
+func (b *Backend) replayTraces(
+	block *evmBlock,
+	txs []transactionEvent,
+	firstHeight uint64,
+) ([]gethCommon.Hash, []json.RawMessage, error) {
+	lowest, err := b.reporter.LowestIndexedHeight()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get lowest indexed height: %w", err)
+	}
+	if firstHeight <= lowest {
+		return nil, nil, fmt.Errorf("state before evm block %d is not indexed: %w", block.Height, storage.ErrHeightNotIndexed)
+	}
+
+	before := &registerSnapshot{registers: b.registers, height: firstHeight - 1}
+	snapshot := &blockSnapshot{
+		chainID:  b.chainID,
+		rootAddr: b.rootAddr,
+		block:    block.Block,
+		storage:  evmStorage.NewReadOnlyStorage(before),
+	}
+
+	tracer, err := debug.NewEVMCallTracer(debug.NewNoopUploader(), b.log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create tracer: %w", err)
+	}
+	replayer := sync.NewReplayer(
+		b.chainID,
+		b.rootAddr,
+		&storageProvider{snapshot: before},
+		&blockSnapshotProvider{snapshot: snapshot},
+		b.log,
+		tracer.TxTracer(),
+		true,
+	)
+
+	payloads := make([]events.TransactionEventPayload, 0, len(txs))
+	for _, tx := range txs {
+		payloads = append(payloads, *tx.TransactionEventPayload)
+	}
+	hash, err := block.Hash()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not compute hash of evm block %d: %w", block.Height, err)
+	}
+	_, err = replayer.ReplayBlock(payloads, &events.BlockEventPayload{
+		Height:              block.Height,
+		Hash:                hash,
+		Timestamp:           block.Timestamp,
+		TotalGasUsed:        block.TotalGasUsed,
+		ParentBlockHash:     block.ParentBlockHash,
+		ReceiptRoot:         block.ReceiptRoot,
+		TransactionHashRoot: block.TransactionHashRoot,
+		PrevRandao:          block.PrevRandao,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not replay evm block %d: %w", block.Height, err)
+	}
+
+	hashes := make([]gethCommon.Hash, 0, len(txs))
+	traces := make([]json.RawMessage, 0, len(txs))
+	for _, tx := range txs {
+		trace := tracer.GetResultByTxHash(tx.Hash)
+		if trace == nil {
+			return nil, nil, fmt.Errorf("no trace was generated for transaction %s", tx.Hash)
+		}
+		err := b.traces.Upload(debug.TraceID(tx.Hash, tx.blockID), trace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not store trace of transaction %s: %w", tx.Hash, err)
+		}
+		hashes = append(hashes, tx.Hash)
+		traces = append(traces, trace)
+	}
+	return hashes, traces, nil
+}
+
+// storageProvider provides the state before the replayed block.
+type storageProvider struct {
+	snapshot types.BackendStorageSnapshot
+}
+
+var _ types.StorageProvider = (*storageProvider)(nil)
+
+func (p *storageProvider) GetSnapshotAt(uint64) (types.BackendStorageSnapshot, error) {
+	return p.snapshot, nil
+}
+
+// blockSnapshotProvider provides the block context of the replayed block.
+type blockSnapshotProvider struct {
+	snapshot types.BlockSnapshot
+}
+
+var _ types.BlockSnapshotProvider = (*blockSnapshotProvider)(nil)
+
+func (p *blockSnapshotProvider) GetSnapshotAt(uint64) (types.BlockSnapshot, error) {
+	return p.snapshot, nil
+}
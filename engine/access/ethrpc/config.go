@@ -0,0 +1,62 @@
+package ethrpc
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMaxCallGasLimit is the default gas limit cap of eth_call and eth_estimateGas.
+	DefaultMaxCallGasLimit = uint64(50_000_000)
+
+	// DefaultBatchRequestLimit is the default maximum number of requests in a JSON-RPC batch.
+	DefaultBatchRequestLimit = 100
+
+	// DefaultBatchResponseMaxSize is the default maximum number of response bytes of a JSON-RPC batch.
+	DefaultBatchResponseMaxSize = 25 * 1000 * 1000
+
+	// DefaultReadTimeout is the default read timeout for the HTTP server
+	DefaultReadTimeout = time.Second * 15
+
+	// DefaultWriteTimeout is the default write timeout for the HTTP server
+	DefaultWriteTimeout = time.Second * 30
+
+	// DefaultIdleTimeout is the default idle timeout for the HTTP server
+	DefaultIdleTimeout = time.Second * 60
+
+	// DefaultTracesRetention is the default duration for which the call traces are kept in the trace store.
+	DefaultTracesRetention = 7 * 24 * time.Hour
+)
+
+// Config is the configuration of the Ethereum JSON-RPC server.
+type Config struct {
+	// ListenAddress is the address the server listens on, the server is disabled if it is empty.
+	ListenAddress string
+	// MaxCallGasLimit is the gas limit cap of eth_call and eth_estimateGas.
+	MaxCallGasLimit uint64
+	// BatchRequestLimit is the maximum number of requests in a JSON-RPC batch.
+	BatchRequestLimit int
+	// BatchResponseMaxSize is the maximum number of response bytes of a JSON-RPC batch.
+	BatchResponseMaxSize int
+	WriteTimeout         time.Duration
+	ReadTimeout          time.Duration
+	IdleTimeout          time.Duration
+	// TracesDir is the directory of the local trace store, the debug trace methods are disabled if it is empty.
+	TracesDir string
+	// TracesRetention is the duration for which the call traces are kept in the trace store, 0 keeps them forever.
+	TracesRetention time.Duration
+}
+
+// DefaultConfig returns the default configuration of the Ethereum JSON-RPC server, which is disabled.
+func DefaultConfig() Config {
+	return Config{
+		ListenAddress:        "",
+		MaxCallGasLimit:      DefaultMaxCallGasLimit,
+		BatchRequestLimit:    DefaultBatchRequestLimit,
+		BatchResponseMaxSize: DefaultBatchResponseMaxSize,
+		WriteTimeout:         DefaultWriteTimeout,
+		ReadTimeout:          DefaultReadTimeout,
+		IdleTimeout:          DefaultIdleTimeout,
+		TracesDir:            "",
+		TracesRetention:      DefaultTracesRetention,
+	}
+}
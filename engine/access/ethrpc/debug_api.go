@@ -0,0 +1,77 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/rpc"
+)
+
+// callTracer is the only tracer supported by DebugAPI, see debug.CallTracer.
+const callTracer = "callTracer"
+
+// TraceConfig is the configuration of the debug trace methods. Only the call tracer is supported, and the traces
+// only contain the top call.
+type TraceConfig struct {
+	Tracer *string `json:"tracer"`
+}
+
+// TxTraceResult is the trace of a transaction of a block.
+type TxTraceResult struct {
+	TxHash gethCommon.Hash `json:"txHash"`
+	Result json.RawMessage `json:"result"`
+}
+
+// DebugAPI implements the trace methods of the "debug" namespace of the Ethereum JSON-RPC API.
+//
+// Traces are served from the trace store of the backend. Tracing a block regenerates and stores the missing traces
+// of the block by replaying it. Tracing a transaction regenerates its trace only if the backend has an EVM index to
+// resolve the block of the transaction. Otherwise, only the stored traces are served, and tracing a transaction
+// without stored trace fails with ErrIndexDisabled, until its block was traced.
+type DebugAPI struct {
+	backend *Backend
+}
+
+// NewDebugAPI creates a new DebugAPI.
+func NewDebugAPI(backend *Backend) *DebugAPI {
+	return &DebugAPI{
+		backend: backend,
+	}
+}
+
+// TraceTransaction returns the call trace of the transaction with the given hash.
+func (api *DebugAPI) TraceTransaction(hash gethCommon.Hash, config *TraceConfig) (json.RawMessage, error) {
+	if err := validateTraceConfig(config); err != nil {
+		return nil, err
+	}
+	return api.backend.transactionTrace(hash)
+}
+
+// TraceBlockByNumber returns the call traces of the transactions of the block with the given number.
+func (api *DebugAPI) TraceBlockByNumber(number rpc.BlockNumber, config *TraceConfig) ([]*TxTraceResult, error) {
+	if err := validateTraceConfig(config); err != nil {
+		return nil, err
+	}
+	block, err := api.backend.blockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	hashes, traces, err := api.backend.blockTraces(block)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TxTraceResult, 0, len(traces))
+	for i, trace := range traces {
+		results = append(results, &TxTraceResult{TxHash: hashes[i], Result: trace})
+	}
+	return results, nil
+}
+
+// validateTraceConfig checks that the configuration requests the call tracer.
+func validateTraceConfig(config *TraceConfig) error {
+	if config == nil || config.Tracer == nil || *config.Tracer == callTracer {
+		return nil
+	}
+	return fmt.Errorf("tracer %s is not supported, only %s is available", *config.Tracer, callTracer)
+}
@@ -0,0 +1,218 @@
+package ethrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rpc"
+	gethTrie "github.com/onflow/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/ethrpc"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/emulator"
+	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
+	. "github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestDebugAPI verifies that the call traces are regenerated by replaying the EVM blocks, and served from the trace
+// store.
+func TestDebugAPI(t *testing.T) {
+	RunWithTestBackend(t, func(backend *TestBackend) {
+		rootAddr := systemcontracts.SystemContractsForChain(chainID).EVMStorage.Address
+		require.NoError(t, backend.SetValue(rootAddr[:], []byte(flow.AccountStatusKey), environment.NewAccountStatus().ToBytes()))
+
+		RunWithDeployedContract(t, GetStorageTestContract(t), backend, rootAddr, func(testContract *TestContract) {
+			RunWithEOATestAccount(t, backend, rootAddr, func(testAccount *EOATestAccount) {
+				// the state at Flow height 11 is the state before EVM block 2, which is executed at Flow height 12
+				before := backend.TestValueStore.Clone()
+
+				tx := testAccount.PrepareAndSignTx(t, testContract.DeployedAt.ToCommon(), testContract.MakeCallData(t, "store", big.NewInt(1)), big.NewInt(0), 100_000, big.NewInt(0))
+				txPayload, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				ctx, err := blocks.NewBlockContext(chainID, 2, 101, func(uint64) gethCommon.Hash { return gethCommon.Hash{} }, gethCommon.Hash{}, nil)
+				require.NoError(t, err)
+				blockView, err := emulator.NewEmulator(backend, rootAddr).NewBlockView(ctx)
+				require.NoError(t, err)
+				res, err := blockView.RunTransaction(tx)
+				require.NoError(t, err)
+				require.NoError(t, res.ValidationError)
+
+				evmBlocks := map[uint64]*types.Block{
+					11: {Height: 1, Timestamp: 100, TotalSupply: big.NewInt(0)},
+					12: {
+						Height:              2,
+						Timestamp:           101,
+						TotalSupply:         big.NewInt(0),
+						TotalGasUsed:        res.GasConsumed,
+						TransactionHashRoot: gethTypes.DeriveSha(types.TransactionHashes{tx.Hash()}, gethTrie.NewStackTrie(nil)),
+					},
+				}
+				registers := &testRegisters{
+					backend:   backend,
+					snapshots: map[uint64]*TestValueStore{11: before},
+					rootAddr:  rootAddr,
+					blocks:    evmBlocks,
+					lowest:    10,
+					highest:   12,
+				}
+
+				reporter := syncmock.NewIndexReporter(t)
+				reporter.On("LowestIndexedHeight").Return(uint64(10), nil).Maybe()
+				reporter.On("HighestIndexedHeight").Return(uint64(12), nil).Maybe()
+
+				headers := storagemock.NewHeaders(t)
+				blockIDs := make(map[uint64]flow.Identifier)
+				for height := uint64(10); height <= 12; height++ {
+					header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+					blockIDs[height] = header.ID()
+					headers.On("BlockIDByHeight", height).Return(blockIDs[height], nil).Maybe()
+					headers.On("ByBlockID", blockIDs[height]).Return(header, nil).Maybe()
+				}
+
+				evts := testEvents{
+					blockIDs[12]: {transactionEvent(t, res, txPayload, 2)},
+				}
+
+				unittest.RunWithPebbleDB(t, func(db *pebble.DB) {
+					traces := debug.NewLocalTraceStore(db, 0)
+
+					config := ethrpc.DefaultConfig()
+					config.ListenAddress = unittest.DefaultAddress
+					server, err := ethrpc.NewServer(
+						unittest.Logger(),
+						config,
+						ethrpc.NewBackend(unittest.Logger(), chainID, config.MaxCallGasLimit, registers, reporter, headers, evts, traces, nil),
+					)
+					require.NoError(t, err)
+
+					ctx, cancel := context.WithCancel(context.Background())
+					signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+					server.Start(signalerCtx)
+					unittest.RequireCloseBefore(t, server.Ready(), time.Second, "server did not start")
+					defer func() {
+						cancel()
+						unittest.RequireCloseBefore(t, server.Done(), time.Second, "server did not stop")
+					}()
+
+					client, err := rpc.DialHTTP("http://" + server.Address().String())
+					require.NoError(t, err)
+					defer client.Close()
+
+					t.Run("missing transaction trace", func(t *testing.T) {
+						// without evm index, the trace is only regenerated by tracing the block
+						var trace json.RawMessage
+						err := client.Call(&trace, "debug_traceTransaction", tx.Hash())
+						require.Error(t, err)
+						assert.Contains(t, err.Error(), ethrpc.ErrIndexDisabled.Error())
+					})
+
+					t.Run("block traces are regenerated", func(t *testing.T) {
+						var results []*ethrpc.TxTraceResult
+						require.NoError(t, client.Call(&results, "debug_traceBlockByNumber", "0x2"))
+						require.Len(t, results, 1)
+						assert.Equal(t, tx.Hash(), results[0].TxHash)
+
+						var call struct {
+							Type string             `json:"type"`
+							From gethCommon.Address `json:"from"`
+							To   gethCommon.Address `json:"to"`
+						}
+						require.NoError(t, json.Unmarshal(results[0].Result, &call))
+						assert.Equal(t, "CALL", call.Type)
+						assert.Equal(t, testAccount.Address().ToCommon(), call.From)
+						assert.Equal(t, testContract.DeployedAt.ToCommon(), call.To)
+
+						stored, err := traces.Trace(tx.Hash(), blockIDs[12])
+						require.NoError(t, err)
+						assert.JSONEq(t, string(results[0].Result), string(stored))
+
+						var trace json.RawMessage
+						require.NoError(t, client.Call(&trace, "debug_traceTransaction", tx.Hash(), map[string]string{"tracer": "callTracer"}))
+						assert.JSONEq(t, string(stored), string(trace))
+					})
+
+					t.Run("blocks without transactions", func(t *testing.T) {
+						var results []*ethrpc.TxTraceResult
+						require.NoError(t, client.Call(&results, "debug_traceBlockByNumber", "0x1"))
+						assert.Empty(t, results)
+					})
+
+					t.Run("unsupported tracer", func(t *testing.T) {
+						var trace json.RawMessage
+						require.Error(t, client.Call(&trace, "debug_traceTransaction", tx.Hash(), map[string]string{"tracer": "prestateTracer"}))
+					})
+				})
+
+				// with an EVM index, the trace of a transaction is regenerated by tracing its block
+				unittest.RunWithPebbleDB(t, func(db *pebble.DB) {
+					unittest.RunWithBadgerDB(t, func(indexDB *badger.DB) {
+						traces := debug.NewLocalTraceStore(db, 0)
+						index := bstorage.NewEVMIndex(indexDB)
+						evmIndexer := indexer.NewEVMIndexer(unittest.Logger(), chainID, index)
+						// the blocks are committed by their EVM.BlockExecuted events, which are not needed to replay them
+						indexedEvents := testEvents{
+							blockIDs[11]: {blockEvent(t, evmBlocks[11])},
+							blockIDs[12]: {transactionEvent(t, res, txPayload, 2), blockEvent(t, evmBlocks[12])},
+						}
+						for height := uint64(10); height <= 12; height++ {
+							batch := bstorage.NewBatch(indexDB)
+							require.NoError(t, evmIndexer.IndexEvents(blockIDs[height], height, indexedEvents[blockIDs[height]], batch))
+							require.NoError(t, batch.Flush())
+						}
+
+						config := ethrpc.DefaultConfig()
+						config.ListenAddress = unittest.DefaultAddress
+						server, err := ethrpc.NewServer(
+							unittest.Logger(),
+							config,
+							ethrpc.NewBackend(unittest.Logger(), chainID, config.MaxCallGasLimit, registers, reporter, headers, evts, traces, index),
+						)
+						require.NoError(t, err)
+
+						ctx, cancel := context.WithCancel(context.Background())
+						signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+						server.Start(signalerCtx)
+						unittest.RequireCloseBefore(t, server.Ready(), time.Second, "server did not start")
+						defer func() {
+							cancel()
+							unittest.RequireCloseBefore(t, server.Done(), time.Second, "server did not stop")
+						}()
+
+						client, err := rpc.DialHTTP("http://" + server.Address().String())
+						require.NoError(t, err)
+						defer client.Close()
+
+						t.Run("transaction traces are regenerated", func(t *testing.T) {
+							var trace json.RawMessage
+							require.NoError(t, client.Call(&trace, "debug_traceTransaction", tx.Hash()))
+
+							stored, err := traces.Trace(tx.Hash(), blockIDs[12])
+							require.NoError(t, err)
+							assert.JSONEq(t, string(stored), string(trace))
+						})
+					})
+				})
+			})
+		})
+	})
+}
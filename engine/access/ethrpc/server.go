@@ -0,0 +1,108 @@
+package ethrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/onflow/go-ethereum/rpc"
+	"github.com/rs/cors"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// Server serves the Ethereum JSON-RPC API over HTTP, see EthAPI. The trace methods of the debug namespace are
+// served if the backend has a trace store, see DebugAPI.
+type Server struct {
+	component.Component
+
+	log    zerolog.Logger
+	config Config
+	rpc    *rpc.Server
+
+	addrLock sync.RWMutex
+	addr     net.Addr
+}
+
+// NewServer creates a new Server serving the API backed by the given backend.
+func NewServer(log zerolog.Logger, config Config, backend *Backend) (*Server, error) {
+	rpcServer := rpc.NewServer()
+	rpcServer.SetBatchLimits(config.BatchRequestLimit, config.BatchResponseMaxSize)
+	err := rpcServer.RegisterName("eth", NewEthAPI(backend))
+	if err != nil {
+		return nil, fmt.Errorf("could not register eth API: %w", err)
+	}
+	if backend.traces != nil {
+		err = rpcServer.RegisterName("debug", NewDebugAPI(backend))
+		if err != nil {
+			return nil, fmt.Errorf("could not register debug API: %w", err)
+		}
+	}
+
+	s := &Server{
+		log:    log.With().Str("component", "ethrpc_server").Logger(),
+		config: config,
+		rpc:    rpcServer,
+	}
+	s.Component = component.NewComponentManagerBuilder().
+		AddWorker(s.serve).
+		Build()
+	return s, nil
+}
+
+// Address returns the address the server listens on, or nil if the server is not listening yet.
+func (s *Server) Address() net.Addr {
+	s.addrLock.RLock()
+	defer s.addrLock.RUnlock()
+	return s.addr
+}
+
+// serve serves the API until the context is done.
+func (s *Server) serve(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	s.log.Info().Str("ethrpc_address", s.config.ListenAddress).Msg("starting Ethereum JSON-RPC server on address")
+
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		AllowedMethods: []string{http.MethodPost, http.MethodOptions},
+	})
+	server := &http.Server{
+		Handler:      c.Handler(s.rpc),
+		WriteTimeout: s.config.WriteTimeout,
+		ReadTimeout:  s.config.ReadTimeout,
+		IdleTimeout:  s.config.IdleTimeout,
+		BaseContext: func(_ net.Listener) context.Context {
+			return irrecoverable.WithSignalerContext(ctx, ctx)
+		},
+	}
+
+	l, err := net.Listen("tcp", s.config.ListenAddress)
+	if err != nil {
+		ctx.Throw(fmt.Errorf("could not listen on %s: %w", s.config.ListenAddress, err))
+		return
+	}
+
+	s.addrLock.Lock()
+	s.addr = l.Addr()
+	s.addrLock.Unlock()
+	ready()
+
+	go func() {
+		<-ctx.Done()
+		s.rpc.Stop()
+		err := server.Shutdown(context.Background())
+		if err != nil {
+			s.log.Err(err).Msg("error stopping Ethereum JSON-RPC server")
+		}
+	}()
+
+	err = server.Serve(l) // blocking call
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		ctx.Throw(fmt.Errorf("Ethereum JSON-RPC server failed: %w", err))
+	}
+}
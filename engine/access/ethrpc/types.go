@@ -0,0 +1,402 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rlp"
+	"github.com/onflow/go-ethereum/rpc"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/evm"
+)
+
+// TransactionArgs are the arguments of eth_call and eth_estimateGas. The gas price and nonce fields are ignored,
+// since the calls are executed as direct calls.
+type TransactionArgs struct {
+	From  *gethCommon.Address `json:"from"`
+	To    *gethCommon.Address `json:"to"`
+	Gas   *hexutil.Uint64     `json:"gas"`
+	Value *hexutil.Big        `json:"value"`
+	Data  *hexutil.Bytes      `json:"data"`
+	Input *hexutil.Bytes      `json:"input"`
+}
+
+// data returns the input of the call, the input field takes precedence over the legacy data field.
+func (args *TransactionArgs) data() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+// OverrideAccount overrides the state of an account during eth_call and eth_estimateGas.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64                     `json:"nonce"`
+	Code      *hexutil.Bytes                      `json:"code"`
+	Balance   *hexutil.Big                        `json:"balance"`
+	State     map[gethCommon.Hash]gethCommon.Hash `json:"state"`
+	StateDiff map[gethCommon.Hash]gethCommon.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the set of accounts overridden during eth_call and eth_estimateGas.
+type StateOverride map[gethCommon.Address]OverrideAccount
+
+// Block is the JSON-RPC representation of an EVM block. Transactions holds either the transaction hashes or the
+// full transactions of the block.
+type Block struct {
+	Number           hexutil.Uint64       `json:"number"`
+	Hash             gethCommon.Hash      `json:"hash"`
+	ParentHash       gethCommon.Hash      `json:"parentHash"`
+	Nonce            gethTypes.BlockNonce `json:"nonce"`
+	Sha3Uncles       gethCommon.Hash      `json:"sha3Uncles"`
+	LogsBloom        gethTypes.Bloom      `json:"logsBloom"`
+	TransactionsRoot gethCommon.Hash      `json:"transactionsRoot"`
+	StateRoot        gethCommon.Hash      `json:"stateRoot"`
+	ReceiptsRoot     gethCommon.Hash      `json:"receiptsRoot"`
+	Miner            gethCommon.Address   `json:"miner"`
+	Difficulty       hexutil.Uint64       `json:"difficulty"`
+	ExtraData        hexutil.Bytes        `json:"extraData"`
+	GasLimit         hexutil.Uint64       `json:"gasLimit"`
+	GasUsed          hexutil.Uint64       `json:"gasUsed"`
+	Timestamp        hexutil.Uint64       `json:"timestamp"`
+	Transactions     interface{}          `json:"transactions"`
+	Uncles           []gethCommon.Hash    `json:"uncles"`
+	MixHash          gethCommon.Hash      `json:"mixHash"`
+	BaseFeePerGas    hexutil.Big          `json:"baseFeePerGas"`
+}
+
+// Transaction is the JSON-RPC representation of an EVM transaction. Direct calls are represented by their
+// canonical legacy transaction, see types.DirectCall.
+type Transaction struct {
+	BlockHash        gethCommon.Hash     `json:"blockHash"`
+	BlockNumber      hexutil.Uint64      `json:"blockNumber"`
+	From             gethCommon.Address  `json:"from"`
+	Gas              hexutil.Uint64      `json:"gas"`
+	GasPrice         *hexutil.Big        `json:"gasPrice"`
+	Hash             gethCommon.Hash     `json:"hash"`
+	Input            hexutil.Bytes       `json:"input"`
+	Nonce            hexutil.Uint64      `json:"nonce"`
+	To               *gethCommon.Address `json:"to"`
+	TransactionIndex hexutil.Uint64      `json:"transactionIndex"`
+	Value            *hexutil.Big        `json:"value"`
+	Type             hexutil.Uint64      `json:"type"`
+	ChainID          *hexutil.Big        `json:"chainId,omitempty"`
+	V                *hexutil.Big        `json:"v"`
+	R                *hexutil.Big        `json:"r"`
+	S                *hexutil.Big        `json:"s"`
+}
+
+// Receipt is the JSON-RPC representation of an EVM transaction receipt.
+type Receipt struct {
+	TransactionHash   gethCommon.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64      `json:"transactionIndex"`
+	BlockHash         gethCommon.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64      `json:"blockNumber"`
+	From              gethCommon.Address  `json:"from"`
+	To                *gethCommon.Address `json:"to"`
+	GasUsed           hexutil.Uint64      `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64      `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *hexutil.Big        `json:"effectiveGasPrice"`
+	ContractAddress   *gethCommon.Address `json:"contractAddress"`
+	Logs              []*gethTypes.Log    `json:"logs"`
+	LogsBloom         gethTypes.Bloom     `json:"logsBloom"`
+	Status            hexutil.Uint64      `json:"status"`
+	Type              hexutil.Uint64      `json:"type"`
+}
+
+// decodedTransaction is a transaction decoded from its EVM.TransactionExecuted event, or from the EVM index.
+type decodedTransaction struct {
+	hash  gethCommon.Hash
+	index uint16
+	// event is the event of the transaction, nil if the transaction was decoded from the EVM index
+	event *events.TransactionEventPayload
+	tx    *gethTypes.Transaction
+	from  gethCommon.Address
+}
+
+// decodeTransaction decodes the transaction of the given event, and recovers its sender.
+func decodeTransaction(event *events.TransactionEventPayload, signer gethTypes.Signer) (*decodedTransaction, error) {
+	if event.TransactionType == types.DirectCallTxType {
+		call, err := types.DirectCallFromEncoded(event.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode direct call %v: %w", event.Hash, err)
+		}
+		return &decodedTransaction{
+			hash:  event.Hash,
+			index: event.Index,
+			event: event,
+			tx:    call.Transaction(),
+			from:  call.From.ToCommon(),
+		}, nil
+	}
+
+	tx := &gethTypes.Transaction{}
+	if err := tx.UnmarshalBinary(event.Payload); err != nil {
+		return nil, fmt.Errorf("could not decode transaction %v: %w", event.Hash, err)
+	}
+	from, err := gethTypes.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover sender of transaction %v: %w", event.Hash, err)
+	}
+	return &decodedTransaction{hash: event.Hash, index: event.Index, event: event, tx: tx, from: from}, nil
+}
+
+// decodeIndexedTransaction decodes the given transaction of the EVM index, its sender is taken from the index.
+func decodeIndexedTransaction(indexed *evm.Transaction) (*decodedTransaction, error) {
+	tx := &gethTypes.Transaction{}
+	if indexed.Type == types.DirectCallTxType {
+		call, err := types.DirectCallFromEncoded(indexed.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode direct call %v: %w", indexed.Hash, err)
+		}
+		tx = call.Transaction()
+	} else if err := tx.UnmarshalBinary(indexed.Payload); err != nil {
+		return nil, fmt.Errorf("could not decode transaction %v: %w", indexed.Hash, err)
+	}
+	return &decodedTransaction{hash: indexed.Hash, index: indexed.Index, tx: tx, from: indexed.From}, nil
+}
+
+// transaction returns the JSON-RPC representation of the transaction.
+func (t *decodedTransaction) transaction(blockHash gethCommon.Hash, blockNumber uint64) *Transaction {
+	v, r, s := t.tx.RawSignatureValues()
+	result := &Transaction{
+		BlockHash:        blockHash,
+		BlockNumber:      hexutil.Uint64(blockNumber),
+		From:             t.from,
+		Gas:              hexutil.Uint64(t.tx.Gas()),
+		GasPrice:         (*hexutil.Big)(t.tx.GasPrice()),
+		Hash:             t.hash,
+		Input:            t.tx.Data(),
+		Nonce:            hexutil.Uint64(t.tx.Nonce()),
+		To:               t.tx.To(),
+		TransactionIndex: hexutil.Uint64(t.index),
+		Value:            (*hexutil.Big)(t.tx.Value()),
+		Type:             hexutil.Uint64(t.tx.Type()),
+		V:                (*hexutil.Big)(v),
+		R:                (*hexutil.Big)(r),
+		S:                (*hexutil.Big)(s),
+	}
+	if t.tx.Type() != gethTypes.LegacyTxType {
+		result.ChainID = (*hexutil.Big)(t.tx.ChainId())
+	}
+	return result
+}
+
+// receipt returns the JSON-RPC representation of the receipt of the transaction. The logs are indexed within the
+// block starting at the given log index.
+func (t *decodedTransaction) receipt(
+	blockHash gethCommon.Hash,
+	blockNumber uint64,
+	cumulativeGasUsed uint64,
+	logIndex uint,
+) (*Receipt, error) {
+	var logs []*gethTypes.Log
+	if len(t.event.Logs) > 0 {
+		if err := rlp.DecodeBytes(t.event.Logs, &logs); err != nil {
+			return nil, fmt.Errorf("could not decode logs of transaction %v: %w", t.event.Hash, err)
+		}
+	}
+	for _, log := range logs {
+		log.BlockNumber = blockNumber
+		log.BlockHash = blockHash
+		log.TxHash = t.event.Hash
+		log.TxIndex = uint(t.event.Index)
+		log.Index = logIndex
+		logIndex++
+	}
+	if logs == nil {
+		logs = []*gethTypes.Log{}
+	}
+
+	receipt := &Receipt{
+		TransactionHash:   t.event.Hash,
+		TransactionIndex:  hexutil.Uint64(t.event.Index),
+		BlockHash:         blockHash,
+		BlockNumber:       hexutil.Uint64(blockNumber),
+		From:              t.from,
+		To:                t.tx.To(),
+		GasUsed:           hexutil.Uint64(t.event.GasConsumed),
+		CumulativeGasUsed: hexutil.Uint64(cumulativeGasUsed),
+		EffectiveGasPrice: (*hexutil.Big)(t.tx.GasPrice()),
+		Logs:              logs,
+		LogsBloom:         gethTypes.BytesToBloom(gethTypes.LogsBloom(logs)),
+		Status:            hexutil.Uint64(gethTypes.ReceiptStatusSuccessful),
+		Type:              hexutil.Uint64(t.tx.Type()),
+	}
+	if types.ErrorCode(t.event.ErrorCode) != types.ErrCodeNoError {
+		receipt.Status = hexutil.Uint64(gethTypes.ReceiptStatusFailed)
+	}
+	if t.event.ContractAddress != "" {
+		address := gethCommon.HexToAddress(t.event.ContractAddress)
+		receipt.ContractAddress = &address
+	}
+	return receipt, nil
+}
+
+// indexedReceipt returns the JSON-RPC representation of the given receipt of the transaction from the EVM index.
+func (t *decodedTransaction) indexedReceipt(indexed *evm.Receipt) *Receipt {
+	logs := indexed.Logs
+	if logs == nil {
+		logs = []*gethTypes.Log{}
+	}
+	return &Receipt{
+		TransactionHash:   indexed.TransactionHash,
+		TransactionIndex:  hexutil.Uint64(indexed.Index),
+		BlockHash:         indexed.BlockHash,
+		BlockNumber:       hexutil.Uint64(indexed.BlockHeight),
+		From:              t.from,
+		To:                t.tx.To(),
+		GasUsed:           hexutil.Uint64(indexed.GasUsed),
+		CumulativeGasUsed: hexutil.Uint64(indexed.CumulativeGasUsed),
+		EffectiveGasPrice: (*hexutil.Big)(t.tx.GasPrice()),
+		ContractAddress:   indexed.ContractAddress,
+		Logs:              logs,
+		LogsBloom:         indexed.LogsBloom,
+		Status:            hexutil.Uint64(indexed.Status),
+		Type:              hexutil.Uint64(t.tx.Type()),
+	}
+}
+
+// newBlock returns the JSON-RPC representation of the given EVM block. The logs bloom is aggregated from the receipts.
+func newBlock(block *types.Block, hash gethCommon.Hash, transactions interface{}, receipts []*Receipt) *Block {
+	var bloom gethTypes.Bloom
+	for _, receipt := range receipts {
+		for i := range bloom {
+			bloom[i] |= receipt.LogsBloom[i]
+		}
+	}
+
+	return &Block{
+		Number:           hexutil.Uint64(block.Height),
+		Hash:             hash,
+		ParentHash:       block.ParentBlockHash,
+		Sha3Uncles:       gethTypes.EmptyUncleHash,
+		LogsBloom:        bloom,
+		TransactionsRoot: block.TransactionHashRoot,
+		ReceiptsRoot:     block.ReceiptRoot,
+		Miner:            types.CoinbaseAddress.ToCommon(),
+		GasLimit:         hexutil.Uint64(types.DefaultBlockLevelGasLimit),
+		GasUsed:          hexutil.Uint64(block.TotalGasUsed),
+		Timestamp:        hexutil.Uint64(block.Timestamp),
+		Transactions:     transactions,
+		Uncles:           []gethCommon.Hash{},
+		MixHash:          block.PrevRandao,
+		BaseFeePerGas:    hexutil.Big(*big.NewInt(0)),
+	}
+}
+
+// maxFilterTopics is the maximum number of topic positions of a log filter.
+const maxFilterTopics = 4
+
+// FilterCriteria are the arguments of eth_getLogs. A log matches the criteria if it was emitted by one of the
+// addresses, and each of its topics is one of the topics given for its position. An empty list of addresses or
+// topics matches any address or topic.
+type FilterCriteria struct {
+	BlockHash *gethCommon.Hash
+	FromBlock *rpc.BlockNumber
+	ToBlock   *rpc.BlockNumber
+	Addresses []gethCommon.Address
+	Topics    [][]gethCommon.Hash
+}
+
+// UnmarshalJSON decodes the criteria, the address and each topic position are given either as a single value or as
+// a list of values, a null topic position matches any topic.
+func (c *FilterCriteria) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *gethCommon.Hash  `json:"blockHash"`
+		FromBlock *rpc.BlockNumber  `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber  `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.BlockHash != nil && (raw.FromBlock != nil || raw.ToBlock != nil) {
+		return fmt.Errorf("blockHash can not be combined with fromBlock or toBlock")
+	}
+	if len(raw.Topics) > maxFilterTopics {
+		return fmt.Errorf("at most %d topic positions are allowed", maxFilterTopics)
+	}
+
+	addresses, err := decodeOneOrMany[gethCommon.Address](raw.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	topics := make([][]gethCommon.Hash, 0, len(raw.Topics))
+	for i, position := range raw.Topics {
+		values, err := decodeOneOrMany[gethCommon.Hash](position)
+		if err != nil {
+			return fmt.Errorf("invalid topic %d: %w", i, err)
+		}
+		topics = append(topics, values)
+	}
+
+	*c = FilterCriteria{
+		BlockHash: raw.BlockHash,
+		FromBlock: raw.FromBlock,
+		ToBlock:   raw.ToBlock,
+		Addresses: addresses,
+		Topics:    topics,
+	}
+	return nil
+}
+
+// matchesBloom returns false if the bloom filter proves that none of the filtered logs matches the criteria.
+func (c *FilterCriteria) matchesBloom(bloom gethTypes.Bloom) bool {
+	if len(c.Addresses) > 0 && !slices.ContainsFunc(c.Addresses, func(address gethCommon.Address) bool {
+		return bloom.Test(address.Bytes())
+	}) {
+		return false
+	}
+	for _, topics := range c.Topics {
+		if len(topics) > 0 && !slices.ContainsFunc(topics, func(topic gethCommon.Hash) bool {
+			return bloom.Test(topic.Bytes())
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns true if the log matches the criteria.
+func (c *FilterCriteria) matches(log *gethTypes.Log) bool {
+	if len(c.Addresses) > 0 && !slices.Contains(c.Addresses, log.Address) {
+		return false
+	}
+	if len(c.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range c.Topics {
+		if len(topics) > 0 && !slices.Contains(topics, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeOneOrMany decodes either a single value or a list of values, null is decoded as an empty list.
+func decodeOneOrMany[T any](data json.RawMessage) ([]T, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err == nil {
+		return []T{one}, nil
+	}
+	var many []T
+	if err := json.Unmarshal(data, &many); err != nil {
+		return nil, err
+	}
+	return many, nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (e *EmergencySeal) Build(seal *flow.EmergencySeal) {
+	chunks := make([]EmergencySealChunk, len(seal.UnapprovedChunks))
+	for i, flowChunk := range seal.UnapprovedChunks {
+		var chunk EmergencySealChunk
+		chunk.Build(flowChunk)
+		chunks[i] = chunk
+	}
+
+	e.ResultId = seal.ResultID.String()
+	e.BlockId = seal.BlockID.String()
+	e.BlockHeight = util.FromUint(seal.BlockHeight)
+	e.IncorporatedBlockId = seal.IncorporatedBlockID.String()
+	e.RequiredApprovals = util.FromUint(seal.RequiredApprovals)
+	e.UnapprovedChunks = chunks
+}
+
+func (c *EmergencySealChunk) Build(chunk flow.EmergencySealChunk) {
+	assignedVerifiers := make([]string, len(chunk.AssignedVerifiers))
+	for i, verifierID := range chunk.AssignedVerifiers {
+		assignedVerifiers[i] = verifierID.String()
+	}
+
+	approvedBy := make([]string, len(chunk.ApprovedBy))
+	for i, verifierID := range chunk.ApprovedBy {
+		approvedBy[i] = verifierID.String()
+	}
+
+	c.Index = util.FromUint(chunk.Index)
+	c.AssignedVerifiers = assignedVerifiers
+	c.ApprovedBy = approvedBy
+}
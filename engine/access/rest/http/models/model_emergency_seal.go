@@ -0,0 +1,18 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type EmergencySeal struct {
+	ResultId            string               `json:"result_id"`
+	BlockId             string               `json:"block_id"`
+	BlockHeight         string               `json:"block_height"`
+	IncorporatedBlockId string               `json:"incorporated_block_id"`
+	RequiredApprovals   string               `json:"required_approvals"`
+	UnapprovedChunks    []EmergencySealChunk `json:"unapproved_chunks"`
+}
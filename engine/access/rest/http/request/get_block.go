@@ -146,3 +146,17 @@ func GetBlockPayloadRequest(r *common.Request) (GetBlockPayload, error) {
 	err := req.Build(r)
 	return req, err
 }
+
+type GetEmergencySeals struct {
+	GetByIDRequest
+}
+
+// GetEmergencySealsRequest extracts necessary variables from the provided request,
+// builds a GetEmergencySeals instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetEmergencySealsRequest(r *common.Request) (GetEmergencySeals, error) {
+	var req GetEmergencySeals
+	err := req.Build(r)
+	return req, err
+}
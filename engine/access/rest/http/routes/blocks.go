@@ -221,3 +221,23 @@ func (blkProvider *blockProvider) getBlock(ctx context.Context) (*flow.Block, fl
 	}
 	return blk, status, nil
 }
+
+// GetEmergencySealsByBlockID gets the audit records for emergency seals included in the payload of the block with the given ID.
+func GetEmergencySealsByBlockID(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetEmergencySealsRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	records, err := backend.GetEmergencySealsByBlockID(r.Context(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.EmergencySeal, len(records))
+	for i, record := range records {
+		response[i].Build(record)
+	}
+
+	return response, nil
+}
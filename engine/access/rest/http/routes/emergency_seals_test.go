@@ -0,0 +1,77 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	mocks "github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func getEmergencySealsReq(blockID string) *http.Request {
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/blocks/%s/emergency_seals", blockID), nil)
+	return req
+}
+
+func TestGetEmergencySealsByBlockID(t *testing.T) {
+	t.Run("get by block ID", func(t *testing.T) {
+		backend := &mock.API{}
+		blockID := unittest.IdentifierFixture()
+		verifierID := unittest.IdentifierFixture()
+		record := &flow.EmergencySeal{
+			ResultID:            unittest.IdentifierFixture(),
+			BlockID:             unittest.IdentifierFixture(),
+			BlockHeight:         42,
+			IncorporatedBlockID: unittest.IdentifierFixture(),
+			RequiredApprovals:   1,
+			UnapprovedChunks: []flow.EmergencySealChunk{{
+				Index:             3,
+				AssignedVerifiers: flow.IdentifierList{verifierID},
+				ApprovedBy:        flow.IdentifierList{},
+			}},
+		}
+		backend.Mock.
+			On("GetEmergencySealsByBlockID", mocks.Anything, blockID).
+			Return([]*flow.EmergencySeal{record}, nil).
+			Once()
+
+		expected := fmt.Sprintf(`[{
+			"result_id": "%s",
+			"block_id": "%s",
+			"block_height": "42",
+			"incorporated_block_id": "%s",
+			"required_approvals": "1",
+			"unapproved_chunks": [{
+				"index": "3",
+				"assigned_verifiers": ["%s"],
+				"approved_by": []
+			}]
+		}]`, record.ResultID, record.BlockID, record.IncorporatedBlockID, verifierID)
+		router.AssertOKResponse(t, getEmergencySealsReq(blockID.String()), expected, backend)
+		mocks.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		backend := &mock.API{}
+		blockID := unittest.IdentifierFixture()
+		backend.Mock.
+			On("GetEmergencySealsByBlockID", mocks.Anything, blockID).
+			Return(nil, status.Error(codes.NotFound, "block not found")).
+			Once()
+
+		router.AssertResponse(t, getEmergencySealsReq(blockID.String()), http.StatusNotFound, `{"code":404,"message":"Flow resource not found: block not found"}`, backend)
+		mocks.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("invalid block ID", func(t *testing.T) {
+		backend := &mock.API{}
+		router.AssertResponse(t, getEmergencySealsReq("invalid"), http.StatusBadRequest, `{"code":400,"message":"invalid ID format"}`, backend)
+	})
+}
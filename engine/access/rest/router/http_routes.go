@@ -44,6 +44,11 @@ var Routes = []route{{
 	Pattern: "/blocks/{id}/payload",
 	Name:    "getBlockPayloadByID",
 	Handler: routes.GetBlockPayloadByID,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/blocks/{id}/emergency_seals",
+	Name:    "getEmergencySealsByBlockID",
+	Handler: routes.GetEmergencySealsByBlockID,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/execution_results/{id}",
@@ -44,6 +44,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/payload",
 			expected: "getBlockPayloadByID",
 		},
+		{
+			name:     "/v1/blocks/{id}/emergency_seals",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/emergency_seals",
+			expected: "getEmergencySealsByBlockID",
+		},
 		{
 			name:     "/v1/execution_results/{id}",
 			url:      "/v1/execution_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -151,6 +156,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/payload",
 			expected: "getBlockPayloadByID",
 		},
+		{
+			name:     "/v1/blocks/{id}/emergency_seals",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/emergency_seals",
+			expected: "getEmergencySealsByBlockID",
+		},
 		{
 			name:     "/v1/execution_results/{id}",
 			url:      "/v1/execution_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
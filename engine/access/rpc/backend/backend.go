@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/fvm/blueprints"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/chunks"
 	"github.com/onflow/flow-go/module/counters"
 	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/module/state_synchronization"
@@ -40,6 +41,10 @@ const DefaultSnapshotHistoryLimit = 500
 // limiting cache size to 16MB and does not affect script execution, only for keeping logs tidy
 const DefaultLoggedScriptsCacheSize = 1_000_000
 
+// DefaultEmergencySealsCacheSize is the default number of finalized blocks, for which the reconstructed emergency seal
+// records are cached
+const DefaultEmergencySealsCacheSize = 1_000
+
 // DefaultConnectionPoolSize is the default size for the connection pool to collection and execution nodes
 const DefaultConnectionPoolSize = 250
 
@@ -63,6 +68,9 @@ type Backend struct {
 	backendBlockDetails
 	backendAccounts
 	backendExecutionResults
+	backendEmergencySeals
+	backendRegisterProofs
+	backendResourceReports
 	backendNetwork
 	backendSubscribeBlocks
 	backendSubscribeTransactions
@@ -112,6 +120,11 @@ type Params struct {
 	IndexReporter              state_synchronization.IndexReporter
 	VersionControl             *version.VersionControl
 	ExecNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+	// TxResourceReports is the local index of transaction resource reports. If nil, reports are always
+	// requested from the execution nodes.
+	TxResourceReports storage.TransactionResourceReports
+	// ScriptResultCache caches the results of scripts executed at sealed blocks. If nil, results are not cached.
+	ScriptResultCache *ScriptResultCache
 }
 
 var _ TransactionErrorMessage = (*Backend)(nil)
@@ -136,6 +149,18 @@ func New(params Params) (*Backend, error) {
 		}
 	}
 
+	// the chunk assignment is computed with the protocol default for the number of verifiers per chunk,
+	// which consensus nodes use for sealing
+	chunkAssigner, err := chunks.NewChunkAssigner(flow.DefaultChunkAssignmentAlpha, params.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize chunk assigner: %w", err)
+	}
+
+	emergencySealsCache, err := lru.New[flow.Identifier, []*flow.EmergencySeal](DefaultEmergencySealsCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize emergency seals cache: %w", err)
+	}
+
 	// the system tx is hardcoded and never changes during runtime
 	systemTx, err := blueprints.SystemChunkTransaction(params.ChainID.Chain())
 	if err != nil {
@@ -168,6 +193,7 @@ func New(params Params) (*Backend, error) {
 			scriptExecutor:             params.ScriptExecutor,
 			scriptExecMode:             params.ScriptExecutionMode,
 			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
+			resultCache:                params.ScriptResultCache,
 		},
 		backendEvents: backendEvents{
 			log:                        params.Log,
@@ -202,6 +228,35 @@ func New(params Params) (*Backend, error) {
 		backendExecutionResults: backendExecutionResults{
 			executionResults: params.ExecutionResults,
 		},
+		backendEmergencySeals: backendEmergencySeals{
+			state:            params.State,
+			headers:          params.Headers,
+			blocks:           params.Blocks,
+			executionResults: params.ExecutionResults,
+			assigner:         chunkAssigner,
+			cache:            emergencySealsCache,
+		},
+		backendRegisterProofs: backendRegisterProofs{
+			log:                        params.Log,
+			state:                      params.State,
+			headers:                    params.Headers,
+			executionResults:           params.ExecutionResults,
+			connFactory:                params.ConnFactory,
+			nodeCommunicator:           params.Communicator,
+			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
+		},
+		backendResourceReports: backendResourceReports{
+			log:                        params.Log,
+			state:                      params.State,
+			headers:                    params.Headers,
+			blocks:                     params.Blocks,
+			collections:                params.Collections,
+			systemTxID:                 systemTxID,
+			reports:                    params.TxResourceReports,
+			connFactory:                params.ConnFactory,
+			nodeCommunicator:           params.Communicator,
+			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
+		},
 		backendNetwork: backendNetwork{
 			state:                params.State,
 			chainID:              params.ChainID,
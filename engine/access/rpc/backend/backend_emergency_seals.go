@@ -0,0 +1,182 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/fork"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// emergencySealRequiredApprovals is the number of approvals per chunk, which access nodes assume to be required for
+// regular seal construction.
+const emergencySealRequiredApprovals = 1
+
+// backendEmergencySeals reconstructs emergency seal audit records from the seals included in
+// finalized blocks. In contrast to consensus nodes, which persist the records once the emergency
+// seals they constructed are finalized, access nodes derive the records from the on-chain approval
+// signatures. The number of approvals required for regular seal construction is a dynamically
+// updatable setting of the consensus nodes, which is not part of the protocol state. Therefore,
+// access nodes only report chunks which were sealed without any approval, which is only possible
+// through emergency sealing, as long as consensus nodes require approvals for sealing at all.
+// The verifier assignment is computed from the protocol state with the protocol default for the
+// number of verifiers per chunk.
+//
+// Reconstructing the records requires loading the unsealed section of the fork up to the block, hence
+// the records of finalized blocks, which never change, are cached.
+type backendEmergencySeals struct {
+	state            protocol.State
+	headers          storage.Headers
+	blocks           storage.Blocks
+	executionResults storage.ExecutionResults
+	assigner         module.ChunkAssigner
+	cache            *lru.Cache[flow.Identifier, []*flow.EmergencySeal]
+}
+
+// GetEmergencySealsByBlockID returns the audit records for all seals in the payload of the given
+// block, which sealed any chunk without approvals. Returns an empty
+// list if the block contains no emergency seals.
+//
+// Expected errors during normal operation:
+//   - codes.NotFound if the block or any of the referenced results are not found.
+func (b *backendEmergencySeals) GetEmergencySealsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.EmergencySeal, error) {
+	if records, ok := b.cache.Get(blockID); ok {
+		return records, nil
+	}
+
+	block, err := b.blocks.ByID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	records, err := b.emergencySealRecords(block)
+	if err != nil {
+		return nil, err
+	}
+
+	finalizedID, err := b.headers.BlockIDByHeight(block.Header.Height)
+	if err == nil && finalizedID == blockID {
+		b.cache.Add(blockID, records)
+	}
+
+	return records, nil
+}
+
+// emergencySealRecords returns the audit records for all emergency seals in the payload of the given block.
+//
+// Expected errors during normal operation:
+//   - codes.NotFound if any of the referenced results are not found.
+func (b *backendEmergencySeals) emergencySealRecords(block *flow.Block) ([]*flow.EmergencySeal, error) {
+	if len(block.Payload.Seals) == 0 {
+		return []*flow.EmergencySeal{}, nil
+	}
+
+	incorporatingBlocks, err := b.incorporatingBlocks(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*flow.EmergencySeal, 0)
+	for _, seal := range block.Payload.Seals {
+		record, err := b.emergencySealRecord(seal, incorporatingBlocks)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// incorporatingBlocks maps the IDs of results incorporated in the unsealed section of the fork
+// up to the parent of the given block to the lowest block incorporating the result. Results
+// sealed in the given block must be incorporated in this section of the fork.
+func (b *backendEmergencySeals) incorporatingBlocks(header *flow.Header) (map[flow.Identifier]*flow.Header, error) {
+	_, lastSeal, err := b.state.AtBlockID(header.ParentID).SealedResult()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get latest seal in fork of block %v: %v", header.ID(), err)
+	}
+
+	incorporatingBlocks := make(map[flow.Identifier]*flow.Header)
+	err = fork.TraverseForward(b.headers, header.ParentID, func(ancestor *flow.Header) error {
+		ancestorBlock, err := b.blocks.ByID(ancestor.ID())
+		if err != nil {
+			return fmt.Errorf("could not get block %v: %w", ancestor.ID(), err)
+		}
+		for _, result := range ancestorBlock.Payload.Results {
+			resultID := result.ID()
+			if _, ok := incorporatingBlocks[resultID]; !ok {
+				incorporatingBlocks[resultID] = ancestor
+			}
+		}
+		return nil
+	}, fork.ExcludingBlock(lastSeal.BlockID))
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+	return incorporatingBlocks, nil
+}
+
+// emergencySealRecord returns the audit record for the given seal, or nil if every chunk of the
+// sealed result has been approved by at least one verifier.
+func (b *backendEmergencySeals) emergencySealRecord(seal *flow.Seal, incorporatingBlocks map[flow.Identifier]*flow.Header) (*flow.EmergencySeal, error) {
+	result, err := b.executionResults.ByID(seal.ResultID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	var unapproved []*flow.Chunk
+	for _, chunk := range result.Chunks {
+		if chunk.Index >= uint64(len(seal.AggregatedApprovalSigs)) ||
+			len(seal.AggregatedApprovalSigs[chunk.Index].SignerIDs) < emergencySealRequiredApprovals {
+			unapproved = append(unapproved, chunk)
+		}
+	}
+	if len(unapproved) == 0 {
+		return nil, nil
+	}
+
+	incorporatingBlock, ok := incorporatingBlocks[seal.ResultID]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "result %v sealed by seal %v is not incorporated in fork", seal.ResultID, seal.ID())
+	}
+	assignment, err := b.assigner.Assign(result, incorporatingBlock.ID())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not compute verifier assignment for result %v: %v", seal.ResultID, err)
+	}
+	executedBlock, err := b.headers.ByBlockID(result.BlockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	chunks := make([]flow.EmergencySealChunk, 0, len(unapproved))
+	for _, chunk := range unapproved {
+		approvedBy := flow.IdentifierList{}
+		if chunk.Index < uint64(len(seal.AggregatedApprovalSigs)) {
+			approvedBy = flow.IdentifierList(seal.AggregatedApprovalSigs[chunk.Index].SignerIDs).Sort(flow.IdentifierCanonical)
+		}
+		chunks = append(chunks, flow.EmergencySealChunk{
+			Index:             chunk.Index,
+			AssignedVerifiers: assignment.Verifiers(chunk).Sort(flow.IdentifierCanonical),
+			ApprovedBy:        approvedBy,
+		})
+	}
+
+	return &flow.EmergencySeal{
+		ResultID:            seal.ResultID,
+		BlockID:             seal.BlockID,
+		BlockHeight:         executedBlock.Height,
+		IncorporatedBlockID: incorporatingBlock.ID(),
+		RequiredApprovals:   emergencySealRequiredApprovals,
+		UnapprovedChunks:    chunks,
+	}, nil
+}
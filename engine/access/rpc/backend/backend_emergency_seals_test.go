@@ -0,0 +1,127 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetEmergencySealsByBlockID verifies that emergency seal records are reconstructed from the
+// seals in a block payload, for the fork
+//
+//	L <- X <- I{ R[X] } <- P <- B{ Seal[R] }
+//
+// where L is the latest sealed block and the result R for block X is incorporated in block I.
+func TestGetEmergencySealsByBlockID(t *testing.T) {
+	latestSealed := unittest.BlockHeaderFixture()
+	executed := unittest.BlockWithParentFixture(latestSealed)
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(executed.ID()), unittest.WithChunks(2))
+	incorporating := unittest.BlockWithParentFixture(executed.Header)
+	incorporating.SetPayload(unittest.PayloadFixture(unittest.WithExecutionResults(result)))
+	parent := unittest.BlockWithParentFixture(incorporating.Header)
+
+	// chunk 0 is approved, chunk 1 has no approvals
+	approver := unittest.IdentifierFixture()
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+	seal.AggregatedApprovalSigs[0] = flow.AggregatedSignature{
+		VerifierSignatures: unittest.SignaturesFixture(1),
+		SignerIDs:          flow.IdentifierList{approver},
+	}
+	seal.AggregatedApprovalSigs[1] = flow.AggregatedSignature{}
+	block := unittest.BlockWithParentFixture(parent.Header)
+	block.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal)))
+
+	headers := storagemock.NewHeaders(t)
+	blocks := storagemock.NewBlocks(t)
+	for _, header := range []*flow.Header{latestSealed, executed.Header, incorporating.Header, parent.Header} {
+		headers.On("ByBlockID", header.ID()).Return(header, nil).Maybe()
+	}
+	for _, b := range []*flow.Block{executed, incorporating, parent} {
+		blocks.On("ByID", b.ID()).Return(b, nil).Maybe()
+	}
+	// the records of the finalized block are cached, hence the block is only loaded once
+	blocks.On("ByID", block.ID()).Return(block, nil).Once()
+	headers.On("BlockIDByHeight", block.Header.Height).Return(block.ID(), nil).Maybe()
+	// the parent is not finalized, hence its records are not cached
+	headers.On("BlockIDByHeight", parent.Header.Height).Return(unittest.IdentifierFixture(), nil).Maybe()
+	unknownID := unittest.IdentifierFixture()
+	blocks.On("ByID", unknownID).Return(nil, storage.ErrNotFound).Maybe()
+
+	results := storagemock.NewExecutionResults(t)
+	results.On("ByID", result.ID()).Return(result, nil).Maybe()
+
+	snapshot := protocol.NewSnapshot(t)
+	snapshot.On("SealedResult").Return(nil, unittest.Seal.Fixture(unittest.Seal.WithBlock(latestSealed)), nil).Maybe()
+	state := protocol.NewState(t)
+	state.On("AtBlockID", parent.ID()).Return(snapshot).Maybe()
+
+	assignedVerifiers := unittest.IdentifierListFixture(3).Sort(flow.IdentifierCanonical)
+	assignment := chunks.NewAssignment()
+	for _, chunk := range result.Chunks {
+		assignment.Add(chunk, assignedVerifiers)
+	}
+	assigner := modulemock.NewChunkAssigner(t)
+	assigner.On("Assign", result, incorporating.ID()).Return(assignment, nil).Maybe()
+
+	cache, err := lru.New[flow.Identifier, []*flow.EmergencySeal](DefaultEmergencySealsCacheSize)
+	require.NoError(t, err)
+
+	backend := backendEmergencySeals{
+		state:            state,
+		headers:          headers,
+		blocks:           blocks,
+		executionResults: results,
+		assigner:         assigner,
+		cache:            cache,
+	}
+
+	t.Run("block with emergency seal", func(t *testing.T) {
+		expected := []*flow.EmergencySeal{{
+			ResultID:            result.ID(),
+			BlockID:             executed.ID(),
+			BlockHeight:         executed.Header.Height,
+			IncorporatedBlockID: incorporating.ID(),
+			RequiredApprovals:   1,
+			UnapprovedChunks: []flow.EmergencySealChunk{{
+				Index:             1,
+				AssignedVerifiers: assignedVerifiers,
+				ApprovedBy:        flow.IdentifierList{},
+			}},
+		}}
+
+		records, err := backend.GetEmergencySealsByBlockID(context.Background(), block.ID())
+		require.NoError(t, err)
+		require.Equal(t, expected, records)
+
+		// the block is finalized, hence the records are served from the cache
+		records, err = backend.GetEmergencySealsByBlockID(context.Background(), block.ID())
+		require.NoError(t, err)
+		require.Equal(t, expected, records)
+	})
+
+	t.Run("block without seals", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			records, err := backend.GetEmergencySealsByBlockID(context.Background(), parent.ID())
+			require.NoError(t, err)
+			require.Empty(t, records)
+		}
+		require.False(t, cache.Contains(parent.ID()))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := backend.GetEmergencySealsByBlockID(context.Background(), unknownID)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rpc/connection"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// backendRegisterProofs proxies requests for register proofs to the execution nodes. Before returning a proof,
+// it verifies that the proof is valid against the state commitment of the sealed result of the block, so that
+// faulty execution nodes can not serve invalid proofs to clients.
+type backendRegisterProofs struct {
+	log                        zerolog.Logger
+	state                      protocol.State
+	headers                    storage.Headers
+	executionResults           storage.ExecutionResults
+	connFactory                connection.ConnectionFactory
+	nodeCommunicator           Communicator
+	execNodeIdentitiesProvider *rpc.ExecutionNodeIdentitiesProvider
+}
+
+// GetRegisterProofs returns a batch proof of the given registers against the state commitment of the given
+// sealed block.
+//
+// Expected error codes during normal operation:
+//   - codes.InvalidArgument if no registers are requested.
+//   - codes.NotFound if the block or its sealed result is not known.
+//   - codes.FailedPrecondition if the block is not finalized or not sealed.
+//   - codes.Unavailable if no execution node returned a valid proof.
+func (b *backendRegisterProofs) GetRegisterProofs(
+	ctx context.Context,
+	blockID flow.Identifier,
+	registerIDs flow.RegisterIDs,
+) (*access.RegisterProofs, error) {
+	if len(registerIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no registers requested")
+	}
+
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get latest sealed block: %v", err)
+	}
+	if header.Height > sealed.Height {
+		return nil, status.Errorf(codes.FailedPrecondition, "block %v at height %d is not sealed, latest sealed height is %d", blockID, header.Height, sealed.Height)
+	}
+	finalizedID, err := b.headers.BlockIDByHeight(header.Height)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get finalized block at height %d: %v", header.Height, err)
+	}
+	if finalizedID != blockID {
+		return nil, status.Errorf(codes.FailedPrecondition, "block %v is not finalized", blockID)
+	}
+
+	// the access node indexes the sealed result of finalized blocks
+	result, err := b.executionResults.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+	commitment, err := result.FinalStateCommitment()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get final state commitment of result %v: %v", result.ID(), err)
+	}
+
+	execNodes, err := b.execNodeIdentitiesProvider.ExecutionNodesForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, rpc.ConvertError(err, "failed to find execution nodes for block", codes.Internal)
+	}
+
+	req := &registerproofs.GetRegisterProofsRequest{
+		BlockId:     convert.IdentifierToMessage(blockID),
+		RegisterIds: make([]*entities.RegisterID, len(registerIDs)),
+	}
+	for i, registerID := range registerIDs {
+		req.RegisterIds[i] = convert.RegisterIDToMessage(registerID)
+	}
+
+	var resp *registerproofs.GetRegisterProofsResponse
+	err = b.nodeCommunicator.CallAvailableNode(
+		execNodes,
+		func(node *flow.IdentitySkeleton) error {
+			var err error
+			start := time.Now()
+			resp, err = b.tryGetRegisterProofs(ctx, node, req, commitment, registerIDs)
+			logger := b.log.With().
+				Str("execution_node", node.String()).
+				Hex("block_id", blockID[:]).
+				Int("registers", len(registerIDs)).
+				Int64("rtt_ms", time.Since(start).Milliseconds()).
+				Logger()
+			if err != nil {
+				logger.Err(err).Msg("failed to get register proofs")
+				return err
+			}
+			logger.Debug().Msg("successfully got register proofs")
+			return nil
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, rpc.ConvertError(err, "failed to retrieve register proofs from execution nodes", codes.Unavailable)
+	}
+
+	return &access.RegisterProofs{
+		BlockID:         blockID,
+		BlockHeight:     header.Height,
+		StateCommitment: commitment,
+		Proof:           resp.GetProof(),
+	}, nil
+}
+
+// tryGetRegisterProofs requests the register proofs from the given execution node, and verifies the response
+// against the sealed state commitment.
+func (b *backendRegisterProofs) tryGetRegisterProofs(
+	ctx context.Context,
+	execNode *flow.IdentitySkeleton,
+	req *registerproofs.GetRegisterProofsRequest,
+	commitment flow.StateCommitment,
+	registerIDs flow.RegisterIDs,
+) (*registerproofs.GetRegisterProofsResponse, error) {
+	client, closer, err := b.connFactory.GetRegisterProofAPIClient(execNode.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	resp, err := client.GetRegisterProofs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = registerproofs.VerifyResponse(resp, flow.HashToID(req.GetBlockId()), commitment, registerIDs)
+	if err != nil {
+		if errors.Is(err, registerproofs.ErrInvalidProof) {
+			return nil, status.Errorf(codes.Internal, "execution node %v returned an invalid proof: %v", execNode.NodeID, err)
+		}
+		return nil, fmt.Errorf("could not verify register proofs: %w", err)
+	}
+	return resp, nil
+}
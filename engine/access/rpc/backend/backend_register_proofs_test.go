@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
+	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+// registerProofClientFunc implements registerproofs.RegisterProofAPIClient with a function.
+type registerProofClientFunc func(req *registerproofs.GetRegisterProofsRequest) (*registerproofs.GetRegisterProofsResponse, error)
+
+func (f registerProofClientFunc) GetRegisterProofs(
+	_ context.Context,
+	req *registerproofs.GetRegisterProofsRequest,
+	_ ...grpc.CallOption,
+) (*registerproofs.GetRegisterProofsResponse, error) {
+	return f(req)
+}
+
+// TestGetRegisterProofs verifies that register proofs are obtained from the execution nodes, and that proofs
+// which are invalid against the sealed state commitment are rejected.
+func TestGetRegisterProofs(t *testing.T) {
+	ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 10, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+	compactor := fixtures.NewNoopCompactor(ldg)
+	<-compactor.Ready()
+	defer func() {
+		<-ldg.Done()
+		<-compactor.Done()
+	}()
+
+	registerID := flow.NewRegisterID(unittest.RandomAddressFixture(), "balance")
+	update, err := ledger.NewUpdate(ldg.InitialState(), []ledger.Key{convert.RegisterIDToLedgerKey(registerID)}, []ledger.Value{{42}})
+	require.NoError(t, err)
+	newState, _, err := ldg.Set(update)
+	require.NoError(t, err)
+	commit := flow.StateCommitment(newState)
+
+	// proves the register against the given state
+	prove := func(state ledger.State) []byte {
+		query, err := ledger.NewQuery(state, []ledger.Key{convert.RegisterIDToLedgerKey(registerID)})
+		require.NoError(t, err)
+		proof, err := ldg.Prove(query)
+		require.NoError(t, err)
+		return proof
+	}
+
+	sealed := unittest.BlockHeaderFixture()
+	unsealed := unittest.BlockHeaderWithParentFixture(sealed)
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(sealed.ID()), unittest.WithFinalState(commit))
+
+	headers := storagemock.NewHeaders(t)
+	headers.On("ByBlockID", sealed.ID()).Return(sealed, nil).Maybe()
+	headers.On("ByBlockID", unsealed.ID()).Return(unsealed, nil).Maybe()
+	headers.On("BlockIDByHeight", sealed.Height).Return(sealed.ID(), nil).Maybe()
+	// a block of an abandoned fork, at the height of the sealed block
+	orphaned := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(sealed.Height))
+	headers.On("ByBlockID", orphaned.ID()).Return(orphaned, nil).Maybe()
+	results := storagemock.NewExecutionResults(t)
+	results.On("ByBlockID", sealed.ID()).Return(result, nil).Maybe()
+
+	executionNodes := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	snapshot := protocol.NewSnapshot(t)
+	snapshot.On("Head").Return(sealed, nil).Maybe()
+	snapshot.On("Identities", mock.Anything).Return(executionNodes, nil).Maybe()
+	params := protocol.NewParams(t)
+	params.On("FinalizedRoot").Return(sealed).Maybe()
+	state := protocol.NewState(t)
+	state.On("Sealed").Return(snapshot).Maybe()
+	state.On("Final").Return(snapshot).Maybe()
+	state.On("Params").Return(params).Maybe()
+
+	newBackend := func(connFactory *connectionmock.ConnectionFactory) *backendRegisterProofs {
+		return &backendRegisterProofs{
+			log:              unittest.Logger(),
+			state:            state,
+			headers:          headers,
+			executionResults: results,
+			connFactory:      connFactory,
+			nodeCommunicator: NewNodeCommunicator(false),
+			execNodeIdentitiesProvider: commonrpc.NewExecutionNodeIdentitiesProvider(
+				unittest.Logger(),
+				state,
+				storagemock.NewExecutionReceipts(t),
+				nil,
+				nil,
+			),
+		}
+	}
+
+	t.Run("valid proof", func(t *testing.T) {
+		connFactory := connectionmock.NewConnectionFactory(t)
+		connFactory.On("GetRegisterProofAPIClient", mock.Anything).Return(
+			registerProofClientFunc(func(req *registerproofs.GetRegisterProofsRequest) (*registerproofs.GetRegisterProofsResponse, error) {
+				return &registerproofs.GetRegisterProofsResponse{
+					BlockId:         req.GetBlockId(),
+					BlockHeight:     sealed.Height,
+					StateCommitment: commit[:],
+					Proof:           prove(newState),
+				}, nil
+			}), &mocks.MockCloser{}, nil).Once()
+
+		proofs, err := newBackend(connFactory).GetRegisterProofs(context.Background(), sealed.ID(), flow.RegisterIDs{registerID})
+		require.NoError(t, err)
+		require.Equal(t, sealed.ID(), proofs.BlockID)
+		require.Equal(t, commit, proofs.StateCommitment)
+
+		values, err := registerproofs.VerifyRegisterProofs(proofs.Proof, proofs.StateCommitment, flow.RegisterIDs{registerID})
+		require.NoError(t, err)
+		require.Equal(t, []flow.RegisterValue{{42}}, values)
+	})
+
+	t.Run("invalid proofs are rejected", func(t *testing.T) {
+		// the execution nodes serve a proof against a state, which differs from the sealed state
+		connFactory := connectionmock.NewConnectionFactory(t)
+		connFactory.On("GetRegisterProofAPIClient", mock.Anything).Return(
+			registerProofClientFunc(func(req *registerproofs.GetRegisterProofsRequest) (*registerproofs.GetRegisterProofsResponse, error) {
+				return &registerproofs.GetRegisterProofsResponse{
+					BlockId:         req.GetBlockId(),
+					BlockHeight:     sealed.Height,
+					StateCommitment: commit[:],
+					Proof:           prove(ldg.InitialState()),
+				}, nil
+			}), &mocks.MockCloser{}, nil).Times(len(executionNodes))
+
+		_, err := newBackend(connFactory).GetRegisterProofs(context.Background(), sealed.ID(), flow.RegisterIDs{registerID})
+		require.Error(t, err)
+	})
+
+	t.Run("unsealed block", func(t *testing.T) {
+		_, err := newBackend(connectionmock.NewConnectionFactory(t)).GetRegisterProofs(context.Background(), unsealed.ID(), flow.RegisterIDs{registerID})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("unfinalized block", func(t *testing.T) {
+		_, err := newBackend(connectionmock.NewConnectionFactory(t)).GetRegisterProofs(context.Background(), orphaned.ID(), flow.RegisterIDs{registerID})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
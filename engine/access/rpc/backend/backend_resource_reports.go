@@ -0,0 +1,335 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rpc/connection"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// backendResourceReports serves the resource reports of transactions executed in sealed blocks.
+//
+// Reports are not part of the execution result, so they can't be verified against the sealed result. They are
+// requested from the execution nodes which produced a receipt for the block. If local indexing is enabled, the
+// reports of a block are stored the first time they are requested, and served from the local index afterwards.
+// Only reports which match the transactions of the block are indexed. The node-local fields of the reports (see
+// flow.TransactionResourceReport) are those of the execution node the reports were requested from.
+type backendResourceReports struct {
+	log         zerolog.Logger
+	state       protocol.State
+	headers     storage.Headers
+	blocks      storage.Blocks
+	collections storage.Collections
+	systemTxID  flow.Identifier
+	// reports is the local index of transaction resource reports, nil if local indexing is disabled.
+	reports                    storage.TransactionResourceReports
+	connFactory                connection.ConnectionFactory
+	nodeCommunicator           Communicator
+	execNodeIdentitiesProvider *rpc.ExecutionNodeIdentitiesProvider
+}
+
+// GetTransactionResourceReport returns the resource report of the given transaction executed in the given
+// sealed block.
+//
+// Expected error codes during normal operation:
+//   - codes.NotFound if the block is not known, or no report is known for the transaction in the block.
+//   - codes.FailedPrecondition if the block is not sealed.
+//   - codes.Unavailable if no execution node returned the report.
+func (b *backendResourceReports) GetTransactionResourceReport(
+	ctx context.Context,
+	blockID flow.Identifier,
+	transactionID flow.Identifier,
+) (*flow.TransactionResourceReport, error) {
+	err := b.checkBlockSealed(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.reports == nil {
+		return b.getReportFromExecutionNodes(ctx, blockID, transactionID)
+	}
+
+	report, err := b.reports.ByBlockIDTransactionID(blockID, transactionID)
+	if err == nil {
+		return report, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction resource report from local index: %v", err)
+	}
+
+	// the reports of a block are indexed together, so if the block was indexed the transaction is not in the block
+	_, err = b.reports.ByBlockID(blockID)
+	if err == nil {
+		return nil, status.Errorf(codes.NotFound, "transaction %v not found in block %v", transactionID, blockID)
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction resource reports from local index: %v", err)
+	}
+
+	reports, err := b.indexReportsFromExecutionNodes(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reports {
+		if reports[i].TransactionID == transactionID {
+			return &reports[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "transaction %v not found in block %v", transactionID, blockID)
+}
+
+// GetTransactionResourceReportsByBlockID returns the resource reports of all transactions executed in the given
+// sealed block, ordered by transaction index.
+//
+// Expected error codes during normal operation:
+//   - codes.NotFound if the block is not known, or no reports are known for the block.
+//   - codes.FailedPrecondition if the block is not sealed.
+//   - codes.Unavailable if no execution node returned the reports.
+func (b *backendResourceReports) GetTransactionResourceReportsByBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+) ([]flow.TransactionResourceReport, error) {
+	err := b.checkBlockSealed(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.reports == nil {
+		return b.getReportsFromExecutionNodes(ctx, blockID, nil)
+	}
+
+	reports, err := b.reports.ByBlockID(blockID)
+	if err == nil {
+		return reports, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction resource reports from local index: %v", err)
+	}
+
+	return b.indexReportsFromExecutionNodes(ctx, blockID)
+}
+
+// checkBlockSealed returns an error if the given block is not known or not sealed.
+//
+// Expected error codes during normal operation:
+//   - codes.NotFound if the block is not known.
+//   - codes.FailedPrecondition if the block is not sealed.
+func (b *backendResourceReports) checkBlockSealed(blockID flow.Identifier) error {
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return rpc.ConvertStorageError(err)
+	}
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not get latest sealed block: %v", err)
+	}
+	if header.Height > sealed.Height {
+		return status.Errorf(codes.FailedPrecondition, "block %v at height %d is not sealed, latest sealed height is %d", blockID, header.Height, sealed.Height)
+	}
+	return nil
+}
+
+// indexReportsFromExecutionNodes requests the reports of the given block from the execution nodes, and stores
+// them in the local index. Reports whose transactions don't match the transactions of the block are rejected. If the
+// collections of the block are not known yet, the reports can't be checked, and are returned without indexing them.
+// If the reports were indexed concurrently, the indexed reports are kept and returned, so that the served reports
+// don't change. Their node-local fields may differ from the requested reports, the other fields must not.
+func (b *backendResourceReports) indexReportsFromExecutionNodes(
+	ctx context.Context,
+	blockID flow.Identifier,
+) ([]flow.TransactionResourceReport, error) {
+	txIDs, err := b.blockTransactionIDs(blockID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.Internal, "failed to get transactions of block %v: %v", blockID, err)
+		}
+		return b.getReportsFromExecutionNodes(ctx, blockID, nil)
+	}
+
+	reports, err := b.getReportsFromExecutionNodes(ctx, blockID, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed, err := b.reports.ByBlockID(blockID)
+	if err == nil {
+		if !consistentReports(indexed, reports) {
+			b.log.Warn().
+				Hex("block_id", blockID[:]).
+				Msg("transaction resource reports of execution nodes are inconsistent, keeping the indexed reports")
+		}
+		return indexed, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction resource reports from local index: %v", err)
+	}
+
+	err = b.reports.Store(blockID, reports)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to index transaction resource reports: %v", err)
+	}
+	return reports, nil
+}
+
+// getReportFromExecutionNodes requests the report of the given transaction from the execution nodes.
+func (b *backendResourceReports) getReportFromExecutionNodes(
+	ctx context.Context,
+	blockID flow.Identifier,
+	transactionID flow.Identifier,
+) (*flow.TransactionResourceReport, error) {
+	req := &resourcereports.GetTransactionResourceReportRequest{
+		BlockId:       convert.IdentifierToMessage(blockID),
+		TransactionId: convert.IdentifierToMessage(transactionID),
+	}
+
+	var resp *resourcereports.GetTransactionResourceReportResponse
+	err := b.callExecutionNodes(ctx, blockID, func(client resourcereports.TransactionResourceReportAPIClient) error {
+		var err error
+		resp, err = client.GetTransactionResourceReport(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, rpc.ConvertError(err, "failed to retrieve transaction resource report from execution nodes", codes.Unavailable)
+	}
+
+	return resourcereports.MessageToReport(resp.GetReport()), nil
+}
+
+// getReportsFromExecutionNodes requests the reports of all transactions of the given block from the execution nodes.
+// If txIDs is not nil, the reports returned by an execution node are rejected unless they are for exactly the given
+// transactions, in order, and the next execution node is requested.
+func (b *backendResourceReports) getReportsFromExecutionNodes(
+	ctx context.Context,
+	blockID flow.Identifier,
+	txIDs flow.IdentifierList,
+) ([]flow.TransactionResourceReport, error) {
+	req := &resourcereports.GetTransactionResourceReportsByBlockIDRequest{
+		BlockId: convert.IdentifierToMessage(blockID),
+	}
+
+	var reports []flow.TransactionResourceReport
+	err := b.callExecutionNodes(ctx, blockID, func(client resourcereports.TransactionResourceReportAPIClient) error {
+		resp, err := client.GetTransactionResourceReportsByBlockID(ctx, req)
+		if err != nil {
+			return err
+		}
+		reports = resourcereports.MessagesToReports(resp.GetReports())
+		if txIDs != nil {
+			return checkReportTransactions(reports, txIDs)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, rpc.ConvertError(err, "failed to retrieve transaction resource reports from execution nodes", codes.Unavailable)
+	}
+
+	return reports, nil
+}
+
+// blockTransactionIDs returns the IDs of the transactions of the given block in execution order, which are the
+// transactions of the collections of the block, followed by the system transaction.
+//
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if the block or any of its collections is not known.
+func (b *backendResourceReports) blockTransactionIDs(blockID flow.Identifier) (flow.IdentifierList, error) {
+	block, err := b.blocks.ByID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block: %w", err)
+	}
+
+	txIDs := make(flow.IdentifierList, 0)
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := b.collections.LightByID(guarantee.ID())
+		if err != nil {
+			return nil, fmt.Errorf("could not get collection %v: %w", guarantee.ID(), err)
+		}
+		txIDs = append(txIDs, collection.Transactions...)
+	}
+	return append(txIDs, b.systemTxID), nil
+}
+
+// checkReportTransactions returns an error unless the given reports are for exactly the given transactions, in order.
+func checkReportTransactions(reports []flow.TransactionResourceReport, txIDs flow.IdentifierList) error {
+	if len(reports) != len(txIDs) {
+		return fmt.Errorf("got %d transaction resource reports for %d transactions", len(reports), len(txIDs))
+	}
+	for i := range reports {
+		if reports[i].TransactionID != txIDs[i] {
+			return fmt.Errorf("transaction resource report %d is for transaction %v instead of %v", i, reports[i].TransactionID, txIDs[i])
+		}
+	}
+	return nil
+}
+
+// consistentReports returns true if both lists contain consistent reports in the same order, ignoring the
+// node-local fields of the reports.
+func consistentReports(a, b []flow.TransactionResourceReport) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].ConsistentWith(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// callExecutionNodes calls the given function with a client of each execution node which executed the given
+// block, until one call succeeds.
+func (b *backendResourceReports) callExecutionNodes(
+	ctx context.Context,
+	blockID flow.Identifier,
+	call func(client resourcereports.TransactionResourceReportAPIClient) error,
+) error {
+	execNodes, err := b.execNodeIdentitiesProvider.ExecutionNodesForBlockID(ctx, blockID)
+	if err != nil {
+		return rpc.ConvertError(err, "failed to find execution nodes for block", codes.Internal)
+	}
+
+	return b.nodeCommunicator.CallAvailableNode(
+		execNodes,
+		func(node *flow.IdentitySkeleton) error {
+			start := time.Now()
+			err := b.tryCallExecutionNode(node, call)
+			logger := b.log.With().
+				Str("execution_node", node.String()).
+				Hex("block_id", blockID[:]).
+				Int64("rtt_ms", time.Since(start).Milliseconds()).
+				Logger()
+			if err != nil {
+				logger.Err(err).Msg("failed to get transaction resource reports")
+				return err
+			}
+			logger.Debug().Msg("successfully got transaction resource reports")
+			return nil
+		},
+		nil,
+	)
+}
+
+// tryCallExecutionNode calls the given function with a client of the given execution node.
+func (b *backendResourceReports) tryCallExecutionNode(
+	execNode *flow.IdentitySkeleton,
+	call func(client resourcereports.TransactionResourceReportAPIClient) error,
+) error {
+	client, closer, err := b.connFactory.GetTransactionResourceReportAPIClient(execNode.Address)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	return call(client)
+}
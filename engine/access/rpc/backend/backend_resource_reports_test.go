@@ -0,0 +1,215 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
+	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+// resourceReportClient implements resourcereports.TransactionResourceReportAPIClient, serving the given reports.
+type resourceReportClient struct {
+	blockID flow.Identifier
+	reports []flow.TransactionResourceReport
+}
+
+func (c *resourceReportClient) GetTransactionResourceReport(
+	_ context.Context,
+	req *resourcereports.GetTransactionResourceReportRequest,
+	_ ...grpc.CallOption,
+) (*resourcereports.GetTransactionResourceReportResponse, error) {
+	if flow.HashToID(req.GetBlockId()) == c.blockID {
+		for i := range c.reports {
+			if c.reports[i].TransactionID == flow.HashToID(req.GetTransactionId()) {
+				return &resourcereports.GetTransactionResourceReportResponse{
+					BlockId: req.GetBlockId(),
+					Report:  resourcereports.ReportToMessage(&c.reports[i]),
+				}, nil
+			}
+		}
+	}
+	return nil, status.Error(codes.NotFound, "transaction resource report not found")
+}
+
+func (c *resourceReportClient) GetTransactionResourceReportsByBlockID(
+	_ context.Context,
+	req *resourcereports.GetTransactionResourceReportsByBlockIDRequest,
+	_ ...grpc.CallOption,
+) (*resourcereports.GetTransactionResourceReportsByBlockIDResponse, error) {
+	if flow.HashToID(req.GetBlockId()) != c.blockID {
+		return nil, status.Error(codes.NotFound, "transaction resource reports not found")
+	}
+	return &resourcereports.GetTransactionResourceReportsByBlockIDResponse{
+		BlockId: req.GetBlockId(),
+		Reports: resourcereports.ReportsToMessages(c.reports),
+	}, nil
+}
+
+// TestGetTransactionResourceReports verifies that the resource reports of sealed blocks are obtained from the
+// execution nodes, and served from the local index once indexed.
+func TestGetTransactionResourceReports(t *testing.T) {
+	sealed := unittest.BlockHeaderFixture()
+	unsealed := unittest.BlockHeaderWithParentFixture(sealed)
+	reports := unittest.TransactionResourceReportsFixture(3)
+
+	headers := storagemock.NewHeaders(t)
+	headers.On("ByBlockID", sealed.ID()).Return(sealed, nil).Maybe()
+	headers.On("ByBlockID", unsealed.ID()).Return(unsealed, nil).Maybe()
+
+	// the sealed block contains a single collection with all but the last report's transaction, which is the
+	// system transaction
+	guarantee := unittest.CollectionGuaranteeFixture()
+	block := &flow.Block{
+		Header:  sealed,
+		Payload: &flow.Payload{Guarantees: []*flow.CollectionGuarantee{guarantee}},
+	}
+	blocks := storagemock.NewBlocks(t)
+	blocks.On("ByID", sealed.ID()).Return(block, nil).Maybe()
+	collections := storagemock.NewCollections(t)
+	collections.On("LightByID", guarantee.ID()).Return(&flow.LightCollection{
+		Transactions: []flow.Identifier{reports[0].TransactionID, reports[1].TransactionID},
+	}, nil).Maybe()
+
+	executionNodes := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	snapshot := protocol.NewSnapshot(t)
+	snapshot.On("Head").Return(sealed, nil).Maybe()
+	snapshot.On("Identities", mock.Anything).Return(executionNodes, nil).Maybe()
+	params := protocol.NewParams(t)
+	params.On("FinalizedRoot").Return(sealed).Maybe()
+	state := protocol.NewState(t)
+	state.On("Sealed").Return(snapshot).Maybe()
+	state.On("Final").Return(snapshot).Maybe()
+	state.On("Params").Return(params).Maybe()
+
+	newBackend := func(connFactory *connectionmock.ConnectionFactory, index storage.TransactionResourceReports) *backendResourceReports {
+		return &backendResourceReports{
+			log:              unittest.Logger(),
+			state:            state,
+			headers:          headers,
+			blocks:           blocks,
+			collections:      collections,
+			systemTxID:       reports[2].TransactionID,
+			reports:          index,
+			connFactory:      connFactory,
+			nodeCommunicator: NewNodeCommunicator(false),
+			execNodeIdentitiesProvider: commonrpc.NewExecutionNodeIdentitiesProvider(
+				unittest.Logger(),
+				state,
+				storagemock.NewExecutionReceipts(t),
+				nil,
+				nil,
+			),
+		}
+	}
+	client := &resourceReportClient{blockID: sealed.ID(), reports: reports}
+
+	t.Run("without local index", func(t *testing.T) {
+		connFactory := connectionmock.NewConnectionFactory(t)
+		connFactory.On("GetTransactionResourceReportAPIClient", mock.Anything).Return(client, &mocks.MockCloser{}, nil)
+		backend := newBackend(connFactory, nil)
+
+		report, err := backend.GetTransactionResourceReport(context.Background(), sealed.ID(), reports[1].TransactionID)
+		require.NoError(t, err)
+		require.Equal(t, &reports[1], report)
+
+		blockReports, err := backend.GetTransactionResourceReportsByBlockID(context.Background(), sealed.ID())
+		require.NoError(t, err)
+		require.Equal(t, reports, blockReports)
+
+		_, err = backend.GetTransactionResourceReport(context.Background(), sealed.ID(), unittest.IdentifierFixture())
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("with local index", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			index := bstorage.NewTransactionResourceReports(db)
+
+			// the reports of the block are requested once, and served from the index afterwards
+			connFactory := connectionmock.NewConnectionFactory(t)
+			connFactory.On("GetTransactionResourceReportAPIClient", mock.Anything).Return(client, &mocks.MockCloser{}, nil).Once()
+			backend := newBackend(connFactory, index)
+
+			report, err := backend.GetTransactionResourceReport(context.Background(), sealed.ID(), reports[1].TransactionID)
+			require.NoError(t, err)
+			require.Equal(t, &reports[1], report)
+
+			indexed, err := index.ByBlockID(sealed.ID())
+			require.NoError(t, err)
+			require.Equal(t, reports, indexed)
+
+			blockReports, err := backend.GetTransactionResourceReportsByBlockID(context.Background(), sealed.ID())
+			require.NoError(t, err)
+			require.Equal(t, reports, blockReports)
+
+			_, err = backend.GetTransactionResourceReport(context.Background(), sealed.ID(), unittest.IdentifierFixture())
+			require.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("reports not matching the block", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			index := bstorage.NewTransactionResourceReports(db)
+
+			// the reports of the other transactions of the block are missing
+			mismatching := &resourceReportClient{blockID: sealed.ID(), reports: reports[1:]}
+			connFactory := connectionmock.NewConnectionFactory(t)
+			connFactory.On("GetTransactionResourceReportAPIClient", mock.Anything).Return(mismatching, &mocks.MockCloser{}, nil)
+			backend := newBackend(connFactory, index)
+
+			_, err := backend.GetTransactionResourceReportsByBlockID(context.Background(), sealed.ID())
+			require.Error(t, err)
+
+			_, err = index.ByBlockID(sealed.ID())
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+	})
+
+	t.Run("reports indexed concurrently", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			index := bstorage.NewTransactionResourceReports(db)
+
+			// the reports indexed from another execution node only differ in their node-local fields
+			otherNodeReports := make([]flow.TransactionResourceReport, len(reports))
+			copy(otherNodeReports, reports)
+			otherNodeReports[0].BytesRead++
+			otherNodeReports[0].RegistersTouched++
+			otherNodeReports[0].MemoryEstimate++
+			otherNodeReports[0].MemoryIntensities = nil
+			require.NoError(t, index.Store(sealed.ID(), otherNodeReports))
+
+			connFactory := connectionmock.NewConnectionFactory(t)
+			connFactory.On("GetTransactionResourceReportAPIClient", mock.Anything).Return(client, &mocks.MockCloser{}, nil)
+			backend := newBackend(connFactory, index)
+
+			// the indexed reports are kept
+			blockReports, err := backend.indexReportsFromExecutionNodes(context.Background(), sealed.ID())
+			require.NoError(t, err)
+			require.Equal(t, otherNodeReports, blockReports)
+
+			indexed, err := index.ByBlockID(sealed.ID())
+			require.NoError(t, err)
+			require.Equal(t, otherNodeReports, indexed)
+		})
+	})
+
+	t.Run("unsealed block", func(t *testing.T) {
+		_, err := newBackend(connectionmock.NewConnectionFactory(t), nil).GetTransactionResourceReportsByBlockID(context.Background(), unsealed.ID())
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
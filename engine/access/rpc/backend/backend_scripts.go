@@ -38,6 +38,8 @@ type backendScripts struct {
 	scriptExecutor             execution.ScriptExecutor
 	scriptExecMode             IndexQueryMode
 	execNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+	// resultCache caches the results of scripts executed at sealed blocks, nil if disabled.
+	resultCache *ScriptResultCache
 }
 
 // scriptExecutionRequest encapsulates the data needed to execute a script to make it easier
@@ -111,11 +113,45 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 	return b.executeScript(ctx, newScriptExecutionRequest(header.ID(), blockHeight, script, arguments))
 }
 
-// executeScript executes the provided script using either the local execution state or the execution
-// nodes depending on the node's configuration and the availability of the data.
+// executeScript executes the provided script. If the script result cache is enabled, the results of scripts
+// executed at sealed blocks are served from the cache, and cached after successful executions.
 func (b *backendScripts) executeScript(
 	ctx context.Context,
 	scriptRequest *scriptExecutionRequest,
+) ([]byte, error) {
+	if b.resultCache == nil || !b.isSealedBlock(scriptRequest) {
+		return b.executeScriptWithMode(ctx, scriptRequest)
+	}
+
+	key := NewScriptResultCacheKey(scriptRequest.script, scriptRequest.arguments, scriptRequest.blockID)
+	if result, ok := b.resultCache.Get(key); ok {
+		return result, nil
+	}
+
+	result, err := b.executeScriptWithMode(ctx, scriptRequest)
+	if err != nil {
+		return nil, err
+	}
+	b.resultCache.Add(key, scriptRequest.height, result)
+	return result, nil
+}
+
+// isSealedBlock returns true if the block of the request is a sealed block, at which script results are immutable.
+func (b *backendScripts) isSealedBlock(r *scriptExecutionRequest) bool {
+	sealed, err := b.state.Sealed().Head()
+	if err != nil || r.height > sealed.Height {
+		return false
+	}
+	// unfinalized blocks may be known at sealed heights, so the block must also be the finalized block at its height
+	blockID, err := b.headers.BlockIDByHeight(r.height)
+	return err == nil && blockID == r.blockID
+}
+
+// executeScriptWithMode executes the provided script using either the local execution state or the execution
+// nodes depending on the node's configuration and the availability of the data.
+func (b *backendScripts) executeScriptWithMode(
+	ctx context.Context,
+	scriptRequest *scriptExecutionRequest,
 ) ([]byte, error) {
 	switch b.scriptExecMode {
 	case IndexQueryModeExecutionNodesOnly:
@@ -236,6 +236,89 @@ func (s *BackendScriptsSuite) TestExecuteScriptFromStorage_HappyPath() {
 	})
 }
 
+// TestExecuteScriptWithResultCache tests that the results of scripts executed at sealed blocks are cached,
+// both when executed locally and on execution nodes, and that results at unsealed blocks are not cached.
+func (s *BackendScriptsSuite) TestExecuteScriptWithResultCache() {
+	ctx := context.Background()
+	height := s.block.Header.Height
+
+	s.headers.On("ByHeight", height).Return(s.block.Header, nil)
+	s.headers.On("BlockIDByHeight", height).Return(s.block.ID(), nil)
+	s.state.On("Sealed").Return(s.snapshot)
+
+	newBackend := func() *backendScripts {
+		cache, err := NewScriptResultCache(10, 1024*1024, metrics.NewNoopCollector())
+		s.Require().NoError(err)
+
+		backend := s.defaultBackend()
+		backend.resultCache = cache
+		return backend
+	}
+
+	s.Run("local execution", func() {
+		s.snapshot.On("Head").Return(s.block.Header, nil).Twice()
+
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, height).
+			Return(expectedResponse, nil).Once()
+
+		backend := newBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		for i := 0; i < 2; i++ {
+			actual, err := backend.ExecuteScriptAtBlockHeight(ctx, height, s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+		s.Require().Equal(uint64(1), backend.resultCache.Stats().Hits)
+	})
+
+	s.Run("execution nodes", func() {
+		s.snapshot.On("Head").Return(s.block.Header, nil).Twice()
+		s.setupExecutionNodes(s.block)
+		blockID := s.block.ID()
+		expectedExecRequest := &execproto.ExecuteScriptAtBlockIDRequest{
+			BlockId:   blockID[:],
+			Script:    s.script,
+			Arguments: s.arguments,
+		}
+		s.execClient.On("ExecuteScriptAtBlockID", mock.Anything, expectedExecRequest).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: expectedResponse}, nil).Once()
+
+		backend := newBackend()
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		for i := 0; i < 2; i++ {
+			actual, err := backend.ExecuteScriptAtBlockHeight(ctx, height, s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+		s.Require().Equal(uint64(1), backend.resultCache.Stats().Hits)
+	})
+
+	s.Run("unsealed block", func() {
+		sealed := unittest.BlockHeaderFixture()
+		sealed.Height = height - 1
+		s.snapshot.On("Head").Return(sealed, nil).Twice()
+
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, height).
+			Return(expectedResponse, nil).Twice()
+
+		backend := newBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		for i := 0; i < 2; i++ {
+			actual, err := backend.ExecuteScriptAtBlockHeight(ctx, height, s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+		s.Require().Zero(backend.resultCache.Stats().Entries)
+	})
+}
+
 // TestExecuteScriptFromStorage_Fails tests that errors received from local storage are handled
 // and converted to the appropriate status code
 func (s *BackendScriptsSuite) TestExecuteScriptFromStorage_Fails() {
@@ -146,6 +146,7 @@ func (s *ScriptExecutorSuite) SetupTest() {
 		s.chain,
 		derivedChainData,
 		nil,
+		nil,
 	)
 	s.Require().NoError(err)
 
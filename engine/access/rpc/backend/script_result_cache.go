@@ -0,0 +1,186 @@
+package backend
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// scriptResultCacheEntryOverhead is the approximate size in bytes of a cache entry without its result,
+// which is accounted for in the memory limit of the cache.
+const scriptResultCacheEntryOverhead = 3*flow.IdentifierLen + 64
+
+// ScriptResultCacheKey identifies a script execution by the hashes of the script and its arguments, and the
+// block it is executed at.
+type ScriptResultCacheKey struct {
+	ScriptHash    flow.Identifier
+	ArgumentsHash flow.Identifier
+	BlockID       flow.Identifier
+}
+
+// NewScriptResultCacheKey returns the cache key of the execution of the script with the given arguments at the
+// given block.
+func NewScriptResultCacheKey(script []byte, arguments [][]byte, blockID flow.Identifier) ScriptResultCacheKey {
+	return ScriptResultCacheKey{
+		ScriptHash:    flow.MakeIDFromFingerPrint(script),
+		ArgumentsHash: flow.MakeID(arguments),
+		BlockID:       blockID,
+	}
+}
+
+// ScriptResultCacheEntry describes a cached script result.
+type ScriptResultCacheEntry struct {
+	ScriptResultCacheKey
+	Height  uint64
+	Size    int
+	Hits    uint64
+	AddedAt time.Time
+}
+
+type scriptResultCacheEntry struct {
+	ScriptResultCacheEntry
+	result []byte
+}
+
+// ScriptResultCacheStats describes the state of a ScriptResultCache.
+type ScriptResultCacheStats struct {
+	Entries    int
+	Bytes      uint64
+	MaxEntries int
+	MaxBytes   uint64
+	Hits       uint64
+	Misses     uint64
+}
+
+// ScriptResultCache caches the results of scripts executed at sealed blocks, which are immutable. Entries are
+// evicted in least recently used order when either the entry or the memory limit is reached.
+//
+// Safe for concurrent use.
+type ScriptResultCache struct {
+	mu         sync.Mutex
+	metrics    module.ScriptResultCacheMetrics
+	entries    *simplelru.LRU[ScriptResultCacheKey, *scriptResultCacheEntry]
+	maxEntries int
+	maxBytes   uint64
+	bytes      uint64
+	hits       uint64
+	misses     uint64
+}
+
+// NewScriptResultCache creates a script result cache holding at most maxEntries results, with a total size of
+// at most maxBytes.
+// No errors are expected during normal operation.
+func NewScriptResultCache(maxEntries uint, maxBytes uint64, metrics module.ScriptResultCacheMetrics) (*ScriptResultCache, error) {
+	c := &ScriptResultCache{
+		metrics:    metrics,
+		maxEntries: int(maxEntries),
+		maxBytes:   maxBytes,
+	}
+	entries, err := simplelru.NewLRU[ScriptResultCacheKey, *scriptResultCacheEntry](c.maxEntries, c.onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.entries = entries
+	return c, nil
+}
+
+// onEvict updates the size of the cache when an entry is removed. It is called with the lock held.
+func (c *ScriptResultCache) onEvict(_ ScriptResultCacheKey, entry *scriptResultCacheEntry) {
+	c.bytes -= uint64(entry.Size)
+}
+
+// Get returns the cached result of the script execution with the given key.
+func (c *ScriptResultCache) Get(key ScriptResultCacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		c.misses++
+		c.metrics.ScriptResultCacheMiss()
+		return nil, false
+	}
+	entry.Hits++
+	c.hits++
+	c.metrics.ScriptResultCacheHit()
+	return entry.result, true
+}
+
+// Add caches the result of the script execution with the given key, executed at the given height. Results
+// larger than the memory limit of the cache are not cached.
+func (c *ScriptResultCache) Add(key ScriptResultCacheKey, height uint64, result []byte) {
+	size := len(result) + scriptResultCacheEntryOverhead
+	if uint64(size) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries.Contains(key) {
+		return
+	}
+	for c.bytes+uint64(size) > c.maxBytes {
+		c.entries.RemoveOldest()
+	}
+	c.entries.Add(key, &scriptResultCacheEntry{
+		ScriptResultCacheEntry: ScriptResultCacheEntry{
+			ScriptResultCacheKey: key,
+			Height:               height,
+			Size:                 size,
+			AddedAt:              time.Now(),
+		},
+		result: result,
+	})
+	c.bytes += uint64(size)
+	c.metrics.ScriptResultCacheSize(c.entries.Len(), c.bytes)
+}
+
+// Flush removes all entries from the cache, and returns the number of removed entries.
+func (c *ScriptResultCache) Flush() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := c.entries.Len()
+	c.entries.Purge()
+	c.metrics.ScriptResultCacheSize(0, 0)
+	return count
+}
+
+// Stats returns the current state of the cache.
+func (c *ScriptResultCache) Stats() ScriptResultCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return ScriptResultCacheStats{
+		Entries:    c.entries.Len(),
+		Bytes:      c.bytes,
+		MaxEntries: c.maxEntries,
+		MaxBytes:   c.maxBytes,
+		Hits:       c.hits,
+		Misses:     c.misses,
+	}
+}
+
+// Entries returns up to n cached entries, sorted by decreasing number of hits.
+func (c *ScriptResultCache) Entries(n int) []ScriptResultCacheEntry {
+	c.mu.Lock()
+	entries := make([]ScriptResultCacheEntry, 0, c.entries.Len())
+	for _, entry := range c.entries.Values() {
+		entries = append(entries, entry.ScriptResultCacheEntry)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Hits > entries[j].Hits
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
@@ -0,0 +1,83 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestScriptResultCache verifies that the script result cache respects its entry and memory limits.
+func TestScriptResultCache(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	keys := make([]ScriptResultCacheKey, 4)
+	for i := range keys {
+		keys[i] = NewScriptResultCacheKey([]byte("access(all) fun main() {}"), [][]byte{{byte(i)}}, blockID)
+	}
+	result := make([]byte, 100)
+	entrySize := uint64(len(result) + scriptResultCacheEntryOverhead)
+
+	t.Run("entry limit", func(t *testing.T) {
+		cache, err := NewScriptResultCache(2, 1024*1024, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		for _, key := range keys[:3] {
+			cache.Add(key, 1, result)
+		}
+
+		// the least recently used result is evicted
+		_, ok := cache.Get(keys[0])
+		assert.False(t, ok)
+		cached, ok := cache.Get(keys[2])
+		assert.True(t, ok)
+		assert.Equal(t, result, cached)
+
+		stats := cache.Stats()
+		assert.Equal(t, 2, stats.Entries)
+		assert.Equal(t, 2*entrySize, stats.Bytes)
+		assert.Equal(t, uint64(1), stats.Hits)
+		assert.Equal(t, uint64(1), stats.Misses)
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		cache, err := NewScriptResultCache(10, 2*entrySize, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		for _, key := range keys[:3] {
+			cache.Add(key, 1, result)
+		}
+		stats := cache.Stats()
+		assert.Equal(t, 2, stats.Entries)
+		assert.Equal(t, 2*entrySize, stats.Bytes)
+
+		// results larger than the cache are not cached
+		cache.Add(keys[3], 1, make([]byte, 2*entrySize))
+		_, ok := cache.Get(keys[3])
+		assert.False(t, ok)
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		cache, err := NewScriptResultCache(10, 1024*1024, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		for _, key := range keys {
+			cache.Add(key, 1, result)
+		}
+		_, _ = cache.Get(keys[1])
+		_, _ = cache.Get(keys[1])
+		_, _ = cache.Get(keys[2])
+
+		top := cache.Entries(2)
+		require.Len(t, top, 2)
+		assert.Equal(t, keys[1], top[0].ScriptResultCacheKey)
+		assert.Equal(t, uint64(2), top[0].Hits)
+
+		assert.Equal(t, len(keys), cache.Flush())
+		stats := cache.Stats()
+		assert.Zero(t, stats.Entries)
+		assert.Zero(t, stats.Bytes)
+	})
+}
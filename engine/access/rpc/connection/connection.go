@@ -11,6 +11,8 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
 
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
 	"github.com/onflow/flow-go/module"
 )
 
@@ -27,6 +29,13 @@ type ConnectionFactory interface {
 	// GetExecutionAPIClient gets an execution API client for the specified address using the default ExecutionGRPCPort.
 	// The returned io.Closer should close the connection after the call if no error occurred during client creation.
 	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
+	// GetRegisterProofAPIClient gets a register proof API client for the specified address using the default ExecutionGRPCPort.
+	// The returned io.Closer should close the connection after the call if no error occurred during client creation.
+	GetRegisterProofAPIClient(address string) (registerproofs.RegisterProofAPIClient, io.Closer, error)
+	// GetTransactionResourceReportAPIClient gets a transaction resource report API client for the specified address
+	// using the default ExecutionGRPCPort.
+	// The returned io.Closer should close the connection after the call if no error occurred during client creation.
+	GetTransactionResourceReportAPIClient(address string) (resourcereports.TransactionResourceReportAPIClient, io.Closer, error)
 }
 
 // ProxyConnectionFactory wraps an existing ConnectionFactory and allows getting API clients for a target address.
@@ -48,6 +57,19 @@ func (p *ProxyConnectionFactory) GetExecutionAPIClient(address string) (executio
 	return p.ConnectionFactory.GetExecutionAPIClient(p.targetAddress)
 }
 
+// GetRegisterProofAPIClient gets a register proof API client for a target address using the default ExecutionGRPCPort.
+// The returned io.Closer should close the connection after the call if no error occurred during client creation.
+func (p *ProxyConnectionFactory) GetRegisterProofAPIClient(address string) (registerproofs.RegisterProofAPIClient, io.Closer, error) {
+	return p.ConnectionFactory.GetRegisterProofAPIClient(p.targetAddress)
+}
+
+// GetTransactionResourceReportAPIClient gets a transaction resource report API client for a target address using
+// the default ExecutionGRPCPort.
+// The returned io.Closer should close the connection after the call if no error occurred during client creation.
+func (p *ProxyConnectionFactory) GetTransactionResourceReportAPIClient(address string) (resourcereports.TransactionResourceReportAPIClient, io.Closer, error) {
+	return p.ConnectionFactory.GetTransactionResourceReportAPIClient(p.targetAddress)
+}
+
 var _ ConnectionFactory = (*ConnectionFactoryImpl)(nil)
 
 type ConnectionFactoryImpl struct {
@@ -99,6 +121,40 @@ func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (executio
 	return execution.NewExecutionAPIClient(conn), closer, nil
 }
 
+// GetRegisterProofAPIClient gets a register proof API client for the specified address using the default ExecutionGRPCPort.
+// The register proof API is served by the same gRPC server as the execution API.
+// The returned io.Closer should close the connection after the call if no error occurred during client creation.
+func (cf *ConnectionFactoryImpl) GetRegisterProofAPIClient(address string) (registerproofs.RegisterProofAPIClient, io.Closer, error) {
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, closer, err := cf.Manager.GetConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return registerproofs.NewRegisterProofAPIClient(conn), closer, nil
+}
+
+// GetTransactionResourceReportAPIClient gets a transaction resource report API client for the specified address using
+// the default ExecutionGRPCPort. The transaction resource report API is served by the same gRPC server as the execution API.
+// The returned io.Closer should close the connection after the call if no error occurred during client creation.
+func (cf *ConnectionFactoryImpl) GetTransactionResourceReportAPIClient(address string) (resourcereports.TransactionResourceReportAPIClient, io.Closer, error) {
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, closer, err := cf.Manager.GetConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resourcereports.NewTransactionResourceReportAPIClient(conn), closer, nil
+}
+
 // getGRPCAddress translates the flow.Identity address to the GRPC address of the node by switching the port to the
 // GRPC port from the libp2p port.
 func getGRPCAddress(address string, grpcPort uint) (string, error) {
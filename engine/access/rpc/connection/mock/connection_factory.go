@@ -12,6 +12,10 @@ import (
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
+
+	registerproofs "github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+
+	resourcereports "github.com/onflow/flow-go/engine/common/rpc/resourcereports"
 )
 
 // ConnectionFactory is an autogenerated mock type for the ConnectionFactory type
@@ -136,6 +140,84 @@ func (_m *ConnectionFactory) GetExecutionAPIClient(address string) (execution.Ex
 	return r0, r1, r2
 }
 
+// GetRegisterProofAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetRegisterProofAPIClient(address string) (registerproofs.RegisterProofAPIClient, io.Closer, error) {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisterProofAPIClient")
+	}
+
+	var r0 registerproofs.RegisterProofAPIClient
+	var r1 io.Closer
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (registerproofs.RegisterProofAPIClient, io.Closer, error)); ok {
+		return rf(address)
+	}
+	if rf, ok := ret.Get(0).(func(string) registerproofs.RegisterProofAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(registerproofs.RegisterProofAPIClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) io.Closer); ok {
+		r1 = rf(address)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Closer)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTransactionResourceReportAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetTransactionResourceReportAPIClient(address string) (resourcereports.TransactionResourceReportAPIClient, io.Closer, error) {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionResourceReportAPIClient")
+	}
+
+	var r0 resourcereports.TransactionResourceReportAPIClient
+	var r1 io.Closer
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (resourcereports.TransactionResourceReportAPIClient, io.Closer, error)); ok {
+		return rf(address)
+	}
+	if rf, ok := ret.Get(0).(func(string) resourcereports.TransactionResourceReportAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(resourcereports.TransactionResourceReportAPIClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) io.Closer); ok {
+		r1 = rf(address)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Closer)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewConnectionFactory creates a new instance of ConnectionFactory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewConnectionFactory(t interface {
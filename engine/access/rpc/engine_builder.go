@@ -10,6 +10,9 @@ import (
 	"github.com/onflow/flow-go/access"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/engine/common/rpc/emergencyseals"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/state_synchronization"
 )
@@ -105,6 +108,21 @@ func (builder *RPCEngineBuilder) Build() (*Engine, error) {
 	rpcHandler := builder.rpcHandler
 	if rpcHandler == nil {
 		rpcHandler = builder.DefaultHandler(builder.signerIndicesDecoder)
+
+		// register proofs and resource reports are proxied to the execution nodes, and emergency seals are reconstructed
+		// from the local protocol state by the backend, which is not available if a custom handler (e.g. the observer's
+		// upstream proxy) is used
+		registerProofsHandler := access.NewRegisterProofsHandler(builder.Engine.backend, builder.Engine.chain)
+		registerproofs.RegisterRegisterProofAPIServer(builder.unsecureGrpcServer.Server, registerProofsHandler)
+		registerproofs.RegisterRegisterProofAPIServer(builder.secureGrpcServer.Server, registerProofsHandler)
+
+		resourceReportsHandler := access.NewResourceReportsHandler(builder.Engine.backend)
+		resourcereports.RegisterTransactionResourceReportAPIServer(builder.unsecureGrpcServer.Server, resourceReportsHandler)
+		resourcereports.RegisterTransactionResourceReportAPIServer(builder.secureGrpcServer.Server, resourceReportsHandler)
+
+		emergencySealsHandler := access.NewEmergencySealsHandler(builder.Engine.backend)
+		emergencyseals.RegisterEmergencySealAPIServer(builder.unsecureGrpcServer.Server, emergencySealsHandler)
+		emergencyseals.RegisterEmergencySealAPIServer(builder.secureGrpcServer.Server, emergencySealsHandler)
 	}
 	accessproto.RegisterAccessAPIServer(builder.unsecureGrpcServer.Server, rpcHandler)
 	accessproto.RegisterAccessAPIServer(builder.secureGrpcServer.Server, rpcHandler)
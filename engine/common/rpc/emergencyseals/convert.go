@@ -0,0 +1,36 @@
+package emergencyseals
+
+import (
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EmergencySealToMessage converts an emergency seal audit record to a protobuf message.
+func EmergencySealToMessage(seal *flow.EmergencySeal) *EmergencySeal {
+	chunks := make([]*EmergencySealChunk, len(seal.UnapprovedChunks))
+	for i, chunk := range seal.UnapprovedChunks {
+		chunks[i] = &EmergencySealChunk{
+			Index:             chunk.Index,
+			AssignedVerifiers: convert.IdentifiersToMessages(chunk.AssignedVerifiers),
+			ApprovedBy:        convert.IdentifiersToMessages(chunk.ApprovedBy),
+		}
+	}
+
+	return &EmergencySeal{
+		ResultId:            convert.IdentifierToMessage(seal.ResultID),
+		BlockId:             convert.IdentifierToMessage(seal.BlockID),
+		BlockHeight:         seal.BlockHeight,
+		IncorporatedBlockId: convert.IdentifierToMessage(seal.IncorporatedBlockID),
+		RequiredApprovals:   uint64(seal.RequiredApprovals),
+		UnapprovedChunks:    chunks,
+	}
+}
+
+// EmergencySealsToMessages converts emergency seal audit records to protobuf messages.
+func EmergencySealsToMessages(seals []*flow.EmergencySeal) []*EmergencySeal {
+	messages := make([]*EmergencySeal, len(seals))
+	for i, seal := range seals {
+		messages[i] = EmergencySealToMessage(seal)
+	}
+	return messages
+}
@@ -0,0 +1,445 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.12
+// source: emergencyseals/emergencyseals.proto
+
+package emergencyseals
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EmergencySealChunk describes a chunk of an emergency-sealed result, which did not collect the required number of approvals.
+type EmergencySealChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index             uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                                 // index of the chunk within the execution result
+	AssignedVerifiers [][]byte `protobuf:"bytes,2,rep,name=assigned_verifiers,json=assignedVerifiers,proto3" json:"assigned_verifiers,omitempty"` // IDs of the verification nodes assigned to the chunk
+	ApprovedBy        [][]byte `protobuf:"bytes,3,rep,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`                      // IDs of the assigned verifiers which approved the chunk
+}
+
+func (x *EmergencySealChunk) Reset() {
+	*x = EmergencySealChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_emergencyseals_emergencyseals_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmergencySealChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencySealChunk) ProtoMessage() {}
+
+func (x *EmergencySealChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_emergencyseals_emergencyseals_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencySealChunk.ProtoReflect.Descriptor instead.
+func (*EmergencySealChunk) Descriptor() ([]byte, []int) {
+	return file_emergencyseals_emergencyseals_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EmergencySealChunk) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *EmergencySealChunk) GetAssignedVerifiers() [][]byte {
+	if x != nil {
+		return x.AssignedVerifiers
+	}
+	return nil
+}
+
+func (x *EmergencySealChunk) GetApprovedBy() [][]byte {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return nil
+}
+
+// EmergencySeal is the audit record for a seal constructed with fewer approvals than required.
+type EmergencySeal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResultId            []byte                `protobuf:"bytes,1,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`                                    // ID of the sealed execution result
+	BlockId             []byte                `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`                                       // ID of the executed block
+	BlockHeight         uint64                `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`                          // height of the executed block
+	IncorporatedBlockId []byte                `protobuf:"bytes,4,opt,name=incorporated_block_id,json=incorporatedBlockId,proto3" json:"incorporated_block_id,omitempty"` // ID of the block incorporating the result
+	RequiredApprovals   uint64                `protobuf:"varint,5,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`        // number of approvals per chunk required for regular sealing
+	UnapprovedChunks    []*EmergencySealChunk `protobuf:"bytes,6,rep,name=unapproved_chunks,json=unapprovedChunks,proto3" json:"unapproved_chunks,omitempty"`            // chunks without the required approvals, ordered by index
+}
+
+func (x *EmergencySeal) Reset() {
+	*x = EmergencySeal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_emergencyseals_emergencyseals_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmergencySeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencySeal) ProtoMessage() {}
+
+func (x *EmergencySeal) ProtoReflect() protoreflect.Message {
+	mi := &file_emergencyseals_emergencyseals_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencySeal.ProtoReflect.Descriptor instead.
+func (*EmergencySeal) Descriptor() ([]byte, []int) {
+	return file_emergencyseals_emergencyseals_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EmergencySeal) GetResultId() []byte {
+	if x != nil {
+		return x.ResultId
+	}
+	return nil
+}
+
+func (x *EmergencySeal) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *EmergencySeal) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *EmergencySeal) GetIncorporatedBlockId() []byte {
+	if x != nil {
+		return x.IncorporatedBlockId
+	}
+	return nil
+}
+
+func (x *EmergencySeal) GetRequiredApprovals() uint64 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *EmergencySeal) GetUnapprovedChunks() []*EmergencySealChunk {
+	if x != nil {
+		return x.UnapprovedChunks
+	}
+	return nil
+}
+
+// GetEmergencySealsByBlockIDRequest is the request for the emergency seals included in a block.
+type GetEmergencySealsByBlockIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"` // ID of the block
+}
+
+func (x *GetEmergencySealsByBlockIDRequest) Reset() {
+	*x = GetEmergencySealsByBlockIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_emergencyseals_emergencyseals_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEmergencySealsByBlockIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencySealsByBlockIDRequest) ProtoMessage() {}
+
+func (x *GetEmergencySealsByBlockIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_emergencyseals_emergencyseals_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencySealsByBlockIDRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencySealsByBlockIDRequest) Descriptor() ([]byte, []int) {
+	return file_emergencyseals_emergencyseals_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetEmergencySealsByBlockIDRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+// GetEmergencySealsByBlockIDResponse contains the emergency seals included in a block.
+type GetEmergencySealsByBlockIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte           `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"` // ID of the block
+	Seals   []*EmergencySeal `protobuf:"bytes,2,rep,name=seals,proto3" json:"seals,omitempty"`                    // audit records of the emergency seals in the block payload
+}
+
+func (x *GetEmergencySealsByBlockIDResponse) Reset() {
+	*x = GetEmergencySealsByBlockIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_emergencyseals_emergencyseals_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEmergencySealsByBlockIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencySealsByBlockIDResponse) ProtoMessage() {}
+
+func (x *GetEmergencySealsByBlockIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_emergencyseals_emergencyseals_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencySealsByBlockIDResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencySealsByBlockIDResponse) Descriptor() ([]byte, []int) {
+	return file_emergencyseals_emergencyseals_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEmergencySealsByBlockIDResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetEmergencySealsByBlockIDResponse) GetSeals() []*EmergencySeal {
+	if x != nil {
+		return x.Seals
+	}
+	return nil
+}
+
+var File_emergencyseals_emergencyseals_proto protoreflect.FileDescriptor
+
+var file_emergencyseals_emergencyseals_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x65, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61, 0x6c, 0x73,
+	0x2f, 0x65, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x22, 0x7a, 0x0a, 0x12, 0x45, 0x6d,
+	0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x11, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x64, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x64, 0x42, 0x79, 0x22, 0xa3, 0x02, 0x0a, 0x0d, 0x45, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x6e, 0x63, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x12, 0x54, 0x0a, 0x11, 0x75, 0x6e, 0x61, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e,
+	0x63, 0x79, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63,
+	0x79, 0x53, 0x65, 0x61, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x10, 0x75, 0x6e, 0x61, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22, 0x3e, 0x0a, 0x21,
+	0x47, 0x65, 0x74, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c,
+	0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22, 0x79, 0x0a, 0x22,
+	0x47, 0x65, 0x74, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c,
+	0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x38, 0x0a,
+	0x05, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61,
+	0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c,
+	0x52, 0x05, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x32, 0xa2, 0x01, 0x0a, 0x10, 0x45, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c, 0x41, 0x50, 0x49, 0x12, 0x8d, 0x01, 0x0a,
+	0x1a, 0x47, 0x65, 0x74, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61,
+	0x6c, 0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x36, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x2e, 0x65, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61, 0x6c,
+	0x73, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65,
+	0x61, 0x6c, 0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x61, 0x6c, 0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3c, 0x5a, 0x3a,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f,
+	0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x65, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x6e, 0x63, 0x79, 0x73, 0x65, 0x61, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_emergencyseals_emergencyseals_proto_rawDescOnce sync.Once
+	file_emergencyseals_emergencyseals_proto_rawDescData = file_emergencyseals_emergencyseals_proto_rawDesc
+)
+
+func file_emergencyseals_emergencyseals_proto_rawDescGZIP() []byte {
+	file_emergencyseals_emergencyseals_proto_rawDescOnce.Do(func() {
+		file_emergencyseals_emergencyseals_proto_rawDescData = protoimpl.X.CompressGZIP(file_emergencyseals_emergencyseals_proto_rawDescData)
+	})
+	return file_emergencyseals_emergencyseals_proto_rawDescData
+}
+
+var file_emergencyseals_emergencyseals_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_emergencyseals_emergencyseals_proto_goTypes = []interface{}{
+	(*EmergencySealChunk)(nil),                 // 0: flow.emergencyseals.EmergencySealChunk
+	(*EmergencySeal)(nil),                      // 1: flow.emergencyseals.EmergencySeal
+	(*GetEmergencySealsByBlockIDRequest)(nil),  // 2: flow.emergencyseals.GetEmergencySealsByBlockIDRequest
+	(*GetEmergencySealsByBlockIDResponse)(nil), // 3: flow.emergencyseals.GetEmergencySealsByBlockIDResponse
+}
+var file_emergencyseals_emergencyseals_proto_depIdxs = []int32{
+	0, // 0: flow.emergencyseals.EmergencySeal.unapproved_chunks:type_name -> flow.emergencyseals.EmergencySealChunk
+	1, // 1: flow.emergencyseals.GetEmergencySealsByBlockIDResponse.seals:type_name -> flow.emergencyseals.EmergencySeal
+	2, // 2: flow.emergencyseals.EmergencySealAPI.GetEmergencySealsByBlockID:input_type -> flow.emergencyseals.GetEmergencySealsByBlockIDRequest
+	3, // 3: flow.emergencyseals.EmergencySealAPI.GetEmergencySealsByBlockID:output_type -> flow.emergencyseals.GetEmergencySealsByBlockIDResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_emergencyseals_emergencyseals_proto_init() }
+func file_emergencyseals_emergencyseals_proto_init() {
+	if File_emergencyseals_emergencyseals_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_emergencyseals_emergencyseals_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmergencySealChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_emergencyseals_emergencyseals_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmergencySeal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_emergencyseals_emergencyseals_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEmergencySealsByBlockIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_emergencyseals_emergencyseals_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEmergencySealsByBlockIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_emergencyseals_emergencyseals_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_emergencyseals_emergencyseals_proto_goTypes,
+		DependencyIndexes: file_emergencyseals_emergencyseals_proto_depIdxs,
+		MessageInfos:      file_emergencyseals_emergencyseals_proto_msgTypes,
+	}.Build()
+	File_emergencyseals_emergencyseals_proto = out.File
+	file_emergencyseals_emergencyseals_proto_rawDesc = nil
+	file_emergencyseals_emergencyseals_proto_goTypes = nil
+	file_emergencyseals_emergencyseals_proto_depIdxs = nil
+}
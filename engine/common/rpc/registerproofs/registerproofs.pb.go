@@ -0,0 +1,271 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.12
+// source: registerproofs/registerproofs.proto
+
+package registerproofs
+
+import (
+	entities "github.com/onflow/flow/protobuf/go/flow/entities"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetRegisterProofsRequest is the request for proofs of registers at a sealed block.
+type GetRegisterProofsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId     []byte                 `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`             // ID of the sealed block
+	RegisterIds []*entities.RegisterID `protobuf:"bytes,2,rep,name=register_ids,json=registerIds,proto3" json:"register_ids,omitempty"` // IDs of the registers to prove
+}
+
+func (x *GetRegisterProofsRequest) Reset() {
+	*x = GetRegisterProofsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_registerproofs_registerproofs_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRegisterProofsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegisterProofsRequest) ProtoMessage() {}
+
+func (x *GetRegisterProofsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_registerproofs_registerproofs_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegisterProofsRequest.ProtoReflect.Descriptor instead.
+func (*GetRegisterProofsRequest) Descriptor() ([]byte, []int) {
+	return file_registerproofs_registerproofs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRegisterProofsRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetRegisterProofsRequest) GetRegisterIds() []*entities.RegisterID {
+	if x != nil {
+		return x.RegisterIds
+	}
+	return nil
+}
+
+// GetRegisterProofsResponse contains a batch proof of the requested registers.
+type GetRegisterProofsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId         []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`                         // ID of the sealed block
+	BlockHeight     uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`            // height of the sealed block
+	StateCommitment []byte `protobuf:"bytes,3,opt,name=state_commitment,json=stateCommitment,proto3" json:"state_commitment,omitempty"` // state commitment of the sealed block, which the proofs are verified against
+	Proof           []byte `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`                                            // encoded ledger.TrieBatchProof, containing one proof per requested register
+}
+
+func (x *GetRegisterProofsResponse) Reset() {
+	*x = GetRegisterProofsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_registerproofs_registerproofs_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRegisterProofsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegisterProofsResponse) ProtoMessage() {}
+
+func (x *GetRegisterProofsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_registerproofs_registerproofs_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegisterProofsResponse.ProtoReflect.Descriptor instead.
+func (*GetRegisterProofsResponse) Descriptor() ([]byte, []int) {
+	return file_registerproofs_registerproofs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRegisterProofsResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetRegisterProofsResponse) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *GetRegisterProofsResponse) GetStateCommitment() []byte {
+	if x != nil {
+		return x.StateCommitment
+	}
+	return nil
+}
+
+func (x *GetRegisterProofsResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+var File_registerproofs_registerproofs_proto protoreflect.FileDescriptor
+
+var file_registerproofs_registerproofs_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x1a, 0x1c, 0x66, 0x6c, 0x6f, 0x77,
+	0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x73, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12,
+	0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44,
+	0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x9a, 0x01,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x32, 0x86, 0x01, 0x0a, 0x10, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x50, 0x49, 0x12,
+	0x72, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x73, 0x12, 0x2d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f,
+	0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x72,
+	0x70, 0x63, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_registerproofs_registerproofs_proto_rawDescOnce sync.Once
+	file_registerproofs_registerproofs_proto_rawDescData = file_registerproofs_registerproofs_proto_rawDesc
+)
+
+func file_registerproofs_registerproofs_proto_rawDescGZIP() []byte {
+	file_registerproofs_registerproofs_proto_rawDescOnce.Do(func() {
+		file_registerproofs_registerproofs_proto_rawDescData = protoimpl.X.CompressGZIP(file_registerproofs_registerproofs_proto_rawDescData)
+	})
+	return file_registerproofs_registerproofs_proto_rawDescData
+}
+
+var file_registerproofs_registerproofs_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_registerproofs_registerproofs_proto_goTypes = []interface{}{
+	(*GetRegisterProofsRequest)(nil),  // 0: flow.registerproofs.GetRegisterProofsRequest
+	(*GetRegisterProofsResponse)(nil), // 1: flow.registerproofs.GetRegisterProofsResponse
+	(*entities.RegisterID)(nil),       // 2: flow.entities.RegisterID
+}
+var file_registerproofs_registerproofs_proto_depIdxs = []int32{
+	2, // 0: flow.registerproofs.GetRegisterProofsRequest.register_ids:type_name -> flow.entities.RegisterID
+	0, // 1: flow.registerproofs.RegisterProofAPI.GetRegisterProofs:input_type -> flow.registerproofs.GetRegisterProofsRequest
+	1, // 2: flow.registerproofs.RegisterProofAPI.GetRegisterProofs:output_type -> flow.registerproofs.GetRegisterProofsResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_registerproofs_registerproofs_proto_init() }
+func file_registerproofs_registerproofs_proto_init() {
+	if File_registerproofs_registerproofs_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_registerproofs_registerproofs_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRegisterProofsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_registerproofs_registerproofs_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRegisterProofsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_registerproofs_registerproofs_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_registerproofs_registerproofs_proto_goTypes,
+		DependencyIndexes: file_registerproofs_registerproofs_proto_depIdxs,
+		MessageInfos:      file_registerproofs_registerproofs_proto_msgTypes,
+	}.Build()
+	File_registerproofs_registerproofs_proto = out.File
+	file_registerproofs_registerproofs_proto_rawDesc = nil
+	file_registerproofs_registerproofs_proto_goTypes = nil
+	file_registerproofs_registerproofs_proto_depIdxs = nil
+}
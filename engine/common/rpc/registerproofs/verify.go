@@ -0,0 +1,228 @@
+package registerproofs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/partial"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var (
+	// ErrInvalidProof is returned if a register proof can not be verified against the trusted state commitment.
+	ErrInvalidProof = errors.New("invalid register proof")
+	// ErrAccountNotFound is returned if an account is proven to not exist at the trusted state commitment.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrVaultNotFound is returned if an account is proven to not store a FLOW vault at the trusted state commitment.
+	ErrVaultNotFound = errors.New("flow vault not found")
+)
+
+// flowTokenVaultIdentifier is the identifier of the storage path at which accounts store their FLOW vault.
+const flowTokenVaultIdentifier = "flowTokenVault"
+
+// MissingRegisterError is returned if a proof does not cover a register which is needed to read a stored value.
+// The register is only known once the registers referencing it are proven, so clients request it together with
+// the already requested registers, until the proof covers all registers of the value.
+type MissingRegisterError struct {
+	RegisterID flow.RegisterID
+}
+
+func (e *MissingRegisterError) Error() string {
+	return fmt.Sprintf("%v: proof does not cover register %v", ErrInvalidProof, e.RegisterID)
+}
+
+// Unwrap returns ErrInvalidProof, as the proof is not sufficient to read the value.
+func (e *MissingRegisterError) Unwrap() error {
+	return ErrInvalidProof
+}
+
+// VerifyRegisterProofs verifies the encoded batch proof against the trusted state commitment, and returns the
+// proven values of the given registers, in the same order as the register IDs. Registers which are proven to be
+// unallocated are returned with an empty value.
+//
+// The state commitment must be obtained from a trusted source, e.g. the seal of the block, as the proof only
+// ensures consistency of the register values with the state commitment.
+//
+// Expected errors during normal operation:
+//   - ErrInvalidProof if the proof is malformed, does not match the state commitment or does not cover all registers.
+func VerifyRegisterProofs(proof []byte, commitment flow.StateCommitment, registerIDs flow.RegisterIDs) ([]flow.RegisterValue, error) {
+	if len(registerIDs) == 0 {
+		return []flow.RegisterValue{}, nil
+	}
+
+	psmt, err := partial.NewLedger(proof, ledger.State(commitment), partial.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	keys := make([]ledger.Key, len(registerIDs))
+	for i, registerID := range registerIDs {
+		keys[i] = convert.RegisterIDToLedgerKey(registerID)
+	}
+	query, err := ledger.NewQuery(ledger.State(commitment), keys)
+	if err != nil {
+		return nil, fmt.Errorf("could not create ledger query: %w", err)
+	}
+
+	values, err := psmt.Get(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	registerValues := make([]flow.RegisterValue, len(values))
+	for i, value := range values {
+		registerValues[i] = value
+	}
+	return registerValues, nil
+}
+
+// VerifyResponse verifies that the response contains proofs for the given registers at the given block, which
+// are valid against the trusted state commitment of the block. It returns the proven register values in the
+// same order as the register IDs.
+//
+// Expected errors during normal operation:
+//   - ErrInvalidProof if the response is for a different block or state commitment, or the proof is invalid.
+func VerifyResponse(
+	response *GetRegisterProofsResponse,
+	blockID flow.Identifier,
+	commitment flow.StateCommitment,
+	registerIDs flow.RegisterIDs,
+) ([]flow.RegisterValue, error) {
+	if flow.HashToID(response.GetBlockId()) != blockID {
+		return nil, fmt.Errorf("%w: response is for block %x, expected %v", ErrInvalidProof, response.GetBlockId(), blockID)
+	}
+	responseCommitment, err := flow.ToStateCommitment(response.GetStateCommitment())
+	if err != nil || responseCommitment != commitment {
+		return nil, fmt.Errorf("%w: response is for state commitment %x, expected %v", ErrInvalidProof, response.GetStateCommitment(), commitment)
+	}
+	return VerifyRegisterProofs(response.GetProof(), commitment, registerIDs)
+}
+
+// VerifyAccountStatus verifies the proof of the account status register of the given account, and returns the
+// proven account status, which contains the storage used by the account and the number of its public keys.
+//
+// Expected errors during normal operation:
+//   - ErrInvalidProof if the proof is invalid.
+//   - ErrAccountNotFound if the account does not exist at the state commitment.
+func VerifyAccountStatus(proof []byte, commitment flow.StateCommitment, address flow.Address) (*environment.AccountStatus, error) {
+	values, err := VerifyRegisterProofs(proof, commitment, flow.RegisterIDs{flow.AccountStatusRegisterID(address)})
+	if err != nil {
+		return nil, err
+	}
+	if len(values[0]) == 0 {
+		return nil, fmt.Errorf("account %v does not exist: %w", address, ErrAccountNotFound)
+	}
+	status, err := environment.AccountStatusFromBytes(values[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not decode account status of %v: %w", address, err)
+	}
+	return status, nil
+}
+
+// VerifyAccountBalance verifies the proofs of the registers storing the FLOW vault of the given account, and returns
+// the proven balance of the vault stored at /storage/flowTokenVault, in the smallest unit of FLOW.
+//
+// The proof has to cover the storage domain register of the account, which is the register with the key "storage",
+// and the registers of the slabs storing the domain and the vault. As the slabs are only known once the registers
+// referencing them are proven, clients start with the storage domain register and add the register of each
+// MissingRegisterError to the next request.
+//
+// Expected errors during normal operation:
+//   - MissingRegisterError if the proof does not cover all registers of the vault. It wraps ErrInvalidProof.
+//   - ErrInvalidProof if the proof is invalid, or the proven registers can not be decoded.
+//   - ErrVaultNotFound if the account does not store a FLOW vault at the state commitment.
+func VerifyAccountBalance(proof []byte, commitment flow.StateCommitment, chainID flow.ChainID, address flow.Address) (balance uint64, err error) {
+	psmt, err := partial.NewLedger(proof, ledger.State(commitment), partial.DefaultPathFinderVersion)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	// the cadence storage reports errors of the ledger as panics
+	defer func() {
+		if r := recover(); r != nil {
+			var missing *MissingRegisterError
+			if recovered, ok := r.(error); ok && errors.As(recovered, &missing) {
+				err = missing
+				return
+			}
+			err = fmt.Errorf("%w: could not decode flow vault of %v: %v", ErrInvalidProof, address, r)
+		}
+	}()
+
+	storage := runtime.NewStorage(&provenLedger{psmt: psmt, commitment: commitment}, nil)
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{Storage: storage})
+	if err != nil {
+		return 0, fmt.Errorf("could not create interpreter: %w", err)
+	}
+
+	storageMap := storage.GetStorageMap(common.Address(address), common.PathDomainStorage.Identifier(), false)
+	if storageMap == nil {
+		return 0, fmt.Errorf("account %v has no storage: %w", address, ErrVaultNotFound)
+	}
+	vault, ok := storageMap.ReadValue(nil, interpreter.StringStorageMapKey(flowTokenVaultIdentifier)).(*interpreter.CompositeValue)
+	if !ok {
+		return 0, fmt.Errorf("account %v stores no vault: %w", address, ErrVaultNotFound)
+	}
+
+	// the vault has to be checked to be of the FLOW token type, as any resource can be stored at the path
+	flowToken := systemcontracts.SystemContractsForChain(chainID).FlowToken
+	vaultType := common.NewAddressLocation(nil, common.Address(flowToken.Address), flowToken.Name).TypeID(nil, "FlowToken.Vault")
+	if vault.TypeID() != vaultType {
+		return 0, fmt.Errorf("account %v stores a vault of type %s: %w", address, vault.TypeID(), ErrVaultNotFound)
+	}
+
+	value, ok := vault.GetField(inter, interpreter.EmptyLocationRange, "balance").(interpreter.UFix64Value)
+	if !ok {
+		return 0, fmt.Errorf("%w: flow vault of %v has no balance", ErrInvalidProof, address)
+	}
+	return uint64(value), nil
+}
+
+// provenLedger is a read-only atree.Ledger, which reads the registers from a verified proof.
+type provenLedger struct {
+	psmt       *partial.Ledger
+	commitment flow.StateCommitment
+}
+
+var _ atree.Ledger = (*provenLedger)(nil)
+
+func (l *provenLedger) GetValue(owner, key []byte) ([]byte, error) {
+	registerID := flow.NewRegisterID(flow.BytesToAddress(owner), string(key))
+	query, err := ledger.NewQuerySingleValue(ledger.State(l.commitment), convert.RegisterIDToLedgerKey(registerID))
+	if err != nil {
+		return nil, fmt.Errorf("could not create ledger query: %w", err)
+	}
+	value, err := l.psmt.GetSingleValue(query)
+	if err != nil {
+		if errors.Is(err, ledger.ErrMissingKeys{}) {
+			return nil, &MissingRegisterError{RegisterID: registerID}
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	return value, nil
+}
+
+func (l *provenLedger) ValueExists(owner, key []byte) (bool, error) {
+	value, err := l.GetValue(owner, key)
+	if err != nil {
+		return false, err
+	}
+	return len(value) > 0, nil
+}
+
+func (l *provenLedger) SetValue(_, _, _ []byte) error {
+	return fmt.Errorf("proven registers are read-only")
+}
+
+func (l *provenLedger) AllocateSlabIndex(_ []byte) (atree.SlabIndex, error) {
+	return atree.SlabIndex{}, fmt.Errorf("proven registers are read-only")
+}
@@ -0,0 +1,170 @@
+package registerproofs_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// TestVerifyAccountBalance tests that the balance of the FLOW vault of an account is read from the proven registers,
+// and that the registers of the vault are discovered from the proofs.
+func TestVerifyAccountBalance(t *testing.T) {
+	chainID := flow.Testnet
+	flowToken := systemcontracts.SystemContractsForChain(chainID).FlowToken
+	vaultAddress := flow.HexToAddress("0x01")
+	otherAddress := flow.HexToAddress("0x02")
+	emptyAddress := flow.HexToAddress("0x03")
+
+	// store a FLOW vault, and a vault of a different token at the FLOW vault path
+	registers := newTestLedger()
+	storeVault(t, registers, vaultAddress, common.Address(flowToken.Address), flowToken.Name, 42_00000000)
+	storeVault(t, registers, otherAddress, common.Address(otherAddress), "OtherToken", 1)
+
+	ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 10, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+	compactor := fixtures.NewNoopCompactor(ldg)
+	<-compactor.Ready()
+	defer func() {
+		<-ldg.Done()
+		<-compactor.Done()
+	}()
+
+	keys := make([]ledger.Key, 0, len(registers.values))
+	values := make([]ledger.Value, 0, len(registers.values))
+	for registerID, value := range registers.values {
+		keys = append(keys, convert.RegisterIDToLedgerKey(registerID))
+		values = append(values, value)
+	}
+	update, err := ledger.NewUpdate(ldg.InitialState(), keys, values)
+	require.NoError(t, err)
+	state, _, err := ldg.Set(update)
+	require.NoError(t, err)
+	commit := flow.StateCommitment(state)
+
+	prove := func(registerIDs flow.RegisterIDs) []byte {
+		keys := make([]ledger.Key, len(registerIDs))
+		for i, registerID := range registerIDs {
+			keys[i] = convert.RegisterIDToLedgerKey(registerID)
+		}
+		query, err := ledger.NewQuery(state, keys)
+		require.NoError(t, err)
+		proof, err := ldg.Prove(query)
+		require.NoError(t, err)
+		return proof
+	}
+
+	// verifyBalance requests the registers reported as missing, until the proof covers all registers of the vault
+	verifyBalance := func(address flow.Address) (uint64, flow.RegisterIDs, error) {
+		registerIDs := flow.RegisterIDs{flow.NewRegisterID(address, common.PathDomainStorage.Identifier())}
+		for {
+			balance, err := registerproofs.VerifyAccountBalance(prove(registerIDs), commit, chainID, address)
+			var missing *registerproofs.MissingRegisterError
+			if !errors.As(err, &missing) {
+				return balance, registerIDs, err
+			}
+			require.NotContains(t, registerIDs, missing.RegisterID)
+			registerIDs = append(registerIDs, missing.RegisterID)
+		}
+	}
+
+	t.Run("flow vault", func(t *testing.T) {
+		balance, registerIDs, err := verifyBalance(vaultAddress)
+		require.NoError(t, err)
+		require.Equal(t, uint64(42_00000000), balance)
+		require.Greater(t, len(registerIDs), 1)
+
+		// the proof of the storage domain register alone does not cover the vault
+		_, err = registerproofs.VerifyAccountBalance(prove(registerIDs[:1]), commit, chainID, vaultAddress)
+		require.ErrorIs(t, err, registerproofs.ErrInvalidProof)
+
+		// the proof is rejected for a different state commitment
+		_, err = registerproofs.VerifyAccountBalance(prove(registerIDs), flow.StateCommitment(ldg.InitialState()), chainID, vaultAddress)
+		require.ErrorIs(t, err, registerproofs.ErrInvalidProof)
+	})
+
+	t.Run("vault of a different token", func(t *testing.T) {
+		_, _, err := verifyBalance(otherAddress)
+		require.ErrorIs(t, err, registerproofs.ErrVaultNotFound)
+	})
+
+	t.Run("account without storage", func(t *testing.T) {
+		_, _, err := verifyBalance(emptyAddress)
+		require.ErrorIs(t, err, registerproofs.ErrVaultNotFound)
+	})
+}
+
+// storeVault stores a vault with the given balance at the FLOW vault path of the account.
+func storeVault(t *testing.T, registers *testLedger, address flow.Address, contractAddress common.Address, contractName string, balance uint64) {
+	storage := runtime.NewStorage(registers, nil)
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{Storage: storage})
+	require.NoError(t, err)
+
+	owner := common.Address(address)
+	vault := interpreter.NewCompositeValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		common.NewAddressLocation(nil, contractAddress, contractName),
+		contractName+".Vault",
+		common.CompositeKindResource,
+		[]interpreter.CompositeField{
+			{Name: "uuid", Value: interpreter.NewUnmeteredUInt64Value(1)},
+			{Name: "balance", Value: interpreter.NewUnmeteredUFix64Value(balance)},
+		},
+		owner,
+	)
+	storageMap := storage.GetStorageMap(owner, common.PathDomainStorage.Identifier(), true)
+	storageMap.WriteValue(inter, interpreter.StringStorageMapKey("flowTokenVault"), vault)
+	require.NoError(t, storage.Commit(inter, false))
+}
+
+// testLedger is an in-memory atree.Ledger, which stores the registers by their register IDs.
+type testLedger struct {
+	values      map[flow.RegisterID]flow.RegisterValue
+	slabIndexes map[flow.Address]uint64
+}
+
+var _ atree.Ledger = (*testLedger)(nil)
+
+func newTestLedger() *testLedger {
+	return &testLedger{
+		values:      make(map[flow.RegisterID]flow.RegisterValue),
+		slabIndexes: make(map[flow.Address]uint64),
+	}
+}
+
+func (l *testLedger) GetValue(owner, key []byte) ([]byte, error) {
+	return l.values[flow.NewRegisterID(flow.BytesToAddress(owner), string(key))], nil
+}
+
+func (l *testLedger) SetValue(owner, key, value []byte) error {
+	l.values[flow.NewRegisterID(flow.BytesToAddress(owner), string(key))] = value
+	return nil
+}
+
+func (l *testLedger) ValueExists(owner, key []byte) (bool, error) {
+	return len(l.values[flow.NewRegisterID(flow.BytesToAddress(owner), string(key))]) > 0, nil
+}
+
+func (l *testLedger) AllocateSlabIndex(owner []byte) (atree.SlabIndex, error) {
+	address := flow.BytesToAddress(owner)
+	l.slabIndexes[address]++
+	var index atree.SlabIndex
+	binary.BigEndian.PutUint64(index[:], l.slabIndexes[address])
+	return index, nil
+}
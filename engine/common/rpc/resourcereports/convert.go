@@ -0,0 +1,86 @@
+package resourcereports
+
+import (
+	"github.com/onflow/cadence/common"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ReportToMessage converts a transaction resource report to a protobuf message. The names of the computation
+// and memory kinds are included, so that clients don't need to know the Cadence kinds.
+func ReportToMessage(report *flow.TransactionResourceReport) *TransactionResourceReport {
+	computationIntensities := make([]*ResourceIntensity, len(report.ComputationIntensities))
+	for i, intensity := range report.ComputationIntensities {
+		computationIntensities[i] = &ResourceIntensity{
+			Kind:      uint32(intensity.Kind),
+			Name:      common.ComputationKind(intensity.Kind).String(),
+			Intensity: intensity.Intensity,
+		}
+	}
+	memoryIntensities := make([]*ResourceIntensity, len(report.MemoryIntensities))
+	for i, intensity := range report.MemoryIntensities {
+		memoryIntensities[i] = &ResourceIntensity{
+			Kind:      uint32(intensity.Kind),
+			Name:      common.MemoryKind(intensity.Kind).String(),
+			Intensity: intensity.Intensity,
+		}
+	}
+
+	return &TransactionResourceReport{
+		TransactionId:          report.TransactionID[:],
+		ComputationUsed:        report.ComputationUsed,
+		ComputationIntensities: computationIntensities,
+		MemoryEstimate:         report.MemoryEstimate,
+		MemoryIntensities:      memoryIntensities,
+		BytesRead:              report.BytesRead,
+		BytesWritten:           report.BytesWritten,
+		RegistersTouched:       report.RegistersTouched,
+		RegistersUpdated:       report.RegistersUpdated,
+		EventBytes:             report.EventBytes,
+	}
+}
+
+// ReportsToMessages converts transaction resource reports to protobuf messages.
+func ReportsToMessages(reports []flow.TransactionResourceReport) []*TransactionResourceReport {
+	messages := make([]*TransactionResourceReport, len(reports))
+	for i := range reports {
+		messages[i] = ReportToMessage(&reports[i])
+	}
+	return messages
+}
+
+// MessageToReport converts a protobuf message to a transaction resource report.
+func MessageToReport(m *TransactionResourceReport) *flow.TransactionResourceReport {
+	return &flow.TransactionResourceReport{
+		TransactionID:          flow.HashToID(m.GetTransactionId()),
+		ComputationUsed:        m.GetComputationUsed(),
+		ComputationIntensities: messagesToIntensities(m.GetComputationIntensities()),
+		MemoryEstimate:         m.GetMemoryEstimate(),
+		MemoryIntensities:      messagesToIntensities(m.GetMemoryIntensities()),
+		BytesRead:              m.GetBytesRead(),
+		BytesWritten:           m.GetBytesWritten(),
+		RegistersTouched:       m.GetRegistersTouched(),
+		RegistersUpdated:       m.GetRegistersUpdated(),
+		EventBytes:             m.GetEventBytes(),
+	}
+}
+
+// MessagesToReports converts protobuf messages to transaction resource reports.
+func MessagesToReports(messages []*TransactionResourceReport) []flow.TransactionResourceReport {
+	reports := make([]flow.TransactionResourceReport, len(messages))
+	for i, m := range messages {
+		reports[i] = *MessageToReport(m)
+	}
+	return reports
+}
+
+func messagesToIntensities(messages []*ResourceIntensity) []flow.ResourceIntensity {
+	intensities := make([]flow.ResourceIntensity, len(messages))
+	for i, m := range messages {
+		intensities[i] = flow.ResourceIntensity{
+			Kind:      uint(m.GetKind()),
+			Intensity: m.GetIntensity(),
+		}
+	}
+	return intensities
+}
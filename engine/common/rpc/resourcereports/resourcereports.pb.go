@@ -0,0 +1,664 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.12
+// source: resourcereports/resourcereports.proto
+
+package resourcereports
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ResourceIntensity is the metered intensity of one kind of computation or memory.
+type ResourceIntensity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      uint32 `protobuf:"varint,1,opt,name=kind,proto3" json:"kind,omitempty"`           // Cadence computation kind or memory kind
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`            // name of the kind
+	Intensity uint64 `protobuf:"varint,3,opt,name=intensity,proto3" json:"intensity,omitempty"` // accumulated intensity metered for the kind
+}
+
+func (x *ResourceIntensity) Reset() {
+	*x = ResourceIntensity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resourcereports_resourcereports_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceIntensity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceIntensity) ProtoMessage() {}
+
+func (x *ResourceIntensity) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcereports_resourcereports_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceIntensity.ProtoReflect.Descriptor instead.
+func (*ResourceIntensity) Descriptor() ([]byte, []int) {
+	return file_resourcereports_resourcereports_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResourceIntensity) GetKind() uint32 {
+	if x != nil {
+		return x.Kind
+	}
+	return 0
+}
+
+func (x *ResourceIntensity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceIntensity) GetIntensity() uint64 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+// TransactionResourceReport is the breakdown of the resources metered while executing a transaction.
+//
+//	Node-local fields depend on the execution node, and may differ between the reports of different execution nodes.
+type TransactionResourceReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId          []byte               `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`                            // ID of the transaction
+	ComputationUsed        uint64               `protobuf:"varint,2,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`                     // total computation used, in computation units
+	ComputationIntensities []*ResourceIntensity `protobuf:"bytes,3,rep,name=computation_intensities,json=computationIntensities,proto3" json:"computation_intensities,omitempty"` // intensities per computation kind, sorted by kind
+	MemoryEstimate         uint64               `protobuf:"varint,4,opt,name=memory_estimate,json=memoryEstimate,proto3" json:"memory_estimate,omitempty"`                        // total estimated memory used, in bytes, node-local
+	MemoryIntensities      []*ResourceIntensity `protobuf:"bytes,5,rep,name=memory_intensities,json=memoryIntensities,proto3" json:"memory_intensities,omitempty"`                // intensities per memory kind, sorted by kind, node-local
+	BytesRead              uint64               `protobuf:"varint,6,opt,name=bytes_read,json=bytesRead,proto3" json:"bytes_read,omitempty"`                                       // size of the registers read from storage, node-local
+	BytesWritten           uint64               `protobuf:"varint,7,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`                              // size of the registers written to storage
+	RegistersTouched       uint64               `protobuf:"varint,8,opt,name=registers_touched,json=registersTouched,proto3" json:"registers_touched,omitempty"`                  // number of distinct registers read or written, node-local
+	RegistersUpdated       uint64               `protobuf:"varint,9,opt,name=registers_updated,json=registersUpdated,proto3" json:"registers_updated,omitempty"`                  // number of distinct registers written
+	EventBytes             uint64               `protobuf:"varint,10,opt,name=event_bytes,json=eventBytes,proto3" json:"event_bytes,omitempty"`                                   // size of the events emitted
+}
+
+func (x *TransactionResourceReport) Reset() {
+	*x = TransactionResourceReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resourcereports_resourcereports_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionResourceReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResourceReport) ProtoMessage() {}
+
+func (x *TransactionResourceReport) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcereports_resourcereports_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResourceReport.ProtoReflect.Descriptor instead.
+func (*TransactionResourceReport) Descriptor() ([]byte, []int) {
+	return file_resourcereports_resourcereports_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransactionResourceReport) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *TransactionResourceReport) GetComputationUsed() uint64 {
+	if x != nil {
+		return x.ComputationUsed
+	}
+	return 0
+}
+
+func (x *TransactionResourceReport) GetComputationIntensities() []*ResourceIntensity {
+	if x != nil {
+		return x.ComputationIntensities
+	}
+	return nil
+}
+
+func (x *TransactionResourceReport) GetMemoryEstimate() uint64 {
+	if x != nil {
+		return x.MemoryEstimate
+	}
+	return 0
+}
+
+func (x *TransactionResourceReport) GetMemoryIntensities() []*ResourceIntensity {
+	if x != nil {
+		return x.MemoryIntensities
+	}
+	return nil
+}
+
+func (x *TransactionResourceReport) GetBytesRead() uint64 {
+	if x != nil {
+		return x.BytesRead
+	}
+	return 0
+}
+
+func (x *TransactionResourceReport) GetBytesWritten() uint64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *TransactionResourceReport) GetRegistersTouched() uint64 {
+	if x != nil {
+		return x.RegistersTouched
+	}
+	return 0
+}
+
+func (x *TransactionResourceReport) GetRegistersUpdated() uint64 {
+	if x != nil {
+		return x.RegistersUpdated
+	}
+	return 0
+}
+
+func (x *TransactionResourceReport) GetEventBytes() uint64 {
+	if x != nil {
+		return x.EventBytes
+	}
+	return 0
+}
+
+// GetTransactionResourceReportRequest is the request for the resource report of a transaction.
+type GetTransactionResourceReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId       []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`                   // ID of the block the transaction was executed in
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // ID of the transaction
+}
+
+func (x *GetTransactionResourceReportRequest) Reset() {
+	*x = GetTransactionResourceReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resourcereports_resourcereports_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionResourceReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResourceReportRequest) ProtoMessage() {}
+
+func (x *GetTransactionResourceReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcereports_resourcereports_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResourceReportRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionResourceReportRequest) Descriptor() ([]byte, []int) {
+	return file_resourcereports_resourcereports_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTransactionResourceReportRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetTransactionResourceReportRequest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// GetTransactionResourceReportResponse contains the resource report of a transaction.
+type GetTransactionResourceReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte                     `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"` // ID of the block the transaction was executed in
+	Report  *TransactionResourceReport `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`                  // resource report of the transaction
+}
+
+func (x *GetTransactionResourceReportResponse) Reset() {
+	*x = GetTransactionResourceReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resourcereports_resourcereports_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionResourceReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResourceReportResponse) ProtoMessage() {}
+
+func (x *GetTransactionResourceReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcereports_resourcereports_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResourceReportResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResourceReportResponse) Descriptor() ([]byte, []int) {
+	return file_resourcereports_resourcereports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTransactionResourceReportResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetTransactionResourceReportResponse) GetReport() *TransactionResourceReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// GetTransactionResourceReportsByBlockIDRequest is the request for the resource reports of all transactions of a block.
+type GetTransactionResourceReportsByBlockIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"` // ID of the block
+}
+
+func (x *GetTransactionResourceReportsByBlockIDRequest) Reset() {
+	*x = GetTransactionResourceReportsByBlockIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resourcereports_resourcereports_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionResourceReportsByBlockIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResourceReportsByBlockIDRequest) ProtoMessage() {}
+
+func (x *GetTransactionResourceReportsByBlockIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcereports_resourcereports_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResourceReportsByBlockIDRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionResourceReportsByBlockIDRequest) Descriptor() ([]byte, []int) {
+	return file_resourcereports_resourcereports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTransactionResourceReportsByBlockIDRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+// GetTransactionResourceReportsByBlockIDResponse contains the resource reports of all transactions of a block.
+type GetTransactionResourceReportsByBlockIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte                       `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"` // ID of the block
+	Reports []*TransactionResourceReport `protobuf:"bytes,2,rep,name=reports,proto3" json:"reports,omitempty"`                // resource reports, ordered by transaction index
+}
+
+func (x *GetTransactionResourceReportsByBlockIDResponse) Reset() {
+	*x = GetTransactionResourceReportsByBlockIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resourcereports_resourcereports_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionResourceReportsByBlockIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResourceReportsByBlockIDResponse) ProtoMessage() {}
+
+func (x *GetTransactionResourceReportsByBlockIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcereports_resourcereports_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResourceReportsByBlockIDResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResourceReportsByBlockIDResponse) Descriptor() ([]byte, []int) {
+	return file_resourcereports_resourcereports_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTransactionResourceReportsByBlockIDResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetTransactionResourceReportsByBlockIDResponse) GetReports() []*TransactionResourceReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+var File_resourcereports_resourcereports_proto protoreflect.FileDescriptor
+
+var file_resourcereports_resourcereports_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x59, 0x0a,
+	0x11, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x22, 0x8f, 0x04, 0x0a, 0x19, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a,
+	0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x65, 0x64, 0x12, 0x60, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x74, 0x79, 0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x45, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x12, 0x56, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x52, 0x11, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x61, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12,
+	0x2b, 0x0a, 0x11, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x74, 0x6f, 0x75,
+	0x63, 0x68, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x67, 0x0a, 0x23, 0x47, 0x65,
+	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x24, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x47, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x22, 0x4a, 0x0a, 0x2d, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22, 0x96, 0x01, 0x0a,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x79,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x49, 0x0a, 0x07, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x32, 0xec, 0x02, 0x0a, 0x1c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x41, 0x50, 0x49, 0x12, 0x95, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x39, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xb3,
+	0x01, 0x0a, 0x26, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x43, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x79,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67,
+	0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f,
+	0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_resourcereports_resourcereports_proto_rawDescOnce sync.Once
+	file_resourcereports_resourcereports_proto_rawDescData = file_resourcereports_resourcereports_proto_rawDesc
+)
+
+func file_resourcereports_resourcereports_proto_rawDescGZIP() []byte {
+	file_resourcereports_resourcereports_proto_rawDescOnce.Do(func() {
+		file_resourcereports_resourcereports_proto_rawDescData = protoimpl.X.CompressGZIP(file_resourcereports_resourcereports_proto_rawDescData)
+	})
+	return file_resourcereports_resourcereports_proto_rawDescData
+}
+
+var file_resourcereports_resourcereports_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_resourcereports_resourcereports_proto_goTypes = []interface{}{
+	(*ResourceIntensity)(nil),                              // 0: flow.resourcereports.ResourceIntensity
+	(*TransactionResourceReport)(nil),                      // 1: flow.resourcereports.TransactionResourceReport
+	(*GetTransactionResourceReportRequest)(nil),            // 2: flow.resourcereports.GetTransactionResourceReportRequest
+	(*GetTransactionResourceReportResponse)(nil),           // 3: flow.resourcereports.GetTransactionResourceReportResponse
+	(*GetTransactionResourceReportsByBlockIDRequest)(nil),  // 4: flow.resourcereports.GetTransactionResourceReportsByBlockIDRequest
+	(*GetTransactionResourceReportsByBlockIDResponse)(nil), // 5: flow.resourcereports.GetTransactionResourceReportsByBlockIDResponse
+}
+var file_resourcereports_resourcereports_proto_depIdxs = []int32{
+	0, // 0: flow.resourcereports.TransactionResourceReport.computation_intensities:type_name -> flow.resourcereports.ResourceIntensity
+	0, // 1: flow.resourcereports.TransactionResourceReport.memory_intensities:type_name -> flow.resourcereports.ResourceIntensity
+	1, // 2: flow.resourcereports.GetTransactionResourceReportResponse.report:type_name -> flow.resourcereports.TransactionResourceReport
+	1, // 3: flow.resourcereports.GetTransactionResourceReportsByBlockIDResponse.reports:type_name -> flow.resourcereports.TransactionResourceReport
+	2, // 4: flow.resourcereports.TransactionResourceReportAPI.GetTransactionResourceReport:input_type -> flow.resourcereports.GetTransactionResourceReportRequest
+	4, // 5: flow.resourcereports.TransactionResourceReportAPI.GetTransactionResourceReportsByBlockID:input_type -> flow.resourcereports.GetTransactionResourceReportsByBlockIDRequest
+	3, // 6: flow.resourcereports.TransactionResourceReportAPI.GetTransactionResourceReport:output_type -> flow.resourcereports.GetTransactionResourceReportResponse
+	5, // 7: flow.resourcereports.TransactionResourceReportAPI.GetTransactionResourceReportsByBlockID:output_type -> flow.resourcereports.GetTransactionResourceReportsByBlockIDResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_resourcereports_resourcereports_proto_init() }
+func file_resourcereports_resourcereports_proto_init() {
+	if File_resourcereports_resourcereports_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_resourcereports_resourcereports_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceIntensity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_resourcereports_resourcereports_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionResourceReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_resourcereports_resourcereports_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionResourceReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_resourcereports_resourcereports_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionResourceReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_resourcereports_resourcereports_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionResourceReportsByBlockIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_resourcereports_resourcereports_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionResourceReportsByBlockIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_resourcereports_resourcereports_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_resourcereports_resourcereports_proto_goTypes,
+		DependencyIndexes: file_resourcereports_resourcereports_proto_depIdxs,
+		MessageInfos:      file_resourcereports_resourcereports_proto_msgTypes,
+	}.Build()
+	File_resourcereports_resourcereports_proto = out.File
+	file_resourcereports_resourcereports_proto_rawDesc = nil
+	file_resourcereports_resourcereports_proto_goTypes = nil
+	file_resourcereports_resourcereports_proto_depIdxs = nil
+}
@@ -23,6 +23,7 @@ type ApprovalCollector struct {
 	aggregatedSignatures *AggregatedSignatures           // aggregated signature for each chunk
 	seals                mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
 	numberOfChunks       uint64                          // number of chunks for execution result, remains constant
+	requiredApprovals    uint                            // number of approvals that are required for each chunk to be sealed
 }
 
 func NewApprovalCollector(
@@ -59,6 +60,7 @@ func NewApprovalCollector(
 		chunkCollectors:      chunkCollectors,
 		aggregatedSignatures: aggSigs,
 		seals:                seals,
+		requiredApprovals:    requiredApprovalsForSealConstruction,
 	}
 
 	// The following code implements a TEMPORARY SHORTCUT: In case no approvals are required
@@ -187,3 +189,28 @@ func (c *ApprovalCollector) CollectMissingVerifiers() map[uint64]flow.Identifier
 
 	return targetIDs
 }
+
+// EmergencySeal returns an audit record describing the chunks of the incorporated result that
+// have not collected the required number of approvals. It is intended to be persisted when the
+// result is sealed via emergency sealing.
+func (c *ApprovalCollector) EmergencySeal() *flow.EmergencySeal {
+	missingChunks := c.aggregatedSignatures.ChunksWithoutAggregatedSignature()
+	unapprovedChunks := make([]flow.EmergencySealChunk, 0, len(missingChunks))
+	for _, chunkIndex := range missingChunks {
+		collector := c.chunkCollectors[chunkIndex]
+		unapprovedChunks = append(unapprovedChunks, flow.EmergencySealChunk{
+			Index:             chunkIndex,
+			AssignedVerifiers: collector.GetAssignedVerifiers(),
+			ApprovedBy:        collector.GetSigners(),
+		})
+	}
+
+	return &flow.EmergencySeal{
+		ResultID:            c.incorporatedResult.Result.ID(),
+		BlockID:             c.incorporatedResult.Result.BlockID,
+		BlockHeight:         c.executedBlock.Height,
+		IncorporatedBlockID: c.IncorporatedBlockID(),
+		RequiredApprovals:   c.requiredApprovals,
+		UnapprovedChunks:    unapprovedChunks,
+	}
+}
@@ -28,6 +28,7 @@ type AssignmentCollectorBase struct {
 	headers                              storage.Headers                 // used to query headers from storage
 	sigHasher                            hash.Hasher                     // used to verify result approval signatures
 	seals                                mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
+	emergencySeals                       *EmergencySealRecords           // keeps audit records for seals constructed by emergency sealing
 	approvalConduit                      network.Conduit                 // used to request missing approvals from verification nodes
 	requestTracker                       *RequestTracker                 // used to keep track of number of approval requests, and blackout periods, by chunk
 	requiredApprovalsForSealConstruction uint                            // number of approvals that are required for each chunk to be sealed
@@ -44,6 +45,7 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 	headers storage.Headers,
 	assigner module.ChunkAssigner,
 	seals mempool.IncorporatedResultSeals,
+	emergencySeals *EmergencySealRecords,
 	sigHasher hash.Hasher,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
@@ -62,6 +64,7 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 		headers:                              headers,
 		sigHasher:                            sigHasher,
 		seals:                                seals,
+		emergencySeals:                       emergencySeals,
 		approvalConduit:                      approvalConduit,
 		requestTracker:                       requestTracker,
 		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
@@ -53,3 +53,22 @@ func (c *ChunkApprovalCollector) GetMissingSigners() flow.IdentifierList {
 
 	return result
 }
+
+// GetAssignedVerifiers returns ids of all verifiers that are assigned to the chunk, in canonical order
+func (c *ChunkApprovalCollector) GetAssignedVerifiers() flow.IdentifierList {
+	result := make(flow.IdentifierList, 0, len(c.assignment))
+	for id := range c.assignment {
+		result = append(result, id)
+	}
+	return result.Sort(flow.IdentifierCanonical)
+}
+
+// GetSigners returns ids of assigned verifiers that have provided an approval, in canonical order
+func (c *ChunkApprovalCollector) GetSigners() flow.IdentifierList {
+	c.lock.Lock()
+	result := make(flow.IdentifierList, len(c.chunkApprovals.signerIDs))
+	copy(result, c.chunkApprovals.signerIDs)
+	c.lock.Unlock()
+
+	return result.Sort(flow.IdentifierCanonical)
+}
@@ -0,0 +1,135 @@
+package approvals
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// EmergencySealRecords keeps the audit records of candidate emergency seals, keyed by the ID of the sealed result,
+// until the executed block is sealed by a finalized seal. Candidate seals are only added to the seals mempool, and
+// may never be finalized, for example because they were included in an orphaned fork, or because a regular seal
+// for the result was finalized instead. Therefore, only the records of emergency seals which were finalized are
+// persisted, with the approvals of the finalized seal.
+//
+// Candidate records are only kept in memory. After a restart, the record of a candidate, which was constructed
+// before the restart, is only persisted if the result qualifies for emergency sealing again.
+//
+// Concurrency safe.
+type EmergencySealRecords struct {
+	log            zerolog.Logger
+	emergencySeals storage.EmergencySeals // persists the records of finalized emergency seals
+	sealsDB        storage.Seals          // used to look up the finalized seals of executed blocks
+
+	lock       sync.Mutex
+	candidates map[flow.Identifier]*flow.EmergencySeal // records of candidate emergency seals, keyed by result ID
+}
+
+func NewEmergencySealRecords(log zerolog.Logger, emergencySeals storage.EmergencySeals, sealsDB storage.Seals) *EmergencySealRecords {
+	return &EmergencySealRecords{
+		log:            log.With().Str("component", "emergency_seal_records").Logger(),
+		emergencySeals: emergencySeals,
+		sealsDB:        sealsDB,
+		candidates:     make(map[flow.Identifier]*flow.EmergencySeal),
+	}
+}
+
+// Add keeps the record of a candidate emergency seal until the executed block is sealed. Emergency sealing is
+// re-checked on every finalized block until the result is sealed, hence at most one record is kept per result,
+// even if the result is incorporated in multiple forks.
+func (r *EmergencySealRecords) Add(record *flow.EmergencySeal) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.candidates[record.ResultID]; !ok {
+		r.candidates[record.ResultID] = record
+	}
+}
+
+// Size returns the number of candidate records, whose executed blocks are not sealed yet.
+func (r *EmergencySealRecords) Size() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.candidates)
+}
+
+// OnBlockFinalized reconciles the candidate records with the finalized seals: the record of a candidate whose
+// executed block was sealed is removed, and persisted if the finalized seal seals the same result with fewer
+// than the required number of approvals for any chunk.
+// No errors are expected during normal operation.
+func (r *EmergencySealRecords) OnBlockFinalized() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for resultID, candidate := range r.candidates {
+		seal, err := r.sealsDB.FinalizedSealForBlock(candidate.BlockID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				// the executed block is not sealed yet
+				continue
+			}
+			return fmt.Errorf("could not retrieve finalized seal for block %v: %w", candidate.BlockID, err)
+		}
+		delete(r.candidates, resultID)
+
+		if seal.ResultID != resultID {
+			// the block was sealed with a different result
+			continue
+		}
+		record := finalizedEmergencySeal(candidate, seal)
+		if record == nil {
+			// the finalized seal has the required number of approvals for every chunk
+			continue
+		}
+
+		err = r.emergencySeals.Store(record)
+		if err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				continue
+			}
+			return fmt.Errorf("could not store emergency seal record for result %v: %w", resultID, err)
+		}
+		r.log.Warn().
+			Str("result_id", record.ResultID.String()).
+			Str("block_id", record.BlockID.String()).
+			Uint64("block_height", record.BlockHeight).
+			Int("unapproved_chunks", len(record.UnapprovedChunks)).
+			Msg("stored audit record for finalized emergency seal")
+	}
+
+	return nil
+}
+
+// finalizedEmergencySeal returns the record of the given candidate emergency seal, with the approvals of the given
+// finalized seal for the same result, or nil if the finalized seal has the required number of approvals for every
+// chunk which was unapproved in the candidate seal.
+func finalizedEmergencySeal(candidate *flow.EmergencySeal, seal *flow.Seal) *flow.EmergencySeal {
+	unapprovedChunks := make([]flow.EmergencySealChunk, 0, len(candidate.UnapprovedChunks))
+	for _, chunk := range candidate.UnapprovedChunks {
+		approvedBy := flow.IdentifierList{}
+		if chunk.Index < uint64(len(seal.AggregatedApprovalSigs)) {
+			approvedBy = flow.IdentifierList(seal.AggregatedApprovalSigs[chunk.Index].SignerIDs).Sort(flow.IdentifierCanonical)
+		}
+		if uint(len(approvedBy)) >= candidate.RequiredApprovals {
+			continue
+		}
+		unapprovedChunks = append(unapprovedChunks, flow.EmergencySealChunk{
+			Index:             chunk.Index,
+			AssignedVerifiers: chunk.AssignedVerifiers,
+			ApprovedBy:        approvedBy,
+		})
+	}
+	if len(unapprovedChunks) == 0 {
+		return nil
+	}
+
+	record := *candidate
+	record.UnapprovedChunks = unapprovedChunks
+	return &record
+}
@@ -0,0 +1,87 @@
+package approvals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	realstorage "github.com/onflow/flow-go/storage"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEmergencySealRecords tests that the records of candidate emergency seals are only persisted once the
+// executed block is sealed by a finalized emergency seal for the same result.
+func TestEmergencySealRecords(t *testing.T) {
+	emergencySeals := storage.NewEmergencySeals(t)
+	sealsDB := storage.NewSeals(t)
+	records := NewEmergencySealRecords(unittest.Logger(), emergencySeals, sealsDB)
+
+	// candidateRecord returns the record of a candidate seal for a result with 3 chunks, of which chunks 1 and 2
+	// have not collected the required 2 approvals
+	verifiers := unittest.IdentifierListFixture(3)
+	candidateRecord := func() (*flow.EmergencySeal, *flow.ExecutionResult) {
+		result := unittest.ExecutionResultFixture(unittest.WithChunks(3))
+		return &flow.EmergencySeal{
+			ResultID:            result.ID(),
+			BlockID:             result.BlockID,
+			BlockHeight:         10,
+			IncorporatedBlockID: unittest.IdentifierFixture(),
+			RequiredApprovals:   2,
+			UnapprovedChunks: []flow.EmergencySealChunk{
+				{Index: 1, AssignedVerifiers: verifiers, ApprovedBy: verifiers[:1]},
+				{Index: 2, AssignedVerifiers: verifiers, ApprovedBy: flow.IdentifierList{}},
+			},
+		}, result
+	}
+	// sealWithApprovals returns a seal for the given result, with the given number of approvals for each chunk
+	sealWithApprovals := func(result *flow.ExecutionResult, approvals ...int) *flow.Seal {
+		seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+		seal.AggregatedApprovalSigs = make([]flow.AggregatedSignature, len(approvals))
+		for i, n := range approvals {
+			seal.AggregatedApprovalSigs[i].SignerIDs = verifiers[:n]
+		}
+		return seal
+	}
+
+	unsealed, _ := candidateRecord()
+	orphaned, _ := candidateRecord()
+	regular, regularResult := candidateRecord()
+	emergency, emergencyResult := candidateRecord()
+	for _, record := range []*flow.EmergencySeal{unsealed, orphaned, regular, emergency} {
+		records.Add(record)
+	}
+	// records of results which already have a candidate are ignored
+	duplicate := *emergency
+	duplicate.IncorporatedBlockID = unittest.IdentifierFixture()
+	records.Add(&duplicate)
+	require.Equal(t, 4, records.Size())
+
+	// the block of the orphaned candidate was sealed with a different result
+	sealsDB.On("FinalizedSealForBlock", unsealed.BlockID).Return(nil, realstorage.ErrNotFound)
+	sealsDB.On("FinalizedSealForBlock", orphaned.BlockID).Return(unittest.Seal.Fixture(unittest.Seal.WithBlockID(orphaned.BlockID)), nil).Once()
+	sealsDB.On("FinalizedSealForBlock", regular.BlockID).Return(sealWithApprovals(regularResult, 2, 2, 3), nil).Once()
+	sealsDB.On("FinalizedSealForBlock", emergency.BlockID).Return(sealWithApprovals(emergencyResult, 0, 2, 1), nil).Once()
+
+	// only the chunk which is unapproved in the finalized seal is recorded, with the approvals of the finalized seal
+	emergencySeals.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+		record := args.Get(0).(*flow.EmergencySeal)
+		require.Equal(t, emergency.ResultID, record.ResultID)
+		require.Equal(t, emergency.IncorporatedBlockID, record.IncorporatedBlockID)
+		require.Equal(t, []flow.EmergencySealChunk{
+			{Index: 2, AssignedVerifiers: verifiers, ApprovedBy: flow.IdentifierList(verifiers[:1]).Sort(flow.IdentifierCanonical)},
+		}, record.UnapprovedChunks)
+	}).Return(nil).Once()
+
+	require.NoError(t, records.OnBlockFinalized())
+	require.Equal(t, 1, records.Size())
+
+	// records which have already been stored are tolerated
+	records.Add(emergency)
+	sealsDB.On("FinalizedSealForBlock", emergency.BlockID).Return(sealWithApprovals(emergencyResult, 0, 0, 0), nil).Once()
+	emergencySeals.On("Store", mock.Anything).Return(realstorage.ErrAlreadyExists).Once()
+	require.NoError(t, records.OnBlockFinalized())
+	require.Equal(t, 1, records.Size())
+}
@@ -87,6 +87,9 @@ type BaseAssignmentCollectorTestSuite struct {
 	Headers           *storage.Headers
 	Assigner          *module.ChunkAssigner
 	SealsPL           *mempool.IncorporatedResultSeals
+	EmergencySeals    *storage.EmergencySeals
+	SealsDB           *storage.Seals
+	EmergencyRecords  *EmergencySealRecords
 	Conduit           *mocknetwork.Conduit
 	FinalizedAtHeight map[uint64]*flow.Header
 	IdentitiesCache   map[flow.Identifier]map[flow.Identifier]*flow.Identity // helper map to store identities for given block
@@ -98,6 +101,9 @@ func (s *BaseAssignmentCollectorTestSuite) SetupTest() {
 
 	s.WorkerPool = workerpool.New(4)
 	s.SealsPL = &mempool.IncorporatedResultSeals{}
+	s.EmergencySeals = &storage.EmergencySeals{}
+	s.SealsDB = &storage.Seals{}
+	s.EmergencyRecords = NewEmergencySealRecords(unittest.Logger(), s.EmergencySeals, s.SealsDB)
 	s.State = &protocol.State{}
 	s.Assigner = &module.ChunkAssigner{}
 	s.Conduit = &mocknetwork.Conduit{}
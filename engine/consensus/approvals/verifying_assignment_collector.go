@@ -103,6 +103,8 @@ func (ac *VerifyingAssignmentCollector) CheckEmergencySealing(observer consensus
 				return fmt.Errorf("could not create emergency seal for result %x incorporated at %x: %w",
 					ac.ResultID(), collector.IncorporatedBlockID(), err)
 			}
+			// the record is only persisted once the seal is finalized
+			ac.emergencySeals.Add(collector.EmergencySeal())
 		}
 	}
 
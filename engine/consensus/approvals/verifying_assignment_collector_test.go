@@ -47,12 +47,13 @@ func newVerifyingAssignmentCollector(logger zerolog.Logger,
 	headers realstorage.Headers,
 	assigner realmodule.ChunkAssigner,
 	seals realmempool.IncorporatedResultSeals,
+	emergencySeals *EmergencySealRecords,
 	sigHasher hash.Hasher,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
 	requiredApprovalsForSealConstruction uint,
 ) (*VerifyingAssignmentCollector, error) {
-	b, err := NewAssignmentCollectorBase(logger, workerPool, result, state, headers, assigner, seals, sigHasher,
+	b, err := NewAssignmentCollectorBase(logger, workerPool, result, state, headers, assigner, seals, emergencySeals, sigHasher,
 		approvalConduit, requestTracker, requiredApprovalsForSealConstruction)
 	if err != nil {
 		return nil, err
@@ -70,7 +71,7 @@ func (s *AssignmentCollectorTestSuite) SetupTest() {
 
 	var err error
 	s.collector, err = newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
-		s.Assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, uint(len(s.AuthorizedVerifiers)))
+		s.Assigner, s.SealsPL, s.EmergencyRecords, s.SigHasher, s.Conduit, s.RequestTracker, uint(len(s.AuthorizedVerifiers)))
 	require.NoError(s.T(), err)
 }
 
@@ -208,7 +209,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult() {
 		assigner.On("Assign", mock.Anything, mock.Anything).Return(nil, fmt.Errorf(""))
 
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
-			assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			assigner, s.SealsPL, s.EmergencyRecords, s.SigHasher, s.Conduit, s.RequestTracker, 1)
 		require.NoError(s.T(), err)
 
 		err = collector.ProcessIncorporatedResult(s.IncorporatedResult)
@@ -220,7 +221,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult() {
 		delete(s.IdentitiesCache, s.IncorporatedResult.Result.BlockID)
 		s.Snapshots[s.IncorporatedResult.Result.BlockID] = unittest.StateSnapshotForKnownBlock(s.Block, nil)
 		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
-			s.Assigner, s.SealsPL, s.SigHasher, s.Conduit, s.RequestTracker, 1)
+			s.Assigner, s.SealsPL, s.EmergencyRecords, s.SigHasher, s.Conduit, s.RequestTracker, 1)
 		require.Error(s.T(), err)
 		require.Nil(s.T(), collector)
 	})
@@ -242,7 +243,7 @@ func (s *AssignmentCollectorTestSuite) TestProcessIncorporatedResult_InvalidIden
 			},
 		)
 
-		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, state, s.Headers, s.Assigner, s.SealsPL,
+		collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, state, s.Headers, s.Assigner, s.SealsPL, s.EmergencyRecords,
 			s.SigHasher, s.Conduit, s.RequestTracker, 1)
 		require.Error(s.T(), err)
 		require.Nil(s.T(), collector)
@@ -399,6 +400,28 @@ func (s *AssignmentCollectorTestSuite) TestCheckEmergencySealing() {
 	err = s.collector.CheckEmergencySealing(&tracker.NoopSealingTracker{}, DefaultEmergencySealingThresholdForFinalization+s.IncorporatedBlock.Height)
 	require.NoError(s.T(), err)
 
+	// the audit record of the candidate seal should list every chunk as unapproved, together with its assigned verifiers
+	require.Len(s.T(), s.EmergencyRecords.candidates, 1)
+	record := s.EmergencyRecords.candidates[s.IncorporatedResult.Result.ID()]
+	require.NotNil(s.T(), record)
+	require.Equal(s.T(), s.Block.ID(), record.BlockID)
+	require.Equal(s.T(), s.Block.Height, record.BlockHeight)
+	require.Equal(s.T(), s.IncorporatedBlock.ID(), record.IncorporatedBlockID)
+	require.Equal(s.T(), uint(len(s.AuthorizedVerifiers)), record.RequiredApprovals)
+	require.Len(s.T(), record.UnapprovedChunks, s.IncorporatedResult.Result.Chunks.Len())
+	for i, chunk := range record.UnapprovedChunks {
+		require.Equal(s.T(), uint64(i), chunk.Index)
+		require.ElementsMatch(s.T(), s.ChunksAssignment.Verifiers(s.IncorporatedResult.Result.Chunks[i]), chunk.AssignedVerifiers)
+		require.Empty(s.T(), chunk.ApprovedBy)
+	}
+
+	// repeated checks keep the first record of the result
+	s.SealsPL.On("Add", mock.Anything).Return(false, nil).Once()
+	err = s.collector.CheckEmergencySealing(&tracker.NoopSealingTracker{}, DefaultEmergencySealingThresholdForFinalization+s.IncorporatedBlock.Height+1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, s.EmergencyRecords.Size())
+	require.Same(s.T(), record, s.EmergencyRecords.candidates[s.IncorporatedResult.Result.ID()])
+
 	s.SealsPL.AssertExpectations(s.T())
 }
 
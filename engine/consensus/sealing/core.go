@@ -50,6 +50,7 @@ type Core struct {
 	sealingTracker             consensus.SealingTracker           // logic-aware component for tracking sealing progress.
 	tracer                     module.Tracer                      // used to trace execution
 	sealingConfigsGetter       module.SealingConfigsGetter        // used to access configs for sealing conditions
+	emergencySealRecords       *approvals.EmergencySealRecords    // keeps audit records of emergency seals until they are finalized
 }
 
 func NewCore(
@@ -62,6 +63,7 @@ func NewCore(
 	headers storage.Headers,
 	state protocol.State,
 	sealsDB storage.Seals,
+	emergencySeals storage.EmergencySeals,
 	assigner module.ChunkAssigner,
 	signatureHasher hash.Hasher,
 	sealsMempool mempool.IncorporatedResultSeals,
@@ -89,12 +91,13 @@ func NewCore(
 		sealsMempool:               sealsMempool,
 		requestTracker:             approvals.NewRequestTracker(headers, 10, 30),
 		sealingConfigsGetter:       sealingConfigsGetter,
+		emergencySealRecords:       approvals.NewEmergencySealRecords(log, emergencySeals, sealsDB),
 	}
 
 	factoryMethod := func(result *flow.ExecutionResult) (approvals.AssignmentCollector, error) {
 		requiredApprovalsForSealConstruction := sealingConfigsGetter.RequireApprovalsForSealConstructionDynamicValue()
 		base, err := approvals.NewAssignmentCollectorBase(core.log, core.workerPool, result, core.state, core.headers,
-			assigner, sealsMempool, signatureHasher,
+			assigner, sealsMempool, core.emergencySealRecords, signatureHasher,
 			approvalConduit, core.requestTracker, requiredApprovalsForSealConstruction)
 		if err != nil {
 			return nil, fmt.Errorf("could not create base collector: %w", err)
@@ -534,6 +537,12 @@ func (c *Core) ProcessFinalizedBlock(finalizedBlockID flow.Identifier) error {
 		return fmt.Errorf("updating to finalized block %v and sealed block %v failed: %w", finalizedBlockID, lastBlockWithFinalizedSeal.ID(), err)
 	}
 
+	// persist the audit records of emergency seals, which have been finalized
+	err = c.emergencySealRecords.OnBlockFinalized()
+	if err != nil {
+		return fmt.Errorf("could not persist emergency seal records at block %v: %w", finalizedBlockID, err)
+	}
+
 	// STEP 2: Check emergency sealing and re-request missing approvals
 	// ------------------------------------------------------------------------
 	sealingObservation := c.sealingTracker.NewSealingObservation(finalized, finalizedSeal, lastBlockWithFinalizedSeal)
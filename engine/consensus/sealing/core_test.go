@@ -20,6 +20,7 @@ import (
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/module/updatable_configs"
 	mockstate "github.com/onflow/flow-go/state/protocol/mock"
+	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -70,7 +71,7 @@ func (s *ApprovalProcessingCoreTestSuite) SetupTest() {
 
 	setter := unittest.NewSealingConfigs(flow.DefaultChunkAssignmentAlpha)
 	var err error
-	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.Assigner, s.SigHasher, s.SealsPL, s.Conduit, setter)
+	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.EmergencySeals, s.Assigner, s.SigHasher, s.SealsPL, s.Conduit, setter)
 	require.NoError(s.T(), err)
 	s.setter = setter
 }
@@ -327,7 +328,7 @@ func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealing(
 		true, // enable emergency sealing
 	)
 	require.NoError(s.T(), err)
-	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.Assigner, s.SigHasher, s.SealsPL, s.Conduit, setter)
+	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.EmergencySeals, s.Assigner, s.SigHasher, s.SealsPL, s.Conduit, setter)
 	require.NoError(s.T(), err)
 	s.setter = setter
 
@@ -340,8 +341,18 @@ func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealing(
 		},
 	).Return(true, nil).Once()
 
+	// the emergency seal is only finalized after the sealing halt is resolved
+	var finalizedEmergencySeal *flow.Seal
+	s.sealsDB.On("FinalizedSealForBlock", s.Block.ID()).Return(
+		func(flow.Identifier) (*flow.Seal, error) {
+			if finalizedEmergencySeal == nil {
+				return nil, realstorage.ErrNotFound
+			}
+			return finalizedEmergencySeal, nil
+		})
+
 	seal := unittest.Seal.Fixture(unittest.Seal.WithBlock(s.ParentBlock))
-	s.sealsDB.On("HighestInFork", mock.Anything).Return(seal, nil).Times(approvals.DefaultEmergencySealingThresholdForFinalization)
+	s.sealsDB.On("HighestInFork", mock.Anything).Return(seal, nil).Times(approvals.DefaultEmergencySealingThresholdForFinalization + 1)
 	s.State.On("Sealed").Return(unittest.StateSnapshotForKnownBlock(s.ParentBlock, nil))
 
 	err = s.core.ProcessIncorporatedResult(s.IncorporatedResult)
@@ -358,7 +369,32 @@ func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealing(
 		lastFinalizedBlock = finalizedBlock
 	}
 
+	// the audit record of the candidate emergency seal is not persisted before the seal is finalized
+	s.EmergencySeals.AssertNotCalled(s.T(), "Store", mock.Anything)
+	require.Equal(s.T(), 1, s.core.emergencySealRecords.Size())
+
+	finalizedEmergencySeal = unittest.Seal.Fixture(
+		unittest.Seal.WithBlock(s.Block),
+		unittest.Seal.WithResult(s.IncorporatedResult.Result),
+	)
+	finalizedEmergencySeal.AggregatedApprovalSigs = nil
+	s.EmergencySeals.On("Store", mock.Anything).Run(
+		func(args mock.Arguments) {
+			record := args.Get(0).(*flow.EmergencySeal)
+			require.Equal(s.T(), s.IncorporatedResult.Result.ID(), record.ResultID)
+			require.Len(s.T(), record.UnapprovedChunks, s.IncorporatedResult.Result.Chunks.Len())
+		},
+	).Return(nil).Once()
+	s.SealsPL.On("Add", mock.Anything).Return(false, nil)
+
+	finalizedBlock := unittest.BlockHeaderWithParentFixture(lastFinalizedBlock)
+	s.Blocks[finalizedBlock.ID()] = finalizedBlock
+	s.MarkFinalized(finalizedBlock)
+	err = s.core.ProcessFinalizedBlock(finalizedBlock.ID())
+	require.NoError(s.T(), err)
+
 	s.SealsPL.AssertExpectations(s.T())
+	s.EmergencySeals.AssertExpectations(s.T())
 }
 
 // TestOnBlockFinalized_ProcessingOrphanApprovals tests that approvals for orphan forks are rejected as outdated entries without processing
@@ -748,7 +784,7 @@ func (s *ApprovalProcessingCoreTestSuite) TestRepopulateAssignmentCollectorTree(
 	s.State.On("Final").Return(finalSnapShot)
 
 	core, err := NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(),
-		s.Headers, s.State, s.sealsDB, assigner, s.SigHasher, s.SealsPL, s.Conduit, s.setter)
+		s.Headers, s.State, s.sealsDB, s.EmergencySeals, assigner, s.SigHasher, s.SealsPL, s.Conduit, s.setter)
 	require.NoError(s.T(), err)
 
 	err = core.RepopulateAssignmentCollectorTree(payloads)
@@ -827,7 +863,7 @@ func (s *ApprovalProcessingCoreTestSuite) TestRepopulateAssignmentCollectorTree_
 	s.State.On("Final").Return(finalSnapShot)
 
 	core, err := NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(),
-		s.Headers, s.State, s.sealsDB, assigner, s.SigHasher, s.SealsPL, s.Conduit, s.setter)
+		s.Headers, s.State, s.sealsDB, s.EmergencySeals, assigner, s.SigHasher, s.SealsPL, s.Conduit, s.setter)
 	require.NoError(s.T(), err)
 
 	err = core.RepopulateAssignmentCollectorTree(payloads)
@@ -100,6 +100,7 @@ func NewEngine(log zerolog.Logger,
 	index storage.Index,
 	state protocol.State,
 	sealsDB storage.Seals,
+	emergencySeals storage.EmergencySeals,
 	assigner module.ChunkAssigner,
 	sealsMempool mempool.IncorporatedResultSeals,
 	requiredApprovalsForSealConstructionGetter module.SealingConfigsGetter,
@@ -144,7 +145,7 @@ func NewEngine(log zerolog.Logger,
 	}
 
 	signatureHasher := msig.NewBLSHasher(msig.ResultApprovalTag)
-	core, err := NewCore(log, e.workerPool, tracer, conMetrics, sealingTracker, unit, headers, state, sealsDB, assigner, signatureHasher, sealsMempool, approvalConduit, requiredApprovalsForSealConstructionGetter)
+	core, err := NewCore(log, e.workerPool, tracer, conMetrics, sealingTracker, unit, headers, state, sealsDB, emergencySeals, assigner, signatureHasher, sealsMempool, approvalConduit, requiredApprovalsForSealConstructionGetter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init sealing engine: %w", err)
 	}
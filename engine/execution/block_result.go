@@ -77,6 +77,16 @@ func (er *BlockExecutionResult) AllTransactionResults() flow.TransactionResults
 	return res
 }
 
+// AllTransactionResourceReports returns the resource reports of all executed transactions, ordered by
+// transaction index.
+func (er *BlockExecutionResult) AllTransactionResourceReports() []flow.TransactionResourceReport {
+	res := make([]flow.TransactionResourceReport, 0)
+	for _, ce := range er.collectionExecutionResults {
+		res = append(res, ce.resourceReports...)
+	}
+	return res
+}
+
 func (er *BlockExecutionResult) AllExecutionSnapshots() []*snapshot.ExecutionSnapshot {
 	res := make([]*snapshot.ExecutionSnapshot, 0)
 	for _, ce := range er.collectionExecutionResults {
@@ -12,6 +12,7 @@ type CollectionExecutionResult struct {
 	serviceEvents          flow.EventsList
 	convertedServiceEvents flow.ServiceEventList
 	transactionResults     flow.TransactionResults
+	resourceReports        []flow.TransactionResourceReport
 	executionSnapshot      *snapshot.ExecutionSnapshot
 }
 
@@ -22,6 +23,7 @@ func NewEmptyCollectionExecutionResult() *CollectionExecutionResult {
 		serviceEvents:          make(flow.EventsList, 0),
 		convertedServiceEvents: make(flow.ServiceEventList, 0),
 		transactionResults:     make(flow.TransactionResults, 0),
+		resourceReports:        make([]flow.TransactionResourceReport, 0),
 	}
 }
 
@@ -37,6 +39,11 @@ func (c *CollectionExecutionResult) AppendTransactionResults(
 	c.transactionResults = append(c.transactionResults, transactionResult)
 }
 
+// AppendTransactionResourceReport appends the resource report of the next executed transaction.
+func (c *CollectionExecutionResult) AppendTransactionResourceReport(report flow.TransactionResourceReport) {
+	c.resourceReports = append(c.resourceReports, report)
+}
+
 func (c *CollectionExecutionResult) UpdateExecutionSnapshot(
 	executionSnapshot *snapshot.ExecutionSnapshot,
 ) {
@@ -63,6 +70,12 @@ func (c *CollectionExecutionResult) TransactionResults() flow.TransactionResults
 	return c.transactionResults
 }
 
+// TransactionResourceReports returns the resource reports of the executed transactions, ordered by
+// transaction index.
+func (c *CollectionExecutionResult) TransactionResourceReports() []flow.TransactionResourceReport {
+	return c.resourceReports
+}
+
 // CollectionAttestationResult holds attestations generated during post-processing
 // phase of collect execution.
 type CollectionAttestationResult struct {
@@ -1361,9 +1361,35 @@ func Test_ExecutingSystemCollection(t *testing.T) {
 
 	assert.Empty(t, result.AllTransactionResults()[0].ErrorMessage)
 
+	// the resource report of the system transaction contains the full meter breakdown
+	reports := result.AllTransactionResourceReports()
+	require.Len(t, reports, 1)
+	report := reports[0]
+	txResult := result.AllTransactionResults()[0]
+	assert.Equal(t, txResult.TransactionID, report.TransactionID)
+	assert.Equal(t, txResult.ComputationUsed, report.ComputationUsed)
+	assert.Equal(t, txResult.MemoryUsed, report.MemoryEstimate)
+	assert.NotEmpty(t, report.ComputationIntensities)
+	assert.NotEmpty(t, report.MemoryIntensities)
+	assert.IsIncreasing(t, intensityKinds(report.ComputationIntensities))
+	assert.IsIncreasing(t, intensityKinds(report.MemoryIntensities))
+	assert.Positive(t, report.BytesRead)
+	assert.Positive(t, report.BytesWritten)
+	assert.Positive(t, report.RegistersTouched)
+	assert.Positive(t, report.RegistersUpdated)
+	assert.GreaterOrEqual(t, report.EventBytes, uint64(expectedMinEventSize))
+
 	committer.AssertExpectations(t)
 }
 
+func intensityKinds(intensities []flow.ResourceIntensity) []uint {
+	kinds := make([]uint, len(intensities))
+	for i, intensity := range intensities {
+		kinds[i] = intensity.Kind
+	}
+	return kinds
+}
+
 func generateBlock(
 	collectionCount, transactionCount int,
 	addressGenerator flow.AddressGenerator,
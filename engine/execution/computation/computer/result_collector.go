@@ -273,14 +273,15 @@ func (collector *resultCollector) processTransactionResult(
 		txnResult.ErrorMessage = output.Err.Error()
 	}
 
-	collector.result.
-		CollectionExecutionResultAt(txn.collectionIndex).
-		AppendTransactionResults(
-			output.Events,
-			output.ServiceEvents,
-			output.ConvertedServiceEvents,
-			txnResult,
-		)
+	collectionResult := collector.result.CollectionExecutionResultAt(txn.collectionIndex)
+	collectionResult.AppendTransactionResults(
+		output.Events,
+		output.ServiceEvents,
+		output.ConvertedServiceEvents,
+		txnResult,
+	)
+	collectionResult.AppendTransactionResourceReport(
+		newTransactionResourceReport(txn.ID, output, txnExecutionSnapshot))
 
 	err := collector.currentCollectionState.Merge(txnExecutionSnapshot)
 	if err != nil {
@@ -297,6 +298,31 @@ func (collector *resultCollector) processTransactionResult(
 		collector.currentCollectionState.Finalize())
 }
 
+// newTransactionResourceReport returns the resource report of a transaction, built from its output and the
+// meter of its execution snapshot.
+func newTransactionResourceReport(
+	txID flow.Identifier,
+	output fvm.ProcedureOutput,
+	txnExecutionSnapshot *snapshot.ExecutionSnapshot,
+) flow.TransactionResourceReport {
+	report := flow.TransactionResourceReport{
+		TransactionID:          txID,
+		ComputationUsed:        output.ComputationUsed,
+		ComputationIntensities: flow.NewResourceIntensities(output.ComputationIntensities),
+		MemoryEstimate:         output.MemoryEstimate,
+		MemoryIntensities:      []flow.ResourceIntensity{},
+		RegistersTouched:       uint64(len(txnExecutionSnapshot.AllRegisterIDs())),
+		RegistersUpdated:       uint64(len(txnExecutionSnapshot.WriteSet)),
+	}
+	if txnExecutionSnapshot.Meter != nil {
+		report.MemoryIntensities = flow.NewResourceIntensities(txnExecutionSnapshot.MemoryIntensities())
+		report.BytesRead = txnExecutionSnapshot.TotalBytesReadFromStorage()
+		report.BytesWritten = txnExecutionSnapshot.TotalBytesWrittenToStorage()
+		report.EventBytes = txnExecutionSnapshot.TotalEmittedEventBytes()
+	}
+	return report
+}
+
 func (collector *resultCollector) handleTransactionExecutionMetrics(
 	timeSpent time.Duration,
 	output fvm.ProcedureOutput,
@@ -158,6 +158,11 @@ func (e *Manager) VM() fvm.VM {
 	return e.vm
 }
 
+// DerivedChainData returns the derived data cache used to execute blocks.
+func (e *Manager) DerivedChainData() *derived.DerivedChainData {
+	return e.derivedChainData
+}
+
 func (e *Manager) ComputeBlock(
 	ctx context.Context,
 	parentBlockExecutionResultID flow.Identifier,
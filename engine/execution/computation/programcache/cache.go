@@ -0,0 +1,224 @@
+package programcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence/common"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/derived"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// DefaultPersistInterval is the default interval between two persistences of the cached programs.
+const DefaultPersistInterval = 10 * time.Minute
+
+// LatestState returns the header of the latest block whose programs are cached in the DerivedChainData, and a
+// snapshot of the state at the end of the block.
+type LatestState func() (*flow.Header, snapshot.StorageSnapshot, error)
+
+// Cache persists the Cadence programs cached by a DerivedChainData, so that they don't have to be parsed and
+// checked again while executing the first blocks after a restart.
+//
+// The programs cached for the latest block are persisted periodically while the cache runs. Cadence programs can't
+// be serialized, so the persisted entries only record the programs and the versions of the contract code they were
+// derived from. WarmUp derives the persisted programs which are still valid against the latest state again, by
+// executing a transaction importing each of them, into the derived data of the latest block. The outdated programs
+// are skipped.
+//
+// The cache warms up when it is started, and is ready once the warm-up completed, so that components started after
+// the cache execute blocks on top of the latest block with derived data inheriting the warmed up programs.
+type Cache struct {
+	component.Component
+
+	log              zerolog.Logger
+	metrics          module.ProgramCacheMetrics
+	store            *Store
+	vm               fvm.VM
+	vmCtx            fvm.Context
+	derivedChainData *derived.DerivedChainData
+	latestState      LatestState
+	persistInterval  time.Duration
+}
+
+// NewCache creates a new Cache persisting the programs of the given DerivedChainData in the given store. The
+// programs are derived with the given VM and context, which must be the ones used to execute the blocks.
+func NewCache(
+	log zerolog.Logger,
+	metrics module.ProgramCacheMetrics,
+	store *Store,
+	vm fvm.VM,
+	vmCtx fvm.Context,
+	derivedChainData *derived.DerivedChainData,
+	latestState LatestState,
+	persistInterval time.Duration,
+) *Cache {
+	c := &Cache{
+		log:              log.With().Str("component", "program_cache").Logger(),
+		metrics:          metrics,
+		store:            store,
+		vm:               vm,
+		vmCtx:            vmCtx,
+		derivedChainData: derivedChainData,
+		latestState:      latestState,
+		persistInterval:  persistInterval,
+	}
+
+	c.Component = component.NewComponentManagerBuilder().
+		AddWorker(c.run).
+		Build()
+
+	return c
+}
+
+// run warms up the cache, and then persists the cached programs periodically. Failures are logged since the node
+// can operate without the persisted programs.
+func (c *Cache) run(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	err := c.WarmUp(ctx)
+	if err != nil && ctx.Err() == nil {
+		c.log.Error().Err(err).Msg("could not warm up program cache")
+	}
+	ready()
+
+	ticker := time.NewTicker(c.persistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := c.Persist()
+			if err != nil {
+				c.log.Error().Err(err).Msg("could not persist program cache")
+			}
+		}
+	}
+}
+
+// WarmUp derives the persisted programs which are still valid against the latest state into the derived data of the
+// latest block. The programs are dropped if the latest block already has derived data. Returns the context error if
+// the context is cancelled before the warm-up completed.
+// No errors are expected during normal operation.
+func (c *Cache) WarmUp(ctx context.Context) error {
+	start := time.Now()
+
+	header, storageSnapshot, err := c.latestState()
+	if err != nil {
+		return fmt.Errorf("could not get latest state: %w", err)
+	}
+	programs, err := c.store.Programs()
+	if err != nil {
+		return fmt.Errorf("could not get persisted programs: %w", err)
+	}
+
+	derivedBlockData := derived.NewEmptyDerivedBlockData(0)
+	vmCtx := fvm.NewContextFromParent(
+		c.vmCtx,
+		fvm.WithBlockHeader(header),
+		fvm.WithDerivedBlockData(derivedBlockData),
+		fvm.WithAuthorizationChecksEnabled(false),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(false),
+		fvm.WithTransactionFeesEnabled(false),
+		fvm.WithAccountStorageLimit(false),
+	)
+
+	hits := 0
+	misses := 0
+	txIndex := uint32(0)
+	for _, program := range programs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		valid, err := program.Valid(storageSnapshot)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			misses++
+			continue
+		}
+
+		txBody := flow.NewTransactionBody().
+			SetScript(warmUpTransaction(program.Location)).
+			SetPayer(c.vmCtx.Chain.ServiceAddress())
+
+		// the transactions are committed to the derived block data in order
+		_, output, err := c.vm.Run(vmCtx, fvm.Transaction(txBody, txIndex), storageSnapshot)
+		if err != nil {
+			return fmt.Errorf("could not derive program %s: %w", program.Location, err)
+		}
+		txIndex++
+
+		if output.Err != nil {
+			c.log.Debug().
+				Err(output.Err).
+				Str("location", program.Location.ID()).
+				Msg("could not derive persisted program")
+			misses++
+			continue
+		}
+		hits++
+	}
+
+	if !c.derivedChainData.AddDerivedBlockData(header.ID(), derivedBlockData) {
+		c.log.Warn().
+			Hex("block_id", logging.ID(header.ID())).
+			Msg("latest block already has derived data, warmed up programs are dropped")
+	}
+
+	duration := time.Since(start)
+	c.metrics.ProgramCacheWarmedUp(hits, misses, duration)
+	c.log.Info().
+		Uint64("height", header.Height).
+		Int("hits", hits).
+		Int("misses", misses).
+		Dur("duration", duration).
+		Msg("program cache warmed up")
+
+	return nil
+}
+
+// Persist replaces the persisted programs with the programs cached for the latest block. Nothing is persisted if
+// the latest block has no derived data.
+// No errors are expected during normal operation.
+func (c *Cache) Persist() error {
+	header, storageSnapshot, err := c.latestState()
+	if err != nil {
+		return fmt.Errorf("could not get latest state: %w", err)
+	}
+
+	derivedBlockData := c.derivedChainData.Get(header.ID())
+	if derivedBlockData == nil {
+		return nil
+	}
+
+	programs, err := derivedBlockData.PersistedPrograms(storageSnapshot)
+	if err != nil {
+		return fmt.Errorf("could not get cached programs of block %v: %w", header.ID(), err)
+	}
+	err = c.store.Replace(programs)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.ProgramCachePersisted(len(programs))
+	return nil
+}
+
+// warmUpTransaction returns a transaction which only imports the program at the given location.
+func warmUpTransaction(location common.AddressLocation) []byte {
+	return []byte(fmt.Sprintf(
+		"import %s from %s\n\ntransaction {}\n",
+		location.Name,
+		location.Address.HexWithPrefix()))
+}
@@ -0,0 +1,157 @@
+package programcache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/derived"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	storagepebble "github.com/onflow/flow-go/storage/pebble"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestCache verifies that the programs cached for the latest block are persisted, and derived again into the
+// derived data of the latest block when warming up, unless they are outdated.
+func TestCache(t *testing.T) {
+	chain := flow.Emulator.Chain()
+	vm := fvm.NewVirtualMachine()
+	vmCtx := fvm.NewContext(
+		fvm.WithChain(chain),
+		fvm.WithAuthorizationChecksEnabled(false),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(false),
+	)
+
+	bootstrapSnapshot, output, err := vm.Run(
+		vmCtx,
+		fvm.Bootstrap(unittest.ServiceAccountPublicKey, fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply)),
+		snapshot.NewSnapshotTree(nil))
+	require.NoError(t, err)
+	require.NoError(t, output.Err)
+	storageSnapshot := snapshot.NewSnapshotTree(nil).Append(bootstrapSnapshot)
+
+	header := unittest.BlockHeaderFixture()
+	latestState := func() (*flow.Header, snapshot.StorageSnapshot, error) {
+		return header, storageSnapshot, nil
+	}
+
+	// execute a transaction importing the fungible token contract, which caches it with its dependencies
+	sc := systemcontracts.SystemContractsForChain(chain.ChainID())
+	fungibleToken := common.NewAddressLocation(nil, common.Address(sc.FungibleToken.Address), sc.FungibleToken.Name)
+	derivedChainData, err := derived.NewDerivedChainData(derived.DefaultDerivedDataCacheSize)
+	require.NoError(t, err)
+	txBody := flow.NewTransactionBody().
+		SetScript([]byte(fmt.Sprintf("import FungibleToken from %s\n\ntransaction {}", sc.FungibleToken.Address.HexWithPrefix()))).
+		SetPayer(chain.ServiceAddress())
+	_, output, err = vm.Run(
+		fvm.NewContextFromParent(
+			vmCtx,
+			fvm.WithDerivedBlockData(derivedChainData.GetOrCreateDerivedBlockData(header.ID(), header.ParentID))),
+		fvm.Transaction(txBody, 0),
+		storageSnapshot)
+	require.NoError(t, err)
+	require.NoError(t, output.Err)
+	cachedPrograms := derivedChainData.Get(header.ID()).CachedPrograms()
+	require.Positive(t, cachedPrograms)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		db, err := storagepebble.OpenDefaultPebbleDB(dir)
+		require.NoError(t, err)
+		defer db.Close()
+		store := NewStore(db)
+
+		metrics := modulemock.NewProgramCacheMetrics(t)
+		metrics.On("ProgramCachePersisted", cachedPrograms).Once()
+		cache := NewCache(unittest.Logger(), metrics, store, vm, vmCtx, derivedChainData, latestState, DefaultPersistInterval)
+		require.NoError(t, cache.Persist())
+
+		programs, err := store.Programs()
+		require.NoError(t, err)
+		require.Len(t, programs, cachedPrograms)
+		locations := make([]common.AddressLocation, 0, len(programs))
+		for _, program := range programs {
+			locations = append(locations, program.Location)
+		}
+		assert.Contains(t, locations, fungibleToken)
+
+		t.Run("warm up", func(t *testing.T) {
+			derivedChainData, err := derived.NewDerivedChainData(derived.DefaultDerivedDataCacheSize)
+			require.NoError(t, err)
+
+			metrics := modulemock.NewProgramCacheMetrics(t)
+			metrics.On("ProgramCacheWarmedUp", cachedPrograms, 0, mock.Anything).Once()
+			cache := NewCache(unittest.Logger(), metrics, store, vm, vmCtx, derivedChainData, latestState, DefaultPersistInterval)
+			require.NoError(t, cache.WarmUp(context.Background()))
+
+			derivedBlockData := derivedChainData.Get(header.ID())
+			require.NotNil(t, derivedBlockData)
+			assert.Equal(t, cachedPrograms, derivedBlockData.CachedPrograms())
+			assert.NotNil(t, derivedBlockData.GetProgramForTestingOnly(fungibleToken))
+		})
+
+		t.Run("warm up on start", func(t *testing.T) {
+			derivedChainData, err := derived.NewDerivedChainData(derived.DefaultDerivedDataCacheSize)
+			require.NoError(t, err)
+
+			metrics := modulemock.NewProgramCacheMetrics(t)
+			metrics.On("ProgramCacheWarmedUp", cachedPrograms, 0, mock.Anything).Once()
+			cache := NewCache(unittest.Logger(), metrics, store, vm, vmCtx, derivedChainData, latestState, DefaultPersistInterval)
+
+			ctx, cancel := irrecoverable.NewMockSignalerContextWithCancel(t, context.Background())
+			cache.Start(ctx)
+			unittest.RequireComponentsReadyBefore(t, 10*time.Second, cache)
+
+			// the cache is only ready once the programs are warmed up
+			derivedBlockData := derivedChainData.Get(header.ID())
+			require.NotNil(t, derivedBlockData)
+			assert.Equal(t, cachedPrograms, derivedBlockData.CachedPrograms())
+
+			cancel()
+			unittest.RequireComponentsDoneBefore(t, time.Second, cache)
+		})
+
+		t.Run("outdated programs are skipped", func(t *testing.T) {
+			derivedChainData, err := derived.NewDerivedChainData(derived.DefaultDerivedDataCacheSize)
+			require.NoError(t, err)
+
+			// updating the code of the fungible token contract outdates it and the programs depending on it
+			outdated := 0
+			for _, program := range programs {
+				for _, dependency := range program.Dependencies {
+					if dependency == fungibleToken {
+						outdated++
+						break
+					}
+				}
+			}
+			updatedSnapshot := storageSnapshot.Append(&snapshot.ExecutionSnapshot{
+				WriteSet: map[flow.RegisterID]flow.RegisterValue{
+					flow.ContractRegisterID(sc.FungibleToken.Address, sc.FungibleToken.Name): []byte("access(all) contract FungibleToken {}"),
+				},
+			})
+			updatedState := func() (*flow.Header, snapshot.StorageSnapshot, error) {
+				return header, updatedSnapshot, nil
+			}
+
+			metrics := modulemock.NewProgramCacheMetrics(t)
+			metrics.On("ProgramCacheWarmedUp", cachedPrograms-outdated, outdated, mock.Anything).Once()
+			cache := NewCache(unittest.Logger(), metrics, store, vm, vmCtx, derivedChainData, updatedState, DefaultPersistInterval)
+			require.NoError(t, cache.WarmUp(context.Background()))
+
+			derivedBlockData := derivedChainData.Get(header.ID())
+			require.NotNil(t, derivedBlockData)
+			assert.Nil(t, derivedBlockData.GetProgramForTestingOnly(fungibleToken))
+		})
+	})
+}
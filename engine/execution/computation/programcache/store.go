@@ -0,0 +1,94 @@
+package programcache
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/fvm/storage/derived"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// codeProgram prefixes the persisted programs, keyed by location ID and dependency set
+const codeProgram byte = 1
+
+// Store persists the programs of the program cache in a local pebble database.
+type Store struct {
+	db *pebble.DB
+}
+
+// NewStore creates a new Store using the given database. The database is not closed by the store.
+func NewStore(db *pebble.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// Programs returns the persisted programs, sorted by location ID.
+// No errors are expected during normal operation.
+func (s *Store) Programs() ([]*derived.PersistedProgram, error) {
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{codeProgram},
+		UpperBound: []byte{codeProgram + 1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	programs := make([]*derived.PersistedProgram, 0)
+	for iter.First(); iter.Valid(); iter.Next() {
+		var program derived.PersistedProgram
+		if err := msgpack.Unmarshal(iter.Value(), &program); err != nil {
+			return nil, fmt.Errorf("could not decode persisted program %x: %w", iter.Key(), err)
+		}
+		programs = append(programs, &program)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("could not iterate persisted programs: %w", err)
+	}
+	return programs, nil
+}
+
+// Replace atomically replaces all persisted programs with the given programs.
+// No errors are expected during normal operation.
+func (s *Store) Replace(programs []*derived.PersistedProgram) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.DeleteRange([]byte{codeProgram}, []byte{codeProgram + 1}, nil); err != nil {
+		return fmt.Errorf("could not delete persisted programs: %w", err)
+	}
+	for _, program := range programs {
+		value, err := msgpack.Marshal(program)
+		if err != nil {
+			return fmt.Errorf("could not encode program %s: %w", program.Location, err)
+		}
+		if err := batch.Set(programKey(program), value, nil); err != nil {
+			return fmt.Errorf("could not persist program %s: %w", program.Location, err)
+		}
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("could not commit persisted programs: %w", err)
+	}
+	return nil
+}
+
+// programKey returns the key of the given program. Programs are keyed by location and dependency set, the location
+// ID is followed by a zero byte and the hash of the IDs of the dependencies.
+func programKey(program *derived.PersistedProgram) []byte {
+	dependencies := make([]byte, 0)
+	for _, dependency := range program.Dependencies {
+		dependencies = append(dependencies, dependency.ID()...)
+		dependencies = append(dependencies, 0)
+	}
+	dependenciesHash := flow.MakeIDFromFingerPrint(dependencies)
+
+	location := program.Location.ID()
+	key := make([]byte, 0, 1+len(location)+1+flow.IdentifierLen)
+	key = append(key, codeProgram)
+	key = append(key, location...)
+	key = append(key, 0)
+	return append(key, dependenciesHash[:]...)
+}
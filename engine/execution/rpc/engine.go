@@ -26,6 +26,8 @@ import (
 	_ "github.com/onflow/flow-go/engine/common/grpc/compressor/snappy"  // required for gRPC compression
 	"github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
 	exeEng "github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/metrics"
 	"github.com/onflow/flow-go/engine/execution/state"
@@ -63,7 +65,10 @@ func New(
 	events storage.Events,
 	exeResults storage.ExecutionResults,
 	txResults storage.TransactionResults,
+	resourceReports storage.TransactionResourceReports,
 	commits storage.Commits,
+	seals storage.Seals,
+	registerProver RegisterProver,
 	transactionMetrics metrics.TransactionExecutionMetricsProvider,
 	chainID flow.ChainID,
 	signerIndicesDecoder hotstuff.BlockSignerDecoder,
@@ -122,6 +127,19 @@ func New(
 	}
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
+	registerproofs.RegisterRegisterProofAPIServer(eng.server, &registerProofsHandler{
+		log:          log,
+		chain:        chainID.Chain(),
+		state:        state,
+		headers:      headers,
+		commits:      commits,
+		seals:        seals,
+		prover:       registerProver,
+		maxRegisters: DefaultMaxRegisterProofs,
+	})
+	resourcereports.RegisterTransactionResourceReportAPIServer(eng.server, &resourceReportsHandler{
+		reports: resourceReports,
+	})
 
 	return eng
 }
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/ledger"
+	ledgerconvert "github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultMaxRegisterProofs is the maximum number of registers that can be proven in one request.
+const DefaultMaxRegisterProofs = 100
+
+// RegisterProver produces Merkle inclusion proofs for registers of the execution state.
+type RegisterProver interface {
+	// HasState returns true if the execution state with the given commitment is available.
+	HasState(state ledger.State) bool
+
+	// Prove returns an encoded batch proof for the keys of the query at the state of the query.
+	Prove(query *ledger.Query) (ledger.Proof, error)
+}
+
+// registerProofsHandler implements the RegisterProofAPI, serving proofs for registers at sealed blocks.
+type registerProofsHandler struct {
+	registerproofs.UnimplementedRegisterProofAPIServer
+
+	log          zerolog.Logger
+	chain        flow.Chain
+	state        protocol.State
+	headers      storage.Headers
+	commits      storage.Commits
+	seals        storage.Seals
+	prover       RegisterProver
+	maxRegisters int
+}
+
+var _ registerproofs.RegisterProofAPIServer = (*registerProofsHandler)(nil)
+
+// GetRegisterProofs returns a batch proof for the requested registers against the state commitment of the
+// requested block. Only proofs for finalized and sealed blocks are served, and only if the node's state
+// commitment matches the final state of the sealed result.
+//
+// Expected error codes during normal operation:
+//   - codes.InvalidArgument if the request is malformed or requests too many registers.
+//   - codes.NotFound if the block is not known or has not been executed.
+//   - codes.FailedPrecondition if the block is not sealed, the node's state commitment differs from the sealed
+//     state commitment, or its execution state is no longer available.
+func (h *registerProofsHandler) GetRegisterProofs(
+	_ context.Context,
+	req *registerproofs.GetRegisterProofsRequest,
+) (*registerproofs.GetRegisterProofsResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetRegisterIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no registers requested")
+	}
+	if len(req.GetRegisterIds()) > h.maxRegisters {
+		return nil, status.Errorf(codes.InvalidArgument, "too many registers requested: %d > %d", len(req.GetRegisterIds()), h.maxRegisters)
+	}
+	registerIDs, err := convert.MessagesToRegisterIDs(req.GetRegisterIds(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid register IDs: %v", err)
+	}
+
+	header, err := h.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+	err = h.ensureSealed(header)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := h.commits.ByBlockID(blockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "state commitment for block %v not found", blockID)
+		}
+		return nil, status.Errorf(codes.Internal, "could not get state commitment for block %v: %v", blockID, err)
+	}
+
+	// a node which computed a different result than the sealed one must not serve proofs for its own state
+	seal, err := h.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "seal for block %v not found", blockID)
+		}
+		return nil, status.Errorf(codes.Internal, "could not get seal for block %v: %v", blockID, err)
+	}
+	if seal.FinalState != commit {
+		return nil, status.Errorf(codes.FailedPrecondition, "state commitment %v of block %v does not match the sealed state commitment %v", commit, blockID, seal.FinalState)
+	}
+	if !h.prover.HasState(ledger.State(commit)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "execution state of block %v is no longer available", blockID)
+	}
+
+	keys := make([]ledger.Key, len(registerIDs))
+	for i, registerID := range registerIDs {
+		keys[i] = ledgerconvert.RegisterIDToLedgerKey(registerID)
+	}
+	query, err := ledger.NewQuery(ledger.State(commit), keys)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not create ledger query: %v", err)
+	}
+	proof, err := h.prover.Prove(query)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not prove registers at block %v: %v", blockID, err)
+	}
+
+	return &registerproofs.GetRegisterProofsResponse{
+		BlockId:         blockID[:],
+		BlockHeight:     header.Height,
+		StateCommitment: commit[:],
+		Proof:           proof,
+	}, nil
+}
+
+// ensureSealed returns an error if the block with the given header is not finalized and sealed.
+//
+// Expected error codes during normal operation:
+//   - codes.FailedPrecondition if the block is not sealed.
+func (h *registerProofsHandler) ensureSealed(header *flow.Header) error {
+	sealed, err := h.state.Sealed().Head()
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not get latest sealed block: %v", err)
+	}
+	if header.Height > sealed.Height {
+		return status.Errorf(codes.FailedPrecondition, "block %v at height %d is not sealed, latest sealed height is %d", header.ID(), header.Height, sealed.Height)
+	}
+
+	finalizedID, err := h.headers.BlockIDByHeight(header.Height)
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not get finalized block at height %d: %v", header.Height, err)
+	}
+	if finalizedID != header.ID() {
+		return status.Errorf(codes.FailedPrecondition, "block %v is not finalized", header.ID())
+	}
+	return nil
+}
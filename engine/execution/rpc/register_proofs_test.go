@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registerproofs"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/ledger"
+	ledgerconvert "github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetRegisterProofs tests that proofs are served for sealed blocks, and can be verified against the
+// state commitment of the block.
+func TestGetRegisterProofs(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	address := chain.ServiceAddress()
+
+	ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 10, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+	compactor := fixtures.NewNoopCompactor(ldg)
+	<-compactor.Ready()
+	defer func() {
+		<-ldg.Done()
+		<-compactor.Done()
+	}()
+
+	accountStatus := environment.NewAccountStatus()
+	accountStatus.SetStorageUsed(1000)
+	balanceID := flow.NewRegisterID(address, "balance")
+	statusID := flow.AccountStatusRegisterID(address)
+	update, err := ledger.NewUpdate(
+		ldg.InitialState(),
+		[]ledger.Key{ledgerconvert.RegisterIDToLedgerKey(balanceID), ledgerconvert.RegisterIDToLedgerKey(statusID)},
+		[]ledger.Value{[]byte{42}, accountStatus.ToBytes()},
+	)
+	require.NoError(t, err)
+	newState, _, err := ldg.Set(update)
+	require.NoError(t, err)
+	commit := flow.StateCommitment(newState)
+
+	sealedHeader := unittest.BlockHeaderFixture()
+	unsealedHeader := unittest.BlockHeaderWithParentFixture(sealedHeader)
+
+	headers := storage.NewHeaders(t)
+	commits := storage.NewCommits(t)
+	state := protocol.NewState(t)
+	snapshot := protocol.NewSnapshot(t)
+	state.On("Sealed").Return(snapshot).Maybe()
+	snapshot.On("Head").Return(sealedHeader, nil).Maybe()
+	headers.On("ByBlockID", sealedHeader.ID()).Return(sealedHeader, nil).Maybe()
+	headers.On("ByBlockID", unsealedHeader.ID()).Return(unsealedHeader, nil).Maybe()
+	headers.On("BlockIDByHeight", sealedHeader.Height).Return(sealedHeader.ID(), nil).Maybe()
+	commits.On("ByBlockID", sealedHeader.ID()).Return(commit, nil).Maybe()
+	seals := storage.NewSeals(t)
+	sealFixture := func(blockID flow.Identifier, finalState flow.StateCommitment) *flow.Seal {
+		return unittest.Seal.Fixture(unittest.Seal.WithBlockID(blockID), func(seal *flow.Seal) {
+			seal.FinalState = finalState
+		})
+	}
+	seals.On("FinalizedSealForBlock", sealedHeader.ID()).Return(sealFixture(sealedHeader.ID(), commit), nil).Maybe()
+
+	// a block which this node executed with a different result than the sealed one
+	divergedHeader := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(sealedHeader.Height - 1))
+	headers.On("ByBlockID", divergedHeader.ID()).Return(divergedHeader, nil).Maybe()
+	headers.On("BlockIDByHeight", divergedHeader.Height).Return(divergedHeader.ID(), nil).Maybe()
+	commits.On("ByBlockID", divergedHeader.ID()).Return(commit, nil).Maybe()
+	seals.On("FinalizedSealForBlock", divergedHeader.ID()).Return(sealFixture(divergedHeader.ID(), unittest.StateCommitmentFixture()), nil).Maybe()
+
+	handler := &registerProofsHandler{
+		log:          zerolog.Nop(),
+		chain:        chain,
+		state:        state,
+		headers:      headers,
+		commits:      commits,
+		seals:        seals,
+		prover:       ldg,
+		maxRegisters: 2,
+	}
+
+	unallocatedID := flow.NewRegisterID(address, "unallocated")
+	request := func(blockID flow.Identifier, registerIDs ...flow.RegisterID) *registerproofs.GetRegisterProofsRequest {
+		ids := make([]*entities.RegisterID, len(registerIDs))
+		for i, registerID := range registerIDs {
+			ids[i] = convert.RegisterIDToMessage(registerID)
+		}
+		return &registerproofs.GetRegisterProofsRequest{BlockId: blockID[:], RegisterIds: ids}
+	}
+
+	t.Run("sealed block", func(t *testing.T) {
+		registerIDs := flow.RegisterIDs{balanceID, unallocatedID}
+		resp, err := handler.GetRegisterProofs(context.Background(), request(sealedHeader.ID(), registerIDs...))
+		require.NoError(t, err)
+		require.Equal(t, sealedHeader.Height, resp.GetBlockHeight())
+
+		values, err := registerproofs.VerifyResponse(resp, sealedHeader.ID(), commit, registerIDs)
+		require.NoError(t, err)
+		require.Equal(t, []flow.RegisterValue{{42}, {}}, values)
+
+		// the proof is rejected for a different state commitment
+		_, err = registerproofs.VerifyRegisterProofs(resp.GetProof(), unittest.StateCommitmentFixture(), registerIDs)
+		require.ErrorIs(t, err, registerproofs.ErrInvalidProof)
+		// the proof does not cover registers which were not requested
+		_, err = registerproofs.VerifyRegisterProofs(resp.GetProof(), commit, flow.RegisterIDs{statusID})
+		require.ErrorIs(t, err, registerproofs.ErrInvalidProof)
+	})
+
+	t.Run("account status", func(t *testing.T) {
+		resp, err := handler.GetRegisterProofs(context.Background(), request(sealedHeader.ID(), statusID))
+		require.NoError(t, err)
+
+		provenStatus, err := registerproofs.VerifyAccountStatus(resp.GetProof(), commit, address)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1000), provenStatus.StorageUsed())
+	})
+
+	t.Run("unsealed block", func(t *testing.T) {
+		_, err := handler.GetRegisterProofs(context.Background(), request(unsealedHeader.ID(), balanceID))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("diverged state commitment", func(t *testing.T) {
+		_, err := handler.GetRegisterProofs(context.Background(), request(divergedHeader.ID(), balanceID))
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("too many registers", func(t *testing.T) {
+		_, err := handler.GetRegisterProofs(context.Background(), request(sealedHeader.ID(), balanceID, statusID, unallocatedID))
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
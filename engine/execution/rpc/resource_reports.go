@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
+	"github.com/onflow/flow-go/storage"
+)
+
+// resourceReportsHandler implements the TransactionResourceReportAPI, serving the resource reports stored with
+// the execution results of executed blocks.
+type resourceReportsHandler struct {
+	resourcereports.UnimplementedTransactionResourceReportAPIServer
+
+	reports storage.TransactionResourceReports
+}
+
+var _ resourcereports.TransactionResourceReportAPIServer = (*resourceReportsHandler)(nil)
+
+// GetTransactionResourceReport returns the resource report of the requested transaction executed in the
+// requested block.
+//
+// Expected error codes during normal operation:
+//   - codes.InvalidArgument if the block ID or transaction ID is malformed.
+//   - codes.NotFound if no report is known for the transaction in the block.
+func (h *resourceReportsHandler) GetTransactionResourceReport(
+	_ context.Context,
+	req *resourcereports.GetTransactionResourceReportRequest,
+) (*resourcereports.GetTransactionResourceReportResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid blockID: %v", err)
+	}
+	txID, err := convert.TransactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transactionID: %v", err)
+	}
+
+	report, err := h.reports.ByBlockIDTransactionID(blockID, txID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "transaction resource report not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transaction resource report: %v", err)
+	}
+
+	return &resourcereports.GetTransactionResourceReportResponse{
+		BlockId: blockID[:],
+		Report:  resourcereports.ReportToMessage(report),
+	}, nil
+}
+
+// GetTransactionResourceReportsByBlockID returns the resource reports of all transactions executed in the
+// requested block, ordered by transaction index.
+//
+// Expected error codes during normal operation:
+//   - codes.InvalidArgument if the block ID is malformed.
+//   - codes.NotFound if no reports are known for the block.
+func (h *resourceReportsHandler) GetTransactionResourceReportsByBlockID(
+	_ context.Context,
+	req *resourcereports.GetTransactionResourceReportsByBlockIDRequest,
+) (*resourcereports.GetTransactionResourceReportsByBlockIDResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid blockID: %v", err)
+	}
+
+	reports, err := h.reports.ByBlockID(blockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "transaction resource reports not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transaction resource reports: %v", err)
+	}
+
+	return &resourcereports.GetTransactionResourceReportsByBlockIDResponse{
+		BlockId: blockID[:],
+		Reports: resourcereports.ReportsToMessages(reports),
+	}, nil
+}
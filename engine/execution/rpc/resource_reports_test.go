@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/resourcereports"
+	realstorage "github.com/onflow/flow-go/storage"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetTransactionResourceReports tests that the stored resource reports of executed transactions are served
+// by transaction and by block.
+func TestGetTransactionResourceReports(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	reports := unittest.TransactionResourceReportsFixture(3)
+
+	store := storage.NewTransactionResourceReports(t)
+	handler := &resourceReportsHandler{reports: store}
+
+	t.Run("by transaction", func(t *testing.T) {
+		store.On("ByBlockIDTransactionID", blockID, reports[1].TransactionID).Return(&reports[1], nil).Once()
+
+		resp, err := handler.GetTransactionResourceReport(context.Background(), &resourcereports.GetTransactionResourceReportRequest{
+			BlockId:       blockID[:],
+			TransactionId: reports[1].TransactionID[:],
+		})
+		require.NoError(t, err)
+		assert.Equal(t, blockID[:], resp.GetBlockId())
+		assert.Equal(t, reports[1], *resourcereports.MessageToReport(resp.GetReport()))
+	})
+
+	t.Run("by block", func(t *testing.T) {
+		store.On("ByBlockID", blockID).Return(reports, nil).Once()
+
+		resp, err := handler.GetTransactionResourceReportsByBlockID(context.Background(), &resourcereports.GetTransactionResourceReportsByBlockIDRequest{
+			BlockId: blockID[:],
+		})
+		require.NoError(t, err)
+		assert.Equal(t, blockID[:], resp.GetBlockId())
+		assert.Equal(t, reports, resourcereports.MessagesToReports(resp.GetReports()))
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		txID := unittest.IdentifierFixture()
+		store.On("ByBlockIDTransactionID", blockID, txID).Return(nil, realstorage.ErrNotFound).Once()
+
+		_, err := handler.GetTransactionResourceReport(context.Background(), &resourcereports.GetTransactionResourceReportRequest{
+			BlockId:       blockID[:],
+			TransactionId: txID[:],
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		unknownID := unittest.IdentifierFixture()
+		store.On("ByBlockID", unknownID).Return(nil, realstorage.ErrNotFound).Once()
+
+		_, err := handler.GetTransactionResourceReportsByBlockID(context.Background(), &resourcereports.GetTransactionResourceReportsByBlockIDRequest{
+			BlockId: unknownID[:],
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid block ID", func(t *testing.T) {
+		_, err := handler.GetTransactionResourceReportsByBlockID(context.Background(), &resourcereports.GetTransactionResourceReportsByBlockIDRequest{
+			BlockId: []byte{1, 2, 3},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -104,6 +104,7 @@ type state struct {
 	events             storage.Events
 	serviceEvents      storage.ServiceEvents
 	transactionResults storage.TransactionResults
+	resourceReports    storage.TransactionResourceReports
 	db                 *badger.DB
 
 	registerStore execution.RegisterStore
@@ -125,6 +126,7 @@ func NewExecutionState(
 	events storage.Events,
 	serviceEvents storage.ServiceEvents,
 	transactionResults storage.TransactionResults,
+	resourceReports storage.TransactionResourceReports,
 	db *badger.DB,
 	tracer module.Tracer,
 	registerStore execution.RegisterStore,
@@ -143,6 +145,7 @@ func NewExecutionState(
 		events:              events,
 		serviceEvents:       serviceEvents,
 		transactionResults:  transactionResults,
+		resourceReports:     resourceReports,
 		db:                  db,
 		registerStore:       registerStore,
 		enableRegisterStore: enableRegisterStore,
@@ -441,6 +444,14 @@ func (s *state) saveExecutionResults(
 		return fmt.Errorf("cannot store transaction result: %w", err)
 	}
 
+	err = s.resourceReports.BatchStore(
+		blockID,
+		result.AllTransactionResourceReports(),
+		batch)
+	if err != nil {
+		return fmt.Errorf("cannot store transaction resource reports: %w", err)
+	}
+
 	executionResult := &result.ExecutionReceipt.ExecutionResult
 	err = s.results.BatchStore(executionResult, batch)
 	if err != nil {
@@ -60,6 +60,8 @@ func prepareStorehouseTest(f func(t *testing.T, es state.ExecutionState, l *ledg
 			serviceEvents.On("BatchStore", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			txResults := storage.NewTransactionResults(t)
 			txResults.On("BatchStore", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			resourceReports := storage.NewTransactionResourceReports(t)
+			resourceReports.On("BatchStore", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			chunkDataPacks := storage.NewChunkDataPacks(t)
 			chunkDataPacks.On("Store", mock.Anything).Return(nil)
 			results := storage.NewExecutionResults(t)
@@ -88,7 +90,7 @@ func prepareStorehouseTest(f func(t *testing.T, es state.ExecutionState, l *ledg
 				require.NoError(t, headersDB.Store(finalizedHeaders[10]))
 
 				es := state.NewExecutionState(
-					ls, stateCommitments, blocks, headers, collections, chunkDataPacks, results, myReceipts, events, serviceEvents, txResults, badgerDB, trace.NewNoopTracer(),
+					ls, stateCommitments, blocks, headers, collections, chunkDataPacks, results, myReceipts, events, serviceEvents, txResults, resourceReports, badgerDB, trace.NewNoopTracer(),
 					rs,
 					true,
 				)
@@ -44,12 +44,13 @@ func prepareTest(f func(t *testing.T, es state.ExecutionState, l *ledger.Ledger,
 			events := storage.NewEvents(t)
 			serviceEvents := storage.NewServiceEvents(t)
 			txResults := storage.NewTransactionResults(t)
+			resourceReports := storage.NewTransactionResourceReports(t)
 			chunkDataPacks := storage.NewChunkDataPacks(t)
 			results := storage.NewExecutionResults(t)
 			myReceipts := storage.NewMyExecutionReceipts(t)
 
 			es := state.NewExecutionState(
-				ls, stateCommitments, blocks, headers, collections, chunkDataPacks, results, myReceipts, events, serviceEvents, txResults, badgerDB, trace.NewNoopTracer(),
+				ls, stateCommitments, blocks, headers, collections, chunkDataPacks, results, myReceipts, events, serviceEvents, txResults, resourceReports, badgerDB, trace.NewNoopTracer(),
 				nil,
 				false,
 			)
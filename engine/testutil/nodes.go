@@ -474,6 +474,7 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity bootstrap.NodeInfo, ide
 		node.Index,
 		node.State,
 		node.Seals,
+		storage.NewEmergencySeals(node.PublicDB),
 		assigner,
 		seals,
 		unittest.NewSealingConfigs(flow.DefaultRequiredApprovalsForSealConstruction),
@@ -533,6 +534,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity bootstrap.NodeInfo, ide
 	eventsStorage := storage.NewEvents(node.Metrics, node.PublicDB)
 	serviceEventsStorage := storage.NewServiceEvents(node.Metrics, node.PublicDB)
 	txResultStorage := storage.NewTransactionResults(node.Metrics, node.PublicDB, storage.DefaultCacheSize)
+	resourceReportStorage := storage.NewTransactionResourceReports(node.PublicDB)
 	commitsStorage := storage.NewCommits(node.Metrics, node.PublicDB)
 	chunkDataPackStorage := storage.NewChunkDataPacks(node.Metrics, node.PublicDB, collectionsStorage, 100)
 	results := storage.NewExecutionResults(node.Metrics, node.PublicDB)
@@ -630,7 +632,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity bootstrap.NodeInfo, ide
 
 	storehouseEnabled := true
 	execState := executionState.NewExecutionState(
-		ls, commitsStorage, node.Blocks, node.Headers, collectionsStorage, chunkDataPackStorage, results, myReceipts, eventsStorage, serviceEventsStorage, txResultStorage, node.PublicDB, node.Tracer,
+		ls, commitsStorage, node.Blocks, node.Headers, collectionsStorage, chunkDataPackStorage, results, myReceipts, eventsStorage, serviceEventsStorage, txResultStorage, resourceReportStorage, node.PublicDB, node.Tracer,
 		// TODO: test with register store
 		registerStore,
 		storehouseEnabled,
@@ -1023,7 +1025,9 @@ func VerificationNode(t testing.TB,
 			node.State,
 			node.Me,
 			chunkVerifier,
-			approvalStorage)
+			approvalStorage,
+			storage.NewChunkVerificationRecords(node.PublicDB),
+			verifier.DefaultVerificationRecordsRetention)
 		require.Nil(t, err)
 	}
 
@@ -3,6 +3,7 @@ package fetcher
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel/attribute"
@@ -200,6 +201,7 @@ func (e *Engine) processAssignedChunk(chunk *flow.Chunk, result *flow.ExecutionR
 		ChunkIndex:      chunk.Index,
 		ExecutionResult: result,
 		BlockHeight:     blockHeight,
+		RequestedAt:     time.Now(),
 	}
 	added := e.pendingChunks.Add(status)
 	if !added {
@@ -307,7 +309,7 @@ func (e *Engine) handleChunkDataPackWithTracing(
 			err)
 	}
 
-	processed, err := e.handleValidatedChunkDataPack(ctx, status, chunkDataPack)
+	processed, err := e.handleValidatedChunkDataPack(ctx, originID, status, chunkDataPack)
 	if err != nil {
 		return processed, fmt.Errorf("could not handle validated chunk data pack: %w", err)
 	}
@@ -319,6 +321,7 @@ func (e *Engine) handleChunkDataPackWithTracing(
 // verifier engine.
 // Boolean return value determines whether verifiable chunk pushed to verifier or not.
 func (e *Engine) handleValidatedChunkDataPack(ctx context.Context,
+	originID flow.Identifier,
 	status *verification.ChunkStatus,
 	chunkDataPack *flow.ChunkDataPack) (bool, error) {
 
@@ -332,7 +335,7 @@ func (e *Engine) handleValidatedChunkDataPack(ctx context.Context,
 
 	// pushes chunk data pack to verifier, and waits for it to be verified.
 	chunk := status.ExecutionResult.Chunks[status.ChunkIndex]
-	err := e.pushToVerifierWithTracing(ctx, chunk, status.ExecutionResult, chunkDataPack)
+	err := e.pushToVerifierWithTracing(ctx, originID, status, chunk, chunkDataPack)
 	if err != nil {
 		return false, fmt.Errorf("could not push the chunk to verifier engine")
 	}
@@ -498,13 +501,14 @@ func (e *Engine) NotifyChunkDataPackSealed(chunkIndex uint64, resultID flow.Iden
 // pushToVerifierWithTracing encapsulates the logic of pushing a verifiable chunk to verifier engine with tracing enabled.
 func (e *Engine) pushToVerifierWithTracing(
 	ctx context.Context,
+	originID flow.Identifier,
+	status *verification.ChunkStatus,
 	chunk *flow.Chunk,
-	result *flow.ExecutionResult,
 	chunkDataPack *flow.ChunkDataPack) error {
 
 	var err error
 	e.tracer.WithSpanFromContext(ctx, trace.VERFetcherPushToVerifier, func() {
-		err = e.pushToVerifier(chunk, result, chunkDataPack)
+		err = e.pushToVerifier(originID, status, chunk, chunkDataPack)
 	})
 
 	return err
@@ -514,10 +518,12 @@ func (e *Engine) pushToVerifierWithTracing(
 //
 // When this method returns without any error, it means that the verification of the chunk at the verifier engine is done (either successfully,
 // or unsuccessfully)
-func (e *Engine) pushToVerifier(chunk *flow.Chunk,
-	result *flow.ExecutionResult,
+func (e *Engine) pushToVerifier(originID flow.Identifier,
+	status *verification.ChunkStatus,
+	chunk *flow.Chunk,
 	chunkDataPack *flow.ChunkDataPack) error {
 
+	result := status.ExecutionResult
 	header, err := e.headers.ByBlockID(chunk.BlockID)
 	if err != nil {
 		return fmt.Errorf("could not get block: %w", err)
@@ -528,6 +534,14 @@ func (e *Engine) pushToVerifier(chunk *flow.Chunk,
 		return fmt.Errorf("could not verify chunk: %w", err)
 	}
 
+	agrees, _, err := e.getAgreeAndDisagreeExecutors(chunk.BlockID, result.ID())
+	if err != nil {
+		return fmt.Errorf("could not get executors of result: %w", err)
+	}
+	vchunk.Executors = agrees
+	vchunk.ChunkDataPackOrigin = originID
+	vchunk.FetchDuration = time.Since(status.RequestedAt)
+
 	err = e.verifier.ProcessLocal(vchunk)
 	if err != nil {
 		return fmt.Errorf("verifier could not verify chunk: %w", err)
@@ -765,6 +765,11 @@ func mockVerifierEngine(t *testing.T,
 			require.NoError(t, err)
 
 			require.Equal(t, endState, vc.EndState)
+
+			// informational fields for the verification history
+			require.NotEmpty(t, vc.Executors)
+			require.NotEqual(t, flow.ZeroID, vc.ChunkDataPackOrigin)
+			require.Positive(t, vc.FetchDuration)
 			wg.Done()
 		}).Return(nil)
 
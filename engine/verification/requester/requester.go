@@ -271,8 +271,8 @@ func (e *Engine) handleChunkDataPackRequest(ctx context.Context, request *verifi
 		Uint64("block_height", request.Height).
 		Logger()
 
-	// if block has been sealed, then we can finish
-	if request.Height <= lastSealedHeight {
+	// if block has been sealed, then we can finish, unless the request is retained for re-verification of a sealed chunk.
+	if request.Height <= lastSealedHeight && !time.Now().Before(request.RetainUntil) {
 		locators, removed := e.pendingRequests.PopAll(request.ChunkID)
 
 		if !removed {
@@ -283,6 +283,56 @@ func TestCompleteRequestingUnsealedChunkLifeCycle(t *testing.T) {
 	testifymock.AssertExpectationsForObjects(t, s.metrics)
 }
 
+// TestRequestRetainedChunkSealedBlock evaluates that a chunk request of a sealed block, which is retained for
+// re-verification, is submitted to the network and its response is passed to the handler, instead of being dropped.
+func TestRequestRetainedChunkSealedBlock(t *testing.T) {
+	s := setupTest()
+	e := newRequesterEngine(t, s)
+
+	sealedHeight := uint64(10)
+	// creates a single chunk request that belongs to a sealed height, retained for a minute.
+	requests := unittest.ChunkDataPackRequestListFixture(1,
+		unittest.WithHeight(5),
+		unittest.WithAgrees(unittest.IdentifierListFixture(2)),
+		unittest.WithDisagrees(unittest.IdentifierListFixture(3)),
+		unittest.WithRetainUntil(time.Now().Add(time.Minute)))
+	response := unittest.ChunkDataResponseMsgFixture(requests[0].ChunkID)
+
+	// mocks the requester pipeline
+	vertestutils.MockLastSealedHeight(s.state, sealedHeight)
+	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
+	handlerWG := mockChunkDataPackHandler(t, s.handler, requests)
+	mockPendingRequestsPopAll(t, s.pendingRequests, requests)
+
+	// makes all chunk requests being qualified for dispatch instantly
+	requestHistoryWG, updateHistoryWG := mockPendingRequestInfoAndUpdate(t,
+		s.pendingRequests,
+		requests,
+		verification.ChunkDataPackRequestList{},
+		verification.ChunkDataPackRequestList{},
+		1)
+	s.metrics.On("OnChunkDataPackResponseReceivedFromNetworkByRequester").Return().Times(len(requests))
+	s.metrics.On("OnChunkDataPackRequestDispatchedInNetworkByRequester").Return().Times(len(requests))
+	s.metrics.On("OnChunkDataPackSentToFetcher").Return().Times(len(requests))
+	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", uint64(0)).Return()
+
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine on time")
+
+	// we wait till the engine submits the chunk request to the network, and receive the response
+	conduitWG := mockConduitForChunkDataPackRequest(t, s.con, requests, 1, func(request *messages.ChunkDataRequest) {
+		err := e.Process(channels.RequestChunks, requests[0].Agrees[0], response)
+		require.NoError(t, err)
+	})
+	unittest.RequireReturnsBefore(t, requestHistoryWG.Wait, time.Duration(2)*s.retryInterval, "could not check chunk requests qualification on time")
+	unittest.RequireReturnsBefore(t, updateHistoryWG.Wait, s.retryInterval, "could not update chunk request history on time")
+	unittest.RequireReturnsBefore(t, conduitWG.Wait, time.Duration(2)*s.retryInterval, "could not request chunks from network")
+	unittest.RequireReturnsBefore(t, handlerWG.Wait, time.Second, "could not handle chunk data responses on time")
+
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+	// the retained request is never dropped as sealed.
+	s.handler.AssertNotCalled(t, "NotifyChunkDataPackSealed", testifymock.Anything, testifymock.Anything)
+}
+
 // TestRequestPendingChunkSealedBlock_Hybrid evaluates the situation that requester has some pending chunk requests belonging to sealed blocks
 // (i.e., sealed chunks), and some pending chunk requests belonging to unsealed blocks (i.e., unsealed chunks).
 //
@@ -0,0 +1,45 @@
+package reverifier
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Report describes the outcome of re-verifying a single chunk.
+type Report struct {
+	ResultID    flow.Identifier `json:"result_id"`
+	ChunkIndex  uint64          `json:"chunk_index"`
+	ChunkID     flow.Identifier `json:"chunk_id"`
+	BlockID     flow.Identifier `json:"block_id"`
+	BlockHeight uint64          `json:"block_height"`
+	SystemChunk bool            `json:"system_chunk"`
+	// ChunkDataPackOrigin is the execution node that provided the chunk data pack.
+	ChunkDataPackOrigin flow.Identifier `json:"chunk_data_pack_origin"`
+
+	// Valid is true if the chunk passed verification.
+	Valid bool `json:"valid"`
+	// Fault describes the chunk fault found by the verifier, if any.
+	Fault string `json:"fault,omitempty"`
+
+	// ClaimedEndState is the end state of the chunk claimed by the execution result.
+	ClaimedEndState flow.StateCommitment `json:"claimed_end_state"`
+	// ComputedEndState is the end state computed by re-executing the chunk. It is only known if
+	// the chunk passed verification or if the end states mismatch.
+	ComputedEndState *flow.StateCommitment `json:"computed_end_state,omitempty"`
+	// ClaimedEventCollection is the events collection hash claimed by the chunk.
+	ClaimedEventCollection flow.Identifier `json:"claimed_event_collection"`
+	// ComputedEventCollection is the events collection hash computed by re-executing the chunk. It
+	// is only known if the chunk passed verification or if the event collections mismatch.
+	ComputedEventCollection *flow.Identifier `json:"computed_event_collection,omitempty"`
+
+	// Spocks lists the SPoCK check for each execution receipt committing to the result. SPoCKs are
+	// only checked if the chunk passed verification.
+	Spocks []SpockCheck `json:"spocks,omitempty"`
+}
+
+// SpockCheck is the outcome of checking the SPoCK of an execution receipt against the SPoCK secret
+// obtained by re-executing the chunk.
+type SpockCheck struct {
+	ExecutorID flow.Identifier `json:"executor_id"`
+	Valid      bool            `json:"valid"`
+	Error      string          `json:"error,omitempty"`
+}
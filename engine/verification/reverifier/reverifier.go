@@ -0,0 +1,445 @@
+package reverifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/crypto"
+	"github.com/onflow/crypto/hash"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+	chmodels "github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/model/verification/convert"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// DefaultRequestTimeout is the default time the Reverifier waits for the chunk data pack of a
+// re-verified chunk to arrive from the execution nodes, if the caller does not set a deadline.
+const DefaultRequestTimeout = time.Minute
+
+var (
+	// ErrUnknownChunk is returned when the requested result or chunk is not known to the node.
+	ErrUnknownChunk = errors.New("unknown chunk")
+	// ErrNotSealed is returned when the requested result has not been sealed.
+	ErrNotSealed = errors.New("result is not sealed")
+	// ErrInProgress is returned when a re-verification of the same chunk is already in progress.
+	ErrInProgress = errors.New("re-verification of chunk already in progress")
+)
+
+// Reverifier re-verifies arbitrary chunks of sealed execution results on demand, for example to
+// audit an execution result after the fact. It fetches the chunk data pack through the requester
+// of the verification pipeline, re-executes the chunk using the ChunkVerifier, and reports the
+// outcome. In contrast to the verifier engine, it never publishes result approvals.
+//
+// The Reverifier is placed between the requester and the fetcher engine: it implements the
+// ChunkDataPackRequester that the fetcher engine uses, forwarding all requests and responses, and
+// intercepts the responses for the chunks it re-verifies.
+type Reverifier struct {
+	log            zerolog.Logger
+	me             module.Local
+	state          protocol.State
+	headers        storage.Headers
+	blocks         storage.Blocks
+	results        storage.ExecutionResults
+	receipts       storage.ExecutionReceipts
+	seals          storage.Seals
+	requester      fetcher.ChunkDataPackRequester
+	chunkVerifier  module.ChunkVerifier
+	spockHasher    hash.Hasher
+	requestTimeout time.Duration
+
+	handler fetcher.ChunkDataPackHandler // downstream handler of the regular verification pipeline.
+
+	mu      sync.Mutex
+	pending map[flow.Identifier]chan *chunkDataPackResponse // response channels by chunk locator ID.
+}
+
+var _ fetcher.ChunkDataPackRequester = (*Reverifier)(nil)
+var _ fetcher.ChunkDataPackHandler = (*Reverifier)(nil)
+
+func New(
+	log zerolog.Logger,
+	me module.Local,
+	state protocol.State,
+	headers storage.Headers,
+	blocks storage.Blocks,
+	results storage.ExecutionResults,
+	receipts storage.ExecutionReceipts,
+	seals storage.Seals,
+	requester fetcher.ChunkDataPackRequester,
+	chunkVerifier module.ChunkVerifier,
+	requestTimeout time.Duration,
+) *Reverifier {
+	r := &Reverifier{
+		log:            log.With().Str("component", "reverifier").Logger(),
+		me:             me,
+		state:          state,
+		headers:        headers,
+		blocks:         blocks,
+		results:        results,
+		receipts:       receipts,
+		seals:          seals,
+		requester:      requester,
+		chunkVerifier:  chunkVerifier,
+		spockHasher:    signature.NewBLSHasher(signature.SPOCKTag),
+		requestTimeout: requestTimeout,
+		pending:        make(map[flow.Identifier]chan *chunkDataPackResponse),
+	}
+
+	requester.WithChunkDataPackHandler(r)
+
+	return r
+}
+
+// Ready returns a channel that is closed once the underlying requester is ready.
+func (r *Reverifier) Ready() <-chan struct{} {
+	return r.requester.Ready()
+}
+
+// Done returns a channel that is closed once the underlying requester is done.
+func (r *Reverifier) Done() <-chan struct{} {
+	return r.requester.Done()
+}
+
+// Request forwards the chunk data pack request of the regular verification pipeline to the requester.
+func (r *Reverifier) Request(request *verification.ChunkDataPackRequest) {
+	r.requester.Request(request)
+}
+
+// WithChunkDataPackHandler registers the handler of the regular verification pipeline, which receives
+// all chunk data packs that are not exclusively requested for re-verification.
+func (r *Reverifier) WithChunkDataPackHandler(handler fetcher.ChunkDataPackHandler) {
+	r.handler = handler
+}
+
+// HandleChunkDataPack passes the chunk data pack to the pending re-verification of the chunk, if any,
+// and forwards it to the downstream handler.
+func (r *Reverifier) HandleChunkDataPack(originID flow.Identifier, response *verification.ChunkDataPackResponse) {
+	r.handler.HandleChunkDataPack(originID, response)
+
+	r.mu.Lock()
+	ch, ok := r.pending[response.Locator.ID()]
+	if ok {
+		delete(r.pending, response.Locator.ID())
+	}
+	r.mu.Unlock()
+
+	if ok {
+		ch <- &chunkDataPackResponse{originID: originID, chunkDataPack: response.Cdp}
+		close(ch)
+	}
+}
+
+// NotifyChunkDataPackSealed aborts the pending re-verification of the chunk, if any, and forwards the
+// notification to the downstream handler. For re-verified chunks, the requester calls this method once
+// the request timed out.
+func (r *Reverifier) NotifyChunkDataPackSealed(chunkIndex uint64, resultID flow.Identifier) {
+	r.handler.NotifyChunkDataPackSealed(chunkIndex, resultID)
+
+	locator := chmodels.Locator{ResultID: resultID, Index: chunkIndex}
+
+	r.mu.Lock()
+	ch, ok := r.pending[locator.ID()]
+	if ok {
+		delete(r.pending, locator.ID())
+	}
+	r.mu.Unlock()
+
+	if ok {
+		close(ch)
+	}
+}
+
+// Reverify fetches the chunk data pack of the given chunk from the execution nodes, re-executes the chunk
+// and returns a report of the outcome. A chunk that fails verification is not an error; the fault is
+// described in the report instead.
+//
+// Expected errors during normal operation:
+//   - ErrUnknownChunk if the result or the chunk is not known.
+//   - ErrNotSealed if the result has not been sealed.
+//   - ErrInProgress if the chunk is already being re-verified.
+//   - context.DeadlineExceeded if no valid chunk data pack was received in time.
+func (r *Reverifier) Reverify(ctx context.Context, resultID flow.Identifier, chunkIndex uint64) (*Report, error) {
+	result, err := r.results.ByID(resultID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("result %v: %w", resultID, ErrUnknownChunk)
+		}
+		return nil, fmt.Errorf("could not retrieve result %v: %w", resultID, err)
+	}
+	chunk, ok := result.Chunks.ByIndex(chunkIndex)
+	if !ok {
+		return nil, fmt.Errorf("result %v has %d chunks, got index %d: %w", resultID, len(result.Chunks), chunkIndex, ErrUnknownChunk)
+	}
+
+	seal, err := r.seals.FinalizedSealForBlock(result.BlockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("block %v: %w", result.BlockID, ErrNotSealed)
+		}
+		return nil, fmt.Errorf("could not retrieve seal for block %v: %w", result.BlockID, err)
+	}
+	if seal.ResultID != resultID {
+		return nil, fmt.Errorf("block %v is sealed with result %v: %w", result.BlockID, seal.ResultID, ErrNotSealed)
+	}
+
+	header, err := r.headers.ByBlockID(result.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve header of block %v: %w", result.BlockID, err)
+	}
+	receipts, err := r.receipts.ByBlockID(result.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve receipts for block %v: %w", result.BlockID, err)
+	}
+
+	lg := r.log.With().
+		Hex("result_id", logging.ID(resultID)).
+		Uint64("chunk_index", chunkIndex).
+		Uint64("block_height", header.Height).
+		Logger()
+
+	response, err := r.fetchChunkDataPack(ctx, chunk, result, header, receipts)
+	if err != nil {
+		return nil, err
+	}
+	lg.Info().Hex("origin_id", logging.ID(response.originID)).Msg("chunk data pack for re-verification received")
+
+	report, err := r.verify(chunk, result, header, receipts, response)
+	if err != nil {
+		return nil, err
+	}
+	lg.Info().Bool("valid", report.Valid).Str("chunk_fault", report.Fault).Msg("chunk re-verified")
+
+	return report, nil
+}
+
+type chunkDataPackResponse struct {
+	originID      flow.Identifier
+	chunkDataPack *flow.ChunkDataPack
+}
+
+// fetchChunkDataPack requests the chunk data pack of the given chunk through the requester and waits for
+// a valid response.
+func (r *Reverifier) fetchChunkDataPack(
+	ctx context.Context,
+	chunk *flow.Chunk,
+	result *flow.ExecutionResult,
+	header *flow.Header,
+	receipts []*flow.ExecutionReceipt,
+) (*chunkDataPackResponse, error) {
+	resultID := result.ID()
+	locator := chmodels.Locator{ResultID: resultID, Index: chunk.Index}
+
+	executors, err := r.state.AtBlockID(result.BlockID).Identities(filter.HasRole[flow.Identity](flow.RoleExecution))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve execution nodes at block %v: %w", result.BlockID, err)
+	}
+	agrees, disagrees := executorsOf(receipts, resultID)
+
+	// the request timeout only applies if the caller did not set a deadline
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(r.requestTimeout)
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	ch := make(chan *chunkDataPackResponse, 1)
+	r.mu.Lock()
+	if _, ok := r.pending[locator.ID()]; ok {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("chunk %d of result %v: %w", chunk.Index, resultID, ErrInProgress)
+	}
+	r.pending[locator.ID()] = ch
+	r.mu.Unlock()
+
+	r.requester.Request(&verification.ChunkDataPackRequest{
+		Locator: locator,
+		ChunkDataPackRequestInfo: verification.ChunkDataPackRequestInfo{
+			ChunkID:     chunk.ID(),
+			Height:      header.Height,
+			Agrees:      agrees,
+			Disagrees:   disagrees,
+			Targets:     executors,
+			RetainUntil: deadline,
+		},
+	})
+
+	select {
+	case <-ctx.Done():
+		r.mu.Lock()
+		delete(r.pending, locator.ID())
+		r.mu.Unlock()
+		return nil, fmt.Errorf("chunk data pack for chunk %d of result %v not received: %w", chunk.Index, resultID, ctx.Err())
+	case response, ok := <-ch:
+		if !ok {
+			return nil, fmt.Errorf("chunk data pack for chunk %d of result %v not received: %w", chunk.Index, resultID, context.DeadlineExceeded)
+		}
+		err := r.validateChunkDataPack(chunk, result, response)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk data pack for chunk %d of result %v from %v: %w", chunk.Index, resultID, response.originID, err)
+		}
+		return response, nil
+	}
+}
+
+// validateChunkDataPack checks that the chunk data pack was sent by an execution node authorized at the
+// block of the chunk, and that it matches the start state and collection of the chunk.
+func (r *Reverifier) validateChunkDataPack(chunk *flow.Chunk, result *flow.ExecutionResult, response *chunkDataPackResponse) error {
+	chunkDataPack := response.chunkDataPack
+
+	authorized, err := protocol.IsNodeAuthorizedWithRoleAt(r.state.AtBlockID(chunk.BlockID), response.originID, flow.RoleExecution)
+	if err != nil {
+		return fmt.Errorf("could not check authorization of sender: %w", err)
+	}
+	if !authorized {
+		return fmt.Errorf("sender is not an authorized execution node at block %v", chunk.BlockID)
+	}
+	if chunkDataPack.StartState != chunk.StartState {
+		return fmt.Errorf("expected start state %x, got %x", chunk.StartState, chunkDataPack.StartState)
+	}
+
+	if convert.IsSystemChunk(chunk.Index, result) {
+		if chunkDataPack.Collection != nil {
+			return fmt.Errorf("non-nil collection for system chunk")
+		}
+		return nil
+	}
+	if chunkDataPack.Collection == nil {
+		return fmt.Errorf("missing collection for non-system chunk")
+	}
+	block, err := r.blocks.ByID(chunk.BlockID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve block %v: %w", chunk.BlockID, err)
+	}
+	if uint64(len(block.Payload.Guarantees)) <= chunk.Index {
+		return fmt.Errorf("block %v has no guarantee for chunk %d", chunk.BlockID, chunk.Index)
+	}
+	expected := block.Payload.Guarantees[chunk.Index].CollectionID
+	if chunkDataPack.Collection.ID() != expected {
+		return fmt.Errorf("expected collection %v, got %v", expected, chunkDataPack.Collection.ID())
+	}
+	return nil
+}
+
+// verify re-executes the chunk and assembles the report.
+// No errors are expected during normal operation.
+func (r *Reverifier) verify(
+	chunk *flow.Chunk,
+	result *flow.ExecutionResult,
+	header *flow.Header,
+	receipts []*flow.ExecutionReceipt,
+	response *chunkDataPackResponse,
+) (*Report, error) {
+	vc, err := convert.FromChunkDataPack(chunk, response.chunkDataPack, header, r.state.AtBlockID(header.ID()), result)
+	if err != nil {
+		return nil, fmt.Errorf("could not create verifiable chunk: %w", err)
+	}
+
+	report := &Report{
+		ResultID:               result.ID(),
+		ChunkIndex:             chunk.Index,
+		ChunkID:                chunk.ID(),
+		BlockID:                result.BlockID,
+		BlockHeight:            header.Height,
+		SystemChunk:            vc.IsSystemChunk,
+		ChunkDataPackOrigin:    response.originID,
+		ClaimedEndState:        vc.EndState,
+		ClaimedEventCollection: chunk.EventCollection,
+	}
+
+	spockSecret, err := r.chunkVerifier.Verify(vc)
+	if err != nil {
+		if !chmodels.IsChunkFaultError(err) {
+			return nil, fmt.Errorf("could not verify chunk: %w", err)
+		}
+		report.Fault = err.Error()
+
+		var finalStateFault *chmodels.CFNonMatchingFinalState
+		if errors.As(err, &finalStateFault) {
+			computed := finalStateFault.Computed()
+			report.ComputedEndState = &computed
+		}
+		var eventsFault *chmodels.CFInvalidEventsCollection
+		if errors.As(err, &eventsFault) {
+			computed := eventsFault.Computed()
+			report.ComputedEventCollection = &computed
+		}
+		return report, nil
+	}
+
+	report.Valid = true
+	report.ComputedEndState = &report.ClaimedEndState
+	report.ComputedEventCollection = &report.ClaimedEventCollection
+
+	report.Spocks, err = r.verifySpocks(chunk, result, receipts, spockSecret)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify SPoCKs: %w", err)
+	}
+	return report, nil
+}
+
+// verifySpocks checks the SPoCK of every execution receipt committing to the given result against the SPoCK
+// secret obtained by re-executing the chunk.
+// No errors are expected during normal operation.
+func (r *Reverifier) verifySpocks(chunk *flow.Chunk, result *flow.ExecutionResult, receipts []*flow.ExecutionReceipt, spockSecret []byte) ([]SpockCheck, error) {
+	proof, err := r.me.SignFunc(spockSecret, r.spockHasher, crypto.SPOCKProve)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate SPoCK: %w", err)
+	}
+	self, err := r.state.Final().Identity(r.me.NodeID())
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve own identity: %w", err)
+	}
+
+	resultID := result.ID()
+	snapshot := r.state.AtBlockID(result.BlockID)
+	checks := make([]SpockCheck, 0, len(receipts))
+	for _, receipt := range receipts {
+		if receipt.ExecutionResult.ID() != resultID {
+			continue
+		}
+		check := SpockCheck{ExecutorID: receipt.ExecutorID}
+		if chunk.Index >= uint64(len(receipt.Spocks)) {
+			check.Error = fmt.Sprintf("receipt %v has no SPoCK for chunk %d", receipt.ID(), chunk.Index)
+			checks = append(checks, check)
+			continue
+		}
+		executor, err := snapshot.Identity(receipt.ExecutorID)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve identity of executor %v: %w", receipt.ExecutorID, err)
+		}
+		check.Valid, err = crypto.SPOCKVerify(self.StakingPubKey, proof, executor.StakingPubKey, receipt.Spocks[chunk.Index])
+		if err != nil {
+			check.Error = err.Error()
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// executorsOf segregates the executors of the given receipts into those that agree with the given result
+// and those that committed to a different result.
+func executorsOf(receipts []*flow.ExecutionReceipt, resultID flow.Identifier) (flow.IdentifierList, flow.IdentifierList) {
+	var agrees flow.IdentifierList
+	var disagrees flow.IdentifierList
+	for _, receipt := range receipts {
+		if receipt.ExecutionResult.ID() == resultID {
+			agrees = append(agrees, receipt.ExecutorID)
+		} else {
+			disagrees = append(disagrees, receipt.ExecutorID)
+		}
+	}
+	return agrees, disagrees
+}
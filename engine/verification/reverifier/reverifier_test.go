@@ -0,0 +1,237 @@
+package reverifier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/crypto"
+	"github.com/onflow/crypto/hash"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	mockfetcher "github.com/onflow/flow-go/engine/verification/fetcher/mock"
+	"github.com/onflow/flow-go/engine/verification/reverifier"
+	chmodels "github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/module/signature"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReverifier(t *testing.T) {
+	suite.Run(t, new(ReverifierSuite))
+}
+
+type ReverifierSuite struct {
+	suite.Suite
+
+	me            *module.Local
+	state         *protocol.State
+	snapshot      *protocol.Snapshot
+	headers       *storagemock.Headers
+	blocks        *storagemock.Blocks
+	results       *storagemock.ExecutionResults
+	receipts      *storagemock.ExecutionReceipts
+	seals         *storagemock.Seals
+	requester     *mockfetcher.ChunkDataPackRequester
+	handler       *mockfetcher.ChunkDataPackHandler
+	chunkVerifier *module.ChunkVerifier
+
+	verifierKey crypto.PrivateKey
+	executorKey crypto.PrivateKey
+	executor    *flow.Identity
+	header      *flow.Header
+	result      *flow.ExecutionResult
+	receipt     *flow.ExecutionReceipt
+	spockSecret []byte
+
+	reverifier *reverifier.Reverifier
+}
+
+func (s *ReverifierSuite) SetupTest() {
+	s.me = module.NewLocal(s.T())
+	s.state = protocol.NewState(s.T())
+	s.snapshot = protocol.NewSnapshot(s.T())
+	s.headers = storagemock.NewHeaders(s.T())
+	s.blocks = storagemock.NewBlocks(s.T())
+	s.results = storagemock.NewExecutionResults(s.T())
+	s.receipts = storagemock.NewExecutionReceipts(s.T())
+	s.seals = storagemock.NewSeals(s.T())
+	s.requester = mockfetcher.NewChunkDataPackRequester(s.T())
+	s.handler = mockfetcher.NewChunkDataPackHandler(s.T())
+	s.chunkVerifier = module.NewChunkVerifier(s.T())
+
+	s.verifierKey = unittest.StakingPrivKeyFixture()
+	s.executorKey = unittest.StakingPrivKeyFixture()
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	verifier.StakingPubKey = s.verifierKey.PublicKey()
+	s.executor = unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.executor.StakingPubKey = s.executorKey.PublicKey()
+
+	s.header = unittest.BlockHeaderFixture()
+	s.result = unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(s.header.ID()))
+	s.spockSecret = unittest.RandomBytes(32)
+	s.receipt = unittest.ExecutionReceiptFixture(unittest.WithResult(s.result), unittest.WithExecutorID(s.executor.NodeID))
+	s.receipt.Spocks = make([]crypto.Signature, len(s.result.Chunks))
+	for i := range s.receipt.Spocks {
+		spock, err := crypto.SPOCKProve(s.executorKey, s.spockSecret, signature.NewBLSHasher(signature.SPOCKTag))
+		s.Require().NoError(err)
+		s.receipt.Spocks[i] = spock
+	}
+
+	s.me.On("NodeID").Return(verifier.NodeID).Maybe()
+	s.me.On("SignFunc", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(msg []byte, hasher hash.Hasher, sign func(crypto.PrivateKey, []byte, hash.Hasher) (crypto.Signature, error)) (crypto.Signature, error) {
+			return sign(s.verifierKey, msg, hasher)
+		}).Maybe()
+	s.state.On("AtBlockID", s.header.ID()).Return(s.snapshot).Maybe()
+	s.state.On("Final").Return(s.snapshot).Maybe()
+	s.snapshot.On("Identities", mock.Anything).Return(flow.IdentityList{s.executor}, nil).Maybe()
+	s.snapshot.On("Identity", s.executor.NodeID).Return(s.executor, nil).Maybe()
+	s.snapshot.On("Identity", verifier.NodeID).Return(verifier, nil).Maybe()
+	s.results.On("ByID", s.result.ID()).Return(s.result, nil).Maybe()
+	s.headers.On("ByBlockID", s.header.ID()).Return(s.header, nil).Maybe()
+	s.receipts.On("ByBlockID", s.header.ID()).Return(flow.ExecutionReceiptList{s.receipt}, nil).Maybe()
+
+	s.requester.On("WithChunkDataPackHandler", mock.Anything).Once()
+	s.reverifier = reverifier.New(
+		unittest.Logger(),
+		s.me,
+		s.state,
+		s.headers,
+		s.blocks,
+		s.results,
+		s.receipts,
+		s.seals,
+		s.requester,
+		s.chunkVerifier,
+		time.Second,
+	)
+	s.reverifier.WithChunkDataPackHandler(s.handler)
+}
+
+// systemChunk returns the system chunk of the result, together with a matching chunk data pack.
+func (s *ReverifierSuite) systemChunk() (*flow.Chunk, *flow.ChunkDataPack) {
+	chunk := s.result.Chunks[len(s.result.Chunks)-1]
+	cdp := unittest.ChunkDataPackFixture(chunk.ID(), unittest.WithStartState(chunk.StartState))
+	cdp.Collection = nil
+	return chunk, cdp
+}
+
+// respondWith mocks the requester to deliver the given chunk data pack for the requested chunk.
+func (s *ReverifierSuite) respondWith(chunk *flow.Chunk, cdp *flow.ChunkDataPack) {
+	s.requester.On("Request", mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*verification.ChunkDataPackRequest)
+		s.Require().Equal(chunk.ID(), request.ChunkID)
+		s.Require().Equal(s.header.Height, request.Height)
+		s.Require().True(request.RetainUntil.After(time.Now()))
+		go s.reverifier.HandleChunkDataPack(s.executor.NodeID, &verification.ChunkDataPackResponse{
+			Locator: request.Locator,
+			Cdp:     cdp,
+		})
+	}).Once()
+	s.handler.On("HandleChunkDataPack", s.executor.NodeID, mock.Anything).Once()
+}
+
+// TestReverify_Valid tests that a valid chunk is reported as valid, with matching end states and valid SPoCKs.
+func (s *ReverifierSuite) TestReverify_Valid() {
+	chunk, cdp := s.systemChunk()
+	s.seals.On("FinalizedSealForBlock", s.header.ID()).Return(unittest.Seal.Fixture(unittest.Seal.WithResult(s.result)), nil)
+	s.respondWith(chunk, cdp)
+	s.chunkVerifier.On("Verify", mock.Anything).Return(s.spockSecret, nil).Once()
+
+	report, err := s.reverifier.Reverify(context.Background(), s.result.ID(), chunk.Index)
+	s.Require().NoError(err)
+
+	s.Assert().True(report.Valid)
+	s.Assert().Empty(report.Fault)
+	s.Assert().True(report.SystemChunk)
+	s.Assert().Equal(s.executor.NodeID, report.ChunkDataPackOrigin)
+	s.Assert().Equal(chunk.EndState, report.ClaimedEndState)
+	s.Require().NotNil(report.ComputedEndState)
+	s.Assert().Equal(report.ClaimedEndState, *report.ComputedEndState)
+	s.Require().Len(report.Spocks, 1)
+	s.Assert().Equal(s.executor.NodeID, report.Spocks[0].ExecutorID)
+	s.Assert().True(report.Spocks[0].Valid)
+}
+
+// TestReverify_InvalidSpock tests that a SPoCK of an execution receipt, which does not match the re-executed chunk,
+// is reported as invalid.
+func (s *ReverifierSuite) TestReverify_InvalidSpock() {
+	chunk, cdp := s.systemChunk()
+	s.seals.On("FinalizedSealForBlock", s.header.ID()).Return(unittest.Seal.Fixture(unittest.Seal.WithResult(s.result)), nil)
+	s.respondWith(chunk, cdp)
+	s.chunkVerifier.On("Verify", mock.Anything).Return(unittest.RandomBytes(32), nil).Once()
+
+	report, err := s.reverifier.Reverify(context.Background(), s.result.ID(), chunk.Index)
+	s.Require().NoError(err)
+
+	s.Assert().True(report.Valid)
+	s.Require().Len(report.Spocks, 1)
+	s.Assert().False(report.Spocks[0].Valid)
+}
+
+// TestReverify_FinalStateMismatch tests that a chunk fault is reported with the computed end state.
+func (s *ReverifierSuite) TestReverify_FinalStateMismatch() {
+	chunk, cdp := s.systemChunk()
+	computed := unittest.StateCommitmentFixture()
+	s.seals.On("FinalizedSealForBlock", s.header.ID()).Return(unittest.Seal.Fixture(unittest.Seal.WithResult(s.result)), nil)
+	s.respondWith(chunk, cdp)
+	s.chunkVerifier.On("Verify", mock.Anything).
+		Return(nil, chmodels.NewCFNonMatchingFinalState(chunk.EndState, computed, chunk.Index, s.result.ID())).
+		Once()
+
+	report, err := s.reverifier.Reverify(context.Background(), s.result.ID(), chunk.Index)
+	s.Require().NoError(err)
+
+	s.Assert().False(report.Valid)
+	s.Assert().NotEmpty(report.Fault)
+	s.Require().NotNil(report.ComputedEndState)
+	s.Assert().Equal(computed, *report.ComputedEndState)
+	s.Assert().Nil(report.ComputedEventCollection)
+	s.Assert().Empty(report.Spocks)
+}
+
+// TestReverify_NotSealed tests that re-verifying a chunk of an unsealed result is rejected.
+func (s *ReverifierSuite) TestReverify_NotSealed() {
+	s.seals.On("FinalizedSealForBlock", s.header.ID()).Return(nil, storage.ErrNotFound).Once()
+	_, err := s.reverifier.Reverify(context.Background(), s.result.ID(), 0)
+	s.Require().ErrorIs(err, reverifier.ErrNotSealed)
+
+	// the block is sealed with a different result
+	s.seals.On("FinalizedSealForBlock", s.header.ID()).Return(unittest.Seal.Fixture(), nil).Once()
+	_, err = s.reverifier.Reverify(context.Background(), s.result.ID(), 0)
+	s.Require().ErrorIs(err, reverifier.ErrNotSealed)
+}
+
+// TestReverify_UnknownChunk tests that re-verifying an unknown result or chunk is rejected.
+func (s *ReverifierSuite) TestReverify_UnknownChunk() {
+	unknownID := unittest.IdentifierFixture()
+	s.results.On("ByID", unknownID).Return(nil, storage.ErrNotFound).Once()
+	_, err := s.reverifier.Reverify(context.Background(), unknownID, 0)
+	s.Require().ErrorIs(err, reverifier.ErrUnknownChunk)
+
+	_, err = s.reverifier.Reverify(context.Background(), s.result.ID(), uint64(len(s.result.Chunks)))
+	s.Require().ErrorIs(err, reverifier.ErrUnknownChunk)
+}
+
+// TestReverify_Timeout tests that re-verification fails if the requester gives up on the request, and that
+// chunk data packs of the regular pipeline are forwarded to the downstream handler.
+func (s *ReverifierSuite) TestReverify_Timeout() {
+	chunk, _ := s.systemChunk()
+	s.seals.On("FinalizedSealForBlock", s.header.ID()).Return(unittest.Seal.Fixture(unittest.Seal.WithResult(s.result)), nil)
+	s.requester.On("Request", mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*verification.ChunkDataPackRequest)
+		go s.reverifier.NotifyChunkDataPackSealed(request.Index, request.ResultID)
+	}).Once()
+	s.handler.On("NotifyChunkDataPackSealed", chunk.Index, s.result.ID()).Once()
+
+	_, err := s.reverifier.Reverify(context.Background(), s.result.ID(), chunk.Index)
+	s.Require().True(errors.Is(err, context.DeadlineExceeded))
+}
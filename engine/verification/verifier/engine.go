@@ -2,7 +2,10 @@ package verifier
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/onflow/crypto"
 	"github.com/onflow/crypto/hash"
@@ -26,26 +29,40 @@ import (
 	"github.com/onflow/flow-go/utils/logging"
 )
 
+// DefaultVerificationRecordsRetention is the default number of heights below the highest verified block, for which
+// the chunk verification records are kept.
+const DefaultVerificationRecordsRetention = uint64(1_000_000)
+
 // Engine (verifier engine) verifies chunks, generates result approvals or raises challenges.
 // as input it accepts verifiable chunks (chunk + all data needed) and perform verification by
 // constructing a partial trie, executing transactions and check the final state commitment and
 // other chunk meta data (e.g. tx count)
 type Engine struct {
-	unit           *engine.Unit               // used to control startup/shutdown
-	log            zerolog.Logger             // used to log relevant actions
-	metrics        module.VerificationMetrics // used to capture the performance metrics
-	tracer         module.Tracer              // used for tracing
-	pushConduit    network.Conduit            // used to push result approvals
-	pullConduit    network.Conduit            // used to respond to requests for result approvals
-	me             module.Local               // used to access local node information
-	state          protocol.State             // used to access the protocol state
-	approvalHasher hash.Hasher                // used as hasher to sign the result approvals
-	chVerif        module.ChunkVerifier       // used to verify chunks
-	spockHasher    hash.Hasher                // used for generating spocks
-	approvals      storage.ResultApprovals    // used to store result approvals
+	unit           *engine.Unit                     // used to control startup/shutdown
+	log            zerolog.Logger                   // used to log relevant actions
+	metrics        module.VerificationMetrics       // used to capture the performance metrics
+	tracer         module.Tracer                    // used for tracing
+	pushConduit    network.Conduit                  // used to push result approvals
+	pullConduit    network.Conduit                  // used to respond to requests for result approvals
+	me             module.Local                     // used to access local node information
+	state          protocol.State                   // used to access the protocol state
+	approvalHasher hash.Hasher                      // used as hasher to sign the result approvals
+	chVerif        module.ChunkVerifier             // used to verify chunks
+	spockHasher    hash.Hasher                      // used for generating spocks
+	approvals      storage.ResultApprovals          // used to store result approvals
+	records        storage.ChunkVerificationRecords // used to store the verification history
+
+	// recordsRetention is the number of heights below the highest verified block, for which verification records
+	// are kept. Records of lower heights are pruned. Zero disables pruning.
+	recordsRetention uint64
+	// pruneLock guards prunedHeight, and prevents concurrent pruning of the verification records.
+	pruneLock sync.Mutex
+	// prunedHeight is the height up to which the verification records were pruned.
+	prunedHeight uint64
 }
 
-// New creates and returns a new instance of a verifier engine.
+// New creates and returns a new instance of a verifier engine. The verification records of blocks more than
+// recordsRetention heights below the highest verified block are pruned, unless recordsRetention is zero.
 func New(
 	log zerolog.Logger,
 	metrics module.VerificationMetrics,
@@ -55,19 +72,23 @@ func New(
 	me module.Local,
 	chVerif module.ChunkVerifier,
 	approvals storage.ResultApprovals,
+	records storage.ChunkVerificationRecords,
+	recordsRetention uint64,
 ) (*Engine, error) {
 
 	e := &Engine{
-		unit:           engine.NewUnit(),
-		log:            log.With().Str("engine", "verifier").Logger(),
-		metrics:        metrics,
-		tracer:         tracer,
-		state:          state,
-		me:             me,
-		chVerif:        chVerif,
-		approvalHasher: utils.NewResultApprovalHasher(),
-		spockHasher:    signature.NewBLSHasher(signature.SPOCKTag),
-		approvals:      approvals,
+		unit:             engine.NewUnit(),
+		log:              log.With().Str("engine", "verifier").Logger(),
+		metrics:          metrics,
+		tracer:           tracer,
+		state:            state,
+		me:               me,
+		chVerif:          chVerif,
+		approvalHasher:   utils.NewResultApprovalHasher(),
+		spockHasher:      signature.NewBLSHasher(signature.SPOCKTag),
+		approvals:        approvals,
+		records:          records,
+		recordsRetention: recordsRetention,
 	}
 
 	var err error
@@ -189,14 +210,18 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 	// execute the assigned chunk
 	span, _ := e.tracer.StartSpanFromContext(ctx, trace.VERVerChunkVerify)
 
+	start := time.Now()
 	spockSecret, err := e.chVerif.Verify(vc)
+	verifyDuration := time.Since(start)
 	span.End()
 
+	// any error besides a ChunkFaultError is a system error
+	if err != nil && !chmodels.IsChunkFaultError(err) {
+		return fmt.Errorf("cannot verify chunk: %w", err)
+	}
+	e.recordVerification(log, vc, verifyDuration, err)
+
 	if err != nil {
-		// any error besides a ChunkFaultError is a system error
-		if !chmodels.IsChunkFaultError(err) {
-			return fmt.Errorf("cannot verify chunk: %w", err)
-		}
 
 		// if any fault found with the chunk
 		switch chFault := err.(type) {
@@ -314,6 +339,67 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 	return nil
 }
 
+// recordVerification persists the outcome of verifying the given chunk in the verification history, and reports it
+// to the metrics per execution node. Failing to persist the record is logged, but does not abort the verification.
+// chunkFault is the chunk fault found during verification, or nil if the chunk passed verification.
+func (e *Engine) recordVerification(log zerolog.Logger, vc *verification.VerifiableChunkData, verifyDuration time.Duration, chunkFault error) {
+	faultType := chmodels.ChunkFaultType(chunkFault)
+	for _, executorID := range vc.Executors {
+		e.metrics.OnChunkVerifiedForExecutor(executorID, faultType)
+	}
+	e.metrics.OnChunkDataPackFetchedFromExecutor(vc.ChunkDataPackOrigin, vc.FetchDuration)
+
+	record := &chmodels.VerificationRecord{
+		ResultID:            vc.Result.ID(),
+		ChunkIndex:          vc.Chunk.Index,
+		BlockID:             vc.Header.ID(),
+		BlockHeight:         vc.Header.Height,
+		Executors:           vc.Executors,
+		ChunkDataPackOrigin: vc.ChunkDataPackOrigin,
+		FaultType:           faultType,
+		FetchDuration:       vc.FetchDuration,
+		VerifyDuration:      verifyDuration,
+		VerifiedAt:          time.Now().UTC(),
+	}
+	if chunkFault != nil {
+		record.Fault = chunkFault.Error()
+	}
+
+	err := e.records.Store(record)
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		log.Error().Err(err).Msg("could not store chunk verification record")
+	}
+
+	e.pruneRecords(log, record.BlockHeight)
+}
+
+// pruneRecords prunes the verification records of blocks more than recordsRetention heights below the given height
+// of a verified block. Records are only pruned once the height increases, and verifications don't wait for pruning in
+// progress. Failing to prune the records is logged, and retried for the next verified block.
+func (e *Engine) pruneRecords(log zerolog.Logger, height uint64) {
+	if e.recordsRetention == 0 || height <= e.recordsRetention {
+		return
+	}
+	pruneHeight := height - e.recordsRetention
+
+	if !e.pruneLock.TryLock() {
+		// records are being pruned by another verification
+		return
+	}
+	defer e.pruneLock.Unlock()
+
+	if pruneHeight <= e.prunedHeight {
+		return
+	}
+
+	err := e.records.PruneUpToHeight(pruneHeight)
+	if err != nil {
+		log.Error().Err(err).Uint64("prune_height", pruneHeight).Msg("could not prune chunk verification records")
+		return
+	}
+	e.prunedHeight = pruneHeight
+}
+
 // GenerateResultApproval generates result approval for specific chunk of an execution receipt.
 func GenerateResultApproval(
 	me module.Local,
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	testifymock "github.com/stretchr/testify/mock"
@@ -43,6 +44,7 @@ type VerifierEngineTestSuite struct {
 	pullCon       *mocknetwork.Conduit
 	metrics       *mockmodule.VerificationMetrics // mocks performance monitoring metrics
 	approvals     *mockstorage.ResultApprovals
+	records       *mockstorage.ChunkVerificationRecords
 	chunkVerifier *mockmodule.ChunkVerifier
 }
 
@@ -60,6 +62,7 @@ func (suite *VerifierEngineTestSuite) SetupTest() {
 	suite.metrics = mockmodule.NewVerificationMetrics(suite.T())
 	suite.chain = flow.Testnet.Chain()
 	suite.approvals = mockstorage.NewResultApprovals(suite.T())
+	suite.records = mockstorage.NewChunkVerificationRecords(suite.T())
 	suite.chunkVerifier = mockmodule.NewChunkVerifier(suite.T())
 
 	suite.net.On("Register", channels.PushApprovals, testifymock.Anything).
@@ -95,6 +98,10 @@ func (suite *VerifierEngineTestSuite) SetupTest() {
 }
 
 func (suite *VerifierEngineTestSuite) getTestNewEngine() *verifier.Engine {
+	return suite.getTestNewEngineWithRecordsRetention(0)
+}
+
+func (suite *VerifierEngineTestSuite) getTestNewEngineWithRecordsRetention(recordsRetention uint64) *verifier.Engine {
 	e, err := verifier.New(
 		unittest.Logger(),
 		suite.metrics,
@@ -103,7 +110,9 @@ func (suite *VerifierEngineTestSuite) getTestNewEngine() *verifier.Engine {
 		suite.state,
 		suite.me,
 		suite.chunkVerifier,
-		suite.approvals)
+		suite.approvals,
+		suite.records,
+		recordsRetention)
 	require.Nil(suite.T(), err)
 
 	suite.net.AssertExpectations(suite.T())
@@ -122,6 +131,9 @@ func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 	suite.ss.On("Identities", testifymock.Anything).Return(consensusNodes, nil)
 
 	vChunk := unittest.VerifiableChunkDataFixture(uint64(0))
+	vChunk.Executors = unittest.IdentifierListFixture(2)
+	vChunk.ChunkDataPackOrigin = vChunk.Executors[0]
+	vChunk.FetchDuration = time.Second
 
 	tests := []struct {
 		name string
@@ -206,8 +218,33 @@ func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 				}).
 				Once()
 
+			// the outcome of the verification is persisted in the verification history
+			suite.records.
+				On("Store", testifymock.Anything).
+				Return(nil).
+				Run(func(args testifymock.Arguments) {
+					record, ok := args[0].(*chmodel.VerificationRecord)
+					suite.Require().True(ok)
+
+					suite.Assert().Equal(vChunk.Result.ID(), record.ResultID)
+					suite.Assert().Equal(vChunk.Chunk.Index, record.ChunkIndex)
+					suite.Assert().Equal(vChunk.Header.ID(), record.BlockID)
+					suite.Assert().Equal(vChunk.Header.Height, record.BlockHeight)
+					suite.Assert().Equal(vChunk.Executors, record.Executors)
+					suite.Assert().Equal(vChunk.ChunkDataPackOrigin, record.ChunkDataPackOrigin)
+					suite.Assert().Equal(vChunk.FetchDuration, record.FetchDuration)
+					suite.Assert().Equal(chmodel.ChunkFaultType(test.err), record.FaultType)
+					suite.Assert().Equal(test.err == nil, record.Valid())
+					suite.Assert().False(record.VerifiedAt.IsZero())
+				}).
+				Once()
+
 			suite.metrics.On("OnVerifiableChunkReceivedAtVerifierEngine").Return().Once()
 			suite.metrics.On("OnResultApprovalDispatchedInNetworkByVerifier").Return().Once()
+			for _, executorID := range vChunk.Executors {
+				suite.metrics.On("OnChunkVerifiedForExecutor", executorID, chmodel.ChunkFaultType(test.err)).Return().Once()
+			}
+			suite.metrics.On("OnChunkDataPackFetchedFromExecutor", vChunk.ChunkDataPackOrigin, vChunk.FetchDuration).Return().Once()
 
 			suite.chunkVerifier.On("Verify", vChunk).Return(nil, test.err).Once()
 
@@ -217,6 +254,34 @@ func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 	}
 }
 
+// TestVerificationRecordsPruning tests that the verification records of blocks more than the retention below the
+// highest verified block are pruned, once per height.
+func (suite *VerifierEngineTestSuite) TestVerificationRecordsPruning() {
+	eng := suite.getTestNewEngineWithRecordsRetention(10)
+
+	// records are pruned up to 10 heights below the highest verified block, once it increases
+	heights := []uint64{5, 10, 100, 100, 95, 101}
+	suite.records.On("PruneUpToHeight", uint64(90)).Return(nil).Once()
+	suite.records.On("PruneUpToHeight", uint64(91)).Return(nil).Once()
+
+	for i, height := range heights {
+		vc := unittest.VerifiableChunkDataFixture(uint64(i))
+		vc.Header.Height = height
+
+		// the chunk is faulty, so that no result approval is emitted
+		chunkFault := chmodel.NewCFInvalidVerifiableChunk("test", errors.New("test error"), vc.Chunk.Index, vc.Result.ID())
+		suite.chunkVerifier.On("Verify", vc).Return(nil, chunkFault).Once()
+		suite.records.On("Store", testifymock.MatchedBy(func(record *chmodel.VerificationRecord) bool {
+			return record.ResultID == vc.Result.ID() && record.BlockHeight == height
+		})).Return(nil).Once()
+		suite.metrics.On("OnVerifiableChunkReceivedAtVerifierEngine").Return().Once()
+		suite.metrics.On("OnChunkDataPackFetchedFromExecutor", vc.ChunkDataPackOrigin, vc.FetchDuration).Return().Once()
+
+		err := eng.ProcessLocal(vc)
+		suite.Require().NoError(err)
+	}
+}
+
 func (suite *VerifierEngineTestSuite) TestVerifyUnhappyPaths() {
 	eng := suite.getTestNewEngine()
 
@@ -307,6 +372,16 @@ func (suite *VerifierEngineTestSuite) TestVerifyUnhappyPaths() {
 		suite.chunkVerifier.On("Verify", vc).Return(nil, expectedErr).Once()
 
 		suite.metrics.On("OnVerifiableChunkReceivedAtVerifierEngine").Return().Once()
+		if chmodel.IsChunkFaultError(expectedErr) {
+			// chunk faults are persisted in the verification history, system errors are not
+			suite.records.
+				On("Store", testifymock.MatchedBy(func(record *chmodel.VerificationRecord) bool {
+					return record.ResultID == vc.Result.ID() && record.FaultType == chmodel.ChunkFaultType(expectedErr)
+				})).
+				Return(nil).
+				Once()
+			suite.metrics.On("OnChunkDataPackFetchedFromExecutor", vc.ChunkDataPackOrigin, vc.FetchDuration).Return().Once()
+		}
 		// note: we shouldn't publish any result approval or emit OnResultApprovalDispatchedInNetworkByVerifier
 
 		err := eng.ProcessLocal(vc)
@@ -440,12 +440,14 @@ func (builder *FollowerServiceBuilder) InitIDProviders() {
 
 		// The following wrapper allows to disallow-list byzantine nodes via an admin command:
 		// the wrapper overrides the 'Ejected' flag of the disallow-listed nodes to true
-		builder.IdentityProvider, err = cache.NewNodeDisallowListWrapper(idCache, node.DB, func() network.DisallowListNotificationConsumer {
+		disallowListWrapper, err := cache.NewNodeDisallowListWrapper(idCache, node.DB, func() network.DisallowListNotificationConsumer {
 			return builder.NetworkUnderlay
 		})
 		if err != nil {
 			return fmt.Errorf("could not initialize NodeBlockListWrapper: %w", err)
 		}
+		builder.IdentityProvider = disallowListWrapper
+		builder.DisallowListWrapper = disallowListWrapper
 
 		// use the default identifier provider
 		builder.SyncEngineParticipantsProviderFactory = func() module.IdentifierProvider {
@@ -625,6 +627,11 @@ func (builder *FollowerServiceBuilder) enqueuePublicNetworkInit() {
 			builder.ProtocolEvents.AddConsumer(idEvents)
 
 			return builder.EngineRegistry, nil
+		}).
+		// the disallow-list wrapper is started after the network, so that it re-applies the persisted
+		// disallow-list to the network on startup.
+		Component("disallow list", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			return builder.DisallowListWrapper, nil
 		})
 }
 
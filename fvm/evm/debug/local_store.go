@@ -0,0 +1,270 @@
+package debug
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// codeTrace prefixes the traces, keyed by block ID and transaction hash
+	codeTrace byte = 1
+	// codeTransaction prefixes the transaction index, keyed by transaction hash and block ID
+	codeTransaction byte = 2
+	// codeExpiry prefixes the retention index, keyed by storage time, block ID and transaction hash
+	codeExpiry byte = 3
+
+	timestampLength = 8
+
+	// pruneInterval is the maximum interval between two prunings triggered by uploads, a shorter retention period
+	// prunes more often
+	pruneInterval = time.Minute
+)
+
+var _ TraceStore = (*LocalTraceStore)(nil)
+
+// LocalTraceStore is a TraceStore storing the traces in a local pebble database.
+//
+// Traces older than the retention period are pruned while new traces are uploaded. A zero retention period keeps
+// the traces forever.
+type LocalTraceStore struct {
+	db        *pebble.DB
+	retention time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewLocalTraceStore creates a new LocalTraceStore using the given database. The database is not closed by the store.
+func NewLocalTraceStore(db *pebble.DB, retention time.Duration) *LocalTraceStore {
+	return &LocalTraceStore{
+		db:        db,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Upload stores the trace with the given ID, which must be created with TraceID. A trace stored with the same ID is
+// replaced.
+func (s *LocalTraceStore) Upload(id string, data json.RawMessage) error {
+	txHash, blockID, err := ParseTraceID(id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	// replacing a trace must also replace its retention entry
+	storedAt, _, err := s.trace(txHash, blockID)
+	if err == nil {
+		err = batch.Delete(expiryKey(storedAt, blockID, txHash), nil)
+		if err != nil {
+			return fmt.Errorf("could not delete retention entry of trace %s: %w", id, err)
+		}
+	} else if !errors.Is(err, ErrTraceNotFound) {
+		return err
+	}
+
+	value := make([]byte, timestampLength, timestampLength+len(data))
+	binary.BigEndian.PutUint64(value, uint64(now.UnixNano()))
+	value = append(value, data...)
+
+	if err := batch.Set(traceKey(blockID, txHash), value, nil); err != nil {
+		return fmt.Errorf("could not store trace %s: %w", id, err)
+	}
+	if err := batch.Set(transactionKey(txHash, blockID), nil, nil); err != nil {
+		return fmt.Errorf("could not index trace %s: %w", id, err)
+	}
+	if err := batch.Set(expiryKey(now, blockID, txHash), nil, nil); err != nil {
+		return fmt.Errorf("could not index retention of trace %s: %w", id, err)
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("could not commit trace %s: %w", id, err)
+	}
+
+	if s.retention > 0 && now.Sub(s.lastPruned) >= min(s.retention, pruneInterval) {
+		s.lastPruned = now
+		if _, err := s.prune(now.Add(-s.retention)); err != nil {
+			return fmt.Errorf("could not prune traces: %w", err)
+		}
+	}
+	return nil
+}
+
+// Trace returns the trace of the transaction executed in the given Flow block.
+// Expected errors:
+//   - ErrTraceNotFound if the trace is not stored
+func (s *LocalTraceStore) Trace(txHash gethCommon.Hash, blockID flow.Identifier) (json.RawMessage, error) {
+	_, data, err := s.trace(txHash, blockID)
+	return data, err
+}
+
+// BlockIDsByTransaction returns the IDs of the Flow blocks in which a trace of the transaction is stored.
+// Expected errors:
+//   - ErrTraceNotFound if no trace of the transaction is stored
+func (s *LocalTraceStore) BlockIDsByTransaction(txHash gethCommon.Hash) ([]flow.Identifier, error) {
+	prefix := append([]byte{codeTransaction}, txHash.Bytes()...)
+	var blockIDs []flow.Identifier
+	err := s.iterate(prefix, func(key []byte, _ []byte) error {
+		blockIDs = append(blockIDs, flow.HashToID(key[len(prefix):]))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(blockIDs) == 0 {
+		return nil, fmt.Errorf("no trace of transaction %s: %w", txHash, ErrTraceNotFound)
+	}
+	return blockIDs, nil
+}
+
+// TracesByBlockID returns the traces of the transactions executed in the given Flow block.
+// No error is returned if no trace is stored for the block.
+func (s *LocalTraceStore) TracesByBlockID(blockID flow.Identifier) (map[gethCommon.Hash]json.RawMessage, error) {
+	prefix := append([]byte{codeTrace}, blockID[:]...)
+	traces := make(map[gethCommon.Hash]json.RawMessage)
+	err := s.iterate(prefix, func(key []byte, value []byte) error {
+		traces[gethCommon.BytesToHash(key[len(prefix):])] = append(json.RawMessage{}, value[timestampLength:]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// Prune removes the traces stored before the given time, and returns the number of removed traces.
+func (s *LocalTraceStore) Prune(before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prune(before)
+}
+
+// prune removes the traces stored before the given time. The caller must hold the lock.
+func (s *LocalTraceStore) prune(before time.Time) (int, error) {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	pruned := 0
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{codeExpiry},
+		UpperBound: expiryKey(before, flow.ZeroID, gethCommon.Hash{}),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		blockID := flow.HashToID(key[1+timestampLength : 1+timestampLength+flow.IdentifierLen])
+		txHash := gethCommon.BytesToHash(key[1+timestampLength+flow.IdentifierLen:])
+
+		if err := batch.Delete(append([]byte{}, key...), nil); err != nil {
+			return 0, fmt.Errorf("could not delete retention entry: %w", err)
+		}
+		if err := batch.Delete(traceKey(blockID, txHash), nil); err != nil {
+			return 0, fmt.Errorf("could not delete trace: %w", err)
+		}
+		if err := batch.Delete(transactionKey(txHash, blockID), nil); err != nil {
+			return 0, fmt.Errorf("could not delete trace index: %w", err)
+		}
+		pruned++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("could not iterate retention entries: %w", err)
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return 0, fmt.Errorf("could not commit pruning: %w", err)
+	}
+	return pruned, nil
+}
+
+// trace returns the time the trace was stored at and the trace.
+// Expected errors:
+//   - ErrTraceNotFound if the trace is not stored
+func (s *LocalTraceStore) trace(txHash gethCommon.Hash, blockID flow.Identifier) (time.Time, json.RawMessage, error) {
+	value, closer, err := s.db.Get(traceKey(blockID, txHash))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return time.Time{}, nil, fmt.Errorf("no trace of transaction %s in block %v: %w", txHash, blockID, ErrTraceNotFound)
+		}
+		return time.Time{}, nil, fmt.Errorf("could not read trace: %w", err)
+	}
+	defer closer.Close()
+
+	if len(value) < timestampLength {
+		return time.Time{}, nil, fmt.Errorf("invalid trace of transaction %s in block %v", txHash, blockID)
+	}
+	storedAt := time.Unix(0, int64(binary.BigEndian.Uint64(value)))
+	return storedAt, append(json.RawMessage{}, value[timestampLength:]...), nil
+}
+
+// iterate calls the given function with all the keys with the given prefix and their values. The key and the value
+// are only valid until the function returns.
+func (s *LocalTraceStore) iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func traceKey(blockID flow.Identifier, txHash gethCommon.Hash) []byte {
+	key := make([]byte, 0, 1+flow.IdentifierLen+gethCommon.HashLength)
+	key = append(key, codeTrace)
+	key = append(key, blockID[:]...)
+	return append(key, txHash.Bytes()...)
+}
+
+func transactionKey(txHash gethCommon.Hash, blockID flow.Identifier) []byte {
+	key := make([]byte, 0, 1+gethCommon.HashLength+flow.IdentifierLen)
+	key = append(key, codeTransaction)
+	key = append(key, txHash.Bytes()...)
+	return append(key, blockID[:]...)
+}
+
+func expiryKey(storedAt time.Time, blockID flow.Identifier, txHash gethCommon.Hash) []byte {
+	key := make([]byte, 1+timestampLength, 1+timestampLength+flow.IdentifierLen+gethCommon.HashLength)
+	key[0] = codeExpiry
+	binary.BigEndian.PutUint64(key[1:], uint64(storedAt.UnixNano()))
+	key = append(key, blockID[:]...)
+	return append(key, txHash.Bytes()...)
+}
+
+// prefixUpperBound returns the lowest key greater than all the keys with the given prefix.
+func prefixUpperBound(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
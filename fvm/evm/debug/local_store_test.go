@@ -0,0 +1,135 @@
+package debug_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func Test_TraceID(t *testing.T) {
+	txHash := testutils.RandomCommonHash(t)
+	blockID := unittest.IdentifierFixture()
+
+	parsedHash, parsedID, err := debug.ParseTraceID(debug.TraceID(txHash, blockID))
+	require.NoError(t, err)
+	require.Equal(t, txHash, parsedHash)
+	require.Equal(t, blockID, parsedID)
+
+	for _, id := range []string{"", "test_p", blockID.String(), blockID.String() + "-0x01"} {
+		_, _, err := debug.ParseTraceID(id)
+		require.Error(t, err, id)
+	}
+}
+
+func Test_LocalTraceStore(t *testing.T) {
+	unittest.RunWithPebbleDB(t, func(db *pebble.DB) {
+		store := debug.NewLocalTraceStore(db, 0)
+
+		txHash := testutils.RandomCommonHash(t)
+		otherTxHash := testutils.RandomCommonHash(t)
+		blockID := unittest.IdentifierFixture()
+		forkBlockID := unittest.IdentifierFixture()
+
+		trace := json.RawMessage(`{"type":"CALL"}`)
+		forkTrace := json.RawMessage(`{"type":"CREATE"}`)
+		otherTrace := json.RawMessage(`{"type":"CALL","value":"0x1"}`)
+
+		t.Run("missing traces", func(t *testing.T) {
+			_, err := store.Trace(txHash, blockID)
+			require.ErrorIs(t, err, debug.ErrTraceNotFound)
+
+			_, err = store.BlockIDsByTransaction(txHash)
+			require.ErrorIs(t, err, debug.ErrTraceNotFound)
+
+			traces, err := store.TracesByBlockID(blockID)
+			require.NoError(t, err)
+			require.Empty(t, traces)
+		})
+
+		t.Run("invalid trace ID", func(t *testing.T) {
+			require.Error(t, store.Upload("test_p", trace))
+		})
+
+		t.Run("store traces", func(t *testing.T) {
+			require.NoError(t, store.Upload(debug.TraceID(txHash, blockID), trace))
+			require.NoError(t, store.Upload(debug.TraceID(txHash, forkBlockID), forkTrace))
+			require.NoError(t, store.Upload(debug.TraceID(otherTxHash, blockID), otherTrace))
+
+			stored, err := store.Trace(txHash, blockID)
+			require.NoError(t, err)
+			require.JSONEq(t, string(trace), string(stored))
+
+			stored, err = store.Trace(txHash, forkBlockID)
+			require.NoError(t, err)
+			require.JSONEq(t, string(forkTrace), string(stored))
+
+			blockIDs, err := store.BlockIDsByTransaction(txHash)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []flow.Identifier{blockID, forkBlockID}, blockIDs)
+
+			traces, err := store.TracesByBlockID(blockID)
+			require.NoError(t, err)
+			require.Len(t, traces, 2)
+			require.JSONEq(t, string(trace), string(traces[txHash]))
+			require.JSONEq(t, string(otherTrace), string(traces[otherTxHash]))
+		})
+
+		t.Run("replace trace", func(t *testing.T) {
+			require.NoError(t, store.Upload(debug.TraceID(otherTxHash, blockID), trace))
+
+			stored, err := store.Trace(otherTxHash, blockID)
+			require.NoError(t, err)
+			require.JSONEq(t, string(trace), string(stored))
+		})
+
+		t.Run("prune traces", func(t *testing.T) {
+			pruned, err := store.Prune(time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			require.Zero(t, pruned)
+
+			pruned, err = store.Prune(time.Now().Add(time.Second))
+			require.NoError(t, err)
+			require.Equal(t, 3, pruned)
+
+			_, err = store.Trace(txHash, blockID)
+			require.ErrorIs(t, err, debug.ErrTraceNotFound)
+
+			_, err = store.BlockIDsByTransaction(txHash)
+			require.ErrorIs(t, err, debug.ErrTraceNotFound)
+
+			traces, err := store.TracesByBlockID(blockID)
+			require.NoError(t, err)
+			require.Empty(t, traces)
+		})
+	})
+}
+
+func Test_LocalTraceStoreRetention(t *testing.T) {
+	unittest.RunWithPebbleDB(t, func(db *pebble.DB) {
+		store := debug.NewLocalTraceStore(db, time.Millisecond)
+
+		txHash := testutils.RandomCommonHash(t)
+		blockID := unittest.IdentifierFixture()
+		require.NoError(t, store.Upload(debug.TraceID(txHash, blockID), json.RawMessage(`{}`)))
+
+		time.Sleep(10 * time.Millisecond)
+
+		// the upload prunes the expired traces
+		otherTxHash := testutils.RandomCommonHash(t)
+		require.NoError(t, store.Upload(debug.TraceID(otherTxHash, blockID), json.RawMessage(`{}`)))
+
+		_, err := store.Trace(txHash, blockID)
+		require.ErrorIs(t, err, debug.ErrTraceNotFound)
+
+		_, err = store.Trace(otherTxHash, blockID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrTraceNotFound is returned when a trace is not stored.
+var ErrTraceNotFound = errors.New("trace not found")
+
+// TraceStore stores the call traces of EVM transactions, indexed by the EVM transaction hash and the ID of the
+// Flow block the transaction was executed in.
+//
+// A TraceStore is an Uploader, so it can be used as the destination of a CallTracer. The uploaded IDs must be
+// created with TraceID.
+type TraceStore interface {
+	Uploader
+
+	// Trace returns the trace of the transaction executed in the given Flow block.
+	// Expected errors:
+	//   - ErrTraceNotFound if the trace is not stored
+	Trace(txHash gethCommon.Hash, blockID flow.Identifier) (json.RawMessage, error)
+
+	// BlockIDsByTransaction returns the IDs of the Flow blocks in which a trace of the transaction is stored.
+	// A transaction can have several traces, if it was executed in blocks of different forks.
+	// Expected errors:
+	//   - ErrTraceNotFound if no trace of the transaction is stored
+	BlockIDsByTransaction(txHash gethCommon.Hash) ([]flow.Identifier, error)
+
+	// TracesByBlockID returns the traces of the transactions executed in the given Flow block.
+	// No error is returned if no trace is stored for the block.
+	TracesByBlockID(blockID flow.Identifier) (map[gethCommon.Hash]json.RawMessage, error)
+}
+
+// ParseTraceID returns the transaction hash and the Flow block ID of a trace ID created with TraceID.
+func ParseTraceID(id string) (gethCommon.Hash, flow.Identifier, error) {
+	blockHex, txHex, found := strings.Cut(id, "-")
+	if !found {
+		return gethCommon.Hash{}, flow.ZeroID, fmt.Errorf("invalid trace ID %s", id)
+	}
+	blockID, err := flow.HexStringToIdentifier(blockHex)
+	if err != nil {
+		return gethCommon.Hash{}, flow.ZeroID, fmt.Errorf("invalid block ID in trace ID %s: %w", id, err)
+	}
+	txBytes, err := hexutil.Decode(txHex)
+	if err != nil || len(txBytes) != gethCommon.HashLength {
+		return gethCommon.Hash{}, flow.ZeroID, fmt.Errorf("invalid transaction hash in trace ID %s", id)
+	}
+	return gethCommon.BytesToHash(txBytes), blockID, nil
+}
@@ -0,0 +1,197 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/holiman/uint256"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethCrypto "github.com/onflow/go-ethereum/crypto"
+	"github.com/onflow/go-ethereum/rlp"
+	gethTrie "github.com/onflow/go-ethereum/trie"
+
+	"github.com/onflow/flow-go/fvm/evm/types"
+)
+
+// IntegrityReport is the result of the integrity check of an EVM state, see CheckIntegrity.
+type IntegrityReport struct {
+	Accounts  int `json:"accounts"`
+	Contracts int `json:"contracts"`
+	Codes     int `json:"codes"`
+	Slots     int `json:"slots"`
+	// TotalBalance is the sum of the balances of all accounts, in atto-FLOW
+	TotalBalance *big.Int `json:"totalBalance"`
+	// StateRoot is the root of the Ethereum Merkle-Patricia state trie of the state
+	StateRoot gethCommon.Hash `json:"stateRoot"`
+	// Issues lists the inconsistencies found in the state, the state is consistent if it is empty
+	Issues []string `json:"issues"`
+}
+
+// CheckIntegrity checks the internal consistency of the given EVM state, and computes its state root:
+//   - the hash of every code matches the code, and its reference count matches the accounts using it
+//   - every account with code references a stored code
+//   - the storage slots belong to contract accounts with a slot collection, and no empty value is stored
+//   - the balances of all accounts sum to the given expected total, unless it is nil
+//
+// The inconsistencies are listed in the report. An error is only returned if the state root can not be computed.
+func CheckIntegrity(st *EVMState, expectedTotalBalance *big.Int) (*IntegrityReport, error) {
+	report := &IntegrityReport{
+		Accounts:     len(st.Accounts),
+		Codes:        len(st.Codes),
+		TotalBalance: big.NewInt(0),
+		Issues:       make([]string, 0),
+	}
+	issue := func(format string, args ...interface{}) {
+		report.Issues = append(report.Issues, fmt.Sprintf(format, args...))
+	}
+
+	refCounts := make(map[gethCommon.Hash]uint64)
+	for _, addr := range sortedAddresses(st.Accounts) {
+		acc := st.Accounts[addr]
+		if acc.Address != addr {
+			issue("account %s is stored under address %s", acc.Address, addr)
+		}
+		if acc.Balance != nil {
+			report.TotalBalance.Add(report.TotalBalance, acc.Balance.ToBig())
+		}
+		if acc.HasCode() {
+			report.Contracts++
+			refCounts[acc.CodeHash]++
+			if _, ok := st.Codes[acc.CodeHash]; !ok {
+				issue("code %s of account %s is not stored", acc.CodeHash, addr)
+			}
+		}
+
+		slots := st.Slots[addr]
+		report.Slots += len(slots)
+		if len(slots) > 0 && !acc.HasCode() {
+			issue("account %s has %d storage slots but no code", addr, len(slots))
+		}
+		if len(slots) > 0 && !acc.HasStoredValues() {
+			issue("account %s has %d storage slots but no slot collection", addr, len(slots))
+		}
+		for key, slot := range slots {
+			if slot.Address != addr || slot.Key != key {
+				issue("slot %s of account %s is stored as slot %s of account %s", key, addr, slot.Key, slot.Address)
+			}
+			if slot.Value == EmptyHash {
+				issue("slot %s of account %s stores an empty value", key, addr)
+			}
+		}
+	}
+
+	for _, addr := range sortedAddresses(st.Slots) {
+		if _, ok := st.Accounts[addr]; !ok {
+			issue("%d storage slots belong to the non-existing account %s", len(st.Slots[addr]), addr)
+		}
+	}
+
+	for _, hash := range sortedHashes(st.Codes) {
+		cic := st.Codes[hash]
+		if computed := gethCrypto.Keccak256Hash(cic.Code); computed != hash || cic.Hash != hash {
+			issue("code %s has hash %s and is stored under hash %s", cic.Hash, computed, hash)
+		}
+		if cic.RefCounts != refCounts[hash] {
+			issue("code %s has reference count %d but is used by %d accounts", hash, cic.RefCounts, refCounts[hash])
+		}
+	}
+
+	if expectedTotalBalance != nil && report.TotalBalance.Cmp(expectedTotalBalance) != 0 {
+		issue("balances sum to %s but the expected total is %s", report.TotalBalance, expectedTotalBalance)
+	}
+
+	root, err := StateRoot(st)
+	if err != nil {
+		return nil, err
+	}
+	report.StateRoot = root
+	return report, nil
+}
+
+// StateRoot returns the root of the Ethereum Merkle-Patricia state trie of the given EVM state, as computed by other
+// EVM implementations for the same accounts, codes and storage slots.
+func StateRoot(st *EVMState) (gethCommon.Hash, error) {
+	leaves := make([]trieLeaf, 0, len(st.Accounts))
+	for addr, acc := range st.Accounts {
+		storageRoot, err := StorageRoot(st.Slots[addr])
+		if err != nil {
+			return gethCommon.Hash{}, fmt.Errorf("could not compute storage root of account %s: %w", addr, err)
+		}
+		account := &gethTypes.StateAccount{
+			Nonce:    acc.Nonce,
+			Balance:  acc.Balance,
+			Root:     storageRoot,
+			CodeHash: acc.CodeHash.Bytes(),
+		}
+		if account.Balance == nil {
+			account.Balance = new(uint256.Int)
+		}
+		encoded, err := rlp.EncodeToBytes(account)
+		if err != nil {
+			return gethCommon.Hash{}, fmt.Errorf("could not encode account %s: %w", addr, err)
+		}
+		leaves = append(leaves, trieLeaf{key: gethCrypto.Keccak256(addr.Bytes()), value: encoded})
+	}
+	return trieRoot(leaves)
+}
+
+// StorageRoot returns the root of the Ethereum Merkle-Patricia storage trie of the given storage slots.
+func StorageRoot(slots map[gethCommon.Hash]*types.SlotEntry) (gethCommon.Hash, error) {
+	leaves := make([]trieLeaf, 0, len(slots))
+	for key, slot := range slots {
+		if slot.Value == EmptyHash {
+			continue
+		}
+		encoded, err := rlp.EncodeToBytes(gethCommon.TrimLeftZeroes(slot.Value.Bytes()))
+		if err != nil {
+			return gethCommon.Hash{}, fmt.Errorf("could not encode slot %s: %w", key, err)
+		}
+		leaves = append(leaves, trieLeaf{key: gethCrypto.Keccak256(key.Bytes()), value: encoded})
+	}
+	return trieRoot(leaves)
+}
+
+type trieLeaf struct {
+	key   []byte
+	value []byte
+}
+
+// trieRoot returns the root of the trie of the given leaves.
+func trieRoot(leaves []trieLeaf) (gethCommon.Hash, error) {
+	// the stack trie requires the keys to be inserted in order
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].key, leaves[j].key) < 0
+	})
+	trie := gethTrie.NewStackTrie(nil)
+	for _, leaf := range leaves {
+		if err := trie.Update(leaf.key, leaf.value); err != nil {
+			return gethCommon.Hash{}, err
+		}
+	}
+	return trie.Hash(), nil
+}
+
+func sortedAddresses[V any](m map[gethCommon.Address]V) []gethCommon.Address {
+	addresses := make([]gethCommon.Address, 0, len(m))
+	for addr := range m {
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	return addresses
+}
+
+func sortedHashes[V any](m map[gethCommon.Hash]V) []gethCommon.Hash {
+	hashes := make([]gethCommon.Hash, 0, len(m))
+	for hash := range m {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i].Bytes(), hashes[j].Bytes()) < 0
+	})
+	return hashes
+}
@@ -0,0 +1,103 @@
+package state_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/core/rawdb"
+	gethState "github.com/onflow/go-ethereum/core/state"
+	"github.com/onflow/go-ethereum/core/tracing"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethCrypto "github.com/onflow/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/emulator/state"
+	"github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	t.Parallel()
+
+	eoa := testutils.RandomCommonAddress(t)
+	contract1 := testutils.RandomCommonAddress(t)
+	contract2 := testutils.RandomCommonAddress(t)
+	code := []byte("code")
+	codeHash := gethCrypto.Keccak256Hash(code)
+	slots := map[gethCommon.Hash]gethCommon.Hash{
+		{1}: {2},
+		{3}: gethCommon.BigToHash(big.NewInt(4)),
+	}
+
+	// extractState returns the state with an EOA and two contracts sharing the same code
+	extractState := func(t *testing.T) *state.EVMState {
+		ledger := testutils.GetSimpleValueStore()
+		rootAddr := flow.Address{1, 2, 3, 4, 5, 6, 7, 8}
+		view, err := state.NewBaseView(ledger, rootAddr)
+		require.NoError(t, err)
+
+		require.NoError(t, view.CreateAccount(eoa, uint256.NewInt(100), 1, nil, gethTypes.EmptyCodeHash))
+		require.NoError(t, view.CreateAccount(contract1, uint256.NewInt(20), 2, code, codeHash))
+		require.NoError(t, view.CreateAccount(contract2, uint256.NewInt(3), 3, code, codeHash))
+		for key, value := range slots {
+			require.NoError(t, view.UpdateSlot(types.SlotAddress{Address: contract1, Key: key}, value))
+		}
+		require.NoError(t, view.Commit())
+
+		st, err := state.Extract(rootAddr, view)
+		require.NoError(t, err)
+		return st
+	}
+
+	t.Run("consistent state", func(t *testing.T) {
+		st := extractState(t)
+
+		report, err := state.CheckIntegrity(st, big.NewInt(123))
+		require.NoError(t, err)
+		require.Empty(t, report.Issues)
+		require.Equal(t, 3, report.Accounts)
+		require.Equal(t, 2, report.Contracts)
+		require.Equal(t, 1, report.Codes)
+		require.Equal(t, 2, report.Slots)
+		require.Equal(t, big.NewInt(123), report.TotalBalance)
+
+		// the state root matches the root computed by geth for the same state
+		gethStateDB, err := gethState.New(gethTypes.EmptyRootHash, gethState.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		require.NoError(t, err)
+		gethStateDB.SetBalance(eoa, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+		gethStateDB.SetNonce(eoa, 1)
+		gethStateDB.SetBalance(contract1, uint256.NewInt(20), tracing.BalanceChangeUnspecified)
+		gethStateDB.SetNonce(contract1, 2)
+		gethStateDB.SetCode(contract1, code)
+		for key, value := range slots {
+			gethStateDB.SetState(contract1, key, value)
+		}
+		gethStateDB.SetBalance(contract2, uint256.NewInt(3), tracing.BalanceChangeUnspecified)
+		gethStateDB.SetNonce(contract2, 3)
+		gethStateDB.SetCode(contract2, code)
+		require.Equal(t, gethStateDB.IntermediateRoot(true), report.StateRoot)
+	})
+
+	t.Run("inconsistent state", func(t *testing.T) {
+		st := extractState(t)
+		st.Codes[codeHash].RefCounts = 1
+		st.Slots[contract1][gethCommon.Hash{5}] = &types.SlotEntry{Address: contract2, Key: gethCommon.Hash{5}}
+		otherCode := []byte("other code")
+		st.Codes[gethCommon.Hash{6}] = &state.CodeInContext{Hash: gethCommon.Hash{6}, Code: otherCode}
+		st.Slots[testutils.RandomCommonAddress(t)] = map[gethCommon.Hash]*types.SlotEntry{}
+
+		report, err := state.CheckIntegrity(st, big.NewInt(100))
+		require.NoError(t, err)
+		require.Len(t, report.Issues, 6, report.Issues)
+	})
+
+	t.Run("empty state", func(t *testing.T) {
+		report, err := state.CheckIntegrity(&state.EVMState{}, big.NewInt(0))
+		require.NoError(t, err)
+		require.Empty(t, report.Issues)
+		require.Equal(t, gethTypes.EmptyRootHash, report.StateRoot)
+	})
+}
@@ -1,6 +1,8 @@
 package derived
 
 import (
+	"sort"
+
 	"github.com/onflow/cadence/common"
 )
 
@@ -54,3 +56,17 @@ func (d ProgramDependencies) ContainsLocation(location common.Location) bool {
 	_, ok := d.locations[location]
 	return ok
 }
+
+// AddressLocations returns the address locations of the dependencies, sorted by ID.
+func (d ProgramDependencies) AddressLocations() []common.AddressLocation {
+	locations := make([]common.AddressLocation, 0, len(d.locations))
+	for location := range d.locations {
+		if addressLocation, ok := location.(common.AddressLocation); ok {
+			locations = append(locations, addressLocation)
+		}
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].ID() < locations[j].ID()
+	})
+	return locations
+}
@@ -58,3 +58,17 @@ func TestProgramDependencies_ContainsLocation(t *testing.T) {
 
 	require.True(t, d.ContainsLocation(location))
 }
+
+func TestProgramDependencies_AddressLocations(t *testing.T) {
+	d := derived.NewProgramDependencies()
+	require.Empty(t, d.AddressLocations())
+
+	address, _ := common.HexToAddress("0xa")
+	b := common.AddressLocation{Address: address, Name: "B"}
+	a := common.AddressLocation{Address: address, Name: "A"}
+	d.Add(common.StringLocation("test"))
+	d.Add(b)
+	d.Add(a)
+
+	require.Equal(t, []common.AddressLocation{a, b}, d.AddressLocations())
+}
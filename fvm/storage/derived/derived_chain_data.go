@@ -89,3 +89,21 @@ func (chain *DerivedChainData) NewDerivedBlockDataForScript(
 
 	return NewEmptyDerivedBlockData(0)
 }
+
+// AddDerivedBlockData sets the derived data of the given block, unless the
+// block already has derived data.  It returns true if the derived data was
+// added.
+func (chain *DerivedChainData) AddDerivedBlockData(
+	currentBlockId flow.Identifier,
+	derivedBlockData *DerivedBlockData,
+) bool {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	if chain.unsafeGet(currentBlockId) != nil {
+		return false
+	}
+
+	chain.lru.Add(currentBlockId, derivedBlockData)
+	return true
+}
@@ -0,0 +1,90 @@
+package derived
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/common"
+
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// PersistedProgram describes a program cached by a DerivedBlockData, so that the program can be derived again
+// ahead of execution after a restart.
+//
+// Cadence programs can't be serialized. Instead, a persisted program records the location of the program, the
+// locations of the programs it depends on, and the versions of the contract code registers it was derived from,
+// which tell whether the program is still valid against a given state.
+type PersistedProgram struct {
+	Location     common.AddressLocation
+	Dependencies []common.AddressLocation
+	Registers    []RegisterVersion
+}
+
+// RegisterVersion is the version of a register, identified by the hash of its value.
+type RegisterVersion struct {
+	ID   flow.RegisterID
+	Hash flow.Identifier
+}
+
+// NewRegisterVersion returns the version of the register with the given value.
+func NewRegisterVersion(id flow.RegisterID, value flow.RegisterValue) RegisterVersion {
+	return RegisterVersion{
+		ID:   id,
+		Hash: flow.MakeIDFromFingerPrint(value),
+	}
+}
+
+// Valid returns true if all registers the program was derived from have the same version in the given snapshot.
+// No errors are expected during normal operation.
+func (p *PersistedProgram) Valid(storageSnapshot snapshot.StorageSnapshot) (bool, error) {
+	for _, register := range p.Registers {
+		value, err := storageSnapshot.Get(register.ID)
+		if err != nil {
+			return false, fmt.Errorf("could not read register %s of program %s: %w", register.ID, p.Location, err)
+		}
+		if NewRegisterVersion(register.ID, value) != register {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// PersistedPrograms returns the programs cached by the block, sorted by location ID. The versions of the contract
+// code registers of the programs and their dependencies are read from the given snapshot, which must be the state
+// the block data was derived from.
+// No errors are expected during normal operation.
+func (block *DerivedBlockData) PersistedPrograms(
+	storageSnapshot snapshot.StorageSnapshot,
+) (
+	[]*PersistedProgram,
+	error,
+) {
+	programs := block.programs.values()
+
+	persisted := make([]*PersistedProgram, 0, len(programs))
+	for location, program := range programs {
+		dependencies := program.Dependencies.AddressLocations()
+		registers := make([]RegisterVersion, 0, len(dependencies))
+		for _, dependency := range dependencies {
+			id := flow.ContractRegisterID(flow.ConvertAddress(dependency.Address), dependency.Name)
+			value, err := storageSnapshot.Get(id)
+			if err != nil {
+				return nil, fmt.Errorf("could not read register %s of program %s: %w", id, location, err)
+			}
+			registers = append(registers, NewRegisterVersion(id, value))
+		}
+
+		persisted = append(persisted, &PersistedProgram{
+			Location:     location,
+			Dependencies: dependencies,
+			Registers:    registers,
+		})
+	}
+
+	sort.Slice(persisted, func(i, j int) bool {
+		return persisted[i].Location.ID() < persisted[j].Location.ID()
+	})
+	return persisted, nil
+}
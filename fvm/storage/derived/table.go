@@ -148,6 +148,22 @@ func (table *DerivedDataTable[TKey, TVal]) EntriesForTestingOnly() map[TKey]*inv
 	return entries
 }
 
+// values returns the values of the valid entries of the table, by key.
+func (table *DerivedDataTable[TKey, TVal]) values() map[TKey]TVal {
+	table.lock.RLock()
+	defer table.lock.RUnlock()
+
+	values := make(map[TKey]TVal, len(table.items))
+	for key, entry := range table.items {
+		if entry.isInvalid {
+			continue
+		}
+		values[key] = entry.Value
+	}
+
+	return values
+}
+
 func (table *DerivedDataTable[TKey, TVal]) InvalidatorsForTestingOnly() chainedTableInvalidators[TKey, TVal] {
 	table.lock.RLock()
 	defer table.lock.RUnlock()
@@ -133,6 +133,12 @@ func (c *CorruptGossipSubAdapter) PeerScoreExposer() p2p.PeerScoreExposer {
 	return c.peerScoreExposer
 }
 
+// SpamPenalty returns the penalty applied to the application specific score of the given peer for its GossipSub
+// control message misbehavior. The corrupt gossipsub adapter does not keep spam records, hence it always returns false.
+func (c *CorruptGossipSubAdapter) SpamPenalty(peer.ID) (float64, bool) {
+	return 0, false
+}
+
 func NewCorruptGossipSubAdapter(ctx context.Context,
 	logger zerolog.Logger,
 	h host.Host,
@@ -59,6 +59,37 @@ type Compactor struct {
 	trieUpdateCh                         <-chan *WALTrieUpdate
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool // to trigger checkpoint manually
 	metrics                              module.WALMetrics
+
+	// fullCheckpointInterval is the number of checkpoints from one full checkpoint to the next.
+	// Checkpoints in between are incremental checkpoints on top of the previous checkpoint.
+	// Values of 0 and 1 mean that every checkpoint is a full checkpoint.
+	fullCheckpointInterval uint
+	// trieByRootHash looks up the tries of the last checkpoint in the ledger state.
+	trieByRootHash func(rootHash ledger.RootHash) (*trie.MTrie, error)
+	// retainTries retains the paged out payloads of the tries to checkpoint in the ledger state,
+	// until the returned function is called, so that they can be read after they were evicted.
+	retainTries func(tries []*trie.MTrie) (release func())
+
+	// The following fields are only accessed by the checkpointing goroutine,
+	// which is limited to one at a time.
+	// Only the root hashes of the tries of the last checkpoint are kept, because holding on to the
+	// tries would keep all their nodes in memory after the tries are evicted from the ledger state.
+	lastCheckpointNum        int
+	lastCheckpointRootHashes []ledger.RootHash // root hashes of tries of last checkpoint, used as base for incremental checkpoints
+	checkpointsSinceFull     uint              // number of incremental checkpoints since last full checkpoint
+}
+
+// CompactorOption is an option for the Compactor.
+type CompactorOption func(*Compactor)
+
+// WithFullCheckpointInterval sets the number of checkpoints from one full checkpoint to the next.
+// The checkpoints in between are incremental checkpoints, which only contain the trie nodes
+// created since the previous checkpoint. The first checkpoint after startup is always a full
+// checkpoint.
+func WithFullCheckpointInterval(interval uint) CompactorOption {
+	return func(c *Compactor) {
+		c.fullCheckpointInterval = interval
+	}
 }
 
 // NewCompactor creates new Compactor which writes WAL record and triggers
@@ -79,6 +110,7 @@ func NewCompactor(
 	checkpointsToKeep uint,
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool,
 	metrics module.WALMetrics,
+	opts ...CompactorOption,
 ) (*Compactor, error) {
 	if checkpointDistance < 1 {
 		checkpointDistance = 1
@@ -105,7 +137,7 @@ func NewCompactor(
 	// Create trieQueue with initial values from ledger state.
 	trieQueue := realWAL.NewTrieQueueWithValues(checkpointCapacity, tries)
 
-	return &Compactor{
+	compactor := &Compactor{
 		checkpointer:                         checkpointer,
 		wal:                                  w,
 		trieQueue:                            trieQueue,
@@ -118,7 +150,16 @@ func NewCompactor(
 		checkpointsToKeep:                    checkpointsToKeep,
 		triggerCheckpointOnNextSegmentFinish: triggerCheckpointOnNextSegmentFinish,
 		metrics:                              metrics,
-	}, nil
+		trieByRootHash:                       l.Trie,
+		retainTries:                          l.RetainTries,
+		lastCheckpointNum:                    -1,
+	}
+
+	for _, opt := range opts {
+		opt(compactor)
+	}
+
+	return compactor, nil
 }
 
 // Subscribe subscribes observer to Compactor.
@@ -243,7 +284,8 @@ Loop:
 
 			var checkpointNum int
 			var checkpointTries []*trie.MTrie
-			activeSegmentNum, checkpointNum, checkpointTries =
+			var releaseCheckpointTries func()
+			activeSegmentNum, checkpointNum, checkpointTries, releaseCheckpointTries =
 				c.processTrieUpdate(update, c.trieQueue, activeSegmentNum, nextCheckpointNum)
 
 			if checkpointTries == nil {
@@ -259,10 +301,12 @@ Loop:
 
 				go func() {
 					defer checkpointSem.Release(1)
+					defer releaseCheckpointTries()
 					err := c.checkpoint(ctx, checkpointTries, checkpointNum)
 					checkpointResultCh <- checkpointResult{checkpointNum, err}
 				}()
 			} else {
+				releaseCheckpointTries()
 				// Failed to get semaphore because checkpointing is running.
 				// Try again when active segment is finalized.
 				c.logger.Info().Msgf("compactor delayed checkpoint %d because prior checkpointing is ongoing", nextCheckpointNum)
@@ -292,9 +336,32 @@ Loop:
 // Since this function is only for checkpointing, Compactor isn't affected by returned error.
 func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpointNum int) error {
 
-	err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
-	if err != nil {
-		return &createCheckpointError{num: checkpointNum, err: err}
+	var baseTries []*trie.MTrie
+	if c.lastCheckpointRootHashes != nil && c.checkpointsSinceFull+1 < c.fullCheckpointInterval {
+		baseTries = c.lastCheckpointTries(tries)
+	}
+
+	if baseTries != nil {
+		err := createIncrementalCheckpoint(c.checkpointer, c.logger, c.lastCheckpointNum, baseTries, tries, checkpointNum, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+		c.checkpointsSinceFull++
+	} else {
+		err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+		c.checkpointsSinceFull = 0
+	}
+
+	// Incremental checkpoints need the tries of the previous checkpoint as base.
+	if c.fullCheckpointInterval > 1 {
+		c.lastCheckpointNum = checkpointNum
+		c.lastCheckpointRootHashes = make([]ledger.RootHash, len(tries))
+		for i, t := range tries {
+			c.lastCheckpointRootHashes[i] = t.RootHash()
+		}
 	}
 
 	// Return if context is canceled.
@@ -304,7 +371,7 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 	default:
 	}
 
-	err = cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
+	err := cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
 	if err != nil {
 		return &removeCheckpointError{err: err}
 	}
@@ -326,6 +393,45 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 	return nil
 }
 
+// lastCheckpointTries returns the tries of the last checkpoint, in the order they were checkpointed.
+// The tries are looked up by root hash among the given tries of the new checkpoint and in the ledger
+// state. Tries which are no longer in memory are replaced by empty tries, so that the incremental
+// checkpoint doesn't reference their subtries, while the positions of the other tries are preserved.
+// Returns nil if none of the tries are in memory, in which case a full checkpoint must be created.
+func (c *Compactor) lastCheckpointTries(tries []*trie.MTrie) []*trie.MTrie {
+	byRootHash := make(map[ledger.RootHash]*trie.MTrie, len(tries))
+	for _, t := range tries {
+		byRootHash[t.RootHash()] = t
+	}
+
+	baseTries := make([]*trie.MTrie, len(c.lastCheckpointRootHashes))
+	found := 0
+	for i, rootHash := range c.lastCheckpointRootHashes {
+		t, ok := byRootHash[rootHash]
+		if !ok {
+			var err error
+			t, err = c.trieByRootHash(rootHash)
+			if err != nil {
+				baseTries[i] = trie.NewEmptyMTrie()
+				continue
+			}
+		}
+		baseTries[i] = t
+		found++
+	}
+
+	c.logger.Info().
+		Int("base_checkpoint", c.lastCheckpointNum).
+		Int("base_tries", len(baseTries)).
+		Int("base_tries_in_memory", found).
+		Msg("looked up tries of base checkpoint")
+
+	if found == 0 {
+		return nil
+	}
+	return baseTries
+}
+
 // createCheckpoint creates checkpoint with given checkpointNum and tries.
 // Errors indicate that checkpoint file can't be created.
 // Caller should handle returned errors by retrying checkpointing when appropriate.
@@ -354,7 +460,45 @@ func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger,
 	return nil
 }
 
+// createIncrementalCheckpoint creates incremental checkpoint with given checkpointNum and tries,
+// on top of the base checkpoint with given number and tries.
+// Errors indicate that checkpoint file can't be created.
+// Caller should handle returned errors by retrying checkpointing when appropriate.
+func createIncrementalCheckpoint(
+	checkpointer *realWAL.Checkpointer,
+	logger zerolog.Logger,
+	baseCheckpointNum int,
+	baseTries []*trie.MTrie,
+	tries []*trie.MTrie,
+	checkpointNum int,
+	metrics module.WALMetrics,
+) error {
+
+	logger.Info().Msgf("serializing incremental checkpoint %d on top of checkpoint %d with %v tries", checkpointNum, baseCheckpointNum, len(tries))
+
+	startTime := time.Now()
+
+	fileName := realWAL.NumberToFilename(checkpointNum)
+	err := realWAL.StoreIncrementalCheckpoint(baseCheckpointNum, baseTries, tries, checkpointer.Dir(), fileName, logger)
+	if err != nil {
+		return fmt.Errorf("error serializing incremental checkpoint (%d): %w", checkpointNum, err)
+	}
+
+	size, err := realWAL.ReadIncrementalCheckpointFileSize(checkpointer.Dir(), fileName)
+	if err != nil {
+		return fmt.Errorf("error reading incremental checkpoint file size (%d): %w", checkpointNum, err)
+	}
+
+	metrics.ExecutionCheckpointSize(size)
+
+	duration := time.Since(startTime)
+	logger.Info().Float64("total_time_s", duration.Seconds()).Msgf("created incremental checkpoint %d", checkpointNum)
+
+	return nil
+}
+
 // cleanupCheckpoints deletes prior checkpoint files if needed.
+// Checkpoints which are the base of a kept incremental checkpoint are not deleted.
 // Since the function is side-effect free, all failures are simply a no-op.
 func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep int) error {
 	// Don't list checkpoints if we keep them all
@@ -369,7 +513,15 @@ func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep in
 		// if condition guarantees this never fails
 		checkpointsToRemove := checkpoints[:len(checkpoints)-int(checkpointsToKeep)]
 
+		requiredBases, err := checkpointer.IncrementalCheckpointBases(checkpoints[len(checkpoints)-int(checkpointsToKeep):])
+		if err != nil {
+			return fmt.Errorf("cannot get bases of incremental checkpoints: %w", err)
+		}
+
 		for _, checkpoint := range checkpointsToRemove {
+			if _, ok := requiredBases[checkpoint]; ok {
+				continue
+			}
 			err := checkpointer.RemoveCheckpoint(checkpoint)
 			if err != nil {
 				return fmt.Errorf("cannot remove checkpoint %d: %w", checkpoint, err)
@@ -380,7 +532,8 @@ func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep in
 }
 
 // processTrieUpdate writes trie update to WAL, updates activeSegmentNum,
-// and returns tries for checkpointing if needed.
+// and returns tries for checkpointing if needed, together with a function releasing them
+// (see Ledger.RetainTries), which must be called once the tries are checkpointed.
 // It sends WAL update result, receives updated trie, and pushes updated trie to trieQueue.
 // When this function returns, WAL update is in sync with trieQueue update.
 func (c *Compactor) processTrieUpdate(
@@ -392,6 +545,7 @@ func (c *Compactor) processTrieUpdate(
 	_activeSegmentNum int,
 	checkpointNum int,
 	checkpointTries []*trie.MTrie,
+	releaseCheckpointTries func(),
 ) {
 
 	// RecordUpdate returns the segment number the record was written to.
@@ -400,9 +554,6 @@ func (c *Compactor) processTrieUpdate(
 	// - incremented by 1 from previous segment number (new segment)
 	segmentNum, skipped, updateErr := c.wal.RecordUpdate(update.Update)
 
-	// Send result of WAL update
-	update.ResultCh <- updateErr
-
 	// This ensures that updated trie matches WAL update.
 	defer func() {
 		// Wait for updated trie
@@ -415,13 +566,19 @@ func (c *Compactor) processTrieUpdate(
 		trieQueue.Push(trie)
 	}()
 
+	// Send result of WAL update. It is sent after the tries for checkpointing are retained,
+	// since the ledger may evict the oldest trie from its state once it receives the result.
+	defer func() {
+		update.ResultCh <- updateErr
+	}()
+
 	if activeSegmentNum == -1 {
 		// Recover from failure to get active segment number at initialization.
-		return segmentNum, -1, nil
+		return segmentNum, -1, nil, nil
 	}
 
 	if updateErr != nil || skipped || segmentNum == activeSegmentNum {
-		return activeSegmentNum, -1, nil
+		return activeSegmentNum, -1, nil, nil
 	}
 
 	// In the remaining code: segmentNum > activeSegmentNum
@@ -442,7 +599,7 @@ func (c *Compactor) processTrieUpdate(
 
 	if nextCheckpointNum > prevSegmentNum {
 		// Not enough segments for checkpointing
-		return activeSegmentNum, -1, nil
+		return activeSegmentNum, -1, nil, nil
 	}
 
 	// In the remaining code: nextCheckpointNum == prevSegmentNum
@@ -455,10 +612,11 @@ func (c *Compactor) processTrieUpdate(
 	// It doesn't include trie for this update
 	// until updated trie is received and added to trieQueue.
 	tries := trieQueue.Tries()
+	release := c.retainTries(tries)
 
 	checkpointNum = nextCheckpointNum
 
-	return activeSegmentNum, checkpointNum, tries
+	return activeSegmentNum, checkpointNum, tries, release
 }
 
 // createCheckpointError creates a checkpoint creation error.
@@ -523,6 +523,157 @@ func TestCompactorAccuracy(t *testing.T) {
 	})
 }
 
+// TestCompactorIncrementalCheckpoints tests that the compactor alternates between full and
+// incremental checkpoints, that checkpoint cleanup keeps the bases of kept incremental
+// checkpoints, and that the ledger state can be rebuilt from incremental checkpoints.
+func TestCompactorIncrementalCheckpoints(t *testing.T) {
+
+	const (
+		numInsPerStep          = 2
+		pathByteSize           = 32
+		minPayloadByteSize     = 2<<11 - 256 // 3840 bytes
+		maxPayloadByteSize     = 2 << 11     // 4096 bytes
+		size                   = 20
+		checkpointDistance     = 1
+		checkpointsToKeep      = 2
+		fullCheckpointInterval = 3
+		forestCapacity         = 500
+	)
+
+	metricsCollector := &metrics.NoopCollector{}
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		l, err := NewLedger(wal, forestCapacity, metricsCollector, zerolog.Logger{}, DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		compactor, err := NewCompactor(l, wal, unittest.Logger(), forestCapacity, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector(),
+			WithFullCheckpointInterval(fullCheckpointInterval))
+		require.NoError(t, err)
+
+		co := CompactorObserver{fromBound: size/2 - 1, done: make(chan struct{})}
+		compactor.Subscribe(&co)
+
+		<-compactor.Ready()
+
+		rootHash := trie.EmptyTrieRootHash()
+		for i := 0; i < size+2; i++ {
+			time.Sleep(LedgerUpdateDelay)
+
+			payloads := testutils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+			keys := make([]ledger.Key, len(payloads))
+			values := make([]ledger.Value, len(payloads))
+			for i, p := range payloads {
+				k, err := p.Key()
+				require.NoError(t, err)
+				keys[i] = k
+				values[i] = p.Value()
+			}
+
+			update, err := ledger.NewUpdate(ledger.State(rootHash), keys, values)
+			require.NoError(t, err)
+
+			newState, _, err := l.Set(update)
+			require.NoError(t, err)
+
+			rootHash = ledger.RootHash(newState)
+		}
+
+		select {
+		case <-co.done:
+			// continue
+		case <-time.After(60 * time.Second):
+			assert.FailNow(t, "timed out")
+		}
+
+		<-l.Done()
+		<-compactor.Done()
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+
+		nums, err := checkpointer.Checkpoints()
+		require.NoError(t, err)
+
+		kept := nums[len(nums)-checkpointsToKeep:]
+		bases, err := checkpointer.IncrementalCheckpointBases(kept)
+		require.NoError(t, err)
+
+		// all other checkpoints have been removed
+		require.Len(t, nums, checkpointsToKeep+len(bases))
+		for base := range bases {
+			require.Contains(t, nums, base)
+		}
+
+		incrementalCount := 0
+		for _, n := range nums {
+			_, incremental, err := realWAL.ReadIncrementalCheckpointBase(dir, realWAL.NumberToFilename(n))
+			require.NoError(t, err)
+			if incremental {
+				incrementalCount++
+			}
+			testCheckpointedTriesMatchReplayedTriesFromSegments(t, checkpointer, n, dir, true)
+		}
+		require.Greater(t, incrementalCount, 0)
+
+		// rebuild ledger state from the latest checkpoint and the remaining segments
+		wal2, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		l2, err := NewLedger(wal2, forestCapacity, metricsCollector, zerolog.Logger{}, DefaultPathFinderVersion)
+		require.NoError(t, err)
+		require.True(t, l2.HasState(ledger.State(rootHash)))
+
+		<-l2.Done()
+		<-wal2.Done()
+	})
+}
+
+// TestCompactorLastCheckpointTries tests that the tries of the last checkpoint are looked up by root
+// hash among the tries of the new checkpoint and in the ledger state, and that tries which are no
+// longer in memory are replaced by empty tries.
+func TestCompactorLastCheckpointTries(t *testing.T) {
+	tries := make([]*trie.MTrie, 0, 3)
+	activeTrie := trie.NewEmptyMTrie()
+	for i := 0; i < 3; i++ {
+		paths := testutils.RandomPaths(2)
+		payloads := []ledger.Payload{*testutils.RandomPayload(32, 64), *testutils.RandomPayload(32, 64)}
+		var err error
+		activeTrie, _, err = trie.NewTrieWithUpdatedRegisters(activeTrie, paths, payloads, false)
+		require.NoError(t, err)
+		tries = append(tries, activeTrie)
+	}
+
+	// the first trie is in the ledger state, the second trie was evicted, and the third trie is
+	// also part of the new checkpoint
+	inLedger := map[ledger.RootHash]*trie.MTrie{tries[0].RootHash(): tries[0]}
+	c := &Compactor{
+		logger: unittest.Logger(),
+		trieByRootHash: func(rootHash ledger.RootHash) (*trie.MTrie, error) {
+			found, ok := inLedger[rootHash]
+			if !ok {
+				return nil, fmt.Errorf("trie with root hash %s not found", rootHash)
+			}
+			return found, nil
+		},
+		lastCheckpointRootHashes: []ledger.RootHash{tries[0].RootHash(), tries[1].RootHash(), tries[2].RootHash()},
+	}
+
+	baseTries := c.lastCheckpointTries(tries[2:])
+	require.Len(t, baseTries, 3)
+	require.Equal(t, tries[0], baseTries[0])
+	require.True(t, baseTries[1].IsEmpty())
+	require.Equal(t, tries[2], baseTries[2])
+
+	// a full checkpoint is required if none of the tries are in memory
+	inLedger = map[ledger.RootHash]*trie.MTrie{}
+	require.Nil(t, c.lastCheckpointTries(nil))
+}
+
 // TestCompactorTriggeredByAdminTool tests that the compactor will listen to the signal from admin tool
 // to trigger checkpoint when current segment file is finished.
 func TestCompactorTriggeredByAdminTool(t *testing.T) {
@@ -49,11 +49,13 @@ func NewLedger(
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
-	pathFinderVer uint8) (*Ledger, error) {
+	pathFinderVer uint8,
+	forestOpts ...mtrie.ForestOption,
+) (*Ledger, error) {
 
 	logger := log.With().Str("ledger_mod", "complete").Logger()
 
-	forest, err := mtrie.NewForest(capacity, metrics, nil)
+	forest, err := mtrie.NewForest(capacity, metrics, nil, forestOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest: %w", err)
 	}
@@ -329,6 +331,12 @@ func (l *Ledger) Trie(rootHash ledger.RootHash) (*trie.MTrie, error) {
 	return l.forest.GetTrie(rootHash)
 }
 
+// RetainTries retains the paged out payloads of the given tries until the returned function is called,
+// even if the tries are evicted from the forest in the meantime (see mtrie.Forest.RetainTries).
+func (l *Ledger) RetainTries(tries []*trie.MTrie) (release func()) {
+	return l.forest.RetainTries(tries)
+}
+
 // Checkpointer returns a checkpointer instance
 func (l *Ledger) Checkpointer() (*realWAL.Checkpointer, error) {
 	checkpointer, err := l.wal.NewCheckpointer()
@@ -1,6 +1,7 @@
 package complete_test
 
 import (
+	"flag"
 	"math"
 	"testing"
 	"time"
@@ -13,6 +14,8 @@ import (
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/common/testutils"
 	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/paging"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/ledger/partial/ptrie"
 	"github.com/onflow/flow-go/module/metrics"
@@ -138,8 +141,43 @@ func benchmarkStorage(steps int, b *testing.B) {
 
 }
 
+// payloadPagingMaxSlowdown is the maximum factor by which trie updates with payload paging
+// may be slower than trie updates with all payloads in memory.
+var payloadPagingMaxSlowdown = flag.Float64("payload-paging-max-slowdown", 0,
+	"maximum slowdown factor of trie updates with payload paging, compared to in-memory trie updates (0 to skip the check)")
+
 // BenchmarkTrieUpdate benchmarks the performance of a trie update
-func BenchmarkTrieUpdate(b *testing.B) {
+func BenchmarkTrieUpdate(b *testing.B) { benchmarkTrieUpdate(b) }
+
+// BenchmarkTrieUpdateWithPayloadPaging benchmarks the performance of a trie update,
+// when payloads are paged out of memory
+func BenchmarkTrieUpdateWithPayloadPaging(b *testing.B) {
+	pager, err := paging.NewPebblePayloadPager(b.TempDir(), paging.DefaultCacheSize)
+	require.NoError(b, err)
+	defer pager.Close()
+
+	benchmarkTrieUpdate(b, mtrie.WithPayloadPager(pager))
+}
+
+// TestTrieUpdatePayloadPagingSlowdown runs the trie update benchmarks, and checks that trie updates with
+// payload paging are at most -payload-paging-max-slowdown times slower than in-memory trie updates, e.g.
+//
+//	go test -run TestTrieUpdatePayloadPagingSlowdown -payload-paging-max-slowdown 1.5
+func TestTrieUpdatePayloadPagingSlowdown(t *testing.T) {
+	if *payloadPagingMaxSlowdown <= 0 {
+		t.Skip("set -payload-paging-max-slowdown to run the trie update benchmarks")
+	}
+
+	inMemory := testing.Benchmark(BenchmarkTrieUpdate)
+	paged := testing.Benchmark(BenchmarkTrieUpdateWithPayloadPaging)
+
+	slowdown := float64(paged.NsPerOp()) / float64(inMemory.NsPerOp())
+	t.Logf("trie updates with payload paging take %v per op, in memory %v per op (slowdown %.2f)",
+		time.Duration(paged.NsPerOp()), time.Duration(inMemory.NsPerOp()), slowdown)
+	require.LessOrEqual(t, slowdown, *payloadPagingMaxSlowdown)
+}
+
+func benchmarkTrieUpdate(b *testing.B, forestOpts ...mtrie.ForestOption) {
 	// key updates per iteration
 	const (
 		numInsPerStep      = 10000
@@ -157,7 +195,7 @@ func BenchmarkTrieUpdate(b *testing.B) {
 	diskWal, err := wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, capacity, pathfinder.PathByteSize, wal.SegmentSize)
 	require.NoError(b, err)
 
-	led, err := complete.NewLedger(diskWal, capacity, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+	led, err := complete.NewLedger(diskWal, capacity, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion, forestOpts...)
 	require.NoError(b, err)
 
 	compactor, err := complete.NewCompactor(led, diskWal, zerolog.Nop(), capacity, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector())
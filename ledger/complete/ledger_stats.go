@@ -1,6 +1,8 @@
 package complete
 
 import (
+	"fmt"
+
 	"github.com/schollz/progressbar/v3"
 
 	"github.com/onflow/flow-go/ledger"
@@ -34,7 +36,10 @@ func CollectStats(tries []*trie.MTrie, payloadCallBack func(payload *ledger.Payl
 		for itr := flattener.NewUniqueNodeIterator(trie.RootNode(), visitedNodes); itr.Next(); {
 			n := itr.Value()
 			if n.IsLeaf() {
-				payload := n.Payload()
+				payload, err := n.LoadPayload()
+				if err != nil {
+					return nil, fmt.Errorf("could not load payload of leaf at path %v: %w", n.Path(), err)
+				}
 				leafNodeCounter++
 				payloadCallBack(payload)
 			} else {
@@ -2,9 +2,11 @@ package mtrie
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/module"
 )
@@ -28,6 +30,30 @@ type Forest struct {
 	forestCapacity int
 	onTreeEvicted  func(tree *trie.MTrie)
 	metrics        module.LedgerMetrics
+	// pager is used to page out the payloads of all tries in the forest, if set.
+	pager node.PayloadPager
+
+	// heldTries counts the holders of the tries with paged out payloads. A trie is held by the
+	// forest while it is stored in the forest, and by callers of RetainTries. Once a trie is not
+	// held anymore, its paged out payloads, which are not referenced by any held trie, are released.
+	heldTriesLock sync.Mutex
+	heldTries     map[*trie.MTrie]int
+}
+
+// ForestOption is a functional option for configuring a Forest.
+type ForestOption func(*Forest)
+
+// WithPayloadPager configures the Forest to page out the payloads of all its tries using
+// the given pager, instead of keeping them in memory. Payloads are paged out when tries are
+// added or created by updates, and paged in on demand when reading registers, generating proofs,
+// or writing checkpoints. The Forest's API is the same as in in-memory mode, except that tries
+// which are used after they might have been evicted from the forest must be retained with RetainTries.
+//
+// Payloads are released when the tries holding them are evicted from the forest, and not retained.
+func WithPayloadPager(pager node.PayloadPager) ForestOption {
+	return func(f *Forest) {
+		f.pager = pager
+	}
 }
 
 // NewForest returns a new instance of memory forest.
@@ -36,12 +62,27 @@ type Forest struct {
 // If more tries are added than the capacity, the Least Recently Added trie is removed (evicted) from the Forest (FIFO queue).
 // Make sure you chose a sufficiently large forestCapacity, such that, when reaching the capacity, the
 // Least Recently Added trie will never be needed again.
-func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie)) (*Forest, error) {
-	forest := &Forest{tries: NewTrieCache(uint(forestCapacity), onTreeEvicted),
+func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie), opts ...ForestOption) (*Forest, error) {
+	forest := &Forest{
 		forestCapacity: forestCapacity,
 		onTreeEvicted:  onTreeEvicted,
 		metrics:        metrics,
+		heldTries:      make(map[*trie.MTrie]int),
+	}
+	for _, opt := range opts {
+		opt(forest)
+	}
+
+	evicted := onTreeEvicted
+	if forest.pager != nil {
+		evicted = func(tree *trie.MTrie) {
+			if onTreeEvicted != nil {
+				onTreeEvicted(tree)
+			}
+			forest.releaseTries([]*trie.MTrie{tree})
+		}
 	}
+	forest.tries = NewTrieCache(uint(forestCapacity), evicted)
 
 	// add trie with no allocated registers
 	emptyTrie := trie.NewEmptyMTrie()
@@ -82,7 +123,10 @@ func (f *Forest) ValueSizes(r *ledger.TrieRead) ([]int, error) {
 		pathOrgIndex[path] = append(indices, i)
 	}
 
-	sizes := trie.UnsafeValueSizes(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	sizes, err := trie.UnsafeValueSizes(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	if err != nil {
+		return nil, fmt.Errorf("could not read value sizes from trie %v: %w", r.RootHash, err)
+	}
 
 	// reconstruct value sizes in the same key order that called the method
 	orderedValueSizes := make([]int, len(r.Paths))
@@ -109,7 +153,10 @@ func (f *Forest) ReadSingleValue(r *ledger.TrieReadSingleValue) (ledger.Value, e
 		return nil, err
 	}
 
-	payload := trie.ReadSinglePayload(r.Path)
+	payload, err := trie.ReadSinglePayload(r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read payload from trie %v: %w", r.RootHash, err)
+	}
 	return payload.Value().DeepCopy(), nil
 }
 
@@ -129,7 +176,10 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]ledger.Value, error) {
 
 	// call ReadSinglePayload if there is only one path
 	if len(r.Paths) == 1 {
-		payload := trie.ReadSinglePayload(r.Paths[0])
+		payload, err := trie.ReadSinglePayload(r.Paths[0])
+		if err != nil {
+			return nil, fmt.Errorf("could not read payload from trie %v: %w", r.RootHash, err)
+		}
 		return []ledger.Value{payload.Value().DeepCopy()}, nil
 	}
 
@@ -149,7 +199,10 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]ledger.Value, error) {
 		pathOrgIndex[path] = append(indices, i)
 	}
 
-	payloads := trie.UnsafeRead(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	payloads, err := trie.UnsafeRead(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	if err != nil {
+		return nil, fmt.Errorf("could not read payloads from trie %v: %w", r.RootHash, err)
+	}
 
 	// reconstruct the payloads in the same key order that called the method
 	orderedValues := make([]ledger.Value, len(r.Paths))
@@ -236,6 +289,12 @@ func (f *Forest) NewTrie(u *ledger.TrieUpdate) (*trie.MTrie, error) {
 		return nil, fmt.Errorf("constructing updated trie failed: %w", err)
 	}
 
+	// the new trie is not shared yet, and only its new nodes hold payloads in memory
+	err = f.pageOut(newTrie)
+	if err != nil {
+		return nil, err
+	}
+
 	f.metrics.LatestTrieRegCount(newTrie.AllocatedRegCount())
 	f.metrics.LatestTrieRegCountDiff(int64(newTrie.AllocatedRegCount() - parentTrie.AllocatedRegCount()))
 	f.metrics.LatestTrieRegSize(newTrie.AllocatedRegSize())
@@ -297,7 +356,10 @@ func (f *Forest) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
 		stateTrie = newTrie
 	}
 
-	bp := stateTrie.UnsafeProofs(r.Paths)
+	bp, err := stateTrie.UnsafeProofs(r.Paths)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate proofs from trie %v: %w", r.RootHash, err)
+	}
 	return bp, nil
 }
 
@@ -345,12 +407,101 @@ func (f *Forest) AddTrie(newTrie *trie.MTrie) error {
 		// do no op
 		return nil
 	}
+
+	err := f.pageOut(newTrie)
+	if err != nil {
+		return err
+	}
+
+	// the new trie is held before the oldest trie might be evicted, so that their shared payloads are not released
+	f.holdTries([]*trie.MTrie{newTrie})
 	f.tries.Push(newTrie)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Count()))
 
 	return nil
 }
 
+// RetainTries retains the paged out payloads of the given tries until the returned function is called,
+// even if the tries are evicted from the forest in the meantime. This allows reading the tries, while
+// other tries are added to the forest, e.g. for writing a checkpoint.
+// Tries which are not in the forest are not retained, as their payloads might have been released already.
+// Without a pager, tries don't need to be retained, and the returned function is a no-op.
+func (f *Forest) RetainTries(tries []*trie.MTrie) (release func()) {
+	if f.pager == nil {
+		return func() {}
+	}
+
+	f.heldTriesLock.Lock()
+	retained := make([]*trie.MTrie, 0, len(tries))
+	for _, t := range tries {
+		if f.heldTries[t] > 0 {
+			f.heldTries[t]++
+			retained = append(retained, t)
+		}
+	}
+	f.heldTriesLock.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			f.releaseTries(retained)
+		})
+	}
+}
+
+// holdTries adds a holder to each of the given tries, if the forest is configured with a pager.
+func (f *Forest) holdTries(tries []*trie.MTrie) {
+	if f.pager == nil {
+		return
+	}
+
+	f.heldTriesLock.Lock()
+	defer f.heldTriesLock.Unlock()
+
+	for _, t := range tries {
+		f.heldTries[t]++
+	}
+}
+
+// releaseTries removes a holder from each of the given tries. The paged out payloads of tries, which
+// are not held anymore, are released, unless they are referenced by tries which are still held.
+func (f *Forest) releaseTries(tries []*trie.MTrie) {
+	f.heldTriesLock.Lock()
+	defer f.heldTriesLock.Unlock()
+
+	for _, t := range tries {
+		holders, ok := f.heldTries[t]
+		if !ok {
+			continue
+		}
+		if holders > 1 {
+			f.heldTries[t] = holders - 1
+			continue
+		}
+		delete(f.heldTries, t)
+
+		retained := make([]*node.Node, 0, len(f.heldTries))
+		for held := range f.heldTries {
+			retained = append(retained, held.RootNode())
+		}
+		t.RootNode().ReleasePagedPayloads(retained)
+	}
+}
+
+// pageOut pages out the payloads of the given trie, if the forest is configured with a pager.
+// CAUTION: the trie must not be accessed concurrently, unless all its nodes are shared with tries in the forest.
+// No errors are expected during normal operation.
+func (f *Forest) pageOut(t *trie.MTrie) error {
+	if f.pager == nil {
+		return nil
+	}
+	err := t.RootNode().UnsafePageOut(f.pager)
+	if err != nil {
+		return fmt.Errorf("could not page out payloads of trie %v: %w", t.RootHash(), err)
+	}
+	return nil
+}
+
 // GetEmptyRootHash returns the rootHash of empty Trie
 func (f *Forest) GetEmptyRootHash() ledger.RootHash {
 	return trie.EmptyTrieRootHash()
@@ -367,12 +518,14 @@ func (f *Forest) MostRecentTouchedRootHash() (ledger.RootHash, error) {
 
 // PurgeCacheExcept removes all tries in the memory except the one with the given root hash
 func (f *Forest) PurgeCacheExcept(rootHash ledger.RootHash) error {
-	trie, found := f.tries.Get(rootHash)
+	t, found := f.tries.Get(rootHash)
 	if !found {
 		return fmt.Errorf("trie with the given root hash not found")
 	}
+	// the remaining trie is held while the forest is purged, so that its payloads are not released
+	f.holdTries([]*trie.MTrie{t})
 	f.tries.Purge()
-	f.tries.Push(trie)
+	f.tries.Push(t)
 	return nil
 }
 
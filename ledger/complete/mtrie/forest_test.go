@@ -2,8 +2,10 @@ package mtrie
 
 import (
 	"bytes"
+	"fmt"
 	"math/rand"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/onflow/flow-go/ledger/common/hash"
 	prf "github.com/onflow/flow-go/ledger/common/proof"
 	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/paging"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/partial/ptrie"
 	"github.com/onflow/flow-go/module/metrics"
@@ -1125,3 +1129,221 @@ func TestPurgeCacheExcept(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, forest.tries.Count())
 }
+
+// TestPayloadPaging tests that a forest paging out payloads has the same tries, register values,
+// value sizes and proofs as a forest keeping all payloads in memory.
+func TestPayloadPaging(t *testing.T) {
+	// a small cache, so that most payloads are paged in from disk
+	pager, err := paging.NewPebblePayloadPager(t.TempDir(), 10)
+	require.NoError(t, err)
+	defer pager.Close()
+
+	inMemoryForest, err := NewForest(20, &metrics.NoopCollector{}, nil)
+	require.NoError(t, err)
+	pagedForest, err := NewForest(20, &metrics.NoopCollector{}, nil, WithPayloadPager(pager))
+	require.NoError(t, err)
+
+	// a trie created outside of the forest is paged out when it is added
+	paths := testutils.RandomPaths(100)
+	payloads := testutils.RandomPayloads(len(paths), 10, 20)
+	payloadValues := make([]ledger.Payload, len(payloads))
+	for i, p := range payloads {
+		payloadValues[i] = *p
+	}
+	// separate tries, since the paged forest modifies the nodes of added tries
+	inMemoryBaseTrie, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloadValues, true)
+	require.NoError(t, err)
+	baseTrie, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloadValues, true)
+	require.NoError(t, err)
+	require.NoError(t, inMemoryForest.AddTrie(inMemoryBaseTrie))
+	require.NoError(t, pagedForest.AddTrie(baseTrie))
+	requireAllLeavesPaged(t, baseTrie.RootNode())
+
+	allPaths := append([]ledger.Path{}, paths...)
+	activeRoot := baseTrie.RootHash()
+	for i := 0; i < 10; i++ {
+		// update existing and new registers, and remove some registers
+		updatePaths := append(testutils.RandomPaths(20), allPaths[i*5:i*5+5]...)
+		updatePayloads := testutils.RandomPayloads(len(updatePaths), 10, 20)
+		updatePayloads[len(updatePayloads)-1] = ledger.EmptyPayload()
+		allPaths = append(allPaths, updatePaths[:20]...)
+
+		update := &ledger.TrieUpdate{RootHash: activeRoot, Paths: updatePaths, Payloads: updatePayloads}
+		inMemoryRoot, err := inMemoryForest.Update(update)
+		require.NoError(t, err)
+		pagedRoot, err := pagedForest.Update(update)
+		require.NoError(t, err)
+		require.Equal(t, inMemoryRoot, pagedRoot)
+
+		pagedTrie, err := pagedForest.GetTrie(pagedRoot)
+		require.NoError(t, err)
+		requireAllLeavesPaged(t, pagedTrie.RootNode())
+
+		inMemoryTrie, err := inMemoryForest.GetTrie(inMemoryRoot)
+		require.NoError(t, err)
+		require.Equal(t, inMemoryTrie.AllocatedRegCount(), pagedTrie.AllocatedRegCount())
+		require.Equal(t, inMemoryTrie.AllocatedRegSize(), pagedTrie.AllocatedRegSize())
+		require.True(t, pagedTrie.RootNode().VerifyCachedHash())
+
+		read := &ledger.TrieRead{RootHash: pagedRoot, Paths: append([]ledger.Path{}, allPaths...)}
+		inMemoryValues, err := inMemoryForest.Read(read)
+		require.NoError(t, err)
+		pagedValues, err := pagedForest.Read(read)
+		require.NoError(t, err)
+		require.Equal(t, inMemoryValues, pagedValues)
+
+		inMemorySizes, err := inMemoryForest.ValueSizes(read)
+		require.NoError(t, err)
+		pagedSizes, err := pagedForest.ValueSizes(read)
+		require.NoError(t, err)
+		require.Equal(t, inMemorySizes, pagedSizes)
+
+		inMemoryProofs, err := inMemoryForest.Proofs(&ledger.TrieRead{RootHash: pagedRoot, Paths: append([]ledger.Path{}, allPaths...)})
+		require.NoError(t, err)
+		pagedProofs, err := pagedForest.Proofs(&ledger.TrieRead{RootHash: pagedRoot, Paths: append([]ledger.Path{}, allPaths...)})
+		require.NoError(t, err)
+		require.True(t, inMemoryProofs.Equals(pagedProofs))
+		require.True(t, prf.VerifyTrieBatchProof(pagedProofs, ledger.State(pagedRoot)))
+
+		activeRoot = pagedRoot
+	}
+}
+
+// requireAllLeavesPaged requires all leaves with non-empty payloads in the subtrie to be paged out.
+func requireAllLeavesPaged(t *testing.T, n *node.Node) {
+	if n == nil {
+		return
+	}
+	if n.IsLeaf() {
+		if !n.Payload().IsEmpty() {
+			require.True(t, n.IsPaged(), "leaf at path %v is not paged out", n.Path())
+		}
+		return
+	}
+	requireAllLeavesPaged(t, n.LeftChild())
+	requireAllLeavesPaged(t, n.RightChild())
+}
+
+// refCountingPager is a node.PayloadPager storing payloads in a map, which deletes payloads once all their
+// references were released.
+type refCountingPager struct {
+	mu       sync.Mutex
+	payloads map[hash.Hash]*ledger.Payload
+	refs     map[hash.Hash]int
+}
+
+func (p *refCountingPager) PageOut(key hash.Hash, payload *ledger.Payload) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.payloads[key] = payload
+	p.refs[key]++
+	return nil
+}
+
+func (p *refCountingPager) PageIn(key hash.Hash) (*ledger.Payload, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	payload, ok := p.payloads[key]
+	if !ok {
+		return nil, fmt.Errorf("payload %v not found", key)
+	}
+	return payload, nil
+}
+
+func (p *refCountingPager) Release(key hash.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refs[key]--
+	if p.refs[key] == 0 {
+		delete(p.refs, key)
+		delete(p.payloads, key)
+	}
+}
+
+func (p *refCountingPager) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.payloads)
+}
+
+// TestPayloadPagingReleasesEvictedPayloads tests that the payloads of tries evicted from the forest are released,
+// while the payloads of the tries in the forest remain readable.
+func TestPayloadPagingReleasesEvictedPayloads(t *testing.T) {
+	pager := &refCountingPager{
+		payloads: make(map[hash.Hash]*ledger.Payload),
+		refs:     make(map[hash.Hash]int),
+	}
+	forest, err := NewForest(3, &metrics.NoopCollector{}, nil, WithPayloadPager(pager))
+	require.NoError(t, err)
+
+	// repeatedly update the same registers, so that the payloads of older tries are not referenced anymore
+	paths := testutils.RandomPaths(10)
+	activeRoot := forest.GetEmptyRootHash()
+	for i := 0; i < 50; i++ {
+		update := &ledger.TrieUpdate{RootHash: activeRoot, Paths: paths, Payloads: testutils.RandomPayloads(len(paths), 10, 20)}
+		activeRoot, err = forest.Update(update)
+		require.NoError(t, err)
+	}
+
+	// the forest holds 3 tries with 10 payloads each
+	require.Equal(t, 30, pager.count())
+
+	values, err := forest.Read(&ledger.TrieRead{RootHash: activeRoot, Paths: paths})
+	require.NoError(t, err)
+	require.Len(t, values, len(paths))
+
+	// a retained trie remains readable after it was evicted from the forest, until it is released
+	retainedTrie, err := forest.GetTrie(activeRoot)
+	require.NoError(t, err)
+	release := forest.RetainTries([]*trie.MTrie{retainedTrie})
+	for i := 0; i < 3; i++ {
+		update := &ledger.TrieUpdate{RootHash: activeRoot, Paths: paths, Payloads: testutils.RandomPayloads(len(paths), 10, 20)}
+		activeRoot, err = forest.Update(update)
+		require.NoError(t, err)
+	}
+	require.False(t, forest.HasTrie(retainedTrie.RootHash()))
+	require.Equal(t, 40, pager.count())
+
+	payloads, err := retainedTrie.UnsafeRead(append([]ledger.Path(nil), paths...))
+	require.NoError(t, err)
+	require.Len(t, payloads, len(paths))
+
+	release()
+	require.Equal(t, 30, pager.count())
+	// releasing a trie again has no effect
+	release()
+	require.Equal(t, 30, pager.count())
+}
+
+// TestPayloadPagingReadErrors tests that failures to page in payloads are returned as errors.
+func TestPayloadPagingReadErrors(t *testing.T) {
+	pager := &refCountingPager{
+		payloads: make(map[hash.Hash]*ledger.Payload),
+		refs:     make(map[hash.Hash]int),
+	}
+	forest, err := NewForest(3, &metrics.NoopCollector{}, nil, WithPayloadPager(pager))
+	require.NoError(t, err)
+
+	paths := testutils.RandomPaths(10)
+	update := &ledger.TrieUpdate{RootHash: forest.GetEmptyRootHash(), Paths: paths, Payloads: testutils.RandomPayloads(len(paths), 10, 20)}
+	activeRoot, err := forest.Update(update)
+	require.NoError(t, err)
+
+	// drop the paged out payloads
+	pager.mu.Lock()
+	pager.payloads = make(map[hash.Hash]*ledger.Payload)
+	pager.mu.Unlock()
+
+	_, err = forest.Read(&ledger.TrieRead{RootHash: activeRoot, Paths: paths})
+	require.Error(t, err)
+
+	_, err = forest.Read(&ledger.TrieRead{RootHash: activeRoot, Paths: paths[:1]})
+	require.Error(t, err)
+
+	_, err = forest.Proofs(&ledger.TrieRead{RootHash: activeRoot, Paths: paths})
+	require.Error(t, err)
+
+	update = &ledger.TrieUpdate{RootHash: activeRoot, Paths: paths[:1], Payloads: testutils.RandomPayloads(1, 10, 20)}
+	_, err = forest.Update(update)
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/module/irrecoverable"
 )
 
 // Node defines an Mtrie node
@@ -36,6 +37,7 @@ type Node struct {
 	height    int             // height where the Node is at
 	path      ledger.Path     // the storage path (dummy value for interim nodes)
 	payload   *ledger.Payload // the payload this node is storing (leaf nodes only)
+	paged     *pagedPayload   // reference to the payload, if it is paged out of memory (see paging.go)
 	hashValue hash.Hash       // hash value of node (cached)
 }
 
@@ -122,11 +124,11 @@ func NewInterimCompactifiedNode(height int, lChild, rChild *Node) *Node {
 	// an empty subtrie => in total we have one allocated register, which we represent as single leaf node
 	if rChild == nil && lChild.IsLeaf() {
 		h := hash.HashInterNode(lChild.hashValue, ledger.GetDefaultHashForHeight(lChild.height))
-		return &Node{height: height, path: lChild.path, payload: lChild.payload, hashValue: h}
+		return &Node{height: height, path: lChild.path, payload: lChild.payload, paged: lChild.paged, hashValue: h}
 	}
 	if lChild == nil && rChild.IsLeaf() {
 		h := hash.HashInterNode(ledger.GetDefaultHashForHeight(rChild.height), rChild.hashValue)
-		return &Node{height: height, path: rChild.path, payload: rChild.payload, hashValue: h}
+		return &Node{height: height, path: rChild.path, payload: rChild.payload, paged: rChild.paged, hashValue: h}
 	}
 
 	// CASE (b): both children contain some allocated registers => we can't compactify; return a full interim leaf
@@ -148,8 +150,8 @@ func (n *Node) computeHash() hash.Hash {
 	// check for leaf node
 	if n.lChild == nil && n.rChild == nil {
 		// if payload is non-nil, compute the hash based on the payload content
-		if n.payload != nil {
-			return ledger.ComputeCompactValue(hash.Hash(n.path), n.payload.Value(), n.height)
+		if payload := n.Payload(); payload != nil {
+			return ledger.ComputeCompactValue(hash.Hash(n.path), payload.Value(), n.height)
 		}
 		// if payload is nil, return the default hash
 		return ledger.GetDefaultHashForHeight(n.height)
@@ -189,6 +191,14 @@ func (n *Node) VerifyCachedHash() bool {
 	return verifyCachedHashRecursive(n)
 }
 
+// VerifyHash returns the hash computed from the node's payload, or from the cached hashes
+// of its children, and whether it matches the node's cached hash.
+// Unlike VerifyCachedHash, the cached hashes of the children are not verified.
+func (n *Node) VerifyHash() (hash.Hash, bool) {
+	computedHash := n.computeHash()
+	return computedHash, n.hashValue == computedHash
+}
+
 // Hash returns the Node's hash value.
 // Do NOT MODIFY returned slice!
 func (n *Node) Hash() hash.Hash {
@@ -212,9 +222,27 @@ func (n *Node) Path() *ledger.Path {
 }
 
 // Payload returns the Node's payload.
+// If the payload is paged out of memory, it is paged in from the node's PayloadPager. Failing to
+// page in the payload is an irrecoverable exception, hence code paths which can return errors,
+// such as reads through the ledger API, must use LoadPayload instead.
 // Do NOT MODIFY returned slices!
 func (n *Node) Payload() *ledger.Payload {
-	return n.payload
+	payload, err := n.LoadPayload()
+	if err != nil {
+		panic(irrecoverable.NewException(err))
+	}
+	return payload
+}
+
+// LoadPayload returns the Node's payload.
+// If the payload is paged out of memory, it is paged in from the node's PayloadPager.
+// Do NOT MODIFY returned slices!
+// No errors are expected during normal operation. Errors are returned, if the payload can't be paged in.
+func (n *Node) LoadPayload() (*ledger.Payload, error) {
+	if n.paged.isPayload() {
+		return n.paged.load()
+	}
+	return n.payload, nil
 }
 
 // LeftChild returns the Node's left child.
@@ -244,8 +272,8 @@ func (n *Node) FmtStr(prefix string, subpath string) string {
 		left = fmt.Sprintf("\n%v", n.lChild.FmtStr(prefix+"\t", subpath+"0"))
 	}
 	payloadSize := 0
-	if n.payload != nil {
-		payloadSize = n.payload.Size()
+	if payload := n.Payload(); payload != nil {
+		payloadSize = payload.Size()
 	}
 	hashStr := hex.EncodeToString(n.hashValue[:])
 	hashStr = hashStr[:3] + "..." + hashStr[len(hashStr)-3:]
@@ -2,6 +2,7 @@ package node_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -114,6 +115,30 @@ func Test_VerifyCachedHash(t *testing.T) {
 	require.True(t, n5.VerifyCachedHash())
 }
 
+func Test_VerifyHash(t *testing.T) {
+	path := testutils.PathByUint16(1)
+	payload := testutils.LightPayload(2, 3)
+	n1 := node.NewLeaf(path, payload, 0)
+	n2 := node.NewLeaf(path, payload, 0)
+	n3 := node.NewInterimNode(1, n1, n2)
+
+	computedHash, ok := n3.VerifyHash()
+	require.True(t, ok)
+	require.Equal(t, n3.Hash(), computedHash)
+
+	// a node with a corrupted cached hash fails verification
+	corrupted := node.NewNode(0, nil, nil, path, testutils.LightPayload(2, 4), n1.Hash())
+	computedHash, ok = corrupted.VerifyHash()
+	require.False(t, ok)
+	require.NotEqual(t, n1.Hash(), computedHash)
+
+	// only the node itself is verified, not its children
+	n4 := node.NewInterimNode(1, corrupted, n2)
+	_, ok = n4.VerifyHash()
+	require.True(t, ok)
+	require.False(t, n4.VerifyCachedHash())
+}
+
 // Test_Compactify_EmptySubtrie tests constructing an interim node
 // with pruning/compactification, where both children are empty. We expect
 // the compactified node to be nil, as it represents a completely empty subtrie
@@ -295,3 +320,104 @@ func requireIsLeafWithHash(t *testing.T, node *node.Node, expectedHash hash.Hash
 	require.True(t, node.VerifyCachedHash())
 	require.True(t, node.IsLeaf())
 }
+
+// mapPayloadPager is a node.PayloadPager storing payloads in a map.
+type mapPayloadPager map[hash.Hash]*ledger.Payload
+
+func (p mapPayloadPager) PageOut(key hash.Hash, payload *ledger.Payload) error {
+	p[key] = payload
+	return nil
+}
+
+func (p mapPayloadPager) PageIn(key hash.Hash) (*ledger.Payload, error) {
+	payload, ok := p[key]
+	if !ok {
+		return nil, fmt.Errorf("payload %v not found", key)
+	}
+	return payload, nil
+}
+
+// Release keeps the payload, so that tests can inspect the paged out payloads.
+func (p mapPayloadPager) Release(hash.Hash) {}
+
+// releaseRecordingPager is a node.PayloadPager which records the released payloads.
+type releaseRecordingPager struct {
+	mapPayloadPager
+	released map[hash.Hash]int
+}
+
+func (p releaseRecordingPager) Release(key hash.Hash) {
+	p.released[key]++
+}
+
+// Test_UnsafePageOut verifies that paging out payloads doesn't change the payloads and hashes of nodes.
+func Test_UnsafePageOut(t *testing.T) {
+	pager := mapPayloadPager{}
+
+	path1 := testutils.PathByUint8(0)
+	payload1 := testutils.LightPayload8('A', 'a')
+	path2 := testutils.PathByUint8(1)
+	emptyLeaf := node.NewLeaf(path2, ledger.EmptyPayload(), 1)
+	leaf := node.NewLeaf(path1, payload1, 1)
+	root := node.NewInterimNode(2, leaf, emptyLeaf)
+	rootHash := root.Hash()
+
+	require.NoError(t, root.UnsafePageOut(pager))
+	require.True(t, leaf.IsPaged())
+	require.False(t, emptyLeaf.IsPaged())
+	require.Len(t, pager, 1)
+	require.Equal(t, payload1, leaf.Payload())
+	require.Equal(t, rootHash, root.Hash())
+	require.True(t, root.VerifyCachedHash())
+
+	// a paged out leaf is not paged out again
+	require.NoError(t, root.UnsafePageOut(mapPayloadPager{}))
+	require.Equal(t, payload1, leaf.Payload())
+
+	// leaves at other heights have the same hash as in-memory leaves, without paging in the payload
+	delete(pager, hash.HashLeaf(hash.Hash(path1), payload1.Value()))
+	for height := 0; height < 5; height++ {
+		require.Equal(t, node.NewLeaf(path1, payload1, height).Hash(), node.NewLeafAtHeight(leaf, height).Hash())
+	}
+
+	// missing payloads can't be paged in
+	_, err := leaf.LoadPayload()
+	require.Error(t, err)
+	require.Panics(t, func() { leaf.Payload() })
+}
+
+// Test_ReleasePagedPayloads verifies that the paged out payloads of a trie are released, unless they
+// are held by the retained tries.
+func Test_ReleasePagedPayloads(t *testing.T) {
+	pager := releaseRecordingPager{mapPayloadPager: mapPayloadPager{}, released: map[hash.Hash]int{}}
+
+	pathA := testutils.PathByUint8(0)
+	pathB := testutils.PathByUint8(128)
+	payloadA1 := testutils.LightPayload8('A', 'a')
+	payloadA2 := testutils.LightPayload8('A', 'b')
+	payloadB := testutils.LightPayload8('B', 'b')
+	keyA1 := hash.HashLeaf(hash.Hash(pathA), payloadA1.Value())
+	keyA2 := hash.HashLeaf(hash.Hash(pathA), payloadA2.Value())
+	keyB := hash.HashLeaf(hash.Hash(pathB), payloadB.Value())
+
+	// the second trie updates the payload at pathA, and shares the leaf at pathB with the first trie
+	leafB := node.NewLeaf(pathB, payloadB, 255)
+	root1 := node.NewInterimNode(256, node.NewLeaf(pathA, payloadA1, 255), leafB)
+	root2 := node.NewInterimNode(256, node.NewLeaf(pathA, payloadA2, 255), leafB)
+	require.NoError(t, root1.UnsafePageOut(pager))
+	require.NoError(t, root2.UnsafePageOut(pager))
+	// the third trie only holds the payload at pathB in a compact leaf
+	root3 := node.NewLeafAtHeight(leafB, 256)
+	require.True(t, root3.IsPaged())
+
+	// the shared leaf is held by the second trie
+	root1.ReleasePagedPayloads([]*node.Node{root2, root3})
+	require.Equal(t, map[hash.Hash]int{keyA1: 1}, pager.released)
+
+	// the compact leaf at a different height holds the payload of the shared leaf
+	root2.ReleasePagedPayloads([]*node.Node{root3})
+	require.Equal(t, map[hash.Hash]int{keyA1: 1, keyA2: 1}, pager.released)
+
+	root3.ReleasePagedPayloads(nil)
+	require.Equal(t, map[hash.Hash]int{keyA1: 1, keyA2: 1, keyB: 1}, pager.released)
+}
@@ -0,0 +1,207 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+)
+
+// PayloadPager stores payloads of leaf nodes outside of memory, and loads them on demand.
+// Payloads are addressed by the hash of the fully-expanded leaf (see hash.HashLeaf), which
+// is the same for all compact leaves holding the payload at the same path.
+//
+// Paged payloads are only referenced by in-memory tries, hence the pager's storage is only
+// valid for the lifetime of the forest the tries are stored in. The references are released
+// explicitly, once the tries holding them were evicted from the forest (see ReleasePagedPayloads).
+//
+// Implementations must be safe for concurrent use.
+type PayloadPager interface {
+	// PageOut stores the payload under the given key.
+	// No errors are expected during normal operation.
+	PageOut(key hash.Hash, payload *ledger.Payload) error
+
+	// PageIn returns the payload stored under the given key.
+	// No errors are expected during normal operation.
+	PageIn(key hash.Hash) (*ledger.Payload, error)
+
+	// Release releases a reference to the payload stored under the given key, which was added
+	// by PageOut. The payload may be deleted once all its references were released.
+	Release(key hash.Hash)
+}
+
+// pagedPayload references a payload, which was paged out of memory.
+type pagedPayload struct {
+	pager PayloadPager
+	key   hash.Hash
+}
+
+// pagedSubtrie marks interim nodes, whose subtrie has no payloads left in memory, except for
+// empty payloads. It allows skipping subtries, which are shared between tries, when paging out.
+var pagedSubtrie = &pagedPayload{}
+
+// isPayload returns true if p references a paged out payload.
+func (p *pagedPayload) isPayload() bool {
+	return p != nil && p != pagedSubtrie
+}
+
+// load pages in the referenced payload.
+// No errors are expected during normal operation.
+func (p *pagedPayload) load() (*ledger.Payload, error) {
+	payload, err := p.pager.PageIn(p.key)
+	if err != nil {
+		return nil, fmt.Errorf("could not page in payload %v: %w", p.key, err)
+	}
+	return payload, nil
+}
+
+// NewLeafAtHeight creates a compact leaf Node at the given height, holding the same path and
+// payload as the given leaf. Unlike NewLeaf, a paged out payload is not paged in.
+// UNCHECKED requirement: leaf is a non-nil leaf node
+// UNCHECKED requirement: height must be non-negative
+func NewLeafAtHeight(leaf *Node, height int) *Node {
+	if !leaf.paged.isPayload() {
+		return NewLeaf(leaf.path, leaf.payload, height)
+	}
+	return &Node{
+		height:    height,
+		path:      leaf.path,
+		paged:     leaf.paged,
+		hashValue: ledger.ComputeCompactValueFromLeafHash(hash.Hash(leaf.path), leaf.paged.key, height),
+	}
+}
+
+// IsPaged returns true if the node is a leaf, whose payload is paged out of memory.
+func (n *Node) IsPaged() bool {
+	return n != nil && n.paged.isPayload()
+}
+
+// UnsafePageOut pages out all non-empty payloads in the subtrie with this node as root,
+// using the given pager. Subtries which were paged out before are skipped.
+//
+// UNSAFE: nodes are modified IN-PLACE. The subtrie must not be accessed concurrently, unless
+// it is already paged out. This holds for tries which were not added to a Forest yet, if all
+// tries in the Forest are paged out: nodes they share with the Forest's tries are skipped.
+// No errors are expected during normal operation.
+func (n *Node) UnsafePageOut(pager PayloadPager) error {
+	if n == nil || n.paged != nil {
+		return nil
+	}
+
+	if n.IsLeaf() {
+		if n.payload == nil || n.payload.IsEmpty() {
+			// empty payloads are small and don't have a leaf hash, they are kept in memory
+			return nil
+		}
+		key := hash.HashLeaf(hash.Hash(n.path), n.payload.Value())
+		err := pager.PageOut(key, n.payload)
+		if err != nil {
+			return fmt.Errorf("could not page out payload at path %v: %w", n.path, err)
+		}
+		// compact leaves created from this leaf share the reference, which is released once
+		// all tries holding the nodes were evicted from the forest
+		n.paged = &pagedPayload{pager: pager, key: key}
+		n.payload = nil
+		return nil
+	}
+
+	err := n.lChild.UnsafePageOut(pager)
+	if err != nil {
+		return err
+	}
+	err = n.rChild.UnsafePageOut(pager)
+	if err != nil {
+		return err
+	}
+	n.paged = pagedSubtrie
+	return nil
+}
+
+// ReleasePagedPayloads releases the references to paged out payloads held by the subtrie with this
+// node as root, which are not held by any of the given retained subtries as well. It is used to
+// release the payloads of a trie evicted from the forest, which are not referenced by the tries
+// remaining in the forest.
+//
+// The retained nodes must be the root nodes of tries, if this node is the root node of a trie.
+// Subtries which are shared with the retained subtries are skipped. The reference to a payload is
+// shared by the compact leaves created from the same leaf (see NewLeafAtHeight), which may be at
+// different heights in different tries. Therefore, a leaf's reference is only released, if no
+// retained subtrie has a leaf with the same reference on the leaf's path.
+//
+// CAUTION: the released payloads can't be paged in anymore, hence the subtrie must not be read afterwards.
+func (n *Node) ReleasePagedPayloads(retained []*Node) {
+	retainedSet := make(map[*Node]struct{}, len(retained))
+	for _, r := range retained {
+		if r != nil {
+			retainedSet[r] = struct{}{}
+		}
+	}
+	releasePagedPayloads(n, retainedSet)
+}
+
+// releasePagedPayloads releases the references to paged out payloads in the subtrie with the given root,
+// which are not held by the given retained nodes. Retained nodes are either at the same position as n,
+// or compact leaves at a greater height, whose path is in the subtrie of n.
+func releasePagedPayloads(n *Node, retained map[*Node]struct{}) {
+	if n == nil {
+		return
+	}
+	if _, ok := retained[n]; ok {
+		// the subtrie is shared with a retained subtrie
+		return
+	}
+
+	if n.IsLeaf() {
+		if n.paged.isPayload() && !retainsPagedPayload(retained, n) {
+			n.paged.pager.Release(n.paged.key)
+		}
+		return
+	}
+
+	var lRetained, rRetained map[*Node]struct{}
+	if len(retained) > 0 {
+		depth := ledger.NodeMaxHeight - n.height
+		lRetained = make(map[*Node]struct{}, len(retained))
+		rRetained = make(map[*Node]struct{}, len(retained))
+		for r := range retained {
+			if r.IsLeaf() {
+				// a compact leaf is carried down to the child on its path
+				if bitutils.ReadBit(r.path[:], depth) == 0 {
+					lRetained[r] = struct{}{}
+				} else {
+					rRetained[r] = struct{}{}
+				}
+				continue
+			}
+			if r.lChild != nil {
+				lRetained[r.lChild] = struct{}{}
+			}
+			if r.rChild != nil {
+				rRetained[r.rChild] = struct{}{}
+			}
+		}
+	}
+
+	releasePagedPayloads(n.lChild, lRetained)
+	releasePagedPayloads(n.rChild, rRetained)
+}
+
+// retainsPagedPayload returns true if any of the retained nodes has a leaf on the path of the given leaf,
+// which holds the same reference to the paged out payload.
+func retainsPagedPayload(retained map[*Node]struct{}, leaf *Node) bool {
+	for r := range retained {
+		for r != nil && !r.IsLeaf() {
+			depth := ledger.NodeMaxHeight - r.height
+			if bitutils.ReadBit(leaf.path[:], depth) == 0 {
+				r = r.lChild
+			} else {
+				r = r.rChild
+			}
+		}
+		if r != nil && r.paged == leaf.paged {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// DefaultCacheSize is the default number of paged out payloads kept in memory.
+const DefaultCacheSize = 1_000_000
+
+// pebbleCacheSize is the size of pebble's block cache. It is small, because recently used
+// payloads are cached decoded by the pager.
+const pebbleCacheSize = 8 << 20
+
+// DBDirName is the name of the subdirectory of the pager's directory, which holds the database.
+const DBDirName = "payload-pager"
+
+// markerFileName is the name of the file marking the database directory as created by the pager.
+// Only directories containing the marker are cleared, so that no unrelated files are removed.
+const markerFileName = "PAYLOAD_PAGER"
+
+// PebblePayloadPager is a node.PayloadPager, which stores paged out payloads in a pebble
+// database, and keeps the most recently used payloads in an LRU cache.
+//
+// Paged out payloads are only referenced by the in-memory tries, which are rebuilt from
+// checkpoints and WAL on startup. Hence, the database is ephemeral: it is cleared when the
+// pager is created, and it is not synced to disk.
+//
+// A payload is deleted once all paged out leaves referencing it were released, which happens
+// when the tries holding them were evicted from the forest (see node.PayloadPager).
+type PebblePayloadPager struct {
+	db    *pebble.DB
+	cache *lru.Cache[hash.Hash, *ledger.Payload]
+
+	// mu serializes paging out and releasing payloads, and guards the fields below.
+	mu sync.Mutex
+	// extraRefs counts the references to payloads which were paged out more than once, in addition
+	// to the first reference. Most payloads are only paged out once, so they are not tracked to save memory.
+	extraRefs map[hash.Hash]uint32
+	closed    bool
+}
+
+var _ node.PayloadPager = (*PebblePayloadPager)(nil)
+
+// NewPebblePayloadPager creates a pager storing payloads in the DBDirName subdirectory of the given
+// directory, and caching up to cacheSize payloads in memory.
+// The database of a previous pager in the subdirectory is removed. Other content of the directory is
+// kept, and an existing subdirectory, which was not created by a pager, is not removed.
+func NewPebblePayloadPager(dir string, cacheSize int) (*PebblePayloadPager, error) {
+	dbDir, err := prepareDBDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := lru.New[hash.Hash, *ledger.Payload](cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create payload cache: %w", err)
+	}
+
+	pebbleCache := pebble.NewCache(pebbleCacheSize)
+	defer pebbleCache.Unref()
+
+	opts := &pebble.Options{
+		Cache: pebbleCache,
+		// payloads don't need to survive a crash, see PebblePayloadPager
+		DisableWAL: true,
+		Levels:     make([]pebble.LevelOptions, 7),
+	}
+	for i := range opts.Levels {
+		// most payloads are paged out once, so most lookups of existing keys in PageOut miss
+		opts.Levels[i].FilterPolicy = bloom.FilterPolicy(10)
+		opts.Levels[i].FilterType = pebble.TableFilter
+	}
+
+	db, err := pebble.Open(dbDir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not open payload pager db in %v: %w", dbDir, err)
+	}
+
+	return &PebblePayloadPager{
+		db:        db,
+		cache:     cache,
+		extraRefs: make(map[hash.Hash]uint32),
+	}, nil
+}
+
+// prepareDBDir creates an empty database directory in the given directory, and marks it as created
+// by the pager. The database directory of a previous pager is removed.
+// No errors are expected during normal operation.
+func prepareDBDir(dir string) (string, error) {
+	dbDir := filepath.Join(dir, DBDirName)
+	markerFile := filepath.Join(dbDir, markerFileName)
+
+	_, err := os.Stat(dbDir)
+	if err == nil {
+		_, err = os.Stat(markerFile)
+		if err != nil {
+			return "", fmt.Errorf("payload pager directory %v exists, and was not created by a payload pager: %w", dbDir, err)
+		}
+		err = os.RemoveAll(dbDir)
+		if err != nil {
+			return "", fmt.Errorf("could not clear payload pager directory %v: %w", dbDir, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("could not check payload pager directory %v: %w", dbDir, err)
+	}
+
+	err = os.MkdirAll(dbDir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("could not create payload pager directory %v: %w", dbDir, err)
+	}
+	err = os.WriteFile(markerFile, nil, 0644)
+	if err != nil {
+		return "", fmt.Errorf("could not create payload pager marker file %v: %w", markerFile, err)
+	}
+	return dbDir, nil
+}
+
+// PageOut stores the payload under the given key. If the payload is already stored, another
+// reference to it is added.
+// No errors are expected during normal operation.
+func (p *PebblePayloadPager) PageOut(key hash.Hash, payload *ledger.Payload) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	exists, err := p.exists(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		// the key is the hash of the leaf's path and value, so the stored payload is the same
+		p.extraRefs[key]++
+		return nil
+	}
+
+	encoded := ledger.EncodeAndAppendPayloadWithoutPrefix(nil, payload, ledger.PayloadVersion)
+	err = p.db.Set(key[:], encoded, pebble.NoSync)
+	if err != nil {
+		return fmt.Errorf("could not store payload %v: %w", key, err)
+	}
+	p.cache.Add(key, payload)
+	return nil
+}
+
+// exists returns true if a payload is stored under the given key.
+// No errors are expected during normal operation.
+func (p *PebblePayloadPager) exists(key hash.Hash) (bool, error) {
+	if p.cache.Contains(key) {
+		return true, nil
+	}
+
+	_, closer, err := p.db.Get(key[:])
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not read payload %v: %w", key, err)
+	}
+	_ = closer.Close()
+	return true, nil
+}
+
+// Release releases a reference to the payload stored under the given key, and deletes the
+// payload once all its references were released.
+// A payload which fails to be deleted remains in the database until it is cleared on startup.
+func (p *PebblePayloadPager) Release(key hash.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	if refs, ok := p.extraRefs[key]; ok {
+		if refs > 1 {
+			p.extraRefs[key] = refs - 1
+		} else {
+			delete(p.extraRefs, key)
+		}
+		return
+	}
+
+	p.cache.Remove(key)
+	_ = p.db.Delete(key[:], pebble.NoSync)
+}
+
+// PageIn returns the payload stored under the given key.
+// No errors are expected during normal operation.
+func (p *PebblePayloadPager) PageIn(key hash.Hash) (*ledger.Payload, error) {
+	if payload, ok := p.cache.Get(key); ok {
+		return payload, nil
+	}
+
+	encoded, closer, err := p.db.Get(key[:])
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, fmt.Errorf("payload %v was not paged out: %w", key, err)
+		}
+		return nil, fmt.Errorf("could not read payload %v: %w", key, err)
+	}
+	defer closer.Close()
+
+	// encoded is only valid until closer is closed, so the payload must not reference it
+	payload, err := ledger.DecodePayloadWithoutPrefix(encoded, false, ledger.PayloadVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode payload %v: %w", key, err)
+	}
+
+	p.cache.Add(key, payload)
+	return payload, nil
+}
+
+// Close closes the database. Tries referencing paged out payloads can't be read afterwards.
+func (p *PebblePayloadPager) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	return p.db.Close()
+}
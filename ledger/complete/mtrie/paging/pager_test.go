@@ -0,0 +1,114 @@
+package paging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+)
+
+func TestPebblePayloadPager(t *testing.T) {
+	// cache a single payload, so that all other payloads are paged in from disk
+	pager, err := NewPebblePayloadPager(t.TempDir(), 1)
+	require.NoError(t, err)
+	defer pager.Close()
+
+	paths := testutils.RandomPaths(10)
+	payloads := testutils.RandomPayloads(len(paths), 10, 100)
+	keys := make([]hash.Hash, len(paths))
+	for i, path := range paths {
+		keys[i] = hash.HashLeaf(hash.Hash(path), payloads[i].Value())
+		require.NoError(t, pager.PageOut(keys[i], payloads[i]))
+	}
+
+	for i, key := range keys {
+		payload, err := pager.PageIn(key)
+		require.NoError(t, err)
+		require.True(t, payloads[i].Equals(payload))
+	}
+
+	_, err = pager.PageIn(hash.DummyHash)
+	require.Error(t, err)
+}
+
+// TestPebblePayloadPagerClearsDirectory tests that payloads paged out by a previous pager are removed,
+// while other content of the directory is kept.
+func TestPebblePayloadPagerClearsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	otherFile := filepath.Join(dir, "other")
+	require.NoError(t, os.WriteFile(otherFile, []byte("other"), 0644))
+
+	payload := testutils.RandomPayload(10, 100)
+	key := hash.HashLeaf(hash.Hash(testutils.RandomPaths(1)[0]), payload.Value())
+
+	pager, err := NewPebblePayloadPager(dir, 1)
+	require.NoError(t, err)
+	require.NoError(t, pager.PageOut(key, payload))
+	require.NoError(t, pager.Close())
+
+	pager, err = NewPebblePayloadPager(dir, 1)
+	require.NoError(t, err)
+	defer pager.Close()
+
+	_, err = pager.PageIn(key)
+	require.Error(t, err)
+
+	content, err := os.ReadFile(otherFile)
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), content)
+}
+
+// TestPebblePayloadPagerKeepsUnrelatedDirectory tests that an existing database directory,
+// which was not created by a pager, is not removed.
+func TestPebblePayloadPagerKeepsUnrelatedDirectory(t *testing.T) {
+	dir := t.TempDir()
+	dbDir := filepath.Join(dir, DBDirName)
+	require.NoError(t, os.MkdirAll(dbDir, 0755))
+	otherFile := filepath.Join(dbDir, "other")
+	require.NoError(t, os.WriteFile(otherFile, []byte("other"), 0644))
+
+	_, err := NewPebblePayloadPager(dir, 1)
+	require.Error(t, err)
+
+	_, err = os.Stat(otherFile)
+	require.NoError(t, err)
+}
+
+// TestPebblePayloadPagerRelease tests that payloads are deleted once all their references were released.
+func TestPebblePayloadPagerRelease(t *testing.T) {
+	pager, err := NewPebblePayloadPager(t.TempDir(), 1)
+	require.NoError(t, err)
+
+	payloads := testutils.RandomPayloads(2, 10, 100)
+	paths := testutils.RandomPaths(2)
+	keys := make([]hash.Hash, len(paths))
+	for i, path := range paths {
+		keys[i] = hash.HashLeaf(hash.Hash(path), payloads[i].Value())
+	}
+
+	// the first payload is referenced by two leaves, the second payload evicts it from the cache
+	require.NoError(t, pager.PageOut(keys[0], payloads[0]))
+	require.NoError(t, pager.PageOut(keys[1], payloads[1]))
+	require.NoError(t, pager.PageOut(keys[0], payloads[0]))
+
+	pager.Release(keys[0])
+	payload, err := pager.PageIn(keys[0])
+	require.NoError(t, err)
+	require.True(t, payloads[0].Equals(payload))
+
+	pager.Release(keys[0])
+	_, err = pager.PageIn(keys[0])
+	require.Error(t, err)
+
+	payload, err = pager.PageIn(keys[1])
+	require.NoError(t, err)
+	require.True(t, payloads[1].Equals(payload))
+
+	// releasing payloads after the pager was closed is a no-op
+	require.NoError(t, pager.Close())
+	pager.Release(keys[1])
+}
@@ -0,0 +1,633 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// MagicBytesCheckpointIncremental is the magic constant of incremental checkpoint files.
+// Incremental checkpoints use their own magic bytes, so that tools which only understand
+// full checkpoints fail early instead of misreading them.
+const MagicBytesCheckpointIncremental uint16 = 0x2139
+
+// IncrementalVersionV1 is the first version of the incremental checkpoint format.
+const IncrementalVersionV1 uint16 = 0x01
+
+const (
+	encBaseCheckpointSize = 8
+	encRefCountSize       = 8
+	encRefTrieIndexSize   = 2
+	encRefHeightSize      = 2
+	encRefSize            = encRefTrieIndexSize + encRefHeightSize + ledger.PathLen + hash.HashLen
+)
+
+// subtrieRef references a subtrie of a trie in the base checkpoint, which is unchanged in
+// the incremental checkpoint.
+// The subtrie root is located by descending from the root of the base trie along the given
+// path until the given height is reached, and it must have the given hash.
+type subtrieRef struct {
+	trieIndex uint16
+	height    uint16
+	path      ledger.Path
+	hash      hash.Hash
+}
+
+// baseNode is a node of the base checkpoint, together with the index of a base trie which contains it.
+type baseNode struct {
+	n         *node.Node
+	trieIndex uint16
+}
+
+// StoreIncrementalCheckpoint writes the given tries as an incremental checkpoint on top of the
+// tries of the base checkpoint with the given number.
+//
+// Instead of the full forest, an incremental checkpoint only contains the nodes which are not
+// part of the base tries. The maximal subtries, which are shared with the base tries, are
+// referenced by their position in a base trie and their hash. Loading an incremental checkpoint
+// requires its base checkpoint, which can be a full or an incremental checkpoint itself.
+//
+// The file consists of:
+//   - header: magic (2 bytes) + version (2 bytes) + base checkpoint number (8 bytes)
+//   - reference count (8 bytes), followed by the encoded subtrie references
+//   - a list of encoded nodes, where references to other nodes are by list index, with
+//     index 1 to reference count denoting the referenced base subtries
+//   - a list of encoded tries, each referencing their respective root node by index
+//   - footer: node count (8 bytes) + trie count (2 bytes) + CRC32 sum (4 bytes)
+//
+// Incremental checkpoints are only created if the execution node is configured with a full checkpoint
+// interval greater than 1, which is off by default. Besides LoadCheckpoint, the following readers
+// resolve the base checkpoints of incremental checkpoints, and are therefore supported by the tools
+// built on them, such as the checkpoint-list-tries, checkpoint-trie-stats, checkpoint-collect-stats,
+// export-parquet-execution-state and verify-checkpoint commands:
+//   - ReadTriesRootHash, CheckpointHasRootHash and CheckpointHasSingleRootHash
+//   - VerifyCheckpointV6
+//   - OpenAndReadCheckpointV6
+//   - OpenAndReadLeafNodesFromCheckpointV6, used to bootstrap the register database
+//
+// Root checkpoints are always full checkpoints. Regenerating part files from the WAL is only
+// supported for full checkpoints.
+//
+// CAUTION: baseTries must be the tries of the base checkpoint in the order they were stored.
+// Base tries which are not available can be replaced by empty tries, in which case their subtries
+// are not referenced.
+func StoreIncrementalCheckpoint(
+	baseCheckpoint int,
+	baseTries []*trie.MTrie,
+	tries []*trie.MTrie,
+	dir string,
+	fileName string,
+	logger zerolog.Logger,
+) (
+	errToReturn error,
+) {
+	if baseCheckpoint < 0 {
+		return fmt.Errorf("invalid base checkpoint number %d", baseCheckpoint)
+	}
+	if len(baseTries) > math.MaxUint16 || len(tries) > math.MaxUint16 {
+		return fmt.Errorf("too many tries for incremental checkpoint: %d base tries, %d tries", len(baseTries), len(tries))
+	}
+
+	refs, refNodes := findBaseSubtries(baseTries, tries)
+
+	writer, err := CreateCheckpointWriterForFile(dir, fileName, logger)
+	if err != nil {
+		return fmt.Errorf("could not create writer: %w", err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(writer, errToReturn)
+	}()
+
+	crc32Writer := NewCRC32Writer(writer)
+
+	// Scratch buffer is used as temporary buffer that node can encode into.
+	// See StoreCheckpointV5 for details.
+	scratch := make([]byte, 1024*4)
+
+	header := scratch[:headerSize+encBaseCheckpointSize+encRefCountSize]
+	binary.BigEndian.PutUint16(header, MagicBytesCheckpointIncremental)
+	binary.BigEndian.PutUint16(header[encMagicSize:], IncrementalVersionV1)
+	binary.BigEndian.PutUint64(header[headerSize:], uint64(baseCheckpoint))
+	binary.BigEndian.PutUint64(header[headerSize+encBaseCheckpointSize:], uint64(len(refs)))
+
+	_, err = crc32Writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+
+	for _, ref := range refs {
+		_, err = crc32Writer.Write(encodeSubtrieRef(ref, scratch))
+		if err != nil {
+			return fmt.Errorf("cannot write subtrie reference: %w", err)
+		}
+	}
+
+	// visitedNodes contains the referenced base subtries, so that they are
+	// skipped when serializing the nodes of the tries.
+	// Index 0 is a special case with nil node.
+	visitedNodes := make(map[*node.Node]uint64, len(refNodes)+1)
+	visitedNodes[nil] = 0
+	for i, n := range refNodes {
+		visitedNodes[n] = uint64(i + 1)
+	}
+
+	nodeCounter := uint64(len(refNodes) + 1)
+	for _, t := range tries {
+		nodeCounter, err = storeUniqueNodes(t.RootNode(), visitedNodes, nodeCounter, scratch, crc32Writer, func(uint64) {})
+		if err != nil {
+			return fmt.Errorf("fail to store nodes for trie %v: %w", t.RootHash(), err)
+		}
+	}
+
+	for _, t := range tries {
+		rootNode := t.RootNode()
+		if !t.IsEmpty() && rootNode.Height() != ledger.NodeMaxHeight {
+			return fmt.Errorf("height of root node must be %d, but is %d",
+				ledger.NodeMaxHeight, rootNode.Height())
+		}
+
+		rootIndex, found := visitedNodes[rootNode]
+		if !found {
+			rootHash := t.RootHash()
+			return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(rootHash[:]))
+		}
+
+		_, err = crc32Writer.Write(flattener.EncodeTrie(t, rootIndex, scratch))
+		if err != nil {
+			return fmt.Errorf("cannot serialize trie: %w", err)
+		}
+	}
+
+	// the node count in the footer doesn't include the referenced subtries
+	footer := scratch[:encNodeCountSize+encTrieCountSize]
+	binary.BigEndian.PutUint64(footer, nodeCounter-1-uint64(len(refNodes)))
+	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32Writer.Crc32())
+
+	_, err = writer.Write(crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	logger.Info().
+		Int("base_checkpoint", baseCheckpoint).
+		Int("referenced_subtries", len(refs)).
+		Uint64("new_nodes", nodeCounter-1-uint64(len(refNodes))).
+		Msgf("stored incremental checkpoint %v with %d tries", fileName, len(tries))
+
+	return nil
+}
+
+// findBaseSubtries returns the maximal subtries of the given tries, which are also subtries of
+// the base tries at the same position, together with their root nodes.
+// Since trie nodes are immutable and shared between tries, only the nodes which were updated since
+// the base tries are descended into, and the nodes of the unchanged subtries are not visited.
+func findBaseSubtries(baseTries []*trie.MTrie, tries []*trie.MTrie) ([]subtrieRef, []*node.Node) {
+	refs := make([]subtrieRef, 0)
+	refNodes := make([]*node.Node, 0)
+	// examined contains the nodes of the tries, which were already examined,
+	// so that nodes shared by multiple tries are only examined once.
+	examined := make(map[*node.Node]struct{})
+
+	var descend func(n *node.Node, bases []baseNode, path ledger.Path, depth int)
+	descend = func(n *node.Node, bases []baseNode, path ledger.Path, depth int) {
+		if n == nil {
+			return
+		}
+		if _, ok := examined[n]; ok {
+			return
+		}
+		examined[n] = struct{}{}
+
+		nodeHash := n.Hash()
+		for _, b := range bases {
+			if b.n.Hash() == nodeHash {
+				refs = append(refs, subtrieRef{
+					trieIndex: b.trieIndex,
+					height:    uint16(n.Height()),
+					path:      path,
+					hash:      nodeHash,
+				})
+				refNodes = append(refNodes, n)
+				return
+			}
+		}
+		if n.IsLeaf() {
+			return
+		}
+
+		leftBases, rightBases := childBaseNodes(bases)
+		descend(n.LeftChild(), leftBases, path, depth+1)
+		rightPath := path
+		bitutils.SetBit(rightPath[:], depth)
+		descend(n.RightChild(), rightBases, rightPath, depth+1)
+	}
+
+	roots := make([]baseNode, 0, len(baseTries))
+	for i, t := range baseTries {
+		if !t.IsEmpty() {
+			roots = append(roots, baseNode{n: t.RootNode(), trieIndex: uint16(i)})
+		}
+	}
+	roots = uniqueBaseNodes(roots)
+
+	for _, t := range tries {
+		descend(t.RootNode(), roots, ledger.Path{}, 0)
+	}
+
+	return refs, refNodes
+}
+
+// childBaseNodes returns the unique left and right children of the given interim base nodes.
+// Children of leaves are not returned, because a leaf has no children and a compactified
+// leaf at a lower height has a different hash.
+func childBaseNodes(bases []baseNode) ([]baseNode, []baseNode) {
+	left := make([]baseNode, 0, len(bases))
+	right := make([]baseNode, 0, len(bases))
+	for _, b := range bases {
+		if b.n.IsLeaf() {
+			continue
+		}
+		if l := b.n.LeftChild(); l != nil {
+			left = append(left, baseNode{n: l, trieIndex: b.trieIndex})
+		}
+		if r := b.n.RightChild(); r != nil {
+			right = append(right, baseNode{n: r, trieIndex: b.trieIndex})
+		}
+	}
+	return uniqueBaseNodes(left), uniqueBaseNodes(right)
+}
+
+// uniqueBaseNodes removes duplicated nodes, keeping the first occurrence.
+func uniqueBaseNodes(bases []baseNode) []baseNode {
+	if len(bases) < 2 {
+		return bases
+	}
+	seen := make(map[*node.Node]struct{}, len(bases))
+	unique := bases[:0]
+	for _, b := range bases {
+		if _, ok := seen[b.n]; ok {
+			continue
+		}
+		seen[b.n] = struct{}{}
+		unique = append(unique, b)
+	}
+	return unique
+}
+
+func encodeSubtrieRef(ref subtrieRef, scratch []byte) []byte {
+	buf := scratch[:encRefSize]
+	pos := 0
+	binary.BigEndian.PutUint16(buf[pos:], ref.trieIndex)
+	pos += encRefTrieIndexSize
+	binary.BigEndian.PutUint16(buf[pos:], ref.height)
+	pos += encRefHeightSize
+	copy(buf[pos:], ref.path[:])
+	pos += ledger.PathLen
+	copy(buf[pos:], ref.hash[:])
+	return buf
+}
+
+func decodeSubtrieRef(encoded []byte) (subtrieRef, error) {
+	if len(encoded) != encRefSize {
+		return subtrieRef{}, fmt.Errorf("wrong subtrie reference size, expect %d, got %d", encRefSize, len(encoded))
+	}
+	var ref subtrieRef
+	pos := 0
+	ref.trieIndex = binary.BigEndian.Uint16(encoded[pos:])
+	pos += encRefTrieIndexSize
+	ref.height = binary.BigEndian.Uint16(encoded[pos:])
+	pos += encRefHeightSize
+	copy(ref.path[:], encoded[pos:pos+ledger.PathLen])
+	pos += ledger.PathLen
+	copy(ref.hash[:], encoded[pos:pos+hash.HashLen])
+	return ref, nil
+}
+
+// resolveSubtrieRef returns the root node of the referenced subtrie of the base tries.
+// Any error indicates that the reference doesn't match the base tries.
+func resolveSubtrieRef(ref subtrieRef, baseTries []*trie.MTrie) (*node.Node, error) {
+	if int(ref.trieIndex) >= len(baseTries) {
+		return nil, fmt.Errorf("base trie index %d out of range, base checkpoint has %d tries", ref.trieIndex, len(baseTries))
+	}
+	if ref.height > ledger.NodeMaxHeight {
+		return nil, fmt.Errorf("invalid subtrie height %d", ref.height)
+	}
+
+	n := baseTries[ref.trieIndex].RootNode()
+	depth := ledger.NodeMaxHeight - int(ref.height)
+	for i := 0; i < depth && n != nil; i++ {
+		if bitutils.ReadBit(ref.path[:], i) == 0 {
+			n = n.LeftChild()
+		} else {
+			n = n.RightChild()
+		}
+	}
+	if n == nil {
+		return nil, fmt.Errorf("no node in base trie %d at height %d of path %x", ref.trieIndex, ref.height, ref.path[:])
+	}
+	if n.Hash() != ref.hash {
+		return nil, fmt.Errorf("node in base trie %d at height %d of path %x has hash %v, but expected %v",
+			ref.trieIndex, ref.height, ref.path[:], n.Hash(), ref.hash)
+	}
+	return n, nil
+}
+
+// ReadIncrementalCheckpointBase returns the number of the base checkpoint, if the given checkpoint
+// is an incremental checkpoint. The returned bool is false for full checkpoints.
+func ReadIncrementalCheckpointBase(dir string, fileName string) (int, bool, error) {
+	f, err := os.Open(filePathCheckpointHeader(dir, fileName))
+	if err != nil {
+		return -1, false, fmt.Errorf("cannot open checkpoint file %s: %w", fileName, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, headerSize+encBaseCheckpointSize)
+	_, err = io.ReadFull(f, header[:headerSize])
+	if err != nil {
+		return -1, false, fmt.Errorf("cannot read header: %w", err)
+	}
+	if binary.BigEndian.Uint16(header) != MagicBytesCheckpointIncremental {
+		return -1, false, nil
+	}
+
+	_, err = io.ReadFull(f, header[headerSize:])
+	if err != nil {
+		return -1, false, fmt.Errorf("cannot read base checkpoint number: %w", err)
+	}
+	return int(binary.BigEndian.Uint64(header[headerSize:])), true, nil
+}
+
+// isIncrementalCheckpoint returns true if the given checkpoint is an incremental checkpoint.
+// It returns false if the checkpoint header can't be read, so that the caller reports the error
+// of reading the checkpoint as a full checkpoint.
+func isIncrementalCheckpoint(dir string, fileName string) bool {
+	_, incremental, err := ReadIncrementalCheckpointBase(dir, fileName)
+	return err == nil && incremental
+}
+
+// loadIncrementalCheckpoint loads the tries of the incremental checkpoint with the given file name,
+// together with its base checkpoints in the same directory.
+func loadIncrementalCheckpoint(dir string, fileName string, logger zerolog.Logger) ([]*trie.MTrie, error) {
+	tries, err := LoadCheckpoint(filePathCheckpointHeader(dir, fileName), logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not load incremental checkpoint %v: %w", fileName, err)
+	}
+	return tries, nil
+}
+
+// readIncrementalTriesRootHash returns the root hashes of the tries of the incremental checkpoint with
+// the given file name. Unlike full checkpoints, incremental checkpoints don't store the trie roots in a
+// separate file, hence the tries are loaded, including their base checkpoints.
+func readIncrementalTriesRootHash(logger zerolog.Logger, dir string, fileName string) ([]ledger.RootHash, error) {
+	tries, err := loadIncrementalCheckpoint(dir, fileName, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	rootHashes := make([]ledger.RootHash, len(tries))
+	for i, t := range tries {
+		rootHashes[i] = t.RootHash()
+	}
+	return rootHashes, nil
+}
+
+// readIncrementalCheckpointLeafNodes pushes the leaf nodes of the incremental checkpoint with the given
+// file name to the given channel. It requires the checkpoint to only have one trie, with the given root hash.
+func readIncrementalCheckpointLeafNodes(
+	allLeafNodesCh chan<- *LeafNode,
+	dir string,
+	fileName string,
+	expectedRootHash ledger.RootHash,
+	logger zerolog.Logger,
+) error {
+	tries, err := loadIncrementalCheckpoint(dir, fileName, logger)
+	if err != nil {
+		return err
+	}
+
+	if len(tries) != 1 {
+		return fmt.Errorf("expected 1 root hash in checkpoint file, but got %v", len(tries))
+	}
+	if tries[0].RootHash() != expectedRootHash {
+		return fmt.Errorf("expected root hash %v, but got %v", expectedRootHash, tries[0].RootHash())
+	}
+
+	for itr := flattener.NewNodeIterator(tries[0].RootNode()); itr.Next(); {
+		n := itr.Value()
+		if n.IsLeaf() {
+			allLeafNodesCh <- nodeToLeaf(n)
+		}
+	}
+	return nil
+}
+
+// ReadIncrementalCheckpointFileSize returns the size of the incremental checkpoint file.
+func ReadIncrementalCheckpointFileSize(dir string, fileName string) (uint64, error) {
+	fileInfo, err := os.Stat(filePathCheckpointHeader(dir, fileName))
+	if err != nil {
+		return 0, fmt.Errorf("could not get file info for %v: %w", fileName, err)
+	}
+	return uint64(fileInfo.Size()), nil
+}
+
+// readIncrementalCheckpoint deserializes an incremental checkpoint file and returns the list of tries.
+// The base checkpoint is loaded from the same directory as the incremental checkpoint. If it is an
+// incremental checkpoint itself, the whole chain is loaded down to the full checkpoint.
+// Header magic is verified by the caller.
+func readIncrementalCheckpoint(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
+	scratch := make([]byte, 1024*4) // must not be less than 1024
+
+	// Read footer to get node count and trie count
+	const footerOffset = encNodeCountSize + encTrieCountSize + crc32SumSize
+	const footerSize = encNodeCountSize + encTrieCountSize // footer doesn't include crc32 sum
+
+	_, err := f.Seek(-footerOffset, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to footer: %w", err)
+	}
+
+	footer := scratch[:footerSize]
+	_, err = io.ReadFull(f, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	nodesCount := binary.BigEndian.Uint64(footer)
+	triesCount := binary.BigEndian.Uint16(footer[encNodeCountSize:])
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
+	}
+
+	var bufReader io.Reader = bufio.NewReaderSize(f, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+	var reader io.Reader = crcReader
+
+	header := scratch[:headerSize+encBaseCheckpointSize+encRefCountSize]
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header: %w", err)
+	}
+
+	version := binary.BigEndian.Uint16(header[encMagicSize:])
+	if version != IncrementalVersionV1 {
+		return nil, fmt.Errorf("unsupported incremental checkpoint version %x", version)
+	}
+	baseCheckpoint := int(binary.BigEndian.Uint64(header[headerSize:]))
+	refsCount := binary.BigEndian.Uint64(header[headerSize+encBaseCheckpointSize:])
+
+	dir, fileName := filepath.Split(f.Name())
+	// checkpoints can only be based on earlier checkpoints, which also prevents loading cyclic chains
+	if num, ok := checkpointNumberFromFilename(fileName); ok && baseCheckpoint >= num {
+		return nil, fmt.Errorf("base checkpoint %d of incremental checkpoint %d is not an earlier checkpoint", baseCheckpoint, num)
+	}
+
+	logger.Info().Msgf("reading incremental checkpoint %v based on checkpoint %d", fileName, baseCheckpoint)
+
+	baseTries, err := LoadCheckpoint(path.Join(dir, NumberToFilename(baseCheckpoint)), logger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load base checkpoint %d: %w", baseCheckpoint, err)
+	}
+
+	// nodes's element at index 0 is a special, meaning nil.
+	// It is followed by the referenced base subtries and the nodes of the checkpoint.
+	nodes := make([]*node.Node, 1+refsCount+nodesCount)
+
+	encRef := make([]byte, encRefSize)
+	for i := uint64(1); i <= refsCount; i++ {
+		_, err = io.ReadFull(reader, encRef)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read subtrie reference %d: %w", i, err)
+		}
+		ref, err := decodeSubtrieRef(encRef)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode subtrie reference %d: %w", i, err)
+		}
+		nodes[i], err = resolveSubtrieRef(ref, baseTries)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve subtrie reference %d: %w", i, err)
+		}
+	}
+
+	logging := logProgress("reading incremental trie nodes", int(nodesCount), logger)
+
+	for i := refsCount + 1; i < uint64(len(nodes)); i++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= i {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+		nodes[i] = n
+		logging(i)
+	}
+
+	tries := make([]*trie.MTrie, triesCount)
+	for i := uint16(0); i < triesCount; i++ {
+		trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(len(nodes)) {
+				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+		}
+		tries[i] = trie
+	}
+
+	// Read footer again for crc32 computation
+	_, err = io.ReadFull(reader, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	crc32buf := scratch[:crc32SumSize]
+	_, err = io.ReadFull(bufReader, crc32buf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	readCrc32 := binary.BigEndian.Uint32(crc32buf)
+	calculatedCrc32 := crcReader.Crc32()
+	if calculatedCrc32 != readCrc32 {
+		return nil, fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+
+	return tries, nil
+}
+
+// checkpointNumberFromFilename returns the number of the checkpoint with the given file name,
+// and false if the file name is not a numbered checkpoint.
+func checkpointNumberFromFilename(fileName string) (int, bool) {
+	if !strings.HasPrefix(fileName, checkpointFilenamePrefix) {
+		return 0, false
+	}
+	num, err := strconv.Atoi(fileName[len(checkpointFilenamePrefix):])
+	if err != nil {
+		return 0, false
+	}
+	return num, true
+}
+
+// IncrementalCheckpointBases returns the numbers of all checkpoints, which are needed to load
+// the given checkpoints. This includes the bases of the incremental checkpoints among them,
+// and transitively the bases of those.
+func (c *Checkpointer) IncrementalCheckpointBases(checkpoints []int) (map[int]struct{}, error) {
+	return IncrementalCheckpointBases(c.dir, checkpoints)
+}
+
+// IncrementalCheckpointBases returns the numbers of all checkpoints in the given directory,
+// which are needed to load the given checkpoints.
+func IncrementalCheckpointBases(dir string, checkpoints []int) (map[int]struct{}, error) {
+	bases := make(map[int]struct{})
+	for _, checkpoint := range checkpoints {
+		for {
+			base, incremental, err := ReadIncrementalCheckpointBase(dir, NumberToFilename(checkpoint))
+			if err != nil {
+				return nil, fmt.Errorf("cannot read base of checkpoint %d: %w", checkpoint, err)
+			}
+			if !incremental {
+				break
+			}
+			if base >= checkpoint {
+				return nil, fmt.Errorf("base checkpoint %d of incremental checkpoint %d is not an earlier checkpoint", base, checkpoint)
+			}
+			if _, ok := bases[base]; ok {
+				break
+			}
+			bases[base] = struct{}{}
+			checkpoint = base
+		}
+	}
+	return bases, nil
+}
@@ -0,0 +1,223 @@
+package wal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// updateTries returns a sequence of new tries, each created by updating random registers of the previous trie.
+func updateTries(t *testing.T, activeTrie *trie.MTrie, count int) []*trie.MTrie {
+	tries := make([]*trie.MTrie, 0, count)
+	for i := 0; i < count; i++ {
+		paths, payloads := randNPathPayloads(20)
+		var err error
+		activeTrie, _, err = trie.NewTrieWithUpdatedRegisters(activeTrie, paths, payloads, false)
+		require.NoError(t, err)
+		tries = append(tries, activeTrie)
+	}
+	return tries
+}
+
+func TestWriteAndReadIncrementalCheckpoint(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		// tries which share most of their nodes with the base tries, and also include some of the base tries
+		tries := append(baseTries[len(baseTries)-2:], updateTries(t, baseTries[len(baseTries)-1], 3)...)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		decoded, err := LoadCheckpoint(filepath.Join(dir, NumberToFilename(2)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+
+		// the incremental checkpoint is much smaller than the full checkpoint
+		fullSize, err := ReadCheckpointFileSize(dir, NumberToFilename(1))
+		require.NoError(t, err)
+		incrementalSize, err := ReadIncrementalCheckpointFileSize(dir, NumberToFilename(2))
+		require.NoError(t, err)
+		require.Less(t, incrementalSize, fullSize/10)
+
+		// chain another incremental checkpoint on top of the incremental checkpoint
+		moreTries := append(tries[len(tries)-1:], updateTries(t, tries[len(tries)-1], 2)...)
+		require.NoError(t, StoreIncrementalCheckpoint(2, decoded, moreTries, dir, NumberToFilename(3), logger))
+
+		decoded, err = LoadCheckpoint(filepath.Join(dir, NumberToFilename(3)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, moreTries, decoded)
+
+		base, incremental, err := ReadIncrementalCheckpointBase(dir, NumberToFilename(3))
+		require.NoError(t, err)
+		require.True(t, incremental)
+		require.Equal(t, 2, base)
+
+		_, incremental, err = ReadIncrementalCheckpointBase(dir, NumberToFilename(1))
+		require.NoError(t, err)
+		require.False(t, incremental)
+
+		bases, err := IncrementalCheckpointBases(dir, []int{3})
+		require.NoError(t, err)
+		require.Equal(t, map[int]struct{}{1: {}, 2: {}}, bases)
+	})
+}
+
+// TestIncrementalCheckpointV6Readers tests that the readers of V6 checkpoints, which are used by the
+// checkpoint tools and bootstrapping, resolve the base checkpoints of incremental checkpoints.
+func TestIncrementalCheckpointV6Readers(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := updateTries(t, baseTries[len(baseTries)-1], 3)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		// a single trie, to read the leaf nodes of
+		singleTrie := updateTries(t, tries[len(tries)-1], 1)
+		require.NoError(t, StoreIncrementalCheckpoint(2, tries, singleTrie, dir, NumberToFilename(3), logger))
+
+		rootHashes, err := ReadTriesRootHash(logger, dir, NumberToFilename(2))
+		require.NoError(t, err)
+		require.Len(t, rootHashes, len(tries))
+		for i, tr := range tries {
+			require.Equal(t, tr.RootHash(), rootHashes[i])
+		}
+		require.NoError(t, CheckpointHasRootHash(logger, dir, NumberToFilename(2), tries[1].RootHash()))
+
+		decoded, err := OpenAndReadCheckpointV6(dir, NumberToFilename(2), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+
+		// reading the leaf nodes requires a single trie
+		leafNodesCh := make(chan *LeafNode, 10)
+		err = OpenAndReadLeafNodesFromCheckpointV6(leafNodesCh, dir, NumberToFilename(2), tries[0].RootHash(), logger)
+		require.Error(t, err)
+
+		leafNodesCh = make(chan *LeafNode, 10)
+		go func() {
+			err := OpenAndReadLeafNodesFromCheckpointV6(leafNodesCh, dir, NumberToFilename(3), singleTrie[0].RootHash(), logger)
+			require.NoError(t, err)
+		}()
+		payloads := make([]*ledger.Payload, 0)
+		for leafNode := range leafNodesCh {
+			payloads = append(payloads, leafNode.Payload)
+		}
+		require.ElementsMatch(t, singleTrie[0].AllPayloads(), payloads)
+
+		// the readers fail, if the base checkpoint is missing
+		require.NoError(t, os.Remove(filepath.Join(dir, NumberToFilename(2))))
+		_, err = ReadTriesRootHash(logger, dir, NumberToFilename(3))
+		require.Error(t, err)
+	})
+}
+
+func TestWriteAndReadIncrementalCheckpointEmptyTries(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := []*trie.MTrie{trie.NewEmptyMTrie()}
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := []*trie.MTrie{trie.NewEmptyMTrie()}
+		tries = append(tries, updateTries(t, tries[0], 2)...)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		decoded, err := LoadCheckpoint(filepath.Join(dir, NumberToFilename(2)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+// TestWriteAndReadIncrementalCheckpointPartialBase tests that base tries, which are not available
+// and replaced by empty tries, are not referenced by the incremental checkpoint.
+func TestWriteAndReadIncrementalCheckpointPartialBase(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := append(baseTries[len(baseTries)-2:], updateTries(t, baseTries[len(baseTries)-1], 3)...)
+
+		// only the last base trie is available
+		partialBaseTries := make([]*trie.MTrie, len(baseTries))
+		for i := range partialBaseTries {
+			partialBaseTries[i] = trie.NewEmptyMTrie()
+		}
+		partialBaseTries[len(baseTries)-1] = baseTries[len(baseTries)-1]
+		require.NoError(t, StoreIncrementalCheckpoint(1, partialBaseTries, tries, dir, NumberToFilename(2), logger))
+
+		decoded, err := LoadCheckpoint(filepath.Join(dir, NumberToFilename(2)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+// TestIncrementalCheckpointWithWrongBase tests that loading an incremental checkpoint fails,
+// if its base checkpoint doesn't contain the referenced subtries.
+func TestIncrementalCheckpointWithWrongBase(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		otherTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(otherTries, dir, NumberToFilename(1), logger))
+
+		tries := updateTries(t, baseTries[len(baseTries)-1], 2)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		_, err := LoadCheckpoint(filepath.Join(dir, NumberToFilename(2)), logger)
+		require.Error(t, err)
+	})
+}
+
+// TestIncrementalCheckpointMissingBase tests that loading an incremental checkpoint fails,
+// if its base checkpoint doesn't exist.
+func TestIncrementalCheckpointMissingBase(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := updateTries(t, baseTries[len(baseTries)-1], 2)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		require.NoError(t, deleteCheckpointFiles(dir, NumberToFilename(1)))
+
+		_, err := LoadCheckpoint(filepath.Join(dir, NumberToFilename(2)), logger)
+		require.Error(t, err)
+	})
+}
+
+// TestIncrementalCheckpointChecksum tests that corrupted incremental checkpoints are detected.
+func TestIncrementalCheckpointChecksum(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := updateTries(t, baseTries[len(baseTries)-1], 2)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		filePath := filepath.Join(dir, NumberToFilename(2))
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		data[len(data)/2] ^= 0x1
+		require.NoError(t, os.WriteFile(filePath, data, 0644))
+
+		_, err = LoadCheckpoint(filePath, logger)
+		require.Error(t, err)
+	})
+}
@@ -30,6 +30,8 @@ func nodeToLeaf(leaf *node.Node) *LeafNode {
 // the given checkpoint file specified by dir and fileName.
 // It returns when finish reading the checkpoint file and the input channel can be closed.
 // It requires the checkpoint file only has one trie.
+// Incremental checkpoints are loaded together with their base checkpoints, and their leaf nodes are
+// read from the loaded trie.
 func OpenAndReadLeafNodesFromCheckpointV6(
 	allLeafNodesCh chan<- *LeafNode,
 	dir string,
@@ -42,6 +44,10 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 		close(allLeafNodesCh)
 	}()
 
+	if isIncrementalCheckpoint(dir, fileName) {
+		return readIncrementalCheckpointLeafNodes(allLeafNodesCh, dir, fileName, expectedRootHash, logger)
+	}
+
 	err := checkpointHasSingleRootHash(logger, dir, fileName, expectedRootHash)
 	if err != nil {
 		return fmt.Errorf("fail to check checkpoint has single root hash: %w", err)
@@ -24,6 +24,10 @@ func ReadTriesRootHash(logger zerolog.Logger, dir string, fileName string) (
 	[]ledger.RootHash,
 	error,
 ) {
+	if isIncrementalCheckpoint(dir, fileName) {
+		return readIncrementalTriesRootHash(logger, dir, fileName)
+	}
+
 	err := validateCheckpointFile(logger, dir, fileName)
 	if err != nil {
 		return nil, err
@@ -97,11 +101,15 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 	return tries, nil
 }
 
-// OpenAndReadCheckpointV6 open the checkpoint file and read it with readCheckpointV6
+// OpenAndReadCheckpointV6 open the checkpoint file and read it with readCheckpointV6.
+// Incremental checkpoints are loaded together with their base checkpoints.
 func OpenAndReadCheckpointV6(dir string, fileName string, logger zerolog.Logger) (
 	triesToReturn []*trie.MTrie,
 	errToReturn error,
 ) {
+	if isIncrementalCheckpoint(dir, fileName) {
+		return loadIncrementalCheckpoint(dir, fileName, logger)
+	}
 
 	filepath := filePathCheckpointHeader(dir, fileName)
 	errToReturn = withFile(logger, filepath, func(file *os.File) error {
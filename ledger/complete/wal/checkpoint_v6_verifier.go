@@ -0,0 +1,608 @@
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// TopTriePartIndex is the index of the part file containing the top level nodes and the trie roots.
+// Part files with lower indices contain the subtries.
+const TopTriePartIndex = subtrieCount
+
+// CorruptedNode describes a trie node, whose stored hash doesn't match the hash computed from
+// its payload or from the hashes of its children.
+type CorruptedNode struct {
+	// NodeIndex is the index of the node in its part file, starting at 1.
+	// It is 0 for top level nodes, which were found to be corrupted while walking the tries.
+	NodeIndex uint64
+	Height    int
+	// Path is the path of a leaf, or the path prefix of an interim node, where the bits
+	// below the node's depth are zero.
+	Path         ledger.Path
+	StoredHash   hash.Hash
+	ComputedHash hash.Hash
+}
+
+func (c *CorruptedNode) String() string {
+	return fmt.Sprintf("node %d at height %d with path %x has hash %v, but computed hash is %v",
+		c.NodeIndex, c.Height, c.Path[:], c.StoredHash, c.ComputedHash)
+}
+
+// CheckpointPartVerification is the result of verifying one part file of a checkpoint.
+type CheckpointPartVerification struct {
+	Index     int
+	NodeCount uint64
+	// Err is set if the part file can't be read, or its checksum doesn't match.
+	Err error
+	// CorruptedNode is the first corrupted node of the part file, if any.
+	CorruptedNode *CorruptedNode
+	// Skipped is set if the part file wasn't verified, because it depends on corrupted part files.
+	Skipped bool
+}
+
+// Valid returns true if the part file was read successfully and contains no corrupted node.
+func (p *CheckpointPartVerification) Valid() bool {
+	return !p.Skipped && p.Err == nil && p.CorruptedNode == nil
+}
+
+// TrieVerification is the result of verifying the metadata of one trie of a checkpoint.
+type TrieVerification struct {
+	RootHash         ledger.RootHash
+	RegCount         uint64
+	ComputedRegCount uint64
+	RegSize          uint64
+	ComputedRegSize  uint64
+}
+
+// Valid returns true if the register count and size of the trie match its registers.
+func (t *TrieVerification) Valid() bool {
+	return t.RegCount == t.ComputedRegCount && t.RegSize == t.ComputedRegSize
+}
+
+// CheckpointVerification is the result of verifying a checkpoint.
+type CheckpointVerification struct {
+	// Incremental is set if the checkpoint is an incremental checkpoint, which consists of a single file,
+	// and is verified together with its base checkpoints.
+	Incremental bool
+	// BaseCheckpoint is the number of the base checkpoint of an incremental checkpoint.
+	BaseCheckpoint int
+	// Parts contains the results of all part files, the top level part file is last.
+	// For incremental checkpoints, it only contains the result of the checkpoint file, with index 0.
+	Parts []*CheckpointPartVerification
+	// Tries contains the results of all tries. It is empty, if any part file is corrupted.
+	Tries []*TrieVerification
+}
+
+// CorruptedParts returns the indices of all corrupted part files.
+// Skipped part files are not included.
+func (v *CheckpointVerification) CorruptedParts() []int {
+	corrupted := make([]int, 0)
+	for _, part := range v.Parts {
+		if !part.Skipped && !part.Valid() {
+			corrupted = append(corrupted, part.Index)
+		}
+	}
+	return corrupted
+}
+
+// Valid returns true if all part files and tries are valid.
+func (v *CheckpointVerification) Valid() bool {
+	for _, part := range v.Parts {
+		if !part.Valid() {
+			return false
+		}
+	}
+	for _, t := range v.Tries {
+		if !t.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// regStats is the allocated register count and size of a subtrie.
+type regStats struct {
+	count uint64
+	size  uint64
+}
+
+func (s regStats) add(o regStats) regStats {
+	return regStats{count: s.count + o.count, size: s.size + o.size}
+}
+
+func leafRegStats(n *node.Node) regStats {
+	payload := n.Payload()
+	if payload == nil {
+		return regStats{}
+	}
+	stats := regStats{size: uint64(payload.Size())}
+	if !payload.IsEmpty() {
+		stats.count = 1
+	}
+	return stats
+}
+
+// VerifyCheckpointV6 fully verifies the V6 checkpoint with the given file name.
+// Besides the checksums of all part files, it recomputes the hash of every node from its payload
+// or its children, and the allocated register count and size of every trie.
+// The subtrie part files are verified concurrently by the given number of workers.
+//
+// Incremental checkpoints are verified against their base checkpoints: the checkpoint is loaded
+// together with its base checkpoints, which verifies the checksums and the referenced base subtries,
+// and the hashes and register metadata of the loaded tries are recomputed.
+//
+// Returned errors indicate that the checkpoint header can't be read, in which case no part file
+// can be verified. Corruptions of part files and tries are reported in the returned result.
+func VerifyCheckpointV6(dir string, fileName string, nWorkers int, logger zerolog.Logger) (*CheckpointVerification, error) {
+	base, incremental, err := ReadIncrementalCheckpointBase(dir, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read checkpoint header of %v: %w", fileName, err)
+	}
+	if incremental {
+		return verifyIncrementalCheckpoint(dir, fileName, base, logger), nil
+	}
+
+	headerPath := filePathCheckpointHeader(dir, fileName)
+	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not read checkpoint header %v: %w", headerPath, err)
+	}
+
+	if nWorkers < 1 {
+		nWorkers = 1
+	}
+
+	type job struct {
+		index    int
+		checksum uint32
+	}
+	type result struct {
+		part  *CheckpointPartVerification
+		nodes []*node.Node
+		roots map[*node.Node]regStats
+	}
+
+	jobs := make(chan job, len(subtrieChecksums))
+	for i, checksum := range subtrieChecksums {
+		jobs <- job{index: i, checksum: checksum}
+	}
+	close(jobs)
+
+	results := make(chan result, len(subtrieChecksums))
+	for i := 0; i < nWorkers; i++ {
+		go func() {
+			for j := range jobs {
+				part, nodes, roots := verifyCheckpointSubTrie(dir, fileName, j.index, j.checksum, logger)
+				results <- result{part: part, nodes: nodes, roots: roots}
+			}
+		}()
+	}
+
+	verification := &CheckpointVerification{
+		Parts: make([]*CheckpointPartVerification, len(subtrieChecksums)+1),
+	}
+	subtrieNodes := make([][]*node.Node, len(subtrieChecksums))
+	subtrieRootStats := make(map[*node.Node]regStats)
+	for range subtrieChecksums {
+		r := <-results
+		verification.Parts[r.part.Index] = r.part
+		subtrieNodes[r.part.Index] = r.nodes
+		for n, stats := range r.roots {
+			subtrieRootStats[n] = stats
+		}
+		logger.Info().
+			Int("part", r.part.Index).
+			Uint64("nodes", r.part.NodeCount).
+			Bool("valid", r.part.Valid()).
+			Msg("verified subtrie part file")
+	}
+
+	topPart := &CheckpointPartVerification{Index: TopTriePartIndex}
+	verification.Parts[TopTriePartIndex] = topPart
+
+	if len(verification.CorruptedParts()) > 0 {
+		// the top level tries reference nodes of all subtrie part files
+		topPart.Skipped = true
+		return verification, nil
+	}
+
+	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, logger)
+	if err != nil {
+		topPart.Err = err
+		return verification, nil
+	}
+
+	// top level nodes are shared by the tries, so they only need to be verified once
+	verified := make(map[*node.Node]regStats)
+	for _, t := range tries {
+		stats, corrupted, err := verifyTopLevelNodes(t.RootNode(), 0, ledger.Path{}, subtrieRootStats, verified)
+		if err != nil {
+			topPart.Err = fmt.Errorf("could not verify trie %v: %w", t.RootHash(), err)
+			return verification, nil
+		}
+		if corrupted != nil && topPart.CorruptedNode == nil {
+			topPart.CorruptedNode = corrupted
+		}
+
+		verification.Tries = append(verification.Tries, &TrieVerification{
+			RootHash:         t.RootHash(),
+			RegCount:         t.AllocatedRegCount(),
+			ComputedRegCount: stats.count,
+			RegSize:          t.AllocatedRegSize(),
+			ComputedRegSize:  stats.size,
+		})
+	}
+
+	return verification, nil
+}
+
+// verifyIncrementalCheckpoint verifies the incremental checkpoint with the given file name, whose base
+// checkpoint has the given number. Failures to load the checkpoint, including mismatches with its base
+// checkpoints, are reported as the error of its only part.
+func verifyIncrementalCheckpoint(dir string, fileName string, base int, logger zerolog.Logger) *CheckpointVerification {
+	part := &CheckpointPartVerification{Index: 0}
+	verification := &CheckpointVerification{
+		Incremental:    true,
+		BaseCheckpoint: base,
+		Parts:          []*CheckpointPartVerification{part},
+	}
+
+	tries, err := loadIncrementalCheckpoint(dir, fileName, logger)
+	if err != nil {
+		part.Err = err
+		return verification
+	}
+
+	// nodes are shared by the tries, so they only need to be verified once
+	verified := make(map[*node.Node]regStats)
+	for _, t := range tries {
+		stats, corrupted, err := verifyTopLevelNodes(t.RootNode(), 0, ledger.Path{}, nil, verified)
+		if err != nil {
+			part.Err = fmt.Errorf("could not verify trie %v: %w", t.RootHash(), err)
+			return verification
+		}
+		if corrupted != nil && part.CorruptedNode == nil {
+			part.CorruptedNode = corrupted
+		}
+
+		verification.Tries = append(verification.Tries, &TrieVerification{
+			RootHash:         t.RootHash(),
+			RegCount:         t.AllocatedRegCount(),
+			ComputedRegCount: stats.count,
+			RegSize:          t.AllocatedRegSize(),
+			ComputedRegSize:  stats.size,
+		})
+	}
+	part.NodeCount = uint64(len(verified))
+
+	logger.Info().
+		Int("base_checkpoint", base).
+		Uint64("nodes", part.NodeCount).
+		Bool("valid", part.Valid()).
+		Msg("verified incremental checkpoint")
+
+	return verification
+}
+
+// verifyCheckpointSubTrie reads the subtrie part file with the given index, and verifies the hash
+// of every node. It returns the nodes, as well as the register stats of the subtrie roots.
+func verifyCheckpointSubTrie(dir string, fileName string, index int, checksum uint32, logger zerolog.Logger) (
+	*CheckpointPartVerification,
+	[]*node.Node,
+	map[*node.Node]regStats,
+) {
+	part := &CheckpointPartVerification{Index: index}
+	var nodes []*node.Node
+	roots := make(map[*node.Node]regStats)
+
+	part.Err = processCheckpointSubTrie(dir, fileName, index, checksum, logger,
+		func(reader *Crc32Reader, nodesCount uint64) error {
+			scratch := make([]byte, 1024*4) // must not be less than 1024
+
+			part.NodeCount = nodesCount
+			nodes = make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
+			stats := make([]regStats, nodesCount+1)
+
+			logging := logProgress(fmt.Sprintf("verifying %v-th sub trie", index), int(nodesCount), logger)
+			for i := uint64(1); i <= nodesCount; i++ {
+				childStats := regStats{}
+				n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+					if nodeIndex >= i {
+						return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+					}
+					childStats = childStats.add(stats[nodeIndex])
+					return nodes[nodeIndex], nil
+				})
+				if err != nil {
+					return fmt.Errorf("cannot read node %d: %w", i, err)
+				}
+				nodes[i] = n
+
+				if n.IsLeaf() {
+					stats[i] = leafRegStats(n)
+				} else {
+					stats[i] = childStats
+				}
+				if n.Height() == ledger.NodeMaxHeight-subtrieLevel {
+					roots[n] = stats[i]
+				}
+
+				if part.CorruptedNode == nil {
+					computedHash, ok := n.VerifyHash()
+					if !ok {
+						part.CorruptedNode = &CorruptedNode{
+							NodeIndex:    i,
+							Height:       n.Height(),
+							Path:         nodePath(n),
+							StoredHash:   n.Hash(),
+							ComputedHash: computedHash,
+						}
+					}
+				}
+				logging(i)
+			}
+			return nil
+		})
+
+	if part.Err != nil {
+		return part, nil, nil
+	}
+	return part, nodes[1:], roots
+}
+
+// verifyTopLevelNodes verifies the hashes of the nodes from the given node down to the subtrie level,
+// and returns the register stats of the subtrie, as well as the first corrupted node, if any.
+// If subtrieRootStats is nil, the nodes of the whole subtrie are verified.
+// Any error indicates that the subtrie roots are inconsistent with the top level nodes.
+func verifyTopLevelNodes(
+	n *node.Node,
+	depth int,
+	path ledger.Path,
+	subtrieRootStats map[*node.Node]regStats,
+	verified map[*node.Node]regStats,
+) (regStats, *CorruptedNode, error) {
+	if n == nil {
+		return regStats{}, nil, nil
+	}
+	if depth == subtrieLevel && subtrieRootStats != nil {
+		stats, ok := subtrieRootStats[n]
+		if !ok {
+			return regStats{}, nil, fmt.Errorf("subtrie root at height %d with path %x not found in subtrie part files", n.Height(), path[:])
+		}
+		return stats, nil, nil
+	}
+	if stats, ok := verified[n]; ok {
+		return stats, nil, nil
+	}
+
+	var stats regStats
+	var corrupted *CorruptedNode
+	if n.IsLeaf() {
+		stats = leafRegStats(n)
+	} else {
+		rightPath := path
+		bitutils.SetBit(rightPath[:], depth)
+
+		leftStats, leftCorrupted, err := verifyTopLevelNodes(n.LeftChild(), depth+1, path, subtrieRootStats, verified)
+		if err != nil {
+			return regStats{}, nil, err
+		}
+		rightStats, rightCorrupted, err := verifyTopLevelNodes(n.RightChild(), depth+1, rightPath, subtrieRootStats, verified)
+		if err != nil {
+			return regStats{}, nil, err
+		}
+		stats = leftStats.add(rightStats)
+		corrupted = leftCorrupted
+		if corrupted == nil {
+			corrupted = rightCorrupted
+		}
+	}
+
+	if corrupted == nil {
+		computedHash, ok := n.VerifyHash()
+		if !ok {
+			corrupted = &CorruptedNode{
+				Height:       n.Height(),
+				Path:         nodePath(n),
+				StoredHash:   n.Hash(),
+				ComputedHash: computedHash,
+			}
+		}
+	}
+
+	verified[n] = stats
+	return stats, corrupted, nil
+}
+
+// nodePath returns the path of a leaf, or the path prefix of an interim node, which is taken from
+// any leaf of its subtrie.
+func nodePath(n *node.Node) ledger.Path {
+	leaf := n
+	for !leaf.IsLeaf() {
+		if leaf.LeftChild() != nil {
+			leaf = leaf.LeftChild()
+		} else {
+			leaf = leaf.RightChild()
+		}
+	}
+
+	p := *leaf.Path()
+	if n.IsLeaf() {
+		return p
+	}
+	for i := ledger.NodeMaxHeight - n.Height(); i < ledger.NodeMaxHeight; i++ {
+		bitutils.ClearBit(p[:], i)
+	}
+	return p
+}
+
+// RegenerateCheckpointPartFiles regenerates the given part files of the checkpoint with the given number
+// from the WAL. The tries of the checkpoint are rebuilt by loading the latest checkpoint before it, and
+// replaying the WAL segments up to the checkpoint number. Since the checkpoint format is deterministic,
+// the regenerated part files are expected to be identical to the original ones. A part file is only
+// replaced, if the checksum of the regenerated part file matches the checksum in the checkpoint header.
+//
+// Returned errors indicate that the part files can't be regenerated, in which case no part file is replaced.
+func (w *DiskWAL) RegenerateCheckpointPartFiles(checkpoint int, parts []int) error {
+	fileName := NumberToFilename(checkpoint)
+
+	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(filePathCheckpointHeader(w.dir, fileName), w.log)
+	if err != nil {
+		return fmt.Errorf("could not read checkpoint header, the checkpoint can't be repaired: %w", err)
+	}
+
+	for _, part := range parts {
+		if part < 0 || part > TopTriePartIndex {
+			return fmt.Errorf("invalid part file index %d", part)
+		}
+	}
+
+	// the order of the tries is needed to regenerate identical part files. If the top level
+	// part file is intact, it is used to restore the order of the tries from the WAL.
+	rootHashes, err := readTriesRootHash(w.log, w.dir, fileName)
+	if err != nil {
+		w.log.Warn().Err(err).Msg("could not read trie root hashes of checkpoint, using trie order from WAL")
+		rootHashes = nil
+	}
+
+	tries, err := w.triesAtCheckpoint(checkpoint, len(rootHashes))
+	if err != nil {
+		return fmt.Errorf("could not rebuild tries of checkpoint %d from WAL: %w", checkpoint, err)
+	}
+
+	if rootHashes != nil {
+		tries, err = orderTriesByRootHash(tries, rootHashes)
+		if err != nil {
+			return fmt.Errorf("tries rebuilt from WAL don't match checkpoint %d: %w", checkpoint, err)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp(w.dir, "regenerate-checkpoint-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = StoreCheckpointV6Concurrently(tries, tmpDir, fileName, w.log)
+	if err != nil {
+		return fmt.Errorf("could not store regenerated checkpoint: %w", err)
+	}
+
+	regeneratedSubtrieChecksums, regeneratedTopTrieChecksum, err := readCheckpointHeader(filePathCheckpointHeader(tmpDir, fileName), w.log)
+	if err != nil {
+		return fmt.Errorf("could not read regenerated checkpoint header: %w", err)
+	}
+
+	// check all part files before replacing any of them
+	for _, part := range parts {
+		expected, actual := topTrieChecksum, regeneratedTopTrieChecksum
+		if part < TopTriePartIndex {
+			expected, actual = subtrieChecksums[part], regeneratedSubtrieChecksums[part]
+		}
+		if expected != actual {
+			return fmt.Errorf("checksum of regenerated part file %d is %v, but checkpoint header has %v", part, actual, expected)
+		}
+	}
+
+	for _, part := range parts {
+		_, partFile := filePathTopTries(w.dir, fileName)
+		if part < TopTriePartIndex {
+			_, partFile, _ = filePathSubTries(w.dir, fileName, part)
+		}
+
+		err = os.Rename(path.Join(tmpDir, partFile), path.Join(w.dir, partFile))
+		if err != nil {
+			return fmt.Errorf("could not replace part file %v: %w", partFile, err)
+		}
+		w.log.Info().Msgf("replaced part file %v with regenerated part file", partFile)
+	}
+
+	return nil
+}
+
+// triesAtCheckpoint rebuilds the tries at the given checkpoint number by loading the latest
+// valid checkpoint before it (or the root checkpoint) and replaying the WAL segments up to the
+// checkpoint number. If capacity is 0, the forest capacity of the WAL is used.
+func (w *DiskWAL) triesAtCheckpoint(checkpoint int, capacity int) ([]*trie.MTrie, error) {
+	if capacity == 0 {
+		capacity = w.forestCapacity
+	}
+	forest, err := mtrie.NewForest(capacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create forest: %w", err)
+	}
+
+	checkpoints, err := Checkpoints(w.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// find the latest loadable checkpoint before the given checkpoint
+	startSegment := 0
+	for i := len(checkpoints) - 1; i >= 0; i-- {
+		if checkpoints[i] >= checkpoint {
+			continue
+		}
+		tries, err := LoadCheckpoint(path.Join(w.dir, NumberToFilename(checkpoints[i])), w.log)
+		if err != nil {
+			w.log.Warn().Err(err).Msgf("could not load checkpoint %d, trying earlier checkpoint", checkpoints[i])
+			continue
+		}
+		err = forest.AddTries(tries)
+		if err != nil {
+			return nil, fmt.Errorf("cannot add tries of checkpoint %d: %w", checkpoints[i], err)
+		}
+		startSegment = checkpoints[i] + 1
+		break
+	}
+
+	// if no earlier checkpoint was loaded, replay starts at segment 0 with the root checkpoint
+	err = w.replay(startSegment, checkpoint,
+		func(tries []*trie.MTrie) error {
+			return forest.AddTries(tries)
+		},
+		func(update *ledger.TrieUpdate) error {
+			_, err := forest.Update(update)
+			return err
+		},
+		func(ledger.RootHash) error {
+			return nil
+		},
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot replay WAL: %w", err)
+	}
+
+	return forest.GetTries()
+}
+
+// orderTriesByRootHash returns the tries with the given root hashes, in the order of the root hashes.
+func orderTriesByRootHash(tries []*trie.MTrie, rootHashes []ledger.RootHash) ([]*trie.MTrie, error) {
+	byRootHash := make(map[ledger.RootHash]*trie.MTrie, len(tries))
+	for _, t := range tries {
+		byRootHash[t.RootHash()] = t
+	}
+
+	ordered := make([]*trie.MTrie, len(rootHashes))
+	for i, rootHash := range rootHashes {
+		t, ok := byRootHash[rootHash]
+		if !ok {
+			return nil, fmt.Errorf("trie with root hash %v not found", rootHash)
+		}
+		ordered[i] = t
+	}
+	return ordered, nil
+}
@@ -0,0 +1,217 @@
+package wal
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestVerifyCheckpointV6(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-verify"
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger))
+
+		result, err := VerifyCheckpointV6(dir, fileName, 4, logger)
+		require.NoError(t, err)
+		require.True(t, result.Valid())
+		require.Empty(t, result.CorruptedParts())
+		require.Len(t, result.Parts, subtrieCount+1)
+		require.Len(t, result.Tries, len(tries))
+
+		for i, tr := range tries {
+			require.Equal(t, tr.RootHash(), result.Tries[i].RootHash)
+			require.Equal(t, tr.AllocatedRegCount(), result.Tries[i].ComputedRegCount)
+			require.Equal(t, tr.AllocatedRegSize(), result.Tries[i].ComputedRegSize)
+		}
+	})
+}
+
+// TestVerifyIncrementalCheckpoint tests that incremental checkpoints are verified against their base checkpoint,
+// and that a checkpoint, which doesn't match its base checkpoint, is reported as invalid.
+func TestVerifyIncrementalCheckpoint(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+
+		tries := append(baseTries[len(baseTries)-1:], updateTries(t, baseTries[len(baseTries)-1], 2)...)
+		require.NoError(t, StoreIncrementalCheckpoint(1, baseTries, tries, dir, NumberToFilename(2), logger))
+
+		result, err := VerifyCheckpointV6(dir, NumberToFilename(2), 4, logger)
+		require.NoError(t, err)
+		require.True(t, result.Valid())
+		require.True(t, result.Incremental)
+		require.Equal(t, 1, result.BaseCheckpoint)
+		require.Len(t, result.Parts, 1)
+		require.Len(t, result.Tries, len(tries))
+		for i, tr := range tries {
+			require.Equal(t, tr.RootHash(), result.Tries[i].RootHash)
+			require.Equal(t, tr.AllocatedRegCount(), result.Tries[i].ComputedRegCount)
+			require.Equal(t, tr.AllocatedRegSize(), result.Tries[i].ComputedRegSize)
+		}
+
+		// the base checkpoint is verified as a full checkpoint
+		result, err = VerifyCheckpointV6(dir, NumberToFilename(1), 4, logger)
+		require.NoError(t, err)
+		require.True(t, result.Valid())
+		require.False(t, result.Incremental)
+
+		// an incremental checkpoint on top of a different base checkpoint can't be loaded
+		otherTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreIncrementalCheckpoint(1, otherTries, updateTries(t, otherTries[0], 1), dir, NumberToFilename(3), logger))
+
+		result, err = VerifyCheckpointV6(dir, NumberToFilename(3), 4, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+		require.Equal(t, []int{0}, result.CorruptedParts())
+		require.Error(t, result.Parts[0].Err)
+		require.Empty(t, result.Tries)
+
+	})
+}
+
+// TestVerifyCheckpointV6CorruptedPartFile tests that a part file with a wrong checksum is reported,
+// and that the top level part file is skipped.
+func TestVerifyCheckpointV6CorruptedPartFile(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-verify"
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger))
+
+		filePath, _, err := filePathSubTries(dir, fileName, 3)
+		require.NoError(t, err)
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		data[len(data)/2] ^= 0x1
+		require.NoError(t, os.WriteFile(filePath, data, 0644))
+
+		result, err := VerifyCheckpointV6(dir, fileName, 4, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+		require.Equal(t, []int{3}, result.CorruptedParts())
+		require.Error(t, result.Parts[3].Err)
+		require.True(t, result.Parts[TopTriePartIndex].Skipped)
+		require.Empty(t, result.Tries)
+	})
+}
+
+// TestVerifyCheckpointV6CorruptedNode tests that a node with a wrong hash is reported,
+// even if the checksum of its part file is correct.
+func TestVerifyCheckpointV6CorruptedNode(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		tries := createMultipleRandomTries(t)
+		last := tries[len(tries)-1]
+
+		// replace the leftmost leaf with a leaf with a different payload, but the original hash
+		corruptedPayload := ledger.NewPayload(ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte("corrupted"))}), []byte("corrupted"))
+		root, leaf := replaceLeftmostLeaf(last.RootNode(), corruptedPayload)
+		corruptedTrie, err := trie.NewMTrie(root, last.AllocatedRegCount(), last.AllocatedRegSize())
+		require.NoError(t, err)
+		require.Equal(t, last.RootHash(), corruptedTrie.RootHash())
+
+		fileName := "checkpoint-verify"
+		require.NoError(t, StoreCheckpointV6Concurrently([]*trie.MTrie{corruptedTrie}, dir, fileName, logger))
+
+		result, err := VerifyCheckpointV6(dir, fileName, 4, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+
+		part := int(leaf.Path()[0] >> 4)
+		require.Equal(t, []int{part}, result.CorruptedParts())
+		require.NoError(t, result.Parts[part].Err)
+		corrupted := result.Parts[part].CorruptedNode
+		require.NotNil(t, corrupted)
+		require.Equal(t, *leaf.Path(), corrupted.Path)
+		require.Equal(t, leaf.Hash(), corrupted.StoredHash)
+		require.NotEqual(t, corrupted.StoredHash, corrupted.ComputedHash)
+	})
+}
+
+// replaceLeftmostLeaf returns a copy of the given subtrie, where the leftmost leaf has the given payload,
+// but keeps its hash. All ancestors of the leaf keep their hashes as well.
+func replaceLeftmostLeaf(n *node.Node, payload *ledger.Payload) (*node.Node, *node.Node) {
+	if n.IsLeaf() {
+		leaf := node.NewNode(n.Height(), nil, nil, *n.Path(), payload, n.Hash())
+		return leaf, leaf
+	}
+	lChild, rChild := n.LeftChild(), n.RightChild()
+	var leaf *node.Node
+	if lChild != nil {
+		lChild, leaf = replaceLeftmostLeaf(lChild, payload)
+	} else {
+		rChild, leaf = replaceLeftmostLeaf(rChild, payload)
+	}
+	return node.NewNode(n.Height(), lChild, rChild, ledger.Path{}, nil, n.Hash()), leaf
+}
+
+func TestRegenerateCheckpointPartFiles(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		w, err := NewDiskWAL(logger, nil, metrics.NewNoopCollector(), dir, 100, pathfinder.PathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		forest, err := mtrie.NewForest(100, &metrics.NoopCollector{}, nil)
+		require.NoError(t, err)
+
+		rootHash := trie.NewEmptyMTrie().RootHash()
+		for i := 0; i < 20; i++ {
+			paths, payloads := randNPathPayloads(50)
+			payloadPtrs := make([]*ledger.Payload, len(payloads))
+			for j := range payloads {
+				payloadPtrs[j] = &payloads[j]
+			}
+			update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloadPtrs}
+			_, _, err = w.RecordUpdate(update)
+			require.NoError(t, err)
+			rootHash, err = forest.Update(update)
+			require.NoError(t, err)
+		}
+
+		_, last, err := w.Segments()
+		require.NoError(t, err)
+		require.Greater(t, last, 1)
+
+		checkpointer, err := w.NewCheckpointer()
+		require.NoError(t, err)
+		require.NoError(t, checkpointer.Checkpoint(last-1))
+
+		fileName := NumberToFilename(last - 1)
+		original, err := os.ReadFile(path.Join(dir, fileName+".000"))
+		require.NoError(t, err)
+
+		corrupted := make([]byte, len(original))
+		copy(corrupted, original)
+		corrupted[len(corrupted)/2] ^= 0x1
+		require.NoError(t, os.WriteFile(path.Join(dir, fileName+".000"), corrupted, 0644))
+
+		result, err := VerifyCheckpointV6(dir, fileName, 4, logger)
+		require.NoError(t, err)
+		require.Equal(t, []int{0}, result.CorruptedParts())
+
+		require.NoError(t, w.RegenerateCheckpointPartFiles(last-1, result.CorruptedParts()))
+
+		regenerated, err := os.ReadFile(path.Join(dir, fileName+".000"))
+		require.NoError(t, err)
+		require.Equal(t, original, regenerated)
+
+		result, err = VerifyCheckpointV6(dir, fileName, 4, logger)
+		require.NoError(t, err)
+		require.True(t, result.Valid())
+
+		<-w.Done()
+	})
+}
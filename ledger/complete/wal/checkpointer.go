@@ -578,8 +578,11 @@ func storeUniqueNodes(
 			}
 		}
 
-		encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
-		_, err := writer.Write(encNode)
+		encNode, err := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
+		if err != nil {
+			return 0, fmt.Errorf("cannot encode node: %w", err)
+		}
+		_, err = writer.Write(encNode)
 		if err != nil {
 			return 0, fmt.Errorf("cannot serialize node: %w", err)
 		}
@@ -684,6 +687,10 @@ func readCheckpoint(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
 		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
 	}
 
+	if magicBytes == MagicBytesCheckpointIncremental {
+		return readIncrementalCheckpoint(f, logger)
+	}
+
 	if magicBytes != MagicBytesCheckpointHeader {
 		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytesCheckpointHeader)
 	}
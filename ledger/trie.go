@@ -24,6 +24,24 @@ func (p Path) MarshalJSON() ([]byte, error) {
 	return json.Marshal(hex.EncodeToString(p[:]))
 }
 
+// UnmarshalJSON unmarshals a hex encoded JSON value of path.
+func (p *Path) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	pathBytes, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	path, err := ToPath(pathBytes)
+	if err != nil {
+		return err
+	}
+	*p = path
+	return nil
+}
+
 // DummyPath is an arbitrary path value, used in function error returns.
 var DummyPath = Path(hash.DummyHash)
 
@@ -73,9 +91,17 @@ func ComputeCompactValue(path hash.Hash, value []byte, nodeHeight int) hash.Hash
 		return GetDefaultHashForHeight(nodeHeight)
 	}
 
-	var out hash.Hash
-	out = hash.HashLeaf(path, value)   // we first compute the hash of the fully-expanded leaf
-	for h := 1; h <= nodeHeight; h++ { // then, we hash our way upwards towards the root until we hit the specified nodeHeight
+	// we first compute the hash of the fully-expanded leaf
+	return ComputeCompactValueFromLeafHash(path, hash.HashLeaf(path, value), nodeHeight)
+}
+
+// ComputeCompactValueFromLeafHash computes the value for the node considering the sub tree
+// to only include this value and default values, given the hash of the fully-expanded leaf
+// (see hash.HashLeaf). This allows computing the value without the payload.
+// UNCHECKED: the leaf hash is not the hash of an unallocated register
+func ComputeCompactValueFromLeafHash(path hash.Hash, leafHash hash.Hash, nodeHeight int) hash.Hash {
+	out := leafHash
+	for h := 1; h <= nodeHeight; h++ { // we hash our way upwards towards the root until we hit the specified nodeHeight
 		// h is the height of the node, whose hash we are computing in this iteration.
 		// The hash is computed from the node's children at height h-1.
 		bit := bitutils.ReadBit(path[:], NodeMaxHeight-h)
@@ -167,6 +193,24 @@ func (rh RootHash) MarshalJSON() ([]byte, error) {
 	return json.Marshal(rh.String())
 }
 
+// UnmarshalJSON unmarshals a hex encoded JSON value of root hash.
+func (rh *RootHash) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	rootHashBytes, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	rootHash, err := ToRootHash(rootHashBytes)
+	if err != nil {
+		return err
+	}
+	*rh = rootHash
+	return nil
+}
+
 func (rh RootHash) String() string {
 	return hex.EncodeToString(rh[:])
 }
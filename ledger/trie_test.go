@@ -1,6 +1,7 @@
 package ledger
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"testing"
 
@@ -511,6 +512,37 @@ func TestPayloadJSONSerialization(t *testing.T) {
 	})
 }
 
+// TestPathAndRootHashJSONSerialization tests that paths and root hashes are encoded as hex
+// strings in JSON, and decoded back.
+func TestPathAndRootHashJSONSerialization(t *testing.T) {
+	var path Path
+	var rootHash RootHash
+	for i := range path {
+		path[i] = byte(i)
+		rootHash[i] = byte(2 * i)
+	}
+
+	b, err := json.Marshal(path)
+	require.NoError(t, err)
+	require.Equal(t, `"`+hex.EncodeToString(path[:])+`"`, string(b))
+
+	var path2 Path
+	require.NoError(t, json.Unmarshal(b, &path2))
+	require.Equal(t, path, path2)
+
+	b, err = json.Marshal(rootHash)
+	require.NoError(t, err)
+	require.Equal(t, `"`+rootHash.String()+`"`, string(b))
+
+	var rootHash2 RootHash
+	require.NoError(t, json.Unmarshal(b, &rootHash2))
+	require.Equal(t, rootHash, rootHash2)
+
+	// values of the wrong length are rejected
+	require.Error(t, json.Unmarshal([]byte(`"0102"`), &path2))
+	require.Error(t, json.Unmarshal([]byte(`"0102"`), &rootHash2))
+}
+
 func TestPayloadCBORSerialization(t *testing.T) {
 	t.Run("nil payload", func(t *testing.T) {
 		encoded := []byte{0xf6} // null
@@ -92,6 +92,16 @@ func (cf CFNonMatchingFinalState) ExecutionResultID() flow.Identifier {
 	return cf.execResID
 }
 
+// Expected returns the final state commitment claimed by the execution result
+func (cf CFNonMatchingFinalState) Expected() flow.StateCommitment {
+	return cf.expected
+}
+
+// Computed returns the final state commitment computed by re-executing the chunk
+func (cf CFNonMatchingFinalState) Computed() flow.StateCommitment {
+	return cf.computed
+}
+
 // NewCFNonMatchingFinalState creates a new instance of Chunk Fault (NonMatchingFinalState)
 func NewCFNonMatchingFinalState(expected flow.StateCommitment, computed flow.StateCommitment, chInx uint64, execResID flow.Identifier) *CFNonMatchingFinalState {
 	return &CFNonMatchingFinalState{expected: expected,
@@ -129,6 +139,16 @@ func (c *CFInvalidEventsCollection) ExecutionResultID() flow.Identifier {
 	return c.resultID
 }
 
+// Expected returns the events collection hash claimed by the chunk
+func (c *CFInvalidEventsCollection) Expected() flow.Identifier {
+	return c.expected
+}
+
+// Computed returns the events collection hash computed by re-executing the chunk
+func (c *CFInvalidEventsCollection) Computed() flow.Identifier {
+	return c.computed
+}
+
 func (c *CFInvalidEventsCollection) String() string {
 	return fmt.Sprintf("events collection hash differs, got %x expected %x for chunk %d with result ID %s, events IDs: %v", c.computed, c.expected,
 		c.chunkIndex, c.resultID, c.eventIDs)
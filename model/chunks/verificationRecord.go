@@ -0,0 +1,77 @@
+package chunks
+
+import (
+	"errors"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// VerificationRecord is a durable record of the verification of a single chunk by a verification node.
+type VerificationRecord struct {
+	// ResultID is the ID of the execution result the chunk belongs to.
+	ResultID flow.Identifier
+	// ChunkIndex is the index of the chunk within the execution result.
+	ChunkIndex uint64
+	// BlockID is the ID of the executed block.
+	BlockID flow.Identifier
+	// BlockHeight is the height of the executed block.
+	BlockHeight uint64
+	// Executors are the execution nodes that committed to the execution result.
+	Executors flow.IdentifierList
+	// ChunkDataPackOrigin is the execution node that provided the chunk data pack.
+	ChunkDataPackOrigin flow.Identifier
+	// FaultType is the type of the chunk fault found during verification (see ChunkFaultType),
+	// or empty if the chunk passed verification.
+	FaultType string
+	// Fault is the description of the chunk fault, or empty if the chunk passed verification.
+	Fault string
+	// FetchDuration is the time between requesting the chunk data pack and its arrival.
+	FetchDuration time.Duration
+	// VerifyDuration is the time spent re-executing the chunk.
+	VerifyDuration time.Duration
+	// VerifiedAt is the time the verification completed.
+	VerifiedAt time.Time
+}
+
+// Valid returns true if the chunk passed verification.
+func (r *VerificationRecord) Valid() bool {
+	return r.FaultType == ""
+}
+
+// ChunkFaultType returns a short name for the type of the given chunk fault, to be used as log field
+// or metric label. It returns an empty string for nil errors and "unknown" for errors that are not
+// chunk faults.
+func ChunkFaultType(err error) string {
+	if err == nil {
+		return ""
+	}
+	var cfErr ChunkFaultError
+	if !errors.As(err, &cfErr) {
+		return "unknown"
+	}
+	switch cfErr.(type) {
+	case *CFMissingRegisterTouch:
+		return "missing_register_touch"
+	case *CFNonMatchingFinalState:
+		return "final_state_mismatch"
+	case *CFInvalidVerifiableChunk:
+		return "invalid_verifiable_chunk"
+	case *CFInvalidEventsCollection:
+		return "invalid_event_collection"
+	case *CFInvalidServiceEventsEmitted:
+		return "invalid_service_events"
+	case *CFSystemChunkIncludedCollection:
+		return "system_chunk_includes_collection"
+	case *CFExecutionDataBlockIDMismatch:
+		return "execution_data_block_id_mismatch"
+	case *CFExecutionDataChunksLengthMismatch:
+		return "execution_data_chunks_count_mismatch"
+	case *CFExecutionDataInvalidChunkCID:
+		return "execution_data_chunk_cid_mismatch"
+	case *CFInvalidExecutionDataID:
+		return "execution_data_root_cid_mismatch"
+	default:
+		return "unknown"
+	}
+}
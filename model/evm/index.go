@@ -0,0 +1,62 @@
+// Package evm contains the EVM blocks, transactions and receipts indexed from the EVM events emitted by Flow blocks.
+package evm
+
+import (
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Block is an EVM block indexed from its EVM.BlockExecuted event.
+type Block struct {
+	Height              uint64
+	Hash                gethCommon.Hash
+	ParentHash          gethCommon.Hash
+	Timestamp           uint64
+	TotalGasUsed        uint64
+	ReceiptRoot         gethCommon.Hash
+	TransactionHashRoot gethCommon.Hash
+	PrevRandao          gethCommon.Hash
+	// TransactionHashes are the hashes of the transactions of the block, ordered by their index in the block.
+	TransactionHashes []gethCommon.Hash
+	// LogsBloom is the bloom filter of the logs of all transactions of the block.
+	LogsBloom gethTypes.Bloom
+	// FlowHeight is the height of the Flow block which committed the EVM block.
+	FlowHeight uint64
+	// FlowBlockID is the ID of the Flow block which committed the EVM block.
+	FlowBlockID flow.Identifier
+}
+
+// Transaction is an EVM transaction indexed from its EVM.TransactionExecuted event.
+type Transaction struct {
+	Hash        gethCommon.Hash
+	BlockHeight uint64
+	Index       uint16
+	// Type is the Ethereum transaction type, or types.DirectCallTxType for direct calls.
+	Type uint8
+	// Payload is the binary encoding of the transaction, or the encoded direct call.
+	Payload []byte
+	// From is the sender of the transaction, recovered from its signature or taken from the direct call.
+	From gethCommon.Address
+	// FlowBlockID is the ID of the Flow block which executed the transaction.
+	FlowBlockID flow.Identifier
+}
+
+// Receipt is the receipt of an indexed EVM transaction.
+type Receipt struct {
+	TransactionHash   gethCommon.Hash
+	BlockHeight       uint64
+	BlockHash         gethCommon.Hash
+	Index             uint16
+	Status            uint64
+	GasUsed           uint64
+	CumulativeGasUsed uint64
+	// ContractAddress is the address of the contract deployed by the transaction, nil if none was deployed.
+	ContractAddress *gethCommon.Address
+	// ErrorMessage is the error of the transaction if it failed.
+	ErrorMessage string
+	// Logs are the logs emitted by the transaction, with their position within the block.
+	Logs      []*gethTypes.Log
+	LogsBloom gethTypes.Bloom
+}
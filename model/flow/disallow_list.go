@@ -0,0 +1,23 @@
+package flow
+
+import (
+	"time"
+)
+
+// DisallowListEntry is an operator-defined disallow-listing of a node, i.e., a node that is disallow-listed by the
+// networking layer till the entry is removed or expires.
+type DisallowListEntry struct {
+	// NodeID is the node ID of the disallow-listed node.
+	NodeID Identifier
+	// Reason is the operator-provided reason for disallow-listing the node, empty if none is provided.
+	Reason string
+	// CreatedAt is the time at which the node is disallow-listed.
+	CreatedAt time.Time
+	// Expiry is the time at which the entry expires and the node is allow-listed again, zero if the entry does not expire.
+	Expiry time.Time
+}
+
+// Expired returns true if the entry has an expiry that is not after the given time.
+func (e DisallowListEntry) Expired(now time.Time) bool {
+	return !e.Expiry.IsZero() && !e.Expiry.After(now)
+}
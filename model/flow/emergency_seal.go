@@ -0,0 +1,43 @@
+package flow
+
+// EmergencySeal is an audit record for a seal that was constructed with fewer approvals than
+// required for regular seal construction. Consensus nodes fall back to emergency sealing when
+// verification is stalled for a long period of time (see `DefaultEmergencySealingActive`). The record
+// captures which chunks of the sealed result were not (sufficiently) approved and which
+// verifiers were assigned to them, so operators can audit how often the network relied on
+// emergency sealing and which verifiers did not deliver.
+type EmergencySeal struct {
+	// ResultID is the ID of the execution result that was sealed.
+	ResultID Identifier
+	// BlockID is the ID of the executed block, which the result is for.
+	BlockID Identifier
+	// BlockHeight is the height of the executed block.
+	BlockHeight uint64
+	// IncorporatedBlockID is the ID of the block incorporating the result, which
+	// determines the verifier assignment.
+	IncorporatedBlockID Identifier
+	// RequiredApprovals is the number of approvals per chunk that were required for regular
+	// seal construction at the time the seal was produced. Access nodes reconstruct records
+	// from finalized seals without knowing the requirement of the consensus node, which
+	// constructed the seal, and report the minimal requirement of one approval.
+	RequiredApprovals uint
+	// UnapprovedChunks lists every chunk of the result that did not collect the required
+	// number of approvals, ordered by chunk index.
+	UnapprovedChunks []EmergencySealChunk
+}
+
+// EmergencySealChunk describes a single chunk of an emergency-sealed result, which did not
+// collect the required number of approvals.
+type EmergencySealChunk struct {
+	// Index is the index of the chunk within the execution result.
+	Index uint64
+	// AssignedVerifiers is the set of verification nodes that were assigned to verify the chunk.
+	AssignedVerifiers IdentifierList
+	// ApprovedBy is the subset of assigned verifiers that did provide an approval for the chunk.
+	ApprovedBy IdentifierList
+}
+
+// ID returns the ID of the emergency seal record, which is the ID of the sealed result.
+func (s *EmergencySeal) ID() Identifier {
+	return s.ResultID
+}
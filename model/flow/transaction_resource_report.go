@@ -0,0 +1,83 @@
+package flow
+
+import (
+	"sort"
+)
+
+// TransactionResourceReport is the breakdown of the resources metered by the FVM while executing a transaction.
+//
+// All fields are derived from the meters of the transaction, and intensities are sorted by kind. The computation,
+// the writes and the events are the same for executing the same transaction against the same state on any execution
+// node. The fields marked as node-local are not: the memory usage is an estimate, like TransactionResult.MemoryUsed,
+// and the reads depend on the programs cached by the execution node, which are not read from storage and metered
+// again. Hence, node-local fields must not be compared across execution nodes or executions (see ConsistentWith).
+type TransactionResourceReport struct {
+	// TransactionID is the ID of the transaction the report was generated for.
+	TransactionID Identifier
+	// ComputationUsed is the total computation used, in computation units.
+	ComputationUsed uint64
+	// ComputationIntensities are the metered intensities per Cadence computation kind, sorted by kind.
+	ComputationIntensities []ResourceIntensity
+	// MemoryEstimate is the total estimated memory used, in bytes. Node-local.
+	MemoryEstimate uint64
+	// MemoryIntensities are the metered intensities per Cadence memory kind, sorted by kind. Node-local.
+	MemoryIntensities []ResourceIntensity
+	// BytesRead is the size of the registers read from storage, including their keys. Node-local.
+	BytesRead uint64
+	// BytesWritten is the size of the registers written to storage, including their keys.
+	BytesWritten uint64
+	// RegistersTouched is the number of distinct registers read or written. Node-local.
+	RegistersTouched uint64
+	// RegistersUpdated is the number of distinct registers written.
+	RegistersUpdated uint64
+	// EventBytes is the size of the events emitted.
+	EventBytes uint64
+}
+
+// ConsistentWith returns true if both reports are for the same transaction, and report the same usage of the
+// resources which don't depend on the execution node, i.e. all fields except the node-local ones.
+func (r *TransactionResourceReport) ConsistentWith(other *TransactionResourceReport) bool {
+	return r.TransactionID == other.TransactionID &&
+		r.ComputationUsed == other.ComputationUsed &&
+		equalResourceIntensities(r.ComputationIntensities, other.ComputationIntensities) &&
+		r.BytesWritten == other.BytesWritten &&
+		r.RegistersUpdated == other.RegistersUpdated &&
+		r.EventBytes == other.EventBytes
+}
+
+// equalResourceIntensities returns true if both lists contain the same intensities in the same order.
+func equalResourceIntensities(a, b []ResourceIntensity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ResourceIntensity is the metered intensity of one kind of computation or memory.
+type ResourceIntensity struct {
+	// Kind is the Cadence computation kind or memory kind.
+	Kind uint
+	// Intensity is the accumulated intensity metered for the kind.
+	Intensity uint64
+}
+
+// NewResourceIntensities converts the given metered intensities, keyed by computation or memory kind,
+// to resource intensities sorted by kind.
+func NewResourceIntensities[K ~uint](intensities map[K]uint) []ResourceIntensity {
+	result := make([]ResourceIntensity, 0, len(intensities))
+	for kind, intensity := range intensities {
+		result = append(result, ResourceIntensity{
+			Kind:      uint(kind),
+			Intensity: uint64(intensity),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Kind < result[j].Kind
+	})
+	return result
+}
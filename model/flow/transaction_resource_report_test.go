@@ -0,0 +1,42 @@
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestTransactionResourceReportConsistentWith verifies that reports are consistent if they only differ in
+// node-local fields.
+func TestTransactionResourceReportConsistentWith(t *testing.T) {
+	report := unittest.TransactionResourceReportsFixture(1)[0]
+
+	t.Run("node-local fields", func(t *testing.T) {
+		other := report
+		other.MemoryEstimate++
+		other.MemoryIntensities = append([]flow.ResourceIntensity{{Kind: 1000, Intensity: 1}}, report.MemoryIntensities...)
+		other.BytesRead++
+		other.RegistersTouched++
+		require.True(t, report.ConsistentWith(&other))
+		require.True(t, other.ConsistentWith(&report))
+	})
+
+	mutations := map[string]func(r *flow.TransactionResourceReport){
+		"transaction ID":          func(r *flow.TransactionResourceReport) { r.TransactionID = unittest.IdentifierFixture() },
+		"computation used":        func(r *flow.TransactionResourceReport) { r.ComputationUsed++ },
+		"computation intensities": func(r *flow.TransactionResourceReport) { r.ComputationIntensities = nil },
+		"bytes written":           func(r *flow.TransactionResourceReport) { r.BytesWritten++ },
+		"registers updated":       func(r *flow.TransactionResourceReport) { r.RegistersUpdated++ },
+		"event bytes":             func(r *flow.TransactionResourceReport) { r.EventBytes++ },
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			other := report
+			mutate(&other)
+			require.False(t, report.ConsistentWith(&other))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package verification
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
@@ -21,6 +22,9 @@ type ChunkDataPackRequestInfo struct {
 	Agrees    flow.IdentifierList // execution node ids that generated the result of chunk.
 	Disagrees flow.IdentifierList // execution node ids that generated a conflicting result with result of chunk.
 	Targets   flow.IdentityList   // list of all execution nodes identity at the block height of this chunk (including non-responders).
+	// RetainUntil keeps the request pending after its block has been sealed, up to the given time.
+	// It is set for on-demand re-verification of sealed chunks, and is zero for requests of the regular verification pipeline.
+	RetainUntil time.Time
 }
 
 // SampleTargets returns identifier of execution nodes that can be asked for the chunk data pack, based on
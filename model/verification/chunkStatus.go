@@ -1,6 +1,8 @@
 package verification
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -10,6 +12,7 @@ type ChunkStatus struct {
 	ChunkIndex      uint64
 	BlockHeight     uint64
 	ExecutionResult *flow.ExecutionResult
+	RequestedAt     time.Time // time the chunk data pack was requested, used for measuring fetch latency.
 }
 
 func (s ChunkStatus) Chunk() *flow.Chunk {
@@ -1,6 +1,8 @@
 package verification
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 )
@@ -16,4 +18,9 @@ type VerifiableChunkData struct {
 	ChunkDataPack     *flow.ChunkDataPack   // chunk data package needed to verify this chunk
 	EndState          flow.StateCommitment  // state commitment at the end of this chunk
 	TransactionOffset uint32                // index of the first transaction in a chunk within a block
+
+	// the following fields are informational, and recorded in the verification history of the node
+	Executors           flow.IdentifierList // execution nodes that committed to the result
+	ChunkDataPackOrigin flow.Identifier     // execution node that provided the chunk data pack
+	FetchDuration       time.Duration       // time between requesting the chunk data pack and its arrival
 }
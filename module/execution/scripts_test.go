@@ -198,6 +198,7 @@ func (s *scriptTestSuite) SetupTest() {
 		flow.Testnet.Chain(),
 		derivedChainData,
 		nil,
+		nil,
 	)
 	s.Require().NoError(err)
 
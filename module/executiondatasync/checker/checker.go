@@ -0,0 +1,503 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage"
+)
+
+// maxReportedHeights is the maximum number of inconsistent heights included in a Report.
+const maxReportedHeights = 100
+
+// DefaultUntrackedBlobGracePeriod is the default minimum duration for which a blob must be untracked before
+// it is deleted.
+const DefaultUntrackedBlobGracePeriod = time.Hour
+
+// Report is the outcome of a consistency check of the execution data blobstore.
+type Report struct {
+	// StartHeight and EndHeight are the first and last height of the checked range, which is the range of
+	// heights retained by the execution data tracker.
+	StartHeight uint64
+	EndHeight   uint64
+	// InconsistentHeights contains the first heights at which the blob tree is incomplete or can not be decoded.
+	InconsistentHeights []uint64
+	// InconsistentHeightCount is the total number of heights at which the blob tree is incomplete or can not
+	// be decoded.
+	InconsistentHeightCount int
+	// MissingBlobs is the number of blobs referenced by a blob tree which are not in the blobstore.
+	MissingBlobs int
+	// MalformedBlobs is the number of blobs referenced by a blob tree which are corrupted, or can not be decoded.
+	MalformedBlobs int
+	// RefetchedHeights is the number of inconsistent heights for which the execution data was requested again
+	// successfully.
+	RefetchedHeights int
+	// UntrackedBlobs is the number of blobs in the blobstore which are not tracked at any height.
+	UntrackedBlobs int
+	// DeletedBlobs is the number of untracked blobs which were deleted.
+	DeletedBlobs int
+}
+
+// Checker checks the consistency of the execution data blobstore with the execution data tracker, which may
+// become inconsistent if the node crashes while pruning or downloading execution data.
+//
+// The Checker walks the blob tree of the execution data of every height retained by the tracker, and confirms
+// that each blob of the tree exists and decodes. When repairing, corrupted blobs are deleted and the execution
+// data of incomplete trees is requested again, and blobs which are not tracked at any height are deleted.
+//
+// Blobs are stored before they are tracked, and are only tracked once the whole blob tree is downloaded, so an
+// untracked blob is only deleted once it was found untracked by checks spanning at least the untracked blob
+// grace period. This ensures that blobs of execution data being downloaded are not deleted.
+//
+// The Checker can run periodically in the background, if it is configured with a check interval.
+type Checker struct {
+	log        zerolog.Logger
+	blobstore  blobs.Blobstore
+	serializer execution_data.Serializer
+	tracker    tracker.Storage
+	headers    storage.Headers
+	seals      storage.Seals
+	results    storage.ExecutionResults
+
+	// checkInterval is the interval between checks in the background. Checks are only run on demand if zero.
+	checkInterval time.Duration
+	// untrackedGracePeriod is the minimum duration for which a blob must be untracked before it is deleted.
+	untrackedGracePeriod time.Duration
+
+	// mu ensures that the blobstore is not scanned by concurrent checks, and guards the fields below. It is not
+	// held while the execution data of inconsistent heights is requested from the network.
+	mu sync.Mutex
+	// downloader is used to request the execution data of incomplete blob trees. Missing blobs are only
+	// reported if nil.
+	downloader execution_data.ExecutionDataGetter
+	// untracked contains the blobs which were not tracked during the previous check, and the time at which
+	// each of them was first found untracked.
+	untracked map[cid.Cid]time.Time
+
+	component.Component
+}
+
+// inconsistentHeight is a height at which the blob tree of the execution data is incomplete or corrupted.
+type inconsistentHeight struct {
+	height          uint64
+	executionDataID flow.Identifier
+}
+
+type CheckerOption func(*Checker)
+
+// WithCheckInterval configures the checker to check the blobstore periodically in the background.
+func WithCheckInterval(interval time.Duration) CheckerOption {
+	return func(c *Checker) {
+		c.checkInterval = interval
+	}
+}
+
+// WithUntrackedBlobGracePeriod configures the minimum duration for which a blob must be untracked before it
+// is deleted.
+func WithUntrackedBlobGracePeriod(gracePeriod time.Duration) CheckerOption {
+	return func(c *Checker) {
+		c.untrackedGracePeriod = gracePeriod
+	}
+}
+
+// WithSerializer configures the serializer used to decode blobs.
+func WithSerializer(serializer execution_data.Serializer) CheckerOption {
+	return func(c *Checker) {
+		c.serializer = serializer
+	}
+}
+
+// NewChecker creates a new Checker for the given blobstore and execution data tracker.
+func NewChecker(
+	log zerolog.Logger,
+	blobstore blobs.Blobstore,
+	tracker tracker.Storage,
+	headers storage.Headers,
+	seals storage.Seals,
+	results storage.ExecutionResults,
+	opts ...CheckerOption,
+) *Checker {
+	c := &Checker{
+		log:                  log.With().Str("component", "execution_data_checker").Logger(),
+		blobstore:            blobstore,
+		serializer:           execution_data.DefaultSerializer,
+		tracker:              tracker,
+		headers:              headers,
+		seals:                seals,
+		results:              results,
+		untrackedGracePeriod: DefaultUntrackedBlobGracePeriod,
+		untracked:            make(map[cid.Cid]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Component = component.NewComponentManagerBuilder().
+		AddWorker(c.loop).
+		Build()
+
+	return c
+}
+
+// SetDownloader sets the downloader used to request the execution data of incomplete blob trees.
+// The downloader depends on the network, so it may only be available after the checker is created.
+func (c *Checker) SetDownloader(downloader execution_data.ExecutionDataGetter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.downloader = downloader
+}
+
+// loop periodically checks the blobstore, if a check interval is configured.
+func (c *Checker) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	if c.checkInterval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := c.Check(ctx, true)
+			if err != nil && ctx.Err() == nil {
+				c.log.Error().Err(err).Msg("failed to check execution data blobstore")
+			}
+		}
+	}
+}
+
+// Check checks the consistency of the blobstore. If repair is true, corrupted blobs are deleted, the execution
+// data of inconsistent heights is requested again, and blobs which have been untracked for at least the
+// untracked blob grace period are deleted.
+//
+// No errors are expected during normal operation.
+func (c *Checker) Check(ctx context.Context, repair bool) (*Report, error) {
+	start := time.Now()
+
+	report, incomplete, downloader, err := c.checkBlobstore(ctx, repair)
+	if err != nil {
+		return nil, err
+	}
+
+	// requesting the execution data may take long, so it is done without holding the lock
+	if repair && downloader != nil {
+		for _, inconsistent := range incomplete {
+			if err := c.refetch(ctx, downloader, inconsistent, report); err != nil {
+				return nil, fmt.Errorf("failed to request execution data at height %d: %w", inconsistent.height, err)
+			}
+		}
+	}
+
+	c.log.Info().
+		Int("inconsistent_heights", report.InconsistentHeightCount).
+		Int("missing_blobs", report.MissingBlobs).
+		Int("malformed_blobs", report.MalformedBlobs).
+		Int("refetched_heights", report.RefetchedHeights).
+		Int("untracked_blobs", report.UntrackedBlobs).
+		Int("deleted_blobs", report.DeletedBlobs).
+		Dur("duration", time.Since(start)).
+		Msg("checked execution data blobstore")
+
+	return report, nil
+}
+
+// checkBlobstore checks the blob trees of all retained heights and the untracked blobs, and deletes corrupted and
+// untracked blobs if requested. It returns the report, the heights at which the blob tree is incomplete, and the
+// downloader to request their execution data with.
+//
+// No errors are expected during normal operation.
+func (c *Checker) checkBlobstore(
+	ctx context.Context,
+	repair bool,
+) (*Report, []inconsistentHeight, execution_data.ExecutionDataGetter, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prunedHeight, err := c.tracker.GetPrunedHeight()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get pruned height: %w", err)
+	}
+
+	fulfilledHeight, err := c.tracker.GetFulfilledHeight()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get fulfilled height: %w", err)
+	}
+
+	report := &Report{
+		StartHeight: prunedHeight + 1,
+		EndHeight:   fulfilledHeight,
+	}
+
+	c.log.Info().
+		Uint64("start_height", report.StartHeight).
+		Uint64("end_height", report.EndHeight).
+		Bool("repair", repair).
+		Msg("checking execution data blobstore")
+
+	var incomplete []inconsistentHeight
+	for height := report.StartHeight; height <= report.EndHeight; height++ {
+		inconsistent, err := c.checkHeight(ctx, height, repair, report)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to check height %d: %w", height, err)
+		}
+		if inconsistent != nil {
+			incomplete = append(incomplete, *inconsistent)
+		}
+	}
+
+	if err := c.checkUntracked(ctx, repair, report); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to check untracked blobs: %w", err)
+	}
+
+	return report, incomplete, c.downloader, nil
+}
+
+// checkHeight checks the blob tree of the execution data of the given height, and deletes its corrupted blobs if
+// requested. It returns the inconsistent height, or nil if the blob tree is consistent.
+//
+// No errors are expected during normal operation.
+func (c *Checker) checkHeight(ctx context.Context, height uint64, repair bool, report *Report) (*inconsistentHeight, error) {
+	rootID, err := c.executionDataID(height)
+	if err != nil {
+		return nil, err
+	}
+
+	missing, malformed, err := c.checkBlobTree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) == 0 && len(malformed) == 0 {
+		return nil, nil
+	}
+
+	report.MissingBlobs += len(missing)
+	report.MalformedBlobs += len(malformed)
+	report.InconsistentHeightCount++
+	if len(report.InconsistentHeights) < maxReportedHeights {
+		report.InconsistentHeights = append(report.InconsistentHeights, height)
+	}
+
+	c.log.Warn().
+		Uint64("height", height).
+		Hex("execution_data_id", rootID[:]).
+		Int("missing_blobs", len(missing)).
+		Int("malformed_blobs", len(malformed)).
+		Msg("inconsistent execution data blob tree")
+
+	if repair {
+		for _, blobCid := range malformed {
+			if err := c.blobstore.DeleteBlob(ctx, blobCid); err != nil {
+				return nil, fmt.Errorf("failed to delete malformed blob %s: %w", blobCid.String(), err)
+			}
+		}
+	}
+
+	return &inconsistentHeight{height: height, executionDataID: rootID}, nil
+}
+
+// refetch requests the execution data of the given inconsistent height. Downloading the execution data stores the
+// missing blobs in the blobstore, and tracks them again.
+//
+// No errors are expected during normal operation.
+func (c *Checker) refetch(
+	ctx context.Context,
+	downloader execution_data.ExecutionDataGetter,
+	inconsistent inconsistentHeight,
+	report *Report,
+) error {
+	if _, err := downloader.Get(ctx, inconsistent.executionDataID); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.log.Warn().
+			Err(err).
+			Uint64("height", inconsistent.height).
+			Hex("execution_data_id", inconsistent.executionDataID[:]).
+			Msg("failed to request execution data")
+		return nil
+	}
+	report.RefetchedHeights++
+
+	return nil
+}
+
+// executionDataID returns the ID of the execution data of the sealed block at the given height.
+//
+// No errors are expected during normal operation.
+func (c *Checker) executionDataID(height uint64) (flow.Identifier, error) {
+	blockID, err := c.headers.BlockIDByHeight(height)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("failed to get block ID: %w", err)
+	}
+
+	seal, err := c.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("failed to lookup seal for block %s: %w", blockID, err)
+	}
+
+	result, err := c.results.ByID(seal.ResultID)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("failed to lookup execution result for block %s: %w", blockID, err)
+	}
+
+	return result.ExecutionDataID, nil
+}
+
+// checkBlobTree walks the blob tree with the given root ID, and returns the blobs of the tree which are missing
+// from the blobstore, and the blobs which are corrupted or can not be decoded. Blobs below a level which could
+// not be read are not checked.
+//
+// No errors are expected during normal operation.
+func (c *Checker) checkBlobTree(ctx context.Context, rootID flow.Identifier) ([]cid.Cid, []cid.Cid, error) {
+	rootCid := flow.IdToCid(rootID)
+
+	v, missing, malformed, err := c.checkLevel(ctx, []cid.Cid{rootCid})
+	if err != nil || len(missing) > 0 || len(malformed) > 0 {
+		return missing, malformed, err
+	}
+
+	executionDataRoot, ok := v.(*flow.BlockExecutionDataRoot)
+	if !ok {
+		return nil, []cid.Cid{rootCid}, nil
+	}
+
+	for _, chunkExecutionDataID := range executionDataRoot.ChunkExecutionDataIDs {
+		cids := []cid.Cid{chunkExecutionDataID}
+
+	levels:
+		for {
+			v, levelMissing, levelMalformed, err := c.checkLevel(ctx, cids)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(levelMissing) > 0 || len(levelMalformed) > 0 {
+				missing = append(missing, levelMissing...)
+				malformed = append(malformed, levelMalformed...)
+				break
+			}
+
+			switch v := v.(type) {
+			case *execution_data.ChunkExecutionData:
+				break levels
+			case *[]cid.Cid:
+				cids = *v
+			default:
+				malformed = append(malformed, cids...)
+				break levels
+			}
+		}
+	}
+
+	return missing, malformed, nil
+}
+
+// checkLevel reads the blobs of one level of a blob tree, and decodes their data. It returns the decoded value,
+// or the blobs of the level which are missing or malformed.
+//
+// No errors are expected during normal operation.
+func (c *Checker) checkLevel(ctx context.Context, cids []cid.Cid) (interface{}, []cid.Cid, []cid.Cid, error) {
+	var missing, malformed []cid.Cid
+	buf := new(bytes.Buffer)
+
+	for _, blobCid := range cids {
+		blob, err := c.blobstore.Get(ctx, blobCid)
+		if err != nil {
+			if errors.Is(err, blobs.ErrNotFound) {
+				missing = append(missing, blobCid)
+				continue
+			}
+			return nil, nil, nil, fmt.Errorf("failed to get blob %s: %w", blobCid.String(), err)
+		}
+
+		// blobs are content addressed, so a blob of which the data does not match the CID is corrupted
+		dataCid, err := blobCid.Prefix().Sum(blob.RawData())
+		if err != nil || !dataCid.Equals(blobCid) {
+			malformed = append(malformed, blobCid)
+			continue
+		}
+
+		buf.Write(blob.RawData())
+	}
+
+	if len(missing) > 0 || len(malformed) > 0 {
+		return nil, missing, malformed, nil
+	}
+
+	v, err := c.serializer.Deserialize(buf)
+	if err != nil {
+		return nil, nil, cids, nil
+	}
+
+	return v, nil, nil, nil
+}
+
+// checkUntracked finds the blobs in the blobstore which are not tracked at any height, and deletes those which
+// have been untracked since a previous check at least the untracked blob grace period ago, if requested.
+//
+// No errors are expected during normal operation.
+func (c *Checker) checkUntracked(ctx context.Context, repair bool, report *Report) error {
+	keys, err := c.blobstore.AllKeysChan(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list blobs: %w", err)
+	}
+
+	var untracked []cid.Cid
+	for blobCid := range keys {
+		tracked, err := c.tracker.IsTracked(blobCid)
+		if err != nil {
+			return fmt.Errorf("failed to check whether blob %s is tracked: %w", blobCid.String(), err)
+		}
+		if !tracked {
+			untracked = append(untracked, blobCid)
+		}
+	}
+
+	// the keys channel is closed early if the context is canceled
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	report.UntrackedBlobs = len(untracked)
+
+	now := time.Now()
+	stillUntracked := make(map[cid.Cid]time.Time, len(untracked))
+	for _, blobCid := range untracked {
+		firstSeen, ok := c.untracked[blobCid]
+		if !ok {
+			firstSeen = now
+		}
+		// the blob may belong to execution data which is still being downloaded
+		if !ok || !repair || now.Sub(firstSeen) < c.untrackedGracePeriod {
+			stillUntracked[blobCid] = firstSeen
+			continue
+		}
+
+		if err := c.blobstore.DeleteBlob(ctx, blobCid); err != nil {
+			return fmt.Errorf("failed to delete untracked blob %s: %w", blobCid.String(), err)
+		}
+		report.DeletedBlobs++
+	}
+	c.untracked = stillUntracked
+
+	return nil
+}
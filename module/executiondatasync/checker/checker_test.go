@@ -0,0 +1,236 @@
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	edmock "github.com/onflow/flow-go/module/executiondatasync/execution_data/mock"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func getAllKeys(t *testing.T, bs blobs.Blobstore) map[cid.Cid]struct{} {
+	cidChan, err := bs.AllKeysChan(context.Background())
+	require.NoError(t, err)
+
+	keys := make(map[cid.Cid]struct{})
+	for c := range cidChan {
+		keys[c] = struct{}{}
+	}
+	return keys
+}
+
+// TestCheck tests that the checker finds missing, malformed and untracked blobs, and repairs them.
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	store := execution_data.NewExecutionDataStore(blobstore, execution_data.DefaultSerializer)
+
+	trackerStorage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop())
+	require.NoError(t, err)
+
+	headers := storagemock.NewHeaders(t)
+	seals := storagemock.NewSeals(t)
+	results := storagemock.NewExecutionResults(t)
+
+	// add the execution data of heights 1 to 3, and track its blobs
+	executionDatas := make(map[uint64]*execution_data.BlockExecutionData)
+	rootIDs := make(map[uint64]flow.Identifier)
+	heightBlobs := make(map[uint64][]cid.Cid)
+	for height := uint64(1); height <= 3; height++ {
+		executionData := unittest.BlockExecutionDataFixture(unittest.WithChunkExecutionDatas(
+			unittest.ChunkExecutionDataFixture(t, 1024),
+			unittest.ChunkExecutionDataFixture(t, 1024),
+		))
+
+		existing := getAllKeys(t, blobstore)
+		rootID, err := store.Add(ctx, executionData)
+		require.NoError(t, err)
+		for c := range getAllKeys(t, blobstore) {
+			if _, ok := existing[c]; !ok && c != flow.IdToCid(rootID) {
+				heightBlobs[height] = append(heightBlobs[height], c)
+			}
+		}
+		require.NotEmpty(t, heightBlobs[height])
+
+		require.NoError(t, trackerStorage.Update(func(trackBlobs tracker.TrackBlobsFn) error {
+			return trackBlobs(height, append(heightBlobs[height], flow.IdToCid(rootID))...)
+		}))
+
+		executionDatas[height] = executionData
+		rootIDs[height] = rootID
+
+		result := unittest.ExecutionResultFixture(func(result *flow.ExecutionResult) {
+			result.ExecutionDataID = rootID
+		})
+		seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+		headers.On("BlockIDByHeight", height).Return(executionData.BlockID, nil)
+		seals.On("FinalizedSealForBlock", executionData.BlockID).Return(seal, nil)
+		results.On("ByID", seal.ResultID).Return(result, nil)
+	}
+	require.NoError(t, trackerStorage.SetFulfilledHeight(3))
+
+	// remove a blob at height 2, corrupt a blob at height 3, and add an untracked blob
+	require.NoError(t, blobstore.DeleteBlob(ctx, heightBlobs[2][0]))
+	corrupted, err := blocks.NewBlockWithCid([]byte("corrupted"), heightBlobs[3][0])
+	require.NoError(t, err)
+	require.NoError(t, blobstore.DeleteBlob(ctx, corrupted.Cid()))
+	require.NoError(t, blobstore.Put(ctx, corrupted))
+	untracked := blobs.NewBlob([]byte("untracked"))
+	require.NoError(t, blobstore.Put(ctx, untracked))
+
+	var checker *Checker
+	downloader := edmock.NewExecutionDataStore(t)
+	for _, height := range []uint64{2, 3} {
+		executionData := executionDatas[height]
+		downloader.On("Get", mock.Anything, rootIDs[height]).
+			Return(func(ctx context.Context, rootID flow.Identifier) (*execution_data.BlockExecutionData, error) {
+				// the lock is not held while requesting execution data from the network
+				require.True(t, checker.mu.TryLock())
+				checker.mu.Unlock()
+
+				// downloading stores the blobs of the execution data
+				_, err := store.Add(ctx, executionData)
+				return executionData, err
+			}).
+			Once()
+	}
+
+	checker = NewChecker(
+		zerolog.Nop(),
+		blobstore,
+		trackerStorage,
+		headers,
+		seals,
+		results,
+		WithUntrackedBlobGracePeriod(0),
+	)
+	checker.SetDownloader(downloader)
+
+	// the first check only reports the inconsistencies
+	report, err := checker.Check(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		StartHeight:             1,
+		EndHeight:               3,
+		InconsistentHeights:     []uint64{2, 3},
+		InconsistentHeightCount: 2,
+		MissingBlobs:            1,
+		MalformedBlobs:          1,
+		UntrackedBlobs:          1,
+	}, report)
+
+	// the second check repairs the blob trees, and deletes the blob which was untracked during both checks
+	report, err = checker.Check(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.InconsistentHeightCount)
+	assert.Equal(t, 2, report.RefetchedHeights)
+	assert.Equal(t, 1, report.UntrackedBlobs)
+	assert.Equal(t, 1, report.DeletedBlobs)
+
+	has, err := blobstore.Has(ctx, untracked.Cid())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// the blobstore is consistent after the repair
+	report, err = checker.Check(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		StartHeight: 1,
+		EndHeight:   3,
+	}, report)
+
+	for height, rootID := range rootIDs {
+		executionData, err := store.Get(ctx, rootID)
+		require.NoError(t, err)
+		assert.Equal(t, executionDatas[height].BlockID, executionData.BlockID)
+	}
+}
+
+// TestCheckUntrackedWithoutRepair tests that untracked blobs are not deleted unless repairing.
+func TestCheckUntrackedWithoutRepair(t *testing.T) {
+	ctx := context.Background()
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+
+	trackerStorage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop())
+	require.NoError(t, err)
+
+	untracked := blobs.NewBlob([]byte("untracked"))
+	require.NoError(t, blobstore.Put(ctx, untracked))
+
+	checker := NewChecker(
+		zerolog.Nop(),
+		blobstore,
+		trackerStorage,
+		storagemock.NewHeaders(t),
+		storagemock.NewSeals(t),
+		storagemock.NewExecutionResults(t),
+	)
+
+	for i := 0; i < 2; i++ {
+		report, err := checker.Check(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.UntrackedBlobs)
+		assert.Zero(t, report.DeletedBlobs)
+	}
+
+	has, err := blobstore.Has(ctx, untracked.Cid())
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+// TestCheckUntrackedGracePeriod tests that untracked blobs are only deleted once they have been untracked for at
+// least the grace period.
+func TestCheckUntrackedGracePeriod(t *testing.T) {
+	ctx := context.Background()
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+
+	trackerStorage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop())
+	require.NoError(t, err)
+
+	untracked := blobs.NewBlob([]byte("untracked"))
+	require.NoError(t, blobstore.Put(ctx, untracked))
+
+	gracePeriod := 100 * time.Millisecond
+	checker := NewChecker(
+		zerolog.Nop(),
+		blobstore,
+		trackerStorage,
+		storagemock.NewHeaders(t),
+		storagemock.NewSeals(t),
+		storagemock.NewExecutionResults(t),
+		WithUntrackedBlobGracePeriod(gracePeriod),
+	)
+
+	// the blob is not deleted by consecutive checks within the grace period
+	for i := 0; i < 2; i++ {
+		report, err := checker.Check(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.UntrackedBlobs)
+		assert.Zero(t, report.DeletedBlobs)
+	}
+
+	time.Sleep(gracePeriod)
+
+	report, err := checker.Check(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.DeletedBlobs)
+
+	has, err := blobstore.Has(ctx, untracked.Cid())
+	require.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,433 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+const (
+	// ManifestFileName is the name of the manifest file of a FileSink.
+	ManifestFileName = "manifest.json"
+
+	// DefaultMaxSegmentSize is the default size in bytes at which a segment is completed, and the next
+	// records are written to a new segment.
+	DefaultMaxSegmentSize = uint64(256 * 1024 * 1024)
+
+	// recordLengthSize is the size in bytes of the big endian length prefix of each record.
+	recordLengthSize = 8
+)
+
+// Manifest describes the segments written by a FileSink.
+type Manifest struct {
+	Format   string
+	Segments []*Segment
+	// Gaps are the heights skipped by the FileSink, in increasing height order.
+	Gaps []*Gap `json:",omitempty"`
+}
+
+// Gap is a height skipped by a FileSink, since its execution data was not available.
+type Gap struct {
+	Height          uint64
+	ExecutionDataID flow.Identifier
+}
+
+// Segment describes a segment file written by a FileSink. Only the last segment of a manifest may be
+// incomplete, which means that records are still appended to it.
+type Segment struct {
+	Name        string
+	FirstHeight uint64
+	LastHeight  uint64
+	Records     uint64
+	Size        uint64
+	// Checksum is the hex encoded SHA-256 hash of the segment file, which is set once the segment is complete.
+	Checksum string `json:",omitempty"`
+}
+
+// Complete returns true if no more records are appended to the segment.
+func (s *Segment) Complete() bool {
+	return s.Checksum != ""
+}
+
+// ReadManifest reads the manifest of the FileSink in the given directory.
+// Returns os.ErrNotExist if no manifest was written in the directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// FileSink is a Sink which writes execution data to a directory of segment files, which are rotated once they
+// reach a maximum size. Each segment contains a sequence of records, each of which is the encoded Record
+// prefixed with its big endian uint64 length. The segments are described by a manifest, which is updated
+// after each record is written.
+//
+// After a restart, the FileSink resumes writing after the highest height in the manifest. A record which was
+// only partially written, or not added to the manifest, is discarded.
+//
+// Safe for concurrent use.
+type FileSink struct {
+	log            zerolog.Logger
+	dir            string
+	format         Format
+	codec          encoding.Codec
+	maxSegmentSize uint64
+
+	mu       sync.Mutex
+	manifest *Manifest
+	// file is the incomplete segment which records are appended to, or nil if there is none.
+	file *os.File
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink creates a FileSink writing segments with the given format to the given directory, and resumes
+// writing to the segments already in the directory.
+// No errors are expected during normal operation.
+func NewFileSink(log zerolog.Logger, dir string, format Format, maxSegmentSize uint64) (*FileSink, error) {
+	codec, err := format.codec()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create execution data sink directory: %w", err)
+	}
+
+	s := &FileSink{
+		log:            log.With().Str("component", "execution_data_file_sink").Logger(),
+		dir:            dir,
+		format:         format,
+		codec:          codec,
+		maxSegmentSize: maxSegmentSize,
+	}
+
+	s.manifest, err = ReadManifest(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+		s.manifest = &Manifest{Format: format.String()}
+	}
+
+	if s.manifest.Format != format.String() {
+		return nil, fmt.Errorf("execution data sink directory contains %s segments, but format is %s", s.manifest.Format, format)
+	}
+
+	if err := s.resume(); err != nil {
+		return nil, fmt.Errorf("failed to resume execution data sink: %w", err)
+	}
+
+	return s, nil
+}
+
+// resume opens the incomplete segment, and discards the data written after the last record in the manifest.
+func (s *FileSink) resume() error {
+	segment := s.lastSegment()
+	if segment == nil || segment.Complete() {
+		return nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dir, segment.Name), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open segment %s: %w", segment.Name, err)
+	}
+
+	if err := file.Truncate(int64(segment.Size)); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to truncate segment %s: %w", segment.Name, err)
+	}
+
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to seek segment %s: %w", segment.Name, err)
+	}
+
+	s.file = file
+
+	s.log.Info().
+		Str("segment", segment.Name).
+		Uint64("highest_height", segment.LastHeight).
+		Msg("resuming execution data sink")
+
+	return nil
+}
+
+// lastSegment returns the last segment of the manifest, or nil if there is none.
+func (s *FileSink) lastSegment() *Segment {
+	if len(s.manifest.Segments) == 0 {
+		return nil
+	}
+	return s.manifest.Segments[len(s.manifest.Segments)-1]
+}
+
+// HighestHeight returns the highest height written to or skipped by the sink, and false if no height was
+// written or skipped.
+func (s *FileSink) HighestHeight() (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.highestHeight()
+}
+
+func (s *FileSink) highestHeight() (uint64, bool) {
+	var highestHeight uint64
+	found := false
+	for i := len(s.manifest.Segments) - 1; i >= 0; i-- {
+		if segment := s.manifest.Segments[i]; segment.Records > 0 {
+			highestHeight, found = segment.LastHeight, true
+			break
+		}
+	}
+
+	if len(s.manifest.Gaps) > 0 {
+		if gap := s.manifest.Gaps[len(s.manifest.Gaps)-1]; !found || gap.Height > highestHeight {
+			highestHeight, found = gap.Height, true
+		}
+	}
+
+	return highestHeight, found
+}
+
+// Skip records a gap at the given height in the manifest, so that writing continues at the next height.
+// The heights at or below the highest written or skipped height are ignored.
+// No errors are expected during normal operation.
+func (s *FileSink) Skip(height uint64, executionDataID flow.Identifier) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if highestHeight, ok := s.highestHeight(); ok && height <= highestHeight {
+		return nil
+	}
+
+	s.manifest.Gaps = append(s.manifest.Gaps, &Gap{
+		Height:          height,
+		ExecutionDataID: executionDataID,
+	})
+
+	return s.writeManifest()
+}
+
+// Write appends the execution data of the block at the given height to the incomplete segment, and completes
+// the segment once it reaches the maximum segment size.
+// The execution data of heights at or below the highest written or skipped height is ignored.
+// No errors are expected during normal operation.
+func (s *FileSink) Write(height uint64, executionDataID flow.Identifier, executionData *execution_data.BlockExecutionData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if highestHeight, ok := s.highestHeight(); ok && height <= highestHeight {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	buf.Write(make([]byte, recordLengthSize))
+	err := s.codec.NewEncoder(buf).Encode(&Record{
+		Height:          height,
+		ExecutionDataID: executionDataID,
+		ExecutionData:   executionData,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	data := buf.Bytes()
+	binary.BigEndian.PutUint64(data, uint64(len(data)-recordLengthSize))
+
+	if s.file == nil {
+		if err := s.openSegment(height); err != nil {
+			return err
+		}
+	}
+	segment := s.lastSegment()
+
+	_, err = s.file.Write(data)
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		// discard the partially written record, so that the record can be written again
+		_ = s.file.Truncate(int64(segment.Size))
+		_, _ = s.file.Seek(0, io.SeekEnd)
+		return fmt.Errorf("failed to write record to segment %s: %w", segment.Name, err)
+	}
+
+	if segment.Records == 0 {
+		segment.FirstHeight = height
+	}
+	segment.LastHeight = height
+	segment.Records++
+	segment.Size += uint64(len(data))
+
+	if segment.Size >= s.maxSegmentSize {
+		return s.completeSegment()
+	}
+
+	return s.writeManifest()
+}
+
+// openSegment creates a new segment, starting at the given height.
+// No errors are expected during normal operation.
+func (s *FileSink) openSegment(height uint64) error {
+	segment := &Segment{
+		Name:        fmt.Sprintf("%020d.%s", height, s.format),
+		FirstHeight: height,
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dir, segment.Name), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create segment %s: %w", segment.Name, err)
+	}
+
+	s.file = file
+	s.manifest.Segments = append(s.manifest.Segments, segment)
+
+	return s.writeManifest()
+}
+
+// completeSegment closes the incomplete segment, and records its checksum in the manifest.
+// No errors are expected during normal operation.
+func (s *FileSink) completeSegment() error {
+	segment := s.lastSegment()
+
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek segment %s: %w", segment.Name, err)
+	}
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, s.file); err != nil {
+		return fmt.Errorf("failed to hash segment %s: %w", segment.Name, err)
+	}
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close segment %s: %w", segment.Name, err)
+	}
+	s.file = nil
+
+	segment.Checksum = hex.EncodeToString(hasher.Sum(nil))
+
+	s.log.Info().
+		Str("segment", segment.Name).
+		Uint64("first_height", segment.FirstHeight).
+		Uint64("last_height", segment.LastHeight).
+		Uint64("size", segment.Size).
+		Msg("completed execution data segment")
+
+	return s.writeManifest()
+}
+
+// writeManifest atomically replaces the manifest file.
+// No errors are expected during normal operation.
+func (s *FileSink) writeManifest() error {
+	data, err := json.MarshalIndent(s.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	path := filepath.Join(s.dir, ManifestFileName)
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create manifest: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to sync manifest: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close manifest: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace manifest: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the incomplete segment. Records are appended to it again when the sink is reopened.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// ReadSegment reads the records of the given segment in the given directory, which is encoded with the given
+// format, and calls fn for each record. Only the records included in the segment size are read.
+// Any error returned by fn is returned.
+func ReadSegment(dir string, format Format, segment *Segment, fn func(*Record) error) error {
+	codec, err := format.codec()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filepath.Join(dir, segment.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(io.LimitReader(file, int64(segment.Size)))
+	remaining := segment.Size
+	var length [recordLengthSize]byte
+	for remaining > 0 {
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			return fmt.Errorf("failed to read record length: %w", err)
+		}
+		remaining -= recordLengthSize
+
+		recordLength := binary.BigEndian.Uint64(length[:])
+		if recordLength > remaining {
+			return fmt.Errorf("record length %d exceeds the remaining segment size %d", recordLength, remaining)
+		}
+		remaining -= recordLength
+
+		data := make([]byte, recordLength)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return fmt.Errorf("failed to read record: %w", err)
+		}
+
+		var record Record
+		if err := codec.NewDecoder(bytes.NewReader(data)).Decode(&record); err != nil {
+			return fmt.Errorf("failed to decode record: %w", err)
+		}
+
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
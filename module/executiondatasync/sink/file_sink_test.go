@@ -0,0 +1,317 @@
+package sink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// executionDataFixture returns execution data and its execution data ID.
+func executionDataFixture(t *testing.T) (*execution_data.BlockExecutionData, flow.Identifier) {
+	executionData := unittest.BlockExecutionDataFixture(unittest.WithChunkExecutionDatas(
+		unittest.ChunkExecutionDataFixture(t, 1024),
+	))
+
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	store := execution_data.NewExecutionDataStore(blobstore, execution_data.DefaultSerializer)
+	executionDataID, err := store.Add(context.Background(), executionData)
+	require.NoError(t, err)
+
+	return executionData, executionDataID
+}
+
+// readRecords reads all records written by the FileSink in the given directory.
+func readRecords(t *testing.T, dir string) []*Record {
+	manifest, err := ReadManifest(dir)
+	require.NoError(t, err)
+
+	format, err := ParseFormat(manifest.Format)
+	require.NoError(t, err)
+
+	var records []*Record
+	for _, segment := range manifest.Segments {
+		err := ReadSegment(dir, format, segment, func(record *Record) error {
+			records = append(records, record)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	return records
+}
+
+// TestFileSink tests that the FileSink writes records in both formats, and that the records can be read and
+// verified.
+func TestFileSink(t *testing.T) {
+	for _, format := range []Format{FormatCBOR, FormatJSON} {
+		t.Run(format.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := NewFileSink(zerolog.Nop(), dir, format, DefaultMaxSegmentSize)
+			require.NoError(t, err)
+
+			_, ok := s.HighestHeight()
+			assert.False(t, ok)
+
+			expected := make([]*Record, 0, 3)
+			for height := uint64(10); height < 13; height++ {
+				executionData, executionDataID := executionDataFixture(t)
+				require.NoError(t, s.Write(height, executionDataID, executionData))
+				expected = append(expected, &Record{
+					Height:          height,
+					ExecutionDataID: executionDataID,
+					ExecutionData:   executionData,
+				})
+			}
+			require.NoError(t, s.Close())
+
+			highestHeight, ok := s.HighestHeight()
+			require.True(t, ok)
+			assert.Equal(t, uint64(12), highestHeight)
+
+			records := readRecords(t, dir)
+			require.Len(t, records, len(expected))
+			for i, record := range records {
+				assert.Equal(t, expected[i].Height, record.Height)
+				assert.Equal(t, expected[i].ExecutionDataID, record.ExecutionDataID)
+				assert.Equal(t, expected[i].ExecutionData.BlockID, record.ExecutionData.BlockID)
+			}
+
+			report, err := Verify(dir, nil)
+			require.NoError(t, err)
+			assert.Empty(t, report.Errors)
+			assert.Equal(t, 1, report.Segments)
+			assert.Equal(t, uint64(3), report.Records)
+			assert.Equal(t, uint64(10), report.FirstHeight)
+			assert.Equal(t, uint64(12), report.LastHeight)
+		})
+	}
+}
+
+// TestFileSinkIgnoresWrittenHeights tests that the execution data of heights at or below the highest written
+// height is not written.
+func TestFileSinkIgnoresWrittenHeights(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSink(zerolog.Nop(), dir, FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+	defer s.Close()
+
+	executionData, executionDataID := executionDataFixture(t)
+	require.NoError(t, s.Write(5, executionDataID, executionData))
+
+	for _, height := range []uint64{3, 5} {
+		executionData, executionDataID := executionDataFixture(t)
+		require.NoError(t, s.Write(height, executionDataID, executionData))
+	}
+
+	records := readRecords(t, dir)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(5), records[0].Height)
+	assert.Equal(t, executionDataID, records[0].ExecutionDataID)
+}
+
+// TestFileSinkSkip tests that skipped heights are recorded as gaps in the manifest, and count towards the
+// highest height after the sink is reopened.
+func TestFileSinkSkip(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSink(zerolog.Nop(), dir, FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+
+	executionData, executionDataID := executionDataFixture(t)
+	require.NoError(t, s.Write(1, executionDataID, executionData))
+
+	skippedID := unittest.IdentifierFixture()
+	require.NoError(t, s.Skip(2, skippedID))
+	// heights at or below the highest height are ignored
+	require.NoError(t, s.Skip(2, unittest.IdentifierFixture()))
+	require.NoError(t, s.Skip(1, unittest.IdentifierFixture()))
+	executionData, executionDataID = executionDataFixture(t)
+	require.NoError(t, s.Write(2, executionDataID, executionData))
+
+	highestHeight, ok := s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), highestHeight)
+	require.NoError(t, s.Close())
+
+	s, err = NewFileSink(zerolog.Nop(), dir, FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+	highestHeight, ok = s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), highestHeight)
+
+	executionData, executionDataID = executionDataFixture(t)
+	require.NoError(t, s.Write(3, executionDataID, executionData))
+	require.NoError(t, s.Close())
+
+	manifest, err := ReadManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Gaps, 1)
+	assert.Equal(t, uint64(2), manifest.Gaps[0].Height)
+	assert.Equal(t, skippedID, manifest.Gaps[0].ExecutionDataID)
+
+	records := readRecords(t, dir)
+	require.Len(t, records, 2)
+	assert.Equal(t, uint64(1), records[0].Height)
+	assert.Equal(t, uint64(3), records[1].Height)
+
+	report, err := Verify(dir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 1, report.Gaps)
+}
+
+// TestFileSinkRotation tests that segments are completed once they reach the maximum segment size.
+func TestFileSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	// every record exceeds the maximum size, so each segment contains a single record
+	s, err := NewFileSink(zerolog.Nop(), dir, FormatCBOR, 1)
+	require.NoError(t, err)
+
+	for height := uint64(1); height <= 3; height++ {
+		executionData, executionDataID := executionDataFixture(t)
+		require.NoError(t, s.Write(height, executionDataID, executionData))
+	}
+	require.NoError(t, s.Close())
+
+	manifest, err := ReadManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Segments, 3)
+	for i, segment := range manifest.Segments {
+		height := uint64(i + 1)
+		assert.True(t, segment.Complete())
+		assert.Equal(t, height, segment.FirstHeight)
+		assert.Equal(t, height, segment.LastHeight)
+		assert.Equal(t, uint64(1), segment.Records)
+	}
+
+	report, err := Verify(dir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, uint64(3), report.Records)
+}
+
+// TestFileSinkResume tests that a reopened FileSink discards data written after the last record in the
+// manifest, and continues writing after the highest written height.
+func TestFileSinkResume(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSink(zerolog.Nop(), dir, FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+
+	for height := uint64(1); height <= 2; height++ {
+		executionData, executionDataID := executionDataFixture(t)
+		require.NoError(t, s.Write(height, executionDataID, executionData))
+	}
+	require.NoError(t, s.Close())
+
+	manifest, err := ReadManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Segments, 1)
+	segment := manifest.Segments[0]
+
+	// simulate a record which was partially written before the node stopped
+	file, err := os.OpenFile(filepath.Join(dir, segment.Name), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0, 0, 0, 0, 0, 0, 1, 0, 1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	t.Run("format mismatch", func(t *testing.T) {
+		_, err := NewFileSink(zerolog.Nop(), dir, FormatJSON, DefaultMaxSegmentSize)
+		require.Error(t, err)
+	})
+
+	s, err = NewFileSink(zerolog.Nop(), dir, FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(dir, segment.Name))
+	require.NoError(t, err)
+	assert.Equal(t, segment.Size, uint64(info.Size()))
+
+	highestHeight, ok := s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), highestHeight)
+
+	executionData, executionDataID := executionDataFixture(t)
+	require.NoError(t, s.Write(3, executionDataID, executionData))
+	require.NoError(t, s.Close())
+
+	records := readRecords(t, dir)
+	require.Len(t, records, 3)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.Height)
+	}
+
+	report, err := Verify(dir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+}
+
+// TestVerify tests that the verification detects records whose execution data does not match their ID,
+// execution data IDs which do not match the looked up ID, and modified segment files.
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSink(zerolog.Nop(), dir, FormatJSON, 1)
+	require.NoError(t, err)
+
+	// the execution data IDs of the blocks, as sealed by the protocol state
+	sealedIDs := make(map[flow.Identifier]flow.Identifier)
+	for height := uint64(1); height <= 2; height++ {
+		executionData, executionDataID := executionDataFixture(t)
+		require.NoError(t, s.Write(height, executionDataID, executionData))
+		sealedIDs[executionData.BlockID] = executionDataID
+		if height == 1 {
+			sealedIDs[executionData.BlockID] = unittest.IdentifierFixture()
+		}
+	}
+
+	// the record ID does not match the execution data
+	executionData, _ := executionDataFixture(t)
+	require.NoError(t, s.Write(3, unittest.IdentifierFixture(), executionData))
+	require.NoError(t, s.Close())
+
+	t.Run("tampered record", func(t *testing.T) {
+		report, err := Verify(dir, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Errors, 1)
+		assert.Contains(t, report.Errors[0], "record at height 3")
+	})
+
+	t.Run("lookup mismatch", func(t *testing.T) {
+		lookupID := func(blockID flow.Identifier) (flow.Identifier, error) {
+			return sealedIDs[blockID], nil
+		}
+
+		report, err := Verify(dir, lookupID)
+		require.NoError(t, err)
+		require.Len(t, report.Errors, 2)
+		assert.Contains(t, report.Errors[0], "record at height 1")
+		assert.Contains(t, report.Errors[1], "record at height 3")
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		manifest, err := ReadManifest(dir)
+		require.NoError(t, err)
+		path := filepath.Join(dir, manifest.Segments[0].Name)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[len(data)-2] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		report, err := Verify(dir, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Errors, 2)
+		assert.Contains(t, report.Errors[0], "checksum")
+		assert.Contains(t, report.Errors[1], "record at height 3")
+	})
+}
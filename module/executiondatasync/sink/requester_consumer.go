@@ -0,0 +1,193 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data/cache"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage"
+)
+
+// RequesterConsumer writes the execution data received by the execution data requester to a sink.
+//
+// The requester notifies about execution data in increasing height order. The received heights are recorded,
+// and the execution data is written by a background worker, which reads it from the local execution data
+// store, so that writes to the sink do not block the requester. The worker writes all heights following the
+// highest height written to the sink, so that heights which were skipped, for example because the node was
+// stopped after the requester processed a height, but before the sink wrote it, are written as well.
+//
+// If the execution data of a height was pruned from the local store before it was written, the height is
+// skipped and recorded as a gap in the sink.
+type RequesterConsumer struct {
+	component.Component
+
+	log     zerolog.Logger
+	metrics module.ExecutionDataSinkMetrics
+	sink    Sink
+	headers storage.Headers
+	// executionDataCache reads execution data from the local execution data store.
+	executionDataCache *cache.ExecutionDataCache
+	notifier           engine.Notifier
+
+	mu sync.Mutex
+	// received is true once execution data was received.
+	received bool
+	// firstHeight is the height of the first execution data received. If nothing was written to the sink,
+	// writing starts at this height.
+	firstHeight uint64
+	// highestHeight is the height of the latest execution data received.
+	highestHeight uint64
+}
+
+// NewRequesterConsumer creates a new RequesterConsumer writing to the given sink.
+func NewRequesterConsumer(
+	log zerolog.Logger,
+	metrics module.ExecutionDataSinkMetrics,
+	sink Sink,
+	headers storage.Headers,
+	executionDataCache *cache.ExecutionDataCache,
+) *RequesterConsumer {
+	c := &RequesterConsumer{
+		log:                log.With().Str("component", "execution_data_sink_consumer").Logger(),
+		metrics:            metrics,
+		sink:               sink,
+		headers:            headers,
+		executionDataCache: executionDataCache,
+		notifier:           engine.NewNotifier(),
+	}
+
+	c.Component = component.NewComponentManagerBuilder().
+		AddWorker(c.loop).
+		Build()
+
+	return c
+}
+
+// OnExecutionDataReceived records the height of the received execution data, and notifies the worker which
+// writes it to the sink.
+//
+// Non-blocking.
+func (c *RequesterConsumer) OnExecutionDataReceived(executionData *execution_data.BlockExecutionDataEntity) {
+	header, err := c.headers.ByBlockID(executionData.BlockID)
+	if err != nil {
+		c.log.Error().Err(err).Hex("block_id", executionData.BlockID[:]).Msg("failed to get header of execution data")
+		return
+	}
+
+	c.mu.Lock()
+	if !c.received {
+		c.received = true
+		c.firstHeight = header.Height
+	}
+	if header.Height > c.highestHeight {
+		c.highestHeight = header.Height
+	}
+	c.mu.Unlock()
+
+	c.notifier.Notify()
+}
+
+// receivedHeights returns the heights of the first and the latest execution data received, and false if no
+// execution data was received.
+func (c *RequesterConsumer) receivedHeights() (uint64, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.firstHeight, c.highestHeight, c.received
+}
+
+// loop writes the received execution data whenever it is notified.
+func (c *RequesterConsumer) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.notifier.Channel():
+			err := c.writeReceived(ctx)
+			if err != nil && ctx.Err() == nil {
+				// the write is attempted again once the next execution data is received
+				c.log.Error().Err(err).Msg("failed to write execution data to sink")
+			}
+		}
+	}
+}
+
+// writeReceived writes the execution data of the heights following the highest height written to the sink,
+// up to the height of the latest execution data received. Nothing is written if no execution data was received.
+// No errors are expected during normal operation.
+func (c *RequesterConsumer) writeReceived(ctx context.Context) error {
+	firstHeight, highestReceived, ok := c.receivedHeights()
+	if !ok {
+		return nil
+	}
+
+	startHeight := firstHeight
+	if highestHeight, ok := c.sink.HighestHeight(); ok {
+		startHeight = highestHeight + 1
+	}
+
+	for height := startHeight; height <= highestReceived; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err := c.writeHeight(ctx, height); err != nil {
+			return fmt.Errorf("failed to write execution data at height %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+// writeHeight writes the execution data of the block at the given height, or skips the height if its
+// execution data was pruned from the local store.
+// No errors are expected during normal operation.
+func (c *RequesterConsumer) writeHeight(ctx context.Context, height uint64) error {
+	executionData, err := c.executionDataCache.ByHeight(ctx, height)
+	if execution_data.IsBlobNotFoundError(err) {
+		return c.skipMissing(height, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get execution data: %w", err)
+	}
+
+	return c.sink.Write(height, executionData.ID(), executionData.BlockExecutionData)
+}
+
+// skipMissing skips the given height, whose execution data was received by the requester, but is missing from
+// the local store, since it was pruned.
+// No errors are expected during normal operation.
+func (c *RequesterConsumer) skipMissing(height uint64, missingErr error) error {
+	blockID, err := c.headers.BlockIDByHeight(height)
+	if err != nil {
+		return fmt.Errorf("failed to get block ID: %w", err)
+	}
+
+	executionDataID, err := c.executionDataCache.LookupID(blockID)
+	if err != nil {
+		return fmt.Errorf("failed to get execution data ID of block %s: %w", blockID, err)
+	}
+
+	if err := c.sink.Skip(height, executionDataID); err != nil {
+		return fmt.Errorf("failed to skip height: %w", err)
+	}
+	c.metrics.ExecutionDataSkipped(height)
+
+	c.log.Warn().Err(missingErr).
+		Uint64("height", height).
+		Hex("block_id", blockID[:]).
+		Hex("execution_data_id", executionDataID[:]).
+		Msg("execution data is not available, skipping height")
+
+	return nil
+}
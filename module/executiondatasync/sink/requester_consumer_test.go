@@ -0,0 +1,103 @@
+package sink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data/cache"
+	"github.com/onflow/flow-go/module/mempool/herocache"
+	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRequesterConsumer tests that the RequesterConsumer writes the received execution data in the background,
+// starting at the first height received, and skips heights whose execution data was pruned.
+func TestRequesterConsumer(t *testing.T) {
+	ctx := context.Background()
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	store := execution_data.NewExecutionDataStore(blobstore, execution_data.DefaultSerializer)
+
+	s, err := NewFileSink(zerolog.Nop(), t.TempDir(), FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+	defer s.Close()
+
+	headers := storagemock.NewHeaders(t)
+	seals := storagemock.NewSeals(t)
+	results := storagemock.NewExecutionResults(t)
+	sinkMetrics := modulemock.NewExecutionDataSinkMetrics(t)
+
+	executionDatas := make(map[uint64]*execution_data.BlockExecutionDataEntity)
+	for height := uint64(4); height <= 6; height++ {
+		executionData, executionDataID := executionDataFixture(t)
+		executionDatas[height] = execution_data.NewBlockExecutionDataEntity(executionDataID, executionData)
+
+		result := unittest.ExecutionResultFixture(func(result *flow.ExecutionResult) {
+			result.ExecutionDataID = executionDataID
+		})
+		seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+		header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+		headers.On("ByBlockID", executionData.BlockID).Return(header, nil).Maybe()
+		headers.On("BlockIDByHeight", height).Return(executionData.BlockID, nil).Maybe()
+		seals.On("FinalizedSealForBlock", executionData.BlockID).Return(seal, nil).Maybe()
+		results.On("ByID", seal.ResultID).Return(result, nil).Maybe()
+
+		// the execution data of height 5 was pruned
+		if height != 5 {
+			_, err := store.Add(ctx, executionData)
+			require.NoError(t, err)
+		}
+	}
+
+	executionDataCache := cache.NewExecutionDataCache(
+		store,
+		headers,
+		seals,
+		results,
+		herocache.NewBlockExecutionData(10, zerolog.Nop(), metrics.NewNoopCollector()),
+	)
+	c := NewRequesterConsumer(zerolog.Nop(), sinkMetrics, s, headers, executionDataCache)
+
+	// nothing is written before execution data is received
+	require.NoError(t, c.writeReceived(ctx))
+	_, ok := s.HighestHeight()
+	assert.False(t, ok)
+
+	// the received execution data is only written by the worker
+	for height := uint64(4); height <= 6; height++ {
+		c.OnExecutionDataReceived(executionDatas[height])
+	}
+	_, ok = s.HighestHeight()
+	assert.False(t, ok)
+
+	sinkMetrics.On("ExecutionDataSkipped", uint64(5)).Once()
+	require.NoError(t, c.writeReceived(ctx))
+
+	highestHeight, ok := s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(6), highestHeight)
+
+	manifest, err := ReadManifest(s.dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Gaps, 1)
+	assert.Equal(t, uint64(5), manifest.Gaps[0].Height)
+	assert.Equal(t, executionDatas[5].ID(), manifest.Gaps[0].ExecutionDataID)
+
+	require.NoError(t, s.Close())
+	records := readRecords(t, s.dir)
+	require.Len(t, records, 2)
+	for i, height := range []uint64{4, 6} {
+		assert.Equal(t, height, records[i].Height)
+		assert.Equal(t, executionDatas[height].ID(), records[i].ExecutionDataID)
+	}
+}
@@ -0,0 +1,208 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// errNotExecuted is returned when the sealed execution data of a block is not in the local store, since this
+// node did not execute the block yet.
+var errNotExecuted = errors.New("block is not executed yet")
+
+// SealedWriter writes the execution data of sealed blocks to a sink, in increasing height order.
+//
+// Only the execution data ID of the sealed execution result of each block is written, so blocks of abandoned
+// forks are never written, and the records match the execution data which can be verified against the
+// protocol state. The execution data is read from the local execution data store by a background worker,
+// which is notified when blocks are finalized, since seals are only included in finalized blocks.
+//
+// If the sealed execution data of an executed block is not in the local store, because this node computed a
+// different result, or the execution data was pruned, the height is skipped and recorded as a gap in the sink.
+type SealedWriter struct {
+	component.Component
+
+	log           zerolog.Logger
+	metrics       module.ExecutionDataSinkMetrics
+	sink          Sink
+	state         protocol.State
+	headers       storage.Headers
+	seals         storage.Seals
+	results       storage.ExecutionResults
+	executionData execution_data.ExecutionDataGetter
+	notifier      engine.Notifier
+
+	// startHeight is the latest sealed height when the writer was created. If nothing was written to the sink,
+	// writing starts at the height following startHeight.
+	startHeight uint64
+}
+
+// NewSealedWriter creates a new SealedWriter writing the execution data of sealed blocks from the given
+// execution data store to the given sink.
+// No errors are expected during normal operation.
+func NewSealedWriter(
+	log zerolog.Logger,
+	metrics module.ExecutionDataSinkMetrics,
+	sink Sink,
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	results storage.ExecutionResults,
+	executionData execution_data.ExecutionDataGetter,
+) (*SealedWriter, error) {
+	sealed, err := state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest sealed block: %w", err)
+	}
+
+	w := &SealedWriter{
+		log:           log.With().Str("component", "execution_data_sealed_writer").Logger(),
+		metrics:       metrics,
+		sink:          sink,
+		state:         state,
+		headers:       headers,
+		seals:         seals,
+		results:       results,
+		executionData: executionData,
+		notifier:      engine.NewNotifier(),
+		startHeight:   sealed.Height,
+	}
+
+	w.Component = component.NewComponentManagerBuilder().
+		AddWorker(w.loop).
+		Build()
+
+	return w, nil
+}
+
+// OnBlockFinalized notifies the writer that a block was finalized, which may seal new blocks.
+//
+// Non-blocking.
+func (w *SealedWriter) OnBlockFinalized(*model.Block) {
+	w.notifier.Notify()
+}
+
+// loop writes the execution data of newly sealed blocks whenever it is notified.
+func (w *SealedWriter) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	// write the blocks which were sealed while the node was stopped
+	w.notifier.Notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.notifier.Channel():
+			err := w.writeSealed(ctx)
+			if err != nil && ctx.Err() == nil {
+				// the write is attempted again once the next block is finalized
+				w.log.Error().Err(err).Msg("failed to write sealed execution data to sink")
+			}
+		}
+	}
+}
+
+// writeSealed writes the execution data of the blocks sealed after the highest height written to the sink.
+// No errors are expected during normal operation.
+func (w *SealedWriter) writeSealed(ctx context.Context) error {
+	sealed, err := w.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("failed to get latest sealed block: %w", err)
+	}
+
+	highestHeight, ok := w.sink.HighestHeight()
+	if !ok {
+		highestHeight = w.startHeight
+	}
+
+	for height := highestHeight + 1; height <= sealed.Height; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err := w.writeHeight(ctx, height)
+		if errors.Is(err, errNotExecuted) {
+			// the height is written once the block is executed, and the next block is finalized
+			w.log.Debug().Err(err).Uint64("height", height).Msg("waiting for sealed block to be executed")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write execution data at height %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+// writeHeight writes the execution data of the sealed execution result of the block at the given height, or
+// skips the height if its execution data is not available.
+// Expected errors during normal operation:
+//   - errNotExecuted if the execution data is not in the local store, since the block is not executed yet
+func (w *SealedWriter) writeHeight(ctx context.Context, height uint64) error {
+	blockID, err := w.headers.BlockIDByHeight(height)
+	if err != nil {
+		return fmt.Errorf("failed to get block ID: %w", err)
+	}
+
+	seal, err := w.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return fmt.Errorf("failed to get seal for block %s: %w", blockID, err)
+	}
+
+	result, err := w.results.ByID(seal.ResultID)
+	if err != nil {
+		return fmt.Errorf("failed to get sealed execution result %s: %w", seal.ResultID, err)
+	}
+
+	executionData, err := w.executionData.Get(ctx, result.ExecutionDataID)
+	if execution_data.IsBlobNotFoundError(err) {
+		return w.skipMissing(height, blockID, result.ExecutionDataID, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get execution data %s: %w", result.ExecutionDataID, err)
+	}
+
+	return w.sink.Write(height, result.ExecutionDataID, executionData)
+}
+
+// skipMissing skips the given height, whose sealed execution data is missing from the local store, if this node
+// executed the block. In that case, the execution data is never going to be added to the store, since this node
+// computed a different result, or the execution data was pruned.
+// Expected errors during normal operation:
+//   - errNotExecuted if this node did not execute the block yet
+func (w *SealedWriter) skipMissing(height uint64, blockID flow.Identifier, executionDataID flow.Identifier, missingErr error) error {
+	// the execution data is added to the local store before the result of the block is indexed
+	_, err := w.results.ByBlockID(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("%w: %v", errNotExecuted, missingErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get own execution result for block %s: %w", blockID, err)
+	}
+
+	if err := w.sink.Skip(height, executionDataID); err != nil {
+		return fmt.Errorf("failed to skip height: %w", err)
+	}
+	w.metrics.ExecutionDataSkipped(height)
+
+	w.log.Warn().Err(missingErr).
+		Uint64("height", height).
+		Hex("block_id", blockID[:]).
+		Hex("execution_data_id", executionDataID[:]).
+		Msg("sealed execution data is not available, skipping height")
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package sink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSealedWriter tests that the SealedWriter writes the execution data of the sealed results of blocks sealed
+// after it was created, waits for blocks which are not executed yet, and skips blocks whose sealed execution
+// data is not available.
+func TestSealedWriter(t *testing.T) {
+	ctx := context.Background()
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	store := execution_data.NewExecutionDataStore(blobstore, execution_data.DefaultSerializer)
+
+	s, err := NewFileSink(zerolog.Nop(), t.TempDir(), FormatCBOR, DefaultMaxSegmentSize)
+	require.NoError(t, err)
+	defer s.Close()
+
+	sealedHeight := uint64(2)
+	snapshot := protocolmock.NewSnapshot(t)
+	snapshot.On("Head").Return(func() (*flow.Header, error) {
+		return unittest.BlockHeaderFixture(unittest.WithHeaderHeight(sealedHeight)), nil
+	})
+	state := protocolmock.NewState(t)
+	state.On("Sealed").Return(snapshot)
+
+	headers := storagemock.NewHeaders(t)
+	seals := storagemock.NewSeals(t)
+	results := storagemock.NewExecutionResults(t)
+
+	sinkMetrics := modulemock.NewExecutionDataSinkMetrics(t)
+
+	executionDatas := make(map[uint64]*execution_data.BlockExecutionData)
+	executionDataIDs := make(map[uint64]flow.Identifier)
+	for height := uint64(3); height <= 7; height++ {
+		executionData, executionDataID := executionDataFixture(t)
+		executionDatas[height] = executionData
+		executionDataIDs[height] = executionDataID
+
+		result := unittest.ExecutionResultFixture(func(result *flow.ExecutionResult) {
+			result.ExecutionDataID = executionDataID
+		})
+		seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+		headers.On("BlockIDByHeight", height).Return(executionData.BlockID, nil).Maybe()
+		seals.On("FinalizedSealForBlock", executionData.BlockID).Return(seal, nil).Maybe()
+		results.On("ByID", seal.ResultID).Return(result, nil).Maybe()
+	}
+
+	w, err := NewSealedWriter(zerolog.Nop(), sinkMetrics, s, state, headers, seals, results, store)
+	require.NoError(t, err)
+
+	// the blocks sealed before the writer was created are not written
+	require.NoError(t, w.writeSealed(ctx))
+	_, ok := s.HighestHeight()
+	assert.False(t, ok)
+
+	// the block at height 5 is not executed yet, so its execution data is not in the local store
+	for height := uint64(3); height <= 4; height++ {
+		_, err := store.Add(ctx, executionDatas[height])
+		require.NoError(t, err)
+	}
+	results.On("ByBlockID", executionDatas[5].BlockID).Return(nil, storage.ErrNotFound).Once()
+	sealedHeight = 5
+	require.NoError(t, w.writeSealed(ctx))
+
+	highestHeight, ok := s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(4), highestHeight)
+
+	_, err = store.Add(ctx, executionDatas[5])
+	require.NoError(t, err)
+	require.NoError(t, w.writeSealed(ctx))
+
+	highestHeight, ok = s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), highestHeight)
+
+	// the block at height 6 was executed with a different result, so its sealed execution data is never
+	// going to be in the local store, and the height is skipped
+	results.On("ByBlockID", executionDatas[6].BlockID).Return(unittest.ExecutionResultFixture(), nil).Once()
+	sinkMetrics.On("ExecutionDataSkipped", uint64(6)).Once()
+	_, err = store.Add(ctx, executionDatas[7])
+	require.NoError(t, err)
+	sealedHeight = 7
+	require.NoError(t, w.writeSealed(ctx))
+
+	highestHeight, ok = s.HighestHeight()
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), highestHeight)
+
+	manifest, err := ReadManifest(s.dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Gaps, 1)
+	assert.Equal(t, uint64(6), manifest.Gaps[0].Height)
+	assert.Equal(t, executionDataIDs[6], manifest.Gaps[0].ExecutionDataID)
+
+	require.NoError(t, s.Close())
+	records := readRecords(t, s.dir)
+	require.Len(t, records, 4)
+	for i, record := range records {
+		height := uint64(i + 3)
+		if height >= 6 {
+			height++
+		}
+		assert.Equal(t, height, record.Height)
+		assert.Equal(t, executionDataIDs[height], record.ExecutionDataID)
+		assert.Equal(t, executionDatas[height].BlockID, record.ExecutionData.BlockID)
+	}
+}
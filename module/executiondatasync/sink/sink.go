@@ -0,0 +1,100 @@
+package sink
+
+import (
+	"fmt"
+	"math"
+
+	cborlib "github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/encoding/json"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// Sink receives the execution data of blocks, in increasing height order, for example to store it in a format
+// which can be consumed by external pipelines.
+type Sink interface {
+	// Write writes the execution data of the block at the given height, which has the given execution data ID.
+	// The execution data of heights at or below the highest written height is ignored, so that writes can be
+	// replayed after a restart.
+	// No errors are expected during normal operation.
+	Write(height uint64, executionDataID flow.Identifier, executionData *execution_data.BlockExecutionData) error
+
+	// Skip records a gap at the given height, whose execution data with the given execution data ID is not
+	// available, so that writing continues at the next height. Heights at or below the highest height are ignored.
+	// No errors are expected during normal operation.
+	Skip(height uint64, executionDataID flow.Identifier) error
+
+	// HighestHeight returns the highest height written to or skipped by the sink, and false if no height was
+	// written or skipped.
+	HighestHeight() (uint64, bool)
+}
+
+// Record is the execution data of a block written to a sink.
+type Record struct {
+	Height          uint64
+	ExecutionDataID flow.Identifier
+	ExecutionData   *execution_data.BlockExecutionData
+}
+
+// Format is the encoding of the records written by a FileSink.
+type Format int
+
+const (
+	// FormatCBOR encodes records with CBOR.
+	FormatCBOR Format = iota + 1
+	// FormatJSON encodes records with JSON.
+	FormatJSON
+)
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(s string) (Format, error) {
+	switch s {
+	case FormatCBOR.String():
+		return FormatCBOR, nil
+	case FormatJSON.String():
+		return FormatJSON, nil
+	default:
+		return 0, fmt.Errorf("invalid execution data sink format: %s", s)
+	}
+}
+
+func (f Format) String() string {
+	switch f {
+	case FormatCBOR:
+		return "cbor"
+	case FormatJSON:
+		return "json"
+	default:
+		return ""
+	}
+}
+
+// codec returns the codec used to encode the records of the format.
+func (f Format) codec() (encoding.Codec, error) {
+	switch f {
+	case FormatCBOR:
+		return cborCodec, nil
+	case FormatJSON:
+		return &json.Codec{}, nil
+	default:
+		return nil, fmt.Errorf("invalid execution data sink format: %d", f)
+	}
+}
+
+// cborCodec decodes records without the default limits on the size of arrays and maps, which may be exceeded
+// by the execution data of large blocks.
+var cborCodec = func() encoding.Codec {
+	decMode, err := cborlib.DecOptions{
+		MaxArrayElements: math.MaxInt64,
+		MaxMapPairs:      math.MaxInt64,
+		MaxNestedLevels:  math.MaxInt16,
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+
+	return cbor.NewCodec(cbor.WithDecMode(decMode))
+}()
@@ -0,0 +1,175 @@
+package sink
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// VerificationReport is the outcome of the verification of the segments written by a FileSink.
+type VerificationReport struct {
+	Segments    int
+	Records     uint64
+	FirstHeight uint64
+	LastHeight  uint64
+	// Gaps is the number of heights skipped by the FileSink, since their execution data was not available.
+	Gaps int
+	// Errors describes the inconsistencies found in the segments.
+	Errors []string
+}
+
+// LookupExecutionDataID returns the execution data ID of the block with the given ID, for example from the
+// execution results sealed by the protocol state.
+type LookupExecutionDataID func(blockID flow.Identifier) (flow.Identifier, error)
+
+// Verify verifies the segments written by the FileSink in the given directory against its manifest, and
+// re-hashes the execution data of each record to confirm that it matches the execution data ID of the record.
+// If lookupID is not nil, the execution data ID of each record is also compared with the ID returned by lookupID.
+//
+// The returned report lists the inconsistencies found. No errors are expected during normal operation.
+func Verify(dir string, lookupID LookupExecutionDataID) (*VerificationReport, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	format, err := ParseFormat(manifest.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VerificationReport{
+		Segments: len(manifest.Segments),
+		Gaps:     len(manifest.Gaps),
+	}
+	addError := func(msg string, args ...interface{}) {
+		report.Errors = append(report.Errors, fmt.Sprintf(msg, args...))
+	}
+
+	var lastHeight uint64
+	for i, segment := range manifest.Segments {
+		if !segment.Complete() && i != len(manifest.Segments)-1 {
+			addError("segment %s is incomplete, but is not the last segment", segment.Name)
+		}
+
+		if err := verifySegmentFile(dir, segment); err != nil {
+			addError("segment %s: %v", segment.Name, err)
+			continue
+		}
+
+		var records uint64
+		err := ReadSegment(dir, format, segment, func(record *Record) error {
+			if record.Height <= lastHeight && report.Records > 0 {
+				addError("segment %s: record at height %d follows height %d", segment.Name, record.Height, lastHeight)
+			}
+			if record.Height < segment.FirstHeight || record.Height > segment.LastHeight {
+				addError("segment %s: record at height %d is outside of the segment height range [%d, %d]",
+					segment.Name, record.Height, segment.FirstHeight, segment.LastHeight)
+			}
+
+			if err := verifyRecord(record, lookupID); err != nil {
+				addError("segment %s: record at height %d: %v", segment.Name, record.Height, err)
+			}
+
+			if report.Records == 0 {
+				report.FirstHeight = record.Height
+			}
+			report.LastHeight = record.Height
+			report.Records++
+			lastHeight = record.Height
+			records++
+
+			return nil
+		})
+		if err != nil {
+			addError("segment %s: %v", segment.Name, err)
+			continue
+		}
+
+		if records != segment.Records {
+			addError("segment %s contains %d records, but the manifest lists %d", segment.Name, records, segment.Records)
+		}
+	}
+
+	return report, nil
+}
+
+// verifySegmentFile checks the size of the segment file, and the checksum of complete segments.
+// Returns an error describing the inconsistency if the file does not match the manifest.
+func verifySegmentFile(dir string, segment *Segment) error {
+	file, err := os.Open(filepath.Join(dir, segment.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// records may have been appended to an incomplete segment after the manifest was written
+	if uint64(info.Size()) < segment.Size || (segment.Complete() && uint64(info.Size()) != segment.Size) {
+		return fmt.Errorf("file size %d does not match the manifest size %d", info.Size(), segment.Size)
+	}
+
+	if !segment.Complete() {
+		return nil
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return fmt.Errorf("failed to hash file: %w", err)
+	}
+	if checksum := hex.EncodeToString(hasher.Sum(nil)); checksum != segment.Checksum {
+		return fmt.Errorf("checksum %s does not match the manifest checksum %s", checksum, segment.Checksum)
+	}
+
+	return nil
+}
+
+// verifyRecord re-hashes the execution data of the record, and compares it with the execution data ID of the
+// record. Returns an error describing the mismatch if the IDs do not match.
+func verifyRecord(record *Record, lookupID LookupExecutionDataID) error {
+	if record.ExecutionData == nil {
+		return fmt.Errorf("execution data is missing")
+	}
+
+	// the execution data ID is the root CID of the blob tree of the execution data
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	store := execution_data.NewExecutionDataStore(blobstore, execution_data.DefaultSerializer)
+	executionDataID, err := store.Add(context.Background(), record.ExecutionData)
+	if err != nil {
+		return fmt.Errorf("failed to hash execution data: %w", err)
+	}
+
+	if executionDataID != record.ExecutionDataID {
+		return fmt.Errorf("execution data hashes to %s, but the record ID is %s", executionDataID, record.ExecutionDataID)
+	}
+
+	if lookupID == nil {
+		return nil
+	}
+
+	expectedID, err := lookupID(record.ExecutionData.BlockID)
+	if err != nil {
+		return fmt.Errorf("failed to lookup execution data ID of block %s: %w", record.ExecutionData.BlockID, err)
+	}
+	if expectedID != record.ExecutionDataID {
+		return fmt.Errorf("record ID %s does not match the execution data ID %s of block %s",
+			record.ExecutionDataID, expectedID, record.ExecutionData.BlockID)
+	}
+
+	return nil
+}
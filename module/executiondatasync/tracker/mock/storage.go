@@ -3,6 +3,8 @@
 package mocktracker
 
 import (
+	cid "github.com/ipfs/go-cid"
+
 	tracker "github.com/onflow/flow-go/module/executiondatasync/tracker"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -68,6 +70,34 @@ func (_m *Storage) GetPrunedHeight() (uint64, error) {
 	return r0, r1
 }
 
+// IsTracked provides a mock function with given fields: c
+func (_m *Storage) IsTracked(c cid.Cid) (bool, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTracked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(cid.Cid) (bool, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(cid.Cid) bool); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(cid.Cid) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PruneUpToHeight provides a mock function with given fields: height
 func (_m *Storage) PruneUpToHeight(height uint64) error {
 	ret := _m.Called(height)
@@ -27,3 +27,9 @@ func (s *NoopStorage) GetPrunedHeight() (uint64, error) {
 func (s *NoopStorage) PruneUpToHeight(height uint64) error {
 	return nil
 }
+
+// IsTracked always returns true, since blobs which are not tracked may be removed from
+// the blobstore, and the noop storage does not track any blobs.
+func (s *NoopStorage) IsTracked(cid.Cid) (bool, error) {
+	return true, nil
+}
@@ -156,6 +156,11 @@ type Storage interface {
 	// It is up to the caller to ensure that this is never
 	// called with a value higher than the fulfilled height.
 	PruneUpToHeight(height uint64) error
+
+	// IsTracked returns true if the given blob is tracked at any height which
+	// has not been pruned yet.
+	// No errors are expected during normal operation.
+	IsTracked(c cid.Cid) (bool, error)
 }
 
 // The storage component tracks the following information:
@@ -534,6 +539,28 @@ func (s *storage) GetPrunedHeight() (uint64, error) {
 	return prunedHeight, nil
 }
 
+func (s *storage) IsTracked(c cid.Cid) (bool, error) {
+	latestHeightKey := makeLatestHeightKey(c)
+	tracked := false
+
+	if err := s.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(latestHeightKey)
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to get latest height entry for Cid %s: %w", c.String(), err)
+		}
+
+		tracked = true
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return tracked, nil
+}
+
 type deleteInfo struct {
 	cid                      cid.Cid
 	height                   uint64
@@ -183,3 +183,37 @@ func TestAscendingOrderOfRecords(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+// TestIsTracked tests that a CID is tracked until the last height at which it appears is pruned.
+func TestIsTracked(t *testing.T) {
+	storageDir := t.TempDir()
+	storage, err := OpenStorage(storageDir, 0, zerolog.Nop())
+	require.NoError(t, err)
+
+	// c1 appears at heights 1 and 2, and c2 only at height 1
+	c1 := randomCid()
+	c2 := randomCid()
+	c3 := randomCid()
+
+	require.NoError(t, storage.Update(func(tbf TrackBlobsFn) error {
+		require.NoError(t, tbf(1, c1, c2))
+		require.NoError(t, tbf(2, c1))
+
+		return nil
+	}))
+
+	assertTracked := func(c cid.Cid, expected bool) {
+		tracked, err := storage.IsTracked(c)
+		require.NoError(t, err)
+		assert.Equal(t, expected, tracked)
+	}
+
+	assertTracked(c1, true)
+	assertTracked(c2, true)
+	assertTracked(c3, false)
+
+	require.NoError(t, storage.PruneUpToHeight(1))
+
+	assertTracked(c1, true)
+	assertTracked(c2, false)
+}
@@ -93,6 +93,9 @@ func (cs *ChunkRequests) Add(request *verification.ChunkDataPackRequest) bool {
 		status.RequestInfo.Agrees = status.RequestInfo.Agrees.Union(request.Agrees)
 		status.RequestInfo.Disagrees = status.RequestInfo.Disagrees.Union(request.Disagrees)
 		status.RequestInfo.Targets = status.RequestInfo.Targets.Union(request.Targets)
+		if request.RetainUntil.After(status.RequestInfo.RetainUntil) {
+			status.RequestInfo.RetainUntil = request.RetainUntil
+		}
 
 		backdata.Add(request.ChunkID, status)
 		return nil
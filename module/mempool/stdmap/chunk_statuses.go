@@ -2,6 +2,7 @@ package stdmap
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
@@ -28,6 +29,7 @@ func chunkStatus(entity flow.Entity) *verification.ChunkStatus {
 		ChunkIndex:      status.ChunkIndex,
 		ExecutionResult: status.ExecutionResult,
 		BlockHeight:     status.BlockHeight,
+		RequestedAt:     status.RequestedAt,
 	}
 }
 
@@ -52,6 +54,7 @@ func (cs *ChunkStatuses) Add(status *verification.ChunkStatus) bool {
 		ChunkIndex:      status.ChunkIndex,
 		ExecutionResult: status.ExecutionResult,
 		BlockHeight:     status.BlockHeight,
+		RequestedAt:     status.RequestedAt,
 	})
 }
 
@@ -91,6 +94,7 @@ type inMemChunkStatus struct {
 	ChunkIndex      uint64
 	BlockHeight     uint64
 	ExecutionResult *flow.ExecutionResult
+	RequestedAt     time.Time
 }
 
 func (s inMemChunkStatus) ID() flow.Identifier {
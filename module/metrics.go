@@ -214,6 +214,10 @@ type UnicastManagerMetrics interface {
 
 	// OnStreamCreationRetryBudgetResetToDefault tracks the number of times the stream creation retry budget is reset to default.
 	OnStreamCreationRetryBudgetResetToDefault()
+
+	// OnStreamCompressed tracks the number of bytes before and after compression, and the time spent compressing, of a write on
+	// an outbound unicast stream of the given channel, which is compressed by the given unicast protocol.
+	OnStreamCompressed(channel string, protocol string, uncompressedBytes int, compressedBytes int, duration time.Duration)
 }
 
 type GossipSubMetrics interface {
@@ -585,6 +589,27 @@ type HotstuffMetrics interface {
 	TimeoutCollectorsRange(lowestRetainedView uint64, newestViewCreatedCollector uint64, activeCollectors int)
 }
 
+// HotstuffParticipationMetrics reports, per consensus participant, how actively the replica
+// takes part in the HotStuff protocol, as observed by the local node.
+type HotstuffParticipationMetrics interface {
+
+	// ReplicaParticipation reports the fraction of the views observed by this node during the
+	// current epoch, in which the given replica voted or timed out.
+	ReplicaParticipation(nodeID flow.Identifier, ratio float64)
+
+	// RemoveReplicaParticipation removes the participation ratio of the given replica, which is
+	// not a participant of the current epoch.
+	RemoveReplicaParticipation(nodeID flow.Identifier)
+
+	// ReplicaVoteDelay measures the time from the local node incorporating a proposal to
+	// processing the given replica's vote for it.
+	ReplicaVoteDelay(nodeID flow.Identifier, delay time.Duration)
+
+	// ReplicaViolation counts protocol violations (double or invalid votes and timeouts)
+	// committed by the given replica.
+	ReplicaViolation(nodeID flow.Identifier, violation string)
+}
+
 type CruiseCtlMetrics interface {
 
 	// PIDError measures the current error values for the proportional, integration,
@@ -712,6 +737,14 @@ type VerificationMetrics interface {
 	// OnResultApprovalDispatchedInNetwork increments a counter that keeps track of number of result approvals dispatched in the network
 	// by verifier engine.
 	OnResultApprovalDispatchedInNetworkByVerifier()
+
+	// OnChunkVerifiedForExecutor increments a counter that keeps track of number of chunks verified by verifier engine per execution
+	// node that committed to the result, and per chunk fault type (empty if the chunk passed verification).
+	OnChunkVerifiedForExecutor(executorID flow.Identifier, faultType string)
+
+	// OnChunkDataPackFetchedFromExecutor records the time between requesting a chunk data pack and its arrival, per execution node
+	// that provided the chunk data pack.
+	OnChunkDataPackFetchedFromExecutor(executorID flow.Identifier, duration time.Duration)
 }
 
 // LedgerMetrics provides an interface to record Ledger Storage metrics.
@@ -840,6 +873,29 @@ type RuntimeMetrics interface {
 	RuntimeTransactionProgramsCacheHit()
 }
 
+// ProgramCacheMetrics reports on the persistent cache of Cadence programs.
+type ProgramCacheMetrics interface {
+	// ProgramCacheWarmedUp reports a warm-up of the program cache, with the number of persisted programs which were
+	// derived again (hits), the number of persisted programs which were outdated or failed to load (misses),
+	// and the time spent warming up.
+	ProgramCacheWarmedUp(hits int, misses int, duration time.Duration)
+
+	// ProgramCachePersisted reports the number of programs persisted by the program cache
+	ProgramCachePersisted(count int)
+}
+
+// ScriptResultCacheMetrics reports on the cache of script execution results of access nodes.
+type ScriptResultCacheMetrics interface {
+	// ScriptResultCacheHit records a script execution served from the cache
+	ScriptResultCacheHit()
+
+	// ScriptResultCacheMiss records a cacheable script execution which was not cached
+	ScriptResultCacheMiss()
+
+	// ScriptResultCacheSize reports the number of cached results and their total size in bytes
+	ScriptResultCacheSize(entries int, bytes uint64)
+}
+
 type EVMMetrics interface {
 	// SetNumberOfDeployedCOAs sets the total number of deployed COAs
 	SetNumberOfDeployedCOAs(count uint64)
@@ -874,6 +930,12 @@ type ExecutionDataPrunerMetrics interface {
 	Pruned(height uint64, duration time.Duration)
 }
 
+type ExecutionDataSinkMetrics interface {
+	// ExecutionDataSkipped is called when the execution data of a height is not available, and the height is
+	// skipped by the execution data sink.
+	ExecutionDataSkipped(height uint64)
+}
+
 type RestMetrics interface {
 	// Example recorder taken from:
 	// https://github.com/slok/go-http-metrics/blob/master/metrics/prometheus/prometheus.go
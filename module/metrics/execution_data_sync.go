@@ -246,3 +246,30 @@ func (c *ExecutionDataPrunerCollector) Pruned(height uint64, duration time.Durat
 	c.pruneDurations.Observe(float64(duration.Milliseconds()))
 	c.latestHeightPruned.Set(float64(height))
 }
+
+type ExecutionDataSinkCollector struct {
+	heightsSkipped      prometheus.Counter
+	latestHeightSkipped prometheus.Gauge
+}
+
+func NewExecutionDataSinkCollector() *ExecutionDataSinkCollector {
+	return &ExecutionDataSinkCollector{
+		heightsSkipped: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "heights_skipped",
+			Namespace: namespaceExecutionDataSync,
+			Subsystem: subsystemExeDataSink,
+			Help:      "the number of heights skipped since their execution data was not available",
+		}),
+		latestHeightSkipped: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "latest_height_skipped",
+			Namespace: namespaceExecutionDataSync,
+			Subsystem: subsystemExeDataSink,
+			Help:      "the latest height skipped since its execution data was not available",
+		}),
+	}
+}
+
+func (c *ExecutionDataSinkCollector) ExecutionDataSkipped(height uint64) {
+	c.heightsSkipped.Inc()
+	c.latestHeightSkipped.Set(float64(height))
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// HotStuff participation violations
+const (
+	HotstuffViolationDoubleVote     = "double_vote"
+	HotstuffViolationInvalidVote    = "invalid_vote"
+	HotstuffViolationDoubleTimeout  = "double_timeout"
+	HotstuffViolationInvalidTimeout = "invalid_timeout"
+)
+
+// HotstuffParticipationCollector implements the metrics for the participation of the
+// individual consensus replicas in HotStuff, as observed by the local node. All metrics
+// are labelled with the node ID of the replica they refer to.
+type HotstuffParticipationCollector struct {
+	participation *prometheus.GaugeVec
+	voteDelay     *prometheus.HistogramVec
+	violations    *prometheus.CounterVec
+}
+
+var _ module.HotstuffParticipationMetrics = (*HotstuffParticipationCollector)(nil)
+
+func NewHotstuffParticipationCollector(chain flow.ChainID) *HotstuffParticipationCollector {
+	return &HotstuffParticipationCollector{
+		participation: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "replica_participation_ratio",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "fraction of the views observed in the current epoch, in which the replica voted or timed out",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}, []string{LabelNodeID}),
+
+		voteDelay: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "replica_vote_delay_seconds",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "duration [seconds; measured with float64 precision] from incorporating a proposal to processing the replica's vote for it",
+			Buckets:     []float64{0.05, 0.1, 0.2, 0.5, 1, 2, 5},
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}, []string{LabelNodeID}),
+
+		violations: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:        "replica_violations_total",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "number of double or invalid votes and timeouts detected for the replica",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}, []string{LabelNodeID, LabelViolationReason}),
+	}
+}
+
+// ReplicaParticipation reports the fraction of the views observed by this node during the
+// current epoch, in which the given replica voted or timed out.
+func (hc *HotstuffParticipationCollector) ReplicaParticipation(nodeID flow.Identifier, ratio float64) {
+	hc.participation.WithLabelValues(nodeID.String()).Set(ratio)
+}
+
+// RemoveReplicaParticipation removes the participation ratio of the given replica, which is
+// not a participant of the current epoch.
+func (hc *HotstuffParticipationCollector) RemoveReplicaParticipation(nodeID flow.Identifier) {
+	hc.participation.DeleteLabelValues(nodeID.String())
+}
+
+// ReplicaVoteDelay measures the time from the local node incorporating a proposal to
+// processing the given replica's vote for it.
+func (hc *HotstuffParticipationCollector) ReplicaVoteDelay(nodeID flow.Identifier, delay time.Duration) {
+	hc.voteDelay.WithLabelValues(nodeID.String()).Observe(delay.Seconds()) // unit: seconds; with float64 precision
+}
+
+// ReplicaViolation counts protocol violations (double or invalid votes and timeouts)
+// committed by the given replica.
+func (hc *HotstuffParticipationCollector) ReplicaViolation(nodeID flow.Identifier, violation string) {
+	hc.violations.WithLabelValues(nodeID.String(), violation).Inc()
+}
@@ -25,6 +25,7 @@ const (
 	LabelService             = "service"
 	LabelRejectionReason     = "rejection_reason"
 	LabelAccountAddress      = "acct_address" // Account address for a machine account
+	LabelChunkFault          = "chunk_fault"
 )
 
 const (
@@ -47,6 +47,7 @@ const (
 	subsystemTransactionValidation = "transaction_validation"
 	subsystemConnectionPool        = "connection_pool"
 	subsystemHTTP                  = "http"
+	subsystemScriptResultCache     = "script_result_cache"
 )
 
 // Observer subsystem
@@ -93,6 +94,7 @@ const (
 	subsystemExeDataRequester       = "requester"
 	subsystemExeDataProvider        = "provider"
 	subsystemExeDataPruner          = "pruner"
+	subsystemExeDataSink            = "sink"
 	subsystemExecutionDataRequester = "execution_data_requester"
 	subsystemExecutionStateIndexer  = "execution_state_indexer"
 	subsystemExeDataBlobstore       = "blobstore"
@@ -39,6 +39,7 @@ var _ module.BackendScriptsMetrics = (*NoopCollector)(nil)
 var _ module.TransactionMetrics = (*NoopCollector)(nil)
 var _ module.TransactionValidationMetrics = (*NoopCollector)(nil)
 var _ module.HotstuffMetrics = (*NoopCollector)(nil)
+var _ module.HotstuffParticipationMetrics = (*NoopCollector)(nil)
 var _ module.EngineMetrics = (*NoopCollector)(nil)
 var _ module.HeroCacheMetrics = (*NoopCollector)(nil)
 var _ module.NetworkMetrics = (*NoopCollector)(nil)
@@ -122,6 +123,10 @@ func (nc *NoopCollector) SignerProcessingDuration(duration time.Duration)
 func (nc *NoopCollector) ValidatorProcessingDuration(duration time.Duration)             {}
 func (nc *NoopCollector) PayloadProductionDuration(duration time.Duration)               {}
 func (nc *NoopCollector) TimeoutCollectorsRange(uint64, uint64, int)                     {}
+func (nc *NoopCollector) ReplicaParticipation(flow.Identifier, float64)                  {}
+func (nc *NoopCollector) RemoveReplicaParticipation(flow.Identifier)                     {}
+func (nc *NoopCollector) ReplicaVoteDelay(flow.Identifier, time.Duration)                {}
+func (nc *NoopCollector) ReplicaViolation(flow.Identifier, string)                       {}
 func (nc *NoopCollector) TransactionIngested(txID flow.Identifier)                       {}
 func (nc *NoopCollector) ClusterBlockProposed(*cluster.Block)                            {}
 func (nc *NoopCollector) ClusterBlockFinalized(*cluster.Block)                           {}
@@ -238,6 +243,7 @@ func (nc *NoopCollector) RequestFailed(duration time.Duration, retryable bool)
 func (nc *NoopCollector) RequestCanceled()                                                      {}
 func (nc *NoopCollector) ResponseDropped()                                                      {}
 func (nc *NoopCollector) Pruned(height uint64, duration time.Duration)                          {}
+func (nc *NoopCollector) ExecutionDataSkipped(height uint64)                                    {}
 func (nc *NoopCollector) UpdateCollectionMaxHeight(height uint64)                               {}
 func (nc *NoopCollector) BucketAvailableSlots(uint64, uint64)                                   {}
 func (nc *NoopCollector) OnKeyPutSuccess(uint32)                                                {}
@@ -279,6 +285,10 @@ func (nc *NoopCollector) OnDialRetryBudgetUpdated(budget uint64)
 func (nc *NoopCollector) OnStreamCreationRetryBudgetUpdated(budget uint64)              {}
 func (nc *NoopCollector) OnDialRetryBudgetResetToDefault()                              {}
 func (nc *NoopCollector) OnStreamCreationRetryBudgetResetToDefault()                    {}
+func (nc *NoopCollector) OnStreamCompressed(string, string, int, int, time.Duration)    {}
+
+func (nc *NoopCollector) OnChunkVerifiedForExecutor(flow.Identifier, string)                {}
+func (nc *NoopCollector) OnChunkDataPackFetchedFromExecutor(flow.Identifier, time.Duration) {}
 
 var _ module.HeroCacheMetrics = (*NoopCollector)(nil)
 
@@ -388,3 +398,14 @@ func (nc *NoopCollector) RecommendedMinBalance(bal float64)  {}
 func (nc *NoopCollector) IsMisconfigured(misconfigured bool) {}
 
 var _ module.MachineAccountMetrics = (*NoopCollector)(nil)
+
+var _ module.ProgramCacheMetrics = (*NoopCollector)(nil)
+
+func (nc *NoopCollector) ProgramCacheWarmedUp(hits int, misses int, duration time.Duration) {}
+func (nc *NoopCollector) ProgramCachePersisted(count int)                                   {}
+
+var _ module.ScriptResultCacheMetrics = (*NoopCollector)(nil)
+
+func (nc *NoopCollector) ScriptResultCacheHit()                           {}
+func (nc *NoopCollector) ScriptResultCacheMiss()                          {}
+func (nc *NoopCollector) ScriptResultCacheSize(entries int, bytes uint64) {}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+type ProgramCacheCollector struct {
+	hits              prometheus.Counter
+	misses            prometheus.Counter
+	warmUpDuration    prometheus.Gauge
+	persistedPrograms prometheus.Gauge
+}
+
+var _ module.ProgramCacheMetrics = (*ProgramCacheCollector)(nil)
+
+func NewProgramCacheCollector() *ProgramCacheCollector {
+	return &ProgramCacheCollector{
+		hits: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "persistent_program_cache_hits_total",
+			Help:      "the number of persisted programs derived again when warming up the program cache",
+		}),
+		misses: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "persistent_program_cache_misses_total",
+			Help:      "the number of persisted programs which were outdated or failed to load when warming up the program cache",
+		}),
+		warmUpDuration: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "persistent_program_cache_warm_up_duration_seconds",
+			Help:      "the time spent on the latest warm-up of the program cache",
+		}),
+		persistedPrograms: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "persistent_program_cache_persisted_programs",
+			Help:      "the number of programs persisted by the latest persistence of the program cache",
+		}),
+	}
+}
+
+// ProgramCacheWarmedUp records a warm-up of the program cache.
+func (c *ProgramCacheCollector) ProgramCacheWarmedUp(hits int, misses int, duration time.Duration) {
+	c.hits.Add(float64(hits))
+	c.misses.Add(float64(misses))
+	c.warmUpDuration.Set(duration.Seconds())
+}
+
+// ProgramCachePersisted records the number of programs persisted by the program cache.
+func (c *ProgramCacheCollector) ProgramCachePersisted(count int) {
+	c.persistedPrograms.Set(float64(count))
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+type ScriptResultCacheCollector struct {
+	hits    prometheus.Counter
+	misses  prometheus.Counter
+	entries prometheus.Gauge
+	bytes   prometheus.Gauge
+}
+
+var _ module.ScriptResultCacheMetrics = (*ScriptResultCacheCollector)(nil)
+
+func NewScriptResultCacheCollector() *ScriptResultCacheCollector {
+	return &ScriptResultCacheCollector{
+		hits: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptResultCache,
+			Name:      "hits_total",
+			Help:      "the number of script executions served from the script result cache",
+		}),
+		misses: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptResultCache,
+			Name:      "misses_total",
+			Help:      "the number of script executions at sealed blocks which were not in the script result cache",
+		}),
+		entries: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptResultCache,
+			Name:      "entries",
+			Help:      "the number of results in the script result cache",
+		}),
+		bytes: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptResultCache,
+			Name:      "size_bytes",
+			Help:      "the approximate size in bytes of the results in the script result cache",
+		}),
+	}
+}
+
+// ScriptResultCacheHit records a script execution served from the cache.
+func (c *ScriptResultCacheCollector) ScriptResultCacheHit() {
+	c.hits.Inc()
+}
+
+// ScriptResultCacheMiss records a cacheable script execution which was not cached.
+func (c *ScriptResultCacheCollector) ScriptResultCacheMiss() {
+	c.misses.Inc()
+}
+
+// ScriptResultCacheSize records the number of cached results and their total size in bytes.
+func (c *ScriptResultCacheCollector) ScriptResultCacheSize(entries int, bytes uint64) {
+	c.entries.Set(float64(entries))
+	c.bytes.Set(float64(bytes))
+}
@@ -31,6 +31,12 @@ type UnicastManagerMetrics struct {
 	dialRetryBudgetResetToDefault prometheus.Counter
 	// Tracks the number of times the stream creation retry budget is reset to default.
 	streamCreationRetryBudgetResetToDefault prometheus.Counter
+	// Tracks the number of bytes written on compressed outbound streams before compression, per channel and protocol.
+	compressionUncompressedBytes *prometheus.CounterVec
+	// Tracks the number of bytes written on compressed outbound streams after compression, per channel and protocol.
+	compressionCompressedBytes *prometheus.CounterVec
+	// Tracks the time spent compressing writes on compressed outbound streams, per channel and protocol.
+	compressionTime *prometheus.CounterVec
 
 	prefix string
 }
@@ -136,6 +142,33 @@ func NewUnicastManagerMetrics(prefix string) *UnicastManagerMetrics {
 			Help:      "the number of times the dial retry budget is reset to default by the unicast manager",
 		})
 
+	uc.compressionUncompressedBytes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      uc.prefix + "unicast_compression_uncompressed_bytes_total",
+			Help:      "the number of bytes written on compressed unicast streams before compression",
+		}, []string{LabelChannel, LabelProtocol},
+	)
+
+	uc.compressionCompressedBytes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      uc.prefix + "unicast_compression_compressed_bytes_total",
+			Help:      "the number of bytes written on compressed unicast streams after compression, the compression ratio is its rate divided by the rate of the uncompressed bytes",
+		}, []string{LabelChannel, LabelProtocol},
+	)
+
+	uc.compressionTime = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      uc.prefix + "unicast_compression_seconds_total",
+			Help:      "the time spent compressing writes on compressed unicast streams",
+		}, []string{LabelChannel, LabelProtocol},
+	)
+
 	return uc
 }
 
@@ -198,3 +231,11 @@ func (u *UnicastManagerMetrics) OnDialRetryBudgetResetToDefault() {
 func (u *UnicastManagerMetrics) OnStreamCreationRetryBudgetResetToDefault() {
 	u.streamCreationRetryBudgetResetToDefault.Inc()
 }
+
+// OnStreamCompressed tracks the number of bytes before and after compression, and the time spent compressing, of a write on
+// an outbound unicast stream of the given channel, which is compressed by the given unicast protocol.
+func (u *UnicastManagerMetrics) OnStreamCompressed(channel string, protocol string, uncompressedBytes int, compressedBytes int, duration time.Duration) {
+	u.compressionUncompressedBytes.WithLabelValues(channel, protocol).Add(float64(uncompressedBytes))
+	u.compressionCompressedBytes.WithLabelValues(channel, protocol).Add(float64(compressedBytes))
+	u.compressionTime.WithLabelValues(channel, protocol).Add(duration.Seconds())
+}
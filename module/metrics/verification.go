@@ -1,8 +1,11 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 )
 
@@ -41,7 +44,10 @@ type VerificationCollector struct {
 	// Verifier Engine
 	receivedVerifiableChunkTotalVerifier prometheus.Counter // total verifiable chunks received by verifier engine
 	sentResultApprovalTotalVerifier      prometheus.Counter // total result approvals sent by verifier engine
-
+	// total verified chunks per execution node that committed to the result, and per chunk fault type.
+	verifiedChunksPerExecutorVerifier *prometheus.CounterVec
+	// time between requesting a chunk data pack and its arrival, per execution node that provided it.
+	chunkDataPackFetchDurationPerExecutor *prometheus.HistogramVec
 }
 
 func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Registerer) *VerificationCollector {
@@ -173,6 +179,21 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		Help:      "total number of emitted result approvals by verifier engine",
 	})
 
+	verifiedChunksPerExecutorVerifier := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "verified_chunks_per_executor_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemVerifierEngine,
+		Help:      "total number of chunks verified by verifier engine per execution node that committed to the result and per chunk fault type",
+	}, []string{LabelNodeID, LabelChunkFault})
+
+	chunkDataPackFetchDurationPerExecutor := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:      "chunk_data_pack_fetch_duration_seconds",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemVerifierEngine,
+		Help:      "time between requesting a chunk data pack and its arrival, per execution node that provided the chunk data pack",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{LabelNodeID})
+
 	// registers all metrics and panics if any fails.
 	registerer.MustRegister(
 		// job consumers
@@ -200,7 +221,9 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 
 		// verifier engine
 		receivedVerifiableChunksTotalVerifier,
-		sentResultApprovalTotalVerifier)
+		sentResultApprovalTotalVerifier,
+		verifiedChunksPerExecutorVerifier,
+		chunkDataPackFetchDurationPerExecutor)
 
 	vc := &VerificationCollector{
 		tracer: tracer,
@@ -222,8 +245,10 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		sentVerifiableChunksTotalFetcher:   sentVerifiableChunksTotalFetcher,
 
 		// verifier
-		sentResultApprovalTotalVerifier:      sentResultApprovalTotalVerifier,
-		receivedVerifiableChunkTotalVerifier: receivedVerifiableChunksTotalVerifier,
+		sentResultApprovalTotalVerifier:       sentResultApprovalTotalVerifier,
+		receivedVerifiableChunkTotalVerifier:  receivedVerifiableChunksTotalVerifier,
+		verifiedChunksPerExecutorVerifier:     verifiedChunksPerExecutorVerifier,
+		chunkDataPackFetchDurationPerExecutor: chunkDataPackFetchDurationPerExecutor,
 
 		// requester
 		receivedChunkDataPackRequestsTotalRequester:         receivedChunkDataPackRequestsTotalRequester,
@@ -339,3 +364,18 @@ func (vc *VerificationCollector) OnBlockConsumerJobDone(processedIndex uint64) {
 func (vc *VerificationCollector) SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester(attempts uint64) {
 	vc.maxChunkDataPackRequestAttemptForNextUnsealedHeight.Set(float64(attempts))
 }
+
+// OnChunkVerifiedForExecutor increments a counter that keeps track of number of chunks verified by verifier engine per execution
+// node that committed to the result, and per chunk fault type (empty if the chunk passed verification).
+func (vc *VerificationCollector) OnChunkVerifiedForExecutor(executorID flow.Identifier, faultType string) {
+	if faultType == "" {
+		faultType = "none"
+	}
+	vc.verifiedChunksPerExecutorVerifier.WithLabelValues(executorID.String(), faultType).Inc()
+}
+
+// OnChunkDataPackFetchedFromExecutor records the time between requesting a chunk data pack and its arrival, per execution node
+// that provided the chunk data pack.
+func (vc *VerificationCollector) OnChunkDataPackFetchedFromExecutor(executorID flow.Identifier, duration time.Duration) {
+	vc.chunkDataPackFetchDurationPerExecutor.WithLabelValues(executorID.String()).Observe(duration.Seconds())
+}
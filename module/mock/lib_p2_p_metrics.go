@@ -402,6 +402,11 @@ func (_m *LibP2PMetrics) OnRpcSent(msgCount int, iHaveCount int, iWantCount int,
 	_m.Called(msgCount, iHaveCount, iWantCount, graftCount, pruneCount)
 }
 
+// OnStreamCompressed provides a mock function with given fields: channel, protocol, uncompressedBytes, compressedBytes, duration
+func (_m *LibP2PMetrics) OnStreamCompressed(channel string, protocol string, uncompressedBytes int, compressedBytes int, duration time.Duration) {
+	_m.Called(channel, protocol, uncompressedBytes, compressedBytes, duration)
+}
+
 // OnStreamCreated provides a mock function with given fields: duration, attempts
 func (_m *LibP2PMetrics) OnStreamCreated(duration time.Duration, attempts int) {
 	_m.Called(duration, attempts)
@@ -38,6 +38,11 @@ func (_m *UnicastManagerMetrics) OnPeerDialed(duration time.Duration, attempts i
 	_m.Called(duration, attempts)
 }
 
+// OnStreamCompressed provides a mock function with given fields: channel, protocol, uncompressedBytes, compressedBytes, duration
+func (_m *UnicastManagerMetrics) OnStreamCompressed(channel string, protocol string, uncompressedBytes int, compressedBytes int, duration time.Duration) {
+	_m.Called(channel, protocol, uncompressedBytes, compressedBytes, duration)
+}
+
 // OnStreamCreated provides a mock function with given fields: duration, attempts
 func (_m *UnicastManagerMetrics) OnStreamCreated(duration time.Duration, attempts int) {
 	_m.Called(duration, attempts)
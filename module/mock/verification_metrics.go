@@ -2,7 +2,12 @@
 
 package mock
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // VerificationMetrics is an autogenerated mock type for the VerificationMetrics type
 type VerificationMetrics struct {
@@ -34,6 +39,11 @@ func (_m *VerificationMetrics) OnChunkDataPackArrivedAtFetcher() {
 	_m.Called()
 }
 
+// OnChunkDataPackFetchedFromExecutor provides a mock function with given fields: executorID, duration
+func (_m *VerificationMetrics) OnChunkDataPackFetchedFromExecutor(executorID flow.Identifier, duration time.Duration) {
+	_m.Called(executorID, duration)
+}
+
 // OnChunkDataPackRequestDispatchedInNetworkByRequester provides a mock function with given fields:
 func (_m *VerificationMetrics) OnChunkDataPackRequestDispatchedInNetworkByRequester() {
 	_m.Called()
@@ -59,6 +69,11 @@ func (_m *VerificationMetrics) OnChunkDataPackSentToFetcher() {
 	_m.Called()
 }
 
+// OnChunkVerifiedForExecutor provides a mock function with given fields: executorID, faultType
+func (_m *VerificationMetrics) OnChunkVerifiedForExecutor(executorID flow.Identifier, faultType string) {
+	_m.Called(executorID, faultType)
+}
+
 // OnChunksAssignmentDoneAtAssigner provides a mock function with given fields: chunks
 func (_m *VerificationMetrics) OnChunksAssignmentDoneAtAssigner(chunks int) {
 	_m.Called(chunks)
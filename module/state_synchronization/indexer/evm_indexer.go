@@ -0,0 +1,249 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rlp"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/evm"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// EVMIndexer indexes the EVM blocks, transactions and receipts from the EVM.BlockExecuted and
+// EVM.TransactionExecuted events emitted by Flow blocks.
+//
+// The transactions of an EVM block are usually executed by the Flow block which commits the EVM block. Older Flow
+// blocks could execute transactions of an EVM block before a later Flow block committed it, these transactions are
+// kept in memory until the EVM block is committed. They are lost if the node restarts in between, in which case the
+// EVM block is indexed without them and an error is logged.
+//
+// This indexer shouldn't be used concurrently.
+type EVMIndexer struct {
+	log                  zerolog.Logger
+	index                storage.EVMIndex
+	blockEventType       flow.EventType
+	transactionEventType flow.EventType
+	signer               gethTypes.Signer
+
+	// pending holds the transactions of the EVM blocks which are not committed yet, by EVM height
+	pending map[uint64]map[gethCommon.Hash]*evm.Transaction
+	// pendingEvents holds the decoded events of the pending transactions, by transaction hash
+	pendingEvents map[gethCommon.Hash]*events.TransactionEventPayload
+}
+
+// NewEVMIndexer creates a new EVMIndexer storing the indexed EVM data in the given index.
+func NewEVMIndexer(log zerolog.Logger, chainID flow.ChainID, index storage.EVMIndex) *EVMIndexer {
+	sc := systemcontracts.SystemContractsForChain(chainID)
+	location := sc.EVMContract.Location()
+	return &EVMIndexer{
+		log:                  log.With().Str("component", "evm_indexer").Logger(),
+		index:                index,
+		blockEventType:       flow.EventType(location.TypeID(nil, string(events.EventTypeBlockExecuted))),
+		transactionEventType: flow.EventType(location.TypeID(nil, string(events.EventTypeTransactionExecuted))),
+		signer:               gethTypes.LatestSignerForChainID(types.EVMChainIDFromFlowChainID(chainID)),
+		pending:              make(map[uint64]map[gethCommon.Hash]*evm.Transaction),
+		pendingEvents:        make(map[gethCommon.Hash]*events.TransactionEventPayload),
+	}
+}
+
+// IndexEvents decodes the EVM events emitted by the given Flow block, and stores the EVM block committed by the Flow
+// block with its transactions and receipts in the given batch. Indexing the same Flow block again is allowed.
+// No errors are expected during normal operation.
+func (i *EVMIndexer) IndexEvents(blockID flow.Identifier, height uint64, flowEvents []flow.Event, batch storage.BatchStorage) error {
+	var blockEvent *events.BlockEventPayload
+	for _, event := range flowEvents {
+		switch event.Type {
+		case i.transactionEventType:
+			payload, err := decodeEVMEvent(event, events.DecodeTransactionEventPayload)
+			if err != nil {
+				return err
+			}
+			tx, err := i.transaction(payload, blockID)
+			if err != nil {
+				return err
+			}
+			if i.pending[tx.BlockHeight] == nil {
+				i.pending[tx.BlockHeight] = make(map[gethCommon.Hash]*evm.Transaction)
+			}
+			i.pending[tx.BlockHeight][tx.Hash] = tx
+			i.pendingEvents[tx.Hash] = payload
+
+		case i.blockEventType:
+			payload, err := decodeEVMEvent(event, events.DecodeBlockEventPayload)
+			if err != nil {
+				return err
+			}
+			blockEvent = payload
+		}
+	}
+	if blockEvent == nil {
+		return nil
+	}
+
+	txs := make([]*evm.Transaction, 0, len(i.pending[blockEvent.Height]))
+	for _, tx := range i.pending[blockEvent.Height] {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(a, b int) bool {
+		return txs[a].Index < txs[b].Index
+	})
+
+	block := &evm.Block{
+		Height:              blockEvent.Height,
+		Hash:                blockEvent.Hash,
+		ParentHash:          blockEvent.ParentBlockHash,
+		Timestamp:           blockEvent.Timestamp,
+		TotalGasUsed:        blockEvent.TotalGasUsed,
+		ReceiptRoot:         blockEvent.ReceiptRoot,
+		TransactionHashRoot: blockEvent.TransactionHashRoot,
+		PrevRandao:          blockEvent.PrevRandao,
+		TransactionHashes:   make([]gethCommon.Hash, 0, len(txs)),
+		FlowHeight:          height,
+		FlowBlockID:         blockID,
+	}
+	receipts := make([]*evm.Receipt, 0, len(txs))
+	cumulativeGasUsed := uint64(0)
+	logIndex := uint(0)
+	for _, tx := range txs {
+		event := i.pendingEvents[tx.Hash]
+		cumulativeGasUsed += event.GasConsumed
+		receipt, err := newEVMReceipt(event, block.Hash, cumulativeGasUsed, logIndex)
+		if err != nil {
+			return err
+		}
+		logIndex += uint(len(receipt.Logs))
+		block.TransactionHashes = append(block.TransactionHashes, tx.Hash)
+		for b := range block.LogsBloom {
+			block.LogsBloom[b] |= receipt.LogsBloom[b]
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if root := types.TransactionHashes(block.TransactionHashes).RootHash(); root != block.TransactionHashRoot {
+		i.log.Error().
+			Uint64("evm_height", block.Height).
+			Uint64("height", height).
+			Int("tx_count", len(txs)).
+			Msg("indexed transactions do not match the transaction hash root of the evm block, transactions are missing")
+	}
+
+	err := i.index.BatchStore(block, txs, receipts, batch)
+	if err != nil {
+		return fmt.Errorf("could not index evm block %d: %w", block.Height, err)
+	}
+
+	// the EVM blocks are committed in order, the transactions of the block and of any earlier block are not needed
+	// anymore once the batch is written
+	batch.OnSucceed(func() {
+		for evmHeight, txs := range i.pending {
+			if evmHeight > block.Height {
+				continue
+			}
+			for hash := range txs {
+				delete(i.pendingEvents, hash)
+			}
+			delete(i.pending, evmHeight)
+		}
+	})
+
+	return nil
+}
+
+// transaction returns the indexed transaction of the given transaction event emitted by the given Flow block.
+func (i *EVMIndexer) transaction(event *events.TransactionEventPayload, blockID flow.Identifier) (*evm.Transaction, error) {
+	tx := &evm.Transaction{
+		Hash:        event.Hash,
+		BlockHeight: event.BlockHeight,
+		Index:       event.Index,
+		Type:        event.TransactionType,
+		Payload:     event.Payload,
+		FlowBlockID: blockID,
+	}
+
+	if event.TransactionType == types.DirectCallTxType {
+		call, err := types.DirectCallFromEncoded(event.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode direct call %v: %w", event.Hash, err)
+		}
+		tx.From = call.From.ToCommon()
+		return tx, nil
+	}
+
+	gethTx := &gethTypes.Transaction{}
+	if err := gethTx.UnmarshalBinary(event.Payload); err != nil {
+		return nil, fmt.Errorf("could not decode transaction %v: %w", event.Hash, err)
+	}
+	from, err := gethTypes.Sender(i.signer, gethTx)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover sender of transaction %v: %w", event.Hash, err)
+	}
+	tx.From = from
+	return tx, nil
+}
+
+// newEVMReceipt returns the receipt of the transaction of the given event. The logs are indexed within the block
+// starting at the given log index.
+func newEVMReceipt(
+	event *events.TransactionEventPayload,
+	blockHash gethCommon.Hash,
+	cumulativeGasUsed uint64,
+	logIndex uint,
+) (*evm.Receipt, error) {
+	var logs []*gethTypes.Log
+	if len(event.Logs) > 0 {
+		if err := rlp.DecodeBytes(event.Logs, &logs); err != nil {
+			return nil, fmt.Errorf("could not decode logs of transaction %v: %w", event.Hash, err)
+		}
+	}
+	for _, log := range logs {
+		log.BlockNumber = event.BlockHeight
+		log.BlockHash = blockHash
+		log.TxHash = event.Hash
+		log.TxIndex = uint(event.Index)
+		log.Index = logIndex
+		logIndex++
+	}
+
+	receipt := &evm.Receipt{
+		TransactionHash:   event.Hash,
+		BlockHeight:       event.BlockHeight,
+		BlockHash:         blockHash,
+		Index:             event.Index,
+		Status:            gethTypes.ReceiptStatusSuccessful,
+		GasUsed:           event.GasConsumed,
+		CumulativeGasUsed: cumulativeGasUsed,
+		Logs:              logs,
+		LogsBloom:         gethTypes.BytesToBloom(gethTypes.LogsBloom(logs)),
+	}
+	if types.ErrorCode(event.ErrorCode) != types.ErrCodeNoError {
+		receipt.Status = gethTypes.ReceiptStatusFailed
+		receipt.ErrorMessage = event.ErrorMessage
+	}
+	if event.ContractAddress != "" {
+		address := gethCommon.HexToAddress(event.ContractAddress)
+		receipt.ContractAddress = &address
+	}
+	return receipt, nil
+}
+
+// decodeEVMEvent decodes the payload of the given EVM event with the given decoder.
+func decodeEVMEvent[T any](event flow.Event, decode func(cadence.Event) (*T, error)) (*T, error) {
+	cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode event %d of transaction %v: %w", event.EventIndex, event.TransactionID, err)
+	}
+	payload, err := decode(cadenceEvent)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode payload of event %d of transaction %v: %w", event.EventIndex, event.TransactionID, err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,145 @@
+package indexer
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/cadence/encoding/ccf"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethCrypto "github.com/onflow/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEVMIndexer verifies that the EVM blocks are indexed with their transactions and receipts when they are
+// committed, including the transactions executed by earlier Flow blocks.
+func TestEVMIndexer(t *testing.T) {
+	chainID := flow.Testnet
+
+	// a deposit executed by the Flow block at height 10, emitting a log
+	deposit := types.NewDepositCall(types.CoinbaseAddress, types.Address{1}, big.NewInt(1), 0)
+	depositPayload, err := deposit.Encode()
+	require.NoError(t, err)
+	depositLog := &gethTypes.Log{
+		Address: gethCommon.Address{2},
+		Topics:  []gethCommon.Hash{{3}},
+		Data:    []byte{4},
+	}
+	depositResult := &types.Result{
+		TxType:      types.DirectCallTxType,
+		TxHash:      deposit.Hash(),
+		Index:       0,
+		GasConsumed: 21_000,
+		Logs:        []*gethTypes.Log{depositLog},
+	}
+
+	// a failed transaction executed by the Flow block at height 11, which commits the EVM block
+	key, err := gethCrypto.GenerateKey()
+	require.NoError(t, err)
+	signer := gethTypes.LatestSignerForChainID(types.EVMChainIDFromFlowChainID(chainID))
+	tx, err := gethTypes.SignTx(gethTypes.NewTransaction(0, gethCommon.Address{5}, big.NewInt(0), 100_000, big.NewInt(0), nil), signer, key)
+	require.NoError(t, err)
+	txPayload, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	txResult := &types.Result{
+		TxType:      tx.Type(),
+		TxHash:      tx.Hash(),
+		Index:       1,
+		GasConsumed: 30_000,
+		VMError:     fmt.Errorf("execution reverted"),
+	}
+
+	block := types.NewBlock(gethCommon.Hash{6}, 2, 100, big.NewInt(0), gethCommon.Hash{7})
+	block.TotalGasUsed = 51_000
+	block.TransactionHashRoot = types.TransactionHashes{deposit.Hash(), tx.Hash()}.RootHash()
+	blockHash, err := block.Hash()
+	require.NoError(t, err)
+
+	toFlowEvent := func(event *events.Event) flow.Event {
+		ev, err := event.Payload.ToCadence(chainID)
+		require.NoError(t, err)
+		encoded, err := ccf.Encode(ev)
+		require.NoError(t, err)
+		return flow.Event{Type: flow.EventType(ev.Type().ID()), Payload: encoded}
+	}
+	depositEvent := toFlowEvent(events.NewTransactionEvent(depositResult, depositPayload, 2))
+	txEvent := toFlowEvent(events.NewTransactionEvent(txResult, txPayload, 2))
+	blockEvent := toFlowEvent(events.NewBlockEvent(block))
+
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewEVMIndex(db)
+		indexer := NewEVMIndexer(unittest.Logger(), chainID, index)
+
+		indexEvents := func(blockID flow.Identifier, height uint64, flowEvents ...flow.Event) {
+			batch := bstorage.NewBatch(db)
+			require.NoError(t, indexer.IndexEvents(blockID, height, flowEvents, batch))
+			require.NoError(t, batch.Flush())
+		}
+		requireIndexed := func(flowBlockID flow.Identifier) {
+			indexed, err := index.BlockByHeight(2)
+			require.NoError(t, err)
+			assert.Equal(t, blockHash, indexed.Hash)
+			assert.Equal(t, uint64(11), indexed.FlowHeight)
+			assert.Equal(t, flowBlockID, indexed.FlowBlockID)
+			assert.Equal(t, []gethCommon.Hash{deposit.Hash(), tx.Hash()}, indexed.TransactionHashes)
+			assert.True(t, indexed.LogsBloom.Test(depositLog.Address.Bytes()))
+			assert.True(t, indexed.LogsBloom.Test(depositLog.Topics[0].Bytes()))
+
+			indexedDeposit, err := index.TransactionByHash(deposit.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, types.CoinbaseAddress.ToCommon(), indexedDeposit.From)
+			assert.Equal(t, depositPayload, indexedDeposit.Payload)
+
+			indexedTx, err := index.TransactionByHash(tx.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, gethCrypto.PubkeyToAddress(key.PublicKey), indexedTx.From)
+			assert.Equal(t, uint16(1), indexedTx.Index)
+
+			depositReceipt, err := index.ReceiptByTransactionHash(deposit.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, gethTypes.ReceiptStatusSuccessful, depositReceipt.Status)
+			assert.Equal(t, uint64(21_000), depositReceipt.CumulativeGasUsed)
+			require.Len(t, depositReceipt.Logs, 1)
+			assert.Equal(t, blockHash, depositReceipt.Logs[0].BlockHash)
+			assert.Equal(t, uint(0), depositReceipt.Logs[0].Index)
+
+			txReceipt, err := index.ReceiptByTransactionHash(tx.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, gethTypes.ReceiptStatusFailed, txReceipt.Status)
+			assert.Equal(t, uint64(51_000), txReceipt.CumulativeGasUsed)
+			assert.Empty(t, txReceipt.Logs)
+		}
+
+		// the deposit is pending until the EVM block is committed
+		indexEvents(unittest.IdentifierFixture(), 10, depositEvent)
+		_, err := index.LatestHeight()
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		flowBlockID := unittest.IdentifierFixture()
+		indexEvents(flowBlockID, 11, txEvent, blockEvent)
+		requireIndexed(flowBlockID)
+
+		latest, err := index.LatestHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), latest)
+
+		// the pending transactions are released once the block is committed
+		assert.Empty(t, indexer.pending)
+		assert.Empty(t, indexer.pendingEvents)
+
+		// reindexing both Flow blocks indexes the same EVM block
+		indexEvents(unittest.IdentifierFixture(), 10, depositEvent)
+		indexEvents(flowBlockID, 11, txEvent, blockEvent)
+		requireIndexed(flowBlockID)
+	})
+}
@@ -31,6 +31,7 @@ type IndexerCore struct {
 	transactions storage.Transactions
 	results      storage.LightTransactionResults
 	batcher      bstorage.BatchBuilder
+	evmIndexer   *EVMIndexer
 
 	collectionExecutedMetric module.CollectionExecutedMetric
 
@@ -41,6 +42,7 @@ type IndexerCore struct {
 // New execution state indexer used to ingest block execution data and index it by height.
 // The passed RegisterIndex storage must be populated to include the first and last height otherwise the indexer
 // won't be initialized to ensure we have bootstrapped the storage first.
+// The EVM indexer is optional, the EVM blocks are not indexed without it.
 func New(
 	log zerolog.Logger,
 	metrics module.ExecutionStateIndexerMetrics,
@@ -54,6 +56,7 @@ func New(
 	chain flow.Chain,
 	derivedChainData *derived.DerivedChainData,
 	collectionExecutedMetric module.CollectionExecutedMetric,
+	evmIndexer *EVMIndexer,
 ) (*IndexerCore, error) {
 	log = log.With().Str("component", "execution_indexer").Logger()
 	metrics.InitializeLatestHeight(registers.LatestHeight())
@@ -75,6 +78,7 @@ func New(
 		results:          results,
 		serviceAddress:   chain.ServiceAddress(),
 		derivedChainData: derivedChainData,
+		evmIndexer:       evmIndexer,
 
 		collectionExecutedMetric: collectionExecutedMetric,
 	}, nil
@@ -157,7 +161,14 @@ func (c *IndexerCore) IndexBlockData(data *execution_data.BlockExecutionDataEnti
 			return fmt.Errorf("could not index transaction results at height %d: %w", header.Height, err)
 		}
 
-		batch.Flush()
+		if c.evmIndexer != nil {
+			err = c.evmIndexer.IndexEvents(data.BlockID, header.Height, events, batch)
+			if err != nil {
+				return fmt.Errorf("could not index evm blocks at height %d: %w", header.Height, err)
+			}
+		}
+
+		err = batch.Flush()
 		if err != nil {
 			return fmt.Errorf("batch flush error: %w", err)
 		}
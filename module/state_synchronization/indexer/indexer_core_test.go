@@ -231,6 +231,7 @@ func (i *indexCoreTest) initIndexer() *indexCoreTest {
 		flow.Testnet.Chain(),
 		derivedChainData,
 		collectionExecutedMetric,
+		nil,
 	)
 	require.NoError(i.t, err)
 	i.indexer = indexer
@@ -709,6 +710,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -743,6 +745,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -770,6 +773,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -814,6 +818,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3,6 +3,7 @@ package network
 import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/network/alsp/model"
 	"github.com/onflow/flow-go/network/channels"
 )
 
@@ -50,4 +51,8 @@ type MisbehaviorReportManager interface {
 	// disallow-listed if the overall penalty of the misbehaving node drops below the disallow-listing threshold.
 	// The implementation of this function should be thread-safe and non-blocking.
 	HandleMisbehaviorReport(channels.Channel, MisbehaviorReport)
+
+	// SpamRecord returns a copy of the spam record of the given node, and false if the node has no spam record, i.e.,
+	// it has not been reported for misbehavior or its record has been evicted.
+	SpamRecord(originId flow.Identifier) (*model.ProtocolSpamRecord, bool)
 }
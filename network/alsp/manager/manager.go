@@ -210,6 +210,18 @@ func NewMisbehaviorReportManager(cfg *MisbehaviorReportManagerConfig, consumer n
 	return m, nil
 }
 
+// SpamRecord returns a copy of the spam record of the given node.
+// Args:
+//
+//	originId: the node id of the node.
+//
+// Returns:
+//
+//	the spam record of the node and true if the node has a spam record, nil and false otherwise.
+func (m *MisbehaviorReportManager) SpamRecord(originId flow.Identifier) (*model.ProtocolSpamRecord, bool) {
+	return m.cache.Get(originId)
+}
+
 // HandleMisbehaviorReport is called upon a new misbehavior is reported.
 // The implementation of this function should be thread-safe and non-blocking.
 // Args:
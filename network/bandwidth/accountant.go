@@ -0,0 +1,284 @@
+package bandwidth
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	bsnet "github.com/ipfs/boxo/bitswap/network"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network/channels"
+	p2plogging "github.com/onflow/flow-go/network/p2p/logging"
+)
+
+// Config is the configuration of the Accountant.
+type Config struct {
+	// Window is the length of the sliding window over which the bandwidth is tracked, i.e., the longest window that can be queried.
+	Window time.Duration
+	// BucketInterval is the granularity of the sliding window.
+	BucketInterval time.Duration
+	// BlobEgressBudget is the number of bytes of blob (bitswap) traffic that may be sent to a single peer within the window.
+	// The budget is disabled when it is 0.
+	BlobEgressBudget uint64
+}
+
+// Key identifies the traffic accounted together.
+type Key struct {
+	Channel channels.Channel
+	// Role is the role of the peer, it is empty if the peer is not a known node.
+	Role   string
+	PeerID peer.ID
+}
+
+// Usage is the number of bytes received from and sent to a peer on a channel within a window.
+type Usage struct {
+	Key
+	Inbound  uint64
+	Outbound uint64
+}
+
+// Accountant tracks the inbound and outbound bytes of the node per channel, peer role and peer ID over a sliding window.
+// The bytes are accounted from two sources:
+//   - the conduit layer, which accounts the messages received on all channels (a GossipSub message is attributed to its
+//     origin), and the messages unicast to a peer. The messages published through GossipSub are forwarded by the mesh
+//     and cannot be attributed to a single peer.
+//   - the libp2p bandwidth reporter, which accounts the bitswap streams of the blob services. The channel of a
+//     bitswap stream is the prefix of its protocol ID.
+//
+// The Accountant optionally enforces a per-peer egress budget for the bitswap traffic, see BlobRequestFilter.
+// All bytes reported by libp2p are also passed to the wrapped libp2p bandwidth counter.
+type Accountant struct {
+	component.Component
+	*metrics.BandwidthCounter
+
+	logger     zerolog.Logger
+	idProvider module.IdentityProvider
+	config     Config
+	size       int64 // the number of buckets of the sliding windows
+
+	mu         sync.Mutex
+	usage      map[Key]*slidingWindow
+	blobEgress map[peer.ID]*slidingWindow // the outbound bitswap bytes per peer, used to enforce the blob egress budget
+}
+
+var _ MessageAccountant = (*Accountant)(nil)
+var _ metrics.Reporter = (*Accountant)(nil)
+var _ component.Component = (*Accountant)(nil)
+
+// NewAccountant creates a new Accountant.
+// Args:
+// - logger: the logger of the node.
+// - idProvider: the identity provider used to resolve the role of the peers.
+// - config: the configuration of the accountant.
+// Returns:
+// - *Accountant: the accountant.
+// - error: if the configuration is invalid, the error is irrecoverable.
+func NewAccountant(logger zerolog.Logger, idProvider module.IdentityProvider, config Config) (*Accountant, error) {
+	if config.BucketInterval <= 0 || config.Window < config.BucketInterval {
+		return nil, fmt.Errorf("invalid bandwidth accounting window %v with bucket interval %v", config.Window, config.BucketInterval)
+	}
+
+	a := &Accountant{
+		BandwidthCounter: metrics.NewBandwidthCounter(),
+		logger:           logger.With().Str("component", "bandwidth_accountant").Logger(),
+		idProvider:       idProvider,
+		config:           config,
+		size:             int64(config.Window / config.BucketInterval),
+		usage:            make(map[Key]*slidingWindow),
+		blobEgress:       make(map[peer.ID]*slidingWindow),
+	}
+
+	a.Component = component.NewComponentManagerBuilder().
+		AddWorker(a.evictionLoop).
+		Build()
+
+	return a, nil
+}
+
+// Window returns the length of the sliding window, i.e., the longest window that can be queried.
+func (a *Accountant) Window() time.Duration {
+	return time.Duration(a.size) * a.config.BucketInterval
+}
+
+// OnMessage accounts a message received from or sent to the given peer on the given channel by the conduit layer.
+func (a *Accountant) OnMessage(direction Direction, channel channels.Channel, peerID peer.ID, size int) {
+	if size <= 0 {
+		return
+	}
+	a.add(time.Now(), direction, channel, peerID, uint64(size))
+}
+
+// LogSentMessageStream is called by libp2p for the bytes written to a stream. The bytes of the bitswap streams are
+// accounted on the channel of the blob service.
+func (a *Accountant) LogSentMessageStream(size int64, proto protocol.ID, p peer.ID) {
+	a.BandwidthCounter.LogSentMessageStream(size, proto, p)
+	a.logStream(Outbound, size, proto, p)
+}
+
+// LogRecvMessageStream is called by libp2p for the bytes read from a stream. The bytes of the bitswap streams are
+// accounted on the channel of the blob service.
+func (a *Accountant) LogRecvMessageStream(size int64, proto protocol.ID, p peer.ID) {
+	a.BandwidthCounter.LogRecvMessageStream(size, proto, p)
+	a.logStream(Inbound, size, proto, p)
+}
+
+// logStream accounts the bytes of a bitswap stream, the bytes of all other streams are accounted by the conduit layer.
+func (a *Accountant) logStream(direction Direction, size int64, proto protocol.ID, p peer.ID) {
+	if size <= 0 {
+		return
+	}
+	channel, ok := BlobChannelFromProtocol(proto)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	a.add(now, direction, channel, p, uint64(size))
+
+	if direction == Outbound {
+		a.mu.Lock()
+		w, ok := a.blobEgress[p]
+		if !ok {
+			w = newSlidingWindow(int(a.size))
+			a.blobEgress[p] = w
+		}
+		w.add(bucketIndex(now, a.config.BucketInterval), Outbound, uint64(size))
+		a.mu.Unlock()
+	}
+}
+
+// add accounts the given number of bytes on the key of the given channel and peer.
+func (a *Accountant) add(now time.Time, direction Direction, channel channels.Channel, peerID peer.ID, size uint64) {
+	key := Key{Channel: channel, PeerID: peerID}
+	if identity, ok := a.idProvider.ByPeerID(peerID); ok {
+		key.Role = identity.Role.String()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w, ok := a.usage[key]
+	if !ok {
+		w = newSlidingWindow(int(a.size))
+		a.usage[key] = w
+	}
+	w.add(bucketIndex(now, a.config.BucketInterval), direction, size)
+}
+
+// Usage returns the bytes received and sent within the given window per channel, peer role and peer ID, the keys without
+// traffic within the window are omitted. The window is rounded up to the bucket interval, and capped at the sliding window.
+func (a *Accountant) Usage(window time.Duration) []Usage {
+	index := bucketIndex(time.Now(), a.config.BucketInterval)
+	n := a.buckets(window)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	usage := make([]Usage, 0, len(a.usage))
+	for key, w := range a.usage {
+		inbound, outbound := w.sum(index, n)
+		if inbound == 0 && outbound == 0 {
+			continue
+		}
+		usage = append(usage, Usage{Key: key, Inbound: inbound, Outbound: outbound})
+	}
+	return usage
+}
+
+// BlobRequestFilter returns a bitswap peer block request filter, which rejects the block requests of the peers that
+// exhausted their blob egress budget, and otherwise defers to the given filter (if any). When the budget is disabled,
+// the given filter is returned as is.
+func (a *Accountant) BlobRequestFilter(next func(peer.ID, cid.Cid) bool) func(peer.ID, cid.Cid) bool {
+	if a.config.BlobEgressBudget == 0 {
+		return next
+	}
+	return func(p peer.ID, c cid.Cid) bool {
+		if next != nil && !next(p, c) {
+			return false
+		}
+		if !a.blobEgressAllowed(p) {
+			a.logger.Debug().
+				Str("peer_id", p2plogging.PeerId(p)).
+				Str("cid", c.String()).
+				Uint64("budget", a.config.BlobEgressBudget).
+				Msg("rejecting blob request, peer exhausted its blob egress budget")
+			return false
+		}
+		return true
+	}
+}
+
+// blobEgressAllowed returns true if the bitswap bytes sent to the given peer within the window are below the budget.
+func (a *Accountant) blobEgressAllowed(p peer.ID) bool {
+	index := bucketIndex(time.Now(), a.config.BucketInterval)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w, ok := a.blobEgress[p]
+	if !ok {
+		return true
+	}
+	_, outbound := w.sum(index, a.size)
+	return outbound < a.config.BlobEgressBudget
+}
+
+// buckets returns the number of buckets covering the given window, capped at the size of the sliding window.
+func (a *Accountant) buckets(window time.Duration) int64 {
+	n := int64((window + a.config.BucketInterval - 1) / a.config.BucketInterval)
+	if n > a.size || n <= 0 {
+		return a.size
+	}
+	return n
+}
+
+// evictionLoop periodically evicts the keys without traffic within the sliding window, so that the memory of the
+// accountant is bounded by the number of peers the node communicates with.
+func (a *Accountant) evictionLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(a.Window())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.evict(time.Now())
+		}
+	}
+}
+
+// evict removes the keys of which the last traffic is older than the sliding window.
+func (a *Accountant) evict(now time.Time) {
+	oldest := bucketIndex(now, a.config.BucketInterval) - a.size
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, w := range a.usage {
+		if w.lastUpdate <= oldest {
+			delete(a.usage, key)
+		}
+	}
+	for p, w := range a.blobEgress {
+		if w.lastUpdate <= oldest {
+			delete(a.blobEgress, p)
+		}
+	}
+}
+
+// BlobChannelFromProtocol returns the channel of the blob service the given bitswap protocol ID belongs to. The blob
+// services prefix the bitswap protocol IDs with their channel. Returns false if the protocol is not a bitswap protocol.
+func BlobChannelFromProtocol(proto protocol.ID) (channels.Channel, bool) {
+	prefix, _, found := strings.Cut(string(proto), string(bsnet.ProtocolBitswapNoVers))
+	if !found {
+		return "", false
+	}
+	return channels.Channel(prefix), true
+}
@@ -0,0 +1,186 @@
+package bandwidth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// bitswapProtocol is the protocol ID of the bitswap streams of the execution data blob service.
+var bitswapProtocol = protocol.ID(channels.ExecutionDataService.String() + "/ipfs/bitswap/1.2.0")
+
+func newTestAccountant(t *testing.T, config Config) (*Accountant, *mockmodule.IdentityProvider) {
+	idProvider := mockmodule.NewIdentityProvider(t)
+	a, err := NewAccountant(unittest.Logger(), idProvider, config)
+	require.NoError(t, err)
+	return a, idProvider
+}
+
+// TestNewAccountant_InvalidConfig verifies that the accountant rejects a window shorter than its bucket interval.
+func TestNewAccountant_InvalidConfig(t *testing.T) {
+	_, err := NewAccountant(unittest.Logger(), mockmodule.NewIdentityProvider(t), Config{Window: time.Second, BucketInterval: time.Minute})
+	require.Error(t, err)
+
+	_, err = NewAccountant(unittest.Logger(), mockmodule.NewIdentityProvider(t), Config{Window: time.Minute})
+	require.Error(t, err)
+}
+
+// TestAccountant_Usage verifies that the conduit layer messages and the bitswap streams are accounted per channel,
+// peer role and peer ID, and that all other streams are only passed to the libp2p bandwidth counter.
+func TestAccountant_Usage(t *testing.T) {
+	a, idProvider := newTestAccountant(t, Config{Window: time.Hour, BucketInterval: time.Minute})
+	assert.Equal(t, time.Hour, a.Window())
+
+	identity := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	knownPeer := unittest.PeerIdFixture(t)
+	unknownPeer := unittest.PeerIdFixture(t)
+	idProvider.On("ByPeerID", knownPeer).Return(identity, true)
+	idProvider.On("ByPeerID", unknownPeer).Return(nil, false)
+
+	a.OnMessage(Inbound, channels.PushBlocks, knownPeer, 100)
+	a.OnMessage(Inbound, channels.PushBlocks, knownPeer, 50)
+	a.OnMessage(Outbound, channels.PushBlocks, knownPeer, 10)
+	a.OnMessage(Outbound, channels.SyncCommittee, unknownPeer, 20)
+	a.OnMessage(Outbound, channels.SyncCommittee, unknownPeer, 0) // empty messages are ignored
+
+	a.LogSentMessageStream(1000, bitswapProtocol, knownPeer)
+	a.LogRecvMessageStream(200, bitswapProtocol, knownPeer)
+	a.LogSentMessageStream(5000, protocol.ID("/flow/push/0.0.1"), knownPeer) // accounted by the conduit layer
+
+	usage := a.Usage(time.Hour)
+	require.ElementsMatch(t, []Usage{
+		{
+			Key:      Key{Channel: channels.PushBlocks, Role: flow.RoleExecution.String(), PeerID: knownPeer},
+			Inbound:  150,
+			Outbound: 10,
+		},
+		{
+			Key:      Key{Channel: channels.SyncCommittee, PeerID: unknownPeer},
+			Outbound: 20,
+		},
+		{
+			Key:      Key{Channel: channels.ExecutionDataService, Role: flow.RoleExecution.String(), PeerID: knownPeer},
+			Inbound:  200,
+			Outbound: 1000,
+		},
+	}, usage)
+
+	// all streams are passed to the wrapped libp2p bandwidth counter
+	require.Eventually(t, func() bool {
+		return a.GetBandwidthForPeer(knownPeer).TotalOut == 6000
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+// TestSlidingWindow verifies that the sliding window sums the buckets within the queried window, and that buckets are
+// overwritten once the window advances past them.
+func TestSlidingWindow(t *testing.T) {
+	w := newSlidingWindow(3)
+
+	w.add(10, Inbound, 1)
+	w.add(11, Outbound, 2)
+	w.add(12, Inbound, 4)
+	w.add(12, Outbound, 8)
+
+	inbound, outbound := w.sum(12, 3)
+	assert.Equal(t, uint64(5), inbound)
+	assert.Equal(t, uint64(10), outbound)
+
+	inbound, outbound = w.sum(12, 1)
+	assert.Equal(t, uint64(4), inbound)
+	assert.Equal(t, uint64(8), outbound)
+
+	// bucket 13 overwrites bucket 10
+	w.add(13, Inbound, 16)
+	inbound, outbound = w.sum(13, 3)
+	assert.Equal(t, uint64(20), inbound)
+	assert.Equal(t, uint64(10), outbound)
+	assert.Equal(t, int64(13), w.lastUpdate)
+
+	// buckets outside the window are not summed
+	inbound, outbound = w.sum(20, 3)
+	assert.Zero(t, inbound)
+	assert.Zero(t, outbound)
+}
+
+// TestAccountant_Evict verifies that the keys without traffic within the sliding window are evicted.
+func TestAccountant_Evict(t *testing.T) {
+	a, idProvider := newTestAccountant(t, Config{Window: time.Hour, BucketInterval: time.Minute, BlobEgressBudget: 1})
+	idProvider.On("ByPeerID", mock.Anything).Return(nil, false)
+
+	p := unittest.PeerIdFixture(t)
+	a.OnMessage(Inbound, channels.PushBlocks, p, 100)
+	a.LogSentMessageStream(100, bitswapProtocol, p)
+	require.Len(t, a.Usage(time.Hour), 2)
+
+	a.evict(time.Now())
+	require.Len(t, a.Usage(time.Hour), 2)
+
+	a.evict(time.Now().Add(time.Hour + time.Minute))
+	require.Empty(t, a.usage)
+	require.Empty(t, a.blobEgress)
+}
+
+// TestAccountant_BlobRequestFilter verifies that the block requests of a peer are rejected once the peer exhausted its
+// blob egress budget, and that the wrapped filter is applied first.
+func TestAccountant_BlobRequestFilter(t *testing.T) {
+	c := cid.Cid{}
+
+	t.Run("budget disabled", func(t *testing.T) {
+		a, _ := newTestAccountant(t, Config{Window: time.Hour, BucketInterval: time.Minute})
+		require.Nil(t, a.BlobRequestFilter(nil))
+
+		filter := a.BlobRequestFilter(func(peer.ID, cid.Cid) bool { return false })
+		require.False(t, filter(unittest.PeerIdFixture(t), c))
+	})
+
+	t.Run("budget enabled", func(t *testing.T) {
+		a, idProvider := newTestAccountant(t, Config{Window: time.Hour, BucketInterval: time.Minute, BlobEgressBudget: 1000})
+		idProvider.On("ByPeerID", mock.Anything).Return(nil, false)
+
+		p := unittest.PeerIdFixture(t)
+		other := unittest.PeerIdFixture(t)
+		rejected := unittest.PeerIdFixture(t)
+		filter := a.BlobRequestFilter(func(p peer.ID, _ cid.Cid) bool { return p != rejected })
+
+		require.True(t, filter(p, c))
+		require.False(t, filter(rejected, c))
+
+		// inbound bitswap bytes and outbound bytes of other protocols do not count towards the budget
+		a.LogRecvMessageStream(5000, bitswapProtocol, p)
+		a.LogSentMessageStream(5000, protocol.ID("/flow/push/0.0.1"), p)
+		a.OnMessage(Outbound, channels.PushBlocks, p, 5000)
+		require.True(t, filter(p, c))
+
+		a.LogSentMessageStream(999, bitswapProtocol, p)
+		require.True(t, filter(p, c))
+
+		a.LogSentMessageStream(1, bitswapProtocol, p)
+		require.False(t, filter(p, c))
+		require.True(t, filter(other, c))
+	})
+}
+
+// TestBlobChannelFromProtocol verifies that the channel of a blob service is derived from its bitswap protocol IDs.
+func TestBlobChannelFromProtocol(t *testing.T) {
+	channel, ok := BlobChannelFromProtocol(bitswapProtocol)
+	require.True(t, ok)
+	require.Equal(t, channels.ExecutionDataService, channel)
+
+	channel, ok = BlobChannelFromProtocol(protocol.ID(channels.PublicExecutionDataService.String() + "/ipfs/bitswap"))
+	require.True(t, ok)
+	require.Equal(t, channels.PublicExecutionDataService, channel)
+
+	_, ok = BlobChannelFromProtocol(protocol.ID("/meshsub/1.1.0"))
+	require.False(t, ok)
+}
@@ -0,0 +1,36 @@
+package bandwidth
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/onflow/flow-go/network/channels"
+)
+
+// Direction is the direction of the accounted traffic relative to the accounting node.
+type Direction string
+
+const (
+	// Inbound marks the bytes received by the accounting node.
+	Inbound Direction = "inbound"
+	// Outbound marks the bytes sent by the accounting node.
+	Outbound Direction = "outbound"
+)
+
+// MessageAccountant accounts the messages received and sent by the conduit layer of the network.
+type MessageAccountant interface {
+	// OnMessage accounts a message of the given size in bytes received from or sent to the given peer on the given channel.
+	// Implementations must be non-blocking and concurrency safe, as the network accounts messages on its hot path.
+	OnMessage(direction Direction, channel channels.Channel, peerID peer.ID, size int)
+}
+
+// NoopMessageAccountant is a MessageAccountant that discards all messages. It is used when the bandwidth accounting is disabled.
+type NoopMessageAccountant struct{}
+
+var _ MessageAccountant = (*NoopMessageAccountant)(nil)
+
+// NewNoopMessageAccountant returns a MessageAccountant that discards all messages.
+func NewNoopMessageAccountant() *NoopMessageAccountant {
+	return &NoopMessageAccountant{}
+}
+
+func (n *NoopMessageAccountant) OnMessage(Direction, channels.Channel, peer.ID, int) {}
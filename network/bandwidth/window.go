@@ -0,0 +1,59 @@
+package bandwidth
+
+import (
+	"time"
+)
+
+// bucket holds the bytes accounted within a single interval of a sliding window.
+type bucket struct {
+	index    int64 // the index of the interval, i.e., the unix time divided by the bucket interval
+	inbound  uint64
+	outbound uint64
+}
+
+// slidingWindow accounts bytes over a fixed number of buckets, the oldest bucket is overwritten once the window
+// advances past it. It is not concurrency safe.
+type slidingWindow struct {
+	buckets    []bucket
+	lastUpdate int64 // the index of the last bucket bytes were added to
+}
+
+// newSlidingWindow creates a sliding window of the given number of buckets.
+func newSlidingWindow(size int) *slidingWindow {
+	return &slidingWindow{
+		buckets: make([]bucket, size),
+	}
+}
+
+// add adds the given number of bytes in the given direction to the bucket of the given index.
+func (w *slidingWindow) add(index int64, direction Direction, size uint64) {
+	b := &w.buckets[index%int64(len(w.buckets))]
+	if b.index != index {
+		*b = bucket{index: index}
+	}
+	switch direction {
+	case Inbound:
+		b.inbound += size
+	case Outbound:
+		b.outbound += size
+	}
+	if index > w.lastUpdate {
+		w.lastUpdate = index
+	}
+}
+
+// sum returns the inbound and outbound bytes of the last n buckets up to (and including) the bucket of the given index.
+func (w *slidingWindow) sum(index int64, n int64) (inbound uint64, outbound uint64) {
+	for _, b := range w.buckets {
+		if b.index <= index && b.index > index-n {
+			inbound += b.inbound
+			outbound += b.outbound
+		}
+	}
+	return inbound, outbound
+}
+
+// bucketIndex returns the index of the bucket the given time falls into.
+func bucketIndex(t time.Time, interval time.Duration) int64 {
+	return t.UnixNano() / int64(interval)
+}
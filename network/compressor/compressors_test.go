@@ -0,0 +1,113 @@
+package compressor_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/compressor"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// compressors are all compressors supported by the unicast protocols.
+var compressors = []struct {
+	name       string
+	compressor network.Compressor
+}{
+	{"gzip", compressor.GzipStreamCompressor{}},
+	{"lz4", compressor.NewLz4Compressor()},
+	{"zstd", compressor.NewZstdCompressor()},
+}
+
+// TestRoundTrip_Compressors evaluates that for all compressors, (1) reading what has been written and flushed
+// yields the same result before the writer is closed, and (2) data is compressed when written.
+func TestRoundTrip_Compressors(t *testing.T) {
+	text := bytes.Repeat([]byte("hello world, "), 100)
+
+	for _, c := range compressors {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+
+			w, err := c.compressor.NewWriter(buf)
+			require.NoError(t, err)
+
+			n, err := w.Write(text)
+			require.NoError(t, err)
+			require.Equal(t, len(text), n)
+			require.NoError(t, w.Flush())
+			// written data on buffer should be compressed in size.
+			require.Less(t, buf.Len(), len(text))
+
+			// the flushed data is readable before the writer is closed, as unicast streams are flushed after each write.
+			r, err := c.compressor.NewReader(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			b := make([]byte, len(text))
+			_, err = io.ReadFull(r, b)
+			require.NoError(t, err)
+			require.Equal(t, text, b)
+			require.NoError(t, r.Close())
+
+			require.NoError(t, w.Close())
+		})
+	}
+}
+
+// BenchmarkCompressors benchmarks the compressors on messages of channels that are sent over unicast streams, and reports
+// the compression ratio (compressed / uncompressed size) of each compressor.
+//
+// Run with:
+//
+//	go test -run=^$ -bench=BenchmarkCompressors ./network/compressor/
+func BenchmarkCompressors(b *testing.B) {
+	codec := cbor.NewCodec()
+
+	// chunk data packs are dominated by trie proofs and payloads
+	chunkDataResponse, err := codec.Encode(unittest.ChunkDataResponseMsgFixture(unittest.IdentifierFixture(), unittest.WithApproximateSize(1<<20)))
+	require.NoError(b, err)
+
+	// execution data is already compressed when it is serialized
+	collection := unittest.CollectionFixture(1000)
+	chunkExecutionData := &execution_data.ChunkExecutionData{
+		Collection:         &collection,
+		Events:             unittest.EventsFixture(1000),
+		TransactionResults: make([]flow.LightTransactionResult, len(collection.Transactions)),
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(b, execution_data.DefaultSerializer.Serialize(buf, chunkExecutionData))
+	executionData := buf.Bytes()
+
+	payloads := []struct {
+		name string
+		data []byte
+	}{
+		{"chunk-data-response", chunkDataResponse},
+		{"execution-data", executionData},
+	}
+
+	for _, payload := range payloads {
+		for _, c := range compressors {
+			b.Run(payload.name+"/"+c.name, func(b *testing.B) {
+				out := new(bytes.Buffer)
+				b.SetBytes(int64(len(payload.data)))
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					out.Reset()
+					w, err := c.compressor.NewWriter(out)
+					require.NoError(b, err)
+					_, err = w.Write(payload.data)
+					require.NoError(b, err)
+					require.NoError(b, w.Close())
+				}
+
+				b.ReportMetric(float64(out.Len())/float64(len(payload.data)), "ratio")
+			})
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package compressor
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/onflow/flow-go/network"
+)
+
+// zstdMaxWindowSize is the window size used for compression, and the maximum window size accepted for decompression.
+// Bounding the window size bounds the memory a remote peer can make us allocate for decompressing a stream.
+const zstdMaxWindowSize = 1 << 20 // 1 MB
+
+var _ network.Compressor = (*ZstdCompressor)(nil)
+
+type ZstdCompressor struct{}
+
+func NewZstdCompressor() *ZstdCompressor {
+	return &ZstdCompressor{}
+}
+
+func (zstdComp ZstdCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	// streams are short-lived and numerous, hence the decoder runs synchronously
+	// instead of spawning its own goroutines.
+	d, err := zstd.NewReader(r,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxWindow(zstdMaxWindowSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not create zstd decoder: %w", err)
+	}
+	return d.IOReadCloser(), nil
+}
+
+func (zstdComp ZstdCompressor) NewWriter(w io.Writer) (network.WriteCloseFlusher, error) {
+	// an encoder is created per stream, hence it runs synchronously and trades some speed
+	// for a smaller memory footprint.
+	e, err := zstd.NewWriter(w,
+		zstd.WithEncoderConcurrency(1),
+		zstd.WithWindowSize(zstdMaxWindowSize),
+		zstd.WithLowerEncoderMem(true))
+	if err != nil {
+		return nil, fmt.Errorf("could not create zstd encoder: %w", err)
+	}
+	return &zstdWriteCloseFlusher{w: e}, nil
+}
+
+type zstdWriteCloseFlusher struct {
+	w *zstd.Encoder
+}
+
+func (zstdW *zstdWriteCloseFlusher) Write(p []byte) (int, error) {
+	return zstdW.w.Write(p)
+}
+
+func (zstdW *zstdWriteCloseFlusher) Close() error {
+	return zstdW.w.Close()
+}
+
+func (zstdW *zstdWriteCloseFlusher) Flush() error {
+	return zstdW.w.Flush()
+}
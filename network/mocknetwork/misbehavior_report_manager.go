@@ -3,7 +3,9 @@
 package mocknetwork
 
 import (
+	flow "github.com/onflow/flow-go/model/flow"
 	irrecoverable "github.com/onflow/flow-go/module/irrecoverable"
+	model "github.com/onflow/flow-go/network/alsp/model"
 	channels "github.com/onflow/flow-go/network/channels"
 
 	mock "github.com/stretchr/testify/mock"
@@ -61,6 +63,36 @@ func (_m *MisbehaviorReportManager) Ready() <-chan struct{} {
 	return r0
 }
 
+// SpamRecord provides a mock function with given fields: originId
+func (_m *MisbehaviorReportManager) SpamRecord(originId flow.Identifier) (*model.ProtocolSpamRecord, bool) {
+	ret := _m.Called(originId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SpamRecord")
+	}
+
+	var r0 *model.ProtocolSpamRecord
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(flow.Identifier) (*model.ProtocolSpamRecord, bool)); ok {
+		return rf(originId)
+	}
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *model.ProtocolSpamRecord); ok {
+		r0 = rf(originId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProtocolSpamRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(flow.Identifier) bool); ok {
+		r1 = rf(originId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *MisbehaviorReportManager) Start(_a0 irrecoverable.SignalerContext) {
 	_m.Called(_a0)
@@ -3,6 +3,8 @@
 package mocknetwork
 
 import (
+	flow "github.com/onflow/flow-go/model/flow"
+	model "github.com/onflow/flow-go/network/alsp/model"
 	channels "github.com/onflow/flow-go/network/channels"
 	mock "github.com/stretchr/testify/mock"
 
@@ -64,6 +66,36 @@ func (_m *Underlay) Ready() <-chan struct{} {
 	return r0
 }
 
+// SpamRecord provides a mock function with given fields: originId
+func (_m *Underlay) SpamRecord(originId flow.Identifier) (*model.ProtocolSpamRecord, bool) {
+	ret := _m.Called(originId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SpamRecord")
+	}
+
+	var r0 *model.ProtocolSpamRecord
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(flow.Identifier) (*model.ProtocolSpamRecord, bool)); ok {
+		return rf(originId)
+	}
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *model.ProtocolSpamRecord); ok {
+		r0 = rf(originId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProtocolSpamRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(flow.Identifier) bool); ok {
+		r1 = rf(originId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Subscribe provides a mock function with given fields: channel
 func (_m *Underlay) Subscribe(channel channels.Channel) error {
 	ret := _m.Called(channel)
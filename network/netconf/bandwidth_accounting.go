@@ -0,0 +1,26 @@
+package netconf
+
+import "time"
+
+const (
+	bandwidthAccountingKey = "bandwidth-accounting"
+	enabledKey             = "enabled"
+	windowKey              = "window"
+	bucketIntervalKey      = "bucket-interval"
+	blobEgressBudgetKey    = "blob-egress-budget"
+)
+
+// BandwidthAccounting configuration for the bandwidth accountant of the networking layer. When enabled, the inbound and
+// outbound bytes are tracked per channel, peer role and peer ID over a sliding window.
+type BandwidthAccounting struct {
+	// Enabled determines whether the bandwidth accounting is enabled.
+	Enabled bool `mapstructure:"enabled"`
+	// Window is the length of the sliding window over which the bandwidth is tracked, i.e., the longest window that
+	// can be queried.
+	Window time.Duration `validate:"gt=0s" mapstructure:"window"`
+	// BucketInterval is the granularity of the sliding window, the window is split into buckets of this length.
+	BucketInterval time.Duration `validate:"gt=0s" mapstructure:"bucket-interval"`
+	// BlobEgressBudget is the number of bytes of blob (bitswap) traffic that may be sent to a single peer within the
+	// window. Blob requests of a peer that exhausted its budget are not served. The budget is disabled when it is 0.
+	BlobEgressBudget uint64 `mapstructure:"blob-egress-budget"`
+}
@@ -17,6 +17,9 @@ type Config struct {
 	Unicast           Unicast                         `mapstructure:"unicast"`
 	ResourceManager   p2pconfig.ResourceManagerConfig `mapstructure:"libp2p-resource-manager"`
 	ConnectionManager ConnectionManager               `mapstructure:"connection-manager"`
+	MessageRecorder   MessageRecorder                 `mapstructure:"message-recorder"`
+	// BandwidthAccounting configuration of the per channel and per peer bandwidth accounting.
+	BandwidthAccounting BandwidthAccounting `mapstructure:"bandwidth-accounting"`
 	// GossipSub core gossipsub configuration.
 	GossipSub  p2pconfig.GossipSubParameters `mapstructure:"gossipsub"`
 	AlspConfig `mapstructure:",squash"`
@@ -56,6 +56,7 @@ func AllFlagNames() []string {
 		BuildFlagName(unicastKey, unicastManagerKey, streamZeroRetryResetThresholdKey),
 		BuildFlagName(unicastKey, unicastManagerKey, maxStreamCreationRetryAttemptTimesKey),
 		BuildFlagName(unicastKey, unicastManagerKey, configCacheSizeKey),
+		BuildFlagName(unicastKey, unicastManagerKey, channelProtocolsKey),
 		dnsCacheTTL,
 		disallowListNotificationCacheSize,
 		BuildFlagName(unicastKey, rateLimiterKey, messageRateLimitKey),
@@ -70,6 +71,14 @@ func AllFlagNames() []string {
 		BuildFlagName(connectionManagerKey, lowWatermarkKey),
 		BuildFlagName(connectionManagerKey, silencePeriodKey),
 		BuildFlagName(connectionManagerKey, gracePeriodKey),
+		BuildFlagName(messageRecorderKey, dirKey),
+		BuildFlagName(messageRecorderKey, maxFileSizeKey),
+		BuildFlagName(messageRecorderKey, maxFilesKey),
+		BuildFlagName(messageRecorderKey, queueSizeKey),
+		BuildFlagName(bandwidthAccountingKey, enabledKey),
+		BuildFlagName(bandwidthAccountingKey, windowKey),
+		BuildFlagName(bandwidthAccountingKey, bucketIntervalKey),
+		BuildFlagName(bandwidthAccountingKey, blobEgressBudgetKey),
 		alspDisabled,
 		alspSpamRecordCacheSize,
 		alspSpamRecordQueueSize,
@@ -247,6 +256,9 @@ func InitializeNetworkFlags(flags *pflag.FlagSet, config *Config) {
 		"max attempts to create a unicast stream.")
 	flags.Uint32(BuildFlagName(unicastKey, unicastManagerKey, configCacheSizeKey), config.Unicast.UnicastManager.ConfigCacheSize,
 		"cache size of the dial config cache, recommended to be big enough to accommodate the entire nodes in the network.")
+	flags.StringSlice(BuildFlagName(unicastKey, unicastManagerKey, channelProtocolsKey), config.Unicast.UnicastManager.ChannelProtocols,
+		"preferred unicast protocol per channel, e.g., request-chunks=zstd-compression,request-execution-data=lz4-compression; "+
+			"the protocol must be one of the preferred unicast protocols or plain.")
 
 	// unicast stream handler rate limits
 	flags.Int(BuildFlagName(unicastKey, rateLimiterKey, messageRateLimitKey), config.Unicast.RateLimiter.MessageRateLimit, "maximum number of unicast messages that a peer can send per second")
@@ -266,6 +278,22 @@ func InitializeNetworkFlags(flags *pflag.FlagSet, config *Config) {
 	flags.Int(BuildFlagName(connectionManagerKey, highWatermarkKey), config.ConnectionManager.HighWatermark, "high watermarking for libp2p connection manager")
 	flags.Duration(BuildFlagName(connectionManagerKey, gracePeriodKey), config.ConnectionManager.GracePeriod, "grace period for libp2p connection manager")
 	flags.Duration(BuildFlagName(connectionManagerKey, silencePeriodKey), config.ConnectionManager.SilencePeriod, "silence period for libp2p connection manager")
+	flags.String(BuildFlagName(messageRecorderKey, dirKey), config.MessageRecorder.Dir,
+		"directory to record all inbound and outbound network messages to, for debugging; recording is disabled when empty")
+	flags.Uint64(BuildFlagName(messageRecorderKey, maxFileSizeKey), config.MessageRecorder.MaxFileSize,
+		"size in bytes of a recorded message log file before the recorder rotates to a new file")
+	flags.Uint32(BuildFlagName(messageRecorderKey, maxFilesKey), config.MessageRecorder.MaxFiles,
+		"number of recorded message log files to keep, the oldest files are removed on rotation")
+	flags.Uint32(BuildFlagName(messageRecorderKey, queueSizeKey), config.MessageRecorder.QueueSize,
+		"number of recorded messages buffered for writing, messages are dropped from the log when the queue is full")
+	flags.Bool(BuildFlagName(bandwidthAccountingKey, enabledKey), config.BandwidthAccounting.Enabled,
+		"enable tracking of the inbound and outbound bytes per channel, peer role and peer ID")
+	flags.Duration(BuildFlagName(bandwidthAccountingKey, windowKey), config.BandwidthAccounting.Window,
+		"length of the sliding window over which the bandwidth is tracked")
+	flags.Duration(BuildFlagName(bandwidthAccountingKey, bucketIntervalKey), config.BandwidthAccounting.BucketInterval,
+		"granularity of the bandwidth accounting sliding window")
+	flags.Uint64(BuildFlagName(bandwidthAccountingKey, blobEgressBudgetKey), config.BandwidthAccounting.BlobEgressBudget,
+		"number of bytes of blob (bitswap) traffic that may be sent to a single peer within the bandwidth accounting window, disabled when 0")
 	flags.Bool(BuildFlagName(gossipsubKey, p2pconfig.PeerScoringEnabledKey), config.GossipSub.PeerScoringEnabled, "enabling peer scoring on pubsub network")
 	flags.Duration(BuildFlagName(gossipsubKey, p2pconfig.RpcTracerKey, p2pconfig.LocalMeshLogIntervalKey),
 		config.GossipSub.RpcTracer.LocalMeshLogInterval,
@@ -0,0 +1,23 @@
+package netconf
+
+const (
+	messageRecorderKey = "message-recorder"
+	dirKey             = "dir"
+	maxFileSizeKey     = "max-file-size"
+	maxFilesKey        = "max-files"
+	queueSizeKey       = "queue-size"
+)
+
+// MessageRecorder configuration for the network message recorder. When enabled, the network records every inbound
+// and outbound message on a rotating on-disk log, which can be replayed into engines under test for debugging.
+type MessageRecorder struct {
+	// Dir is the directory of the recorded message log. The recorder is disabled when it is empty.
+	Dir string `mapstructure:"dir"`
+	// MaxFileSize is the size in bytes a log file grows to before the recorder rotates to a new file.
+	MaxFileSize uint64 `validate:"gt=0" mapstructure:"max-file-size"`
+	// MaxFiles is the number of log files kept in the directory, the oldest files are removed on rotation.
+	MaxFiles uint32 `validate:"gt=0" mapstructure:"max-files"`
+	// QueueSize is the number of messages buffered for writing, messages are dropped from the log when the queue is full
+	// so that the recorder never blocks the network.
+	QueueSize uint32 `validate:"gt=0" mapstructure:"queue-size"`
+}
@@ -55,6 +55,7 @@ const (
 	streamZeroRetryResetThresholdKey      = "stream-zero-retry-reset-threshold"
 	maxStreamCreationRetryAttemptTimesKey = "max-stream-creation-retry-attempt-times"
 	configCacheSizeKey                    = "dial-config-cache-size"
+	channelProtocolsKey                   = "channel-protocols"
 )
 
 // UnicastManager configuration for the unicast manager. The unicast manager is responsible for establishing unicast streams.
@@ -76,4 +77,10 @@ type UnicastManager struct {
 	MaxStreamCreationRetryAttemptTimes uint64 `validate:"gt=1" mapstructure:"max-stream-creation-retry-attempt-times"`
 	// ConfigCacheSize is the cache size of the dial config cache that keeps the individual dial config for each peer.
 	ConfigCacheSize uint32 `validate:"gt=0" mapstructure:"dial-config-cache-size"`
+	// ChannelProtocols lists the unicast protocol preferred for streams of a channel as <channel>=<protocol> entries, e.g.,
+	// the compression that suits the messages of the channel best. The protocol must either be one of the preferred unicast
+	// protocols, or "plain" for no compression. Streams of channels without an entry use the preferred unicast protocols in
+	// their order. If the remote peer does not support the preferred protocol of a channel, the stream falls back to the other
+	// protocols.
+	ChannelProtocols []string `mapstructure:"channel-protocols"`
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/network/alsp/model"
 	"github.com/onflow/flow-go/network/channels"
 )
 
@@ -94,6 +95,10 @@ type Underlay interface {
 	// UpdateNodeAddresses fetches and updates the addresses of all the authorized participants
 	// in the Flow protocol.
 	UpdateNodeAddresses()
+
+	// SpamRecord returns a copy of the application layer spam record the network keeps for the given node, and
+	// false if the node has no spam record, i.e., it has not been reported for misbehavior or its record has been evicted.
+	SpamRecord(originId flow.Identifier) (*model.ProtocolSpamRecord, bool)
 }
 
 // Connection represents an interface to read from & write to a connection.
@@ -12,6 +12,7 @@ import (
 	"github.com/libp2p/go-libp2p/config"
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/host"
+	p2pmetrics "github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/routing"
 	"github.com/libp2p/go-libp2p/core/transport"
@@ -67,6 +68,7 @@ type LibP2PNodeBuilder struct {
 	connManager          connmgr.ConnManager
 	connGater            p2p.ConnectionGater
 	routingFactory       func(context.Context, host.Host) (routing.Routing, error)
+	bandwidthReporter    p2pmetrics.Reporter
 	peerManagerConfig    *p2pbuilderconfig.PeerManagerConfig
 	createNode           p2p.NodeConstructor
 	disallowListCacheCfg *p2p.DisallowListCacheConfig
@@ -147,6 +149,12 @@ func (builder *LibP2PNodeBuilder) SetRoutingSystem(f func(context.Context, host.
 	return builder
 }
 
+// SetBandwidthReporter sets the reporter of the bytes read from and written to the streams of the node.
+func (builder *LibP2PNodeBuilder) SetBandwidthReporter(reporter p2pmetrics.Reporter) p2p.NodeBuilder {
+	builder.bandwidthReporter = reporter
+	return builder
+}
+
 // OverrideGossipSubFactory overrides the default gossipsub factory for the GossipSub protocol.
 // The purpose of override is to allow the node to provide a custom gossipsub factory for sake of testing or experimentation.
 // Note: it is not recommended to override the default gossipsub factory in production unless you know what you are doing.
@@ -241,6 +249,10 @@ func (builder *LibP2PNodeBuilder) Build() (p2p.LibP2PNode, error) {
 		opts = append(opts, libp2p.ConnectionGater(builder.connGater))
 	}
 
+	if builder.bandwidthReporter != nil {
+		opts = append(opts, libp2p.BandwidthReporter(builder.bandwidthReporter))
+	}
+
 	h, err := DefaultLibP2PHost(builder.address, builder.networkKey, opts...)
 	if err != nil {
 		return nil, err
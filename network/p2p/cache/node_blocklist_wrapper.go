@@ -3,18 +3,25 @@ package cache
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
+// DisallowListExpiryCheckInterval is the interval at which the wrapper removes expired disallow-list entries.
+const DisallowListExpiryCheckInterval = time.Minute
+
 // IdentifierSet represents a set of node IDs (operator-defined) whose communication should be blocked.
 type IdentifierSet map[flow.Identifier]struct{}
 
@@ -24,6 +31,24 @@ func (s IdentifierSet) Contains(id flow.Identifier) bool {
 	return found
 }
 
+// disallowListEntries represents the operator-defined disallow-list entries, keyed by the node ID of the disallow-listed node.
+type disallowListEntries map[flow.Identifier]flow.DisallowListEntry
+
+// contains returns true iff the node with the given ID has an entry that is not expired at the given time.
+func (e disallowListEntries) contains(id flow.Identifier, now time.Time) bool {
+	entry, found := e[id]
+	return found && !entry.Expired(now)
+}
+
+// copy returns a shallow copy of the entries.
+func (e disallowListEntries) copy() disallowListEntries {
+	c := make(disallowListEntries, len(e))
+	for id, entry := range e {
+		c[id] = entry
+	}
+	return c
+}
+
 // NodeDisallowListingWrapper is a wrapper for an `module.IdentityProvider` instance, where the
 // wrapper overrides the `Ejected` flag to true for all NodeIDs in a `disallowList`.
 // To avoid modifying the source of the identities, the wrapper creates shallow copies
@@ -35,13 +60,18 @@ func (s IdentifierSet) Contains(id flow.Identifier) bool {
 // to config or command-line inputs.
 // When a node is disallow-listed, the networking layer connection to that node is closed and no
 // incoming or outgoing connections are established with that node.
+// Each disallow-listed node has an entry recording the reason and time of disallow-listing, and an optional expiry
+// after which the node is allow-listed again. The entries are persisted in the database, and the wrapper is a
+// component that, once started, re-applies the persisted disallow-list to the networking layer and removes the
+// expired entries periodically.
 // TODO: terminology change - rename `blocklist` to `disallowList` everywhere to be consistent with the code.
 type NodeDisallowListingWrapper struct {
+	component.Component
 	m  sync.RWMutex
 	db *badger.DB
 
 	identityProvider module.IdentityProvider
-	disallowList     IdentifierSet // `IdentifierSet` is a map, hence efficient O(1) lookup
+	disallowList     disallowListEntries // `disallowListEntries` is a map, hence efficient O(1) lookup
 
 	// updateConsumerOracle is called whenever the disallow-list is updated.
 	// Note that we do not use the `updateConsumer` directly due to the circular dependency between the
@@ -66,12 +96,48 @@ func NewNodeDisallowListWrapper(
 		return nil, fmt.Errorf("failed to read set of disallowed node IDs from data base: %w", err)
 	}
 
-	return &NodeDisallowListingWrapper{
+	w := &NodeDisallowListingWrapper{
 		db:                   db,
 		identityProvider:     identityProvider,
 		disallowList:         disallowList,
 		updateConsumerOracle: updateConsumerOracle,
-	}, nil
+	}
+
+	w.Component = component.NewComponentManagerBuilder().
+		AddWorker(w.expiryLoop).
+		Build()
+
+	return w, nil
+}
+
+// expiryLoop re-applies the persisted disallow-list to the networking layer on startup, so that the connections to
+// the disallow-listed nodes are pruned, and then removes the expired entries periodically till the wrapper is shut down.
+func (w *NodeDisallowListingWrapper) expiryLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	err := w.PruneExpired()
+	if err != nil {
+		ctx.Throw(fmt.Errorf("failed to prune expired disallow-list entries: %w", err))
+	}
+	if disallowList := w.GetDisallowList(); len(disallowList) > 0 {
+		w.updateConsumerOracle().OnDisallowListNotification(&network.DisallowListingUpdate{
+			FlowIds: disallowList,
+			Cause:   network.DisallowListedCauseAdmin,
+		})
+	}
+	ready()
+
+	ticker := time.NewTicker(DisallowListExpiryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := w.PruneExpired()
+			if err != nil {
+				ctx.Throw(fmt.Errorf("failed to prune expired disallow-list entries: %w", err))
+			}
+		}
+	}
 }
 
 // Update sets the wrapper's internal set of blocked nodes to `disallowList`. Empty list and `nil`
@@ -88,11 +154,22 @@ func NewNodeDisallowListWrapper(
 // Returns:
 // - error: if the update fails, e.g., due to a database error. Any returned error is irrecoverable and the caller
 // should abort the process.
+//
+// Nodes that are already disallow-listed keep their entry; nodes that are newly disallow-listed get an entry
+// without reason and expiry.
 func (w *NodeDisallowListingWrapper) Update(disallowList flow.IdentifierList) error {
-	b := disallowList.Lookup() // converts slice to map
+	now := time.Now()
 
 	w.m.Lock()
 	defer w.m.Unlock()
+	b := make(disallowListEntries, len(disallowList))
+	for _, id := range disallowList {
+		if entry, found := w.disallowList[id]; found && !entry.Expired(now) {
+			b[id] = entry
+			continue
+		}
+		b[id] = flow.DisallowListEntry{NodeID: id, CreatedAt: now}
+	}
 	err := persistDisallowList(b, w.db)
 	if err != nil {
 		return fmt.Errorf("failed to persist set of blocked nodes to the data base: %w", err)
@@ -112,18 +189,138 @@ func (w *NodeDisallowListingWrapper) ClearDisallowList() error {
 	return w.Update(nil)
 }
 
-// GetDisallowList returns the set of blocked node IDs.
+// Add disallow-lists the node of the given entry, or replaces its existing entry. The entry is persisted to the
+// database before the networking layer is notified, so that the connections to the node are pruned.
+// If the entry has no creation time, it is set to the current time.
+// No errors are expected during normal operations.
+//
+// Args:
+// - entry: the disallow-list entry of the node.
+//
+// Returns:
+// - error: if the update fails, e.g., due to a database error. Any returned error is irrecoverable and the caller
+// should abort the process.
+func (w *NodeDisallowListingWrapper) Add(entry flow.DisallowListEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	b := w.disallowList.copy()
+	b[entry.NodeID] = entry
+	err := persistDisallowList(b, w.db)
+	if err != nil {
+		return fmt.Errorf("failed to persist disallow-list entry of node %v to the data base: %w", entry.NodeID, err)
+	}
+	w.disallowList = b
+	w.updateConsumerOracle().OnDisallowListNotification(&network.DisallowListingUpdate{
+		FlowIds: flow.IdentifierList{entry.NodeID},
+		Cause:   network.DisallowListedCauseAdmin,
+	})
+
+	return nil
+}
+
+// Remove removes the disallow-list entry of the given node, and notifies the networking layer that the node is
+// allow-listed again. Returns false if the node has no entry.
+// No errors are expected during normal operations.
+//
+// Args:
+// - nodeID: the node ID of the node to be allow-listed.
+//
+// Returns:
+// - bool: true if the node had an entry, false otherwise.
+// - error: if the update fails, e.g., due to a database error. Any returned error is irrecoverable and the caller
+// should abort the process.
+func (w *NodeDisallowListingWrapper) Remove(nodeID flow.Identifier) (bool, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if _, found := w.disallowList[nodeID]; !found {
+		return false, nil
+	}
+	err := w.removeEntries(flow.IdentifierList{nodeID})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PruneExpired removes the expired disallow-list entries, and notifies the networking layer that the respective
+// nodes are allow-listed again.
+// No errors are expected during normal operations.
+func (w *NodeDisallowListingWrapper) PruneExpired() error {
+	now := time.Now()
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	var expired flow.IdentifierList
+	for id, entry := range w.disallowList {
+		if entry.Expired(now) {
+			expired = append(expired, id)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	return w.removeEntries(expired)
+}
+
+// removeEntries removes the entries of the given nodes, persists the remaining entries and notifies the networking
+// layer that the nodes are allow-listed again. The caller must hold the write lock.
+// No errors are expected during normal operations.
+func (w *NodeDisallowListingWrapper) removeEntries(nodeIDs flow.IdentifierList) error {
+	b := w.disallowList.copy()
+	for _, id := range nodeIDs {
+		delete(b, id)
+	}
+	err := persistDisallowList(b, w.db)
+	if err != nil {
+		return fmt.Errorf("failed to persist disallow-list entries to the data base: %w", err)
+	}
+	w.disallowList = b
+	w.updateConsumerOracle().OnAllowListNotification(&network.AllowListingUpdate{
+		FlowIds: nodeIDs,
+		Cause:   network.DisallowListedCauseAdmin,
+	})
+	return nil
+}
+
+// GetDisallowList returns the set of blocked node IDs, i.e., the nodes with a disallow-list entry that is not expired.
 func (w *NodeDisallowListingWrapper) GetDisallowList() flow.IdentifierList {
+	now := time.Now()
+
 	w.m.RLock()
 	defer w.m.RUnlock()
 
 	identifiers := make(flow.IdentifierList, 0, len(w.disallowList))
-	for i := range w.disallowList {
-		identifiers = append(identifiers, i)
+	for i, entry := range w.disallowList {
+		if !entry.Expired(now) {
+			identifiers = append(identifiers, i)
+		}
 	}
 	return identifiers
 }
 
+// Entries returns the disallow-list entries that are not expired, ordered by creation time.
+func (w *NodeDisallowListingWrapper) Entries() []flow.DisallowListEntry {
+	now := time.Now()
+
+	w.m.RLock()
+	entries := make([]flow.DisallowListEntry, 0, len(w.disallowList))
+	for _, entry := range w.disallowList {
+		if !entry.Expired(now) {
+			entries = append(entries, entry)
+		}
+	}
+	w.m.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries
+}
+
 // Identities returns the full identities of _all_ nodes currently known to the
 // protocol that pass the provided filter. Caution, this includes ejected nodes.
 // Please check the `Ejected` flag in the returned identities (or provide a
@@ -138,9 +335,10 @@ func (w *NodeDisallowListingWrapper) Identities(filter flow.IdentityFilter[flow.
 	// We copy both the return slice and identities of blocked nodes to avoid
 	// any possibility of accidentally modifying the wrapped IdentityProvider
 	idtx := make(flow.IdentityList, 0, len(identities))
+	now := time.Now()
 	w.m.RLock()
 	for _, identity := range identities {
-		if w.disallowList.Contains(identity.NodeID) {
+		if w.disallowList.contains(identity.NodeID, now) {
 			var i = *identity // shallow copy is sufficient, because `EpochParticipationStatus` is a value type in DynamicIdentity which is also a value type.
 			i.EpochParticipationStatus = flow.EpochParticipationStatusEjected
 			if filter(&i) { // we need to check the filter here again, because the filter might drop ejected nodes and we are modifying the ejected status here
@@ -175,7 +373,7 @@ func (w *NodeDisallowListingWrapper) setEjectedIfBlocked(identity *flow.Identity
 	}
 
 	w.m.RLock()
-	isBlocked := w.disallowList.Contains(identity.NodeID)
+	isBlocked := w.disallowList.contains(identity.NodeID, time.Now())
 	w.m.RUnlock()
 	if !isBlocked {
 		return identity
@@ -203,22 +401,46 @@ func (w *NodeDisallowListingWrapper) ByPeerID(p peer.ID) (*flow.Identity, bool)
 
 // persistDisallowList writes the given disallowList to the database. To avoid legacy
 // entries in the database, we prune the entire data base entry if `disallowList` is
-// empty. No errors are expected during normal operations.
-func persistDisallowList(disallowList IdentifierSet, db *badger.DB) error {
-	if len(disallowList) == 0 {
-		return db.Update(operation.PurgeBlocklist())
-	}
-	return db.Update(operation.PersistBlocklist(disallowList))
+// empty, as well as the legacy set of blocked nodes which is superseded by the entries.
+// No errors are expected during normal operations.
+func persistDisallowList(disallowList disallowListEntries, db *badger.DB) error {
+	return db.Update(func(tx *badger.Txn) error {
+		err := operation.PurgeBlocklist()(tx)
+		if err != nil {
+			return err
+		}
+		if len(disallowList) == 0 {
+			return operation.PurgeDisallowListEntries()(tx)
+		}
+		return operation.PersistDisallowListEntries(disallowList)(tx)
+	})
 }
 
-// retrieveDisallowList reads the set of blocked nodes from the data base.
+// retrieveDisallowList reads the disallow-list entries from the data base. If the data base only holds
+// the legacy set of blocked nodes, each blocked node is returned with an entry without reason and expiry.
 // In case no database entry exists, an empty set (nil map) is returned.
 // No errors are expected during normal operations.
-func retrieveDisallowList(db *badger.DB) (IdentifierSet, error) {
+func retrieveDisallowList(db *badger.DB) (disallowListEntries, error) {
+	var entries map[flow.Identifier]flow.DisallowListEntry
+	err := db.View(operation.RetrieveDisallowListEntries(&entries))
+	if err == nil {
+		return entries, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("unexpected error reading disallow-list entries from data base: %w", err)
+	}
+
 	var blocklist map[flow.Identifier]struct{}
-	err := db.View(operation.RetrieveBlocklist(&blocklist))
+	err = db.View(operation.RetrieveBlocklist(&blocklist))
 	if err != nil && !errors.Is(err, storage.ErrNotFound) {
 		return nil, fmt.Errorf("unexpected error reading set of blocked nodes from data base: %w", err)
 	}
-	return blocklist, nil
+	if len(blocklist) == 0 {
+		return nil, nil
+	}
+	entries = make(disallowListEntries, len(blocklist))
+	for id := range blocklist {
+		entries[id] = flow.DisallowListEntry{NodeID: id}
+	}
+	return entries, nil
 }
@@ -1,8 +1,10 @@
 package cache_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -13,10 +15,12 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module/irrecoverable"
 	mocks "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/mocknetwork"
 	"github.com/onflow/flow-go/network/p2p/cache"
+	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -446,3 +450,141 @@ func (s *NodeDisallowListWrapperTestSuite) TestDataBasePersist() {
 		require.Equal(s.T(), disallowList2.Lookup(), w2.GetDisallowList().Lookup())
 	})
 }
+
+// TestDisallowListEntries verifies the operator-managed disallow-list entries:
+//   - adding an entry disallow-lists the node and notifies the networking layer,
+//   - removing an entry allow-lists the node and notifies the networking layer,
+//   - expired entries are ignored, and pruned with an allow-list notification,
+//   - entries (including reason and expiry) are persisted and read by a new wrapper.
+func (s *NodeDisallowListWrapperTestSuite) TestDisallowListEntries() {
+	identity := unittest.IdentityFixture()
+	s.provider.On("ByNodeID", identity.NodeID).Return(identity, true)
+
+	s.Run("Add and remove entry", func() {
+		s.updateConsumer.On("OnDisallowListNotification", &network.DisallowListingUpdate{
+			FlowIds: flow.IdentifierList{identity.NodeID},
+			Cause:   network.DisallowListedCauseAdmin,
+		}).Return().Once()
+		err := s.wrapper.Add(flow.DisallowListEntry{NodeID: identity.NodeID, Reason: "spamming"})
+		require.NoError(s.T(), err)
+
+		i, found := s.wrapper.ByNodeID(identity.NodeID)
+		require.True(s.T(), found)
+		require.True(s.T(), i.IsEjected())
+
+		entries := s.wrapper.Entries()
+		require.Len(s.T(), entries, 1)
+		require.Equal(s.T(), identity.NodeID, entries[0].NodeID)
+		require.Equal(s.T(), "spamming", entries[0].Reason)
+		require.False(s.T(), entries[0].CreatedAt.IsZero())
+
+		s.updateConsumer.On("OnAllowListNotification", &network.AllowListingUpdate{
+			FlowIds: flow.IdentifierList{identity.NodeID},
+			Cause:   network.DisallowListedCauseAdmin,
+		}).Return().Once()
+		removed, err := s.wrapper.Remove(identity.NodeID)
+		require.NoError(s.T(), err)
+		require.True(s.T(), removed)
+		require.Empty(s.T(), s.wrapper.Entries())
+
+		i, found = s.wrapper.ByNodeID(identity.NodeID)
+		require.True(s.T(), found)
+		require.False(s.T(), i.IsEjected())
+
+		// removing a node without entry is a no-op
+		removed, err = s.wrapper.Remove(identity.NodeID)
+		require.NoError(s.T(), err)
+		require.False(s.T(), removed)
+	})
+
+	s.Run("Expired entry is ignored and pruned", func() {
+		s.updateConsumer.On("OnDisallowListNotification", &network.DisallowListingUpdate{
+			FlowIds: flow.IdentifierList{identity.NodeID},
+			Cause:   network.DisallowListedCauseAdmin,
+		}).Return().Once()
+		err := s.wrapper.Add(flow.DisallowListEntry{NodeID: identity.NodeID, Expiry: time.Now().Add(-time.Second)})
+		require.NoError(s.T(), err)
+
+		require.Empty(s.T(), s.wrapper.Entries())
+		require.Empty(s.T(), s.wrapper.GetDisallowList())
+		i, found := s.wrapper.ByNodeID(identity.NodeID)
+		require.True(s.T(), found)
+		require.False(s.T(), i.IsEjected())
+
+		s.updateConsumer.On("OnAllowListNotification", &network.AllowListingUpdate{
+			FlowIds: flow.IdentifierList{identity.NodeID},
+			Cause:   network.DisallowListedCauseAdmin,
+		}).Return().Once()
+		require.NoError(s.T(), s.wrapper.PruneExpired())
+
+		// pruning without expired entries is a no-op
+		require.NoError(s.T(), s.wrapper.PruneExpired())
+	})
+
+	s.Run("Entries are read from database", func() {
+		expiry := time.Now().Add(time.Hour)
+		s.updateConsumer.On("OnDisallowListNotification", &network.DisallowListingUpdate{
+			FlowIds: flow.IdentifierList{identity.NodeID},
+			Cause:   network.DisallowListedCauseAdmin,
+		}).Return().Once()
+		err := s.wrapper.Add(flow.DisallowListEntry{NodeID: identity.NodeID, Reason: "spamming", Expiry: expiry})
+		require.NoError(s.T(), err)
+
+		w, err := cache.NewNodeDisallowListWrapper(s.provider, s.DB, func() network.DisallowListNotificationConsumer {
+			return s.updateConsumer
+		})
+		require.NoError(s.T(), err)
+		entries := w.Entries()
+		require.Len(s.T(), entries, 1)
+		require.Equal(s.T(), identity.NodeID, entries[0].NodeID)
+		require.Equal(s.T(), "spamming", entries[0].Reason)
+		require.True(s.T(), expiry.Equal(entries[0].Expiry))
+	})
+}
+
+// TestLegacyDisallowListMigration verifies that a disallow-list persisted in the legacy format, i.e., as a set of
+// node IDs, is read by the wrapper as entries without expiry.
+func (s *NodeDisallowListWrapperTestSuite) TestLegacyDisallowListMigration() {
+	disallowList := unittest.IdentifierListFixture(4)
+	err := s.DB.Update(operation.PersistBlocklist(disallowList.Lookup()))
+	require.NoError(s.T(), err)
+
+	w, err := cache.NewNodeDisallowListWrapper(s.provider, s.DB, func() network.DisallowListNotificationConsumer {
+		return s.updateConsumer
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), disallowList.Lookup(), w.GetDisallowList().Lookup())
+	for _, entry := range w.Entries() {
+		require.True(s.T(), entry.Expiry.IsZero())
+	}
+}
+
+// TestStartupNotification verifies that the wrapper re-applies the persisted disallow-list by notifying the
+// networking layer once it is started.
+func (s *NodeDisallowListWrapperTestSuite) TestStartupNotification() {
+	disallowList := unittest.IdentifierListFixture(1)
+	s.updateConsumer.On("OnDisallowListNotification", &network.DisallowListingUpdate{
+		FlowIds: disallowList,
+		Cause:   network.DisallowListedCauseAdmin,
+	}).Return().Once()
+	require.NoError(s.T(), s.wrapper.Update(disallowList))
+
+	w, err := cache.NewNodeDisallowListWrapper(s.provider, s.DB, func() network.DisallowListNotificationConsumer {
+		return s.updateConsumer
+	})
+	require.NoError(s.T(), err)
+
+	notified := make(chan struct{})
+	s.updateConsumer.On("OnDisallowListNotification", &network.DisallowListingUpdate{
+		FlowIds: disallowList,
+		Cause:   network.DisallowListedCauseAdmin,
+	}).Run(func(mock.Arguments) { close(notified) }).Return().Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signalerCtx := irrecoverable.NewMockSignalerContext(s.T(), ctx)
+	w.Start(signalerCtx)
+	unittest.RequireCloseBefore(s.T(), w.Ready(), time.Second, "wrapper did not start")
+	unittest.RequireCloseBefore(s.T(), notified, time.Second, "disallow-list was not re-applied")
+	cancel()
+	unittest.RequireCloseBefore(s.T(), w.Done(), time.Second, "wrapper did not stop")
+}
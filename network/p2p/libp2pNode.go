@@ -140,6 +140,8 @@ type LibP2PNode interface {
 	PeerConnections
 	// PeerScore exposes the peer score API.
 	PeerScore
+	// PeerReporter exposes the per-peer state of the node for debugging.
+	PeerReporter
 	// DisallowListNotificationConsumer exposes the disallow list notification consumer API for the node so that
 	// it will be notified when a new disallow list update is distributed.
 	DisallowListNotificationConsumer
@@ -338,6 +338,26 @@ func (_m *LibP2PNode) PeerManagerComponent() component.Component {
 	return r0
 }
 
+// PeerReport provides a mock function with given fields: peerID
+func (_m *LibP2PNode) PeerReport(peerID peer.ID) *p2p.PeerReport {
+	ret := _m.Called(peerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PeerReport")
+	}
+
+	var r0 *p2p.PeerReport
+	if rf, ok := ret.Get(0).(func(peer.ID) *p2p.PeerReport); ok {
+		r0 = rf(peerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*p2p.PeerReport)
+		}
+	}
+
+	return r0
+}
+
 // PeerScoreExposer provides a mock function with given fields:
 func (_m *LibP2PNode) PeerScoreExposer() p2p.PeerScoreExposer {
 	ret := _m.Called()
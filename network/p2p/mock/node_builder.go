@@ -11,6 +11,8 @@ import (
 
 	madns "github.com/multiformats/go-multiaddr-dns"
 
+	metrics "github.com/libp2p/go-libp2p/core/metrics"
+
 	mock "github.com/stretchr/testify/mock"
 
 	network "github.com/libp2p/go-libp2p/core/network"
@@ -137,6 +139,26 @@ func (_m *NodeBuilder) OverrideNodeConstructor(_a0 p2p.NodeConstructor) p2p.Node
 	return r0
 }
 
+// SetBandwidthReporter provides a mock function with given fields: _a0
+func (_m *NodeBuilder) SetBandwidthReporter(_a0 metrics.Reporter) p2p.NodeBuilder {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBandwidthReporter")
+	}
+
+	var r0 p2p.NodeBuilder
+	if rf, ok := ret.Get(0).(func(metrics.Reporter) p2p.NodeBuilder); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(p2p.NodeBuilder)
+		}
+	}
+
+	return r0
+}
+
 // SetBasicResolver provides a mock function with given fields: _a0
 func (_m *NodeBuilder) SetBasicResolver(_a0 madns.BasicResolver) p2p.NodeBuilder {
 	ret := _m.Called(_a0)
@@ -3,6 +3,7 @@
 package mockp2p
 
 import (
+	peer "github.com/libp2p/go-libp2p/core/peer"
 	irrecoverable "github.com/onflow/flow-go/module/irrecoverable"
 	mock "github.com/stretchr/testify/mock"
 
@@ -86,6 +87,34 @@ func (_m *ScoreOptionBuilder) Ready() <-chan struct{} {
 	return r0
 }
 
+// SpamPenalty provides a mock function with given fields: peerID
+func (_m *ScoreOptionBuilder) SpamPenalty(peerID peer.ID) (float64, bool) {
+	ret := _m.Called(peerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SpamPenalty")
+	}
+
+	var r0 float64
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(peer.ID) (float64, bool)); ok {
+		return rf(peerID)
+	}
+	if rf, ok := ret.Get(0).(func(peer.ID) float64); ok {
+		r0 = rf(peerID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(peer.ID) bool); ok {
+		r1 = rf(peerID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *ScoreOptionBuilder) Start(_a0 irrecoverable.SignalerContext) {
 	_m.Called(_a0)
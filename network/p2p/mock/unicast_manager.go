@@ -8,6 +8,8 @@ import (
 	network "github.com/libp2p/go-libp2p/core/network"
 	mock "github.com/stretchr/testify/mock"
 
+	p2p "github.com/onflow/flow-go/network/p2p"
+
 	peer "github.com/libp2p/go-libp2p/core/peer"
 
 	protocols "github.com/onflow/flow-go/network/p2p/unicast/protocols"
@@ -48,6 +50,36 @@ func (_m *UnicastManager) CreateStream(ctx context.Context, peerID peer.ID) (net
 	return r0, r1
 }
 
+// DialState provides a mock function with given fields: peerID
+func (_m *UnicastManager) DialState(peerID peer.ID) (*p2p.UnicastDialState, bool) {
+	ret := _m.Called(peerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DialState")
+	}
+
+	var r0 *p2p.UnicastDialState
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(peer.ID) (*p2p.UnicastDialState, bool)); ok {
+		return rf(peerID)
+	}
+	if rf, ok := ret.Get(0).(func(peer.ID) *p2p.UnicastDialState); ok {
+		r0 = rf(peerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*p2p.UnicastDialState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(peer.ID) bool); ok {
+		r1 = rf(peerID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: unicast
 func (_m *UnicastManager) Register(unicast protocols.ProtocolName) error {
 	ret := _m.Called(unicast)
@@ -30,9 +30,11 @@ type GossipSubAdapter struct {
 	// It is not recommended to use this adapter without a topicScoreParamFunc. Also in mature
 	// implementations of the Flow network, the topicScoreParamFunc must be a required parameter.
 	topicScoreParamFunc func(topic *pubsub.Topic) *pubsub.TopicScoreParams
-	logger              zerolog.Logger
-	peerScoreExposer    p2p.PeerScoreExposer
-	localMeshTracer     p2p.PubSubTracer
+	// spamPenaltyFunc is a function that returns the spam penalty of a given peer, nil if the node does not score peers.
+	spamPenaltyFunc  func(peer.ID) (float64, bool)
+	logger           zerolog.Logger
+	peerScoreExposer p2p.PeerScoreExposer
+	localMeshTracer  p2p.PubSubTracer
 	// clusterChangeConsumer is a callback that is invoked when the set of active clusters of collection nodes changes.
 	// This callback is implemented by the rpc inspector suite of the GossipSubAdapter, and consumes the cluster changes
 	// to update the rpc inspector state of the recent topics (i.e., channels).
@@ -71,6 +73,10 @@ func NewGossipSubAdapter(ctx context.Context,
 		a.logger.Warn().Msg("no topic score param func provided")
 	}
 
+	if spamPenaltyFunc, ok := gossipSubConfig.SpamPenaltyFunc(); ok {
+		a.spamPenaltyFunc = spamPenaltyFunc
+	}
+
 	if scoreTracer := gossipSubConfig.ScoreTracer(); scoreTracer != nil {
 		builder.AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
 			ready()
@@ -238,6 +244,20 @@ func (g *GossipSubAdapter) PeerScoreExposer() p2p.PeerScoreExposer {
 	return g.peerScoreExposer
 }
 
+// SpamPenalty returns the penalty applied to the application specific score of the given peer for its GossipSub
+// control message misbehavior.
+// Args:
+// - peerID: the peer ID of the peer.
+// Returns:
+// - float64: the spam penalty of the peer.
+// - bool: true if the peer has a spam record, false if it has none or the adapter does not score peers.
+func (g *GossipSubAdapter) SpamPenalty(peerID peer.ID) (float64, bool) {
+	if g.spamPenaltyFunc == nil {
+		return 0, false
+	}
+	return g.spamPenaltyFunc(peerID)
+}
+
 // ActiveClustersChanged is called when the active clusters of collection nodes changes.
 // GossipSubAdapter implements this method to forward the call to the clusterChangeConsumer (rpc inspector),
 // which will then update the cluster state of the rpc inspector.
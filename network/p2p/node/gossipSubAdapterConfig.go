@@ -214,6 +214,22 @@ func (g *GossipSubAdapterConfig) TopicScoreParamFunc() (func(topic *pubsub.Topic
 	return nil, false
 }
 
+// SpamPenaltyFunc returns the spam penalty function. This function is used to get the spam penalty of a peer, i.e., the
+// penalty applied to its application specific score for its GossipSub control message misbehavior.
+// Args:
+//   - None
+//
+// Returns:
+// - func(peer.ID) (float64, bool): the spam penalty function if set, nil otherwise.
+// - bool: true if the spam penalty function is set, false otherwise.
+func (g *GossipSubAdapterConfig) SpamPenaltyFunc() (func(peer.ID) (float64, bool), bool) {
+	if g.scoreOption != nil {
+		return g.scoreOption.SpamPenalty, true
+	}
+
+	return nil, false
+}
+
 // Build returns the libp2p pubsub options.
 // Args:
 //   - None
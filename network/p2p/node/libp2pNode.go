@@ -666,6 +666,50 @@ func (n *Node) IsDisallowListed(peerId peer.ID) ([]flownet.DisallowListedCause,
 	return n.disallowListedCache.IsDisallowListed(peerId)
 }
 
+// PeerReport returns a snapshot of the state the node keeps about the given peer, i.e., its connectedness,
+// disallow-list status, GossipSub scores, mesh membership and unicast dial state.
+// The report is a best-effort snapshot for debugging purposes; the state of the peer may change while it is collected.
+// Args:
+// - peerID: the peer ID of the remote peer.
+// Returns:
+// - the report of the peer.
+func (n *Node) PeerReport(peerID peer.ID) *p2p.PeerReport {
+	report := &p2p.PeerReport{
+		PeerID:    peerID,
+		Connected: n.host.Network().Connectedness(peerID) == libp2pnet.Connected,
+	}
+	report.DisallowListCauses, _ = n.disallowListedCache.IsDisallowListed(peerID)
+
+	if n.pubSub != nil {
+		if exposer := n.pubSub.PeerScoreExposer(); exposer != nil {
+			if score, ok := exposer.GetScore(peerID); ok {
+				report.Score = &p2p.PeerScoreReport{Score: score}
+				report.Score.AppSpecificScore, _ = exposer.GetAppScore(peerID)
+				report.Score.IPColocationFactor, _ = exposer.GetIPColocationFactor(peerID)
+				report.Score.BehaviourPenalty, _ = exposer.GetBehaviourPenalty(peerID)
+				report.Score.TopicScores, _ = exposer.GetTopicScores(peerID)
+			}
+		}
+		if penalty, ok := n.pubSub.SpamPenalty(peerID); ok {
+			report.SpamPenalty = &penalty
+		}
+		for _, topic := range n.pubSub.GetTopics() {
+			for _, meshPeer := range n.pubSub.GetLocalMeshPeers(channels.Topic(topic)) {
+				if meshPeer == peerID {
+					report.MeshTopics = append(report.MeshTopics, topic)
+					break
+				}
+			}
+		}
+	}
+
+	if n.uniMgr != nil {
+		report.Dial, _ = n.uniMgr.DialState(peerID)
+	}
+
+	return report
+}
+
 // ActiveClustersChanged is called when the active clusters list of the collection clusters has changed.
 // The LibP2PNode implementation directly calls the ActiveClustersChanged method of the pubsub implementation, as
 // the pubsub implementation is responsible for the actual handling of the event.
@@ -138,6 +138,24 @@ func TestCreateStream_WithPreferredGzipUnicast(t *testing.T) {
 		protocols.FlowGzipProtocolId(sporkId))
 }
 
+// TestCreateStream_WithPreferredLz4Unicast evaluates correctness of creating lz4-compressed tcp unicast streams between two libp2p nodes.
+func TestCreateStream_WithPreferredLz4Unicast(t *testing.T) {
+	sporkId := unittest.IdentifierFixture()
+	testCreateStream(t,
+		sporkId,
+		[]protocols.ProtocolName{protocols.GzipCompressionUnicast, protocols.Lz4CompressionUnicast},
+		protocols.FlowLz4ProtocolId(sporkId))
+}
+
+// TestCreateStream_WithPreferredZstdUnicast evaluates correctness of creating zstd-compressed tcp unicast streams between two libp2p nodes.
+func TestCreateStream_WithPreferredZstdUnicast(t *testing.T) {
+	sporkId := unittest.IdentifierFixture()
+	testCreateStream(t,
+		sporkId,
+		[]protocols.ProtocolName{protocols.GzipCompressionUnicast, protocols.Lz4CompressionUnicast, protocols.ZstdCompressionUnicast},
+		protocols.FlowZstdProtocolId(sporkId))
+}
+
 // testCreateStreams checks if a new streams of "preferred" type is created each time when CreateStream is called and an existing stream is not
 // reused. The "preferred" stream type is the one with the largest index in `unicasts` list.
 // To check that the streams are of "preferred" type, it evaluates the protocol id of established stream against the input `protocolID`.
@@ -273,6 +291,44 @@ func TestCreateStream_FallBack(t *testing.T) {
 	unittest.RequireReturnsBefore(t, allStreamsClosedWg.Wait, 1*time.Second, "could not close streams on time")
 }
 
+// TestCreateStream_NegotiatesCommonCompression checks two libp2p nodes with different sets of supported compressions negotiate the most
+// preferred compression that both of them support. To do this, a node preferring zstd over gzip creates a stream to a node preferring
+// lz4 over gzip, and the test evaluates that the stream is gzip-compressed.
+func TestCreateStream_NegotiatesCommonCompression(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signalerCtx := irrecoverable.NewMockSignalerContext(t, ctx)
+
+	sporkId := unittest.IdentifierFixture()
+	idProvider := mockmodule.NewIdentityProvider(t)
+	thisNode, thisID := p2ptest.NodeFixture(t,
+		sporkId,
+		t.Name(),
+		idProvider,
+		p2ptest.WithPreferredUnicasts([]protocols.ProtocolName{protocols.GzipCompressionUnicast, protocols.ZstdCompressionUnicast}))
+	otherNode, otherId := p2ptest.NodeFixture(t,
+		sporkId,
+		t.Name(),
+		idProvider,
+		p2ptest.WithPreferredUnicasts([]protocols.ProtocolName{protocols.GzipCompressionUnicast, protocols.Lz4CompressionUnicast}))
+	identities := []flow.Identity{thisID, otherId}
+	nodes := []p2p.LibP2PNode{thisNode, otherNode}
+	for i, node := range nodes {
+		idProvider.On("ByPeerID", node.ID()).Return(&identities[i], true).Maybe()
+	}
+	p2ptest.StartNodes(t, signalerCtx, nodes)
+	defer p2ptest.StopNodes(t, nodes, cancel)
+
+	pInfo, err := utils.PeerAddressInfo(otherId.IdentitySkeleton)
+	require.NoError(t, err)
+	thisNode.Host().Peerstore().AddAddrs(pInfo.ID, pInfo.Addrs, peerstore.AddressTTL)
+
+	err = thisNode.OpenAndWriteOnStream(ctx, pInfo.ID, t.Name(), func(stream network.Stream) error {
+		require.Equal(t, protocols.FlowGzipProtocolId(sporkId), stream.Protocol())
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 // TestCreateStreamIsConcurrencySafe tests that the CreateStream is concurrency safe
 func TestCreateStreamIsConcurrencySafe(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -406,6 +462,18 @@ func TestUnicastOverStream_WithGzipStreamCompression(t *testing.T) {
 	testUnicastOverStream(t, p2ptest.WithPreferredUnicasts([]protocols.ProtocolName{protocols.GzipCompressionUnicast}))
 }
 
+// TestUnicastOverStream_WithLz4StreamCompression checks two nodes can send and receive unicast messages on lz4 compressed streams
+// when both nodes have lz4 stream compression enabled.
+func TestUnicastOverStream_WithLz4StreamCompression(t *testing.T) {
+	testUnicastOverStream(t, p2ptest.WithPreferredUnicasts([]protocols.ProtocolName{protocols.Lz4CompressionUnicast}))
+}
+
+// TestUnicastOverStream_WithZstdStreamCompression checks two nodes can send and receive unicast messages on zstd compressed streams
+// when both nodes have zstd stream compression enabled.
+func TestUnicastOverStream_WithZstdStreamCompression(t *testing.T) {
+	testUnicastOverStream(t, p2ptest.WithPreferredUnicasts([]protocols.ProtocolName{protocols.ZstdCompressionUnicast}))
+}
+
 // testUnicastOverStream sends a message from node 1 to node 2 and then from node 2 to node 1 over a unicast stream.
 func testUnicastOverStream(t *testing.T, opts ...p2ptest.NodeFixtureParameterOption) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,56 @@
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/onflow/flow-go/network"
+)
+
+// PeerReporter is the interface for inspecting the state the libp2p node keeps about a remote peer.
+type PeerReporter interface {
+	// PeerReport returns a snapshot of the state the node keeps about the given peer, i.e., its connectedness,
+	// disallow-list status, GossipSub scores, mesh membership and unicast dial state.
+	// The report is a best-effort snapshot for debugging purposes; the state of the peer may change while it is collected.
+	PeerReport(peerID peer.ID) *PeerReport
+}
+
+// PeerReport is a snapshot of the state the libp2p node keeps about a remote peer.
+type PeerReport struct {
+	// PeerID is the libp2p peer ID of the peer.
+	PeerID peer.ID
+	// Connected is true if the node currently has at least one connection to the peer.
+	Connected bool
+	// DisallowListCauses are the causes for which the peer is currently disallow-listed, empty if the peer is not disallow-listed.
+	DisallowListCauses []network.DisallowListedCause
+	// Score is the GossipSub score of the peer, nil if the node has no score tracer or the peer has no score.
+	Score *PeerScoreReport
+	// SpamPenalty is the penalty applied to the application specific score of the peer for its GossipSub control
+	// message misbehavior, nil if the peer has no spam record or the node does not score peers.
+	SpamPenalty *float64
+	// MeshTopics are the topics on which the peer is in the local mesh of the node.
+	MeshTopics []string
+	// Dial is the unicast dial state of the peer, nil if the node has not dialed the peer.
+	Dial *UnicastDialState
+}
+
+// PeerScoreReport is the GossipSub score of a peer, broken down into its components.
+type PeerScoreReport struct {
+	// Score is the overall score of the peer.
+	Score float64
+	// AppSpecificScore is the application specific score of the peer.
+	AppSpecificScore float64
+	// IPColocationFactor is the IP colocation factor of the peer.
+	IPColocationFactor float64
+	// BehaviourPenalty is the behaviour penalty of the peer.
+	BehaviourPenalty float64
+	// TopicScores are the topic scores of the peer, keyed by topic.
+	TopicScores map[string]TopicScoreSnapshot
+}
+
+// UnicastDialState is the state the unicast manager keeps about dialing a peer.
+type UnicastDialState struct {
+	// StreamCreationRetryAttemptBudget is the number of attempts to create a stream to the peer before giving up.
+	StreamCreationRetryAttemptBudget uint64
+	// ConsecutiveSuccessfulStream is the number of consecutive successful streams to the peer since the last failure.
+	ConsecutiveSuccessfulStream uint64
+}
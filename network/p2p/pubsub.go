@@ -71,6 +71,10 @@ type PubSubAdapter interface {
 	// Returns:
 	//    The peer score exposer for the gossipsub adapter.
 	PeerScoreExposer() PeerScoreExposer
+
+	// SpamPenalty returns the penalty applied to the application specific score of the given peer for its
+	// GossipSub control message misbehavior, and false if the peer has no spam record or the adapter does not score peers.
+	SpamPenalty(peerID peer.ID) (float64, bool)
 }
 
 // PubSubAdapterConfig abstracts the configuration for the underlying pubsub implementation.
@@ -130,6 +134,9 @@ type ScoreOptionBuilder interface {
 	// TopicScoreParams returns the topic score params for the given topic.
 	// If the topic score params for the given topic does not exist, it will return the default topic score params.
 	TopicScoreParams(*pubsub.Topic) *pubsub.TopicScoreParams
+	// SpamPenalty returns the spam penalty of the given peer, i.e., the penalty applied to its application specific
+	// score for its GossipSub control message misbehavior, and false if the peer has no spam record.
+	SpamPenalty(peerID peer.ID) (float64, bool)
 }
 
 // Subscription is the abstraction of the underlying pubsub subscription that is used by the Flow network.
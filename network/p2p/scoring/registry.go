@@ -250,6 +250,27 @@ func (r *GossipSubAppSpecificScoreRegistry) AppSpecificScoreFunc() func(peer.ID)
 	}
 }
 
+// SpamPenalty returns the current spam penalty of the given peer, i.e., the penalty applied to its application specific
+// score for its GossipSub control message misbehavior. The penalty is read from the spam record cache and hence reflects
+// the decay of the penalty up to now.
+// Args:
+// - pid: the peer ID of the peer in the GossipSub protocol.
+// Returns:
+// - float64: the spam penalty of the peer.
+// - bool: true if the peer has a spam record, false otherwise.
+func (r *GossipSubAppSpecificScoreRegistry) SpamPenalty(pid peer.ID) (float64, bool) {
+	spamRecord, err, spamRecordExists := r.spamScoreCache.Get(pid)
+	if err != nil {
+		// the error is considered fatal as it means the cache is not working properly.
+		r.logger.Fatal().Str("peer_id", p2plogging.PeerId(pid)).Err(err).Msg("could not get spam penalty for peer")
+		return 0, false // unreachable, but added to avoid proceeding with the execution if log level is changed.
+	}
+	if !spamRecordExists {
+		return 0, false
+	}
+	return spamRecord.Penalty, true
+}
+
 // computeAppSpecificScore computes the application specific score of a peer.
 // The application specific score is computed based on the spam penalty, staking score, and subscription penalty.
 // The spam penalty is the penalty applied to the application specific score when a peer conducts a spamming misbehaviour.
@@ -266,6 +266,17 @@ func (s *ScoreOption) TopicScoreParams(topic *pubsub.Topic) *pubsub.TopicScorePa
 	return params
 }
 
+// SpamPenalty returns the spam penalty of the given peer, i.e., the penalty applied to its application specific
+// score for its GossipSub control message misbehavior.
+// Args:
+// - peerID: the peer ID of the peer.
+// Returns:
+// - float64: the spam penalty of the peer.
+// - bool: true if the peer has a spam record, false otherwise.
+func (s *ScoreOption) SpamPenalty(peerID peer.ID) (float64, bool) {
+	return s.appScoreRegistry.SpamPenalty(peerID)
+}
+
 // OnInvalidControlMessageNotification is called when a new invalid control message notification is distributed.
 // Any error on consuming event must handle internally.
 // The implementation must be concurrency safe and non-blocking.
@@ -140,6 +140,31 @@ func (d *UnicastConfigCache) GetWithInit(peerID peer.ID) (*unicast.Config, error
 	}, nil
 }
 
+// Get returns the unicast config for the given peer id without initializing it.
+// Args:
+// - peerID: the peer id of the unicast config.
+// Returns:
+//   - *Config, a copy of the unicast config for the given peer id, nil if the config does not exist.
+//   - bool, true if the config exists, false otherwise.
+func (d *UnicastConfigCache) Get(peerID peer.ID) (*unicast.Config, bool) {
+	entity, ok := d.peerCache.ByID(entityIdOf(peerID))
+	if !ok {
+		return nil, false
+	}
+	cfg, ok := entity.(UnicastConfigEntity)
+	if !ok {
+		// sanity check
+		// This should never happen, because the cache only contains UnicastConfigEntity entities.
+		panic(fmt.Sprintf("invalid entity type, expected UnicastConfigEntity type, got: %T", entity))
+	}
+
+	// return a copy of the config (we do not want the caller to modify the config).
+	return &unicast.Config{
+		StreamCreationRetryAttemptBudget: cfg.StreamCreationRetryAttemptBudget,
+		ConsecutiveSuccessfulStream:      cfg.ConsecutiveSuccessfulStream,
+	}, true
+}
+
 // Size returns the number of unicast configs in the cache.
 func (d *UnicastConfigCache) Size() uint {
 	return d.peerCache.Size()
@@ -35,6 +35,35 @@ func unicastConfigFixture() unicast.Config {
 	}
 }
 
+// TestUnicastConfigCache_Get tests the Get method of the UnicastConfigCache. It asserts that Get does not initialize
+// the unicast config of an unknown peer, and returns a copy of the config of a known peer.
+func TestUnicastConfigCache_Get(t *testing.T) {
+	cache := unicastcache.NewUnicastConfigCache(uint32(100), zerolog.Nop(), metrics.NewNoopCollector(), unicastConfigFixture)
+	peerID := unittest.PeerIdFixture(t)
+
+	cfg, ok := cache.Get(peerID)
+	require.False(t, ok)
+	require.Nil(t, cfg)
+	require.Zerof(t, cache.Size(), "get must not initialize the unicast config")
+
+	_, err := cache.AdjustWithInit(peerID, func(cfg unicast.Config) (unicast.Config, error) {
+		cfg.ConsecutiveSuccessfulStream = 5
+		return cfg, nil
+	})
+	require.NoError(t, err)
+
+	cfg, ok = cache.Get(peerID)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), cfg.StreamCreationRetryAttemptBudget)
+	require.Equal(t, uint64(5), cfg.ConsecutiveSuccessfulStream)
+
+	// the returned config is a copy.
+	cfg.ConsecutiveSuccessfulStream = 0
+	cfg, ok = cache.Get(peerID)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), cfg.ConsecutiveSuccessfulStream)
+}
+
 // TestUnicastConfigCache_Adjust tests the Adjust method of the UnicastConfigCache. It asserts that the unicast config is initialized, adjusted,
 // and stored in the cache.
 func TestUnicastConfigCache_Adjust_Init(t *testing.T) {
@@ -16,6 +16,14 @@ type ConfigCache interface {
 	//   - error if the factory function returns an error. Any error should be treated as an irrecoverable error and indicates a bug.
 	GetWithInit(peerID peer.ID) (*Config, error)
 
+	// Get returns the dial config for the given peer id without initializing it.
+	// Args:
+	// - peerID: the peer id of the dial config.
+	// Returns:
+	//   - *Config, the dial config for the given peer id, nil if the config does not exist.
+	//   - bool, true if the config exists, false otherwise.
+	Get(peerID peer.ID) (*Config, bool)
+
 	// Adjust adjusts the dial config for the given peer id using the given adjustFunc.
 	// It returns an error if the adjustFunc returns an error.
 	// Args:
@@ -17,6 +17,7 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network/channels"
 	"github.com/onflow/flow-go/network/p2p"
 	p2plogging "github.com/onflow/flow-go/network/p2p/logging"
 	"github.com/onflow/flow-go/network/p2p/unicast/protocols"
@@ -44,6 +45,11 @@ type Manager struct {
 	sporkId        flow.Identifier
 	metrics        module.UnicastManagerMetrics
 
+	// protocolNames holds the names of the registered protocols, in the same order as protocols.
+	protocolNames []protocols.ProtocolName
+	// channelProtocols holds the protocol preferred for the streams of each channel, if configured.
+	channelProtocols map[channels.Channel]protocols.ProtocolName
+
 	// createStreamBackoffDelay is the delay between each stream creation retry attempt.
 	// The manager uses an exponential backoff strategy to retry stream creation, and this parameter
 	// is the initial delay between each retry attempt. The delay is doubled after each retry attempt.
@@ -81,6 +87,11 @@ func NewUnicastManager(cfg *ManagerConfig) (*Manager, error) {
 		return nil, fmt.Errorf("invalid unicast manager config: %w", err)
 	}
 
+	channelProtocols, err := protocols.ToChannelProtocols(cfg.Parameters.ChannelProtocols)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel unicast protocols: %w", err)
+	}
+
 	m := &Manager{
 		logger: cfg.Logger.With().Str("module", "unicast-manager").Logger(),
 		dialConfigCache: cfg.UnicastConfigCacheFactory(func() Config {
@@ -90,6 +101,7 @@ func NewUnicastManager(cfg *ManagerConfig) (*Manager, error) {
 		}),
 		streamFactory:                   cfg.StreamFactory,
 		sporkId:                         cfg.SporkId,
+		channelProtocols:                channelProtocols,
 		metrics:                         cfg.Metrics,
 		createStreamBackoffDelay:        cfg.Parameters.CreateStreamBackoffDelay,
 		streamZeroBackoffResetThreshold: cfg.Parameters.StreamZeroRetryResetThreshold,
@@ -118,6 +130,7 @@ func (m *Manager) SetDefaultHandler(defaultHandler libp2pnet.StreamHandler) {
 	m.protocols = []protocols.Protocol{
 		stream.NewPlainStream(defaultHandler, defaultProtocolID),
 	}
+	m.protocolNames = []protocols.ProtocolName{protocols.PlainUnicast}
 
 	m.streamFactory.SetStreamHandler(defaultProtocolID, defaultHandler)
 	m.logger.Info().Str("protocol_id", string(defaultProtocolID)).Msg("default unicast handler registered")
@@ -134,6 +147,7 @@ func (m *Manager) Register(protocol protocols.ProtocolName) error {
 	u := factory(m.logger, m.sporkId, m.defaultHandler)
 
 	m.protocols = append(m.protocols, u)
+	m.protocolNames = append(m.protocolNames, protocol)
 	m.streamFactory.SetStreamHandler(u.ProtocolId(), u.Handler)
 	m.logger.Info().Str("protocol_id", string(u.ProtocolId())).Msg("unicast handler registered")
 
@@ -141,7 +155,8 @@ func (m *Manager) Register(protocol protocols.ProtocolName) error {
 }
 
 // CreateStream tries establishing a libp2p stream to the remote peer id. It tries creating streams in the descending order of preference until
-// it either creates a successful stream or runs out of options.
+// it either creates a successful stream or runs out of options. If the context carries a channel (see protocols.ContextWithChannel) with a
+// preferred protocol, that protocol is tried first.
 // Args:
 //   - ctx: context for the stream creation.
 //   - peerID: peer ID of the remote peer.
@@ -168,7 +183,8 @@ func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID) (libp2pnet.S
 		Str("dial_config", fmt.Sprintf("%+v", dialCfg)).
 		Msg("dial config for the peer retrieved")
 
-	for i := len(m.protocols) - 1; i >= 0; i-- {
+	channel, hasChannel := protocols.ChannelFromContext(ctx)
+	for _, i := range m.protocolPreferenceOrder(channel, hasChannel) {
 		s, err := m.createStream(ctx, peerID, m.protocols[i], dialCfg)
 		if err != nil {
 			errs = multierror.Append(errs, err)
@@ -201,6 +217,50 @@ func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID) (libp2pnet.S
 	return nil, fmt.Errorf("could not create stream on any available unicast protocol: %w", errs)
 }
 
+// DialState returns the dial state of the given peer, and false if the manager has no dial state for the peer,
+// i.e., it has not dialed the peer or the dial state has been evicted from the dial config cache.
+// Args:
+//   - peerID: peer ID of the remote peer.
+//
+// Returns:
+//   - the dial state of the peer.
+//   - true if the dial state exists, false otherwise.
+func (m *Manager) DialState(peerID peer.ID) (*p2p.UnicastDialState, bool) {
+	dialCfg, ok := m.dialConfigCache.Get(peerID)
+	if !ok {
+		return nil, false
+	}
+	return &p2p.UnicastDialState{
+		StreamCreationRetryAttemptBudget: dialCfg.StreamCreationRetryAttemptBudget,
+		ConsecutiveSuccessfulStream:      dialCfg.ConsecutiveSuccessfulStream,
+	}, true
+}
+
+// protocolPreferenceOrder returns the indices of the registered protocols in descending order of preference for streams of the given
+// channel. The preferred protocol of the channel comes first if it is registered, followed by the remaining protocols in descending
+// order of registration.
+func (m *Manager) protocolPreferenceOrder(channel channels.Channel, hasChannel bool) []int {
+	order := make([]int, 0, len(m.protocols))
+
+	preferred := -1
+	if name, ok := m.channelProtocols[channel]; ok && hasChannel {
+		for i, registered := range m.protocolNames {
+			if registered == name {
+				preferred = i
+				order = append(order, i)
+				break
+			}
+		}
+	}
+
+	for i := len(m.protocols) - 1; i >= 0; i-- {
+		if i != preferred {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
 // createStream attempts to establish a new stream with a peer using the specified protocol. It employs
 // exponential backoff with a maximum number of attempts defined by dialCfg.StreamCreationRetryAttemptBudget.
 // If the stream cannot be established after the maximum attempts, it returns a compiled multierror of all
@@ -212,7 +272,8 @@ func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID) (libp2pnet.S
 // Metrics are collected to monitor the duration and number of attempts for stream creation.
 //
 // Arguments:
-// - ctx: Context to control the lifecycle of the stream creation.
+// - ctx: Context to control the lifecycle of the stream creation. If it carries a channel, compressed writes on the stream are reported
+// to the metrics of the channel.
 // - peerID: The ID of the peer with which the stream is to be established.
 // - protocol: The specific protocol used for the stream.
 // - dialCfg: Configuration parameters for dialing and stream creation, including retry logic.
@@ -229,7 +290,14 @@ func (m *Manager) createStream(ctx context.Context, peerID peer.ID, protocol pro
 		return nil, fmt.Errorf("failed to create a stream to peer: %w", err)
 	}
 
-	s, err = protocol.UpgradeRawStream(s)
+	channel, hasChannel := protocols.ChannelFromContext(ctx)
+	if compressed, ok := protocol.(protocols.CompressedProtocol); ok && hasChannel {
+		s, err = compressed.UpgradeRawStreamWithObserver(s, func(uncompressedBytes int, compressedBytes int, duration time.Duration) {
+			m.metrics.OnStreamCompressed(channel.String(), string(compressed.Name()), uncompressedBytes, compressedBytes, duration)
+		})
+	} else {
+		s, err = protocol.UpgradeRawStream(s)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to upgrade raw stream: %w", err)
 	}
@@ -1,8 +1,10 @@
 package unicast_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"testing"
 
 	libp2pnet "github.com/libp2p/go-libp2p/core/network"
@@ -13,10 +15,13 @@ import (
 
 	"github.com/onflow/flow-go/config"
 	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/channels"
 	mockp2p "github.com/onflow/flow-go/network/p2p/mock"
 	p2ptest "github.com/onflow/flow-go/network/p2p/test"
 	"github.com/onflow/flow-go/network/p2p/unicast"
 	unicastcache "github.com/onflow/flow-go/network/p2p/unicast/cache"
+	"github.com/onflow/flow-go/network/p2p/unicast/protocols"
 	"github.com/onflow/flow-go/network/p2p/unicast/stream"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -114,6 +119,26 @@ func TestManagerConfigValidation(t *testing.T) {
 		require.Nil(t, mgr)
 	})
 
+	t.Run("Invalid Channel Protocol", func(t *testing.T) {
+		cfg := validConfig
+		parameters := *cfg.Parameters
+		parameters.ChannelProtocols = []string{channels.RequestChunks.String() + "=unknown-compression"}
+		cfg.Parameters = &parameters
+		mgr, err := unicast.NewUnicastManager(&cfg)
+		require.Error(t, err)
+		require.Nil(t, mgr)
+	})
+
+	t.Run("Malformed Channel Protocol", func(t *testing.T) {
+		cfg := validConfig
+		parameters := *cfg.Parameters
+		parameters.ChannelProtocols = []string{string(protocols.GzipCompressionUnicast)}
+		cfg.Parameters = &parameters
+		mgr, err := unicast.NewUnicastManager(&cfg)
+		require.Error(t, err)
+		require.Nil(t, mgr)
+	})
+
 	t.Run("Missing Metrics", func(t *testing.T) {
 		cfg := validConfig
 		cfg.Metrics = nil
@@ -504,3 +529,180 @@ func TestUnicastManager_Stream_NoBackoff_When_Budget_Is_Zero(t *testing.T) {
 	require.Equal(t, uint64(0), unicastCfg.StreamCreationRetryAttemptBudget) // stream backoff budget must remain zero.
 	require.Equal(t, uint64(0), unicastCfg.ConsecutiveSuccessfulStream)      // consecutive successful stream must be set to zero.
 }
+
+// TestUnicastManager_ChannelPreferredProtocol tests that streams of a channel with a preferred protocol are first created on the preferred
+// protocol, and fall back to the other protocols in descending order of preference if the remote peer does not support it. Streams without
+// channel, or of channels without preferred protocol, are created on the most preferred protocol.
+func TestUnicastManager_ChannelPreferredProtocol(t *testing.T) {
+	peerID := unittest.PeerIdFixture(t)
+	sporkID := unittest.IdentifierFixture()
+
+	cfg, err := config.DefaultConfig()
+	require.NoError(t, err)
+	parameters := cfg.NetworkConfig.Unicast.UnicastManager
+	parameters.ChannelProtocols = []string{
+		channels.RequestChunks.String() + "=" + string(protocols.Lz4CompressionUnicast),
+		channels.SyncCommittee.String() + "=" + string(protocols.PlainUnicast),
+		channels.ProvideReceiptsByBlockID.String() + "=" + string(protocols.GzipCompressionUnicast),
+	}
+
+	streamFactory := mockp2p.NewStreamFactory(t)
+	streamFactory.On("SetStreamHandler", mock.AnythingOfType("protocol.ID"), mock.Anything).Return().Times(4)
+
+	mgr, err := unicast.NewUnicastManager(&unicast.ManagerConfig{
+		Logger:        unittest.Logger(),
+		StreamFactory: streamFactory,
+		SporkId:       sporkID,
+		Metrics:       metrics.NewNoopCollector(),
+		Parameters:    &parameters,
+		UnicastConfigCacheFactory: func(configFactory func() unicast.Config) unicast.ConfigCache {
+			return unicastcache.NewUnicastConfigCache(parameters.ConfigCacheSize, unittest.Logger(), metrics.NewNoopCollector(), configFactory)
+		},
+	})
+	require.NoError(t, err)
+	mgr.SetDefaultHandler(func(libp2pnet.Stream) {})
+	// ascending order of preference
+	require.NoError(t, mgr.Register(protocols.GzipCompressionUnicast))
+	require.NoError(t, mgr.Register(protocols.Lz4CompressionUnicast))
+	require.NoError(t, mgr.Register(protocols.ZstdCompressionUnicast))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("without channel", func(t *testing.T) {
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowZstdProtocolId(sporkID)).Return(&p2ptest.MockStream{}, nil).Once()
+
+		s, err := mgr.CreateStream(ctx, peerID)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("channel without preferred protocol", func(t *testing.T) {
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowZstdProtocolId(sporkID)).Return(&p2ptest.MockStream{}, nil).Once()
+
+		s, err := mgr.CreateStream(protocols.ContextWithChannel(ctx, channels.PushBlocks), peerID)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("channel with preferred protocol", func(t *testing.T) {
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowLz4ProtocolId(sporkID)).Return(&p2ptest.MockStream{}, nil).Once()
+
+		s, err := mgr.CreateStream(protocols.ContextWithChannel(ctx, channels.RequestChunks), peerID)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("channel preferring plain streams", func(t *testing.T) {
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowProtocolID(sporkID)).Return(&p2ptest.MockStream{}, nil).Once()
+
+		s, err := mgr.CreateStream(protocols.ContextWithChannel(ctx, channels.SyncCommittee), peerID)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("preferred protocol not supported by remote peer", func(t *testing.T) {
+		// the remote peer only supports gzip and plain streams
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowLz4ProtocolId(sporkID)).
+			Return(nil, stream.NewProtocolNotSupportedErr(peerID, protocols.FlowLz4ProtocolId(sporkID), fmt.Errorf("not supported"))).
+			Once()
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowZstdProtocolId(sporkID)).
+			Return(nil, stream.NewProtocolNotSupportedErr(peerID, protocols.FlowZstdProtocolId(sporkID), fmt.Errorf("not supported"))).
+			Once()
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowGzipProtocolId(sporkID)).Return(&p2ptest.MockStream{}, nil).Once()
+
+		s, err := mgr.CreateStream(protocols.ContextWithChannel(ctx, channels.RequestChunks), peerID)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("preferred protocol not registered", func(t *testing.T) {
+		parameters := parameters
+		parameters.ChannelProtocols = []string{channels.RequestChunks.String() + "=" + string(protocols.Lz4CompressionUnicast)}
+		streamFactory := mockp2p.NewStreamFactory(t)
+		streamFactory.On("SetStreamHandler", mock.AnythingOfType("protocol.ID"), mock.Anything).Return().Twice()
+
+		mgr, err := unicast.NewUnicastManager(&unicast.ManagerConfig{
+			Logger:        unittest.Logger(),
+			StreamFactory: streamFactory,
+			SporkId:       sporkID,
+			Metrics:       metrics.NewNoopCollector(),
+			Parameters:    &parameters,
+			UnicastConfigCacheFactory: func(configFactory func() unicast.Config) unicast.ConfigCache {
+				return unicastcache.NewUnicastConfigCache(parameters.ConfigCacheSize, unittest.Logger(), metrics.NewNoopCollector(), configFactory)
+			},
+		})
+		require.NoError(t, err)
+		mgr.SetDefaultHandler(func(libp2pnet.Stream) {})
+		require.NoError(t, mgr.Register(protocols.ZstdCompressionUnicast))
+
+		streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowZstdProtocolId(sporkID)).Return(&p2ptest.MockStream{}, nil).Once()
+
+		s, err := mgr.CreateStream(protocols.ContextWithChannel(ctx, channels.RequestChunks), peerID)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+}
+
+// TestUnicastManager_CompressionMetrics tests that writes on compressed streams of a channel are reported to the compression metrics
+// of the channel, and that streams without channel are not reported.
+func TestUnicastManager_CompressionMetrics(t *testing.T) {
+	peerID := unittest.PeerIdFixture(t)
+	sporkID := unittest.IdentifierFixture()
+
+	cfg, err := config.DefaultConfig()
+	require.NoError(t, err)
+
+	streamFactory := mockp2p.NewStreamFactory(t)
+	streamFactory.On("SetStreamHandler", mock.AnythingOfType("protocol.ID"), mock.Anything).Return().Twice()
+
+	collector := mockmodule.NewUnicastManagerMetrics(t)
+	collector.On("OnStreamEstablished", mock.Anything, mock.Anything).Return()
+
+	mgr, err := unicast.NewUnicastManager(&unicast.ManagerConfig{
+		Logger:        unittest.Logger(),
+		StreamFactory: streamFactory,
+		SporkId:       sporkID,
+		Metrics:       collector,
+		Parameters:    &cfg.NetworkConfig.Unicast.UnicastManager,
+		UnicastConfigCacheFactory: func(configFactory func() unicast.Config) unicast.ConfigCache {
+			return unicastcache.NewUnicastConfigCache(cfg.NetworkConfig.Unicast.UnicastManager.ConfigCacheSize, unittest.Logger(), metrics.NewNoopCollector(), configFactory)
+		},
+	})
+	require.NoError(t, err)
+	mgr.SetDefaultHandler(func(libp2pnet.Stream) {})
+	require.NoError(t, mgr.Register(protocols.ZstdCompressionUnicast))
+
+	ra, wa := io.Pipe()
+	defer ra.Close()
+	go func() {
+		_, _ = io.Copy(io.Discard, ra)
+	}()
+	streamFactory.On("NewStream", mock.Anything, peerID, protocols.FlowZstdProtocolId(sporkID)).Return(p2ptest.NewMockStream(wa, nil), nil).Twice()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	payload := bytes.Repeat([]byte("hello world, "), 1000)
+
+	// writes on streams of a channel are reported
+	collector.On("OnStreamCompressed", channels.RequestChunks.String(), string(protocols.ZstdCompressionUnicast), len(payload), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			compressedBytes := args.Int(3)
+			require.Greater(t, compressedBytes, 0)
+			require.Less(t, compressedBytes, len(payload))
+		}).
+		Return().
+		Once()
+
+	s, err := mgr.CreateStream(protocols.ContextWithChannel(ctx, channels.RequestChunks), peerID)
+	require.NoError(t, err)
+	_, err = s.Write(payload)
+	require.NoError(t, err)
+
+	// writes on streams without channel are not reported
+	s, err = mgr.CreateStream(ctx, peerID)
+	require.NoError(t, err)
+	_, err = s.Write(payload)
+	require.NoError(t, err)
+}
@@ -0,0 +1,22 @@
+package protocols
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/network/channels"
+)
+
+type channelContextKey struct{}
+
+// ContextWithChannel returns a copy of the context, which carries the channel of the unicast message to be sent
+// on the streams created with the context. The unicast manager uses it to pick the preferred protocol of the
+// channel, and to report compression metrics per channel.
+func ContextWithChannel(ctx context.Context, channel channels.Channel) context.Context {
+	return context.WithValue(ctx, channelContextKey{}, channel)
+}
+
+// ChannelFromContext returns the channel carried by the context, and false if the context carries no channel.
+func ChannelFromContext(ctx context.Context) (channels.Channel, bool) {
+	channel, ok := ctx.Value(channelContextKey{}).(channels.Channel)
+	return channel, ok
+}
@@ -0,0 +1,99 @@
+package protocols
+
+import (
+	libp2pnet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/compressor"
+	"github.com/onflow/flow-go/network/p2p/unicast/protocols/internal"
+)
+
+const (
+	GzipCompressionUnicast = ProtocolName("gzip-compression")
+	Lz4CompressionUnicast  = ProtocolName("lz4-compression")
+	ZstdCompressionUnicast = ProtocolName("zstd-compression")
+)
+
+func FlowGzipProtocolId(sporkId flow.Identifier) protocol.ID {
+	return protocol.ID(FlowLibP2PProtocolGzipCompressedOneToOne + sporkId.String())
+}
+
+func FlowLz4ProtocolId(sporkId flow.Identifier) protocol.ID {
+	return protocol.ID(FlowLibP2PProtocolLz4CompressedOneToOne + sporkId.String())
+}
+
+func FlowZstdProtocolId(sporkId flow.Identifier) protocol.ID {
+	return protocol.ID(FlowLibP2PProtocolZstdCompressedOneToOne + sporkId.String())
+}
+
+// CompressedUnicast is a unicast protocol which creates and returns a compressed stream out of input stream.
+type CompressedUnicast struct {
+	name           ProtocolName
+	protocolId     protocol.ID
+	compressor     network.Compressor
+	defaultHandler libp2pnet.StreamHandler
+	logger         zerolog.Logger
+}
+
+var _ CompressedProtocol = (*CompressedUnicast)(nil)
+
+func NewGzipCompressedUnicast(logger zerolog.Logger, sporkId flow.Identifier, defaultHandler libp2pnet.StreamHandler) *CompressedUnicast {
+	return newCompressedUnicast(logger, GzipCompressionUnicast, FlowGzipProtocolId(sporkId), compressor.GzipStreamCompressor{}, defaultHandler)
+}
+
+func NewLz4CompressedUnicast(logger zerolog.Logger, sporkId flow.Identifier, defaultHandler libp2pnet.StreamHandler) *CompressedUnicast {
+	return newCompressedUnicast(logger, Lz4CompressionUnicast, FlowLz4ProtocolId(sporkId), compressor.NewLz4Compressor(), defaultHandler)
+}
+
+func NewZstdCompressedUnicast(logger zerolog.Logger, sporkId flow.Identifier, defaultHandler libp2pnet.StreamHandler) *CompressedUnicast {
+	return newCompressedUnicast(logger, ZstdCompressionUnicast, FlowZstdProtocolId(sporkId), compressor.NewZstdCompressor(), defaultHandler)
+}
+
+func newCompressedUnicast(
+	logger zerolog.Logger,
+	name ProtocolName,
+	protocolId protocol.ID,
+	compressor network.Compressor,
+	defaultHandler libp2pnet.StreamHandler,
+) *CompressedUnicast {
+	return &CompressedUnicast{
+		name:           name,
+		protocolId:     protocolId,
+		compressor:     compressor,
+		defaultHandler: defaultHandler,
+		logger:         logger.With().Str("subsystem", string(name)+"-unicast").Logger(),
+	}
+}
+
+// UpgradeRawStream wraps compression and decompression around the plain libp2p stream.
+func (c CompressedUnicast) UpgradeRawStream(s libp2pnet.Stream) (libp2pnet.Stream, error) {
+	return internal.NewCompressedStream(s, c.compressor)
+}
+
+// UpgradeRawStreamWithObserver wraps compression and decompression around the plain libp2p stream, and notifies
+// the observer about each compressed write on the stream.
+func (c CompressedUnicast) UpgradeRawStreamWithObserver(s libp2pnet.Stream, observer CompressionObserver) (libp2pnet.Stream, error) {
+	return internal.NewObservedCompressedStream(s, c.compressor, observer)
+}
+
+func (c CompressedUnicast) Handler(s libp2pnet.Stream) {
+	// converts native libp2p stream to compressed stream
+	s, err := c.UpgradeRawStream(s)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("could not create compressed stream")
+		return
+	}
+	c.defaultHandler(s)
+}
+
+func (c CompressedUnicast) ProtocolId() protocol.ID {
+	return c.protocolId
+}
+
+// Name returns the name of the compression protocol, e.g., GzipCompressionUnicast.
+func (c CompressedUnicast) Name() ProtocolName {
+	return c.name
+}
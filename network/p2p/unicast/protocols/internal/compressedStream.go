@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"go.uber.org/multierr"
@@ -23,16 +24,34 @@ type CompressedStream struct {
 
 	r io.ReadCloser
 	w flownet.WriteCloseFlusher
+
+	// cw counts the compressed bytes the compressor writes to the underlying stream, and the time spent writing them.
+	cw *countingWriter
+	// observer (optional) is notified about the uncompressed and compressed sizes, and the compression
+	// time of each write.
+	observer func(uncompressedBytes int, compressedBytes int, duration time.Duration)
 }
 
 // NewCompressedStream creates a compressed stream with gzip as default compressor.
 func NewCompressedStream(s network.Stream, compressor flownet.Compressor) (*CompressedStream, error) {
+	return NewObservedCompressedStream(s, compressor, nil)
+}
+
+// NewObservedCompressedStream creates a compressed stream, which notifies the observer about the uncompressed and
+// compressed sizes, and the compression time of each write. A nil observer is ignored.
+func NewObservedCompressedStream(
+	s network.Stream,
+	compressor flownet.Compressor,
+	observer func(uncompressedBytes int, compressedBytes int, duration time.Duration),
+) (*CompressedStream, error) {
 	c := &CompressedStream{
 		Stream:     s,
 		compressor: compressor,
+		cw:         &countingWriter{w: s},
+		observer:   observer,
 	}
 
-	w, err := c.compressor.NewWriter(s)
+	w, err := c.compressor.NewWriter(c.cw)
 	if err != nil {
 		return nil, fmt.Errorf("could not create compressor writer: %w", err)
 	}
@@ -46,9 +65,17 @@ func (c *CompressedStream) Write(b []byte) (int, error) {
 	c.writeLock.Lock()
 	defer c.writeLock.Unlock()
 
+	c.cw.n = 0
+	c.cw.d = 0
+	start := time.Now()
 	n, err := c.w.Write(b)
+	err = multierr.Combine(err, c.w.Flush())
+	if c.observer != nil {
+		// the time spent writing the compressed data to the underlying stream is not part of the compression time
+		c.observer(n, c.cw.n, time.Since(start)-c.cw.d)
+	}
 
-	return n, multierr.Combine(err, c.w.Flush())
+	return n, err
 }
 
 func (c *CompressedStream) Read(b []byte) (int, error) {
@@ -77,3 +104,19 @@ func (c *CompressedStream) Close() error {
 
 	return multierr.Combine(c.w.Close(), c.Stream.Close())
 }
+
+// countingWriter counts the bytes written to the wrapped writer, and the time spent writing them.
+// Not concurrency safe, it is only used with the write lock of the stream held.
+type countingWriter struct {
+	w io.Writer
+	n int
+	d time.Duration
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	start := time.Now()
+	n, err := c.w.Write(b)
+	c.d += time.Since(start)
+	c.n += n
+	return n, err
+}
@@ -1,27 +1,45 @@
 package internal_test
 
 import (
+	"bytes"
 	"io"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/stretchr/testify/require"
 
+	flownet "github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/compressor"
 	p2ptest "github.com/onflow/flow-go/network/p2p/test"
 	"github.com/onflow/flow-go/network/p2p/unicast/protocols/internal"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
+// compressors are all compressors supported by the unicast protocols.
+var compressors = map[string]flownet.Compressor{
+	"gzip": compressor.GzipStreamCompressor{},
+	"lz4":  compressor.NewLz4Compressor(),
+	"zstd": compressor.NewZstdCompressor(),
+}
+
 // TestHappyPath evaluates reading from a compressed stream retrieves what originally has been written on it.
 func TestHappyPath(t *testing.T) {
+	for name, comp := range compressors {
+		t.Run(name, func(t *testing.T) {
+			testHappyPath(t, comp)
+		})
+	}
+}
+
+func testHappyPath(t *testing.T, comp flownet.Compressor) {
 	text := "hello world, hello world!"
 	textByte := []byte(text)
 	textByteLen := len(textByte)
 
 	// creates a pair of compressed streams
-	mca, _, mcb, _ := newCompressedStreamPair(t)
+	mca, _, mcb, _ := newCompressedStreamPair(t, comp)
 
 	// writes on stream mca
 	writeWG := sync.WaitGroup{}
@@ -56,13 +74,21 @@ func TestHappyPath(t *testing.T) {
 // TestUnhappyPath evaluates that sending uncompressed data to the compressed end of a stream results
 // in an error at the reader side.
 func TestUnhappyPath(t *testing.T) {
+	for name, comp := range compressors {
+		t.Run(name, func(t *testing.T) {
+			testUnhappyPath(t, comp)
+		})
+	}
+}
+
+func testUnhappyPath(t *testing.T, comp flownet.Compressor) {
 	text := "hello world, hello world!"
 	textByte := []byte(text)
 	textByteLen := len(textByte)
 
 	// sa is the underlying stream of sender (non-compressed)
 	// mcb is the compressed stream of receiver
-	_, sa, mcb, _ := newCompressedStreamPair(t)
+	_, sa, mcb, sb := newCompressedStreamPair(t, comp)
 
 	// writes on sa (uncompressed)
 	writeWG := sync.WaitGroup{}
@@ -92,12 +118,80 @@ func TestUnhappyPath(t *testing.T) {
 		// b on reader side.
 		require.Equal(t, n, 0)
 		require.Equal(t, b, make([]byte, textByteLen))
+
+		// some compressors reject the data after reading only its header, hence the rest of the data is
+		// drained to unblock the writer.
+		_, _ = io.Copy(io.Discard, sb)
 	}()
 
 	unittest.RequireReturnsBefore(t, writeWG.Wait, 1*time.Second, "timeout for writing on stream")
+	require.NoError(t, sa.CloseWrite())
 	unittest.RequireReturnsBefore(t, readWG.Wait, 1*time.Second, "timeout for reading from stream")
 }
 
+// TestObservedCompressedStream evaluates that the observer of a compressed stream is notified about the uncompressed and
+// compressed sizes of each write, and that the written data is read back on the other end.
+func TestObservedCompressedStream(t *testing.T) {
+	textByte := bytes.Repeat([]byte("hello world, "), 1000)
+	textByteLen := len(textByte)
+
+	sa, sb := newStreamPair()
+
+	// writing to the underlying stream is slow, which must not be observed as compression time
+	writeDelay := 200 * time.Millisecond
+	var uncompressed, compressed int
+	var compressionTime time.Duration
+	mca, err := internal.NewObservedCompressedStream(&slowStream{Stream: sa, delay: writeDelay}, compressor.NewZstdCompressor(), func(uncompressedBytes int, compressedBytes int, duration time.Duration) {
+		uncompressed += uncompressedBytes
+		compressed += compressedBytes
+		compressionTime += duration
+	})
+	require.NoError(t, err)
+
+	mcb, err := internal.NewCompressedStream(sb, compressor.NewZstdCompressor())
+	require.NoError(t, err)
+
+	writeWG := sync.WaitGroup{}
+	writeWG.Add(1)
+	go func() {
+		defer writeWG.Done()
+
+		n, err := mca.Write(textByte)
+		require.NoError(t, err)
+		require.Equal(t, textByteLen, n)
+	}()
+
+	readWG := sync.WaitGroup{}
+	readWG.Add(1)
+	go func() {
+		defer readWG.Done()
+
+		b := make([]byte, textByteLen)
+		_, err := io.ReadFull(mcb, b)
+		require.NoError(t, err)
+		require.Equal(t, textByte, b)
+	}()
+
+	unittest.RequireReturnsBefore(t, writeWG.Wait, 5*time.Second, "timeout for writing on stream")
+	unittest.RequireReturnsBefore(t, readWG.Wait, 5*time.Second, "timeout for reading from stream")
+
+	require.Equal(t, textByteLen, uncompressed)
+	require.Greater(t, compressed, 0)
+	require.Less(t, compressed, textByteLen)
+	require.Less(t, compressionTime, writeDelay)
+}
+
+// slowStream is a stream which delays each write by the given delay.
+type slowStream struct {
+	network.Stream
+	delay time.Duration
+}
+
+func (s *slowStream) Write(b []byte) (int, error) {
+	time.Sleep(s.delay)
+	return s.Stream.Write(b)
+}
+
 // newStreamPair is a test helper that creates a pair of compressed streams a and b such that
 // a reads what b writes and b reads what a writes.
 func newStreamPair() (*p2ptest.MockStream, *p2ptest.MockStream) {
@@ -112,13 +206,13 @@ func newStreamPair() (*p2ptest.MockStream, *p2ptest.MockStream) {
 
 // newCompressedStreamPair is a test helper that creates a pair of compressed streams a and b such that
 // a reads what b writes and b reads what a writes.
-func newCompressedStreamPair(t *testing.T) (*internal.CompressedStream, *p2ptest.MockStream, *internal.CompressedStream, *p2ptest.MockStream) {
+func newCompressedStreamPair(t *testing.T, comp flownet.Compressor) (*internal.CompressedStream, *p2ptest.MockStream, *internal.CompressedStream, *p2ptest.MockStream) {
 	sa, sb := newStreamPair()
 
-	mca, err := internal.NewCompressedStream(sa, compressor.GzipStreamCompressor{})
+	mca, err := internal.NewCompressedStream(sa, comp)
 	require.NoError(t, err)
 
-	mcb, err := internal.NewCompressedStream(sb, compressor.GzipStreamCompressor{})
+	mcb, err := internal.NewCompressedStream(sb, comp)
 	require.NoError(t, err)
 
 	return mca, sa, mcb, sb
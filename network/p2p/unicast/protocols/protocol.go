@@ -2,13 +2,16 @@ package protocols
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	libp2pnet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network/channels"
 )
 
 // Flow Libp2p protocols
@@ -28,8 +31,18 @@ const (
 
 	// FlowLibP2PProtocolGzipCompressedOneToOne represents the protocol id for compressed streams under gzip compressor.
 	FlowLibP2PProtocolGzipCompressedOneToOne = FlowLibP2POneToOneProtocolIDPrefix + "/gzip/"
+
+	// FlowLibP2PProtocolLz4CompressedOneToOne represents the protocol id for compressed streams under lz4 compressor.
+	FlowLibP2PProtocolLz4CompressedOneToOne = FlowLibP2POneToOneProtocolIDPrefix + "/lz4/"
+
+	// FlowLibP2PProtocolZstdCompressedOneToOne represents the protocol id for compressed streams under zstd compressor.
+	FlowLibP2PProtocolZstdCompressedOneToOne = FlowLibP2POneToOneProtocolIDPrefix + "/zstd/"
 )
 
+// PlainUnicast is the name of the default unicast protocol, which does not compress streams. It is always
+// supported, hence it is not registered, but it can be preferred for a channel.
+const PlainUnicast = ProtocolName("plain")
+
 // IsFlowProtocolStream returns true if the libp2p stream is for a Flow protocol
 func IsFlowProtocolStream(s libp2pnet.Stream) bool {
 	p := string(s.Protocol())
@@ -63,12 +76,61 @@ func ToProtocolNames(names []string) []ProtocolName {
 	return p
 }
 
+// ToChannelProtocols parses the preferred protocols of channels, given as <channel>=<protocol> entries.
+// The protocol must either be a known unicast protocol, or PlainUnicast. Whether the protocols are registered, i.e.,
+// preferred unicast protocols, is checked by ValidateChannelProtocols as part of the config validation.
+// All errors returned from this function can be considered benign.
+func ToChannelProtocols(entries []string) (map[channels.Channel]ProtocolName, error) {
+	channelProtocols := make(map[channels.Channel]ProtocolName, len(entries))
+	for _, entry := range entries {
+		channel, name, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || len(channel) == 0 {
+			return nil, fmt.Errorf("invalid channel protocol entry %q, expected <channel>=<protocol>", entry)
+		}
+
+		protocol := ProtocolName(name)
+		if protocol != PlainUnicast {
+			if _, err := ToProtocolFactory(protocol); err != nil {
+				return nil, fmt.Errorf("invalid unicast protocol for channel %s: %w", channel, err)
+			}
+		}
+		channelProtocols[channels.Channel(channel)] = protocol
+	}
+	return channelProtocols, nil
+}
+
+// ValidateChannelProtocols checks the preferred protocols of channels, given as <channel>=<protocol> entries, against the
+// preferred unicast protocols of the node. The protocol of each channel must either be one of the preferred unicast
+// protocols, which are the only compression protocols registered with the unicast manager, or PlainUnicast.
+// All errors returned from this function can be considered benign.
+func ValidateChannelProtocols(entries []string, preferred []ProtocolName) error {
+	channelProtocols, err := ToChannelProtocols(entries)
+	if err != nil {
+		return err
+	}
+
+	for channel, protocol := range channelProtocols {
+		if protocol != PlainUnicast && !slices.Contains(preferred, protocol) {
+			return fmt.Errorf("unicast protocol %s for channel %s is not one of the preferred unicast protocols %v", protocol, channel, preferred)
+		}
+	}
+	return nil
+}
+
 func ToProtocolFactory(name ProtocolName) (ProtocolFactory, error) {
 	switch name {
 	case GzipCompressionUnicast:
 		return func(logger zerolog.Logger, sporkId flow.Identifier, handler libp2pnet.StreamHandler) Protocol {
 			return NewGzipCompressedUnicast(logger, sporkId, handler)
 		}, nil
+	case Lz4CompressionUnicast:
+		return func(logger zerolog.Logger, sporkId flow.Identifier, handler libp2pnet.StreamHandler) Protocol {
+			return NewLz4CompressedUnicast(logger, sporkId, handler)
+		}, nil
+	case ZstdCompressionUnicast:
+		return func(logger zerolog.Logger, sporkId flow.Identifier, handler libp2pnet.StreamHandler) Protocol {
+			return NewZstdCompressedUnicast(logger, sporkId, handler)
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown unicast protocol name: %s", name)
 	}
@@ -86,3 +148,17 @@ type Protocol interface {
 	// streams running with the same protocol are identified with the same  protocol id.
 	ProtocolId() protocol.ID
 }
+
+// CompressionObserver is notified about each compressed write on a stream, with the number of bytes before and
+// after compression, and the time spent compressing.
+type CompressionObserver func(uncompressedBytes int, compressedBytes int, duration time.Duration)
+
+// CompressedProtocol represents a unicast protocol, which compresses the data written to its streams.
+type CompressedProtocol interface {
+	Protocol
+	// UpgradeRawStreamWithObserver wraps the compression around the plain libp2p stream, and notifies the
+	// observer about each compressed write on the stream.
+	UpgradeRawStreamWithObserver(s libp2pnet.Stream, observer CompressionObserver) (libp2pnet.Stream, error)
+	// Name returns the name of the compression protocol.
+	Name() ProtocolName
+}
@@ -20,7 +20,11 @@ type UnicastManager interface {
 	Register(unicast protocols.ProtocolName) error
 	// CreateStream tries establishing a libp2p stream to the remote peer id. It tries creating streams in the descending order of preference until
 	// it either creates a successful stream or runs out of options. Creating stream on each protocol is tried at most `maxAttempts`, and then falls
-	// back to the less preferred one.
+	// back to the less preferred one. If the context carries a channel (see protocols.ContextWithChannel), the preferred protocol of the channel
+	// is tried first, and compressed writes on the stream are reported to the metrics of the channel.
 	// All errors returned from this function can be considered benign.
 	CreateStream(ctx context.Context, peerID peer.ID) (libp2pnet.Stream, error)
+	// DialState returns the dial state of the given peer, and false if the manager has no dial state for the peer,
+	// i.e., it has not dialed the peer or the dial state has been evicted.
+	DialState(peerID peer.ID) (*UnicastDialState, bool)
 }
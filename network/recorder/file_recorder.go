@@ -0,0 +1,277 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+const (
+	// logFilePrefix and logFileSuffix frame the sequence number in the names of the log files, e.g., messages-00000000000000000001.jsonl.
+	logFilePrefix = "messages-"
+	logFileSuffix = ".jsonl"
+
+	// flushInterval is the interval at which buffered records are flushed to the current log file.
+	flushInterval = time.Second
+)
+
+// FileRecorderConfig is the configuration of the FileRecorder.
+type FileRecorderConfig struct {
+	// Dir is the directory of the log files, it is created if it does not exist.
+	Dir string
+	// MaxFileSize is the size in bytes a log file grows to before the recorder rotates to a new file.
+	MaxFileSize uint64
+	// MaxFiles is the number of log files kept in the directory, the oldest files are removed on rotation.
+	MaxFiles uint32
+	// QueueSize is the number of records buffered for writing, records are dropped when the queue is full.
+	QueueSize uint32
+}
+
+// FileRecorder is a MessageRecorder that writes the records as newline delimited JSON to a rotating set of log
+// files in a directory. Records are queued by Record and written by a single worker, so that recording never
+// blocks the network; when the queue is full, records are dropped and the number of dropped records is logged.
+// The log files are named by an increasing sequence number, hence reading them in lexicographic order yields the
+// records in the order they are recorded.
+type FileRecorder struct {
+	component.Component
+	logger      zerolog.Logger
+	dir         string
+	maxFileSize uint64
+	maxFiles    int
+	records     chan *Record
+	dropped     *atomic.Uint64
+
+	// the following fields are only accessed by the worker.
+	seq     uint64        // sequence number of the current log file
+	file    *os.File      // current log file, nil if there is no open log file
+	writer  *bufio.Writer // buffered writer of the current log file
+	written uint64        // number of bytes written to the current log file
+}
+
+var _ MessageRecorder = (*FileRecorder)(nil)
+var _ component.Component = (*FileRecorder)(nil)
+
+// NewFileRecorder creates a FileRecorder writing to the directory of the given config. The recorder continues the
+// sequence of log files already present in the directory, e.g., from before a restart.
+// No errors are expected during normal operation.
+func NewFileRecorder(logger zerolog.Logger, cfg FileRecorderConfig) (*FileRecorder, error) {
+	if cfg.MaxFileSize == 0 || cfg.MaxFiles == 0 || cfg.QueueSize == 0 {
+		return nil, fmt.Errorf("invalid file recorder config, max file size, max files and queue size must be positive: %+v", cfg)
+	}
+
+	err := os.MkdirAll(cfg.Dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create message recorder directory %s: %w", cfg.Dir, err)
+	}
+
+	files, err := LogFiles(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not list existing log files: %w", err)
+	}
+	seq := uint64(0)
+	if len(files) > 0 {
+		seq, err = logFileSeq(files[len(files)-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r := &FileRecorder{
+		logger:      logger.With().Str("component", "message-recorder").Str("dir", cfg.Dir).Logger(),
+		dir:         cfg.Dir,
+		maxFileSize: cfg.MaxFileSize,
+		maxFiles:    int(cfg.MaxFiles),
+		records:     make(chan *Record, cfg.QueueSize),
+		dropped:     atomic.NewUint64(0),
+		seq:         seq,
+	}
+
+	r.Component = component.NewComponentManagerBuilder().
+		AddWorker(r.writeLoop).
+		Build()
+
+	return r, nil
+}
+
+// Record queues the record for writing. It is non-blocking, and drops the record if the queue is full.
+func (r *FileRecorder) Record(record *Record) {
+	select {
+	case r.records <- record:
+	default:
+		r.dropped.Inc()
+	}
+}
+
+// writeLoop writes the queued records to the log files till the recorder is shut down, at which point the
+// remaining queued records are written and the current log file is closed.
+func (r *FileRecorder) writeLoop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.drain()
+			r.closeFile()
+			return
+		case record := <-r.records:
+			r.write(record)
+		case <-ticker.C:
+			r.flush()
+			if dropped := r.dropped.Swap(0); dropped > 0 {
+				r.logger.Warn().Uint64("dropped", dropped).Msg("message recorder queue is full, messages are dropped from the log")
+			}
+		}
+	}
+}
+
+// drain writes all records remaining in the queue.
+func (r *FileRecorder) drain() {
+	for {
+		select {
+		case record := <-r.records:
+			r.write(record)
+		default:
+			return
+		}
+	}
+}
+
+// write appends the record to the current log file, and rotates to a new log file once the current one reaches
+// the maximum file size. Write failures are logged and the record is dropped, as recording is a debugging aid that
+// must not affect the node.
+func (r *FileRecorder) write(record *Record) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		r.logger.Error().Err(err).Str("channel", record.Channel.String()).Msg("could not encode recorded message")
+		return
+	}
+	b = append(b, '\n')
+
+	if r.file == nil {
+		err = r.openNextFile()
+		if err != nil {
+			r.logger.Error().Err(err).Msg("could not open log file")
+			return
+		}
+	}
+
+	n, err := r.writer.Write(b)
+	r.written += uint64(n)
+	if err != nil {
+		r.logger.Error().Err(err).Str("file", r.file.Name()).Msg("could not write recorded message")
+		// the log file is left behind as is and a new one is opened for the next record.
+		r.closeFile()
+		return
+	}
+
+	if r.written >= r.maxFileSize {
+		// the next log file is opened lazily by the next record.
+		r.closeFile()
+	}
+}
+
+// flush writes the buffered records to the current log file.
+func (r *FileRecorder) flush() {
+	if r.file == nil {
+		return
+	}
+	err := r.writer.Flush()
+	if err != nil {
+		r.logger.Error().Err(err).Str("file", r.file.Name()).Msg("could not flush log file")
+	}
+}
+
+// openNextFile opens the log file with the next sequence number, after removing the oldest log files beyond the
+// maximum number of files.
+func (r *FileRecorder) openNextFile() error {
+	r.removeOldFiles()
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%s%020d%s", logFilePrefix, r.seq+1, logFileSuffix))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create log file %s: %w", path, err)
+	}
+
+	r.seq++
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.written = 0
+	return nil
+}
+
+// closeFile flushes and closes the current log file, if any.
+func (r *FileRecorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+	r.flush()
+	err := r.file.Close()
+	if err != nil {
+		r.logger.Error().Err(err).Str("file", r.file.Name()).Msg("could not close log file")
+	}
+	r.file = nil
+	r.writer = nil
+}
+
+// removeOldFiles removes the oldest log files, so that at most maxFiles-1 log files are left before the next one is opened.
+func (r *FileRecorder) removeOldFiles() {
+	files, err := LogFiles(r.dir)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("could not list log files for rotation")
+		return
+	}
+	for len(files) >= r.maxFiles {
+		err = os.Remove(files[0])
+		if err != nil {
+			r.logger.Error().Err(err).Str("file", files[0]).Msg("could not remove old log file")
+			return
+		}
+		files = files[1:]
+	}
+}
+
+// LogFiles returns the paths of the log files in the directory, in the order they are written.
+// No errors are expected during normal operation.
+func LogFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, logFilePrefix) || !strings.HasSuffix(name, logFileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	// sequence numbers are zero padded, hence lexicographic order is the order of writing.
+	sort.Strings(files)
+	return files, nil
+}
+
+// logFileSeq returns the sequence number of the log file at the given path.
+func logFileSeq(path string) (uint64, error) {
+	name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), logFilePrefix), logFileSuffix)
+	seq, err := strconv.ParseUint(name, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid log file name %s: %w", path, err)
+	}
+	return seq, nil
+}
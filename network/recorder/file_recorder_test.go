@@ -0,0 +1,213 @@
+package recorder_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/message"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/network/recorder"
+	"github.com/onflow/flow-go/network/stub"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestFileRecorder_RecordAndRead evaluates that the records written by the file recorder are read back in the order
+// they are recorded, and that a recorder restarted on the same directory continues the log after the existing files.
+func TestFileRecorder_RecordAndRead(t *testing.T) {
+	dir := unittest.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	cfg := recorder.FileRecorderConfig{
+		Dir:         dir,
+		MaxFileSize: 1 << 20,
+		MaxFiles:    10,
+		QueueSize:   100,
+	}
+
+	first := recordFixtures(t, 10)
+	recordAll(t, cfg, first)
+
+	second := recordFixtures(t, 10)
+	recordAll(t, cfg, second)
+
+	files, err := recorder.LogFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	records, err := recorder.ReadDir(dir)
+	require.NoError(t, err)
+	requireRecordsEqual(t, append(first, second...), records)
+}
+
+// TestFileRecorder_Rotation evaluates that the file recorder rotates to a new log file once the current one reaches the
+// maximum file size, and that it keeps at most the maximum number of log files by removing the oldest ones.
+func TestFileRecorder_Rotation(t *testing.T) {
+	dir := unittest.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	cfg := recorder.FileRecorderConfig{
+		Dir: dir,
+		// each record exceeds the maximum file size, hence each record is written to its own log file.
+		MaxFileSize: 1,
+		MaxFiles:    3,
+		QueueSize:   100,
+	}
+
+	records := recordFixtures(t, 10)
+	recordAll(t, cfg, records)
+
+	files, err := recorder.LogFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	read, err := recorder.ReadDir(dir)
+	require.NoError(t, err)
+	requireRecordsEqual(t, records[len(records)-3:], read)
+}
+
+// TestReadFile_TruncatedRecord evaluates that a record truncated at the end of a log file, e.g., by a crash while
+// writing it, is skipped when reading the file.
+func TestReadFile_TruncatedRecord(t *testing.T) {
+	dir := unittest.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	records := recordFixtures(t, 3)
+	recordAll(t, recorder.FileRecorderConfig{Dir: dir, MaxFileSize: 1 << 20, MaxFiles: 1, QueueSize: 100}, records)
+
+	files, err := recorder.LogFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"direction":"inbound","channel":"push-blo`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	read, err := recorder.ReadFile(files[0])
+	require.NoError(t, err)
+	requireRecordsEqual(t, records, read)
+}
+
+// TestReplay evaluates that replaying a recorded message log through the stub network delivers the inbound messages
+// of the replayed channels to the attached engine, in the recorded order and on behalf of their recorded origin.
+func TestReplay(t *testing.T) {
+	dir := unittest.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	records := recordFixtures(t, 10)
+	recordAll(t, recorder.FileRecorderConfig{Dir: dir, MaxFileSize: 1 << 20, MaxFiles: 1, QueueSize: 100}, records)
+
+	read, err := recorder.ReadDir(dir)
+	require.NoError(t, err)
+
+	nodeID := unittest.IdentifierFixture()
+	net := stub.NewNetwork(t, nodeID, stub.NewNetworkHub())
+
+	codec := cbor.NewCodec()
+	var expected []interface{}
+	var origins []flow.Identifier
+	for _, record := range records {
+		if record.Direction == recorder.Inbound && record.Channel == channels.RequestChunks {
+			event, err := codec.Decode(record.Payload)
+			require.NoError(t, err)
+			expected = append(expected, event)
+			origins = append(origins, record.OriginID)
+		}
+	}
+	require.NotEmpty(t, expected)
+
+	var processed []interface{}
+	var processedOrigins []flow.Identifier
+	engine := mocknetwork.NewMessageProcessor(t)
+	engine.On("Process", channels.RequestChunks, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			processedOrigins = append(processedOrigins, args.Get(1).(flow.Identifier))
+			processed = append(processed, args.Get(2))
+		}).
+		Return(nil)
+	_, err = net.Register(channels.RequestChunks, engine)
+	require.NoError(t, err)
+
+	// messages of the push-blocks channel are skipped, as no engine is attached to it.
+	require.NoError(t, net.Replay(codec, read))
+	require.Equal(t, expected, processed)
+	require.Equal(t, origins, processedOrigins)
+}
+
+// recordFixtures returns records of entity requests, alternating between inbound and outbound messages, and between
+// the request-chunks and push-blocks channels.
+func recordFixtures(t *testing.T, count int) []*recorder.Record {
+	codec := cbor.NewCodec()
+	records := make([]*recorder.Record, 0, count)
+	for i := 0; i < count; i++ {
+		request := &messages.EntityRequest{
+			Nonce:     uint64(i),
+			EntityIDs: unittest.IdentifierListFixture(2),
+		}
+		payload, err := codec.Encode(request)
+		require.NoError(t, err)
+
+		direction := recorder.Inbound
+		if i%2 == 1 {
+			direction = recorder.Outbound
+		}
+		channel := channels.RequestChunks
+		if i%3 == 2 {
+			channel = channels.PushBlocks
+		}
+
+		records = append(records, &recorder.Record{
+			Direction: direction,
+			Protocol:  message.ProtocolTypeUnicast,
+			Channel:   channel,
+			OriginID:  unittest.IdentifierFixture(),
+			TargetIDs: unittest.IdentifierListFixture(1),
+			Timestamp: time.Now().UTC(),
+			Type:      "*messages.EntityRequest",
+			Payload:   payload,
+		})
+	}
+	return records
+}
+
+// recordAll records the given records with a file recorder of the given config, and shuts the recorder down once done.
+func recordAll(t *testing.T, cfg recorder.FileRecorderConfig, records []*recorder.Record) {
+	r, err := recorder.NewFileRecorder(unittest.Logger(), cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Start(irrecoverable.NewMockSignalerContext(t, ctx))
+	unittest.RequireComponentsReadyBefore(t, time.Second, r)
+
+	for _, record := range records {
+		r.Record(record)
+	}
+
+	cancel()
+	unittest.RequireComponentsDoneBefore(t, time.Second, r)
+}
+
+func requireRecordsEqual(t *testing.T, expected []*recorder.Record, actual []*recorder.Record) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].Direction, actual[i].Direction)
+		require.Equal(t, expected[i].Protocol, actual[i].Protocol)
+		require.Equal(t, expected[i].Channel, actual[i].Channel)
+		require.Equal(t, expected[i].OriginID, actual[i].OriginID)
+		require.Equal(t, expected[i].TargetIDs, actual[i].TargetIDs)
+		require.True(t, expected[i].Timestamp.Equal(actual[i].Timestamp))
+		require.Equal(t, expected[i].Type, actual[i].Type)
+		require.Equal(t, expected[i].Payload, actual[i].Payload)
+	}
+}
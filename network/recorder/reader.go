@@ -0,0 +1,53 @@
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadDir reads the records of all log files in the directory, in the order they are recorded.
+// No errors are expected during normal operation.
+func ReadDir(dir string) ([]*Record, error) {
+	files, err := LogFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	for _, file := range files {
+		fileRecords, err := ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, fileRecords...)
+	}
+	return records, nil
+}
+
+// ReadFile reads the records of a single log file, in the order they are recorded. A truncated record at the end
+// of the file, e.g., when the node crashed while writing it, is skipped.
+// No errors are expected during normal operation.
+func ReadFile(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open log file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var records []*Record
+	decoder := json.NewDecoder(file)
+	for {
+		record := &Record{}
+		err := decoder.Decode(record)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not decode record %d of log file %s: %w", len(records), path, err)
+		}
+		records = append(records, record)
+	}
+}
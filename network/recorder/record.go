@@ -0,0 +1,58 @@
+package recorder
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/network/message"
+)
+
+// Direction is the direction of a recorded message relative to the recording node.
+type Direction string
+
+const (
+	// Inbound marks messages received by the recording node.
+	Inbound Direction = "inbound"
+	// Outbound marks messages sent by the recording node.
+	Outbound Direction = "outbound"
+)
+
+// Record is a single network message captured by the recorder.
+type Record struct {
+	// Direction is the direction of the message relative to the recording node.
+	Direction Direction `json:"direction"`
+	// Protocol is the networking protocol the message is sent or received on, i.e., unicast or pubsub.
+	Protocol message.ProtocolType `json:"protocol"`
+	// Channel is the channel of the message.
+	Channel channels.Channel `json:"channel"`
+	// OriginID is the node ID of the sender of the message.
+	OriginID flow.Identifier `json:"origin_id"`
+	// TargetIDs are the node IDs of the intended recipients of the message.
+	TargetIDs flow.IdentifierList `json:"target_ids"`
+	// Timestamp is the time at which the message is received or sent by the recording node.
+	Timestamp time.Time `json:"timestamp"`
+	// Type is the type of the decoded payload, e.g., *messages.BlockProposal.
+	Type string `json:"type"`
+	// Payload is the payload of the message encoded by the network codec.
+	Payload []byte `json:"payload"`
+}
+
+// MessageRecorder records the messages received and sent by the network.
+type MessageRecorder interface {
+	// Record records the given message. Implementations must be non-blocking and concurrency safe, as
+	// the network records messages on its hot path.
+	Record(record *Record)
+}
+
+// NoopRecorder is a MessageRecorder that discards all messages. It is used when recording is disabled.
+type NoopRecorder struct{}
+
+var _ MessageRecorder = (*NoopRecorder)(nil)
+
+// NewNoopRecorder returns a MessageRecorder that discards all messages.
+func NewNoopRecorder() *NoopRecorder {
+	return &NoopRecorder{}
+}
+
+func (n *NoopRecorder) Record(*Record) {}
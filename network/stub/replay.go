@@ -0,0 +1,102 @@
+package stub
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/network/recorder"
+)
+
+// replayConfig is the configuration of a replay of recorded messages.
+type replayConfig struct {
+	channels       map[channels.Channel]struct{} // channels to replay, all channels if empty.
+	recordedTiming bool                          // whether to keep the recorded delays between messages.
+}
+
+// ReplayOption is a function that can be used to override the default configuration of a replay.
+type ReplayOption func(*replayConfig)
+
+// WithReplayChannels restricts the replay to the messages of the given channels.
+func WithReplayChannels(replayChannels ...channels.Channel) ReplayOption {
+	return func(cfg *replayConfig) {
+		for _, channel := range replayChannels {
+			cfg.channels[channel] = struct{}{}
+		}
+	}
+}
+
+// WithRecordedTiming makes the replay wait between messages for the delays observed when the messages were recorded.
+// By default, messages are replayed back to back.
+func WithRecordedTiming() ReplayOption {
+	return func(cfg *replayConfig) {
+		cfg.recordedTiming = true
+	}
+}
+
+// Replay feeds the inbound messages of a recorded message log (see recorder.ReadDir) into the engines attached to
+// the Network, in the order they were recorded. Each message is decoded with the given codec and processed by the
+// engine of its channel on behalf of its recorded origin, synchronously, so that the engines under test observe the
+// exact sequence of messages the recording node received. Messages on channels without an attached engine are skipped,
+// and duplicate messages are dropped as they are by the networking layer.
+// Messages sent by the engines while processing the replayed messages are buffered in the Hub as usual.
+// Returns an error if a recorded message cannot be decoded, or if an engine fails to process a message.
+func (n *Network) Replay(codec network.Codec, records []*recorder.Record, opts ...ReplayOption) error {
+	cfg := &replayConfig{
+		channels: make(map[channels.Channel]struct{}),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var previous time.Time
+	for i, record := range records {
+		if record.Direction != recorder.Inbound {
+			continue
+		}
+		if _, ok := cfg.channels[record.Channel]; len(cfg.channels) > 0 && !ok {
+			continue
+		}
+		if !n.hasEngine(record.Channel) {
+			continue
+		}
+
+		if cfg.recordedTiming && !previous.IsZero() && record.Timestamp.After(previous) {
+			time.Sleep(record.Timestamp.Sub(previous))
+		}
+		previous = record.Timestamp
+
+		event, err := codec.Decode(record.Payload)
+		if err != nil {
+			return fmt.Errorf("could not decode recorded message %d (%s) on channel %s: %w", i, record.Type, record.Channel, err)
+		}
+
+		m := &PendingMessage{
+			From:      record.OriginID,
+			Channel:   record.Channel,
+			Event:     event,
+			TargetIDs: record.TargetIDs,
+		}
+		key, err := eventKey(m.From, m.Channel, m.Event)
+		if err != nil {
+			return fmt.Errorf("could not generate event key for recorded message %d: %w", i, err)
+		}
+
+		err = n.processWithEngine(true, key, m)
+		if err != nil {
+			return fmt.Errorf("could not replay recorded message %d (%s) on channel %s: %w", i, record.Type, record.Channel, err)
+		}
+	}
+
+	return nil
+}
+
+// hasEngine returns true if an engine is attached to the channel.
+func (n *Network) hasEngine(channel channels.Channel) bool {
+	n.Lock()
+	defer n.Unlock()
+
+	_, ok := n.engines[channel]
+	return ok
+}
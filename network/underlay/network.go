@@ -24,6 +24,8 @@ import (
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/network"
 	alspmgr "github.com/onflow/flow-go/network/alsp/manager"
+	"github.com/onflow/flow-go/network/alsp/model"
+	"github.com/onflow/flow-go/network/bandwidth"
 	netcache "github.com/onflow/flow-go/network/cache"
 	"github.com/onflow/flow-go/network/channels"
 	"github.com/onflow/flow-go/network/codec"
@@ -38,6 +40,7 @@ import (
 	"github.com/onflow/flow-go/network/p2p/unicast/ratelimit"
 	"github.com/onflow/flow-go/network/p2p/utils"
 	"github.com/onflow/flow-go/network/queue"
+	"github.com/onflow/flow-go/network/recorder"
 	"github.com/onflow/flow-go/network/underlay/internal"
 	"github.com/onflow/flow-go/network/validator"
 	flowpubsub "github.com/onflow/flow-go/network/validator/pubsub"
@@ -113,6 +116,8 @@ type Network struct {
 	validators                  []network.MessageValidator
 	authorizedSenderValidator   *validator.AuthorizedSenderValidator
 	preferredUnicasts           []protocols.ProtocolName
+	recorder                    recorder.MessageRecorder    // records inbound and outbound messages for debugging
+	bandwidthAccountant         bandwidth.MessageAccountant // accounts the bytes of inbound and outbound messages per channel and peer
 }
 
 var _ network.EngineRegistry = &Network{}
@@ -247,6 +252,22 @@ func WithPreferredUnicastProtocols(protocols ...protocols.ProtocolName) NetworkO
 	}
 }
 
+// WithMessageRecorder sets the recorder of the inbound and outbound messages of the network. It overrides the default
+// recorder, which discards all messages. If the recorder is a component, its lifecycle is managed by the network.
+func WithMessageRecorder(r recorder.MessageRecorder) NetworkOption {
+	return func(n *Network) {
+		n.recorder = r
+	}
+}
+
+// WithBandwidthAccountant sets the accountant of the bytes of the inbound and outbound messages of the network. It
+// overrides the default accountant, which discards all messages.
+func WithBandwidthAccountant(a bandwidth.MessageAccountant) NetworkOption {
+	return func(n *Network) {
+		n.bandwidthAccountant = a
+	}
+}
+
 // WithMessageValidators sets the message validators for the network. It overrides the default
 // message validators.
 func WithMessageValidators(validators ...network.MessageValidator) NetworkOption {
@@ -282,6 +303,8 @@ func NewNetwork(param *NetworkConfig, opts ...NetworkOption) (*Network, error) {
 		libP2PNode:                  param.Libp2pNode,
 		unicastRateLimiters:         ratelimit.NoopRateLimiters(),
 		validators:                  DefaultValidators(param.Logger.With().Str("component", "network-validators").Logger(), param.Me.NodeID()),
+		recorder:                    recorder.NewNoopRecorder(),
+		bandwidthAccountant:         bandwidth.NewNoopMessageAccountant(),
 	}
 
 	n.subscriptionManager = subscription.NewChannelSubscriptionManager(n)
@@ -328,6 +351,15 @@ func NewNetwork(param *NetworkConfig, opts ...NetworkOption) (*Network, error) {
 		n.logger.Debug().Msg("network context is done")
 	})
 
+	if recorderComponent, ok := n.recorder.(component.Component); ok {
+		builder.AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+			recorderComponent.Start(ctx)
+			<-recorderComponent.Ready()
+			ready()
+			<-recorderComponent.Done()
+		})
+	}
+
 	for _, limiter := range n.unicastRateLimiters.Limiters() {
 		rateLimiter := limiter
 		builder.AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
@@ -553,6 +585,7 @@ func (n *Network) Identity(pid peer.ID) (*flow.Identity, bool) {
 
 func (n *Network) Receive(msg network.IncomingMessageScope) error {
 	n.metrics.InboundMessageReceived(msg.Size(), msg.Channel().String(), msg.Protocol().String(), msg.PayloadType())
+	n.recordMessage(recorder.Inbound, msg.Protocol(), msg.Channel(), msg.OriginId(), msg.TargetIDs(), msg.PayloadType(), msg.Proto().Payload)
 
 	err := n.processNetworkMessage(msg)
 	if err != nil {
@@ -643,6 +676,9 @@ func (n *Network) UnicastOnChannel(channel channels.Channel, payload interface{}
 	}
 	streamProtectionTag := fmt.Sprintf("%v:%v", channel, msg.PayloadType())
 
+	// the channel lets the unicast manager pick the preferred protocol (e.g., compression) of the channel.
+	ctx = protocols.ContextWithChannel(ctx, channel)
+
 	err = n.libP2PNode.OpenAndWriteOnStream(ctx, peerID, streamProtectionTag, func(stream libp2pnet.Stream) error {
 		bufw := bufio.NewWriter(stream)
 		writer := ggio.NewDelimitedWriter(bufw)
@@ -665,6 +701,8 @@ func (n *Network) UnicastOnChannel(channel channels.Channel, payload interface{}
 	}
 
 	n.metrics.OutboundMessageSent(msg.Size(), channel.String(), message.ProtocolTypeUnicast.String(), msg.PayloadType())
+	n.bandwidthAccountant.OnMessage(bandwidth.Outbound, channel, peerID, msg.Size())
+	n.recordMessage(recorder.Outbound, message.ProtocolTypeUnicast, channel, n.me.NodeID(), msg.TargetIds(), msg.PayloadType(), msg.Proto().Payload)
 	return nil
 }
 
@@ -744,10 +782,33 @@ func (n *Network) sendOnChannel(channel channels.Channel, msg interface{}, targe
 	}
 
 	n.metrics.OutboundMessageSent(scope.Size(), channel.String(), message.ProtocolTypePubSub.String(), scope.PayloadType())
+	n.recordMessage(recorder.Outbound, message.ProtocolTypePubSub, channel, n.me.NodeID(), scope.TargetIds(), scope.PayloadType(), scope.Proto().Payload)
 
 	return nil
 }
 
+// recordMessage passes a message received or sent by the network to the message recorder.
+func (n *Network) recordMessage(
+	direction recorder.Direction,
+	protocol message.ProtocolType,
+	channel channels.Channel,
+	originID flow.Identifier,
+	targetIDs flow.IdentifierList,
+	payloadType string,
+	payload []byte,
+) {
+	n.recorder.Record(&recorder.Record{
+		Direction: direction,
+		Protocol:  protocol,
+		Channel:   channel,
+		OriginID:  originID,
+		TargetIDs: targetIDs,
+		Timestamp: time.Now(),
+		Type:      payloadType,
+		Payload:   payload,
+	})
+}
+
 // queueSubmitFunc submits the message to the engine synchronously. It is the callback for the queue worker
 // when it gets a message from the queue
 func (n *Network) queueSubmitFunc(message interface{}) {
@@ -799,6 +860,15 @@ func (n *Network) ReportMisbehaviorOnChannel(channel channels.Channel, report ne
 	n.misbehaviorReportManager.HandleMisbehaviorReport(channel, report)
 }
 
+// SpamRecord returns a copy of the application layer spam record the misbehavior report manager keeps for the given node.
+// Args:
+// - originId: the node id of the node.
+// Returns:
+// - the spam record of the node and true if the node has a spam record, nil and false otherwise.
+func (n *Network) SpamRecord(originId flow.Identifier) (*model.ProtocolSpamRecord, bool) {
+	return n.misbehaviorReportManager.SpamRecord(originId)
+}
+
 func DefaultValidators(log zerolog.Logger, flowID flow.Identifier) []network.MessageValidator {
 	return []network.MessageValidator{
 		validator.ValidateNotSender(flowID),   // validator to filter out messages sent by this node itself
@@ -1151,6 +1221,8 @@ func (n *Network) processUnicastStreamMessage(remotePeer peer.ID, msg *message.M
 // processAuthenticatedMessage processes a message and a source (indicated by its peer ID) and eventually passes it to the overlay
 // In particular, it populates the `OriginID` field of the message with a Flow ID translated from this source.
 func (n *Network) processAuthenticatedMessage(msg *message.Message, peerID peer.ID, protocol message.ProtocolType) {
+	n.bandwidthAccountant.OnMessage(bandwidth.Inbound, channels.Channel(msg.ChannelID), peerID, msg.Size())
+
 	originId, err := n.identityTranslator.GetFlowID(peerID)
 	if err != nil {
 		// this error should never happen. by the time the message gets here, the peer should be
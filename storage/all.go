@@ -19,6 +19,7 @@ type All struct {
 	LightTransactionResults        LightTransactionResults
 	TransactionResults             TransactionResults
 	TransactionResultErrorMessages TransactionResultErrorMessages
+	TransactionResourceReports     TransactionResourceReports
 	Collections                    Collections
 	Events                         Events
 	EpochProtocolStateEntries      EpochProtocolStateEntries
@@ -0,0 +1,64 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ChunkVerificationRecords implements persistent storage for the history of verified chunks.
+type ChunkVerificationRecords struct {
+	db *badger.DB
+}
+
+var _ storage.ChunkVerificationRecords = (*ChunkVerificationRecords)(nil)
+
+func NewChunkVerificationRecords(db *badger.DB) *ChunkVerificationRecords {
+	return &ChunkVerificationRecords{
+		db: db,
+	}
+}
+
+// Store persists the given chunk verification record.
+// Error returns:
+//   - storage.ErrAlreadyExists if a record for the same chunk has already been stored
+func (r *ChunkVerificationRecords) Store(record *chunks.VerificationRecord) error {
+	return operation.RetryOnConflict(r.db.Update, operation.InsertChunkVerificationRecord(record))
+}
+
+// ByHeightRange returns all chunk verification records for executed blocks with heights in the
+// range [fromHeight, toHeight], ordered by ascending height.
+// No errors are expected during normal operation.
+func (r *ChunkVerificationRecords) ByHeightRange(fromHeight, toHeight uint64) ([]*chunks.VerificationRecord, error) {
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid height range: from height %d is larger than to height %d", fromHeight, toHeight)
+	}
+	records := make([]*chunks.VerificationRecord, 0)
+	err := r.db.View(operation.LookupChunkVerificationRecordsInHeightRange(fromHeight, toHeight, &records))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chunk verification records in height range [%d, %d]: %w", fromHeight, toHeight, err)
+	}
+	return records, nil
+}
+
+// PruneUpToHeight removes all chunk verification records for executed blocks with heights lower than
+// the given height.
+// No errors are expected during normal operation.
+func (r *ChunkVerificationRecords) PruneUpToHeight(height uint64) error {
+	batch := NewBatch(r.db)
+
+	err := r.db.View(operation.BatchRemoveChunkVerificationRecordsBelowHeight(height, batch.GetWriter()))
+	if err != nil {
+		return fmt.Errorf("could not remove chunk verification records below height %d: %w", height, err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot flush batch: %w", err)
+	}
+	return nil
+}
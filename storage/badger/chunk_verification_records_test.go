@@ -0,0 +1,100 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func chunkVerificationRecordFixture(height uint64, chunkIndex uint64) *chunks.VerificationRecord {
+	return &chunks.VerificationRecord{
+		ResultID:            unittest.IdentifierFixture(),
+		ChunkIndex:          chunkIndex,
+		BlockID:             unittest.IdentifierFixture(),
+		BlockHeight:         height,
+		Executors:           unittest.IdentifierListFixture(2),
+		ChunkDataPackOrigin: unittest.IdentifierFixture(),
+		FaultType:           "final_state_mismatch",
+		Fault:               "final state commitment doesn't match",
+		FetchDuration:       150 * time.Millisecond,
+		VerifyDuration:      2 * time.Second,
+		VerifiedAt:          time.Unix(1700000000, 0).UTC(),
+	}
+}
+
+// TestChunkVerificationRecordsStoreAndRetrieve verifies that chunk verification records can be stored and
+// are retrieved in order of ascending height, and that duplicates are rejected.
+func TestChunkVerificationRecordsStoreAndRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewChunkVerificationRecords(db)
+
+		records := []*chunks.VerificationRecord{
+			chunkVerificationRecordFixture(30, 0),
+			chunkVerificationRecordFixture(10, 1),
+			chunkVerificationRecordFixture(20, 0),
+			chunkVerificationRecordFixture(300, 2),
+		}
+		for _, record := range records {
+			require.NoError(t, store.Store(record))
+		}
+
+		err := store.Store(records[0])
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+
+		retrieved, err := store.ByHeightRange(10, 30)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 3)
+		for i, expected := range []*chunks.VerificationRecord{records[1], records[2], records[0]} {
+			require.True(t, expected.VerifiedAt.Equal(retrieved[i].VerifiedAt))
+			retrieved[i].VerifiedAt = expected.VerifiedAt
+			require.Equal(t, expected, retrieved[i])
+		}
+
+		retrieved, err = store.ByHeightRange(31, 299)
+		require.NoError(t, err)
+		require.Empty(t, retrieved)
+
+		_, err = store.ByHeightRange(30, 10)
+		require.Error(t, err)
+	})
+}
+
+// TestChunkVerificationRecordsPruneUpToHeight verifies that pruning removes the records of all heights lower than
+// the pruned height, and keeps the records of the pruned height and above.
+func TestChunkVerificationRecordsPruneUpToHeight(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewChunkVerificationRecords(db)
+
+		for _, height := range []uint64{10, 19, 20, 20, 21, 300} {
+			require.NoError(t, store.Store(chunkVerificationRecordFixture(height, 0)))
+		}
+
+		require.NoError(t, store.PruneUpToHeight(20))
+
+		retrieved, err := store.ByHeightRange(0, 1000)
+		require.NoError(t, err)
+		heights := make([]uint64, 0, len(retrieved))
+		for _, record := range retrieved {
+			heights = append(heights, record.BlockHeight)
+		}
+		require.Equal(t, []uint64{20, 20, 21, 300}, heights)
+
+		// pruning a lower height is a no-op
+		require.NoError(t, store.PruneUpToHeight(5))
+		retrieved, err = store.ByHeightRange(0, 1000)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 4)
+
+		require.NoError(t, store.PruneUpToHeight(1000))
+		retrieved, err = store.ByHeightRange(0, 1000)
+		require.NoError(t, err)
+		require.Empty(t, retrieved)
+	})
+}
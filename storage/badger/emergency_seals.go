@@ -0,0 +1,46 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// EmergencySeals implements persistent storage for emergency seal audit records.
+type EmergencySeals struct {
+	db *badger.DB
+}
+
+var _ storage.EmergencySeals = (*EmergencySeals)(nil)
+
+func NewEmergencySeals(db *badger.DB) *EmergencySeals {
+	return &EmergencySeals{
+		db: db,
+	}
+}
+
+// Store persists the given emergency seal record.
+// Error returns:
+//   - storage.ErrAlreadyExists if a record for the same result has already been stored
+func (s *EmergencySeals) Store(seal *flow.EmergencySeal) error {
+	return operation.RetryOnConflict(s.db.Update, operation.InsertEmergencySeal(seal))
+}
+
+// ByHeightRange returns all emergency seal records for executed blocks with heights in the
+// range [fromHeight, toHeight], ordered by ascending height.
+// No errors are expected during normal operation.
+func (s *EmergencySeals) ByHeightRange(fromHeight, toHeight uint64) ([]*flow.EmergencySeal, error) {
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid height range: from height %d is larger than to height %d", fromHeight, toHeight)
+	}
+	seals := make([]*flow.EmergencySeal, 0)
+	err := s.db.View(operation.LookupEmergencySealsInHeightRange(fromHeight, toHeight, &seals))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve emergency seals in height range [%d, %d]: %w", fromHeight, toHeight, err)
+	}
+	return seals, nil
+}
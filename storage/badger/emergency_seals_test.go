@@ -0,0 +1,62 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func emergencySealFixture(height uint64) *flow.EmergencySeal {
+	return &flow.EmergencySeal{
+		ResultID:            unittest.IdentifierFixture(),
+		BlockID:             unittest.IdentifierFixture(),
+		BlockHeight:         height,
+		IncorporatedBlockID: unittest.IdentifierFixture(),
+		RequiredApprovals:   flow.DefaultRequiredApprovalsForSealConstruction,
+		UnapprovedChunks: []flow.EmergencySealChunk{
+			{
+				Index:             1,
+				AssignedVerifiers: unittest.IdentifierListFixture(3),
+				ApprovedBy:        flow.IdentifierList{},
+			},
+		},
+	}
+}
+
+// TestEmergencySealsStoreAndRetrieve verifies that emergency seal records can be stored and
+// are retrieved in order of ascending height, and that duplicates are rejected.
+func TestEmergencySealsStoreAndRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewEmergencySeals(db)
+
+		seals := []*flow.EmergencySeal{
+			emergencySealFixture(30),
+			emergencySealFixture(10),
+			emergencySealFixture(20),
+			emergencySealFixture(300),
+		}
+		for _, seal := range seals {
+			require.NoError(t, store.Store(seal))
+		}
+
+		err := store.Store(seals[0])
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+
+		retrieved, err := store.ByHeightRange(10, 30)
+		require.NoError(t, err)
+		require.Equal(t, []*flow.EmergencySeal{seals[1], seals[2], seals[0]}, retrieved)
+
+		retrieved, err = store.ByHeightRange(31, 299)
+		require.NoError(t, err)
+		require.Empty(t, retrieved)
+
+		_, err = store.ByHeightRange(30, 10)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,147 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/evm"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// EVMIndex implements persistent storage for the EVM blocks, transactions and receipts indexed from EVM events.
+type EVMIndex struct {
+	db *badger.DB
+}
+
+var _ storage.EVMIndex = (*EVMIndex)(nil)
+
+func NewEVMIndex(db *badger.DB) *EVMIndex {
+	return &EVMIndex{
+		db: db,
+	}
+}
+
+// BatchStore stores the given EVM block with its transactions and receipts in the given batch, and sets the block
+// as the latest indexed EVM block. Storing a block again overwrites it.
+// No errors are expected during normal operation.
+func (i *EVMIndex) BatchStore(block *evm.Block, transactions []*evm.Transaction, receipts []*evm.Receipt, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	var firstHeight uint64
+	err := i.db.View(operation.RetrieveEVMFirstHeight(&firstHeight))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not retrieve first evm height: %w", err)
+	}
+	if errors.Is(err, storage.ErrNotFound) || block.Height < firstHeight {
+		err = operation.BatchInsertEVMFirstHeight(block.Height)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert first evm height: %w", err)
+		}
+	}
+
+	err = operation.BatchInsertEVMBlock(block)(writeBatch)
+	if err != nil {
+		return fmt.Errorf("cannot batch insert evm block %d: %w", block.Height, err)
+	}
+	for _, tx := range transactions {
+		err = operation.BatchInsertEVMTransaction(tx)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert evm transaction %v: %w", tx.Hash, err)
+		}
+	}
+	for _, receipt := range receipts {
+		err = operation.BatchInsertEVMReceipt(receipt)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert evm receipt %v: %w", receipt.TransactionHash, err)
+		}
+	}
+
+	err = operation.BatchUpdateEVMLatestHeight(block.Height)(writeBatch)
+	if err != nil {
+		return fmt.Errorf("cannot batch update latest evm height: %w", err)
+	}
+	return nil
+}
+
+// BlockByHeight returns the EVM block with the given height.
+// Expected errors:
+//   - storage.ErrNotFound if the block is not indexed
+func (i *EVMIndex) BlockByHeight(height uint64) (*evm.Block, error) {
+	var block evm.Block
+	err := i.db.View(operation.RetrieveEVMBlock(height, &block))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve evm block %d: %w", height, err)
+	}
+	return &block, nil
+}
+
+// BlockByHash returns the EVM block with the given hash.
+// Expected errors:
+//   - storage.ErrNotFound if the block is not indexed
+func (i *EVMIndex) BlockByHash(hash gethCommon.Hash) (*evm.Block, error) {
+	var block evm.Block
+	err := i.db.View(func(tx *badger.Txn) error {
+		var height uint64
+		err := operation.LookupEVMBlockHeight(hash, &height)(tx)
+		if err != nil {
+			return err
+		}
+		return operation.RetrieveEVMBlock(height, &block)(tx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve evm block %v: %w", hash, err)
+	}
+	return &block, nil
+}
+
+// TransactionByHash returns the EVM transaction with the given hash.
+// Expected errors:
+//   - storage.ErrNotFound if the transaction is not indexed
+func (i *EVMIndex) TransactionByHash(hash gethCommon.Hash) (*evm.Transaction, error) {
+	var tx evm.Transaction
+	err := i.db.View(operation.RetrieveEVMTransaction(hash, &tx))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve evm transaction %v: %w", hash, err)
+	}
+	return &tx, nil
+}
+
+// ReceiptByTransactionHash returns the receipt of the EVM transaction with the given hash.
+// Expected errors:
+//   - storage.ErrNotFound if the transaction is not indexed
+func (i *EVMIndex) ReceiptByTransactionHash(hash gethCommon.Hash) (*evm.Receipt, error) {
+	var receipt evm.Receipt
+	err := i.db.View(operation.RetrieveEVMReceipt(hash, &receipt))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve evm receipt %v: %w", hash, err)
+	}
+	return &receipt, nil
+}
+
+// FirstHeight returns the height of the first indexed EVM block.
+// Expected errors:
+//   - storage.ErrNotFound if no block is indexed yet
+func (i *EVMIndex) FirstHeight() (uint64, error) {
+	var height uint64
+	err := i.db.View(operation.RetrieveEVMFirstHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve first evm height: %w", err)
+	}
+	return height, nil
+}
+
+// LatestHeight returns the height of the latest indexed EVM block.
+// Expected errors:
+//   - storage.ErrNotFound if no block is indexed yet
+func (i *EVMIndex) LatestHeight() (uint64, error) {
+	var height uint64
+	err := i.db.View(operation.RetrieveEVMLatestHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve latest evm height: %w", err)
+	}
+	return height, nil
+}
@@ -0,0 +1,150 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/evm"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEVMIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewEVMIndex(db)
+
+		t.Run("empty index", func(t *testing.T) {
+			_, err := index.FirstHeight()
+			require.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = index.LatestHeight()
+			require.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = index.BlockByHeight(1)
+			require.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = index.BlockByHash(gethCommon.Hash{1})
+			require.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = index.TransactionByHash(gethCommon.Hash{1})
+			require.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = index.ReceiptByTransactionHash(gethCommon.Hash{1})
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+
+		blocks := make([]*evm.Block, 0)
+		for height := uint64(5); height <= 7; height++ {
+			block, txs, receipts := evmBlockFixture(height)
+			blocks = append(blocks, block)
+
+			batch := bstorage.NewBatch(db)
+			require.NoError(t, index.BatchStore(block, txs, receipts, batch))
+			require.NoError(t, batch.Flush())
+
+			first, err := index.FirstHeight()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(5), first)
+			latest, err := index.LatestHeight()
+			require.NoError(t, err)
+			assert.Equal(t, height, latest)
+
+			actual, err := index.BlockByHeight(height)
+			require.NoError(t, err)
+			assert.Equal(t, block, actual)
+
+			actual, err = index.BlockByHash(block.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, block, actual)
+
+			for i, tx := range txs {
+				actualTx, err := index.TransactionByHash(tx.Hash)
+				require.NoError(t, err)
+				assert.Equal(t, tx, actualTx)
+
+				actualReceipt, err := index.ReceiptByTransactionHash(tx.Hash)
+				require.NoError(t, err)
+				assert.Equal(t, receipts[i], actualReceipt)
+			}
+		}
+
+		t.Run("reindexing a block", func(t *testing.T) {
+			block, txs, receipts := evmBlockFixture(6)
+			batch := bstorage.NewBatch(db)
+			require.NoError(t, index.BatchStore(block, txs, receipts, batch))
+			require.NoError(t, batch.Flush())
+
+			actual, err := index.BlockByHeight(6)
+			require.NoError(t, err)
+			assert.Equal(t, block, actual)
+
+			latest, err := index.LatestHeight()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(6), latest)
+
+			// the hash of the overwritten block still resolves to the block at its height
+			actual, err = index.BlockByHash(blocks[1].Hash)
+			require.NoError(t, err)
+			assert.Equal(t, block, actual)
+		})
+	})
+}
+
+// evmBlockFixture returns an EVM block at the given height with two transactions and their receipts.
+func evmBlockFixture(height uint64) (*evm.Block, []*evm.Transaction, []*evm.Receipt) {
+	block := &evm.Block{
+		Height:              height,
+		Hash:                gethCommon.Hash(unittest.IdentifierFixture()),
+		ParentHash:          gethCommon.Hash(unittest.IdentifierFixture()),
+		Timestamp:           height * 100,
+		TotalGasUsed:        42_000,
+		ReceiptRoot:         gethCommon.Hash(unittest.IdentifierFixture()),
+		TransactionHashRoot: gethCommon.Hash(unittest.IdentifierFixture()),
+		PrevRandao:          gethCommon.Hash(unittest.IdentifierFixture()),
+		FlowHeight:          height + 1000,
+		FlowBlockID:         unittest.IdentifierFixture(),
+	}
+
+	txs := make([]*evm.Transaction, 0)
+	receipts := make([]*evm.Receipt, 0)
+	for i := uint16(0); i < 2; i++ {
+		tx := &evm.Transaction{
+			Hash:        gethCommon.Hash(unittest.IdentifierFixture()),
+			BlockHeight: height,
+			Index:       i,
+			Type:        gethTypes.DynamicFeeTxType,
+			Payload:     unittest.RandomBytes(100),
+			From:        gethCommon.BytesToAddress(unittest.RandomBytes(20)),
+			FlowBlockID: block.FlowBlockID,
+		}
+		log := &gethTypes.Log{
+			Address:     gethCommon.BytesToAddress(unittest.RandomBytes(20)),
+			Topics:      []gethCommon.Hash{gethCommon.Hash(unittest.IdentifierFixture())},
+			Data:        unittest.RandomBytes(32),
+			BlockNumber: height,
+			TxHash:      tx.Hash,
+			TxIndex:     uint(i),
+			BlockHash:   block.Hash,
+			Index:       uint(i),
+		}
+		contract := gethCommon.BytesToAddress(unittest.RandomBytes(20))
+		receipt := &evm.Receipt{
+			TransactionHash:   tx.Hash,
+			BlockHeight:       height,
+			BlockHash:         block.Hash,
+			Index:             i,
+			Status:            gethTypes.ReceiptStatusSuccessful,
+			GasUsed:           21_000,
+			CumulativeGasUsed: 21_000 * uint64(i+1),
+			ContractAddress:   &contract,
+			Logs:              []*gethTypes.Log{log},
+			LogsBloom:         gethTypes.CreateBloom(gethTypes.Receipts{{Logs: []*gethTypes.Log{log}}}),
+		}
+		block.TransactionHashes = append(block.TransactionHashes, tx.Hash)
+		block.LogsBloom.Add(log.Address.Bytes())
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	return block, txs, receipts
+}
@@ -40,3 +40,29 @@ func PersistBlocklist(blocklist map[flow.Identifier]struct{}) func(*badger.Txn)
 func RetrieveBlocklist(blocklist *map[flow.Identifier]struct{}) func(*badger.Txn) error {
 	return retrieve(makePrefix(blockedNodeIDs), blocklist)
 }
+
+// PersistDisallowListEntries writes the operator-managed disallow-list entries of the networking layer into the data base.
+// If an entry already exists, it is overwritten; otherwise a new entry is created.
+// No errors are expected during normal operations.
+func PersistDisallowListEntries(entries map[flow.Identifier]flow.DisallowListEntry) func(*badger.Txn) error {
+	return upsert(makePrefix(codeDisallowListEntries), entries)
+}
+
+// RetrieveDisallowListEntries reads the operator-managed disallow-list entries of the networking layer from the data base.
+// Returns `storage.ErrNotFound` error in case no respective data base entry is present.
+func RetrieveDisallowListEntries(entries *map[flow.Identifier]flow.DisallowListEntry) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDisallowListEntries), entries)
+}
+
+// PurgeDisallowListEntries removes the operator-managed disallow-list entries of the networking layer from the data base.
+// If no corresponding entry exists, this function is a no-op.
+// No errors are expected during normal operations.
+func PurgeDisallowListEntries() func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := remove(makePrefix(codeDisallowListEntries))(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("unexpected error while purging disallow-list entries: %w", err)
+		}
+		return nil
+	}
+}
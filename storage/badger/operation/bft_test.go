@@ -1,7 +1,9 @@
 package operation
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
@@ -93,3 +95,80 @@ func Test_PersistBlocklist(t *testing.T) {
 		})
 	})
 }
+
+// Test_PersistDisallowListEntries tests the operations:
+//   - PersistDisallowListEntries(entries map[flow.Identifier]flow.DisallowListEntry)
+//   - RetrieveDisallowListEntries(entries *map[flow.Identifier]flow.DisallowListEntry)
+//   - PurgeDisallowListEntries()
+func Test_PersistDisallowListEntries(t *testing.T) {
+	entriesFixture := func() map[flow.Identifier]flow.DisallowListEntry {
+		now := time.Now().UTC().Truncate(time.Second)
+		entries := make(map[flow.Identifier]flow.DisallowListEntry)
+		for i, id := range unittest.IdentifierListFixture(4) {
+			entries[id] = flow.DisallowListEntry{
+				NodeID:    id,
+				Reason:    fmt.Sprintf("reason %d", i),
+				CreatedAt: now,
+				Expiry:    now.Add(time.Duration(i) * time.Hour),
+			}
+		}
+		return entries
+	}
+
+	t.Run("Retrieving non-existing entries should return 'storage.ErrNotFound'", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			var entries map[flow.Identifier]flow.DisallowListEntry
+			err := db.View(RetrieveDisallowListEntries(&entries))
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+	})
+
+	t.Run("Write & Purge & Write entries", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			entries1 := entriesFixture()
+			err := db.Update(PersistDisallowListEntries(entries1))
+			require.NoError(t, err)
+
+			var e map[flow.Identifier]flow.DisallowListEntry
+			err = db.View(RetrieveDisallowListEntries(&e))
+			require.NoError(t, err)
+			requireEqualDisallowListEntries(t, entries1, e)
+
+			err = db.Update(PurgeDisallowListEntries())
+			require.NoError(t, err)
+
+			err = db.View(RetrieveDisallowListEntries(&e))
+			require.ErrorIs(t, err, storage.ErrNotFound)
+
+			entries2 := entriesFixture()
+			err = db.Update(PersistDisallowListEntries(entries2))
+			require.NoError(t, err)
+
+			e = nil
+			err = db.View(RetrieveDisallowListEntries(&e))
+			require.NoError(t, err)
+			requireEqualDisallowListEntries(t, entries2, e)
+		})
+	})
+
+	t.Run("Purge non-existing entries", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			err := db.Update(PurgeDisallowListEntries())
+			require.NoError(t, err)
+		})
+	})
+}
+
+// requireEqualDisallowListEntries compares the entries, the timestamps are compared as instants as their location
+// is not preserved by the encoding.
+func requireEqualDisallowListEntries(t *testing.T, expected, actual map[flow.Identifier]flow.DisallowListEntry) {
+	require.Len(t, actual, len(expected))
+	for id, e := range expected {
+		a, ok := actual[id]
+		require.True(t, ok)
+		require.Equal(t, e.NodeID, a.NodeID)
+		require.Equal(t, e.Reason, a.Reason)
+		require.True(t, e.CreatedAt.Equal(a.CreatedAt))
+		require.True(t, e.Expiry.Equal(a.Expiry))
+	}
+}
@@ -0,0 +1,72 @@
+package operation
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// InsertChunkVerificationRecord inserts a chunk verification record, keyed by the height of the executed
+// block, the ID of the execution result and the chunk index. This allows iterating records in order of block height.
+// Returns storage.ErrAlreadyExists if a record for the chunk has already been stored.
+func InsertChunkVerificationRecord(record *chunks.VerificationRecord) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkVerificationRecord, record.BlockHeight, record.ResultID, record.ChunkIndex), record)
+}
+
+// RetrieveChunkVerificationRecord retrieves the verification record for the given chunk of a result executing
+// a block at the given height.
+// Returns storage.ErrNotFound if no such record exists.
+func RetrieveChunkVerificationRecord(height uint64, resultID flow.Identifier, chunkIndex uint64, record *chunks.VerificationRecord) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeChunkVerificationRecord, height, resultID, chunkIndex), record)
+}
+
+// LookupChunkVerificationRecordsInHeightRange retrieves all chunk verification records for executed blocks with
+// heights in the range [fromHeight, toHeight], ordered by ascending height.
+func LookupChunkVerificationRecordsInHeightRange(fromHeight, toHeight uint64, records *[]*chunks.VerificationRecord) func(*badger.Txn) error {
+	return iterate(makePrefix(codeChunkVerificationRecord, fromHeight), makePrefix(codeChunkVerificationRecord, toHeight), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var record chunks.VerificationRecord
+		create := func() interface{} {
+			return &record
+		}
+		handle := func() error {
+			*records = append(*records, &record)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// BatchRemoveChunkVerificationRecordsBelowHeight removes all chunk verification records for executed blocks with
+// heights lower than the given height, in the provided batch.
+// No errors are expected during normal operation.
+func BatchRemoveChunkVerificationRecordsBelowHeight(height uint64, batch *badger.WriteBatch) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		prefix := makePrefix(codeChunkVerificationRecord)
+		// keys are ordered by height, so all keys below the end key are records below the height
+		end := makePrefix(codeChunkVerificationRecord, height)
+
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			key := it.Item().KeyCopy(nil)
+			if bytes.Compare(key, end) >= 0 {
+				break
+			}
+			err := batch.Delete(key)
+			if err != nil {
+				return irrecoverable.NewExceptionf("could not delete chunk verification record in batch: %w", err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertEmergencySeal inserts an emergency seal record, keyed by the height of the executed block
+// and the ID of the sealed result. This allows iterating records in order of block height.
+// Returns storage.ErrAlreadyExists if a record for the result has already been stored.
+func InsertEmergencySeal(seal *flow.EmergencySeal) func(*badger.Txn) error {
+	return insert(makePrefix(codeEmergencySeal, seal.BlockHeight, seal.ResultID), seal)
+}
+
+// RetrieveEmergencySeal retrieves the emergency seal record for the given result executing a block at the given height.
+// Returns storage.ErrNotFound if no such record exists.
+func RetrieveEmergencySeal(height uint64, resultID flow.Identifier, seal *flow.EmergencySeal) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEmergencySeal, height, resultID), seal)
+}
+
+// LookupEmergencySealsInHeightRange retrieves all emergency seal records for executed blocks with heights
+// in the range [fromHeight, toHeight], ordered by ascending height.
+func LookupEmergencySealsInHeightRange(fromHeight, toHeight uint64, seals *[]*flow.EmergencySeal) func(*badger.Txn) error {
+	return iterate(makePrefix(codeEmergencySeal, fromHeight), makePrefix(codeEmergencySeal, toHeight), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var seal flow.EmergencySeal
+		create := func() interface{} {
+			return &seal
+		}
+		handle := func() error {
+			*seals = append(*seals, &seal)
+			return nil
+		}
+		return check, create, handle
+	})
+}
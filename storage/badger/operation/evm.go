@@ -0,0 +1,78 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/evm"
+)
+
+// BatchInsertEVMBlock writes the EVM block keyed by its height, and indexes its height by its hash.
+// If an entry already exists, it is overwritten.
+func BatchInsertEVMBlock(block *evm.Block) func(batch *badger.WriteBatch) error {
+	return func(batch *badger.WriteBatch) error {
+		err := batchWrite(makePrefix(codeEVMBlock, block.Height), block)(batch)
+		if err != nil {
+			return err
+		}
+		return batchWrite(makePrefix(codeEVMBlockByHash, block.Hash[:]), block.Height)(batch)
+	}
+}
+
+// RetrieveEVMBlock retrieves the EVM block with the given height.
+// Returns storage.ErrNotFound if the block is not indexed.
+func RetrieveEVMBlock(height uint64, block *evm.Block) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMBlock, height), block)
+}
+
+// LookupEVMBlockHeight retrieves the height of the EVM block with the given hash.
+// Returns storage.ErrNotFound if the block is not indexed.
+func LookupEVMBlockHeight(hash gethCommon.Hash, height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMBlockByHash, hash[:]), height)
+}
+
+// BatchInsertEVMTransaction writes the EVM transaction keyed by its hash.
+// If an entry already exists, it is overwritten.
+func BatchInsertEVMTransaction(tx *evm.Transaction) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEVMTransaction, tx.Hash[:]), tx)
+}
+
+// RetrieveEVMTransaction retrieves the EVM transaction with the given hash.
+// Returns storage.ErrNotFound if the transaction is not indexed.
+func RetrieveEVMTransaction(hash gethCommon.Hash, tx *evm.Transaction) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMTransaction, hash[:]), tx)
+}
+
+// BatchInsertEVMReceipt writes the EVM receipt keyed by the hash of its transaction.
+// If an entry already exists, it is overwritten.
+func BatchInsertEVMReceipt(receipt *evm.Receipt) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEVMReceipt, receipt.TransactionHash[:]), receipt)
+}
+
+// RetrieveEVMReceipt retrieves the receipt of the EVM transaction with the given hash.
+// Returns storage.ErrNotFound if the transaction is not indexed.
+func RetrieveEVMReceipt(txHash gethCommon.Hash, receipt *evm.Receipt) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMReceipt, txHash[:]), receipt)
+}
+
+// BatchInsertEVMFirstHeight writes the height of the first indexed EVM block.
+func BatchInsertEVMFirstHeight(height uint64) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEVMFirstHeight), height)
+}
+
+// RetrieveEVMFirstHeight retrieves the height of the first indexed EVM block.
+// Returns storage.ErrNotFound if no block is indexed.
+func RetrieveEVMFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMFirstHeight), height)
+}
+
+// BatchUpdateEVMLatestHeight writes the height of the latest indexed EVM block.
+func BatchUpdateEVMLatestHeight(height uint64) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEVMLatestHeight), height)
+}
+
+// RetrieveEVMLatestHeight retrieves the height of the latest indexed EVM block.
+// Returns storage.ErrNotFound if no block is indexed.
+func RetrieveEVMLatestHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMLatestHeight), height)
+}
@@ -32,6 +32,8 @@ const (
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
 	codeEpochFirstHeight        = 26 // the height of the first block in a given epoch
 	codeSealedRootHeight        = 27 // the height of the highest sealed block contained in the root snapshot
+	codeEVMFirstHeight          = 28 // the height of the first EVM block indexed from EVM events
+	codeEVMLatestHeight         = 29 // the height of the latest EVM block indexed from EVM events
 
 	// codes for single entity storage
 	codeHeader               = 30
@@ -78,6 +80,22 @@ const (
 	codeEpochProtocolState = 68
 	codeProtocolKVStore    = 69
 
+	// code for audit records of seals constructed by emergency sealing (consensus nodes only)
+	codeEmergencySeal = 73
+
+	// code for records of chunks verified by this node (verification nodes only)
+	codeChunkVerificationRecord = 74
+
+	// codes for EVM blocks, transactions and receipts indexed from EVM events (access and observer nodes only)
+	codeEVMBlock       = 75 // EVM block, keyed by EVM height
+	codeEVMBlockByHash = 76 // index mapping EVM block hash to EVM height
+	codeEVMTransaction = 77 // EVM transaction, keyed by EVM transaction hash
+	codeEVMReceipt     = 78 // EVM receipt, keyed by EVM transaction hash
+
+	// codes for resource reports of executed transactions (execution nodes, and access nodes indexing them)
+	codeTransactionResourceReport      = 79 // transaction resource report, keyed by block ID and transaction index
+	codeTransactionResourceReportIndex = 80 // index mapping block ID and transaction ID to transaction index
+
 	// code for ComputationResult upload status storage
 	// NOTE: for now only GCP uploader is supported. When other uploader (AWS e.g.) needs to
 	//		 be supported, we will need to define new code.
@@ -109,6 +127,9 @@ const (
 	// TEMPORARY codes
 	blockedNodeIDs = 205 // manual override for adding node IDs to list of ejected nodes, applies to networking layer only
 
+	// operator-managed disallow-list entries of the networking layer
+	codeDisallowListEntries = 206
+
 	// internal failure information that should be preserved across restarts
 	codeExecutionFork                   = 254
 	codeEpochEmergencyFallbackTriggered = 255
@@ -137,6 +158,8 @@ func b(v interface{}) []byte {
 		return b
 	case string:
 		return []byte(i)
+	case []byte:
+		return i
 	case flow.Role:
 		return []byte{byte(i)}
 	case flow.Identifier:
@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BatchInsertTransactionResourceReport inserts the resource report of the transaction with the given index in
+// the given block into a batch.
+func BatchInsertTransactionResourceReport(blockID flow.Identifier, txIndex uint32, report *flow.TransactionResourceReport) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeTransactionResourceReport, blockID, txIndex), report)
+}
+
+// BatchIndexTransactionResourceReport indexes the transaction index of the resource report of the given
+// transaction in the given block into a batch.
+func BatchIndexTransactionResourceReport(blockID flow.Identifier, txID flow.Identifier, txIndex uint32) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeTransactionResourceReportIndex, blockID, txID), txIndex)
+}
+
+// RetrieveTransactionResourceReport retrieves the resource report of the transaction with the given index in
+// the given block.
+// Returns storage.ErrNotFound if no report is stored for the transaction.
+func RetrieveTransactionResourceReport(blockID flow.Identifier, txIndex uint32, report *flow.TransactionResourceReport) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeTransactionResourceReport, blockID, txIndex), report)
+}
+
+// LookupTransactionResourceReportIndex retrieves the transaction index of the resource report of the given
+// transaction in the given block.
+// Returns storage.ErrNotFound if no report is stored for the transaction.
+func LookupTransactionResourceReportIndex(blockID flow.Identifier, txID flow.Identifier, txIndex *uint32) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeTransactionResourceReportIndex, blockID, txID), txIndex)
+}
+
+// LookupTransactionResourceReportsByBlockID retrieves the resource reports of all transactions of the given
+// block, ordered by transaction index.
+func LookupTransactionResourceReportsByBlockID(blockID flow.Identifier, reports *[]flow.TransactionResourceReport) func(*badger.Txn) error {
+	return traverse(makePrefix(codeTransactionResourceReport, blockID), func() (checkFunc, createFunc, handleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var report flow.TransactionResourceReport
+		create := func() interface{} {
+			return &report
+		}
+		handle := func() error {
+			*reports = append(*reports, report)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// BatchRemoveTransactionResourceReportsByBlockID removes the resource reports of all transactions of the given
+// block, and their index, in the provided batch.
+// No errors are expected during normal operation.
+func BatchRemoveTransactionResourceReportsByBlockID(blockID flow.Identifier, batch *badger.WriteBatch) func(*badger.Txn) error {
+	return func(txn *badger.Txn) error {
+		err := batchRemoveByPrefix(makePrefix(codeTransactionResourceReport, blockID))(txn, batch)
+		if err != nil {
+			return fmt.Errorf("could not remove transaction resource reports for block %v: %w", blockID, err)
+		}
+		err = batchRemoveByPrefix(makePrefix(codeTransactionResourceReportIndex, blockID))(txn, batch)
+		if err != nil {
+			return fmt.Errorf("could not remove transaction resource report index for block %v: %w", blockID, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// TransactionResourceReports implements persistent storage for the resource reports of executed transactions.
+type TransactionResourceReports struct {
+	db *badger.DB
+}
+
+var _ storage.TransactionResourceReports = (*TransactionResourceReports)(nil)
+
+func NewTransactionResourceReports(db *badger.DB) *TransactionResourceReports {
+	return &TransactionResourceReports{
+		db: db,
+	}
+}
+
+// BatchStore inserts the resource reports of all transactions of the given block, ordered by
+// transaction index, into a batch.
+// No errors are expected during normal operation.
+func (r *TransactionResourceReports) BatchStore(blockID flow.Identifier, reports []flow.TransactionResourceReport, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	for i := range reports {
+		txIndex := uint32(i)
+		err := operation.BatchInsertTransactionResourceReport(blockID, txIndex, &reports[i])(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert transaction resource report: %w", err)
+		}
+
+		err = operation.BatchIndexTransactionResourceReport(blockID, reports[i].TransactionID, txIndex)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch index transaction resource report: %w", err)
+		}
+	}
+	return nil
+}
+
+// Store stores the resource reports of all transactions of the given block, ordered by transaction index.
+// Storing the reports of a block again replaces all previously stored reports of the block, including the
+// reports of transactions which are not part of the new reports.
+// No errors are expected during normal operation.
+func (r *TransactionResourceReports) Store(blockID flow.Identifier, reports []flow.TransactionResourceReport) error {
+	batch := NewBatch(r.db)
+
+	// the removals are applied before the inserts of the same batch, so the new reports are kept
+	err := r.BatchRemoveByBlockID(blockID, batch)
+	if err != nil {
+		return fmt.Errorf("cannot batch remove previous transaction resource reports: %w", err)
+	}
+
+	err = r.BatchStore(blockID, reports, batch)
+	if err != nil {
+		return err
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot flush batch: %w", err)
+	}
+	return nil
+}
+
+// ByBlockIDTransactionID returns the resource report of the given transaction executed in the given block.
+// If the transaction was executed more than once in the block, the report of the last execution is returned.
+//
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no report is known for the transaction in the block.
+func (r *TransactionResourceReports) ByBlockIDTransactionID(blockID flow.Identifier, txID flow.Identifier) (*flow.TransactionResourceReport, error) {
+	var report flow.TransactionResourceReport
+	err := r.db.View(func(tx *badger.Txn) error {
+		var txIndex uint32
+		err := operation.LookupTransactionResourceReportIndex(blockID, txID, &txIndex)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up index of transaction resource report: %w", err)
+		}
+		return operation.RetrieveTransactionResourceReport(blockID, txIndex, &report)(tx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve resource report of transaction %v in block %v: %w", txID, blockID, err)
+	}
+	return &report, nil
+}
+
+// ByBlockID returns the resource reports of all transactions executed in the given block, ordered by
+// transaction index.
+//
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no reports are known for the block.
+func (r *TransactionResourceReports) ByBlockID(blockID flow.Identifier) ([]flow.TransactionResourceReport, error) {
+	reports := make([]flow.TransactionResourceReport, 0)
+	err := r.db.View(operation.LookupTransactionResourceReportsByBlockID(blockID, &reports))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve transaction resource reports of block %v: %w", blockID, err)
+	}
+	// every executed block contains at least the system transaction
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no transaction resource reports for block %v: %w", blockID, storage.ErrNotFound)
+	}
+	return reports, nil
+}
+
+// BatchRemoveByBlockID removes the resource reports of all transactions of the given block in a batch.
+// No errors are expected during normal operation.
+func (r *TransactionResourceReports) BatchRemoveByBlockID(blockID flow.Identifier, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+	return r.db.View(operation.BatchRemoveTransactionResourceReportsByBlockID(blockID, writeBatch))
+}
@@ -0,0 +1,93 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestTransactionResourceReportsStoreAndRetrieve verifies that the resource reports of a block can be retrieved
+// by block ID in order of transaction index, and by transaction ID, and that they can be removed.
+func TestTransactionResourceReportsStoreAndRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewTransactionResourceReports(db)
+
+		blockID := unittest.IdentifierFixture()
+		// more than 255 reports, so that ordering relies on the encoding of the transaction index
+		reports := unittest.TransactionResourceReportsFixture(300)
+
+		batch := badgerstorage.NewBatch(db)
+		require.NoError(t, store.BatchStore(blockID, reports, batch))
+		require.NoError(t, batch.Flush())
+
+		retrieved, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		require.Equal(t, reports, retrieved)
+
+		for _, i := range []int{0, 42, 299} {
+			report, err := store.ByBlockIDTransactionID(blockID, reports[i].TransactionID)
+			require.NoError(t, err)
+			require.Equal(t, reports[i], *report)
+		}
+
+		_, err = store.ByBlockID(unittest.IdentifierFixture())
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = store.ByBlockIDTransactionID(unittest.IdentifierFixture(), reports[0].TransactionID)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = store.ByBlockIDTransactionID(blockID, unittest.IdentifierFixture())
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		// the reports of other blocks are not removed
+		otherBlockID := unittest.IdentifierFixture()
+		otherReports := unittest.TransactionResourceReportsFixture(2)
+		require.NoError(t, store.Store(otherBlockID, otherReports))
+
+		batch = badgerstorage.NewBatch(db)
+		require.NoError(t, store.BatchRemoveByBlockID(blockID, batch))
+		require.NoError(t, batch.Flush())
+
+		_, err = store.ByBlockID(blockID)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = store.ByBlockIDTransactionID(blockID, reports[0].TransactionID)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		retrieved, err = store.ByBlockID(otherBlockID)
+		require.NoError(t, err)
+		require.Equal(t, otherReports, retrieved)
+	})
+}
+
+// TestTransactionResourceReportsStoreReplaces verifies that storing the reports of a block again replaces all
+// previously stored reports of the block.
+func TestTransactionResourceReportsStoreReplaces(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewTransactionResourceReports(db)
+
+		blockID := unittest.IdentifierFixture()
+		reports := unittest.TransactionResourceReportsFixture(3)
+		require.NoError(t, store.Store(blockID, reports))
+
+		// the new reports share the first transaction, and contain fewer transactions
+		newReports := unittest.TransactionResourceReportsFixture(2)
+		newReports[0].TransactionID = reports[0].TransactionID
+		require.NoError(t, store.Store(blockID, newReports))
+
+		retrieved, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		require.Equal(t, newReports, retrieved)
+
+		report, err := store.ByBlockIDTransactionID(blockID, reports[0].TransactionID)
+		require.NoError(t, err)
+		require.Equal(t, newReports[0], *report)
+
+		for _, i := range []int{1, 2} {
+			_, err = store.ByBlockIDTransactionID(blockID, reports[i].TransactionID)
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		}
+	})
+}
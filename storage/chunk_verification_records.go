@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/chunks"
+)
+
+// ChunkVerificationRecords represents persistent storage for the history of chunks verified by a
+// verification node.
+type ChunkVerificationRecords interface {
+
+	// Store persists the given chunk verification record.
+	// Error returns:
+	//   - storage.ErrAlreadyExists if a record for the same chunk has already been stored
+	Store(record *chunks.VerificationRecord) error
+
+	// ByHeightRange returns all chunk verification records for executed blocks with heights in the
+	// range [fromHeight, toHeight], ordered by ascending height. Returns an empty list if there
+	// are no records in the range.
+	// No errors are expected during normal operation.
+	ByHeightRange(fromHeight, toHeight uint64) ([]*chunks.VerificationRecord, error)
+
+	// PruneUpToHeight removes all chunk verification records for executed blocks with heights lower than
+	// the given height.
+	// No errors are expected during normal operation.
+	PruneUpToHeight(height uint64) error
+}
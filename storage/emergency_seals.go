@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EmergencySeals represents persistent storage for audit records of seals, which consensus
+// nodes constructed with fewer approvals than required (emergency sealing).
+type EmergencySeals interface {
+
+	// Store persists the given emergency seal record.
+	// Error returns:
+	//   - storage.ErrAlreadyExists if a record for the same result has already been stored
+	Store(seal *flow.EmergencySeal) error
+
+	// ByHeightRange returns all emergency seal records for executed blocks with heights in the
+	// range [fromHeight, toHeight], ordered by ascending height. Returns an empty list if there
+	// are no records in the range.
+	// No errors are expected during normal operation.
+	ByHeightRange(fromHeight, toHeight uint64) ([]*flow.EmergencySeal, error)
+}
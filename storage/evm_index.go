@@ -0,0 +1,47 @@
+package storage
+
+import (
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/evm"
+)
+
+// EVMIndex represents persistent storage for the EVM blocks, transactions and receipts indexed from the EVM events
+// emitted by Flow blocks.
+type EVMIndex interface {
+
+	// BatchStore stores the given EVM block with its transactions and receipts in the given batch, and sets the block
+	// as the latest indexed EVM block. Storing a block again overwrites it.
+	// No errors are expected during normal operation.
+	BatchStore(block *evm.Block, transactions []*evm.Transaction, receipts []*evm.Receipt, batch BatchStorage) error
+
+	// BlockByHeight returns the EVM block with the given height.
+	// Expected errors:
+	//   - storage.ErrNotFound if the block is not indexed
+	BlockByHeight(height uint64) (*evm.Block, error)
+
+	// BlockByHash returns the EVM block with the given hash.
+	// Expected errors:
+	//   - storage.ErrNotFound if the block is not indexed
+	BlockByHash(hash gethCommon.Hash) (*evm.Block, error)
+
+	// TransactionByHash returns the EVM transaction with the given hash.
+	// Expected errors:
+	//   - storage.ErrNotFound if the transaction is not indexed
+	TransactionByHash(hash gethCommon.Hash) (*evm.Transaction, error)
+
+	// ReceiptByTransactionHash returns the receipt of the EVM transaction with the given hash.
+	// Expected errors:
+	//   - storage.ErrNotFound if the transaction is not indexed
+	ReceiptByTransactionHash(hash gethCommon.Hash) (*evm.Receipt, error)
+
+	// FirstHeight returns the height of the first indexed EVM block.
+	// Expected errors:
+	//   - storage.ErrNotFound if no block is indexed yet
+	FirstHeight() (uint64, error)
+
+	// LatestHeight returns the height of the latest indexed EVM block.
+	// Expected errors:
+	//   - storage.ErrNotFound if no block is indexed yet
+	LatestHeight() (uint64, error)
+}
@@ -0,0 +1,35 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// TransactionResourceReports represents persistent storage for the resource reports of executed transactions.
+type TransactionResourceReports interface {
+
+	// BatchStore inserts the resource reports of all transactions of the given block, ordered by
+	// transaction index, into a batch.
+	// No errors are expected during normal operation.
+	BatchStore(blockID flow.Identifier, reports []flow.TransactionResourceReport, batch BatchStorage) error
+
+	// Store stores the resource reports of all transactions of the given block, ordered by transaction index.
+	// Storing the reports of a block again replaces all previously stored reports of the block, including the
+	// reports of transactions which are not part of the new reports.
+	// No errors are expected during normal operation.
+	Store(blockID flow.Identifier, reports []flow.TransactionResourceReport) error
+
+	// ByBlockIDTransactionID returns the resource report of the given transaction executed in the given block.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no report is known for the transaction in the block.
+	ByBlockIDTransactionID(blockID flow.Identifier, txID flow.Identifier) (*flow.TransactionResourceReport, error)
+
+	// ByBlockID returns the resource reports of all transactions executed in the given block, ordered by
+	// transaction index.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no reports are known for the block.
+	ByBlockID(blockID flow.Identifier) ([]flow.TransactionResourceReport, error)
+
+	// BatchRemoveByBlockID removes the resource reports of all transactions of the given block in a batch.
+	// No errors are expected during normal operation.
+	BatchRemoveByBlockID(blockID flow.Identifier, batch BatchStorage) error
+}
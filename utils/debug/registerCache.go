@@ -38,20 +38,25 @@ func (c *memRegisterCache) Persist() error {
 	return nil
 }
 
-type fileRegisterCache struct {
+// FileRegisterCache is a register cache backed by a file, so that registers fetched from an execution node
+// can be reused by later runs, without being fetched again.
+//
+// The file contains one JSON encoded register entry per line, with hex encoded register owner and key.
+type FileRegisterCache struct {
 	filePath string
 	data     map[string]flow.RegisterEntry
 }
 
-func newFileRegisterCache(filePath string) *fileRegisterCache {
-	cache := &fileRegisterCache{filePath: filePath}
+// NewFileRegisterCache creates a register cache backed by the given file, and loads the registers cached in
+// the file, if it exists.
+func NewFileRegisterCache(filePath string) (*FileRegisterCache, error) {
+	cache := &FileRegisterCache{filePath: filePath}
 	data := make(map[string]flow.RegisterEntry)
 
 	if _, err := os.Stat(filePath); err == nil {
 		f, err := os.Open(filePath)
 		if err != nil {
-			fmt.Printf("error opening file: %v\n", err)
-			os.Exit(1)
+			return nil, fmt.Errorf("error opening file: %w", err)
 		}
 		defer f.Close()
 		r := bufio.NewReader(f)
@@ -59,20 +64,20 @@ func newFileRegisterCache(filePath string) *fileRegisterCache {
 		for {
 			s, err = r.ReadString('\n')
 			if err != nil && err != io.EOF {
-				break
+				return nil, fmt.Errorf("error reading file: %w", err)
 			}
 			if len(s) > 0 {
 				var d flow.RegisterEntry
 				if err := json.Unmarshal([]byte(s), &d); err != nil {
-					panic(err)
+					return nil, fmt.Errorf("error decoding register entry: %w", err)
 				}
 				owner, err := hex.DecodeString(d.Key.Owner)
 				if err != nil {
-					panic(err)
+					return nil, fmt.Errorf("error decoding register owner: %w", err)
 				}
 				keyCopy, err := hex.DecodeString(d.Key.Key)
 				if err != nil {
-					panic(err)
+					return nil, fmt.Errorf("error decoding register key: %w", err)
 				}
 				data[string(owner)+"~"+string(keyCopy)] = d
 			}
@@ -83,10 +88,10 @@ func newFileRegisterCache(filePath string) *fileRegisterCache {
 	}
 
 	cache.data = data
-	return cache
+	return cache, nil
 }
 
-func (f *fileRegisterCache) Get(owner, key string) ([]byte, bool) {
+func (f *FileRegisterCache) Get(owner, key string) ([]byte, bool) {
 	v, found := f.data[owner+"~"+key]
 	if found {
 		return v.Value, found
@@ -94,18 +99,19 @@ func (f *fileRegisterCache) Get(owner, key string) ([]byte, bool) {
 	return nil, found
 }
 
-func (f *fileRegisterCache) Set(owner, key string, value []byte) {
+func (f *FileRegisterCache) Set(owner, key string, value []byte) {
 	valueCopy := make([]byte, len(value))
 	copy(valueCopy, value)
-	ownerAddr := flow.BytesToAddress([]byte(owner))
-	fmt.Println(ownerAddr.Hex(), hex.EncodeToString([]byte(key)), len(value))
 	f.data[owner+"~"+key] = flow.RegisterEntry{
-		Key:   flow.NewRegisterID(ownerAddr, hex.EncodeToString([]byte(key))),
+		Key: flow.RegisterID{
+			Owner: hex.EncodeToString([]byte(owner)),
+			Key:   hex.EncodeToString([]byte(key)),
+		},
 		Value: flow.RegisterValue(valueCopy),
 	}
 }
 
-func (c *fileRegisterCache) Persist() error {
+func (c *FileRegisterCache) Persist() error {
 	f, err := os.OpenFile(c.filePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
 	if err != nil {
 		return err
@@ -132,3 +138,23 @@ func (c *fileRegisterCache) Persist() error {
 	}
 	return nil
 }
+
+// CachedStorageSnapshot provides a storage snapshot which reads the registers only from a register cache,
+// so that transactions can be replayed offline against the registers cached by a previous run.
+type CachedStorageSnapshot struct {
+	cache registerCache
+}
+
+// NewCachedStorageSnapshot creates a storage snapshot reading the registers from the given cache.
+func NewCachedStorageSnapshot(cache registerCache) *CachedStorageSnapshot {
+	return &CachedStorageSnapshot{cache: cache}
+}
+
+// Get returns the cached value of the register, or an error if the register is not cached.
+func (snapshot *CachedStorageSnapshot) Get(id flow.RegisterID) (flow.RegisterValue, error) {
+	value, found := snapshot.cache.Get(id.Owner, id.Key)
+	if !found {
+		return nil, fmt.Errorf("register %s is not cached", id)
+	}
+	return value, nil
+}
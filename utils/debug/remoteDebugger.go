@@ -30,6 +30,7 @@ func NewRemoteDebugger(
 		fvm.WithLogger(logger),
 		fvm.WithChain(chain),
 		fvm.WithAuthorizationChecksEnabled(false),
+		fvm.WithCadenceLogging(true),
 	)
 
 	return &RemoteDebugger{
@@ -75,11 +76,15 @@ func (d *RemoteDebugger) RunTransactionAtBlockID(
 	snapshot := NewRemoteStorageSnapshot(d.grpcAddress, WithBlockID(blockID))
 	defer snapshot.Close()
 
+	var err error
 	blockCtx := fvm.NewContextFromParent(
 		d.ctx,
 		fvm.WithBlockHeader(d.ctx.BlockHeader))
 	if len(regCachePath) > 0 {
-		snapshot.Cache = newFileRegisterCache(regCachePath)
+		snapshot.Cache, err = NewFileRegisterCache(regCachePath)
+		if err != nil {
+			return nil, err
+		}
 	}
 	tx := fvm.Transaction(txBody, 0)
 	_, output, err := d.vm.Run(blockCtx, tx, snapshot)
@@ -0,0 +1,199 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/cadence/interpreter"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionReplay is the outcome of replaying a transaction against a pre-state.
+type TransactionReplay struct {
+	// PreState is the state the transaction was executed against.
+	PreState snapshot.StorageSnapshot
+	// ExecutionSnapshot contains the registers read and written by the transaction.
+	ExecutionSnapshot *snapshot.ExecutionSnapshot
+	// Output is the output of the transaction.
+	Output fvm.ProcedureOutput
+}
+
+// RegisterChange is the change of the value of a register by a transaction.
+type RegisterChange struct {
+	ID       flow.RegisterID
+	OldValue flow.RegisterValue
+	NewValue flow.RegisterValue
+}
+
+// ReplayTransaction runs the transaction against the given pre-state, which is usually a RemoteStorageSnapshot,
+// a CachedStorageSnapshot, or one of them with registers overridden by a snapshot tree.
+//
+// The pre-state is read again when computing the register changes, so the pre-state of a remote snapshot
+// should be persisted after the changes are computed, to be able to compute them offline.
+func (d *RemoteDebugger) ReplayTransaction(
+	txBody *flow.TransactionBody,
+	preState snapshot.StorageSnapshot,
+) (
+	*TransactionReplay,
+	error,
+) {
+	blockCtx := fvm.NewContextFromParent(
+		d.ctx,
+		fvm.WithBlockHeader(d.ctx.BlockHeader))
+	tx := fvm.Transaction(txBody, 0)
+	executionSnapshot, output, err := d.vm.Run(blockCtx, tx, preState)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionReplay{
+		PreState:          preState,
+		ExecutionSnapshot: executionSnapshot,
+		Output:            output,
+	}, nil
+}
+
+// RegisterChanges returns the registers updated by the transaction with their values before and after the
+// transaction, sorted by register ID. Registers written with their previous value are omitted.
+func (r *TransactionReplay) RegisterChanges() ([]RegisterChange, error) {
+	changes := make([]RegisterChange, 0, len(r.ExecutionSnapshot.WriteSet))
+	for id, newValue := range r.ExecutionSnapshot.WriteSet {
+		oldValue, err := r.PreState.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("could not read register %s: %w", id, err)
+		}
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, RegisterChange{
+			ID:       id,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ID.Owner != changes[j].ID.Owner {
+			return changes[i].ID.Owner < changes[j].ID.Owner
+		}
+		return changes[i].ID.Key < changes[j].ID.Key
+	})
+	return changes, nil
+}
+
+// ComputationIntensities returns the computation metered by the transaction per computation kind, sorted by kind.
+func (r *TransactionReplay) ComputationIntensities() []flow.ResourceIntensity {
+	return flow.NewResourceIntensities(r.Output.ComputationIntensities)
+}
+
+// DecodeEventPayload returns a human-readable representation of the payload of the given event, or the hex
+// encoded payload if it can not be decoded.
+func DecodeEventPayload(event flow.Event) string {
+	value, err := ccf.Decode(nil, event.Payload)
+	if err != nil {
+		return hex.EncodeToString(event.Payload)
+	}
+	return value.String()
+}
+
+// DecodeRegisterValue returns a human-readable representation of the value of the given register, for the
+// registers of which the encoding is known to flow-go: account registers, contract code, Cadence storage slabs,
+// and internal counters. The value is hex encoded if it can not be decoded.
+func DecodeRegisterValue(id flow.RegisterID, value flow.RegisterValue) string {
+	if len(value) == 0 {
+		return "<empty>"
+	}
+
+	decoded, err := decodeRegisterValue(id, value)
+	if err != nil || decoded == "" {
+		return hex.EncodeToString(value)
+	}
+	return decoded
+}
+
+func decodeRegisterValue(id flow.RegisterID, value flow.RegisterValue) (string, error) {
+	switch {
+	case id == flow.AddressStateRegisterID,
+		id.Owner == "" && strings.HasPrefix(id.Key, flow.UUIDKeyPrefix):
+		if len(value) != 8 {
+			return "", fmt.Errorf("invalid counter length %d", len(value))
+		}
+		return strconv.FormatUint(binary.BigEndian.Uint64(value), 10), nil
+
+	case id.Key == flow.AccountStatusKey:
+		status, err := environment.AccountStatusFromBytes(value)
+		if err != nil {
+			return "", err
+		}
+		slabIndex := status.SlabIndex()
+		return fmt.Sprintf(
+			"account status: storage used %d, storage index %d, public keys %d, account ID counter %d",
+			status.StorageUsed(),
+			binary.BigEndian.Uint64(slabIndex[:]),
+			status.PublicKeyCount(),
+			status.AccountIdCounter(),
+		), nil
+
+	case flow.IsContractKey(id.Key):
+		return string(value), nil
+
+	case flow.IsContractNamesRegisterID(id):
+		names, err := environment.DecodeContractNames(value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("contract names: %v", names), nil
+
+	case strings.HasPrefix(id.Key, flow.PublicKeyKeyPrefix):
+		index, err := strconv.ParseUint(strings.TrimPrefix(id.Key, flow.PublicKeyKeyPrefix), 10, 32)
+		if err != nil {
+			return "", err
+		}
+		key, err := flow.DecodeAccountPublicKey(value, uint32(index))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			"public key %d: %s, %s, %s, weight %d, sequence number %d, revoked %t",
+			key.Index,
+			key.PublicKey,
+			key.SignAlgo,
+			key.HashAlgo,
+			key.Weight,
+			key.SeqNumber,
+			key.Revoked,
+		), nil
+
+	case id.IsSlabIndex():
+		var index atree.SlabIndex
+		copy(index[:], id.Key[1:])
+		slabID := atree.NewSlabID(atree.Address(flow.BytesToAddress([]byte(id.Owner))), index)
+		slab, err := atree.DecodeSlab(
+			slabID,
+			value,
+			interpreter.CBORDecMode,
+			func(decoder *cbor.StreamDecoder, slabID atree.SlabID, inlinedExtraData []atree.ExtraData) (atree.Storable, error) {
+				return interpreter.DecodeStorable(decoder, slabID, inlinedExtraData, nil)
+			},
+			func(decoder *cbor.StreamDecoder) (atree.TypeInfo, error) {
+				return interpreter.DecodeTypeInfo(decoder, nil)
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprint(slab), nil
+	}
+
+	return "", nil
+}
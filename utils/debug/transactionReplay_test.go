@@ -0,0 +1,87 @@
+package debug
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestReplayTransaction verifies that a transaction is replayed against registers cached in a file, and that
+// the register changes and computation of the transaction are reported.
+func TestReplayTransaction(t *testing.T) {
+	chain := flow.Emulator.Chain()
+	debugger := NewRemoteDebugger("", chain, zerolog.Nop())
+
+	bootstrapSnapshot, output, err := debugger.vm.Run(
+		debugger.ctx,
+		fvm.Bootstrap(unittest.ServiceAccountPublicKey, fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply)),
+		snapshot.NewSnapshotTree(nil))
+	require.NoError(t, err)
+	require.NoError(t, output.Err)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		cachePath := filepath.Join(dir, "registers")
+
+		// cache the bootstrapped registers, and load them again
+		cache, err := NewFileRegisterCache(cachePath)
+		require.NoError(t, err)
+		for id, value := range bootstrapSnapshot.WriteSet {
+			cache.Set(id.Owner, id.Key, value)
+		}
+		require.NoError(t, cache.Persist())
+		cache, err = NewFileRegisterCache(cachePath)
+		require.NoError(t, err)
+
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+					prepare(signer: auth(Storage) &Account) {
+						signer.storage.save(42, to: /storage/answer)
+						log("saved")
+					}
+				}`)).
+			SetComputeLimit(9999).
+			SetPayer(chain.ServiceAddress()).
+			SetProposalKey(chain.ServiceAddress(), 0, 0).
+			AddAuthorizer(chain.ServiceAddress())
+
+		replay, err := debugger.ReplayTransaction(txBody, NewCachedStorageSnapshot(cache))
+		require.NoError(t, err)
+		require.NoError(t, replay.Output.Err)
+		assert.Equal(t, []string{`"saved"`}, replay.Output.Logs)
+		assert.NotEmpty(t, replay.ComputationIntensities())
+
+		changes, err := replay.RegisterChanges()
+		require.NoError(t, err)
+		require.NotEmpty(t, changes)
+
+		// the storage of the service account is updated
+		serviceOwner := flow.AddressToRegisterOwner(chain.ServiceAddress())
+		var accountStatus, storageSlab bool
+		for _, change := range changes {
+			if change.ID.Owner != serviceOwner {
+				continue
+			}
+			switch {
+			case change.ID.Key == flow.AccountStatusKey:
+				accountStatus = true
+				assert.True(t, strings.HasPrefix(DecodeRegisterValue(change.ID, change.NewValue), "account status:"))
+			case change.ID.IsSlabIndex():
+				storageSlab = true
+				assert.NotEqual(t, fmt.Sprintf("%x", change.NewValue), DecodeRegisterValue(change.ID, change.NewValue))
+			}
+		}
+		assert.True(t, accountStatus)
+		assert.True(t, storageSlab)
+	})
+}
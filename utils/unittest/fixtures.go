@@ -1673,6 +1673,12 @@ func WithChunkID(chunkID flow.Identifier) func(*verification.ChunkDataPackReques
 	}
 }
 
+func WithRetainUntil(retainUntil time.Time) func(*verification.ChunkDataPackRequest) {
+	return func(request *verification.ChunkDataPackRequest) {
+		request.RetainUntil = retainUntil
+	}
+}
+
 // ChunkDataPackRequestFixture creates a chunk data request with some default values, i.e., one agree execution node, one disagree execution node,
 // and height of zero.
 // Use options to customize the request.
@@ -2521,6 +2527,30 @@ func LightTransactionResultsFixture(n int) []flow.LightTransactionResult {
 	return results
 }
 
+func TransactionResourceReportsFixture(n int) []flow.TransactionResourceReport {
+	reports := make([]flow.TransactionResourceReport, 0, n)
+	for i := 0; i < n; i++ {
+		reports = append(reports, flow.TransactionResourceReport{
+			TransactionID:   IdentifierFixture(),
+			ComputationUsed: Uint64InRange(1, 10_000),
+			ComputationIntensities: []flow.ResourceIntensity{
+				{Kind: 1001, Intensity: Uint64InRange(1, 100)},
+				{Kind: 1002, Intensity: Uint64InRange(1, 100)},
+			},
+			MemoryEstimate: Uint64InRange(1, 1_000_000),
+			MemoryIntensities: []flow.ResourceIntensity{
+				{Kind: 1, Intensity: Uint64InRange(1, 1000)},
+			},
+			BytesRead:        Uint64InRange(1, 10_000),
+			BytesWritten:     Uint64InRange(1, 10_000),
+			RegistersTouched: Uint64InRange(1, 100),
+			RegistersUpdated: Uint64InRange(1, 10),
+			EventBytes:       Uint64InRange(1, 1000),
+		})
+	}
+	return reports
+}
+
 func AllowAllPeerFilter() func(peer.ID) error {
 	return func(_ peer.ID) error {
 		return nil